@@ -1,9 +1,11 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // Array provides methods to inspect attached []interface{} object
@@ -54,6 +56,22 @@ func (a *Array) Raw() []interface{} {
 	return a.value
 }
 
+// Decode is similar to Value.Decode.
+//
+// Example:
+//
+//	var ids []int
+//	array := NewArray(t, []interface{}{1, 2, 3})
+//	array.Decode(&ids)
+func (a *Array) Decode(target interface{}) *Array {
+	a.chain.enter("Decode()")
+	defer a.chain.leave()
+
+	jsonUnmarshal(a.chain, a.value, target)
+
+	return a
+}
+
 // Path is similar to Value.Path.
 func (a *Array) Path(path string) *Value {
 	a.chain.enter("Path(%q)", path)
@@ -218,6 +236,8 @@ func (a *Array) Iter() []Value {
 // Every runs the passed function on all the Elements in the array.
 //
 // If assertion inside function fails, the original Array is marked failed.
+// Failure report includes index and value of failed element, see
+// AssertionContext.Element.
 //
 // Every will execute the function for all values in the array irrespective
 // of assertion failures for some values in the array.
@@ -252,6 +272,7 @@ func (a *Array) Every(fn func(index int, value *Value)) *Array {
 	for index, val := range a.value {
 		valueChain := a.chain.clone()
 		valueChain.replace("Every[%d]", index)
+		valueChain.setElement(AssertionElement{Index: index, Value: val})
 
 		valueChain.setFailCallback(func() {
 			chainFailure = true
@@ -313,6 +334,7 @@ func (a *Array) Filter(fn func(index int, value *Value) bool) *Array {
 			chainFailed = true
 		})
 		valueChain.replace("Filter[%v]", index)
+		valueChain.setElement(AssertionElement{Index: index, Value: element})
 		if fn(index, newValue(valueChain, element)) && !chainFailed {
 			filteredArray = append(filteredArray, element)
 		}
@@ -321,6 +343,68 @@ func (a *Array) Filter(fn func(index int, value *Value) bool) *Array {
 	return newArray(a.chain, filteredArray)
 }
 
+// Find accepts a function that returns a boolean, runs it over the array
+// items, and returns a new Value instance for the first item for which
+// the function returned true.
+//
+// If there are any failed assertions in the function, the item is
+// considered as not matching, without causing test failure.
+//
+// If no item matches, Find reports failure and returns empty (but non-nil)
+// instance.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//	    map[string]interface{}{"id": 1, "name": "alice"},
+//	    map[string]interface{}{"id": 2, "name": "bob"},
+//	})
+//	array.Find(func(index int, value *httpexpect.Value) bool {
+//	    return value.Object().Value("name").Raw() == "bob"
+//	}).Object().ValueEqual("id", 2)
+func (a *Array) Find(fn func(index int, value *Value) bool) *Value {
+	a.chain.enter("Find()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return newValue(a.chain, nil)
+	}
+
+	if fn == nil {
+		a.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return newValue(a.chain, nil)
+	}
+
+	for index, element := range a.value {
+		valueChain := a.chain.clone()
+		valueChain.setSeverity(SeverityLog)
+		chainFailed := false
+		valueChain.setFailCallback(func() {
+			chainFailed = true
+		})
+		valueChain.replace("Find[%v]", index)
+		valueChain.setElement(AssertionElement{Index: index, Value: element})
+		if fn(index, newValue(valueChain, element)) && !chainFailed {
+			return newValue(a.chain, element)
+		}
+	}
+
+	a.chain.fail(AssertionFailure{
+		Type:   AssertContainsElement,
+		Actual: &AssertionValue{a.value},
+		Errors: []error{
+			errors.New("expected: array contains element matching predicate"),
+		},
+	})
+
+	return newValue(a.chain, nil)
+}
+
 // Transform runs the passed function on all the Elements in the array
 // and returns a new array without effeecting original array.
 //
@@ -512,74 +596,7 @@ func (a *Array) EqualUnordered(value interface{}) *Array {
 		return a
 	}
 
-	for _, element := range expected {
-		expectedCount := countElement(expected, element)
-		actualCount := countElement(a.value, element)
-
-		if actualCount != expectedCount {
-			if expectedCount == 1 && actualCount == 0 {
-				a.chain.fail(AssertionFailure{
-					Type:      AssertContainsElement,
-					Actual:    &AssertionValue{a.value},
-					Expected:  &AssertionValue{element},
-					Reference: &AssertionValue{value},
-					Errors: []error{
-						errors.New("expected: array contains element from reference array"),
-					},
-				})
-			} else {
-				a.chain.fail(AssertionFailure{
-					Type:      AssertNotContainsElement,
-					Actual:    &AssertionValue{a.value},
-					Expected:  &AssertionValue{element},
-					Reference: &AssertionValue{value},
-					Errors: []error{
-						fmt.Errorf(
-							"expected: element occurs %d time(s), as in reference array,"+
-								" but it occurs %d time(s)",
-							expectedCount,
-							actualCount),
-					},
-				})
-			}
-			return a
-		}
-	}
-
-	for _, element := range a.value {
-		expectedCount := countElement(expected, element)
-		actualCount := countElement(a.value, element)
-
-		if actualCount != expectedCount {
-			if expectedCount == 0 && actualCount == 1 {
-				a.chain.fail(AssertionFailure{
-					Type:      AssertNotContainsElement,
-					Actual:    &AssertionValue{a.value},
-					Expected:  &AssertionValue{element},
-					Reference: &AssertionValue{value},
-					Errors: []error{
-						errors.New("expected: array does not contain elements" +
-							" that are not present in reference array"),
-					},
-				})
-			} else {
-				a.chain.fail(AssertionFailure{
-					Type:      AssertNotContainsElement,
-					Actual:    &AssertionValue{a.value},
-					Expected:  &AssertionValue{element},
-					Reference: &AssertionValue{value},
-					Errors: []error{
-						fmt.Errorf(
-							"expected: element occurs %d time(s), as in reference array,"+
-								" but it occurs %d time(s)",
-							expectedCount,
-							actualCount),
-					},
-				})
-			}
-			return a
-		}
-	}
+	a.checkUnordered(expected, value)
 
 	return a
 }
@@ -1001,6 +1018,345 @@ func (a *Array) NotContainsAny(values ...interface{}) *Array {
 	return a
 }
 
+// ContainsExactlyInAnyOrder succeeds if array contains all given elements,
+// in any order, and only them. Unlike ContainsOnly, number of occurrences
+// of every element should match too. Before comparison, array and all
+// elements are converted to canonical form.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"foo", 123, 123})
+//	array.ContainsExactlyInAnyOrder(123, "foo", 123) // success
+//	array.ContainsExactlyInAnyOrder(123, "foo")      // failure
+//
+// These calls are equivalent:
+//
+//	array.ContainsExactlyInAnyOrder("a", "b")
+//	array.EqualUnordered([]interface{}{"a", "b"})
+func (a *Array) ContainsExactlyInAnyOrder(values ...interface{}) *Array {
+	a.chain.enter("ContainsExactlyInAnyOrder()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return a
+	}
+
+	expected, ok := canonArray(a.chain, values)
+	if !ok {
+		return a
+	}
+
+	a.checkUnordered(expected, values)
+
+	return a
+}
+
+// IntersectsWith succeeds if array has at least one element in common
+// with given array. Before comparison, both arrays are converted to
+// canonical form.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"foo", "bar"})
+//	array.IntersectsWith([]string{"bar", "baz"}) // success
+//	array.IntersectsWith([]string{"baz"})        // failure
+func (a *Array) IntersectsWith(value interface{}) *Array {
+	a.chain.enter("IntersectsWith()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return a
+	}
+
+	elements, ok := canonArray(a.chain, value)
+	if !ok {
+		return a
+	}
+
+	for _, element := range elements {
+		if countElement(a.value, element) > 0 {
+			return a
+		}
+	}
+
+	a.chain.fail(AssertionFailure{
+		Type:      AssertContainsElement,
+		Actual:    &AssertionValue{a.value},
+		Reference: &AssertionValue{value},
+		Errors: []error{
+			errors.New("expected: array intersects with reference array"),
+		},
+	})
+
+	return a
+}
+
+// IsSortedBy succeeds if array elements are sorted by given field,
+// in ascending order if asc is true, and in descending order otherwise.
+// Equal adjacent values are allowed.
+//
+// Elements should be objects containing given field. If field is empty,
+// elements are compared themselves. Compared values should be either
+// all numbers or all strings.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//	    map[string]interface{}{"name": "alice", "age": 30},
+//	    map[string]interface{}{"name": "bob", "age": 20},
+//	})
+//	array.IsSortedBy("name", true)  // success
+//	array.IsSortedBy("age", false)  // success
+//	array.IsSortedBy("age", true)   // failure
+func (a *Array) IsSortedBy(field string, asc bool) *Array {
+	a.chain.enter("IsSortedBy(%q)", field)
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return a
+	}
+
+	keys, ok := a.fieldValues(field)
+	if !ok {
+		return a
+	}
+
+	order := "ascending"
+	if !asc {
+		order = "descending"
+	}
+
+	for i := 1; i < len(keys); i++ {
+		cmp, ok := compareElements(keys[i-1], keys[i])
+		if !ok {
+			a.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{a.value},
+				Errors: []error{
+					errors.New("expected: array elements are comparable"),
+					fmt.Errorf("can't compare values %v and %v at indexes %d and %d",
+						keys[i-1], keys[i], i-1, i),
+				},
+			})
+			return a
+		}
+
+		if (asc && cmp > 0) || (!asc && cmp < 0) {
+			a.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{a.value},
+				Errors: []error{
+					fmt.Errorf("expected: array is sorted by %q in %s order",
+						field, order),
+					fmt.Errorf("element at index %d (%v) is out of order"+
+						" after element at index %d (%v)",
+						i, keys[i], i-1, keys[i-1]),
+				},
+			})
+			return a
+		}
+	}
+
+	return a
+}
+
+// HasUniqueValues succeeds if values of given field are unique across
+// array elements. Before comparison, values are converted to canonical form.
+//
+// Elements should be objects containing given field. If field is empty,
+// elements are compared themselves.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{
+//	    map[string]interface{}{"id": 1, "name": "alice"},
+//	    map[string]interface{}{"id": 2, "name": "alice"},
+//	})
+//	array.HasUniqueValues("id")   // success
+//	array.HasUniqueValues("name") // failure
+func (a *Array) HasUniqueValues(field string) *Array {
+	a.chain.enter("HasUniqueValues(%q)", field)
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return a
+	}
+
+	keys, ok := a.fieldValues(field)
+	if !ok {
+		return a
+	}
+
+	for i := range keys {
+		for j := 0; j < i; j++ {
+			if reflect.DeepEqual(keys[i], keys[j]) {
+				a.chain.fail(AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{a.value},
+					Errors: []error{
+						fmt.Errorf("expected: array has unique values of field %q",
+							field),
+						fmt.Errorf("duplicate value %v at indexes %d and %d",
+							keys[i], j, i),
+					},
+				})
+				return a
+			}
+		}
+	}
+
+	return a
+}
+
+// Return values of given field for every element, or elements themselves
+// if field is empty.
+func (a *Array) fieldValues(field string) ([]interface{}, bool) {
+	if field == "" {
+		return a.value, true
+	}
+
+	values := make([]interface{}, 0, len(a.value))
+
+	for i, element := range a.value {
+		obj, ok := element.(map[string]interface{})
+		if !ok {
+			a.chain.fail(AssertionFailure{
+				Type:   AssertType,
+				Actual: &AssertionValue{element},
+				Errors: []error{
+					fmt.Errorf("expected: array element at index %d is an object", i),
+				},
+			})
+			return nil, false
+		}
+
+		value, ok := obj[field]
+		if !ok {
+			a.chain.fail(AssertionFailure{
+				Type:     AssertContainsKey,
+				Actual:   &AssertionValue{element},
+				Expected: &AssertionValue{field},
+				Errors: []error{
+					fmt.Errorf("expected: array element at index %d contains key", i),
+				},
+			})
+			return nil, false
+		}
+
+		values = append(values, value)
+	}
+
+	return values, true
+}
+
+// Compare two numbers or two strings.
+func compareElements(a, b interface{}) (int, bool) {
+	toFloat := func(v interface{}) (float64, bool) {
+		switch n := v.(type) {
+		case float64:
+			return n, true
+		case json.Number:
+			f, err := n.Float64()
+			return f, err == nil
+		}
+		return 0, false
+	}
+
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			switch {
+			case fa < fb:
+				return -1, true
+			case fa > fb:
+				return 1, true
+			}
+			return 0, true
+		}
+		return 0, false
+	}
+
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			return strings.Compare(sa, sb), true
+		}
+	}
+
+	return 0, false
+}
+
+// Report failure if array doesn't contain exactly given elements,
+// ignoring order.
+func (a *Array) checkUnordered(expected []interface{}, value interface{}) {
+	for _, element := range expected {
+		expectedCount := countElement(expected, element)
+		actualCount := countElement(a.value, element)
+
+		if actualCount != expectedCount {
+			if expectedCount == 1 && actualCount == 0 {
+				a.chain.fail(AssertionFailure{
+					Type:      AssertContainsElement,
+					Actual:    &AssertionValue{a.value},
+					Expected:  &AssertionValue{element},
+					Reference: &AssertionValue{value},
+					Errors: []error{
+						errors.New("expected: array contains element from reference array"),
+					},
+				})
+			} else {
+				a.chain.fail(AssertionFailure{
+					Type:      AssertNotContainsElement,
+					Actual:    &AssertionValue{a.value},
+					Expected:  &AssertionValue{element},
+					Reference: &AssertionValue{value},
+					Errors: []error{
+						fmt.Errorf(
+							"expected: element occurs %d time(s), as in reference array,"+
+								" but it occurs %d time(s)",
+							expectedCount,
+							actualCount),
+					},
+				})
+			}
+			return
+		}
+	}
+
+	for _, element := range a.value {
+		expectedCount := countElement(expected, element)
+		actualCount := countElement(a.value, element)
+
+		if actualCount != expectedCount {
+			if expectedCount == 0 && actualCount == 1 {
+				a.chain.fail(AssertionFailure{
+					Type:      AssertNotContainsElement,
+					Actual:    &AssertionValue{a.value},
+					Expected:  &AssertionValue{element},
+					Reference: &AssertionValue{value},
+					Errors: []error{
+						errors.New("expected: array does not contain elements" +
+							" that are not present in reference array"),
+					},
+				})
+			} else {
+				a.chain.fail(AssertionFailure{
+					Type:      AssertNotContainsElement,
+					Actual:    &AssertionValue{a.value},
+					Expected:  &AssertionValue{element},
+					Reference: &AssertionValue{value},
+					Errors: []error{
+						fmt.Errorf(
+							"expected: element occurs %d time(s), as in reference array,"+
+								" but it occurs %d time(s)",
+							expectedCount,
+							actualCount),
+					},
+				})
+			}
+			return
+		}
+	}
+}
+
 func countElement(array []interface{}, element interface{}) int {
 	count := 0
 	for _, e := range array {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestArrayFailed(t *testing.T) {
@@ -12,6 +13,7 @@ func TestArrayFailed(t *testing.T) {
 
 		value.Path("$")
 		value.Schema("")
+		value.Match(nil)
 
 		assert.NotNil(t, value.Length())
 		assert.NotNil(t, value.Element(0))
@@ -37,6 +39,10 @@ func TestArrayFailed(t *testing.T) {
 		value.NotContainsOnly("foo")
 		value.ContainsAny("foo")
 		value.NotContainsAny("foo")
+		value.ContainsExactlyInAnyOrder("foo")
+		value.IntersectsWith([]interface{}{"foo"})
+		value.IsSortedBy("", true)
+		value.HasUniqueValues("")
 		value.Every(func(_ int, val *Value) {
 			val.String().NotEmpty()
 		})
@@ -47,6 +53,13 @@ func TestArrayFailed(t *testing.T) {
 		value.Transform(func(index int, value interface{}) interface{} {
 			return nil
 		})
+
+		assert.NotNil(t, value.Find(func(_ int, val *Value) bool {
+			return true
+		}))
+		value.Find(func(_ int, val *Value) bool {
+			return true
+		}).chain.assertFailed(t)
 	}
 
 	t.Run("failed_chain", func(t *testing.T) {
@@ -390,6 +403,21 @@ func TestArrayEqualUnordered(t *testing.T) {
 	})
 }
 
+func TestArrayDecode(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{1, 2, 3})
+
+	var ids []int
+	value.Decode(&ids)
+	value.chain.assertNotFailed(t)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	var names []string
+	value.Decode(&names)
+	value.chain.assertFailed(t)
+}
+
 func TestArrayElements(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -630,6 +658,161 @@ func TestArrayContainsAny(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestArrayContainsExactlyInAnyOrder(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{123, "foo", 123})
+
+	value.ContainsExactlyInAnyOrder(123, "foo", 123)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.ContainsExactlyInAnyOrder("foo", 123, 123)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.ContainsExactlyInAnyOrder(123, "foo")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.ContainsExactlyInAnyOrder(123, "foo", 123, 123)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.ContainsExactlyInAnyOrder(123, "foo", 123, "bar")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.ContainsExactlyInAnyOrder()
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.ContainsExactlyInAnyOrder(func() {})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestArrayIntersectsWith(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{123, "foo"})
+
+	value.IntersectsWith([]interface{}{"bar", 123})
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.IntersectsWith([]string{"foo"})
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.IntersectsWith([]string{"bar"})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IntersectsWith([]interface{}{})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IntersectsWith("foo")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestArrayIsSortedBy(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("field", func(t *testing.T) {
+		value := NewArray(reporter, []interface{}{
+			map[string]interface{}{"name": "alice", "age": 30, "score": 1},
+			map[string]interface{}{"name": "bob", "age": 20, "score": 1},
+			map[string]interface{}{"name": "carol", "age": 10, "score": 2},
+		})
+
+		value.IsSortedBy("name", true)
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.IsSortedBy("name", false)
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.IsSortedBy("age", false)
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.IsSortedBy("age", true)
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.IsSortedBy("score", true)
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.IsSortedBy("missing", true)
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+	})
+
+	t.Run("elements", func(t *testing.T) {
+		NewArray(reporter, []interface{}{1, 2, 10}).
+			IsSortedBy("", true).chain.assertNotFailed(t)
+
+		NewArray(reporter, []interface{}{"1", "10", "2"}).
+			IsSortedBy("", true).chain.assertNotFailed(t)
+
+		NewArray(reporter, []interface{}{}).
+			IsSortedBy("", true).chain.assertNotFailed(t)
+
+		NewArray(reporter, []interface{}{2, 1}).
+			IsSortedBy("", true).chain.assertFailed(t)
+	})
+
+	t.Run("not comparable", func(t *testing.T) {
+		NewArray(reporter, []interface{}{1, "2"}).
+			IsSortedBy("", true).chain.assertFailed(t)
+
+		NewArray(reporter, []interface{}{true, false}).
+			IsSortedBy("", true).chain.assertFailed(t)
+
+		NewArray(reporter, []interface{}{1, 2}).
+			IsSortedBy("id", true).chain.assertFailed(t)
+	})
+}
+
+func TestArrayHasUniqueValues(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1, "name": "alice"},
+		map[string]interface{}{"id": 2, "name": "alice"},
+		map[string]interface{}{"id": 3, "name": "bob"},
+	})
+
+	value.HasUniqueValues("id")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.HasUniqueValues("name")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.HasUniqueValues("missing")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.HasUniqueValues("")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	NewArray(reporter, []interface{}{"a", "b", "a"}).
+		HasUniqueValues("").chain.assertFailed(t)
+
+	NewArray(reporter, []interface{}{
+		map[string]interface{}{"tags": []interface{}{"a"}},
+		map[string]interface{}{"tags": []interface{}{"a"}},
+	}).HasUniqueValues("tags").chain.assertFailed(t)
+}
+
 func TestArrayConvertEqual(t *testing.T) {
 	type (
 		myArray []interface{}
@@ -759,6 +942,19 @@ func TestArrayConvertContains(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+type elementContextHandler struct {
+	failures []*AssertionContext
+}
+
+func (h *elementContextHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *elementContextHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.failures = append(h.failures, ctx)
+}
+
 func TestArrayEvery(t *testing.T) {
 	t.Run("Check validation", func(ts *testing.T) {
 		reporter := newMockReporter(ts)
@@ -814,6 +1010,89 @@ func TestArrayEvery(t *testing.T) {
 		assert.Equal(t, 3, invoked)
 		array.chain.assertFailed(ts)
 	})
+
+	t.Run("Failed element in context", func(ts *testing.T) {
+		handler := &elementContextHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		array := newArray(chain, []interface{}{"foo", "", "bar"})
+		array.Every(func(_ int, val *Value) {
+			val.String().NotEmpty()
+		})
+		array.chain.assertFailed(ts)
+
+		require.Equal(ts, 1, len(handler.failures))
+		require.NotNil(ts, handler.failures[0].Element)
+		assert.Equal(ts, 1, handler.failures[0].Element.Index)
+		assert.Equal(ts, "", handler.failures[0].Element.Value)
+		assert.Contains(ts, handler.failures[0].Path, "Every[1]")
+	})
+}
+
+func TestArrayFind(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	array := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1, "name": "alice"},
+		map[string]interface{}{"id": 2, "name": "bob"},
+		"foo",
+		map[string]interface{}{"id": 3, "name": "bob"},
+	})
+
+	t.Run("found", func(t *testing.T) {
+		var indexes []int
+
+		value := array.Find(func(index int, value *Value) bool {
+			indexes = append(indexes, index)
+			return value.Object().Value("name").String().Raw() == "bob"
+		})
+
+		value.chain.assertNotFailed(t)
+		array.chain.assertNotFailed(t)
+
+		assert.Equal(t, []int{0, 1}, indexes)
+		value.Object().ValueEqual("id", 2)
+	})
+
+	t.Run("failed assertions ignored", func(t *testing.T) {
+		value := array.Find(func(index int, value *Value) bool {
+			return value.String().NotEmpty().Raw() == "foo"
+		})
+
+		value.chain.assertNotFailed(t)
+		array.chain.assertNotFailed(t)
+
+		assert.Equal(t, "foo", value.Raw())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		array := NewArray(reporter, []interface{}{1, 2})
+
+		value := array.Find(func(index int, value *Value) bool {
+			return value.Number().Raw() > 2
+		})
+
+		value.chain.assertFailed(t)
+		array.chain.assertFailed(t)
+		assert.Nil(t, value.Raw())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		array := NewArray(reporter, []interface{}{})
+
+		array.Find(func(index int, value *Value) bool {
+			return true
+		}).chain.assertFailed(t)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		array := NewArray(reporter, []interface{}{1})
+
+		array.Find(nil).chain.assertFailed(t)
+		array.chain.assertFailed(t)
+	})
 }
 
 func TestArrayTransform(t *testing.T) {
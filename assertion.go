@@ -1,5 +1,9 @@
 package httpexpect
 
+import (
+	"time"
+)
+
 // AssertionType defines type of performed assertion.
 type AssertionType uint
 
@@ -100,6 +104,12 @@ const (
 	// This severity is used for assertions issued inside predicate functions,
 	// e.g. in Array.Filter and Object.Filter.
 	SeverityLog
+
+	// This assertion failure should be visible, but should not cause test
+	// failure.
+	// Typically handler will call t.Logf() and mark message as warning.
+	// This severity is used for assertions issued after Response.Warn().
+	SeverityWarning
 )
 
 // AssertionContext provides context where the assetion happened.
@@ -112,11 +122,33 @@ type AssertionContext struct {
 	// Comes from Request.WithName()
 	RequestName string
 
+	// Ticket or reason of known failure
+	// Comes from Request.ExpectedFailure()
+	// If non-empty, failures are expected and reported with SeverityLog
+	ExpectedFailure string
+
+	// Table case being run
+	// Comes from Expect.Table()
+	// May be nil if assertion is not part of a table
+	Case *Case
+
+	// Array element or object value being checked
+	// Comes from Every(), Filter(), and Find() methods of Array and Object
+	// May be nil if assertion is not made on element
+	Element *AssertionElement
+
 	// Chain of nested assertion names
 	// Example value:
 	//   {`Request("GET")`, `Expect()`, `JSON()`, `NotNull()`}
 	Path []string
 
+	// Source locations from which elements of Path were called,
+	// in form "file.go:line"
+	// Has the same length as Path, with empty strings for unknown locations
+	// Filled only when failure is reported
+	// Only the last location is known, unless Config.TrackLocations is set
+	PathLocations []string
+
 	// Request being sent
 	// May be nil if request was not yet sent
 	Request *Request
@@ -128,11 +160,53 @@ type AssertionContext struct {
 	// Environment shared between tests
 	// Comes from Expect instance
 	Environment *Environment
+
+	// Time elapsed since the last assertion in Path was entered until
+	// it was reported
+	// Useful to find slow assertions, see SlowAssertionHandler
+	Duration time.Duration
+
+	// Durations of request phases
+	// May be nil if request was not yet sent
+	RequestPhases *RequestPhases
+}
+
+// AssertionElement identifies array element or object value being checked.
+type AssertionElement struct {
+	// Index of element in array
+	// Set to -1 for object values
+	Index int
+
+	// Key of value in object
+	// Empty for array elements
+	Key string
+
+	// Element value
+	Value interface{}
+}
+
+// RequestPhases provides durations of phases of sending request and
+// receiving response, measured by Request.Expect.
+//
+// Phases that didn't happen (e.g. because of failure) are zero.
+type RequestPhases struct {
+	// Encoding request, including attached transformers
+	Encode time.Duration
+
+	// Sending request and receiving response headers, including retries,
+	// redirects, and rate limiting delays
+	Send time.Duration
+
+	// Reading response body
+	Receive time.Duration
+
+	// Running matchers attached to request
+	Match time.Duration
 }
 
 // AssertionFailure provides detailed information about failed assertion.
 //
-// [Type] and [Errors] fields are set for all assertions.
+// [Type], [Code], and [Errors] fields are set for all assertions.
 // [Actual], [Expected], [Reference], and [Delta] fields are set only for
 // certain assertion types.
 //
@@ -162,6 +236,10 @@ type AssertionFailure struct {
 	// Type of failed assertion
 	Type AssertionType
 
+	// Stable machine-readable code of failure, e.g. "status.mismatch"
+	// If not set explicitly, filled from [Type] and assertion path
+	Code FailureCode
+
 	// Severity of failure
 	Severity AssertionSeverity
 
@@ -214,6 +292,7 @@ type AssertionHandler interface {
 	// Handling depends on Failure.Severity field:
 	//  - for SeverityError, reports failure to testing suite, e.g. using t.Errorf()
 	//  - for SeverityLog, ignores failure, or logs it, e.g. using t.Logf()
+	//  - for SeverityWarning, logs failure, e.g. using t.Logf()
 	Failure(*AssertionContext, *AssertionFailure)
 }
 
@@ -223,6 +302,10 @@ type AssertionHandler interface {
 // - Reporter is used to report formatted fatal failure messages
 // - Logger is used to print formatted success and non-fatal failure messages
 //
+// Warnings (failures with SeverityWarning) are printed using Logger, or,
+// if Logger is nil, using Reporter if it implements Logger too (e.g.
+// testing.T does), so that they are visible by default.
+//
 // Formatter and Reporter are required. Logger is optional.
 // By default httpexpect creates DefaultAssertionHandler without Logger.
 type DefaultAssertionHandler struct {
@@ -272,5 +355,18 @@ func (h *DefaultAssertionHandler) Failure(
 		msg := h.Formatter.FormatFailure(ctx, failure)
 
 		h.Logger.Logf("%s", msg)
+
+	case SeverityWarning:
+		logger := h.Logger
+		if logger == nil {
+			logger, _ = h.Reporter.(Logger)
+		}
+		if logger == nil {
+			return
+		}
+
+		msg := h.Formatter.FormatFailure(ctx, failure)
+
+		logger.Logf("%s", msg)
 	}
 }
@@ -0,0 +1,395 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AllureAssertionHandler is an AssertionHandler that produces Allure
+// test results (https://allurereport.org).
+//
+// Every test (AssertionContext.TestName) becomes an Allure test result,
+// and every request sent by the test becomes a step of that result.
+// Each step has three attachments: request body, response body, and
+// curl command reproducing the request. Failed assertions are added
+// as nested steps of the request step that they belong to.
+//
+// Redaction rules are applied to attachments, step names, and failure
+// messages, so that sensitive data doesn't leak into Allure results.
+//
+// Results are accumulated in memory and written to OutputDir when
+// Flush is called. Then you can use `allure generate` or `allure serve`
+// to build a report.
+//
+// All assertions are also forwarded to Handler, if it's set.
+//
+// Example:
+//
+//	func TestSomething(t *testing.T) {
+//	    allure := &httpexpect.AllureAssertionHandler{
+//	        Handler: &httpexpect.DefaultAssertionHandler{
+//	            Formatter: &httpexpect.DefaultFormatter{},
+//	            Reporter:  httpexpect.NewAssertReporter(t),
+//	        },
+//	        OutputDir: "allure-results",
+//	    }
+//	    defer allure.Flush()
+//
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        TestName:         t.Name(),
+//	        AssertionHandler: allure,
+//	    })
+//	}
+type AllureAssertionHandler struct {
+	// Handler receives all assertions after they're recorded.
+	// May be nil.
+	Handler AssertionHandler
+
+	// Directory where results are written.
+	// If empty, "allure-results" is used.
+	OutputDir string
+
+	// Rules for hiding sensitive data.
+	// May be nil. If nil, Config.RedactionRules of the request are used.
+	RedactionRules *RedactionRules
+
+	mu      sync.Mutex
+	results []*allureResult
+}
+
+type allureResult struct {
+	UUID          string               `json:"uuid"`
+	Name          string               `json:"name"`
+	FullName      string               `json:"fullName"`
+	Status        string               `json:"status"`
+	Stage         string               `json:"stage"`
+	StatusDetails *allureStatusDetails `json:"statusDetails,omitempty"`
+	Start         int64                `json:"start"`
+	Stop          int64                `json:"stop"`
+	Steps         []*allureStep        `json:"steps"`
+	Labels        []allureLabel        `json:"labels"`
+
+	stepMap map[*Request]*allureStep
+	files   []allureFile
+}
+
+type allureStep struct {
+	Name          string               `json:"name"`
+	Status        string               `json:"status"`
+	Stage         string               `json:"stage"`
+	StatusDetails *allureStatusDetails `json:"statusDetails,omitempty"`
+	Start         int64                `json:"start"`
+	Stop          int64                `json:"stop"`
+	Attachments   []allureAttachment   `json:"attachments,omitempty"`
+	Steps         []*allureStep        `json:"steps,omitempty"`
+
+	attached bool
+}
+
+type allureStatusDetails struct {
+	Message string `json:"message,omitempty"`
+	Trace   string `json:"trace,omitempty"`
+}
+
+type allureAttachment struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+type allureLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type allureFile struct {
+	name string
+	data []byte
+}
+
+const (
+	allureStatusPassed = "passed"
+	allureStatusFailed = "failed"
+	allureStageDone    = "finished"
+)
+
+// Success implements AssertionHandler.Success.
+func (h *AllureAssertionHandler) Success(ctx *AssertionContext) {
+	h.record(ctx, nil)
+
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *AllureAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.record(ctx, failure)
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
+
+// Flush writes all accumulated results to OutputDir and resets
+// handler state.
+func (h *AllureAssertionHandler) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	dir := h.OutputDir
+	if dir == "" {
+		dir = "allure-results"
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, res := range h.results {
+		for _, f := range res.files {
+			if err := ioutil.WriteFile(
+				filepath.Join(dir, f.name), f.data, 0644); err != nil {
+				return err
+			}
+		}
+
+		b, err := json.MarshalIndent(res, "", defaultIndent)
+		if err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(
+			filepath.Join(dir, res.UUID+"-result.json"), b, 0644); err != nil {
+			return err
+		}
+	}
+
+	h.results = nil
+
+	return nil
+}
+
+func (h *AllureAssertionHandler) record(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := allureTime(time.Now())
+
+	res := h.getResult(ctx.TestName, now)
+	res.Stop = now
+
+	rules := contextRedactionRules(h.RedactionRules, ctx)
+
+	var step *allureStep
+
+	if ctx.Request != nil {
+		step = res.stepMap[ctx.Request]
+		if step == nil {
+			step = &allureStep{
+				Name:   allureStepName(ctx, rules),
+				Status: allureStatusPassed,
+				Stage:  allureStageDone,
+				Start:  now,
+			}
+			res.stepMap[ctx.Request] = step
+			res.Steps = append(res.Steps, step)
+		}
+		step.Stop = now
+
+		if ctx.Response != nil && !step.attached {
+			step.attached = true
+			step.Name = allureStepName(ctx, rules)
+			allureAttach(res, step, ctx.Request, ctx.Response, rules)
+		}
+	}
+
+	if failure == nil || failure.Severity != SeverityError {
+		return
+	}
+
+	var messages []string
+	for _, err := range failure.Errors {
+		if err == nil {
+			continue
+		}
+		if rules != nil {
+			messages = append(messages, rules.redactString(err.Error()))
+		} else {
+			messages = append(messages, err.Error())
+		}
+	}
+
+	details := &allureStatusDetails{
+		Message: strings.Join(messages, "\n"),
+		Trace:   strings.Join(ctx.Path, "."),
+	}
+
+	res.Status = allureStatusFailed
+	if res.StatusDetails == nil {
+		res.StatusDetails = details
+	}
+
+	failedStep := &allureStep{
+		Name:          strings.Join(ctx.Path, "."),
+		Status:        allureStatusFailed,
+		Stage:         allureStageDone,
+		StatusDetails: details,
+		Start:         now,
+		Stop:          now,
+	}
+
+	if step != nil {
+		step.Status = allureStatusFailed
+		if step.StatusDetails == nil {
+			step.StatusDetails = details
+		}
+		step.Steps = append(step.Steps, failedStep)
+	} else {
+		res.Steps = append(res.Steps, failedStep)
+	}
+}
+
+func (h *AllureAssertionHandler) getResult(testName string, now int64) *allureResult {
+	for _, res := range h.results {
+		if res.FullName == testName {
+			return res
+		}
+	}
+
+	name := testName
+	if name == "" {
+		name = "httpexpect"
+	}
+
+	res := &allureResult{
+		UUID:     allureUUID(),
+		Name:     name,
+		FullName: testName,
+		Status:   allureStatusPassed,
+		Stage:    allureStageDone,
+		Start:    now,
+		Stop:     now,
+		Steps:    []*allureStep{},
+		Labels: []allureLabel{
+			{Name: "framework", Value: "httpexpect"},
+			{Name: "language", Value: "go"},
+		},
+		stepMap: map[*Request]*allureStep{},
+	}
+
+	h.results = append(h.results, res)
+
+	return res
+}
+
+func allureStepName(ctx *AssertionContext, rules *RedactionRules) string {
+	if ctx.RequestName != "" {
+		return ctx.RequestName
+	}
+
+	if ctx.Request != nil && ctx.Request.httpReq != nil {
+		u := ctx.Request.httpReq.URL
+		if rules != nil {
+			u = rules.redactURL(u)
+		}
+		return fmt.Sprintf("%s %s", ctx.Request.httpReq.Method, u)
+	}
+
+	return "request"
+}
+
+func allureAttach(
+	res *allureResult, step *allureStep, req *Request, resp *Response,
+	rules *RedactionRules,
+) {
+	add := func(name, mimeType, ext string, data []byte) {
+		source := allureUUID() + "-attachment" + ext
+
+		res.files = append(res.files, allureFile{
+			name: source,
+			data: data,
+		})
+
+		step.Attachments = append(step.Attachments, allureAttachment{
+			Name:   name,
+			Source: source,
+			Type:   mimeType,
+		})
+	}
+
+	if httpReq := req.httpReq; httpReq != nil {
+		if rules != nil {
+			httpReq = rules.redactRequest(httpReq)
+		}
+
+		var reqBody []byte
+
+		if bw, ok := httpReq.Body.(rewindBody); ok {
+			if rd, err := bw.GetBody(); err == nil {
+				reqBody, _ = ioutil.ReadAll(rd)
+			}
+		}
+
+		add("request body", allureMimeType(httpReq.Header), ".txt", reqBody)
+
+		curlReq := httpReq.Clone(httpReq.Context())
+		curlReq.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+
+		if cmd, err := curlCommand(curlReq, nil); err == nil {
+			add("curl command", "text/plain", ".txt", []byte(cmd))
+		}
+	}
+
+	if resp.httpResp != nil {
+		respBody := append([]byte(nil), resp.content...)
+		if rules != nil {
+			respBody = rules.redactBody(respBody)
+		}
+
+		add("response body", allureMimeType(resp.httpResp.Header), ".txt", respBody)
+	}
+}
+
+func allureMimeType(header http.Header) string {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		return "text/plain"
+	}
+
+	if idx := strings.Index(contentType, ";"); idx >= 0 {
+		contentType = contentType[:idx]
+	}
+
+	return strings.TrimSpace(contentType)
+}
+
+func allureTime(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func allureUUID() string {
+	var b [16]byte
+
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,209 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllureAssertionHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend := &mockAssertionHandler{}
+
+	handler := &AllureAssertionHandler{
+		Handler:   backend,
+		OutputDir: dir,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	e := WithConfig(Config{
+		TestName:         "TestAllure",
+		BaseURL:          "http://example.com",
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(mux),
+		},
+	})
+
+	e.POST("/echo").
+		WithJSON(map[string]interface{}{"foo": "bar"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().ValueEqual("foo", "bar")
+
+	e.GET("/echo").
+		WithName("second").
+		Expect().
+		Status(http.StatusTeapot)
+
+	assert.NotNil(t, backend.ctx)
+	assert.NotNil(t, backend.failure)
+
+	require.NoError(t, handler.Flush())
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+
+	var (
+		result      map[string]interface{}
+		attachments = map[string]string{}
+	)
+
+	for _, f := range files {
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		require.NoError(t, err)
+
+		if strings.HasSuffix(f.Name(), "-result.json") {
+			require.Nil(t, result)
+			require.NoError(t, json.Unmarshal(data, &result))
+		} else {
+			attachments[f.Name()] = string(data)
+		}
+	}
+
+	require.NotNil(t, result)
+
+	assert.Equal(t, "TestAllure", result["name"])
+	assert.Equal(t, "failed", result["status"])
+
+	steps := result["steps"].([]interface{})
+	require.Equal(t, 2, len(steps))
+
+	step1 := steps[0].(map[string]interface{})
+	assert.Equal(t, "POST http://example.com/echo", step1["name"])
+	assert.Equal(t, "passed", step1["status"])
+
+	step1Attachments := step1["attachments"].([]interface{})
+	require.Equal(t, 3, len(step1Attachments))
+
+	names := []string{}
+	for _, a := range step1Attachments {
+		attachment := a.(map[string]interface{})
+		names = append(names, attachment["name"].(string))
+
+		content, ok := attachments[attachment["source"].(string)]
+		require.True(t, ok)
+
+		switch attachment["name"] {
+		case "request body", "response body":
+			assert.Equal(t, `{"foo":"bar"}`, content)
+			assert.Equal(t, "application/json", attachment["type"])
+		case "curl command":
+			assert.Contains(t, content, "curl -X 'POST'")
+		}
+	}
+	assert.Equal(t,
+		[]string{"request body", "curl command", "response body"}, names)
+
+	step2 := steps[1].(map[string]interface{})
+	assert.Equal(t, "second", step2["name"])
+	assert.Equal(t, "failed", step2["status"])
+	assert.Equal(t, 1, len(step2["steps"].([]interface{})))
+
+	require.NoError(t, handler.Flush())
+
+	files2, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, len(files), len(files2))
+}
+
+func TestAllureAssertionHandlerNoRequest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	handler := &AllureAssertionHandler{
+		OutputDir: dir,
+	}
+
+	handler.Success(&AssertionContext{})
+	handler.Failure(&AssertionContext{Path: []string{"Value()"}},
+		&AssertionFailure{Severity: SeverityLog})
+
+	require.Equal(t, 1, len(handler.results))
+	assert.Equal(t, "passed", handler.results[0].Status)
+
+	handler.Failure(&AssertionContext{Path: []string{"Value()"}},
+		&AssertionFailure{Severity: SeverityError})
+
+	assert.Equal(t, "failed", handler.results[0].Status)
+	assert.Equal(t, 1, len(handler.results[0].Steps))
+
+	require.NoError(t, handler.Flush())
+	assert.Equal(t, 0, len(handler.results))
+}
+
+func TestAllureAssertionHandlerRedaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	handler := &AllureAssertionHandler{
+		OutputDir: dir,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token":"response-secret"}`))
+	})
+
+	e := WithConfig(Config{
+		TestName:         "TestAllureRedaction",
+		BaseURL:          "http://example.com",
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(mux),
+		},
+		RedactionRules: &RedactionRules{
+			Headers:   []string{"Authorization"},
+			JSONPaths: []string{"$.password", "$.token"},
+			Patterns:  []*regexp.Regexp{regexp.MustCompile(`key=[\w-]+`)},
+		},
+	})
+
+	e.POST("/login").
+		WithQuery("key", "query-secret").
+		WithHeader("Authorization", "Bearer header-secret").
+		WithJSON(map[string]interface{}{"password": "request-secret"}).
+		Expect().
+		Status(http.StatusTeapot)
+
+	require.NoError(t, handler.Flush())
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	var curl string
+
+	for _, f := range files {
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(data), "secret", f.Name())
+
+		if strings.HasPrefix(string(data), "curl ") {
+			curl = string(data)
+		}
+	}
+
+	assert.Contains(t, curl, "Authorization: [REDACTED]")
+}
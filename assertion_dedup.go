@@ -0,0 +1,227 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DedupAssertionHandler is an AssertionHandler that collapses repeated
+// identical failures into a single report.
+//
+// Two failures are considered identical if they have the same assertion
+// path, type, severity, and error messages.
+//
+// The first occurrence of every failure is passed to underlying Handler.
+// Subsequent occurrences in the same test are only counted. Subsequent
+// occurrences in other tests, e.g. other subtests of a table test, are
+// passed to Handler in short form, without actual and expected values,
+// so that every failing test is still reported. When the run is finished,
+// you can call Flush to report how many duplicates were suppressed.
+//
+// Successful assertions are always passed to Handler as is.
+//
+// Example:
+//
+//	handler := &httpexpect.DedupAssertionHandler{
+//	    Handler: &httpexpect.DefaultAssertionHandler{
+//	        Formatter: &httpexpect.DefaultFormatter{},
+//	        Reporter:  httpexpect.NewAssertReporter(t),
+//	    },
+//	    Logger: t,
+//	}
+//	defer handler.Flush()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    AssertionHandler: handler,
+//	})
+type DedupAssertionHandler struct {
+	// Handler is used to report successful assertions and first occurrence
+	// of every failure. Should not be nil.
+	Handler AssertionHandler
+
+	// Logger is used by Flush to print summary of suppressed failures.
+	// May be nil. If nil, Flush does nothing.
+	Logger Logger
+
+	// If positive, every failure is passed to Handler in full form until
+	// it occurs MaxRepeats times, and only further occurrences are
+	// collapsed. If zero or negative, only the first occurrence is passed
+	// in full form.
+	MaxRepeats int
+
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+}
+
+type dedupEntry struct {
+	path       string
+	firstTest  string
+	count      int
+	suppressed int
+	tests      map[string]struct{}
+}
+
+type dedupAction int
+
+const (
+	dedupReport dedupAction = iota
+	dedupReportShort
+	dedupSuppress
+)
+
+// Success implements AssertionHandler.Success.
+func (h *DedupAssertionHandler) Success(ctx *AssertionContext) {
+	if h.Handler == nil {
+		panic("DedupAssertionHandler.Handler is nil")
+	}
+
+	h.Handler.Success(ctx)
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *DedupAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if h.Handler == nil {
+		panic("DedupAssertionHandler.Handler is nil")
+	}
+
+	action, firstTest := h.register(ctx, failure)
+
+	switch action {
+	case dedupReport:
+		h.Handler.Failure(ctx, failure)
+
+	case dedupReportShort:
+		h.Handler.Failure(ctx, shortDedupFailure(failure, firstTest))
+
+	case dedupSuppress:
+	}
+}
+
+// Suppressed returns total number of failures that were not passed
+// to Handler because they were duplicates within the same test.
+func (h *DedupAssertionHandler) Suppressed() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	total := 0
+	for _, entry := range h.entries {
+		total += entry.suppressed
+	}
+
+	return total
+}
+
+// Flush prints summary of suppressed failures to Logger and resets
+// all counters.
+func (h *DedupAssertionHandler) Flush() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.Logger != nil {
+		keys := make([]string, 0, len(h.entries))
+		for key := range h.entries {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			entry := h.entries[key]
+			if n := entry.count - h.maxRepeats(); n > 0 {
+				h.Logger.Logf("failure repeated %d more time(s), total %d:\n%s",
+					n, entry.count, entry.path)
+			}
+		}
+	}
+
+	h.entries = nil
+}
+
+func (h *DedupAssertionHandler) register(
+	ctx *AssertionContext, failure *AssertionFailure,
+) (dedupAction, string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.entries == nil {
+		h.entries = make(map[string]*dedupEntry)
+	}
+
+	key := dedupKey(ctx, failure)
+
+	entry := h.entries[key]
+	if entry == nil {
+		entry = &dedupEntry{
+			path:      strings.Join(ctx.Path, "."),
+			firstTest: ctx.TestName,
+			tests:     make(map[string]struct{}),
+		}
+		h.entries[key] = entry
+	}
+
+	entry.count++
+
+	_, seen := entry.tests[ctx.TestName]
+	entry.tests[ctx.TestName] = struct{}{}
+
+	switch {
+	case entry.count <= h.maxRepeats():
+		return dedupReport, entry.firstTest
+
+	case !seen:
+		return dedupReportShort, entry.firstTest
+
+	default:
+		entry.suppressed++
+		return dedupSuppress, entry.firstTest
+	}
+}
+
+func (h *DedupAssertionHandler) maxRepeats() int {
+	if h.MaxRepeats > 0 {
+		return h.MaxRepeats
+	}
+	return 1
+}
+
+func dedupKey(ctx *AssertionContext, failure *AssertionFailure) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s\x00%s\x00%s",
+		failure.Type, failure.Severity, strings.Join(ctx.Path, "\x00"))
+
+	for _, err := range failure.Errors {
+		if err == nil {
+			continue
+		}
+		sb.WriteString("\x00")
+		sb.WriteString(err.Error())
+	}
+
+	return sb.String()
+}
+
+// Make copy of failure without actual and expected values, which were
+// already reported by first occurrence.
+func shortDedupFailure(failure *AssertionFailure, firstTest string) *AssertionFailure {
+	short := *failure
+
+	short.Actual = nil
+	short.Expected = nil
+	short.Reference = nil
+	short.Delta = nil
+
+	note := "same failure was reported earlier, details omitted"
+	if firstTest != "" {
+		note = fmt.Sprintf("same failure was reported earlier in %q, details omitted",
+			firstTest)
+	}
+
+	short.Errors = append([]error{errors.New(note)}, failure.Errors...)
+
+	return &short
+}
@@ -0,0 +1,168 @@
+package httpexpect
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupAssertionHandler(t *testing.T) {
+	failure := func(msg string) *AssertionFailure {
+		return &AssertionFailure{
+			Type:     AssertValid,
+			Severity: SeverityError,
+			Errors:   []error{errors.New(msg)},
+		}
+	}
+
+	t.Run("duplicates", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+		logger := newMockLogger(t)
+
+		handler := &DedupAssertionHandler{
+			Handler: backend,
+			Logger:  logger,
+		}
+
+		ctx := &AssertionContext{Path: []string{"foo", "bar"}}
+
+		handler.Failure(ctx, failure("error"))
+		assert.NotNil(t, backend.failure)
+
+		backend.failure = nil
+
+		handler.Failure(ctx, failure("error"))
+		handler.Failure(ctx, failure("error"))
+		assert.Nil(t, backend.failure)
+
+		assert.Equal(t, 2, handler.Suppressed())
+
+		handler.Flush()
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "2 more time(s), total 3")
+		assert.Contains(t, logger.lastMessage, "foo.bar")
+
+		assert.Equal(t, 0, handler.Suppressed())
+
+		handler.Failure(ctx, failure("error"))
+		assert.NotNil(t, backend.failure)
+	})
+
+	t.Run("different", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+
+		handler := &DedupAssertionHandler{
+			Handler: backend,
+		}
+
+		handler.Failure(&AssertionContext{Path: []string{"foo"}}, failure("error"))
+		assert.NotNil(t, backend.failure)
+		backend.failure = nil
+
+		handler.Failure(&AssertionContext{Path: []string{"bar"}}, failure("error"))
+		assert.NotNil(t, backend.failure)
+		backend.failure = nil
+
+		handler.Failure(&AssertionContext{Path: []string{"foo"}}, failure("other"))
+		assert.NotNil(t, backend.failure)
+
+		assert.Equal(t, 0, handler.Suppressed())
+	})
+
+	t.Run("subtests", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+
+		handler := &DedupAssertionHandler{
+			Handler: backend,
+		}
+
+		fail := func(t *testing.T) {
+			f := failure("error")
+			f.Actual = &AssertionValue{"actual"}
+			f.Expected = &AssertionValue{"expected"}
+
+			handler.Failure(&AssertionContext{TestName: t.Name()}, f)
+		}
+
+		t.Run("first", func(t *testing.T) {
+			fail(t)
+
+			assert.NotNil(t, backend.failure)
+			assert.NotNil(t, backend.failure.Actual)
+			backend.failure = nil
+
+			fail(t)
+			assert.Nil(t, backend.failure)
+		})
+
+		t.Run("second", func(t *testing.T) {
+			fail(t)
+
+			// reported, but without details
+			assert.NotNil(t, backend.failure)
+			assert.Nil(t, backend.failure.Actual)
+			assert.Nil(t, backend.failure.Expected)
+			assert.Equal(t, 2, len(backend.failure.Errors))
+			assert.Contains(t, backend.failure.Errors[0].Error(),
+				"subtests/first")
+			backend.failure = nil
+
+			fail(t)
+			assert.Nil(t, backend.failure)
+		})
+
+		assert.Equal(t, 2, handler.Suppressed())
+	})
+
+	t.Run("max_repeats", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+
+		handler := &DedupAssertionHandler{
+			Handler:    backend,
+			MaxRepeats: 2,
+		}
+
+		ctx := &AssertionContext{}
+
+		handler.Failure(ctx, failure("error"))
+		assert.NotNil(t, backend.failure)
+		backend.failure = nil
+
+		handler.Failure(ctx, failure("error"))
+		assert.NotNil(t, backend.failure)
+		backend.failure = nil
+
+		handler.Failure(ctx, failure("error"))
+		assert.Nil(t, backend.failure)
+
+		assert.Equal(t, 1, handler.Suppressed())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+
+		handler := &DedupAssertionHandler{
+			Handler: backend,
+		}
+
+		ctx := &AssertionContext{TestName: "test"}
+
+		handler.Success(ctx)
+		handler.Success(ctx)
+
+		assert.Equal(t, ctx, backend.ctx)
+		assert.Equal(t, 0, handler.Suppressed())
+	})
+
+	t.Run("nil_handler", func(t *testing.T) {
+		handler := &DedupAssertionHandler{}
+
+		assert.Panics(t, func() {
+			handler.Success(&AssertionContext{})
+		})
+		assert.Panics(t, func() {
+			handler.Failure(&AssertionContext{}, failure("error"))
+		})
+	})
+}
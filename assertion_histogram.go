@@ -0,0 +1,375 @@
+package httpexpect
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HistogramFormat defines output format of HistogramAssertionHandler.
+type HistogramFormat int
+
+const (
+	// JSON array with one object per endpoint.
+	HistogramJSON HistogramFormat = iota
+
+	// CSV table with one row per endpoint.
+	HistogramCSV
+)
+
+// DefaultHistogramBuckets defines default upper bounds of latency buckets
+// used by HistogramAssertionHandler.
+var DefaultHistogramBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// HistogramAssertionHandler is an AssertionHandler that collects round-trip
+// times of all responses and groups them into per-endpoint histograms.
+//
+// Endpoint is identified by request method and path template, as passed to
+// request constructor, e.g. "GET /users/{id}". So requests with different
+// path arguments are accounted in the same histogram.
+//
+// Every response is accounted once, when the first assertion on it is
+// reported. Responses without round-trip time are ignored.
+//
+// Every assertion is forwarded to Handler (if it's set). When the test run
+// is finished, call WriteFile or Write to export histograms, e.g. to track
+// performance trends of test suite across CI builds.
+//
+// HistogramAssertionHandler is safe for concurrent use.
+//
+// Example:
+//
+//	func TestSomething(t *testing.T) {
+//	    histogram := &httpexpect.HistogramAssertionHandler{
+//	        Handler: &httpexpect.DefaultAssertionHandler{
+//	            Formatter: &httpexpect.DefaultFormatter{},
+//	            Reporter:  httpexpect.NewAssertReporter(t),
+//	        },
+//	        Format: httpexpect.HistogramCSV,
+//	    }
+//	    defer histogram.WriteFile("latency.csv")
+//
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        BaseURL:          "http://example.com",
+//	        AssertionHandler: histogram,
+//	    })
+//	}
+type HistogramAssertionHandler struct {
+	// Handler receives all assertions after they're recorded.
+	// May be nil.
+	Handler AssertionHandler
+
+	// Upper bounds of histogram buckets, in ascending order.
+	// If nil, DefaultHistogramBuckets is used.
+	Buckets []time.Duration
+
+	// Format of exported histograms.
+	// Default is HistogramJSON.
+	Format HistogramFormat
+
+	mu        sync.Mutex
+	seen      map[*Response]struct{}
+	endpoints map[string][]time.Duration
+}
+
+// LatencyHistogram describes latency statistics of single endpoint,
+// collected by HistogramAssertionHandler.
+type LatencyHistogram struct {
+	// Request method and path template, e.g. "GET /users/{id}".
+	Endpoint string
+
+	// Number of responses.
+	Count int
+
+	// Statistics of round-trip times.
+	Min    time.Duration
+	Max    time.Duration
+	Mean   time.Duration
+	Median time.Duration
+	P90    time.Duration
+	P99    time.Duration
+
+	// Number of responses in every bucket.
+	// Buckets are not cumulative, and the last bucket has no upper bound.
+	Buckets []LatencyBucket
+}
+
+// LatencyBucket describes single bucket of LatencyHistogram.
+type LatencyBucket struct {
+	// Upper bound of bucket (inclusive).
+	// Zero for the last bucket, which has no upper bound.
+	Le time.Duration
+
+	// Number of responses in bucket.
+	Count int
+}
+
+// Success implements AssertionHandler.Success.
+func (h *HistogramAssertionHandler) Success(ctx *AssertionContext) {
+	h.record(ctx)
+
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *HistogramAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.record(ctx)
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
+
+// Histograms returns histograms of all endpoints, sorted by endpoint.
+func (h *HistogramAssertionHandler) Histograms() []LatencyHistogram {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buckets := h.Buckets
+	if buckets == nil {
+		buckets = DefaultHistogramBuckets
+	}
+
+	names := make([]string, 0, len(h.endpoints))
+	for name := range h.endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]LatencyHistogram, 0, len(names))
+
+	for _, name := range names {
+		sorted := append([]time.Duration(nil), h.endpoints[name]...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] < sorted[j]
+		})
+
+		var sum time.Duration
+		for _, d := range sorted {
+			sum += d
+		}
+
+		hist := LatencyHistogram{
+			Endpoint: name,
+			Count:    len(sorted),
+			Min:      sorted[0],
+			Max:      sorted[len(sorted)-1],
+			Mean:     sum / time.Duration(len(sorted)),
+			Median:   percentile(sorted, 50),
+			P90:      percentile(sorted, 90),
+			P99:      percentile(sorted, 99),
+		}
+
+		for _, le := range buckets {
+			hist.Buckets = append(hist.Buckets, LatencyBucket{Le: le})
+		}
+		hist.Buckets = append(hist.Buckets, LatencyBucket{})
+
+		for _, d := range sorted {
+			i := sort.Search(len(buckets), func(i int) bool {
+				return d <= buckets[i]
+			})
+			hist.Buckets[i].Count++
+		}
+
+		result = append(result, hist)
+	}
+
+	return result
+}
+
+// Reset removes all collected round-trip times.
+func (h *HistogramAssertionHandler) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seen = nil
+	h.endpoints = nil
+}
+
+// WriteFile writes histograms to file with given path.
+// If file exists, it's truncated.
+func (h *HistogramAssertionHandler) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Write writes histograms to given writer.
+func (h *HistogramAssertionHandler) Write(w io.Writer) error {
+	histograms := h.Histograms()
+
+	switch h.Format {
+	case HistogramJSON:
+		return writeJSONHistograms(w, histograms)
+
+	case HistogramCSV:
+		return writeCSVHistograms(w, histograms)
+
+	default:
+		return errors.New("unsupported histogram format")
+	}
+}
+
+func (h *HistogramAssertionHandler) record(ctx *AssertionContext) {
+	resp, req := ctx.Response, ctx.Request
+	if resp == nil || resp.rtt == nil || req == nil || req.httpReq == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.seen[resp]; ok {
+		return
+	}
+
+	if h.seen == nil {
+		h.seen = make(map[*Response]struct{})
+	}
+	h.seen[resp] = struct{}{}
+
+	if h.endpoints == nil {
+		h.endpoints = make(map[string][]time.Duration)
+	}
+
+	endpoint := req.httpReq.Method + " " + req.pathTemplate
+	h.endpoints[endpoint] = append(h.endpoints[endpoint], *resp.rtt)
+}
+
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+type jsonHistogram struct {
+	Endpoint string                `json:"endpoint"`
+	Count    int                   `json:"count"`
+	MinMs    float64               `json:"min_ms"`
+	MaxMs    float64               `json:"max_ms"`
+	MeanMs   float64               `json:"mean_ms"`
+	MedianMs float64               `json:"median_ms"`
+	P90Ms    float64               `json:"p90_ms"`
+	P99Ms    float64               `json:"p99_ms"`
+	Buckets  []jsonHistogramBucket `json:"buckets"`
+}
+
+type jsonHistogramBucket struct {
+	LeMs  *float64 `json:"le_ms"`
+	Count int      `json:"count"`
+}
+
+func writeJSONHistograms(w io.Writer, histograms []LatencyHistogram) error {
+	out := []jsonHistogram{}
+
+	for _, hist := range histograms {
+		jh := jsonHistogram{
+			Endpoint: hist.Endpoint,
+			Count:    hist.Count,
+			MinMs:    durationMillis(hist.Min),
+			MaxMs:    durationMillis(hist.Max),
+			MeanMs:   durationMillis(hist.Mean),
+			MedianMs: durationMillis(hist.Median),
+			P90Ms:    durationMillis(hist.P90),
+			P99Ms:    durationMillis(hist.P99),
+		}
+
+		for i, b := range hist.Buckets {
+			jb := jsonHistogramBucket{Count: b.Count}
+			if i != len(hist.Buckets)-1 {
+				le := durationMillis(b.Le)
+				jb.LeMs = &le
+			}
+			jh.Buckets = append(jh.Buckets, jb)
+		}
+
+		out = append(out, jh)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", defaultIndent)
+
+	return enc.Encode(out)
+}
+
+func writeCSVHistograms(w io.Writer, histograms []LatencyHistogram) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{
+		"endpoint", "count",
+		"min_ms", "max_ms", "mean_ms", "median_ms", "p90_ms", "p99_ms",
+	}
+
+	if len(histograms) != 0 {
+		buckets := histograms[0].Buckets
+		for i, b := range buckets {
+			if i == len(buckets)-1 {
+				header = append(header, "le_inf")
+			} else {
+				header = append(header, "le_"+formatMillis(b.Le))
+			}
+		}
+	}
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, hist := range histograms {
+		row := []string{
+			hist.Endpoint,
+			strconv.Itoa(hist.Count),
+			formatMillis(hist.Min),
+			formatMillis(hist.Max),
+			formatMillis(hist.Mean),
+			formatMillis(hist.Median),
+			formatMillis(hist.P90),
+			formatMillis(hist.P99),
+		}
+
+		for _, b := range hist.Buckets {
+			row = append(row, strconv.Itoa(b.Count))
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func formatMillis(d time.Duration) string {
+	return strconv.FormatFloat(durationMillis(d), 'f', -1, 64)
+}
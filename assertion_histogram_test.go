@@ -0,0 +1,191 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramAssertionHandler(t *testing.T) {
+	newCtx := func(method, path string, rtt time.Duration) *AssertionContext {
+		return &AssertionContext{
+			Request: &Request{
+				httpReq:      &http.Request{Method: method},
+				pathTemplate: path,
+			},
+			Response: &Response{
+				rtt: &rtt,
+			},
+		}
+	}
+
+	fill := func(h *HistogramAssertionHandler) {
+		h.Success(newCtx("GET", "/users/{id}", 3*time.Millisecond))
+		h.Success(newCtx("GET", "/users/{id}", 7*time.Millisecond))
+		h.Failure(newCtx("GET", "/users/{id}", 30*time.Millisecond),
+			&AssertionFailure{Type: AssertEqual})
+		h.Success(newCtx("POST", "/users", 2*time.Second))
+		h.Success(&AssertionContext{})
+	}
+
+	t.Run("forward", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+
+		h := &HistogramAssertionHandler{
+			Handler: backend,
+		}
+
+		ctx := &AssertionContext{TestName: "test"}
+		failure := &AssertionFailure{Type: AssertValid}
+
+		h.Success(ctx)
+		assert.Equal(t, ctx, backend.ctx)
+
+		h.Failure(ctx, failure)
+		assert.Equal(t, failure, backend.failure)
+	})
+
+	t.Run("histograms", func(t *testing.T) {
+		h := &HistogramAssertionHandler{
+			Buckets: []time.Duration{5 * time.Millisecond, 10 * time.Millisecond},
+		}
+
+		fill(h)
+
+		hist := h.Histograms()
+		require.Equal(t, 2, len(hist))
+
+		assert.Equal(t, "GET /users/{id}", hist[0].Endpoint)
+		assert.Equal(t, 3, hist[0].Count)
+		assert.Equal(t, 3*time.Millisecond, hist[0].Min)
+		assert.Equal(t, 30*time.Millisecond, hist[0].Max)
+		assert.Equal(t, 40*time.Millisecond/3, hist[0].Mean)
+		assert.Equal(t, 7*time.Millisecond, hist[0].Median)
+		assert.Equal(t, []LatencyBucket{
+			{Le: 5 * time.Millisecond, Count: 1},
+			{Le: 10 * time.Millisecond, Count: 1},
+			{Le: 0, Count: 1},
+		}, hist[0].Buckets)
+
+		assert.Equal(t, "POST /users", hist[1].Endpoint)
+		assert.Equal(t, 1, hist[1].Count)
+		assert.Equal(t, 1, hist[1].Buckets[2].Count)
+
+		h.Reset()
+		assert.Equal(t, 0, len(h.Histograms()))
+	})
+
+	t.Run("once per response", func(t *testing.T) {
+		h := &HistogramAssertionHandler{}
+
+		ctx := newCtx("GET", "/", time.Millisecond)
+
+		h.Success(ctx)
+		h.Success(ctx)
+		h.Failure(ctx, &AssertionFailure{Type: AssertEqual})
+
+		hist := h.Histograms()
+		require.Equal(t, 1, len(hist))
+		assert.Equal(t, 1, hist[0].Count)
+		assert.Equal(t, len(DefaultHistogramBuckets)+1, len(hist[0].Buckets))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		h := &HistogramAssertionHandler{
+			Buckets: []time.Duration{5 * time.Millisecond},
+			Format:  HistogramJSON,
+		}
+
+		fill(h)
+
+		var buf bytes.Buffer
+		require.NoError(t, h.Write(&buf))
+
+		var out []map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		require.Equal(t, 2, len(out))
+
+		assert.Equal(t, "GET /users/{id}", out[0]["endpoint"])
+		assert.Equal(t, 3.0, out[0]["count"])
+		assert.Equal(t, 3.0, out[0]["min_ms"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"le_ms": 5.0, "count": 1.0},
+			map[string]interface{}{"le_ms": nil, "count": 2.0},
+		}, out[0]["buckets"])
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		h := &HistogramAssertionHandler{
+			Buckets: []time.Duration{5 * time.Millisecond, 10 * time.Millisecond},
+			Format:  HistogramCSV,
+		}
+
+		fill(h)
+
+		var buf bytes.Buffer
+		require.NoError(t, h.Write(&buf))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, 3, len(rows))
+
+		assert.Equal(t, []string{
+			"endpoint", "count",
+			"min_ms", "max_ms", "mean_ms", "median_ms", "p90_ms", "p99_ms",
+			"le_5", "le_10", "le_inf",
+		}, rows[0])
+
+		assert.Equal(t, "GET /users/{id}", rows[1][0])
+		assert.Equal(t, "3", rows[1][1])
+		assert.Equal(t, []string{"1", "1", "1"}, rows[1][8:])
+
+		assert.Equal(t, "POST /users", rows[2][0])
+		assert.Equal(t, "2000", rows[2][2])
+	})
+
+	t.Run("bad format", func(t *testing.T) {
+		h := &HistogramAssertionHandler{
+			Format: HistogramFormat(100),
+		}
+
+		assert.Error(t, h.Write(&bytes.Buffer{}))
+	})
+}
+
+func TestHistogramAssertionHandlerExpect(t *testing.T) {
+	h := &HistogramAssertionHandler{
+		Handler: &mockAssertionHandler{},
+	}
+
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: h,
+		Client: &http.Client{
+			Transport: NewBinder(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})),
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		e.GET("/users/{id}", i).Expect().Status(http.StatusOK)
+	}
+	e.GET("/health").Expect()
+
+	hist := h.Histograms()
+	require.Equal(t, 2, len(hist))
+
+	assert.Equal(t, "GET /health", hist[0].Endpoint)
+	assert.Equal(t, 1, hist[0].Count)
+
+	assert.Equal(t, "GET /users/{id}", hist[1].Endpoint)
+	assert.Equal(t, 3, hist[1].Count)
+}
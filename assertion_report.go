@@ -0,0 +1,325 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ReportFormat defines output format of ReportAssertionHandler.
+type ReportFormat int
+
+const (
+	// JUnit XML report, understood by most CI systems.
+	ReportJUnit ReportFormat = iota
+
+	// JSON report with one entry per assertion.
+	ReportJSON
+)
+
+// ReportAssertionHandler is an AssertionHandler that records all assertions,
+// both succeeded and failed, and can write them as a JUnit XML or JSON report.
+//
+// Every assertion is forwarded to Handler (if it's set) and also stored
+// in memory. When the test run is finished, call WriteFile or Write to
+// produce the report.
+//
+// Each test (AssertionContext.TestName) becomes a test suite, and each
+// assertion becomes a test case named after assertion path.
+//
+// Only failures with SeverityError are counted as failed test cases.
+// Failures with other severities, e.g. failed predicates in Array.Filter
+// or warnings from Response.Warn, don't fail the test, so they are
+// recorded as passed test cases with failure message in system output.
+//
+// Redaction rules are applied to recorded errors and messages, so that
+// sensitive data doesn't leak into the report.
+//
+// ReportAssertionHandler is safe for concurrent use.
+//
+// Example:
+//
+//	func TestSomething(t *testing.T) {
+//	    report := &httpexpect.ReportAssertionHandler{
+//	        Handler: &httpexpect.DefaultAssertionHandler{
+//	            Formatter: &httpexpect.DefaultFormatter{},
+//	            Reporter:  httpexpect.NewAssertReporter(t),
+//	        },
+//	        Format: httpexpect.ReportJUnit,
+//	    }
+//	    defer report.WriteFile("report.xml")
+//
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        TestName:         t.Name(),
+//	        AssertionHandler: report,
+//	    })
+//	}
+type ReportAssertionHandler struct {
+	// Handler receives all assertions after they're recorded.
+	// May be nil.
+	Handler AssertionHandler
+
+	// Formatter is used to format failure messages stored in report.
+	// May be nil. If nil, only error messages are stored.
+	Formatter Formatter
+
+	// Format of the report.
+	// Default is ReportJUnit.
+	Format ReportFormat
+
+	// Rules for hiding sensitive data.
+	// May be nil. If nil, Config.RedactionRules of the request are used.
+	RedactionRules *RedactionRules
+
+	mu      sync.Mutex
+	entries []ReportEntry
+}
+
+// ReportEntry describes single assertion recorded by ReportAssertionHandler.
+type ReportEntry struct {
+	TestName    string   `json:"test_name,omitempty"`
+	RequestName string   `json:"request_name,omitempty"`
+	Path        []string `json:"path"`
+	Success     bool     `json:"success"`
+	Type        string   `json:"type,omitempty"`
+	Code        string   `json:"code,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Errors      []string `json:"errors,omitempty"`
+	Message     string   `json:"message,omitempty"`
+}
+
+// Check if entry is a failure that fails the test.
+func (e *ReportEntry) isFailure() bool {
+	return !e.Success && e.Severity == SeverityError.String()
+}
+
+// Success implements AssertionHandler.Success.
+func (h *ReportAssertionHandler) Success(ctx *AssertionContext) {
+	h.record(ReportEntry{
+		TestName:    ctx.TestName,
+		RequestName: ctx.RequestName,
+		Path:        append([]string(nil), ctx.Path...),
+		Success:     true,
+	})
+
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *ReportAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	entry := ReportEntry{
+		TestName:    ctx.TestName,
+		RequestName: ctx.RequestName,
+		Path:        append([]string(nil), ctx.Path...),
+		Success:     false,
+		Type:        failure.Type.String(),
+		Code:        string(failure.Code),
+		Severity:    failure.Severity.String(),
+	}
+
+	rules := contextRedactionRules(h.RedactionRules, ctx)
+
+	for _, err := range failure.Errors {
+		if err == nil {
+			continue
+		}
+		if rules != nil {
+			entry.Errors = append(entry.Errors, rules.redactString(err.Error()))
+		} else {
+			entry.Errors = append(entry.Errors, err.Error())
+		}
+	}
+
+	if h.Formatter != nil {
+		formatter := h.Formatter
+		if rules != nil {
+			formatter = rules.WrapFormatter(formatter)
+		}
+		entry.Message = formatter.FormatFailure(ctx, failure)
+	} else {
+		entry.Message = strings.Join(entry.Errors, "\n")
+	}
+
+	h.record(entry)
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
+
+// Entries returns a copy of all recorded assertions.
+func (h *ReportAssertionHandler) Entries() []ReportEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]ReportEntry(nil), h.entries...)
+}
+
+// Reset removes all recorded assertions.
+func (h *ReportAssertionHandler) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = nil
+}
+
+// WriteFile writes report to file with given path.
+// If file exists, it's truncated.
+func (h *ReportAssertionHandler) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Write writes report to given writer.
+func (h *ReportAssertionHandler) Write(w io.Writer) error {
+	entries := h.Entries()
+
+	switch h.Format {
+	case ReportJUnit:
+		return writeJUnitReport(w, entries)
+
+	case ReportJSON:
+		return writeJSONReport(w, entries)
+
+	default:
+		return errors.New("unsupported report format")
+	}
+}
+
+func (h *ReportAssertionHandler) record(entry ReportEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Code    string `xml:"code,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnitReport(w io.Writer, entries []ReportEntry) error {
+	var report junitTestSuites
+
+	suiteIndex := map[string]int{}
+
+	for _, entry := range entries {
+		idx, ok := suiteIndex[entry.TestName]
+		if !ok {
+			idx = len(report.Suites)
+			suiteIndex[entry.TestName] = idx
+			report.Suites = append(report.Suites, junitTestSuite{
+				Name: entry.TestName,
+			})
+		}
+
+		suite := &report.Suites[idx]
+
+		tc := junitTestCase{
+			Name:      strings.Join(entry.Path, "."),
+			ClassName: entry.TestName,
+		}
+
+		if entry.RequestName != "" {
+			tc.ClassName = entry.TestName + "/" + entry.RequestName
+		}
+
+		if entry.isFailure() {
+			tc.Failure = &junitFailure{
+				Message: strings.Join(entry.Errors, "; "),
+				Type:    entry.Type,
+				Code:    entry.Code,
+				Text:    entry.Message,
+			}
+			suite.Failures++
+			report.Failures++
+		} else if !entry.Success {
+			tc.SystemOut = entry.Severity + ": " + entry.Message
+		}
+
+		suite.Tests++
+		report.Tests++
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", defaultIndent)
+
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func writeJSONReport(w io.Writer, entries []ReportEntry) error {
+	report := struct {
+		Tests      int           `json:"tests"`
+		Failures   int           `json:"failures"`
+		Assertions []ReportEntry `json:"assertions"`
+	}{
+		Assertions: entries,
+	}
+
+	if report.Assertions == nil {
+		report.Assertions = []ReportEntry{}
+	}
+
+	for _, entry := range entries {
+		report.Tests++
+		if entry.isFailure() {
+			report.Failures++
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", defaultIndent)
+
+	return enc.Encode(report)
+}
@@ -0,0 +1,286 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportAssertionHandler(t *testing.T) {
+	fill := func(h *ReportAssertionHandler) {
+		h.Success(&AssertionContext{
+			TestName: "TestFoo",
+			Path:     []string{"Request(\"GET\")", "Expect()"},
+		})
+		h.Failure(
+			&AssertionContext{
+				TestName:    "TestFoo",
+				RequestName: "login",
+				Path:        []string{"Request(\"GET\")", "Expect()", "Status()"},
+			},
+			&AssertionFailure{
+				Type:   AssertEqual,
+				Code:   "status.mismatch",
+				Errors: []error{errors.New("unexpected status")},
+			})
+		h.Success(&AssertionContext{
+			TestName: "TestBar",
+			Path:     []string{"Value()"},
+		})
+	}
+
+	t.Run("forward", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+
+		h := &ReportAssertionHandler{
+			Handler: backend,
+		}
+
+		ctx := &AssertionContext{TestName: "test"}
+		failure := &AssertionFailure{Type: AssertValid}
+
+		h.Success(ctx)
+		assert.Equal(t, ctx, backend.ctx)
+
+		h.Failure(ctx, failure)
+		assert.Equal(t, failure, backend.failure)
+
+		assert.Equal(t, 2, len(h.Entries()))
+
+		h.Reset()
+		assert.Equal(t, 0, len(h.Entries()))
+	})
+
+	t.Run("formatter", func(t *testing.T) {
+		formatter := newMockFormatter(t)
+
+		h := &ReportAssertionHandler{
+			Formatter: formatter,
+		}
+
+		h.Failure(&AssertionContext{TestName: "test"}, &AssertionFailure{})
+
+		assert.Equal(t, 1, formatter.formattedFailure)
+		assert.Equal(t, "test", h.Entries()[0].Message)
+	})
+
+	t.Run("redaction", func(t *testing.T) {
+		rules := &RedactionRules{
+			Patterns: []*regexp.Regexp{regexp.MustCompile(`secret\w*`)},
+		}
+
+		failure := &AssertionFailure{
+			Type:   AssertEqual,
+			Actual: &AssertionValue{"secret123"},
+			Errors: []error{errors.New("unexpected value secret123")},
+		}
+
+		t.Run("own rules", func(t *testing.T) {
+			h := &ReportAssertionHandler{
+				Formatter:      &DefaultFormatter{},
+				RedactionRules: rules,
+			}
+
+			h.Failure(&AssertionContext{TestName: "test"}, failure)
+
+			entry := h.Entries()[0]
+			assert.Equal(t, []string{"unexpected value [REDACTED]"}, entry.Errors)
+			assert.NotContains(t, entry.Message, "secret")
+		})
+
+		t.Run("request rules", func(t *testing.T) {
+			config := newMockConfig(newMockReporter(t))
+			config.RedactionRules = rules
+
+			h := &ReportAssertionHandler{}
+
+			h.Failure(&AssertionContext{
+				TestName: "test",
+				Request:  NewRequestC(config, "GET", "/"),
+			}, failure)
+
+			entry := h.Entries()[0]
+			assert.Equal(t, []string{"unexpected value [REDACTED]"}, entry.Errors)
+			assert.Equal(t, "unexpected value [REDACTED]", entry.Message)
+		})
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		h := &ReportAssertionHandler{
+			Format: ReportJUnit,
+		}
+		fill(h)
+
+		var buf bytes.Buffer
+		require.NoError(t, h.Write(&buf))
+
+		t.Logf("\n%s", buf.String())
+
+		var report junitTestSuites
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+		assert.Equal(t, 3, report.Tests)
+		assert.Equal(t, 1, report.Failures)
+
+		require.Equal(t, 2, len(report.Suites))
+
+		assert.Equal(t, "TestFoo", report.Suites[0].Name)
+		assert.Equal(t, 2, report.Suites[0].Tests)
+		assert.Equal(t, 1, report.Suites[0].Failures)
+
+		require.Equal(t, 2, len(report.Suites[0].Cases))
+		assert.Nil(t, report.Suites[0].Cases[0].Failure)
+		require.NotNil(t, report.Suites[0].Cases[1].Failure)
+		assert.Equal(t, "TestFoo/login", report.Suites[0].Cases[1].ClassName)
+		assert.Equal(t, "unexpected status",
+			report.Suites[0].Cases[1].Failure.Message)
+		assert.Equal(t, "AssertEqual", report.Suites[0].Cases[1].Failure.Type)
+		assert.Equal(t, "status.mismatch", report.Suites[0].Cases[1].Failure.Code)
+
+		assert.Equal(t, "TestBar", report.Suites[1].Name)
+		assert.Equal(t, 1, report.Suites[1].Tests)
+		assert.Equal(t, 0, report.Suites[1].Failures)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		h := &ReportAssertionHandler{
+			Format: ReportJSON,
+		}
+		fill(h)
+
+		var buf bytes.Buffer
+		require.NoError(t, h.Write(&buf))
+
+		t.Logf("\n%s", buf.String())
+
+		var report struct {
+			Tests      int
+			Failures   int
+			Assertions []ReportEntry
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+
+		assert.Equal(t, 3, report.Tests)
+		assert.Equal(t, 1, report.Failures)
+		assert.Equal(t, h.Entries(), report.Assertions)
+		assert.Equal(t, "status.mismatch", report.Assertions[1].Code)
+	})
+
+	t.Run("severity", func(t *testing.T) {
+		fillSeverity := func(h *ReportAssertionHandler) {
+			for _, severity := range []AssertionSeverity{
+				SeverityError, SeverityLog, SeverityWarning,
+			} {
+				h.Failure(
+					&AssertionContext{
+						TestName: "TestFoo",
+						Path:     []string{severity.String()},
+					},
+					&AssertionFailure{
+						Type:     AssertValid,
+						Severity: severity,
+						Errors:   []error{errors.New("failed")},
+					})
+			}
+		}
+
+		t.Run("junit", func(t *testing.T) {
+			h := &ReportAssertionHandler{
+				Format: ReportJUnit,
+			}
+			fillSeverity(h)
+
+			var buf bytes.Buffer
+			require.NoError(t, h.Write(&buf))
+
+			var report junitTestSuites
+			require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+
+			assert.Equal(t, 3, report.Tests)
+			assert.Equal(t, 1, report.Failures)
+
+			require.Equal(t, 1, len(report.Suites))
+			require.Equal(t, 3, len(report.Suites[0].Cases))
+
+			assert.NotNil(t, report.Suites[0].Cases[0].Failure)
+			assert.Empty(t, report.Suites[0].Cases[0].SystemOut)
+
+			assert.Nil(t, report.Suites[0].Cases[1].Failure)
+			assert.Equal(t, "SeverityLog: failed",
+				report.Suites[0].Cases[1].SystemOut)
+
+			assert.Nil(t, report.Suites[0].Cases[2].Failure)
+			assert.Equal(t, "SeverityWarning: failed",
+				report.Suites[0].Cases[2].SystemOut)
+		})
+
+		t.Run("json", func(t *testing.T) {
+			h := &ReportAssertionHandler{
+				Format: ReportJSON,
+			}
+			fillSeverity(h)
+
+			var buf bytes.Buffer
+			require.NoError(t, h.Write(&buf))
+
+			var report struct {
+				Tests    int
+				Failures int
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+
+			assert.Equal(t, 3, report.Tests)
+			assert.Equal(t, 1, report.Failures)
+		})
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		for _, format := range []ReportFormat{ReportJUnit, ReportJSON} {
+			h := &ReportAssertionHandler{
+				Format: format,
+			}
+
+			var buf bytes.Buffer
+			assert.NoError(t, h.Write(&buf))
+			assert.NotEmpty(t, buf.String())
+		}
+	})
+
+	t.Run("bad_format", func(t *testing.T) {
+		h := &ReportAssertionHandler{
+			Format: ReportFormat(999),
+		}
+
+		var buf bytes.Buffer
+		assert.Error(t, h.Write(&buf))
+	})
+
+	t.Run("write_file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "httpexpect")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		h := &ReportAssertionHandler{
+			Format: ReportJSON,
+		}
+		fill(h)
+
+		path := filepath.Join(dir, "report.json")
+		require.NoError(t, h.WriteFile(path))
+
+		b, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "unexpected status")
+
+		assert.Error(t, h.WriteFile(filepath.Join(dir, "missing", "report.json")))
+	})
+}
@@ -0,0 +1,90 @@
+package httpexpect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SlowAssertionHandler is an AssertionHandler that warns about assertions
+// that took longer than given threshold.
+//
+// Duration of assertion is taken from AssertionContext.Duration. For
+// request assertions, like Expect, warning also includes durations of
+// request phases from AssertionContext.RequestPhases.
+//
+// Every assertion is forwarded to Handler as is.
+//
+// Example:
+//
+//	handler := &httpexpect.SlowAssertionHandler{
+//	    Handler: &httpexpect.DefaultAssertionHandler{
+//	        Formatter: &httpexpect.DefaultFormatter{},
+//	        Reporter:  httpexpect.NewAssertReporter(t),
+//	    },
+//	    Threshold: 500 * time.Millisecond,
+//	    Logger:    t,
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    AssertionHandler: handler,
+//	})
+type SlowAssertionHandler struct {
+	// Handler receives all assertions.
+	// Should not be nil.
+	Handler AssertionHandler
+
+	// Assertions that took longer than Threshold are reported to Logger.
+	// If zero or negative, nothing is reported.
+	Threshold time.Duration
+
+	// Logger is used to print warnings about slow assertions.
+	// May be nil. If nil, nothing is reported.
+	Logger Logger
+}
+
+// Success implements AssertionHandler.Success.
+func (h *SlowAssertionHandler) Success(ctx *AssertionContext) {
+	if h.Handler == nil {
+		panic("SlowAssertionHandler.Handler is nil")
+	}
+
+	h.check(ctx)
+	h.Handler.Success(ctx)
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *SlowAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if h.Handler == nil {
+		panic("SlowAssertionHandler.Handler is nil")
+	}
+
+	h.check(ctx)
+	h.Handler.Failure(ctx, failure)
+}
+
+func (h *SlowAssertionHandler) check(ctx *AssertionContext) {
+	if h.Logger == nil || h.Threshold <= 0 || ctx.Duration <= h.Threshold {
+		return
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "slow assertion: %s took %v (threshold %v)",
+		strings.Join(ctx.Path, "."), ctx.Duration, h.Threshold)
+
+	if ctx.TestName != "" {
+		fmt.Fprintf(&b, " in test %s", ctx.TestName)
+	}
+
+	if len(ctx.Path) != 0 && ctx.Path[len(ctx.Path)-1] == "Expect()" &&
+		ctx.RequestPhases != nil {
+		p := ctx.RequestPhases
+		fmt.Fprintf(&b, "; encode %v, send %v, receive %v, match %v",
+			p.Encode, p.Send, p.Receive, p.Match)
+	}
+
+	h.Logger.Logf("%s", b.String())
+}
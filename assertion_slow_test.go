@@ -0,0 +1,135 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlowAssertionHandler(t *testing.T) {
+	t.Run("forward", func(t *testing.T) {
+		backend := &mockAssertionHandler{}
+
+		h := &SlowAssertionHandler{
+			Handler: backend,
+		}
+
+		ctx := &AssertionContext{TestName: "test"}
+		failure := &AssertionFailure{Type: AssertValid}
+
+		h.Success(ctx)
+		assert.Equal(t, ctx, backend.ctx)
+
+		h.Failure(ctx, failure)
+		assert.Equal(t, failure, backend.failure)
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		h := &SlowAssertionHandler{
+			Handler:   &mockAssertionHandler{},
+			Threshold: time.Second,
+			Logger:    logger,
+		}
+
+		h.Success(&AssertionContext{
+			Path:     []string{"Value()", "Schema()"},
+			Duration: 500 * time.Millisecond,
+		})
+		assert.False(t, logger.logged)
+
+		h.Failure(&AssertionContext{
+			TestName: "TestFoo",
+			Path:     []string{"Value()", "Schema()"},
+			Duration: 2 * time.Second,
+		}, &AssertionFailure{Type: AssertMatchSchema})
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "Value().Schema()")
+		assert.Contains(t, logger.lastMessage, "2s")
+		assert.Contains(t, logger.lastMessage, "TestFoo")
+	})
+
+	t.Run("phases", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		h := &SlowAssertionHandler{
+			Handler:   &mockAssertionHandler{},
+			Threshold: time.Millisecond,
+			Logger:    logger,
+		}
+
+		h.Success(&AssertionContext{
+			Path:     []string{`Request("GET")`, "Expect()"},
+			Duration: time.Second,
+			RequestPhases: &RequestPhases{
+				Send: 900 * time.Millisecond,
+			},
+		})
+		assert.Contains(t, logger.lastMessage, "send 900ms")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		h := &SlowAssertionHandler{
+			Handler: &mockAssertionHandler{},
+			Logger:  logger,
+		}
+
+		h.Success(&AssertionContext{Duration: time.Hour})
+		assert.False(t, logger.logged)
+	})
+
+	t.Run("nil handler", func(t *testing.T) {
+		h := &SlowAssertionHandler{}
+
+		assert.Panics(t, func() {
+			h.Success(&AssertionContext{})
+		})
+		assert.Panics(t, func() {
+			h.Failure(&AssertionContext{}, &AssertionFailure{})
+		})
+	})
+}
+
+func TestSlowAssertionHandlerExpect(t *testing.T) {
+	logger := newMockLogger(t)
+
+	e := WithConfig(Config{
+		BaseURL: "http://example.com",
+		AssertionHandler: &SlowAssertionHandler{
+			Handler:   &mockAssertionHandler{},
+			Threshold: 25 * time.Millisecond,
+			Logger:    logger,
+		},
+		Client: &http.Client{
+			Transport: NewBinder(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(20 * time.Millisecond)
+					w.WriteHeader(http.StatusOK)
+				})),
+		},
+	})
+
+	req := e.GET("/").
+		WithMatcher(func(resp *Response) {
+			time.Sleep(10 * time.Millisecond)
+		})
+
+	assert.False(t, logger.logged)
+
+	resp := req.Expect()
+
+	assert.True(t, logger.logged)
+	assert.Contains(t, logger.lastMessage, `Request("GET").Expect()`)
+	assert.Contains(t, logger.lastMessage, "send ")
+
+	phases := resp.chain.context.RequestPhases
+	require.NotNil(t, phases)
+	assert.GreaterOrEqual(t, int64(phases.Send), int64(20*time.Millisecond))
+	assert.GreaterOrEqual(t, int64(phases.Match), int64(10*time.Millisecond))
+}
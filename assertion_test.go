@@ -139,6 +139,70 @@ func TestAssertionHandler(t *testing.T) {
 		assert.Nil(t, test.logger)
 		assert.True(t, test.reporter.reported)
 	})
+
+	t.Run("failure_severity_warning", func(t *testing.T) {
+		test := createTest(t, true)
+
+		test.handler.Failure(
+			&AssertionContext{
+				TestName: t.Name(),
+			},
+			&AssertionFailure{
+				Type:     AssertValid,
+				Severity: SeverityWarning,
+			})
+
+		assert.Equal(t, 0, test.formatter.formattedSuccess)
+		assert.Equal(t, 1, test.formatter.formattedFailure)
+
+		assert.True(t, test.logger.logged)
+		assert.False(t, test.reporter.reported)
+	})
+
+	t.Run("failure_severity_warning_no_logger", func(t *testing.T) {
+		test := createTest(t, false)
+
+		test.handler.Failure(
+			&AssertionContext{
+				TestName: t.Name(),
+			},
+			&AssertionFailure{
+				Type:     AssertValid,
+				Severity: SeverityWarning,
+			})
+
+		assert.Equal(t, 0, test.formatter.formattedFailure)
+
+		assert.Nil(t, test.logger)
+		assert.False(t, test.reporter.reported)
+	})
+
+	t.Run("failure_severity_warning_reporter_logger", func(t *testing.T) {
+		test := createTest(t, false)
+
+		reporter := struct {
+			*mockReporter
+			*mockLogger
+		}{
+			newMockReporter(t),
+			newMockLogger(t),
+		}
+		test.handler.Reporter = reporter
+
+		test.handler.Failure(
+			&AssertionContext{
+				TestName: t.Name(),
+			},
+			&AssertionFailure{
+				Type:     AssertValid,
+				Severity: SeverityWarning,
+			})
+
+		assert.Equal(t, 1, test.formatter.formattedFailure)
+
+		assert.True(t, reporter.logged)
+		assert.False(t, reporter.reported)
+	})
 }
 
 func TestAssertionHandlerPanics(t *testing.T) {
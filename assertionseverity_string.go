@@ -10,11 +10,12 @@ func _() {
 	var x [1]struct{}
 	_ = x[SeverityError-0]
 	_ = x[SeverityLog-1]
+	_ = x[SeverityWarning-2]
 }
 
-const _AssertionSeverity_name = "SeverityErrorSeverityLog"
+const _AssertionSeverity_name = "SeverityErrorSeverityLogSeverityWarning"
 
-var _AssertionSeverity_index = [...]uint8{0, 13, 24}
+var _AssertionSeverity_index = [...]uint8{0, 13, 24, 39}
 
 func (i AssertionSeverity) String() string {
 	if i >= AssertionSeverity(len(_AssertionSeverity_index)-1) {
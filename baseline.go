@@ -0,0 +1,398 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Baseline stores responses from a blessed run and checks that responses
+// of subsequent runs are equivalent to them.
+//
+// Baseline works like snapshot testing, but responses are organized per
+// endpoint: every response is stored in a JSON file under directory that
+// mirrors request path, named after request method and query, e.g.:
+//
+//	testdata/baseline/users/42/GET.json
+//	testdata/baseline/users/GET_limit_10.json
+//
+// If request has a name (see Request.WithName), it's used instead of query,
+// so that different requests to the same endpoint may be distinguished.
+//
+// Responses are normalized before storing: status, headers, and body are
+// stored, JSON bodies are stored structurally, and Date and Content-Length
+// headers are ignored. Other headers and JSON fields can be excluded using
+// IgnoreHeaders and IgnoreFields.
+//
+// In update mode (see WithUpdate), responses are written to baseline
+// instead of being compared. Keys, Remove, and Prune allow to manage
+// stored entries in bulk.
+//
+// Baseline is safe for concurrent use.
+//
+// Example:
+//
+//	baseline := httpexpect.NewBaseline("testdata/baseline").
+//	    WithUpdate(os.Getenv("UPDATE_BASELINE") != "").
+//	    IgnoreHeaders("X-Request-Id").
+//	    IgnoreFields("meta.generated_at")
+//
+//	e := httpexpect.Default(t, server.URL).
+//	    Matcher(baseline.Matcher())
+//
+//	e.GET("/users").WithQuery("limit", 10).Expect()
+type Baseline struct {
+	dir    string
+	update bool
+
+	ignoreHeaders map[string]bool
+	ignoreFields  [][]string
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// NewBaseline returns a new Baseline instance, which stores responses
+// in given directory.
+func NewBaseline(dir string) *Baseline {
+	return &Baseline{
+		dir: dir,
+		ignoreHeaders: map[string]bool{
+			"Date":           true,
+			"Content-Length": true,
+		},
+		seen: map[string]bool{},
+	}
+}
+
+// WithUpdate enables or disables update mode.
+//
+// In update mode, responses are written to baseline, overwriting existing
+// entries, and checks always succeed.
+func (b *Baseline) WithUpdate(update bool) *Baseline {
+	b.update = update
+	return b
+}
+
+// IgnoreHeaders excludes given headers from baseline.
+//
+// Example:
+//
+//	baseline.IgnoreHeaders("Server", "X-Request-Id")
+func (b *Baseline) IgnoreHeaders(names ...string) *Baseline {
+	for _, name := range names {
+		b.ignoreHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	return b
+}
+
+// IgnoreFields excludes given fields of JSON body from baseline.
+//
+// Field path is a dot-separated list of object keys and array indexes.
+// Asterisk matches any key or index.
+//
+// Example:
+//
+//	baseline.IgnoreFields("meta.request_id", "items.*.updated_at")
+func (b *Baseline) IgnoreFields(paths ...string) *Baseline {
+	for _, path := range paths {
+		b.ignoreFields = append(b.ignoreFields, strings.Split(path, "."))
+	}
+	return b
+}
+
+// Matcher returns a function that checks response against baseline.
+// It can be passed to Expect.Matcher or Request.WithMatcher.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, server.URL).
+//	    Matcher(baseline.Matcher())
+func (b *Baseline) Matcher() func(*Response) {
+	return func(resp *Response) {
+		resp.MatchBaseline(b)
+	}
+}
+
+// Keys returns sorted list of keys of all entries stored in baseline.
+// Key is a path of entry file relative to baseline directory, without
+// extension, e.g. "users/42/GET".
+func (b *Baseline) Keys() ([]string, error) {
+	var keys []string
+
+	err := filepath.Walk(b.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == b.dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(b.dir, path)
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, filepath.ToSlash(strings.TrimSuffix(rel, ".json")))
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Remove removes entries with given keys from baseline.
+// Missing entries are ignored.
+func (b *Baseline) Remove(keys ...string) error {
+	for _, key := range keys {
+		err := os.Remove(b.path(key))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Prune removes all entries that were not checked or updated by this
+// Baseline instance, and returns their keys.
+//
+// It's useful after a complete run in update mode, to remove entries of
+// endpoints that are no longer tested.
+func (b *Baseline) Prune() ([]string, error) {
+	keys, err := b.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	var stale []string
+	for _, key := range keys {
+		if !b.seen[key] {
+			stale = append(stale, key)
+		}
+	}
+	b.mu.Unlock()
+
+	if err := b.Remove(stale...); err != nil {
+		return nil, err
+	}
+
+	return stale, nil
+}
+
+type baselineEntry struct {
+	Request  baselineRequest `json:"request"`
+	Response interface{}     `json:"response"`
+}
+
+type baselineRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Name   string `json:"name,omitempty"`
+}
+
+// Builds entry key from request identity.
+func (b *Baseline) key(req *http.Request, name string) string {
+	var segments []string
+	for _, s := range strings.Split(req.URL.Path, "/") {
+		if s != "" {
+			segments = append(segments, captureName(s))
+		}
+	}
+
+	file := strings.ToUpper(req.Method)
+
+	if name != "" {
+		file += "_" + captureName(name)
+	} else if req.URL.RawQuery != "" {
+		file += "_" + captureName(req.URL.Query().Encode())
+	}
+
+	return strings.Join(append(segments, file), "/")
+}
+
+func (b *Baseline) path(key string) string {
+	return filepath.Join(b.dir, filepath.FromSlash(key)+".json")
+}
+
+func (b *Baseline) markSeen(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seen[key] = true
+}
+
+// MatchBaseline succeeds if response is equivalent to response stored
+// in baseline for the same request. See Baseline for details.
+//
+// If baseline is in update mode, response is stored instead.
+//
+// Example:
+//
+//	baseline := httpexpect.NewBaseline("testdata/baseline")
+//
+//	resp := e.GET("/users/42").Expect()
+//	resp.MatchBaseline(baseline)
+func (r *Response) MatchBaseline(baseline *Baseline) *Response {
+	r.chain.enter("MatchBaseline()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if baseline == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil baseline argument"),
+			},
+		})
+		return r
+	}
+
+	req := r.httpResp.Request
+	if req == nil || req.URL == nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{req},
+			Errors: []error{
+				errors.New("expected: response has associated request"),
+			},
+		})
+		return r
+	}
+
+	name := r.chain.context.RequestName
+
+	key := baseline.key(req, name)
+	path := baseline.path(key)
+
+	baseline.markSeen(key)
+
+	if r.chain.manifest != nil {
+		r.chain.manifest.addFile("baseline", path)
+	}
+
+	actual, err := canonSnapshot(
+		snapshotResponse(r.chain, r, baseline.ignoreHeaders, baseline.ignoreFields))
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to normalize response"),
+				err,
+			},
+		})
+		return r
+	}
+
+	if baseline.update {
+		entry := baselineEntry{
+			Request: baselineRequest{
+				Method: req.Method,
+				URL:    req.URL.RequestURI(),
+				Name:   name,
+			},
+			Response: actual,
+		}
+
+		if err := writeBaselineEntry(path, &entry); err != nil {
+			r.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to write baseline entry %q", key),
+					err,
+				},
+			})
+		}
+		return r
+	}
+
+	entry, err := readBaselineEntry(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = errors.New("entry not found, run in update mode to create it")
+		}
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to read baseline entry %q", key),
+				err,
+			},
+		})
+		return r
+	}
+
+	if !reflect.DeepEqual(entry.Response, actual) {
+		r.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{entry.Response},
+			Errors: []error{
+				fmt.Errorf("expected: response is equivalent to baseline entry %q", key),
+			},
+		})
+	}
+
+	return r
+}
+
+// Convert snapshot to the same representation that is read from file.
+func canonSnapshot(snapshot interface{}) (interface{}, error) {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func readBaselineEntry(path string) (*baselineEntry, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry baselineEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func writeBaselineEntry(path string, entry *baselineEntry) error {
+	b, err := json.MarshalIndent(entry, "", defaultIndent)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
@@ -0,0 +1,155 @@
+package httpexpect
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	version := "1"
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", r.URL.Query().Get("id"))
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path +
+			`","version":"` + version + `","meta":{"id":"` + r.URL.Query().Get("id") + `"}}`))
+	})
+
+	newExpect := func(t *testing.T, baseline *Baseline) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}).Matcher(baseline.Matcher())
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		baseline := NewBaseline(dir)
+		e := newExpect(t, baseline)
+
+		e.GET("/users/42").Expect().chain.assertFailed(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		baseline := NewBaseline(dir).WithUpdate(true)
+		e := newExpect(t, baseline)
+
+		e.GET("/users/42").Expect().chain.assertNotFailed(t)
+		e.GET("/users").WithQuery("limit", 10).Expect().chain.assertNotFailed(t)
+		e.GET("/users").WithName("first page").Expect().chain.assertNotFailed(t)
+		e.DELETE("/").Expect().chain.assertNotFailed(t)
+
+		keys, err := baseline.Keys()
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"DELETE",
+			"users/42/GET",
+			"users/GET_first_page",
+			"users/GET_limit_10",
+		}, keys)
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, "users", "42", "GET.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(b), `"url": "/users/42"`)
+		assert.Contains(t, string(b), `"version": "1"`)
+	})
+
+	t.Run("match", func(t *testing.T) {
+		baseline := NewBaseline(dir)
+		e := newExpect(t, baseline)
+
+		e.GET("/users/42").Expect().chain.assertNotFailed(t)
+		e.GET("/users").WithQuery("limit", 10).Expect().chain.assertNotFailed(t)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		version = "2"
+		defer func() {
+			version = "1"
+		}()
+
+		baseline := NewBaseline(dir)
+		e := newExpect(t, baseline)
+
+		e.GET("/users/42").Expect().chain.assertFailed(t)
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		baseline := NewBaseline(dir).
+			IgnoreHeaders("x-request-id").
+			IgnoreFields("meta.id")
+		e := newExpect(t, baseline)
+
+		baseline.WithUpdate(true)
+		e.GET("/users/42").WithName("volatile").WithQuery("id", "abc").
+			Expect().chain.assertNotFailed(t)
+
+		baseline.WithUpdate(false)
+		e.GET("/users/42").WithName("volatile").WithQuery("id", "def").
+			Expect().chain.assertNotFailed(t)
+
+		baseline = NewBaseline(dir)
+		e = newExpect(t, baseline)
+
+		e.GET("/users/42").WithName("volatile").WithQuery("id", "def").
+			Expect().chain.assertFailed(t)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		baseline := NewBaseline(dir)
+		e := newExpect(t, baseline)
+
+		e.GET("/users/42").Expect().chain.assertNotFailed(t)
+
+		removed, err := baseline.Prune()
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"DELETE",
+			"users/42/GET_volatile",
+			"users/GET_first_page",
+			"users/GET_limit_10",
+		}, removed)
+
+		keys, err := baseline.Keys()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"users/42/GET"}, keys)
+
+		require.NoError(t, baseline.Remove("users/42/GET", "missing"))
+
+		keys, err = baseline.Keys()
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("no dir", func(t *testing.T) {
+		baseline := NewBaseline(filepath.Join(dir, "missing"))
+
+		keys, err := baseline.Keys()
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{})
+
+		resp.MatchBaseline(nil)
+		resp.chain.assertFailed(t)
+
+		resp = NewResponse(newMockReporter(t), &http.Response{})
+
+		resp.MatchBaseline(NewBaseline(dir))
+		resp.chain.assertFailed(t)
+	})
+}
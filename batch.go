@@ -0,0 +1,626 @@
+package httpexpect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BatchFormat defines encoding of batch requests and responses.
+type BatchFormat int
+
+const (
+	// BatchMultipart encodes batch as "multipart/mixed" body, where every
+	// part has "application/http" type and holds a single HTTP request or
+	// response. This format is used by OData $batch and Google APIs.
+	BatchMultipart BatchFormat = iota
+
+	// BatchJSON encodes batch as JSON object with "requests" array, where
+	// every element has "id", "method", "url", "headers", and "body" fields.
+	// Responses are expected to be a JSON object with "responses" array
+	// (or just a JSON array), where every element has "id", "status",
+	// "headers", and "body" fields. This format is used by Microsoft Graph
+	// and similar APIs.
+	BatchJSON
+)
+
+// BatchItem defines a single operation of a batch request.
+//
+// Body may be nil, string, []byte, or any other value, which is
+// marshaled using json.Marshal().
+//
+// If ID is empty, item index (starting from 1) is used.
+type BatchItem struct {
+	ID     string
+	Method string
+	URL    string
+	Header http.Header
+	Body   interface{}
+}
+
+// WithBatch sets request body to a batch of operations, encoded
+// using given format, and sets Content-Type header accordingly.
+//
+// Use Response.Batch() to inspect responses of individual operations.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "http://example.com/$batch")
+//	req.WithBatch(BatchMultipart,
+//	    BatchItem{Method: "GET", URL: "/users/1"},
+//	    BatchItem{Method: "POST", URL: "/users", Body: map[string]interface{}{
+//	        "name": "john",
+//	    }},
+//	)
+func (r *Request) WithBatch(format BatchFormat, items ...BatchItem) *Request {
+	r.chain.enter("WithBatch()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	var (
+		contentType string
+		body        []byte
+		err         error
+	)
+
+	switch format {
+	case BatchMultipart:
+		contentType, body, err = encodeMultipartBatch(items)
+
+	case BatchJSON:
+		contentType, body, err = encodeJSONBatch(items)
+
+	default:
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected batch format %d", format),
+			},
+		})
+		return r
+	}
+
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{items},
+			Errors: []error{
+				errors.New("invalid batch items"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType("WithBatch()", contentType, false)
+	r.setBody("WithBatch()", bytes.NewReader(body), len(body), false)
+
+	return r
+}
+
+func batchItemID(item BatchItem, index int) string {
+	if item.ID != "" {
+		return item.ID
+	}
+	return strconv.Itoa(index + 1)
+}
+
+func batchItemBody(item BatchItem) ([]byte, bool, error) {
+	switch body := item.Body.(type) {
+	case nil:
+		return nil, false, nil
+
+	case string:
+		return []byte(body), false, nil
+
+	case []byte:
+		return body, false, nil
+
+	default:
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, false, err
+		}
+		return b, true, nil
+	}
+}
+
+func encodeMultipartBatch(items []BatchItem) (string, []byte, error) {
+	var buf bytes.Buffer
+
+	mw := multipart.NewWriter(&buf)
+
+	for n, item := range items {
+		body, isJSON, err := batchItemBody(item)
+		if err != nil {
+			return "", nil, err
+		}
+
+		partHeader := textproto.MIMEHeader{}
+		partHeader.Set("Content-Type", "application/http")
+		partHeader.Set("Content-Transfer-Encoding", "binary")
+		partHeader.Set("Content-ID", "<"+batchItemID(item, n)+">")
+
+		pw, err := mw.CreatePart(partHeader)
+		if err != nil {
+			return "", nil, err
+		}
+
+		method := item.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+
+		header := item.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		if isJSON && header.Get("Content-Type") == "" {
+			header.Set("Content-Type", "application/json; charset=utf-8")
+		}
+		if len(body) != 0 && header.Get("Content-Length") == "" {
+			header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+
+		if _, err := fmt.Fprintf(pw, "%s %s HTTP/1.1\r\n", method, item.URL); err != nil {
+			return "", nil, err
+		}
+		if err := header.Write(pw); err != nil {
+			return "", nil, err
+		}
+		if _, err := io.WriteString(pw, "\r\n"); err != nil {
+			return "", nil, err
+		}
+		if _, err := pw.Write(body); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", nil, err
+	}
+
+	return "multipart/mixed; boundary=" + mw.Boundary(), buf.Bytes(), nil
+}
+
+type jsonBatchRequest struct {
+	ID      string            `json:"id"`
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+func encodeJSONBatch(items []BatchItem) (string, []byte, error) {
+	requests := []jsonBatchRequest{}
+
+	for n, item := range items {
+		body, isJSON, err := batchItemBody(item)
+		if err != nil {
+			return "", nil, err
+		}
+
+		req := jsonBatchRequest{
+			ID:     batchItemID(item, n),
+			Method: item.Method,
+			URL:    item.URL,
+		}
+
+		if req.Method == "" {
+			req.Method = http.MethodGet
+		}
+
+		for k, v := range item.Header {
+			if req.Headers == nil {
+				req.Headers = map[string]string{}
+			}
+			req.Headers[k] = strings.Join(v, ", ")
+		}
+
+		if body != nil {
+			if isJSON {
+				req.Body = body
+				if _, ok := req.Headers["Content-Type"]; !ok {
+					if req.Headers == nil {
+						req.Headers = map[string]string{}
+					}
+					req.Headers["Content-Type"] = "application/json"
+				}
+			} else {
+				req.Body, err = json.Marshal(string(body))
+				if err != nil {
+					return "", nil, err
+				}
+			}
+		}
+
+		requests = append(requests, req)
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"requests": requests,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "application/json; charset=utf-8", b, nil
+}
+
+// Batch provides methods to inspect responses of individual operations
+// of a batch request.
+//
+// Each operation response is returned as a regular Response, so all
+// Response assertions can be used on it.
+type Batch struct {
+	config Config
+	chain  *chain
+	items  []batchResponseItem
+}
+
+type batchResponseItem struct {
+	id   string
+	resp *http.Response
+}
+
+func newBatch(parent *chain, config Config, items []batchResponseItem) *Batch {
+	return &Batch{
+		config: config,
+		chain:  parent.clone(),
+		items:  items,
+	}
+}
+
+// Batch decodes response body as a batch response and returns a new
+// Batch instance for it.
+//
+// Both multipart ("multipart/mixed" with "application/http" parts,
+// including nested OData changesets) and JSON batch responses are
+// supported. Format is detected using Content-Type header.
+//
+// Example:
+//
+//	batch := resp.Batch()
+//	batch.Length().Equal(2)
+//	batch.Item(0).Status(http.StatusOK).JSON().Object().ValueEqual("id", 1)
+//	batch.ItemByID("2").Status(http.StatusCreated)
+func (r *Response) Batch() *Batch {
+	r.chain.enter("Batch()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newBatch(r.chain, r.config, nil)
+	}
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+
+	var (
+		items []batchResponseItem
+		err   error
+	)
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		items, err = decodeMultipartBatch(r.readContent(), params["boundary"])
+
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		items, err = decodeJSONBatch(r.readContent())
+
+	default:
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: []error{
+				errors.New(
+					"expected: multipart or json batch response content type"),
+			},
+		})
+		return newBatch(r.chain, r.config, nil)
+	}
+
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(r.readContent())},
+			Errors: []error{
+				errors.New("failed to decode batch response"),
+				err,
+			},
+		})
+		return newBatch(r.chain, r.config, nil)
+	}
+
+	return newBatch(r.chain, r.config, items)
+}
+
+func decodeMultipartBatch(content []byte, boundary string) ([]batchResponseItem, error) {
+	if boundary == "" {
+		return nil, errors.New("missing multipart boundary")
+	}
+
+	var items []batchResponseItem
+
+	reader := multipart.NewReader(bytes.NewReader(content), boundary)
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		mediaType, params, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+
+		if strings.HasPrefix(mediaType, "multipart/") {
+			data, err := ioutil.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			nested, err := decodeMultipartBatch(data, params["boundary"])
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, nested...)
+			continue
+		}
+
+		data, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		// Line break preceding boundary belongs to the boundary, so if
+		// the message has no body, its header block may be unterminated.
+		if !bytes.Contains(data, []byte("\r\n\r\n")) &&
+			!bytes.Contains(data, []byte("\n\n")) {
+			data = append(data, "\r\n"...)
+		}
+
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		id := strings.TrimSpace(part.Header.Get("Content-ID"))
+		id = strings.TrimSuffix(strings.TrimPrefix(id, "<"), ">")
+
+		items = append(items, batchResponseItem{
+			id:   id,
+			resp: resp,
+		})
+	}
+
+	return items, nil
+}
+
+type jsonBatchResponse struct {
+	ID      interface{}            `json:"id"`
+	Status  int                    `json:"status"`
+	Headers map[string]interface{} `json:"headers"`
+	Body    json.RawMessage        `json:"body"`
+}
+
+func decodeJSONBatch(content []byte) ([]batchResponseItem, error) {
+	var responses []jsonBatchResponse
+
+	if trimmed := bytes.TrimSpace(content); len(trimmed) != 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &responses); err != nil {
+			return nil, err
+		}
+	} else {
+		var wrapper struct {
+			Responses *[]jsonBatchResponse `json:"responses"`
+		}
+		if err := json.Unmarshal(content, &wrapper); err != nil {
+			return nil, err
+		}
+		if wrapper.Responses == nil {
+			return nil, errors.New(`missing "responses" field`)
+		}
+		responses = *wrapper.Responses
+	}
+
+	var items []batchResponseItem
+
+	for _, r := range responses {
+		header := http.Header{}
+
+		keys := make([]string, 0, len(r.Headers))
+		for k := range r.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			switch v := r.Headers[k].(type) {
+			case []interface{}:
+				for _, e := range v {
+					header.Add(k, fmt.Sprint(e))
+				}
+			default:
+				header.Add(k, fmt.Sprint(v))
+			}
+		}
+
+		var body []byte
+
+		if len(r.Body) != 0 && string(r.Body) != "null" {
+			var s string
+			if json.Unmarshal(r.Body, &s) == nil {
+				body = []byte(s)
+			} else {
+				body = r.Body
+				if header.Get("Content-Type") == "" {
+					header.Set("Content-Type", "application/json")
+				}
+			}
+		}
+
+		var id string
+		switch v := r.ID.(type) {
+		case nil:
+		case string:
+			id = v
+		default:
+			id = fmt.Sprint(v)
+		}
+
+		items = append(items, batchResponseItem{
+			id: id,
+			resp: &http.Response{
+				Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+				StatusCode:    r.Status,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        header,
+				Body:          ioutil.NopCloser(bytes.NewReader(body)),
+				ContentLength: int64(len(body)),
+			},
+		})
+	}
+
+	return items, nil
+}
+
+// Raw returns underlying http.Response objects of batch items.
+func (b *Batch) Raw() []*http.Response {
+	var ret []*http.Response
+	for _, item := range b.items {
+		ret = append(ret, item.resp)
+	}
+	return ret
+}
+
+// Length returns a new Number instance with number of items in batch.
+//
+// Example:
+//
+//	batch := resp.Batch()
+//	batch.Length().Equal(3)
+func (b *Batch) Length() *Number {
+	b.chain.enter("Length()")
+	defer b.chain.leave()
+
+	if b.chain.failed() {
+		return newNumber(b.chain, 0)
+	}
+
+	return newNumber(b.chain, float64(len(b.items)))
+}
+
+// Item returns a new Response instance for batch item with given index.
+//
+// If index is out of bounds, Item reports failure and returns empty
+// (but non-nil) instance.
+//
+// Example:
+//
+//	batch := resp.Batch()
+//	batch.Item(0).Status(http.StatusOK)
+func (b *Batch) Item(index int) *Response {
+	b.chain.enter("Item(%d)", index)
+	defer b.chain.leave()
+
+	if b.chain.failed() {
+		return b.newItem(nil)
+	}
+
+	if index < 0 || index >= len(b.items) {
+		b.chain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(b.items) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid batch item index"),
+			},
+		})
+		return b.newItem(nil)
+	}
+
+	return b.newItem(b.items[index].resp)
+}
+
+// ItemByID returns a new Response instance for batch item with given ID.
+//
+// For multipart batches, ID is taken from Content-ID header of the part.
+// Since servers often prefix Content-ID of responses with "response-",
+// such prefix is allowed as well.
+//
+// If there is no such item, ItemByID reports failure and returns empty
+// (but non-nil) instance.
+//
+// Example:
+//
+//	batch := resp.Batch()
+//	batch.ItemByID("2").Status(http.StatusCreated)
+func (b *Batch) ItemByID(id string) *Response {
+	b.chain.enter("ItemByID(%q)", id)
+	defer b.chain.leave()
+
+	if b.chain.failed() {
+		return b.newItem(nil)
+	}
+
+	for _, item := range b.items {
+		if item.id == id || item.id == "response-"+id {
+			return b.newItem(item.resp)
+		}
+	}
+
+	b.chain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{b.itemIDs()},
+		Expected: &AssertionValue{id},
+		Errors: []error{
+			errors.New("expected: batch contains item with given id"),
+		},
+	})
+
+	return b.newItem(nil)
+}
+
+func (b *Batch) newItem(resp *http.Response) *Response {
+	return newResponse(responseOpts{
+		config:   b.config,
+		chain:    b.chain,
+		httpResp: resp,
+	})
+}
+
+func (b *Batch) itemIDs() []interface{} {
+	ids := []interface{}{}
+	for _, item := range b.items {
+		ids = append(ids, item.id)
+	}
+	return ids
+}
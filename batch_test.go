@@ -0,0 +1,306 @@
+package httpexpect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	batch := newBatch(chain, newMockConfig(newMockReporter(t)), nil)
+
+	assert.NotNil(t, batch.Length())
+	assert.NotNil(t, batch.Item(0))
+	assert.NotNil(t, batch.ItemByID("1"))
+	assert.Nil(t, batch.Raw())
+
+	batch.chain.assertFailed(t)
+}
+
+func TestBatchRequestMultipart(t *testing.T) {
+	reporter := newMockReporter(t)
+	config := newMockConfig(reporter)
+
+	req := NewRequestC(config, "POST", "http://example.com/$batch")
+	req.WithBatch(BatchMultipart,
+		BatchItem{Method: "GET", URL: "/users/1"},
+		BatchItem{ID: "create", Method: "POST", URL: "/users",
+			Body: map[string]interface{}{"name": "john"}},
+	)
+	req.chain.assertNotFailed(t)
+
+	mediaType, params, err := mime.ParseMediaType(
+		req.httpReq.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	body, err := ioutil.ReadAll(req.httpReq.Body)
+	require.NoError(t, err)
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+
+	part, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "application/http", part.Header.Get("Content-Type"))
+	assert.Equal(t, "<1>", part.Header.Get("Content-ID"))
+
+	httpReq, err := http.ReadRequest(bufio.NewReader(part))
+	require.NoError(t, err)
+	assert.Equal(t, "GET", httpReq.Method)
+	assert.Equal(t, "/users/1", httpReq.URL.String())
+
+	part, err = reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "<create>", part.Header.Get("Content-ID"))
+
+	httpReq, err = http.ReadRequest(bufio.NewReader(part))
+	require.NoError(t, err)
+	assert.Equal(t, "POST", httpReq.Method)
+	assert.Equal(t, "application/json; charset=utf-8",
+		httpReq.Header.Get("Content-Type"))
+
+	reqBody, err := ioutil.ReadAll(httpReq.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"john"}`, string(reqBody))
+}
+
+func TestBatchRequestJSON(t *testing.T) {
+	reporter := newMockReporter(t)
+	config := newMockConfig(reporter)
+
+	req := NewRequestC(config, "POST", "http://example.com/$batch")
+	req.WithBatch(BatchJSON,
+		BatchItem{URL: "/me", Header: http.Header{"Accept": {"text/plain"}}},
+		BatchItem{ID: "b", Method: "PATCH", URL: "/users/1",
+			Body: map[string]interface{}{"name": "john"}},
+		BatchItem{Method: "PUT", URL: "/notes/1", Body: "text"},
+	)
+	req.chain.assertNotFailed(t)
+
+	assert.Equal(t, "application/json; charset=utf-8",
+		req.httpReq.Header.Get("Content-Type"))
+
+	body, err := ioutil.ReadAll(req.httpReq.Body)
+	require.NoError(t, err)
+
+	var actual interface{}
+	require.NoError(t, json.Unmarshal(body, &actual))
+
+	assert.Equal(t, map[string]interface{}{
+		"requests": []interface{}{
+			map[string]interface{}{
+				"id":      "1",
+				"method":  "GET",
+				"url":     "/me",
+				"headers": map[string]interface{}{"Accept": "text/plain"},
+			},
+			map[string]interface{}{
+				"id":      "b",
+				"method":  "PATCH",
+				"url":     "/users/1",
+				"headers": map[string]interface{}{"Content-Type": "application/json"},
+				"body":    map[string]interface{}{"name": "john"},
+			},
+			map[string]interface{}{
+				"id":     "3",
+				"method": "PUT",
+				"url":    "/notes/1",
+				"body":   "text",
+			},
+		},
+	}, actual)
+}
+
+func TestBatchRequestErrors(t *testing.T) {
+	t.Run("bad_format", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, "POST", "http://example.com")
+		req.WithBatch(BatchFormat(-1))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("bad_body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, "POST", "http://example.com")
+		req.WithBatch(BatchJSON, BatchItem{Body: func() {}})
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestBatchResponseMultipart(t *testing.T) {
+	body := "--batch\r\n" +
+		"Content-Type: application/http\r\n" +
+		"Content-ID: <response-1>\r\n" +
+		"\r\n" +
+		"HTTP/1.1 200 OK\r\n" +
+		"Content-Type: application/json\r\n" +
+		"\r\n" +
+		`{"id":1}` + "\r\n" +
+		"--batch\r\n" +
+		"Content-Type: multipart/mixed; boundary=changeset\r\n" +
+		"\r\n" +
+		"--changeset\r\n" +
+		"Content-Type: application/http\r\n" +
+		"Content-ID: <2>\r\n" +
+		"\r\n" +
+		"HTTP/1.1 201 Created\r\n" +
+		"Location: /users/2\r\n" +
+		"\r\n" +
+		"--changeset--\r\n" +
+		"--batch--\r\n"
+
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"multipart/mixed; boundary=batch"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader(body)),
+	})
+
+	batch := resp.Batch()
+	batch.chain.assertNotFailed(t)
+
+	assert.Equal(t, 2, len(batch.Raw()))
+
+	batch.Length().Equal(2)
+
+	batch.Item(0).Status(http.StatusOK).
+		JSON().Object().ValueEqual("id", 1)
+
+	batch.ItemByID("1").Status(http.StatusOK)
+	batch.ItemByID("2").Status(http.StatusCreated).
+		Header("Location").Equal("/users/2")
+
+	batch.chain.assertNotFailed(t)
+
+	batch.Item(2)
+	batch.chain.assertFailed(t)
+	batch.chain.clearFailed()
+
+	batch.ItemByID("3")
+	batch.chain.assertFailed(t)
+}
+
+func TestBatchResponseJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "object",
+			body: `{"responses": [
+				{"id": "1", "status": 200, "body": {"name": "john"}},
+				{"id": 2, "status": 204, "headers": {"X-Foo": "bar"}},
+				{"id": "3", "status": 400, "body": "bad request",
+					"headers": {"Content-Type": "text/plain"}}
+			]}`,
+		},
+		{
+			name: "array",
+			body: `[
+				{"id": "1", "status": 200, "body": {"name": "john"}},
+				{"id": 2, "status": 204, "headers": {"X-Foo": ["bar"]}},
+				{"id": "3", "status": 400, "body": "bad request",
+					"headers": {"Content-Type": "text/plain"}}
+			]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(strings.NewReader(tc.body)),
+			})
+
+			batch := resp.Batch()
+			batch.Length().Equal(3)
+
+			batch.Item(0).Status(http.StatusOK).
+				JSON().Object().ValueEqual("name", "john")
+
+			batch.ItemByID("2").Status(http.StatusNoContent).
+				Header("X-Foo").Equal("bar")
+
+			batch.ItemByID("3").Status(http.StatusBadRequest).
+				Text().Equal("bad request")
+
+			batch.chain.assertNotFailed(t)
+		})
+	}
+}
+
+func TestBatchResponseErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "bad_content_type",
+			contentType: "text/plain",
+			body:        "",
+		},
+		{
+			name:        "no_boundary",
+			contentType: "multipart/mixed",
+			body:        "",
+		},
+		{
+			name:        "bad_part",
+			contentType: "multipart/mixed; boundary=batch",
+			body:        "--batch\r\n\r\ngarbage\r\n--batch--\r\n",
+		},
+		{
+			name:        "bad_json",
+			contentType: "application/json",
+			body:        "{",
+		},
+		{
+			name:        "no_responses",
+			contentType: "application/json",
+			body:        `{"foo": []}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {tc.contentType},
+				},
+				Body: ioutil.NopCloser(strings.NewReader(tc.body)),
+			})
+
+			batch := resp.Batch()
+			batch.chain.assertFailed(t)
+			resp.chain.assertFailed(t)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+// Bench runs requests in a benchmark, see Expect.Bench.
+type Bench struct {
+	chain  *chain
+	expect *Expect
+	b      *testing.B
+
+	requests int64
+}
+
+// Maximum number of idle connections per host kept by benchmark client.
+const benchIdleConns = 100
+
+// Bench returns a new Bench instance, that allows to benchmark API
+// handlers through the same fluent interface.
+//
+// Requests are made using a copy of Expect, prepared for benchmarking:
+//
+//   - Config.TestName and Reporter are bound to b, like in WithTestName
+//   - Printers are disabled, so that logging doesn't affect results
+//   - if Client is *http.Client with *http.Transport (or default
+//     transport), transport is cloned and allowed to keep enough idle
+//     connections, so that connections are reused between iterations
+//
+// Besides standard allocs/op, Bench reports "req/op", "allocs/req",
+// and "B/req" metrics, so that results are comparable regardless of
+// how many requests every iteration makes.
+//
+// Example:
+//
+//	func BenchmarkGetUser(b *testing.B) {
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        Reporter: httpexpect.NewRequireReporter(b),
+//	        Client: &http.Client{
+//	            Transport: httpexpect.NewBinder(handler),
+//	        },
+//	    })
+//
+//	    e.Bench(b).Run(func(e *httpexpect.Expect) {
+//	        e.GET("/users/john").
+//	            Expect().
+//	            Status(http.StatusOK)
+//	    })
+//	}
+func (e *Expect) Bench(b *testing.B) *Bench {
+	e.chain.enter("Bench()")
+	defer e.chain.leave()
+
+	bn := &Bench{
+		chain: e.chain.clone(),
+		b:     b,
+	}
+
+	if e.chain.failed() {
+		bn.expect = e
+		return bn
+	}
+
+	if b == nil {
+		bn.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		bn.expect = e
+		return bn
+	}
+
+	be := e.WithTestName(b)
+
+	be.config.Printers = nil
+	be.config.Client = benchClient(be.config.Client)
+
+	bn.expect = be.Builder(func(*Request) {
+		atomic.AddInt64(&bn.requests, 1)
+	})
+
+	return bn
+}
+
+// Run invokes fn b.N times and reports benchmark metrics.
+//
+// If benchmark fails, remaining iterations are skipped.
+//
+// Example:
+//
+//	e.Bench(b).Run(func(e *httpexpect.Expect) {
+//	    e.GET("/users").Expect().Status(http.StatusOK)
+//	})
+func (bn *Bench) Run(fn func(e *Expect)) *Bench {
+	bn.chain.enter("Run()")
+	defer bn.chain.leave()
+
+	if bn.chain.failed() {
+		return bn
+	}
+
+	if fn == nil {
+		bn.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return bn
+	}
+
+	bn.measure(func() {
+		for i := 0; i < bn.b.N && !bn.b.Failed(); i++ {
+			fn(bn.expect)
+		}
+	})
+
+	return bn
+}
+
+// RunParallel invokes fn b.N times in parallel, using testing.B.RunParallel,
+// and reports benchmark metrics.
+//
+// Every goroutine gets its own copy of Expect.
+// If benchmark fails, remaining iterations are skipped.
+//
+// Example:
+//
+//	e.Bench(b).RunParallel(func(e *httpexpect.Expect) {
+//	    e.GET("/users").Expect().Status(http.StatusOK)
+//	})
+func (bn *Bench) RunParallel(fn func(e *Expect)) *Bench {
+	bn.chain.enter("RunParallel()")
+	defer bn.chain.leave()
+
+	if bn.chain.failed() {
+		return bn
+	}
+
+	if fn == nil {
+		bn.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return bn
+	}
+
+	bn.measure(func() {
+		bn.b.RunParallel(func(pb *testing.PB) {
+			e := bn.expect.clone()
+			e.chain = bn.expect.chain.clone()
+
+			for pb.Next() {
+				if !bn.b.Failed() {
+					fn(e)
+				}
+			}
+		})
+	})
+
+	return bn
+}
+
+func (bn *Bench) measure(run func()) {
+	atomic.StoreInt64(&bn.requests, 0)
+
+	bn.b.ReportAllocs()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	bn.b.ResetTimer()
+	run()
+	bn.b.StopTimer()
+
+	runtime.ReadMemStats(&after)
+
+	requests := atomic.LoadInt64(&bn.requests)
+	if requests == 0 || bn.b.N == 0 {
+		return
+	}
+
+	bn.b.ReportMetric(float64(requests)/float64(bn.b.N), "req/op")
+	bn.b.ReportMetric(
+		float64(after.Mallocs-before.Mallocs)/float64(requests), "allocs/req")
+	bn.b.ReportMetric(
+		float64(after.TotalAlloc-before.TotalAlloc)/float64(requests), "B/req")
+}
+
+// Clone client with transport that keeps enough idle connections
+// for concurrent benchmarks.
+func benchClient(client Client) Client {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return client
+	}
+
+	var transport *http.Transport
+
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		if transport, ok = http.DefaultTransport.(*http.Transport); !ok {
+			return client
+		}
+		transport = transport.Clone()
+
+	case *http.Transport:
+		transport = t.Clone()
+
+	default:
+		return client
+	}
+
+	if transport.MaxIdleConns != 0 && transport.MaxIdleConns < benchIdleConns {
+		transport.MaxIdleConns = benchIdleConns
+	}
+	if transport.MaxIdleConnsPerHost < benchIdleConns {
+		transport.MaxIdleConnsPerHost = benchIdleConns
+	}
+
+	clientCopy := *httpClient
+	clientCopy.Transport = transport
+
+	return &clientCopy
+}
@@ -0,0 +1,187 @@
+package httpexpect
+
+import (
+	"flag"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setBenchTime(t *testing.T, value string) {
+	f := flag.Lookup("test.benchtime")
+	require.NotNil(t, f)
+
+	prev := f.Value.String()
+	require.NoError(t, f.Value.Set(value))
+
+	t.Cleanup(func() {
+		_ = f.Value.Set(prev)
+	})
+}
+
+func TestBenchRun(t *testing.T) {
+	setBenchTime(t, "20x")
+
+	var calls int32
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	printer := &mockPrinter{}
+
+	cases := []struct {
+		name string
+		run  func(bn *Bench, fn func(e *Expect)) *Bench
+	}{
+		{
+			name: "sequential",
+			run: func(bn *Bench, fn func(e *Expect)) *Bench {
+				return bn.Run(fn)
+			},
+		},
+		{
+			name: "parallel",
+			run: func(bn *Bench, fn func(e *Expect)) *Bench {
+				return bn.RunParallel(fn)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+
+			var bn *Bench
+
+			result := testing.Benchmark(func(b *testing.B) {
+				e := WithConfig(Config{
+					BaseURL:  "http://example.com",
+					Reporter: NewAssertReporter(t),
+					Printers: []Printer{printer},
+					Client: &http.Client{
+						Transport: NewBinder(handler),
+					},
+				})
+
+				bn = tc.run(e.Bench(b), func(e *Expect) {
+					e.GET("/a").Expect().Status(http.StatusOK)
+					e.GET("/b").Expect().Status(http.StatusOK)
+				})
+
+				assert.Equal(t, b.Name(), bn.expect.config.TestName)
+			})
+
+			require.NotNil(t, bn)
+			bn.chain.assertNotFailed(t)
+
+			// benchmark is run once with N=1, then with requested N
+			assert.Equal(t, 20, result.N)
+			assert.Equal(t, int32(2*(1+20)), atomic.LoadInt32(&calls))
+
+			assert.Equal(t, 2.0, result.Extra["req/op"])
+			assert.Greater(t, result.Extra["allocs/req"], 0.0)
+			assert.Greater(t, result.Extra["B/req"], 0.0)
+
+			assert.Nil(t, printer.reqBody)
+		})
+	}
+}
+
+func TestBenchFailure(t *testing.T) {
+	setBenchTime(t, "20x")
+
+	var calls int32
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	result := testing.Benchmark(func(b *testing.B) {
+		// reporter is rebound to b
+		e := WithConfig(Config{
+			Reporter: NewAssertReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		e.Bench(b).Run(func(e *Expect) {
+			e.GET("/").Expect().Status(http.StatusOK)
+		})
+	})
+
+	// failed benchmark is stopped after first failure
+	assert.Equal(t, 0, result.N)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestBenchUsage(t *testing.T) {
+	setBenchTime(t, "1x")
+
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+		Client:   &mockClient{},
+	})
+
+	bn := e.Bench(nil)
+	bn.chain.assertFailed(t)
+
+	bn.Run(func(e *Expect) {
+		t.Fatal("unexpected call")
+	})
+
+	result := testing.Benchmark(func(b *testing.B) {
+		bn := e.Bench(b)
+		bn.chain.assertNotFailed(t)
+
+		bn.Run(nil)
+		bn.chain.assertFailed(t)
+
+		bn.RunParallel(nil)
+	})
+
+	assert.NotNil(t, result)
+}
+
+func TestBenchClient(t *testing.T) {
+	t.Run("default transport", func(t *testing.T) {
+		client := &http.Client{}
+
+		bc, ok := benchClient(client).(*http.Client)
+		require.True(t, ok)
+
+		assert.True(t, client != bc)
+		assert.Nil(t, client.Transport)
+
+		tr, ok := bc.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, benchIdleConns, tr.MaxIdleConnsPerHost)
+	})
+
+	t.Run("custom transport", func(t *testing.T) {
+		orig := &http.Transport{MaxIdleConnsPerHost: 2, MaxIdleConns: 10}
+		client := &http.Client{Transport: orig}
+
+		bc := benchClient(client).(*http.Client)
+
+		tr := bc.Transport.(*http.Transport)
+		assert.True(t, orig != tr)
+		assert.Equal(t, benchIdleConns, tr.MaxIdleConnsPerHost)
+		assert.Equal(t, benchIdleConns, tr.MaxIdleConns)
+		assert.Equal(t, 2, orig.MaxIdleConnsPerHost)
+	})
+
+	t.Run("other transport", func(t *testing.T) {
+		client := &http.Client{Transport: NewBinder(http.NotFoundHandler())}
+		assert.Same(t, client, benchClient(client))
+
+		mc := &mockClient{}
+		assert.Same(t, mc, benchClient(mc))
+	})
+}
@@ -20,6 +20,12 @@ import (
 // Binder emulates network communication by invoking given http.Handler
 // directly. It passes httptest.ResponseRecorder as http.ResponseWriter
 // to the handler, and then constructs http.Response from recorded data.
+//
+// Response has the same protocol version as request, and trailers set by
+// handler are available in http.Response.Trailer. If request protocol
+// version is HTTP/2 or later (see Request.WithProto), Binder emulates its
+// semantics: connection-specific headers are removed from request and
+// response, and chunked Transfer-Encoding is never used.
 type Binder struct {
 	// HTTP handler invoked for every request.
 	Handler http.Handler
@@ -46,8 +52,12 @@ func (binder Binder) RoundTrip(origReq *http.Request) (*http.Response, error) {
 		req.Proto = fmt.Sprintf("HTTP/%d.%d", req.ProtoMajor, req.ProtoMinor)
 	}
 
+	if req.ProtoMajor >= 2 {
+		req.Header = removeConnectionHeaders(req.Header)
+	}
+
 	if req.Body != nil && req.Body != http.NoBody {
-		if req.ContentLength == -1 {
+		if req.ContentLength == -1 && req.ProtoMajor < 2 {
 			req.TransferEncoding = []string{"chunked"}
 		}
 	} else {
@@ -66,14 +76,29 @@ func (binder Binder) RoundTrip(origReq *http.Request) (*http.Response, error) {
 
 	binder.Handler.ServeHTTP(recorder, &req)
 
+	result := recorder.Result()
+
 	resp := http.Response{
 		Request:    &req,
+		Proto:      req.Proto,
+		ProtoMajor: req.ProtoMajor,
+		ProtoMinor: req.ProtoMinor,
 		StatusCode: recorder.Code,
 		Status:     http.StatusText(recorder.Code),
-		Header:     recorder.Result().Header,
+		Header:     result.Header,
 	}
 
-	if recorder.Flushed {
+	if len(result.Trailer) != 0 {
+		// like http.Transport, move declared trailers from header
+		resp.Trailer = result.Trailer
+		resp.Header.Del("Trailer")
+	}
+
+	if req.ProtoMajor >= 2 {
+		// HTTP/2 and later don't use connection-specific headers and
+		// chunked encoding; body is always streamed in frames
+		resp.Header = removeConnectionHeaders(resp.Header)
+	} else if recorder.Flushed {
 		resp.TransferEncoding = []string{"chunked"}
 	}
 
@@ -84,6 +109,25 @@ func (binder Binder) RoundTrip(origReq *http.Request) (*http.Response, error) {
 	return &resp, nil
 }
 
+// Connection-specific headers, prohibited in HTTP/2 (RFC 9113, 8.2.2).
+var connectionHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Connection",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+func removeConnectionHeaders(header http.Header) http.Header {
+	header = header.Clone()
+
+	for _, name := range connectionHeaders {
+		header.Del(name)
+	}
+
+	return header
+}
+
 // FastBinder implements networkless http.RoundTripper attached directly
 // to fasthttp.RequestHandler.
 //
@@ -167,6 +167,75 @@ func TestBinderChunked(t *testing.T) {
 	assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
 }
 
+func TestBinderProtocol(t *testing.T) {
+	var gotReq *http.Request
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotReq = r
+
+		w.Header().Set("Trailer", "X-Checksum")
+		w.Header().Set("Connection", "close")
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("hello"))
+		w.(http.Flusher).Flush()
+		w.Header().Set("X-Checksum", "123")
+	})
+
+	client := &http.Client{
+		Transport: NewBinder(handler),
+	}
+
+	t.Run("http1", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "http://example.com/path", nil)
+		req.Header.Set("Connection", "keep-alive")
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "HTTP/1.1", resp.Proto)
+		assert.Equal(t, 1, resp.ProtoMajor)
+		assert.Equal(t, 1, resp.ProtoMinor)
+
+		assert.Equal(t, "keep-alive", gotReq.Header.Get("Connection"))
+		assert.Equal(t, "close", resp.Header.Get("Connection"))
+		assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+
+		assert.Equal(t, "", resp.Header.Get("Trailer"))
+		assert.Equal(t, http.Header{"X-Checksum": {"123"}}, resp.Trailer)
+	})
+
+	t.Run("http2", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "http://example.com/path",
+			strings.NewReader("body"))
+		req.Proto = "HTTP/2.0"
+		req.ProtoMajor = 2
+		req.ProtoMinor = 0
+		req.ContentLength = -1
+		req.Header.Set("Connection", "keep-alive")
+		req.Header.Set("X-Custom", "value")
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "HTTP/2.0", gotReq.Proto)
+		assert.Equal(t, "", gotReq.Header.Get("Connection"))
+		assert.Equal(t, "value", gotReq.Header.Get("X-Custom"))
+		assert.Nil(t, gotReq.TransferEncoding)
+
+		assert.Equal(t, "keep-alive", req.Header.Get("Connection"))
+
+		assert.Equal(t, "HTTP/2.0", resp.Proto)
+		assert.Equal(t, 2, resp.ProtoMajor)
+		assert.Equal(t, 0, resp.ProtoMinor)
+
+		assert.Equal(t, "", resp.Header.Get("Connection"))
+		assert.Equal(t, "text/plain", resp.Header.Get("Content-Type"))
+		assert.Nil(t, resp.TransferEncoding)
+
+		assert.Equal(t, http.Header{"X-Checksum": {"123"}}, resp.Trailer)
+	})
+}
+
 func TestFastBinder(t *testing.T) {
 	handler := func(ctx *fasthttp.RequestCtx) {
 		assert.Equal(t, "POST", string(ctx.Request.Header.Method()))
@@ -0,0 +1,479 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BodyStream provides methods to incrementally read and inspect response
+// body, without waiting until the whole body is received.
+//
+// It's useful for endpoints that stream data (e.g. NDJSON or server-sent
+// events) or use long polling. To prevent buffering of response body,
+// the request should be sent with Request.WithStreaming.
+//
+// Every read waits for data no longer than timeout set by WithTimeout.
+// By default there is no per-read timeout, but request timeout still
+// applies.
+//
+// All data read from BodyStream is also retained by Response, so calling
+// Response.Body or other body methods later reads the rest of the stream
+// and returns the whole body.
+type BodyStream struct {
+	chain   *chain
+	resp    *Response
+	reader  *streamReader
+	timeout time.Duration
+}
+
+func newBodyStream(parent *chain, resp *Response, reader *streamReader) *BodyStream {
+	return &BodyStream{
+		chain:  parent.clone(),
+		resp:   resp,
+		reader: reader,
+	}
+}
+
+// BodyStream returns a new BodyStream instance for incremental reading of
+// response body.
+//
+// If the request was sent with Request.WithStreaming, BodyStream reads
+// response body from connection as it arrives. Otherwise, it reads already
+// buffered body.
+//
+// Multiple calls to BodyStream share read position.
+//
+// Example:
+//
+//	resp := e.GET("/events").WithStreaming().Expect()
+//
+//	stream := resp.BodyStream().WithTimeout(time.Second)
+//	stream.Chunked()
+//	stream.ReadLine().Equal(`{"event": "started"}`)
+//	stream.ReadLine().Equal(`{"event": "finished"}`)
+//	stream.Terminates()
+func (r *Response) BodyStream() *BodyStream {
+	r.chain.enter("BodyStream()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newBodyStream(r.chain, r, nil)
+	}
+
+	if r.stream == nil {
+		r.stream = newStreamReader(ioutil.NopCloser(bytes.NewReader(r.content)))
+	}
+
+	return newBodyStream(r.chain, r, r.stream)
+}
+
+// WithTimeout sets timeout for every subsequent read.
+// Zero timeout means no timeout.
+//
+// Example:
+//
+//	stream := resp.BodyStream()
+//	stream.WithTimeout(time.Second).ReadLine()
+func (s *BodyStream) WithTimeout(timeout time.Duration) *BodyStream {
+	s.chain.enter("WithTimeout()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if timeout < 0 {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative timeout"),
+			},
+		})
+		return s
+	}
+
+	s.timeout = timeout
+
+	return s
+}
+
+// Chunked succeeds if response uses "chunked" Transfer-Encoding.
+//
+// Example:
+//
+//	stream := resp.BodyStream()
+//	stream.Chunked()
+func (s *BodyStream) Chunked() *BodyStream {
+	s.chain.enter("Chunked()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	encoding := s.resp.httpResp.TransferEncoding
+
+	for _, e := range encoding {
+		if strings.EqualFold(e, "chunked") {
+			return s
+		}
+	}
+
+	s.chain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{encoding},
+		Expected: &AssertionValue{"chunked"},
+		Errors: []error{
+			errors.New(`expected: response uses "chunked" Transfer-Encoding`),
+		},
+	})
+
+	return s
+}
+
+// Read returns a new String instance with next n bytes of body.
+//
+// If stream ends before n bytes are received, or timeout expires,
+// failure is reported.
+//
+// Example:
+//
+//	stream := resp.BodyStream()
+//	stream.Read(5).Equal("hello")
+func (s *BodyStream) Read(n int) *String {
+	s.chain.enter("Read(%d)", n)
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newString(s.chain, "")
+	}
+
+	if n <= 0 {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive byte count"),
+			},
+		})
+		return newString(s.chain, "")
+	}
+
+	data, err := s.reader.readN(n, s.timeout)
+	if err != nil {
+		s.failRead(data, err)
+		return newString(s.chain, "")
+	}
+
+	return newString(s.chain, string(data))
+}
+
+// ReadLine returns a new String instance with next line of body,
+// without trailing "\n" or "\r\n".
+//
+// The last line may be not terminated by newline. If stream ends before
+// any data is received, or timeout expires, failure is reported.
+//
+// Example:
+//
+//	stream := resp.BodyStream()
+//	stream.ReadLine().Equal(`{"id": 1}`)
+func (s *BodyStream) ReadLine() *String {
+	s.chain.enter("ReadLine()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newString(s.chain, "")
+	}
+
+	line, err := s.reader.readLine(s.timeout)
+	if err != nil {
+		s.failRead(line, err)
+		return newString(s.chain, "")
+	}
+
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	line = bytes.TrimSuffix(line, []byte("\r"))
+
+	return newString(s.chain, string(line))
+}
+
+// Terminates succeeds if stream ends before timeout expires.
+// All remaining data is read and discarded.
+//
+// Example:
+//
+//	stream := resp.BodyStream()
+//	stream.WithTimeout(5 * time.Second).Terminates()
+func (s *BodyStream) Terminates() *BodyStream {
+	s.chain.enter("Terminates()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if _, err := s.reader.readAll(s.timeout); err != nil {
+		s.failRead(nil, err)
+	}
+
+	return s
+}
+
+// Close closes stream and underlying response body.
+// Subsequent reads report failure.
+//
+// Example:
+//
+//	stream := resp.BodyStream()
+//	stream.ReadLine()
+//	stream.Close()
+func (s *BodyStream) Close() *BodyStream {
+	s.chain.enter("Close()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	s.reader.close()
+
+	return s
+}
+
+func (s *BodyStream) failRead(data []byte, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		s.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("timed out reading response body stream after %v", s.timeout),
+			},
+		})
+
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		s.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(data)},
+			Errors: []error{
+				errors.New("unexpected end of response body stream"),
+			},
+		})
+
+	default:
+		s.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read response body stream"),
+				err,
+			},
+		})
+	}
+}
+
+// Reads body in background goroutine, so that every read can be limited
+// by timeout. Methods should be called from a single goroutine.
+type streamReader struct {
+	body io.ReadCloser
+
+	data chan []byte
+	done chan struct{}
+
+	once sync.Once
+
+	// set by background goroutine before closing data
+	err error
+
+	pending  []byte
+	consumed []byte
+	lines    int
+	eof      bool
+	closed   bool
+}
+
+var errStreamClosed = errors.New("stream is closed")
+
+func newStreamReader(body io.ReadCloser) *streamReader {
+	s := &streamReader{
+		body: body,
+		data: make(chan []byte),
+		done: make(chan struct{}),
+	}
+
+	go s.pump()
+
+	return s
+}
+
+func (s *streamReader) pump() {
+	defer close(s.data)
+
+	buf := make([]byte, 32*1024)
+
+	for {
+		n, err := s.body.Read(buf)
+
+		if n > 0 {
+			chunk := append([]byte(nil), buf[:n]...)
+			select {
+			case s.data <- chunk:
+			case <-s.done:
+				return
+			}
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+			}
+			_ = s.body.Close()
+			return
+		}
+	}
+}
+
+func (s *streamReader) close() {
+	s.closed = true
+	s.once.Do(func() {
+		close(s.done)
+		_ = s.body.Close()
+	})
+}
+
+// Wait for more data until deadline (zero deadline means no timeout).
+func (s *streamReader) fill(deadline time.Time) error {
+	if s.closed {
+		return errStreamClosed
+	}
+	if s.eof {
+		return s.err
+	}
+
+	var timer <-chan time.Time
+
+	if !deadline.IsZero() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return context.DeadlineExceeded
+		}
+		t := time.NewTimer(remaining)
+		defer t.Stop()
+		timer = t.C
+	}
+
+	select {
+	case chunk, ok := <-s.data:
+		if !ok {
+			s.eof = true
+			return s.err
+		}
+		s.pending = append(s.pending, chunk...)
+		return nil
+
+	case <-timer:
+		return context.DeadlineExceeded
+	}
+}
+
+func (s *streamReader) take(n int) []byte {
+	data := s.pending[:n:n]
+	s.pending = s.pending[n:]
+	s.consumed = append(s.consumed, data...)
+	s.lines += bytes.Count(data, []byte("\n"))
+	return data
+}
+
+func (s *streamReader) readN(n int, timeout time.Duration) ([]byte, error) {
+	if s.closed {
+		return nil, errStreamClosed
+	}
+
+	deadline := makeDeadline(timeout)
+
+	for len(s.pending) < n {
+		if s.eof {
+			if s.err != nil {
+				return nil, s.err
+			}
+			return s.take(len(s.pending)), io.ErrUnexpectedEOF
+		}
+		if err := s.fill(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.take(n), nil
+}
+
+func (s *streamReader) readLine(timeout time.Duration) ([]byte, error) {
+	if s.closed {
+		return nil, errStreamClosed
+	}
+
+	deadline := makeDeadline(timeout)
+
+	for {
+		if i := bytes.IndexByte(s.pending, '\n'); i >= 0 {
+			return s.take(i + 1), nil
+		}
+		if s.eof {
+			if s.err != nil {
+				return nil, s.err
+			}
+			if len(s.pending) == 0 {
+				return nil, io.EOF
+			}
+			return s.take(len(s.pending)), nil
+		}
+		if err := s.fill(deadline); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *streamReader) readAll(timeout time.Duration) ([]byte, error) {
+	if s.closed {
+		return nil, errStreamClosed
+	}
+
+	deadline := makeDeadline(timeout)
+
+	for !s.eof {
+		if err := s.fill(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.take(len(s.pending)), nil
+}
+
+func makeDeadline(timeout time.Duration) time.Time {
+	if timeout == 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
+
+// Closes body and cancels request context.
+type cancelCloser struct {
+	io.ReadCloser
+	cancelFn context.CancelFunc
+}
+
+func (c *cancelCloser) Close() error {
+	err := c.ReadCloser.Close()
+	if c.cancelFn != nil {
+		c.cancelFn()
+	}
+	return err
+}
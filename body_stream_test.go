@@ -0,0 +1,174 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBodyStreamFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newBodyStream(chain, nil, nil)
+
+	value.chain.assertFailed(t)
+
+	value.WithTimeout(time.Second)
+	value.Chunked()
+	value.Read(1).chain.assertFailed(t)
+	value.ReadLine().chain.assertFailed(t)
+	value.Terminates()
+	value.Close()
+}
+
+func TestBodyStreamBuffered(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		Body: ioutil.NopCloser(bytes.NewBufferString("first\nsecond\r\nlast")),
+	})
+
+	stream := resp.BodyStream()
+
+	stream.ReadLine().Equal("first")
+	stream.Read(3).Equal("sec")
+	stream.ReadLine().Equal("ond")
+
+	resp.BodyStream().ReadLine().Equal("last")
+	stream.chain.assertNotFailed(t)
+
+	stream.ReadLine()
+	stream.chain.assertFailed(t)
+	stream.chain.clearFailed()
+
+	stream.Terminates()
+	stream.chain.assertNotFailed(t)
+
+	resp.Body().Equal("first\nsecond\r\nlast")
+	resp.chain.assertNotFailed(t)
+}
+
+func TestBodyStreamRead(t *testing.T) {
+	newStream := func(t *testing.T, body string) *BodyStream {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+
+		return resp.BodyStream()
+	}
+
+	t.Run("past end", func(t *testing.T) {
+		stream := newStream(t, "abc")
+
+		stream.Read(4)
+		stream.chain.assertFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		stream := newStream(t, "")
+
+		stream.ReadLine()
+		stream.chain.assertFailed(t)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		stream := newStream(t, "abc")
+
+		stream.Read(1).Equal("a")
+		stream.Close()
+		stream.chain.assertNotFailed(t)
+
+		stream.Read(1)
+		stream.chain.assertFailed(t)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		stream := newStream(t, "abc")
+
+		stream.Read(0)
+		stream.chain.assertFailed(t)
+		stream.chain.clearFailed()
+
+		stream.WithTimeout(-1)
+		stream.chain.assertFailed(t)
+		stream.chain.clearFailed()
+	})
+
+	t.Run("not chunked", func(t *testing.T) {
+		stream := newStream(t, "abc")
+
+		stream.Chunked()
+		stream.chain.assertFailed(t)
+	})
+}
+
+func TestBodyStreamStreaming(t *testing.T) {
+	proceed := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+
+			_, _ = w.Write([]byte("{\"n\": 1}\n"))
+			w.(http.Flusher).Flush()
+
+			<-proceed
+
+			_, _ = w.Write([]byte("{\"n\": 2}\n"))
+		}))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+	})
+
+	resp := e.GET("/").WithStreaming().Expect()
+	resp.chain.assertNotFailed(t)
+
+	stream := resp.BodyStream().WithTimeout(5 * time.Second)
+
+	stream.Chunked()
+	stream.ReadLine().Equal(`{"n": 1}`)
+	stream.chain.assertNotFailed(t)
+
+	stream.WithTimeout(10 * time.Millisecond).ReadLine()
+	stream.chain.assertFailed(t)
+	stream.chain.clearFailed()
+
+	close(proceed)
+
+	stream.WithTimeout(5 * time.Second).ReadLine().Equal(`{"n": 2}`)
+	stream.Terminates()
+	stream.chain.assertNotFailed(t)
+
+	resp.Body().Equal("{\"n\": 1}\n{\"n\": 2}\n")
+	resp.chain.assertNotFailed(t)
+}
+
+func TestBodyStreamLazyBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"a": 1}`))
+		}))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Printers: []Printer{NewDebugPrinter(newMockLogger(t), true)},
+	})
+
+	resp := e.GET("/").WithStreaming().Expect()
+
+	resp.BodyStream().Read(2).Equal(`{"`)
+	resp.JSON().Object().ValueEqual("a", 1)
+	resp.chain.assertNotFailed(t)
+}
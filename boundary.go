@@ -0,0 +1,315 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// BoundaryValue is a named input value used to probe input validation
+// of an endpoint. Name identifies value in failure reports.
+type BoundaryValue struct {
+	Name  string
+	Value string
+}
+
+// IntBoundaries returns boundary values for integer parameters: zero,
+// negative numbers, limits of 32-bit and 64-bit integers, values that
+// overflow them, and malformed numbers.
+func IntBoundaries() []BoundaryValue {
+	return []BoundaryValue{
+		{"zero", "0"},
+		{"negative", "-1"},
+		{"max int32", strconv.FormatInt(math.MaxInt32, 10)},
+		{"min int32", strconv.FormatInt(math.MinInt32, 10)},
+		{"overflow int32", strconv.FormatInt(math.MaxInt32+1, 10)},
+		{"max int64", strconv.FormatInt(math.MaxInt64, 10)},
+		{"min int64", strconv.FormatInt(math.MinInt64, 10)},
+		{"overflow int64", "9223372036854775808"},
+		{"fraction", "1.5"},
+		{"exponent", "1e3"},
+		{"not a number", "abc"},
+	}
+}
+
+// StringBoundaries returns boundary values for string parameters with
+// given maximum length: empty and blank strings, strings of maximum and
+// overlong length, and non-ASCII and control characters.
+//
+// If maxLen is zero or negative, overlong strings of 1024 and 65536
+// characters are used instead.
+func StringBoundaries(maxLen int) []BoundaryValue {
+	values := []BoundaryValue{
+		{"empty", ""},
+		{"blank", " "},
+		{"unicode", "é中\U0001f600"},
+		{"control", "\x00\x1f"},
+	}
+
+	if maxLen > 0 {
+		values = append(values,
+			BoundaryValue{"max length", strings.Repeat("a", maxLen)},
+			BoundaryValue{"overlong", strings.Repeat("a", maxLen+1)},
+		)
+	} else {
+		values = append(values,
+			BoundaryValue{"overlong", strings.Repeat("a", 1024)},
+			BoundaryValue{"very overlong", strings.Repeat("a", 65536)},
+		)
+	}
+
+	return values
+}
+
+// ReservedBoundaries returns values consisting of characters reserved
+// in URLs (RFC 3986), percent sign, and common injection payloads.
+func ReservedBoundaries() []BoundaryValue {
+	var values []BoundaryValue
+
+	for _, c := range ":/?#[]@!$&'()*+,;=%" {
+		values = append(values, BoundaryValue{
+			Name:  fmt.Sprintf("reserved %q", c),
+			Value: string(c),
+		})
+	}
+
+	return append(values,
+		BoundaryValue{"path traversal", "../../etc/passwd"},
+		BoundaryValue{"sql injection", "' OR '1'='1"},
+		BoundaryValue{"html injection", "<script>alert(1)</script>"},
+	)
+}
+
+// DefaultBoundaries returns union of IntBoundaries, StringBoundaries
+// without length limit, and ReservedBoundaries.
+func DefaultBoundaries() []BoundaryValue {
+	var values []BoundaryValue
+
+	values = append(values, IntBoundaries()...)
+	values = append(values, StringBoundaries(0)...)
+	values = append(values, ReservedBoundaries()...)
+
+	return values
+}
+
+type boundaryTarget int
+
+const (
+	boundaryQuery boundaryTarget = iota
+	boundaryPath
+)
+
+// BoundaryCheck sends the same request multiple times, every time with
+// a different boundary value injected into a parameter, and checks every
+// response using assertion template.
+//
+// Every request is named after parameter and value (see Request.WithName),
+// so that failures identify which value caused them.
+type BoundaryCheck struct {
+	chain  *chain
+	expect *Expect
+
+	target boundaryTarget
+	param  string
+	values []BoundaryValue
+
+	request func(e *Expect) *Request
+}
+
+// QueryBoundaries returns a new BoundaryCheck instance, which injects
+// given values into query parameter. Value replaces parameter if it's
+// already set by request.
+//
+// If no values are given, DefaultBoundaries is used.
+//
+// Example:
+//
+//	httpexpect.QueryBoundaries(e, "limit", httpexpect.IntBoundaries()...).
+//	    Request(func(e *httpexpect.Expect) *httpexpect.Request {
+//	        return e.GET("/users")
+//	    }).
+//	    Check(func(resp *httpexpect.Response, v httpexpect.BoundaryValue) {
+//	        resp.StatusRange(httpexpect.Status4xx)
+//	    })
+func QueryBoundaries(e *Expect, param string, values ...BoundaryValue) *BoundaryCheck {
+	return newBoundaryCheck("QueryBoundaries()", e, boundaryQuery, param, values)
+}
+
+// PathBoundaries returns a new BoundaryCheck instance, which substitutes
+// given values into named path parameter, like Request.WithPath.
+//
+// Value is substituted as is and is percent-encoded when URL is built;
+// note that slash in value acts as path separator.
+//
+// If no values are given, DefaultBoundaries is used.
+//
+// Example:
+//
+//	httpexpect.PathBoundaries(e, "id", httpexpect.IntBoundaries()...).
+//	    Request(func(e *httpexpect.Expect) *httpexpect.Request {
+//	        return e.GET("/users/{id}")
+//	    }).
+//	    Rejected()
+func PathBoundaries(e *Expect, param string, values ...BoundaryValue) *BoundaryCheck {
+	return newBoundaryCheck("PathBoundaries()", e, boundaryPath, param, values)
+}
+
+func newBoundaryCheck(
+	name string, e *Expect, target boundaryTarget, param string, values []BoundaryValue,
+) *BoundaryCheck {
+	if e == nil {
+		panic(name + ": Expect is nil")
+	}
+
+	if len(values) == 0 {
+		values = DefaultBoundaries()
+	}
+
+	b := &BoundaryCheck{
+		expect: e,
+		target: target,
+		param:  param,
+		values: append([]BoundaryValue(nil), values...),
+	}
+
+	b.chain = e.chain.clone()
+	b.chain.enter(name)
+	defer b.chain.leave()
+
+	if param == "" {
+		b.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty parameter name"),
+			},
+		})
+	}
+
+	return b
+}
+
+// Request sets function that constructs request template. It's invoked
+// once for every value.
+//
+// Function should construct request using given Expect instance, but
+// should not send it.
+//
+// Example:
+//
+//	check.Request(func(e *httpexpect.Expect) *httpexpect.Request {
+//	    return e.GET("/users").WithHeader("Authorization", token)
+//	})
+func (b *BoundaryCheck) Request(fn func(e *Expect) *Request) *BoundaryCheck {
+	b.chain.enter("Request()")
+	defer b.chain.leave()
+
+	if b.chain.failed() {
+		return b
+	}
+
+	if fn == nil {
+		b.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return b
+	}
+
+	b.request = fn
+
+	return b
+}
+
+// Check sends request for every value and invokes assertion template
+// for every response.
+//
+// Example:
+//
+//	check.Check(func(resp *httpexpect.Response, v httpexpect.BoundaryValue) {
+//	    resp.Status(http.StatusBadRequest).
+//	        JSON().Object().ContainsKey("error")
+//	})
+func (b *BoundaryCheck) Check(fn func(resp *Response, value BoundaryValue)) *BoundaryCheck {
+	b.chain.enter("Check()")
+	defer b.chain.leave()
+
+	if b.chain.failed() {
+		return b
+	}
+
+	if fn == nil {
+		b.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return b
+	}
+
+	if b.request == nil {
+		b.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("request template is not set, use Request()"),
+			},
+		})
+		return b
+	}
+
+	for _, value := range b.values {
+		req := b.request(b.expect)
+		if req == nil {
+			b.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("request function returned nil"),
+				},
+			})
+			return b
+		}
+
+		b.inject(req, value)
+
+		fn(req.Expect(), value)
+	}
+
+	return b
+}
+
+// Rejected is a shorthand for Check with template that expects
+// "Client Error" (4xx) status for every value.
+func (b *BoundaryCheck) Rejected() *BoundaryCheck {
+	return b.Check(func(resp *Response, _ BoundaryValue) {
+		resp.StatusRange(Status4xx)
+	})
+}
+
+func (b *BoundaryCheck) inject(req *Request, value BoundaryValue) {
+	name := fmt.Sprintf("%s=%s", b.param, value.Name)
+	if prev := req.chain.context.RequestName; prev != "" {
+		name = prev + " " + name
+	}
+
+	req.WithName(name)
+
+	if req.chain.failed() {
+		return
+	}
+
+	switch b.target {
+	case boundaryQuery:
+		if req.query == nil {
+			req.query = make(url.Values)
+		}
+		req.query.Set(b.param, value.Value)
+
+	case boundaryPath:
+		req.WithPath(b.param, value.Value)
+	}
+}
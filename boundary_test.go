@@ -0,0 +1,149 @@
+package httpexpect
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundaryGenerators(t *testing.T) {
+	for _, values := range [][]BoundaryValue{
+		IntBoundaries(),
+		StringBoundaries(10),
+		StringBoundaries(0),
+		ReservedBoundaries(),
+		DefaultBoundaries(),
+	} {
+		names := map[string]bool{}
+		for _, v := range values {
+			assert.NotEmpty(t, v.Name)
+			assert.False(t, names[v.Name], v.Name)
+			names[v.Name] = true
+		}
+	}
+
+	values := StringBoundaries(10)
+	assert.Contains(t, values, BoundaryValue{"overlong", strings.Repeat("a", 11)})
+}
+
+func TestBoundaryCheck(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		params []string
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := r.URL.Query()["limit"]
+		id := strings.TrimPrefix(r.URL.Path, "/users/")
+
+		mu.Lock()
+		params = append(params, strings.Join(limit, ",")+"|"+id)
+		mu.Unlock()
+
+		if n, err := strconv.Atoi(strings.Join(limit, ",")); err != nil || n < 0 || n > 100 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	newExpect := func(reporter Reporter) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+	}
+
+	t.Run("query", func(t *testing.T) {
+		params = nil
+		reporter := newMockReporter(t)
+
+		var names []string
+
+		QueryBoundaries(newExpect(reporter), "limit",
+			BoundaryValue{"negative", "-1"},
+			BoundaryValue{"overlong", "1000"},
+		).
+			Request(func(e *Expect) *Request {
+				return e.GET("/users/{id}").WithPath("id", 1).WithQuery("limit", 10)
+			}).
+			Check(func(resp *Response, v BoundaryValue) {
+				names = append(names, resp.chain.context.RequestName)
+				resp.Status(http.StatusBadRequest)
+			})
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, []string{"-1|1", "1000|1"}, params)
+		assert.Equal(t, []string{"limit=negative", "limit=overlong"}, names)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		params = nil
+		reporter := newMockReporter(t)
+
+		PathBoundaries(newExpect(reporter), "id",
+			BoundaryValue{"reserved", "a?b"},
+		).
+			Request(func(e *Expect) *Request {
+				return e.GET("/users/{id}").WithQuery("limit", 10)
+			}).
+			Check(func(resp *Response, v BoundaryValue) {
+				resp.Status(http.StatusOK)
+			})
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, []string{"10|a?b"}, params)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		QueryBoundaries(newExpect(reporter), "limit",
+			BoundaryValue{"valid", "5"},
+		).
+			Request(func(e *Expect) *Request {
+				return e.GET("/users")
+			}).
+			Rejected()
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		count := 0
+
+		QueryBoundaries(newExpect(reporter), "limit").
+			Request(func(e *Expect) *Request {
+				return e.GET("/users")
+			}).
+			Check(func(resp *Response, v BoundaryValue) {
+				count++
+			})
+
+		assert.Equal(t, len(DefaultBoundaries()), count)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		QueryBoundaries(newExpect(reporter), "limit").
+			Check(func(resp *Response, v BoundaryValue) {})
+		assert.True(t, reporter.reported)
+
+		reporter = newMockReporter(t)
+		PathBoundaries(newExpect(reporter), "")
+		assert.True(t, reporter.reported)
+
+		assert.Panics(t, func() {
+			QueryBoundaries(nil, "limit")
+		})
+	})
+}
@@ -0,0 +1,507 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheControl provides methods to inspect Cache-Control header directives
+// (RFC 9111) and related caching headers.
+type CacheControl struct {
+	chain      *chain
+	header     http.Header
+	directives map[string]string
+}
+
+// NewCacheControl returns a new CacheControl instance for Cache-Control
+// header contained in given header map.
+//
+// Header map is also used by Consistent to cross-check Date and Expires
+// headers.
+//
+// reporter and header should not be nil.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, http.Header{
+//	    "Cache-Control": {"public, max-age=3600"},
+//	})
+//	cc.Public()
+//	cc.MaxAge().Equal(time.Hour)
+func NewCacheControl(reporter Reporter, header http.Header) *CacheControl {
+	return newCacheControl(newChainWithDefaults("CacheControl()", reporter), header)
+}
+
+func newCacheControl(parent *chain, header http.Header) *CacheControl {
+	c := &CacheControl{chain: parent.clone()}
+
+	if header == nil {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: non-nil header"),
+			},
+		})
+		return c
+	}
+
+	directives, err := parseCacheControl(header.Values("Cache-Control"))
+	if err != nil {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{header.Values("Cache-Control")},
+			Errors: []error{
+				errors.New("expected: valid Cache-Control header"),
+				err,
+			},
+		})
+		return c
+	}
+
+	c.header = header
+	c.directives = directives
+
+	return c
+}
+
+// Raw returns parsed directives, mapped from lower-case directive name
+// to its unquoted value. Directives without value have empty value.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	assert.Equal(t, map[string]string{"max-age": "60"}, cc.Raw())
+func (c *CacheControl) Raw() map[string]string {
+	return c.directives
+}
+
+// HaveDirective succeeds if Cache-Control has directive with given name.
+// Directive names are case-insensitive.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.HaveDirective("stale-while-revalidate")
+func (c *CacheControl) HaveDirective(name string) *CacheControl {
+	c.chain.enter("HaveDirective(%q)", name)
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkDirective(name, true)
+
+	return c
+}
+
+// NotHaveDirective succeeds if Cache-Control does not have directive with
+// given name. Directive names are case-insensitive.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.NotHaveDirective("no-store")
+func (c *CacheControl) NotHaveDirective(name string) *CacheControl {
+	c.chain.enter("NotHaveDirective(%q)", name)
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkDirective(name, false)
+
+	return c
+}
+
+// Directive returns a new String instance with value of directive with
+// given name. Directives without value have empty value.
+//
+// If directive is not present, method fails.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.Directive("private").Equal("Set-Cookie")
+func (c *CacheControl) Directive(name string) *String {
+	c.chain.enter("Directive(%q)", name)
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	if !c.checkDirective(name, true) {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, c.directives[strings.ToLower(name)])
+}
+
+// MaxAge returns a new Duration instance with value of max-age directive.
+//
+// If directive is not present or is not a valid number of seconds,
+// method fails.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.MaxAge().InRange(time.Minute, time.Hour)
+func (c *CacheControl) MaxAge() *Duration {
+	c.chain.enter("MaxAge()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newDuration(c.chain, nil)
+	}
+
+	return c.durationDirective("max-age")
+}
+
+// SMaxAge returns a new Duration instance with value of s-maxage
+// directive, which applies to shared caches, e.g. CDNs.
+//
+// If directive is not present or is not a valid number of seconds,
+// method fails.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.SMaxAge().Equal(24 * time.Hour)
+func (c *CacheControl) SMaxAge() *Duration {
+	c.chain.enter("SMaxAge()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newDuration(c.chain, nil)
+	}
+
+	return c.durationDirective("s-maxage")
+}
+
+// NoStore succeeds if Cache-Control has no-store directive.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.NoStore()
+func (c *CacheControl) NoStore() *CacheControl {
+	c.chain.enter("NoStore()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkDirective("no-store", true)
+
+	return c
+}
+
+// NoCache succeeds if Cache-Control has no-cache directive.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.NoCache()
+func (c *CacheControl) NoCache() *CacheControl {
+	c.chain.enter("NoCache()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkDirective("no-cache", true)
+
+	return c
+}
+
+// Public succeeds if Cache-Control has public directive.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.Public()
+func (c *CacheControl) Public() *CacheControl {
+	c.chain.enter("Public()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkDirective("public", true)
+
+	return c
+}
+
+// Private succeeds if Cache-Control has private directive.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.Private()
+func (c *CacheControl) Private() *CacheControl {
+	c.chain.enter("Private()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkDirective("private", true)
+
+	return c
+}
+
+// MustRevalidate succeeds if Cache-Control has must-revalidate directive.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, header)
+//	cc.MustRevalidate()
+func (c *CacheControl) MustRevalidate() *CacheControl {
+	c.chain.enter("MustRevalidate()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkDirective("must-revalidate", true)
+
+	return c
+}
+
+// Consistent succeeds if Cache-Control, Date, and Expires headers don't
+// contradict each other.
+//
+// The following rules are checked:
+//   - Date and Expires, if present, are valid HTTP dates ("0" is allowed
+//     for Expires and means "already expired")
+//   - max-age and s-maxage, if present, are valid numbers of seconds
+//   - public and private are not used together
+//   - no-store is not used together with positive max-age or s-maxage
+//   - if max-age, Date, and Expires are present, Expires equals to Date
+//     plus max-age (with one second tolerance)
+//   - if no-store or no-cache is present, Expires is not later than Date
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CacheControl().Public().Consistent()
+func (c *CacheControl) Consistent() *CacheControl {
+	c.chain.enter("Consistent()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	var errs []error
+
+	date, hasDate, err := cacheHeaderTime(c.header, "Date")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	expires, hasExpires, err := cacheHeaderTime(c.header, "Expires")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	maxAge, hasMaxAge, err := c.parseDuration("max-age")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	sMaxAge, hasSMaxAge, err := c.parseDuration("s-maxage")
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	_, public := c.directives["public"]
+	_, private := c.directives["private"]
+	_, noStore := c.directives["no-store"]
+	_, noCache := c.directives["no-cache"]
+
+	if public && private {
+		errs = append(errs,
+			errors.New("public and private directives are mutually exclusive"))
+	}
+
+	if noStore && ((hasMaxAge && maxAge > 0) || (hasSMaxAge && sMaxAge > 0)) {
+		errs = append(errs,
+			errors.New("no-store directive is used together with positive max age"))
+	}
+
+	if hasMaxAge && hasDate && hasExpires {
+		if delta := expires.Sub(date) - maxAge; absDuration(delta) > time.Second {
+			errs = append(errs, fmt.Errorf(
+				"Expires is %s after Date, but max-age is %s",
+				expires.Sub(date), maxAge))
+		}
+	}
+
+	if (noStore || noCache) && hasDate && hasExpires && expires.After(date) {
+		errs = append(errs, errors.New(
+			"Expires is later than Date, but response must not be reused"+
+				" without revalidation"))
+	}
+
+	if len(errs) != 0 {
+		c.chain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{http.Header{
+				"Cache-Control": c.header.Values("Cache-Control"),
+				"Date":          c.header.Values("Date"),
+				"Expires":       c.header.Values("Expires"),
+			}},
+			Errors: append([]error{
+				errors.New("expected: consistent caching headers"),
+			}, errs...),
+		})
+	}
+
+	return c
+}
+
+func (c *CacheControl) checkDirective(name string, want bool) bool {
+	_, present := c.directives[strings.ToLower(name)]
+
+	switch {
+	case want && !present:
+		c.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{c.directives},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: Cache-Control has %s directive", name),
+			},
+		})
+		return false
+
+	case !want && present:
+		c.chain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{c.directives},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: Cache-Control does not have %s directive", name),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func (c *CacheControl) durationDirective(name string) *Duration {
+	if !c.checkDirective(name, true) {
+		return newDuration(c.chain, nil)
+	}
+
+	d, _, err := c.parseDuration(name)
+	if err != nil {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.directives[name]},
+			Errors: []error{
+				fmt.Errorf("expected: %s directive is valid number of seconds", name),
+				err,
+			},
+		})
+		return newDuration(c.chain, nil)
+	}
+
+	return newDuration(c.chain, &d)
+}
+
+// Parse delta-seconds directive value.
+func (c *CacheControl) parseDuration(name string) (time.Duration, bool, error) {
+	value, ok := c.directives[name]
+	if !ok {
+		return 0, false, nil
+	}
+
+	if value == "" || strings.TrimLeft(value, "0123456789") != "" {
+		return 0, true, fmt.Errorf("invalid %s value %q", name, value)
+	}
+
+	// Values exceeding the limit are treated as maximum duration,
+	// as permitted by RFC 9111.
+	secs, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || secs > math.MaxInt64/int64(time.Second) {
+		return time.Duration(math.MaxInt64), true, nil
+	}
+
+	return time.Duration(secs) * time.Second, true, nil
+}
+
+func cacheHeaderTime(header http.Header, name string) (time.Time, bool, error) {
+	value := header.Get(name)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+
+	if name == "Expires" && value == "0" {
+		return time.Time{}, false, nil
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s header %q", name, value)
+	}
+
+	return t, true, nil
+}
+
+// Parse directives of all Cache-Control header lines.
+func parseCacheControl(values []string) (map[string]string, error) {
+	directives := map[string]string{}
+
+	for _, elem := range splitHeaderList(values) {
+		name, value := elem, ""
+		if eq := strings.IndexByte(elem, '='); eq >= 0 {
+			name, value = strings.TrimSpace(elem[:eq]), strings.TrimSpace(elem[eq+1:])
+		}
+
+		if name == "" || strings.ContainsAny(name, " \t\"") {
+			return nil, fmt.Errorf("invalid directive %q", elem)
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+				return nil, fmt.Errorf("invalid directive %q", elem)
+			}
+			value = unquoteHeaderValue(value[1 : len(value)-1])
+		}
+
+		name = strings.ToLower(name)
+		if _, ok := directives[name]; !ok {
+			directives[name] = value
+		}
+	}
+
+	return directives, nil
+}
+
+// Remove backslash escaping from contents of quoted string.
+func unquoteHeaderValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,238 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheControlFailed(t *testing.T) {
+	check := func(value *CacheControl) {
+		value.chain.assertFailed(t)
+
+		assert.Nil(t, value.Raw())
+		assert.NotNil(t, value.Directive("foo"))
+		assert.NotNil(t, value.MaxAge())
+		assert.NotNil(t, value.SMaxAge())
+
+		value.HaveDirective("foo")
+		value.NotHaveDirective("foo")
+		value.NoStore()
+		value.NoCache()
+		value.Public()
+		value.Private()
+		value.MustRevalidate()
+		value.Consistent()
+	}
+
+	t.Run("failed_chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.fail(mockFailure())
+
+		check(newCacheControl(chain, nil))
+	})
+
+	t.Run("nil_value", func(t *testing.T) {
+		check(newCacheControl(newMockChain(t), nil))
+	})
+
+	t.Run("invalid_value", func(t *testing.T) {
+		check(newCacheControl(newMockChain(t), http.Header{
+			"Cache-Control": {`max-age="60`},
+		}))
+	})
+}
+
+func TestCacheControlDirectives(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cc := NewCacheControl(reporter, http.Header{
+		"Cache-Control": {
+			`Public, MAX-AGE=3600`,
+			`s-maxage="86400", no-cache="Set-Cookie, X-Foo", stale-if-error=60`,
+		},
+	})
+	cc.chain.assertNotFailed(t)
+
+	assert.Equal(t, map[string]string{
+		"public":         "",
+		"max-age":        "3600",
+		"s-maxage":       "86400",
+		"no-cache":       "Set-Cookie, X-Foo",
+		"stale-if-error": "60",
+	}, cc.Raw())
+
+	cc.Public().NoCache().HaveDirective("Stale-If-Error").NotHaveDirective("private")
+	cc.MaxAge().Equal(time.Hour)
+	cc.SMaxAge().Equal(24 * time.Hour)
+	cc.Directive("no-cache").Equal("Set-Cookie, X-Foo")
+	cc.Directive("public").Empty()
+	cc.chain.assertNotFailed(t)
+
+	for _, fn := range []func(){
+		func() { cc.NoStore() },
+		func() { cc.Private() },
+		func() { cc.MustRevalidate() },
+		func() { cc.HaveDirective("immutable") },
+		func() { cc.NotHaveDirective("PUBLIC") },
+		func() { cc.Directive("private") },
+	} {
+		fn()
+		cc.chain.assertFailed(t)
+		cc.chain.clearFailed()
+	}
+}
+
+func TestCacheControlMaxAge(t *testing.T) {
+	cases := []struct {
+		name   string
+		value  string
+		result time.Duration
+		fail   bool
+	}{
+		{"zero", "max-age=0", 0, false},
+		{"positive", "max-age=60", time.Minute, false},
+		{"overflow", "max-age=99999999999999999999", time.Duration(1<<63 - 1), false},
+		{"missing", "no-store", 0, true},
+		{"empty", "max-age=", 0, true},
+		{"negative", "max-age=-1", 0, true},
+		{"fraction", "max-age=1.5", 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cc := NewCacheControl(newMockReporter(t), http.Header{
+				"Cache-Control": {tc.value},
+			})
+
+			d := cc.MaxAge()
+
+			if tc.fail {
+				cc.chain.assertFailed(t)
+			} else {
+				cc.chain.assertNotFailed(t)
+				assert.Equal(t, tc.result, d.Raw())
+			}
+		})
+	}
+}
+
+func TestCacheControlConsistent(t *testing.T) {
+	const date = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+	cases := []struct {
+		name   string
+		header http.Header
+		fail   bool
+	}{
+		{
+			name: "max_age_matches_expires",
+			header: http.Header{
+				"Cache-Control": {"public, max-age=3600"},
+				"Date":          {date},
+				"Expires":       {"Mon, 02 Jan 2006 16:04:05 GMT"},
+			},
+		},
+		{
+			name: "max_age_without_dates",
+			header: http.Header{
+				"Cache-Control": {"max-age=3600"},
+			},
+		},
+		{
+			name: "no_store_expired",
+			header: http.Header{
+				"Cache-Control": {"no-store"},
+				"Date":          {date},
+				"Expires":       {"0"},
+			},
+		},
+		{
+			name: "max_age_mismatches_expires",
+			header: http.Header{
+				"Cache-Control": {"max-age=60"},
+				"Date":          {date},
+				"Expires":       {"Mon, 02 Jan 2006 16:04:05 GMT"},
+			},
+			fail: true,
+		},
+		{
+			name: "public_and_private",
+			header: http.Header{
+				"Cache-Control": {"public, private"},
+			},
+			fail: true,
+		},
+		{
+			name: "no_store_with_max_age",
+			header: http.Header{
+				"Cache-Control": {"no-store, s-maxage=60"},
+			},
+			fail: true,
+		},
+		{
+			name: "no_cache_with_future_expires",
+			header: http.Header{
+				"Cache-Control": {"no-cache"},
+				"Date":          {date},
+				"Expires":       {"Tue, 03 Jan 2006 15:04:05 GMT"},
+			},
+			fail: true,
+		},
+		{
+			name: "invalid_date",
+			header: http.Header{
+				"Cache-Control": {"max-age=60"},
+				"Date":          {"yesterday"},
+			},
+			fail: true,
+		},
+		{
+			name: "invalid_max_age",
+			header: http.Header{
+				"Cache-Control": {"max-age=soon"},
+			},
+			fail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cc := NewCacheControl(newMockReporter(t), tc.header)
+			cc.chain.assertNotFailed(t)
+
+			cc.Consistent()
+
+			if tc.fail {
+				cc.chain.assertFailed(t)
+			} else {
+				cc.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
+
+func TestResponseCacheControl(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Cache-Control": {"private, max-age=60"},
+		},
+	})
+
+	cc := resp.CacheControl()
+	cc.Private().MaxAge().Equal(time.Minute)
+	cc.chain.assertNotFailed(t)
+
+	resp = NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	})
+
+	resp.CacheControl().chain.assertFailed(t)
+	resp.chain.assertFailed(t)
+}
@@ -0,0 +1,340 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// CallbackCatcher is a local HTTP server that records incoming requests,
+// e.g. webhook calls made by the service under test.
+//
+// Typical flow is: start catcher, pass its URL to the API being tested,
+// trigger an action, and then wait until the callback is received and
+// inspect it.
+//
+// CallbackCatcher should be closed after use.
+//
+// Example:
+//
+//	catcher := e.CallbackCatcher()
+//	defer catcher.Close()
+//
+//	e.POST("/subscriptions").
+//	    WithJSON(map[string]interface{}{"url": catcher.URL() + "/hook"}).
+//	    Expect().
+//	    Status(http.StatusCreated)
+//
+//	call := catcher.Wait(5 * time.Second)
+//	call.Method().Equal("POST")
+//	call.Path().Equal("/hook")
+//	call.JSON().Object().ValueEqual("event", "created")
+type CallbackCatcher struct {
+	chain  *chain
+	server *httptest.Server
+
+	mu       sync.Mutex
+	calls    []*callbackRecord
+	consumed int
+	notify   chan struct{}
+	status   int
+}
+
+type callbackRecord struct {
+	req  *http.Request
+	body []byte
+}
+
+// NewCallbackCatcher returns a new started CallbackCatcher.
+//
+// reporter should not be nil.
+//
+// Example:
+//
+//	catcher := NewCallbackCatcher(t)
+//	defer catcher.Close()
+func NewCallbackCatcher(reporter Reporter) *CallbackCatcher {
+	return newCallbackCatcher(newChainWithDefaults("CallbackCatcher()", reporter))
+}
+
+// CallbackCatcher returns a new started CallbackCatcher.
+// It's a shorthand for NewCallbackCatcher, but it uses assertion handler
+// of Expect instance.
+func (e *Expect) CallbackCatcher() *CallbackCatcher {
+	e.chain.enter("CallbackCatcher()")
+	defer e.chain.leave()
+
+	return newCallbackCatcher(e.chain)
+}
+
+func newCallbackCatcher(parent *chain) *CallbackCatcher {
+	c := &CallbackCatcher{
+		chain:  parent.clone(),
+		notify: make(chan struct{}),
+		status: http.StatusOK,
+	}
+
+	c.server = httptest.NewServer(http.HandlerFunc(c.serveHTTP))
+
+	return c
+}
+
+func (c *CallbackCatcher) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+
+	c.mu.Lock()
+	c.calls = append(c.calls, &callbackRecord{req: req, body: body})
+	status := c.status
+	close(c.notify)
+	c.notify = make(chan struct{})
+	c.mu.Unlock()
+
+	w.WriteHeader(status)
+}
+
+// URL returns base URL of the catcher server, e.g. "http://127.0.0.1:1234".
+// All requests sent to this URL or any path under it are recorded.
+func (c *CallbackCatcher) URL() string {
+	return c.server.URL
+}
+
+// Close shuts down the catcher server.
+func (c *CallbackCatcher) Close() {
+	c.server.Close()
+}
+
+// WithStatus sets HTTP status code that catcher replies with.
+// Default is http.StatusOK.
+func (c *CallbackCatcher) WithStatus(status int) *CallbackCatcher {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status = status
+
+	return c
+}
+
+// Count returns a new Number instance with number of calls received
+// so far.
+//
+// Example:
+//
+//	catcher.Count().Equal(1)
+func (c *CallbackCatcher) Count() *Number {
+	c.chain.enter("Count()")
+	defer c.chain.leave()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return newNumber(c.chain, float64(len(c.calls)))
+}
+
+// Wait waits until the next call is received and returns a new
+// CallbackCall instance for it.
+//
+// Every call is returned by Wait only once, in order of arrival. If the
+// call was already received before Wait is invoked, it's returned
+// immediately. If no call arrives during given timeout, failure is
+// reported.
+//
+// Example:
+//
+//	call := catcher.Wait(time.Second)
+//	call.Header("X-Signature").NotEmpty()
+func (c *CallbackCatcher) Wait(timeout time.Duration) *CallbackCall {
+	c.chain.enter("Wait()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newCallbackCall(c.chain, nil)
+	}
+
+	deadline := time.After(timeout)
+
+	for {
+		c.mu.Lock()
+		if c.consumed < len(c.calls) {
+			rec := c.calls[c.consumed]
+			c.consumed++
+			c.mu.Unlock()
+
+			return newCallbackCall(c.chain, rec)
+		}
+		notify := c.notify
+		c.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-deadline:
+			c.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("expected: callback received within %s", timeout),
+				},
+			})
+			return newCallbackCall(c.chain, nil)
+		}
+	}
+}
+
+// WaitCount waits until the total number of received calls becomes
+// at least n. If it doesn't happen during given timeout, failure is
+// reported.
+//
+// Example:
+//
+//	catcher.WaitCount(3, time.Second)
+func (c *CallbackCatcher) WaitCount(n int, timeout time.Duration) *CallbackCatcher {
+	c.chain.enter("WaitCount(%d)", n)
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	deadline := time.After(timeout)
+
+	for {
+		c.mu.Lock()
+		count := len(c.calls)
+		notify := c.notify
+		c.mu.Unlock()
+
+		if count >= n {
+			return c
+		}
+
+		select {
+		case <-notify:
+		case <-deadline:
+			c.chain.fail(AssertionFailure{
+				Type:     AssertGe,
+				Actual:   &AssertionValue{count},
+				Expected: &AssertionValue{n},
+				Errors: []error{
+					fmt.Errorf("expected: at least %d callbacks received within %s",
+						n, timeout),
+				},
+			})
+			return c
+		}
+	}
+}
+
+// CallbackCall provides methods to inspect request received by
+// CallbackCatcher.
+type CallbackCall struct {
+	chain *chain
+	req   *http.Request
+	body  []byte
+}
+
+func newCallbackCall(parent *chain, rec *callbackRecord) *CallbackCall {
+	c := &CallbackCall{chain: parent.clone()}
+
+	if rec != nil {
+		c.req = rec.req
+		c.body = rec.body
+	}
+
+	return c
+}
+
+// Raw returns received http.Request.
+// Its body is already consumed; use Body to inspect it.
+func (c *CallbackCall) Raw() *http.Request {
+	return c.req
+}
+
+// Method returns a new String instance with request method.
+func (c *CallbackCall) Method() *String {
+	c.chain.enter("Method()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, c.req.Method)
+}
+
+// Path returns a new String instance with request URL path.
+func (c *CallbackCall) Path() *String {
+	c.chain.enter("Path()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, c.req.URL.Path)
+}
+
+// Query returns a new String instance with value of given query parameter.
+func (c *CallbackCall) Query(key string) *String {
+	c.chain.enter("Query(%q)", key)
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, c.req.URL.Query().Get(key))
+}
+
+// Header returns a new String instance with value of given header.
+func (c *CallbackCall) Header(header string) *String {
+	c.chain.enter("Header(%q)", header)
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, c.req.Header.Get(header))
+}
+
+// Body returns a new String instance with request body.
+func (c *CallbackCall) Body() *String {
+	c.chain.enter("Body()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, string(c.body))
+}
+
+// JSON returns a new Value instance with JSON decoded from request body.
+func (c *CallbackCall) JSON() *Value {
+	c.chain.enter("JSON()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newValue(c.chain, nil)
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(c.body, &value); err != nil {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(c.body)},
+			Errors: []error{
+				errors.New("failed to decode json"),
+				err,
+			},
+		})
+		return newValue(c.chain, nil)
+	}
+
+	return newValue(c.chain, value)
+}
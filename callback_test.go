@@ -0,0 +1,124 @@
+package httpexpect
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackCatcherFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	catcher := newCallbackCatcher(chain)
+	defer catcher.Close()
+
+	call := catcher.Wait(time.Millisecond)
+	catcher.WaitCount(1, time.Millisecond)
+
+	assert.NotNil(t, call.Method())
+	assert.NotNil(t, call.Path())
+	assert.NotNil(t, call.Query("foo"))
+	assert.NotNil(t, call.Header("foo"))
+	assert.NotNil(t, call.Body())
+	assert.NotNil(t, call.JSON())
+	assert.Nil(t, call.Raw())
+
+	call.chain.assertFailed(t)
+	catcher.chain.assertFailed(t)
+}
+
+func TestCallbackCatcherWait(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	catcher := NewCallbackCatcher(reporter)
+	defer catcher.Close()
+
+	catcher.WithStatus(http.StatusAccepted)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+
+		req, err := http.NewRequest("POST", catcher.URL()+"/hook?id=1",
+			strings.NewReader(`{"event":"created"}`))
+		if err != nil {
+			panic(err)
+		}
+		req.Header.Set("X-Signature", "abc")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			panic(err)
+		}
+		resp.Body.Close()
+	}()
+
+	call := catcher.Wait(5 * time.Second)
+	catcher.chain.assertNotFailed(t)
+
+	require.NotNil(t, call.Raw())
+
+	call.Method().Equal("POST")
+	call.Path().Equal("/hook")
+	call.Query("id").Equal("1")
+	call.Header("X-Signature").Equal("abc")
+	call.Body().Equal(`{"event":"created"}`)
+	call.JSON().Object().ValueEqual("event", "created")
+	call.chain.assertNotFailed(t)
+
+	catcher.Count().Equal(1)
+	catcher.chain.assertNotFailed(t)
+
+	catcher.Wait(10 * time.Millisecond)
+	catcher.chain.assertFailed(t)
+}
+
+func TestCallbackCatcherWaitCount(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	catcher := NewCallbackCatcher(reporter)
+	defer catcher.Close()
+
+	for i := 0; i < 3; i++ {
+		resp, err := http.Get(catcher.URL())
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	catcher.WaitCount(3, time.Second)
+	catcher.chain.assertNotFailed(t)
+
+	catcher.Wait(time.Second).Method().Equal("GET")
+	catcher.Wait(time.Second).Method().Equal("GET")
+	catcher.Wait(time.Second).Method().Equal("GET")
+	catcher.chain.assertNotFailed(t)
+
+	catcher.WaitCount(4, 10*time.Millisecond)
+	catcher.chain.assertFailed(t)
+}
+
+func TestCallbackCatcherBadJSON(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		Reporter: reporter,
+	})
+
+	catcher := e.CallbackCatcher()
+	defer catcher.Close()
+
+	resp, err := http.Post(catcher.URL(), "text/plain", strings.NewReader("{"))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	call := catcher.Wait(time.Second)
+	call.chain.assertNotFailed(t)
+
+	call.JSON()
+	call.chain.assertFailed(t)
+}
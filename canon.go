@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
+	"strconv"
 )
 
 func canonNumber(chain *chain, in interface{}) (out float64, ok bool) {
@@ -63,7 +65,7 @@ func canonMap(chain *chain, in interface{}) (map[string]interface{}, bool) {
 }
 
 func canonValue(chain *chain, in interface{}) (interface{}, bool) {
-	b, err := json.Marshal(in)
+	b, err := marshalJSON(chain.marshaler, in)
 	if err != nil {
 		chain.fail(AssertionFailure{
 			Type:   AssertValid,
@@ -76,8 +78,8 @@ func canonValue(chain *chain, in interface{}) (interface{}, bool) {
 		return nil, false
 	}
 
-	var out interface{}
-	if err := json.Unmarshal(b, &out); err != nil {
+	out, err := jsonDecode(chain, b)
+	if err != nil {
 		chain.fail(AssertionFailure{
 			Type:   AssertValid,
 			Actual: &AssertionValue{in},
@@ -89,5 +91,75 @@ func canonValue(chain *chain, in interface{}) (interface{}, bool) {
 		return nil, false
 	}
 
+	if chain.jsonNumber {
+		out = canonJSONNumbers(out)
+	}
+
 	return out, true
 }
+
+// Replace json.Number values with float64, except numbers that can't
+// be exactly represented by float64, which are kept as json.Number in
+// normalized decimal form.
+func canonJSONNumbers(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = canonJSONNumbers(val)
+		}
+		return v
+
+	case []interface{}:
+		for i, val := range v {
+			v[i] = canonJSONNumbers(val)
+		}
+		return v
+
+	case json.Number:
+		exact, ok := new(big.Rat).SetString(string(v))
+		if !ok {
+			return v
+		}
+		f, err := v.Float64()
+		if err == nil && exact.Cmp(floatDecimal(f)) == 0 {
+			return f
+		}
+		return json.Number(ratDecimal(exact))
+	}
+
+	return in
+}
+
+// Get decimal value of float64, as it's printed using shortest representation,
+// e.g. 0.1 instead of 0.1000000000000000055511151231257827.
+func floatDecimal(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// Format rational number, which has finite decimal representation,
+// as decimal without trailing zeros.
+func ratDecimal(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// find number of fractional digits needed to represent r exactly
+	prec := 0
+	denom := new(big.Int).Set(r.Denom())
+	for _, p := range []int64{2, 5} {
+		n := 0
+		for new(big.Int).Mod(denom, big.NewInt(p)).Sign() == 0 {
+			denom.Div(denom, big.NewInt(p))
+			n++
+		}
+		if n > prec {
+			prec = n
+		}
+	}
+
+	return r.FloatString(prec)
+}
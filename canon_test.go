@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -123,3 +124,39 @@ func TestCanonMap(t *testing.T) {
 	chain.assertFailed(t)
 	chain.clearFailed()
 }
+
+func TestCanonJSONNumber(t *testing.T) {
+	chain := newMockChain(t)
+	chain.jsonNumber = true
+
+	cases := []struct {
+		in  interface{}
+		out interface{}
+	}{
+		{json.Number("123"), 123.0},
+		{json.Number("0.1"), 0.1},
+		{json.Number("0.10"), 0.1},
+		{json.Number("1e3"), 1000.0},
+		{json.Number("9007199254740993"), json.Number("9007199254740993")},
+		{int64(9007199254740993), json.Number("9007199254740993")},
+		{json.Number("123456789012345678.990"), json.Number("123456789012345678.99")},
+		{json.Number("0.12345678901234567890"), json.Number("0.1234567890123456789")},
+		{json.Number("1.2345678901234567890e1"), json.Number("12.34567890123456789")},
+	}
+
+	for _, tc := range cases {
+		out, ok := canonValue(chain, tc.in)
+		assert.True(t, ok)
+		assert.Equal(t, tc.out, out, tc.in)
+	}
+
+	out, ok := canonValue(chain, map[string]interface{}{
+		"a": []interface{}{json.Number("1"), json.Number("18446744073709551617")},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{1.0, json.Number("18446744073709551617")},
+	}, out)
+
+	chain.assertNotFailed(t)
+}
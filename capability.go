@@ -0,0 +1,211 @@
+package httpexpect
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// HeaderList returns a new Array instance with elements of comma-separated
+// list header, e.g. Allow or Vary.
+//
+// Values of all header lines with given name are split by commas (except
+// commas inside quoted strings), trimmed, and empty elements are dropped.
+// If header is missing, empty array is returned.
+//
+// Example:
+//
+//	// Allow: GET, HEAD
+//	// Allow: OPTIONS
+//	resp := NewResponse(t, response)
+//	resp.HeaderList("Allow").ContainsAll("GET", "OPTIONS")
+func (r *Response) HeaderList(name string) *Array {
+	r.chain.enter("HeaderList(%q)", name)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	items := []interface{}{}
+	for _, elem := range splitHeaderList(r.httpResp.Header.Values(name)) {
+		items = append(items, elem)
+	}
+
+	return newArray(r.chain, items)
+}
+
+// Allow succeeds if Allow header lists exactly given methods.
+//
+// Header and methods are compared as sets: order and duplicates are
+// ignored, and header may be split into multiple lines. Methods are
+// case-sensitive. If no methods are given, header should be missing
+// or empty.
+//
+// Example:
+//
+//	// Allow: HEAD, GET, OPTIONS
+//	resp := NewResponse(t, response)
+//	resp.Allow("GET", "HEAD", "OPTIONS")
+func (r *Response) Allow(methods ...string) *Response {
+	r.chain.enter("Allow()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	r.checkHeaderSet("Allow", methods, func(s string) string {
+		return s
+	})
+
+	return r
+}
+
+// AcceptPatch succeeds if Accept-Patch header (RFC 5789) lists exactly
+// given media types.
+//
+// Header and media types are compared as sets: order and duplicates are
+// ignored, and header may be split into multiple lines. Media types are
+// normalized before comparison: type, subtype, and parameter names are
+// case-insensitive, and whitespace is ignored.
+//
+// Example:
+//
+//	// Accept-Patch: application/merge-patch+json, application/json-patch+json
+//	resp := NewResponse(t, response)
+//	resp.AcceptPatch("application/json-patch+json", "application/merge-patch+json")
+func (r *Response) AcceptPatch(mediaTypes ...string) *Response {
+	r.chain.enter("AcceptPatch()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	r.checkHeaderSet("Accept-Patch", mediaTypes, normalizeMediaType)
+
+	return r
+}
+
+// AcceptPost succeeds if Accept-Post header (W3C Linked Data Platform)
+// lists exactly given media types.
+//
+// Comparison rules are the same as for AcceptPatch.
+//
+// Example:
+//
+//	// Accept-Post: text/turtle, application/ld+json
+//	resp := NewResponse(t, response)
+//	resp.AcceptPost("application/ld+json", "text/turtle")
+func (r *Response) AcceptPost(mediaTypes ...string) *Response {
+	r.chain.enter("AcceptPost()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	r.checkHeaderSet("Accept-Post", mediaTypes, normalizeMediaType)
+
+	return r
+}
+
+// Vary succeeds if Vary header lists exactly given header names.
+//
+// Header and names are compared as sets: order and duplicates are
+// ignored, and header may be split into multiple lines. Header names
+// are case-insensitive. Special "*" value is compared as is.
+//
+// Example:
+//
+//	// Vary: accept-encoding, Origin
+//	resp := NewResponse(t, response)
+//	resp.Vary("Origin", "Accept-Encoding")
+func (r *Response) Vary(headers ...string) *Response {
+	r.chain.enter("Vary()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	r.checkHeaderSet("Vary", headers, http.CanonicalHeaderKey)
+
+	return r
+}
+
+func (r *Response) checkHeaderSet(
+	name string, expected []string, normalize func(string) string,
+) {
+	expectedSet := normalizeHeaderSet(expected, normalize)
+	actualSet := normalizeHeaderSet(
+		splitHeaderList(r.httpResp.Header.Values(name)), normalize)
+
+	r.checkEqual(fmt.Sprintf("%q header set", name), expectedSet, actualSet)
+}
+
+// Normalize, deduplicate and sort elements.
+func normalizeHeaderSet(elems []string, normalize func(string) string) []string {
+	set := make(map[string]struct{}, len(elems))
+	for _, elem := range elems {
+		set[normalize(strings.TrimSpace(elem))] = struct{}{}
+	}
+
+	result := make([]string, 0, len(set))
+	for elem := range set {
+		result = append(result, elem)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func normalizeMediaType(s string) string {
+	mediaType, params, err := mime.ParseMediaType(s)
+	if err != nil {
+		return strings.ToLower(s)
+	}
+	return mime.FormatMediaType(mediaType, params)
+}
+
+// Split values of list header into elements, respecting quoted strings.
+func splitHeaderList(values []string) []string {
+	var elems []string
+
+	for _, value := range values {
+		var (
+			start   = 0
+			quoted  = false
+			escaped = false
+		)
+
+		for i := 0; i <= len(value); i++ {
+			if i < len(value) {
+				c := value[i]
+				switch {
+				case escaped:
+					escaped = false
+					continue
+				case quoted && c == '\\':
+					escaped = true
+					continue
+				case c == '"':
+					quoted = !quoted
+					continue
+				case c != ',' || quoted:
+					continue
+				}
+			}
+
+			if elem := strings.TrimSpace(value[start:i]); elem != "" {
+				elems = append(elems, elem)
+			}
+			start = i + 1
+		}
+	}
+
+	return elems
+}
@@ -0,0 +1,126 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+)
+
+func newCapabilityResponse(t *testing.T, header http.Header) *Response {
+	return NewResponse(newMockReporter(t), &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+	})
+}
+
+func TestResponseHeaderList(t *testing.T) {
+	resp := newCapabilityResponse(t, http.Header{
+		"Allow": {"GET, HEAD", " OPTIONS ,,"},
+		"Link":  {`<a>; title="x, y", <b>`},
+	})
+
+	resp.HeaderList("Allow").Equal([]interface{}{"GET", "HEAD", "OPTIONS"})
+	resp.HeaderList("allow").ContainsOnly("OPTIONS", "HEAD", "GET")
+	resp.HeaderList("Link").Equal([]interface{}{`<a>; title="x, y"`, "<b>"})
+	resp.HeaderList("Vary").Empty()
+
+	resp.chain.assertNotFailed(t)
+}
+
+func TestResponseAllow(t *testing.T) {
+	resp := newCapabilityResponse(t, http.Header{
+		"Allow": {"HEAD, GET", "OPTIONS, GET"},
+	})
+
+	resp.Allow("GET", "HEAD", "OPTIONS")
+	resp.chain.assertNotFailed(t)
+
+	resp.Allow("OPTIONS", "GET", "HEAD", "GET")
+	resp.chain.assertNotFailed(t)
+
+	resp.Allow("GET", "HEAD")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Allow("GET", "HEAD", "OPTIONS", "DELETE")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Allow("get", "head", "options")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	empty := newCapabilityResponse(t, http.Header{})
+
+	empty.Allow()
+	empty.chain.assertNotFailed(t)
+
+	empty.Allow("GET")
+	empty.chain.assertFailed(t)
+}
+
+func TestResponseAcceptPatch(t *testing.T) {
+	resp := newCapabilityResponse(t, http.Header{
+		"Accept-Patch": {"application/merge-patch+json, Text/Plain; Charset=utf-8"},
+		"Accept-Post":  {"text/turtle", "application/ld+json"},
+	})
+
+	resp.AcceptPatch("text/plain;charset=utf-8", "application/merge-patch+json")
+	resp.chain.assertNotFailed(t)
+
+	resp.AcceptPatch("application/merge-patch+json")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.AcceptPatch("application/json-patch+json", "text/plain; charset=utf-8")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.AcceptPost("application/ld+json", "TEXT/TURTLE")
+	resp.chain.assertNotFailed(t)
+
+	resp.AcceptPost("text/turtle")
+	resp.chain.assertFailed(t)
+}
+
+func TestResponseVary(t *testing.T) {
+	resp := newCapabilityResponse(t, http.Header{
+		"Vary": {"accept-encoding, Origin", "ORIGIN"},
+	})
+
+	resp.Vary("Origin", "Accept-Encoding")
+	resp.chain.assertNotFailed(t)
+
+	resp.Vary("origin", "ACCEPT-ENCODING")
+	resp.chain.assertNotFailed(t)
+
+	resp.Vary("Origin")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	star := newCapabilityResponse(t, http.Header{
+		"Vary": {"*"},
+	})
+
+	star.Vary("*")
+	star.chain.assertNotFailed(t)
+
+	star.Vary("Origin")
+	star.chain.assertFailed(t)
+}
+
+func TestResponseCapabilityFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	resp := newResponse(responseOpts{
+		config:   newMockConfig(newMockReporter(t)),
+		chain:    chain,
+		httpResp: &http.Response{Header: http.Header{"Allow": {"GET"}}},
+	})
+
+	resp.HeaderList("Allow").chain.assertFailed(t)
+	resp.Allow("GET").chain.assertFailed(t)
+	resp.AcceptPatch().chain.assertFailed(t)
+	resp.AcceptPost().chain.assertFailed(t)
+	resp.Vary().chain.assertFailed(t)
+}
@@ -0,0 +1,266 @@
+package httpexpect
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// Capture records raw bytes sent and received over the wire, and saves
+// them to files when a request fails.
+//
+// Capture works as a wrapper for connections created by http.Transport.
+// For every failed request, it writes two flow files into its directory:
+//   - <name>.request - raw bytes sent by client
+//   - <name>.response - raw bytes received from server
+//
+// These files can be inspected with hex viewers or replayed with external
+// tools, which is useful for debugging framing and encoding issues.
+//
+// Captured bytes are exactly what was written to and read from the
+// connection. Hence, for HTTPS, they are encrypted, and for HTTP/2,
+// they contain frames of all streams multiplexed on connection.
+//
+// Example:
+//
+//	capture := httpexpect.NewCapture(t, "testdata/capture")
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    BaseURL:  server.URL,
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	    Client: &http.Client{
+//	        Transport: capture.Transport(nil),
+//	    },
+//	    Capture: capture,
+//	})
+type Capture struct {
+	logger Logger
+	dir    string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewCapture returns a new Capture instance, which saves flow files
+// into given directory. Directory is created if it doesn't exist.
+//
+// If logger is non-nil, it's used to report paths of saved files
+// and errors.
+func NewCapture(logger Logger, dir string) *Capture {
+	return &Capture{
+		logger: logger,
+		dir:    dir,
+	}
+}
+
+// Transport returns a copy of given transport, which dials connections
+// via capture.
+//
+// If base is nil, http.DefaultTransport is used.
+func (c *Capture) Transport(base *http.Transport) *http.Transport {
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+
+	tr := base.Clone()
+	tr.DialContext = c.WrapDial(base.DialContext)
+
+	return tr
+}
+
+// WrapDial returns dial function, which creates connections using given
+// function and records all bytes written to and read from them.
+//
+// If dial is nil, net.Dialer is used.
+func (c *Capture) WrapDial(
+	dial func(ctx context.Context, network, addr string) (net.Conn, error),
+) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return &captureConn{Conn: conn}, nil
+	}
+}
+
+func (c *Capture) save(name string, out, in []byte) {
+	c.mu.Lock()
+	c.seq++
+	seq := c.seq
+	c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		c.logf("failed to create capture directory: %s", err)
+		return
+	}
+
+	base := filepath.Join(c.dir,
+		fmt.Sprintf("%s-%03d", captureName(name), seq))
+
+	for _, f := range []struct {
+		path string
+		data []byte
+	}{
+		{base + ".request", out},
+		{base + ".response", in},
+	} {
+		if err := ioutil.WriteFile(f.path, f.data, 0644); err != nil {
+			c.logf("failed to write capture file: %s", err)
+			return
+		}
+	}
+
+	c.logf("captured failed request to %s.{request,response}", base)
+}
+
+func (c *Capture) logf(message string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Logf(message, args...)
+	}
+}
+
+var captureNameRe = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+func captureName(name string) string {
+	name = captureNameRe.ReplaceAllString(name, "_")
+	if name == "" {
+		name = "request"
+	}
+	return name
+}
+
+// Connection that records all written and read bytes.
+// Buffers are reset when connection is acquired by a new request.
+type captureConn struct {
+	net.Conn
+
+	mu  sync.Mutex
+	out []byte
+	in  []byte
+}
+
+func (c *captureConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+
+	c.mu.Lock()
+	c.in = append(c.in, b[:n]...)
+	c.mu.Unlock()
+
+	return n, err
+}
+
+func (c *captureConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+
+	c.mu.Lock()
+	c.out = append(c.out, b[:n]...)
+	c.mu.Unlock()
+
+	return n, err
+}
+
+func (c *captureConn) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.out = nil
+	c.in = nil
+}
+
+func (c *captureConn) bytes() ([]byte, []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]byte(nil), c.out...), append([]byte(nil), c.in...)
+}
+
+// Capture state of single Request.Expect call.
+// It is shared between retry attempts; only the last attempt is saved.
+type captureRecord struct {
+	capture *Capture
+	name    string
+
+	mu       sync.Mutex
+	conn     *captureConn
+	out      []byte
+	in       []byte
+	snapshot bool
+	saved    bool
+}
+
+func newCaptureRecord(capture *Capture, name string) *captureRecord {
+	return &captureRecord{
+		capture: capture,
+		name:    name,
+	}
+}
+
+// Start new attempt; returned context tracks connection used by request.
+func (rec *captureRecord) withContext(ctx context.Context) context.Context {
+	rec.mu.Lock()
+	rec.conn = nil
+	rec.out, rec.in = nil, nil
+	rec.snapshot = false
+	rec.mu.Unlock()
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			conn, ok := info.Conn.(*captureConn)
+			if !ok {
+				return
+			}
+
+			conn.reset()
+
+			rec.mu.Lock()
+			rec.conn = conn
+			rec.mu.Unlock()
+		},
+	})
+}
+
+// Copy bytes of current request from connection, before it's reused
+// by another request.
+func (rec *captureRecord) takeSnapshot() {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.conn == nil || rec.snapshot {
+		return
+	}
+
+	rec.out, rec.in = rec.conn.bytes()
+	rec.snapshot = true
+}
+
+// Save recorded bytes; invoked on first failure.
+func (rec *captureRecord) save() {
+	rec.mu.Lock()
+
+	if rec.saved || rec.conn == nil {
+		rec.mu.Unlock()
+		return
+	}
+	rec.saved = true
+
+	out, in := rec.out, rec.in
+	if !rec.snapshot {
+		out, in = rec.conn.bytes()
+	}
+
+	rec.mu.Unlock()
+
+	rec.capture.save(rec.name, out, in)
+}
@@ -0,0 +1,99 @@
+package httpexpect
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("body of " + r.URL.Path))
+		}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "httpexpect-capture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	logger := newMockLogger(t)
+	capture := NewCapture(logger, filepath.Join(dir, "capture"))
+
+	e := WithConfig(Config{
+		TestName: "TestCapture/sub",
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: capture.Transport(nil),
+		},
+		Capture: capture,
+	})
+
+	e.GET("/ok").Expect().Status(http.StatusOK).Body().Equal("body of /ok")
+	assert.False(t, logger.logged)
+
+	resp := e.GET("/bad").Expect()
+	e.GET("/other").Expect().Status(http.StatusOK)
+
+	resp.Status(http.StatusNotFound)
+	resp.Body().Equal("something")
+	resp.chain.assertFailed(t)
+
+	assert.True(t, logger.logged)
+	assert.Contains(t, logger.lastMessage, "TestCapture_sub-001")
+
+	files, err := filepath.Glob(filepath.Join(dir, "capture", "*"))
+	require.NoError(t, err)
+	sort.Strings(files)
+
+	require.Equal(t, 2, len(files))
+	assert.Equal(t, "TestCapture_sub-001.request", filepath.Base(files[0]))
+	assert.Equal(t, "TestCapture_sub-001.response", filepath.Base(files[1]))
+
+	out, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), "GET /bad HTTP/1.1\r\n"))
+
+	in, err := ioutil.ReadFile(files[1])
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(in), "HTTP/1.1 200 OK\r\n"))
+	assert.True(t, strings.HasSuffix(string(in), "body of /bad"))
+}
+
+func TestCaptureNoConn(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect-capture")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	capture := NewCapture(nil, dir)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(http.NotFoundHandler()),
+		},
+		Capture: capture,
+	})
+
+	e.GET("/").Expect().Status(http.StatusOK)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestCaptureName(t *testing.T) {
+	assert.Equal(t, "request", captureName(""))
+	assert.Equal(t, "Test_a_b-c.d", captureName("Test/a b-c.d"))
+}
@@ -2,6 +2,11 @@ package httpexpect
 
 import (
 	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Every matcher struct, e.g. Value, Object, Array, etc. contains a chain instance.
@@ -33,15 +38,74 @@ import (
 //
 //   - context.Path automatically contains a path to current chain from root
 //
+//   - on failure, chain captures where the failed assertion was called from
+//     user code and stores it in context.PathLocations; if location tracking
+//     is enabled, chain remembers location of every element of context.Path
+//
 //   - fail bit is inherited as well; if there were a failure in parent chain,
 //     subsequent failures will be ignored not only in parent chain, but also
 //     in all newly created child chains
+//
+//   - in soft mode (see Expect.SoftAssertions), fail bit of the chain that
+//     reported failure is cleared on next enter, so that next assertion on
+//     the same value is evaluated; child chains created after failure still
+//     inherit fail bit
 type chain struct {
 	context  AssertionContext
 	handler  AssertionHandler
 	severity AssertionSeverity
 	failCb   func()
 	failBit  bool
+
+	// If true, chain is in soft mode.
+	// Children chains inherit this flag.
+	soft bool
+
+	// If true, chain is in soft mode and fail bit was set by this chain,
+	// and should be cleared on next enter.
+	// Children chains don't inherit this flag.
+	softFailed bool
+
+	// Times when every element of context.Path was entered,
+	// relative to chainEpoch.
+	enterTimes []time.Duration
+
+	// Call stacks from which every element of context.Path was entered.
+	// Captured only if trackLocations is set.
+	// Resolved to source locations only on failure.
+	enterStacks [][]uintptr
+
+	// If true, call stack is captured on every enter.
+	// Children chains inherit this flag.
+	trackLocations bool
+
+	// If true, JSON numbers are decoded and canonicalized using json.Number.
+	// Children chains inherit this flag.
+	jsonNumber bool
+
+	// If true, decoding JSON into structs fails on unknown fields.
+	// Children chains inherit this flag.
+	strictDecoding bool
+
+	// If non-nil, used to validate structs after decoding.
+	// Children chains inherit validator.
+	validator StructValidator
+
+	// Fields excluded from object comparisons, split by dot.
+	// Children chains inherit ignored paths.
+	ignorePaths [][]string
+
+	// If non-nil, used to marshal values instead of json.Marshal.
+	// Children chains inherit marshaler.
+	marshaler Marshaler
+
+	// If non-nil, used schemas and files are recorded in manifest.
+	// Children chains inherit manifest.
+	manifest *Manifest
+
+	// If non-nil, invoked on success and failure of assertions.
+	// Children chains inherit hooks.
+	hooks *chainHooks
 }
 
 // Construct chain using config.
@@ -57,8 +121,28 @@ func newChainWithConfig(name string, config Config) *chain {
 
 	c.context.TestName = config.TestName
 
+	c.jsonNumber = config.JSONNumber
+	c.strictDecoding = config.StrictDecoding
+	c.validator = config.Validator
+	c.ignorePaths = splitPaths(config.IgnorePaths)
+	c.marshaler = config.Marshaler
+	c.manifest = config.Manifest
+	c.trackLocations = config.TrackLocations
+
+	if config.OnFailure != nil || config.OnSuccess != nil {
+		c.hooks = &chainHooks{
+			onFailure: config.OnFailure,
+			onSuccess: config.OnSuccess,
+			config:    config,
+		}
+	}
+
 	if name != "" {
 		c.context.Path = []string{name}
+		c.enterTimes = []time.Duration{chainNow()}
+		if c.trackLocations {
+			c.enterStacks = [][]uintptr{callerStack()}
+		}
 	} else {
 		c.context.Path = []string{}
 	}
@@ -86,6 +170,10 @@ func newChainWithDefaults(name string, reporter Reporter) *chain {
 
 	if name != "" {
 		c.context.Path = []string{name}
+		c.enterTimes = []time.Duration{chainNow()}
+		if c.trackLocations {
+			c.enterStacks = [][]uintptr{callerStack()}
+		}
 	} else {
 		c.context.Path = []string{}
 	}
@@ -121,6 +209,24 @@ func (c *chain) setRequestName(name string) {
 	c.context.RequestName = name
 }
 
+// Store expected failure ticket in AssertionContext.
+// Children chains inherit context.
+func (c *chain) setExpectedFailure(ticket string) {
+	c.context.ExpectedFailure = ticket
+}
+
+// Store table case pointer in AssertionContext.
+// Children chains inherit context.
+func (c *chain) setCase(tc *Case) {
+	c.context.Case = tc
+}
+
+// Store array element or object value in AssertionContext.
+// Children chains inherit context.
+func (c *chain) setElement(elem AssertionElement) {
+	c.context.Element = &elem
+}
+
 // Store request pointer in AssertionContext.
 // Children chains inherit context.
 func (c *chain) setRequest(req *Request) {
@@ -133,20 +239,44 @@ func (c *chain) setResponse(resp *Response) {
 	c.context.Response = resp
 }
 
+// Store request phases pointer in AssertionContext.
+// Children chains inherit context.
+func (c *chain) setRequestPhases(phases *RequestPhases) {
+	c.context.RequestPhases = phases
+}
+
 // Create a clone of the chain.
 // Modifications of the clone wont affect the original.
 func (c *chain) clone() *chain {
 	ret := *c
 
+	ret.softFailed = false
+
 	ret.context.Path = nil
 	ret.context.Path = append(ret.context.Path, c.context.Path...)
 
+	ret.enterTimes = nil
+	ret.enterTimes = append(ret.enterTimes, c.enterTimes...)
+
+	ret.enterStacks = nil
+	ret.enterStacks = append(ret.enterStacks, c.enterStacks...)
+
 	return &ret
 }
 
 // Append string to chain path.
+// In soft mode, clears fail bit set by previous assertion.
 func (c *chain) enter(name string, args ...interface{}) {
+	if c.softFailed {
+		c.failBit = false
+		c.softFailed = false
+	}
+
 	c.context.Path = append(c.context.Path, fmt.Sprintf(name, args...))
+	c.enterTimes = append(c.enterTimes, chainNow())
+	if c.trackLocations {
+		c.enterStacks = append(c.enterStacks, callerStack())
+	}
 }
 
 // Replace last element in chain path.
@@ -165,10 +295,108 @@ func (c *chain) leave() {
 	}
 
 	if !c.failBit {
+		c.context.Duration = c.elapsed()
 		c.handler.Success(&c.context)
+
+		if c.hooks != nil && !c.soft {
+			c.hooks.success(&c.context)
+		}
 	}
 
 	c.context.Path = c.context.Path[:len(c.context.Path)-1]
+	if len(c.enterTimes) != 0 {
+		c.enterTimes = c.enterTimes[:len(c.enterTimes)-1]
+	}
+	if len(c.enterStacks) != 0 {
+		c.enterStacks = c.enterStacks[:len(c.enterStacks)-1]
+	}
+}
+
+// Get time elapsed since the last path element was entered.
+func (c *chain) elapsed() time.Duration {
+	if len(c.enterTimes) == 0 {
+		return 0
+	}
+
+	return chainNow() - c.enterTimes[len(c.enterTimes)-1]
+}
+
+// Reference point for enterTimes. Durations are more compact than
+// time.Time and still use monotonic clock.
+var chainEpoch = time.Now()
+
+func chainNow() time.Duration {
+	return time.Since(chainEpoch)
+}
+
+// Get source locations of path elements.
+// Empty string is used when location is unknown.
+//
+// If locations are not tracked, only location of the last element
+// is known, and it's captured from the current call stack.
+func (c *chain) locations() []string {
+	if len(c.context.Path) == 0 {
+		return nil
+	}
+
+	locations := make([]string, len(c.context.Path))
+
+	if c.trackLocations && len(c.enterStacks) == len(c.context.Path) {
+		for n, stack := range c.enterStacks {
+			locations[n] = callerLocation(stack)
+		}
+	} else {
+		locations[len(locations)-1] = callerLocation(callerStack())
+	}
+
+	return locations
+}
+
+// Maximum number of stack frames remembered for every path element.
+const maxCallerDepth = 32
+
+// Directory with sources of this package, used to skip its frames.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// Capture stack of the function that entered chain.
+func callerStack() []uintptr {
+	var pcs [maxCallerDepth]uintptr
+
+	// skip runtime.Callers, callerStack, and its caller
+	n := runtime.Callers(3, pcs[:])
+
+	return append([]uintptr(nil), pcs[:n]...)
+}
+
+// Find first frame outside of this package (except its tests),
+// and format it as "file.go:line".
+func callerLocation(stack []uintptr) string {
+	if len(stack) == 0 {
+		return ""
+	}
+
+	frames := runtime.CallersFrames(stack)
+
+	for {
+		frame, more := frames.Next()
+
+		if frame.File != "" && !isPackageFile(frame.File) &&
+			!strings.HasPrefix(frame.Function, "runtime.") {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+func isPackageFile(file string) bool {
+	return filepath.Dir(file) == packageDir &&
+		!strings.HasSuffix(file, "_test.go")
 }
 
 // If enabled, chain.fail() will panic on illformed AssertionFailure.
@@ -182,12 +410,24 @@ func (c *chain) fail(failure AssertionFailure) {
 		return
 	}
 	c.failBit = true
+	c.softFailed = c.soft
 
 	failure.Severity = c.severity
+	if failure.Code == "" {
+		failure.Code = makeFailureCode(c.context.Path, failure.Type)
+	}
 	if c.severity == SeverityError {
 		failure.IsFatal = true
 	}
 
+	c.context.Duration = c.elapsed()
+	c.context.PathLocations = c.locations()
+
+	// Invoke hook before handler, which may stop the test.
+	if c.hooks != nil && !c.soft && c.severity == SeverityError {
+		c.hooks.failure(&c.context, &failure)
+	}
+
 	c.handler.Failure(&c.context, &failure)
 
 	if c.failCb != nil {
@@ -204,6 +444,49 @@ func (c *chain) fail(failure AssertionFailure) {
 // Set fail bit.
 func (c *chain) setFailed() {
 	c.failBit = true
+	c.softFailed = c.soft
+}
+
+// Hooks from Config.OnFailure and Config.OnSuccess.
+type chainHooks struct {
+	onFailure func(e *Expect, ctx *AssertionContext, failure *AssertionFailure)
+	onSuccess func(e *Expect, ctx *AssertionContext)
+
+	// Expect passed to hooks. It's created lazily from the same config,
+	// but with hooks disabled, so that assertions made by hook itself
+	// don't invoke hooks again.
+	config Config
+	once   sync.Once
+	expect *Expect
+}
+
+func (h *chainHooks) failure(ctx *AssertionContext, failure *AssertionFailure) {
+	if h.onFailure != nil {
+		h.onFailure(h.getExpect(), ctx, failure)
+	}
+}
+
+func (h *chainHooks) success(ctx *AssertionContext) {
+	if h.onSuccess != nil {
+		h.onSuccess(h.getExpect(), ctx)
+	}
+}
+
+func (h *chainHooks) getExpect() *Expect {
+	h.once.Do(func() {
+		config := h.config
+
+		config.OnFailure = nil
+		config.OnSuccess = nil
+
+		h.expect = &Expect{
+			chain:  newChainWithConfig("", config),
+			config: config,
+			queue:  &requestQueue{},
+		}
+	})
+
+	return h.expect
 }
 
 // Clear fail bit.
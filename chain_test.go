@@ -2,9 +2,13 @@ package httpexpect
 
 import (
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestChainFail(t *testing.T) {
@@ -275,3 +279,214 @@ func TestChainCallback(t *testing.T) {
 	chain.fail(mockFailure())
 	assert.True(t, called)
 }
+
+func TestChainHooks(t *testing.T) {
+	var (
+		successes []string
+		failures  []string
+	)
+
+	chain := newChainWithConfig("test", Config{
+		AssertionHandler: &mockAssertionHandler{},
+		OnSuccess: func(e *Expect, ctx *AssertionContext) {
+			successes = append(successes, strings.Join(ctx.Path, "."))
+		},
+		OnFailure: func(e *Expect, ctx *AssertionContext, failure *AssertionFailure) {
+			failures = append(failures, strings.Join(ctx.Path, "."))
+		},
+	}.withDefaults())
+
+	chain.enter("foo")
+	chain.leave()
+
+	assert.Equal(t, []string{"test.foo"}, successes)
+	assert.Empty(t, failures)
+
+	chain.enter("bar")
+	chain.fail(mockFailure())
+	chain.leave()
+
+	assert.Equal(t, []string{"test.foo"}, successes)
+	assert.Equal(t, []string{"test.bar"}, failures)
+
+	t.Run("severity", func(t *testing.T) {
+		failures = nil
+
+		child := chain.clone()
+		child.clearFailed()
+		child.setSeverity(SeverityLog)
+		child.fail(mockFailure())
+
+		assert.Empty(t, failures)
+	})
+
+	t.Run("soft", func(t *testing.T) {
+		failures = nil
+
+		child := chain.clone()
+		child.clearFailed()
+		child.soft = true
+		child.fail(mockFailure())
+
+		assert.Empty(t, failures)
+	})
+
+	t.Run("recursion", func(t *testing.T) {
+		var (
+			failures  int
+			successes int
+			hookE     *Expect
+		)
+
+		root := newChainWithConfig("test", Config{
+			Reporter:         newMockReporter(t),
+			AssertionHandler: &mockAssertionHandler{},
+			OnSuccess: func(e *Expect, ctx *AssertionContext) {
+				successes++
+			},
+			OnFailure: func(e *Expect, ctx *AssertionContext, failure *AssertionFailure) {
+				failures++
+				hookE = e
+
+				e.Value(1).Equal(1)
+				e.Value(1).Equal(2)
+			},
+		}.withDefaults())
+
+		root.fail(mockFailure())
+		assert.Equal(t, 1, failures)
+		assert.Equal(t, 0, successes)
+
+		root.clearFailed()
+		root.fail(mockFailure())
+		assert.Equal(t, 2, failures)
+		assert.Equal(t, 0, successes)
+
+		require.NotNil(t, hookE)
+		assert.Nil(t, hookE.chain.hooks)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		const numGoroutines = 10
+
+		var calls int32
+
+		root := newChainWithConfig("test", Config{
+			AssertionHandler: &mockAssertionHandler{},
+			OnFailure: func(e *Expect, ctx *AssertionContext, failure *AssertionFailure) {
+				atomic.AddInt32(&calls, 1)
+				// keep hook running while other goroutines fail
+				time.Sleep(10 * time.Millisecond)
+			},
+		}.withDefaults())
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < numGoroutines; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				child := root.clone()
+				child.handler = &mockAssertionHandler{}
+				child.fail(mockFailure())
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(numGoroutines), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestChainDuration(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	chain := newChainWithConfig("root", Config{
+		AssertionHandler: handler,
+	}.withDefaults())
+
+	chain.enter("outer")
+
+	chain.enter("inner")
+	time.Sleep(10 * time.Millisecond)
+	chain.leave()
+
+	inner := handler.ctx.Duration
+	assert.GreaterOrEqual(t, int64(inner), int64(10*time.Millisecond))
+
+	child := chain.clone()
+
+	time.Sleep(10 * time.Millisecond)
+	chain.leave()
+
+	assert.Greater(t, int64(handler.ctx.Duration), int64(inner))
+
+	child.enter("fail")
+	child.fail(mockFailure())
+
+	assert.Less(t, int64(handler.ctx.Duration), int64(inner))
+}
+
+func TestChainLocations(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		root := newChainWithConfig("root", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		root.enter("outer")
+
+		helper := func(c *chain) {
+			c.enter("inner")
+			c.fail(mockFailure())
+		}
+
+		helper(root.clone())
+
+		assert.NotNil(t, handler.failure)
+		assert.Equal(t, []string{"root", "outer", "inner"}, handler.ctx.Path)
+		assert.Equal(t, 3, len(handler.ctx.PathLocations))
+
+		assert.Empty(t, root.enterStacks)
+
+		assert.Equal(t, "", handler.ctx.PathLocations[0])
+		assert.Equal(t, "", handler.ctx.PathLocations[1])
+		assert.True(t,
+			strings.HasPrefix(handler.ctx.PathLocations[2], "chain_test.go:"),
+			handler.ctx.PathLocations[2])
+	})
+
+	t.Run("tracked", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		root := newChainWithConfig("root", Config{
+			AssertionHandler: handler,
+			TrackLocations:   true,
+		}.withDefaults())
+
+		root.enter("outer")
+
+		helper := func(c *chain) {
+			c.enter("inner")
+			c.fail(mockFailure())
+		}
+
+		helper(root.clone())
+
+		assert.NotNil(t, handler.failure)
+		assert.Equal(t, []string{"root", "outer", "inner"}, handler.ctx.Path)
+		assert.Equal(t, 3, len(handler.ctx.PathLocations))
+
+		for _, loc := range handler.ctx.PathLocations {
+			assert.True(t, strings.HasPrefix(loc, "chain_test.go:"), loc)
+		}
+
+		assert.NotEqual(t,
+			handler.ctx.PathLocations[0], handler.ctx.PathLocations[1])
+		assert.NotEqual(t,
+			handler.ctx.PathLocations[1], handler.ctx.PathLocations[2])
+	})
+}
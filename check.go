@@ -0,0 +1,164 @@
+package httpexpect
+
+import (
+	"errors"
+)
+
+// Check is a single named response assertion, part of CheckBundle.
+type Check struct {
+	// Name of the check, shown in assertion path on failure.
+	Name string
+
+	// Func performs assertions on response.
+	Func func(*Response)
+}
+
+// CheckBundle is a named reusable set of response assertions.
+//
+// Bundles allow to declare common cross-cutting response requirements
+// once (e.g. standard security headers) and apply them to many responses.
+//
+// Use Checks to create a bundle and Response.Check to apply it.
+type CheckBundle struct {
+	name   string
+	checks []Check
+}
+
+// Checks returns a new CheckBundle with given name and checks.
+//
+// Example:
+//
+//	var StandardHeaders = httpexpect.Checks("StandardHeaders",
+//	    httpexpect.Check{
+//	        Name: "ContentTypeOptions",
+//	        Func: func(resp *httpexpect.Response) {
+//	            resp.Header("X-Content-Type-Options").Equal("nosniff")
+//	        },
+//	    },
+//	    httpexpect.Check{
+//	        Name: "RequestID",
+//	        Func: func(resp *httpexpect.Response) {
+//	            resp.Header("X-Request-Id").NotEmpty()
+//	        },
+//	    },
+//	)
+func Checks(name string, checks ...Check) *CheckBundle {
+	return &CheckBundle{
+		name:   name,
+		checks: append([]Check(nil), checks...),
+	}
+}
+
+// Name returns bundle name.
+func (b *CheckBundle) Name() string {
+	return b.name
+}
+
+// With returns a new bundle with the same name that contains all
+// checks from this bundle plus given checks.
+//
+// Example:
+//
+//	var APIHeaders = StandardHeaders.With(httpexpect.Check{
+//	    Name: "APIVersion",
+//	    Func: func(resp *httpexpect.Response) {
+//	        resp.Header("API-Version").NotEmpty()
+//	    },
+//	})
+func (b *CheckBundle) With(checks ...Check) *CheckBundle {
+	ret := &CheckBundle{
+		name: b.name,
+	}
+
+	ret.checks = append(ret.checks, b.checks...)
+	ret.checks = append(ret.checks, checks...)
+
+	return ret
+}
+
+// Matcher returns function that applies bundle to response.
+// It can be passed to Expect.Matcher or Request.WithMatcher.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//	e = e.Matcher(StandardHeaders.Matcher())
+func (b *CheckBundle) Matcher() func(*Response) {
+	return func(resp *Response) {
+		resp.Check(b)
+	}
+}
+
+// Check applies given bundles of assertions to response.
+//
+// Every check of every bundle is run, even if some of them fail, so that
+// all violated requirements are reported at once. Assertion path of
+// failures includes bundle and check names, e.g.:
+//
+//	Response().Check("StandardHeaders").RequestID().Header("X-Request-Id")
+//
+// If any check fails, response is marked as failed.
+//
+// Example:
+//
+//	resp := e.GET("/path").Expect()
+//	resp.Check(StandardHeaders, CacheHeaders)
+func (r *Response) Check(bundles ...*CheckBundle) *Response {
+	for _, bundle := range bundles {
+		r.checkBundle(bundle)
+	}
+
+	return r
+}
+
+func (r *Response) checkBundle(bundle *CheckBundle) {
+	if bundle == nil {
+		r.chain.enter("Check()")
+		defer r.chain.leave()
+
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil bundle argument"),
+			},
+		})
+		return
+	}
+
+	r.chain.enter("Check(%q)", bundle.name)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return
+	}
+
+	failed := false
+
+	// Failures are usually reported by child matchers (e.g. String returned
+	// by Header), so we use fail callback inherited by children to detect them.
+	parentCb := r.chain.failCb
+	failCb := func() {
+		failed = true
+		if parentCb != nil {
+			parentCb()
+		}
+	}
+
+	for _, check := range bundle.checks {
+		if check.Func == nil {
+			continue
+		}
+
+		resp := *r
+		resp.chain = r.chain.clone()
+		resp.chain.setFailCallback(failCb)
+
+		resp.chain.enter("%s()", check.Name)
+		check.Func(&resp)
+		resp.chain.leave()
+	}
+
+	if failed {
+		r.chain.setFailed()
+	}
+}
@@ -0,0 +1,157 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCheckBundle = Checks("Standard",
+	Check{
+		Name: "ContentTypeOptions",
+		Func: func(resp *Response) {
+			resp.Header("X-Content-Type-Options").Equal("nosniff")
+		},
+	},
+	Check{
+		Name: "RequestID",
+		Func: func(resp *Response) {
+			resp.Header("X-Request-Id").NotEmpty()
+		},
+	},
+)
+
+func newCheckResponse(handler AssertionHandler, header http.Header) *Response {
+	config := Config{
+		AssertionHandler: handler,
+	}.withDefaults()
+
+	return newResponse(responseOpts{
+		config: config,
+		chain:  newChainWithConfig("Response()", config),
+		httpResp: &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+		},
+	})
+}
+
+func TestCheckBundleSuccess(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	resp := newCheckResponse(handler, http.Header{
+		"X-Content-Type-Options": {"nosniff"},
+		"X-Request-Id":           {"123"},
+	})
+
+	resp.Check(testCheckBundle)
+	resp.chain.assertNotFailed(t)
+
+	assert.Nil(t, handler.failure)
+	assert.Equal(t, "Standard", testCheckBundle.Name())
+}
+
+func TestCheckBundleFailure(t *testing.T) {
+	var failures []*AssertionFailure
+	var paths [][]string
+
+	handler := &mockAssertionHandler{}
+
+	resp := newCheckResponse(&checkRecordingHandler{
+		handler: handler,
+		onFailure: func(ctx *AssertionContext, failure *AssertionFailure) {
+			failures = append(failures, failure)
+			paths = append(paths, append([]string(nil), ctx.Path...))
+		},
+	}, http.Header{})
+
+	resp.Check(testCheckBundle)
+	resp.chain.assertFailed(t)
+
+	require.Equal(t, 2, len(failures))
+
+	assert.Equal(t, []string{
+		"Response()",
+		`Check("Standard")`,
+		"ContentTypeOptions()",
+		`Header("X-Content-Type-Options")`,
+		"Equal()",
+	}, paths[0])
+
+	assert.Equal(t, []string{
+		"Response()",
+		`Check("Standard")`,
+		"RequestID()",
+		`Header("X-Request-Id")`,
+		"NotEmpty()",
+	}, paths[1])
+}
+
+func TestCheckBundleWith(t *testing.T) {
+	called := false
+
+	extended := testCheckBundle.With(Check{
+		Name: "Extra",
+		Func: func(resp *Response) {
+			called = true
+			resp.Status(http.StatusOK)
+		},
+	})
+
+	assert.Equal(t, 2, len(testCheckBundle.checks))
+	assert.Equal(t, 3, len(extended.checks))
+
+	resp := newCheckResponse(&mockAssertionHandler{}, http.Header{
+		"X-Content-Type-Options": {"nosniff"},
+		"X-Request-Id":           {"123"},
+	})
+
+	extended.Matcher()(resp)
+	resp.chain.assertNotFailed(t)
+
+	assert.True(t, called)
+}
+
+func TestCheckBundleMatcher(t *testing.T) {
+	client := &mockClient{
+		resp: http.Response{StatusCode: http.StatusOK},
+	}
+	handler := &mockAssertionHandler{}
+
+	e := WithConfig(Config{
+		Client:           client,
+		AssertionHandler: handler,
+	})
+
+	e = e.Matcher(testCheckBundle.Matcher())
+
+	resp := e.GET("/path").WithHeader("X-Request-Id", "1").Expect()
+	resp.chain.assertFailed(t)
+
+	require.NotNil(t, handler.failure)
+}
+
+func TestCheckBundleNil(t *testing.T) {
+	resp := newCheckResponse(&mockAssertionHandler{}, http.Header{})
+
+	resp.Check(nil)
+	resp.chain.assertFailed(t)
+}
+
+type checkRecordingHandler struct {
+	handler   AssertionHandler
+	onFailure func(*AssertionContext, *AssertionFailure)
+}
+
+func (h *checkRecordingHandler) Success(ctx *AssertionContext) {
+	h.handler.Success(ctx)
+}
+
+func (h *checkRecordingHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.onFailure(ctx, failure)
+	h.handler.Failure(ctx, failure)
+}
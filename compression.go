@@ -0,0 +1,184 @@
+package httpexpect
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content codings supported by Request.WithCompression and decoded
+// automatically in Response.
+var supportedEncodings = []string{"gzip", "deflate", "br"}
+
+func isSupportedEncoding(encoding string) bool {
+	for _, e := range supportedEncodings {
+		if e == encoding {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns list of codings from Content-Encoding header, in order in which
+// they were applied. Header may be repeated, and every value may be
+// a comma-separated list.
+func contentEncodings(header http.Header) []string {
+	var encodings []string
+
+	for _, value := range header.Values("Content-Encoding") {
+		for _, e := range strings.Split(value, ",") {
+			e = strings.ToLower(strings.TrimSpace(e))
+			switch e {
+			case "", "identity":
+				continue
+			case "x-gzip":
+				e = "gzip"
+			}
+			encodings = append(encodings, e)
+		}
+	}
+
+	return encodings
+}
+
+// Checks if body with given codings can be decoded. Bodies with
+// unknown codings (e.g. "compress") are left as is.
+func canDecode(encodings []string) bool {
+	if len(encodings) == 0 {
+		return false
+	}
+
+	for _, e := range encodings {
+		if !isSupportedEncoding(e) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Decodes whole body. Codings are removed in reverse order.
+func decompressContent(encodings []string, content []byte) ([]byte, error) {
+	if len(content) == 0 {
+		return content, nil
+	}
+
+	reader, err := newDecompressReader(bytes.NewReader(content), encodings)
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(reader)
+}
+
+func newDecompressReader(reader io.Reader, encodings []string) (io.Reader, error) {
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var err error
+
+		switch encodings[i] {
+		case "gzip":
+			reader, err = gzip.NewReader(reader)
+
+		case "deflate":
+			reader, err = newDeflateReader(reader)
+
+		case "br":
+			reader = brotli.NewReader(reader)
+
+		default:
+			err = fmt.Errorf("unsupported content coding %q", encodings[i])
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reader, nil
+}
+
+// HTTP "deflate" coding is zlib format (RFC 9110), but some servers send
+// raw deflate stream without zlib header, so both are accepted.
+func newDeflateReader(reader io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(reader)
+
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	// zlib header: CM=8 in low bits of CMF, and CMF*256+FLG divisible by 31
+	if header[0]&0x0f == 8 && (uint(header[0])<<8|uint(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
+
+// Encodes whole body with given coding.
+func compressContent(encoding string, content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var writer io.WriteCloser
+
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+
+	case "deflate":
+		writer = zlib.NewWriter(&buf)
+
+	case "br":
+		writer = brotli.NewWriter(&buf)
+
+	default:
+		return nil, fmt.Errorf("unsupported content coding %q", encoding)
+	}
+
+	if _, err := writer.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decodes response body lazily, when it's read for the first time,
+// so that creating reader doesn't block waiting for body.
+type decompressReader struct {
+	body      io.ReadCloser
+	encodings []string
+	reader    io.Reader
+	err       error
+}
+
+func (d *decompressReader) Read(p []byte) (int, error) {
+	if d.reader == nil && d.err == nil {
+		d.reader, d.err = newDecompressReader(d.body, d.encodings)
+	}
+
+	if d.err != nil {
+		if d.err == io.EOF {
+			return 0, io.EOF
+		}
+		return 0, fmt.Errorf("failed to decompress response body: %w", d.err)
+	}
+
+	return d.reader.Read(p)
+}
+
+func (d *decompressReader) Close() error {
+	return d.body.Close()
+}
@@ -0,0 +1,218 @@
+package httpexpect
+
+import (
+	"bytes"
+	"compress/flate"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	for _, encoding := range supportedEncodings {
+		t.Run(encoding, func(t *testing.T) {
+			compressed, err := compressContent(encoding, []byte("hello, world!"))
+			require.NoError(t, err)
+			assert.NotEqual(t, "hello, world!", string(compressed))
+
+			decoded, err := decompressContent([]string{encoding}, compressed)
+			require.NoError(t, err)
+			assert.Equal(t, "hello, world!", string(decoded))
+		})
+	}
+
+	t.Run("multiple", func(t *testing.T) {
+		compressed, err := compressContent("gzip", []byte("hello, world!"))
+		require.NoError(t, err)
+
+		compressed, err = compressContent("br", compressed)
+		require.NoError(t, err)
+
+		decoded, err := decompressContent([]string{"gzip", "br"}, compressed)
+		require.NoError(t, err)
+		assert.Equal(t, "hello, world!", string(decoded))
+	})
+
+	t.Run("raw deflate", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		require.NoError(t, err)
+		_, _ = w.Write([]byte("hello, world!"))
+		require.NoError(t, w.Close())
+
+		decoded, err := decompressContent([]string{"deflate"}, buf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "hello, world!", string(decoded))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := compressContent("compress", []byte("hello"))
+		assert.Error(t, err)
+	})
+}
+
+func TestCompressionEncodings(t *testing.T) {
+	cases := []struct {
+		header    []string
+		encodings []string
+		decode    bool
+	}{
+		{nil, nil, false},
+		{[]string{"identity"}, nil, false},
+		{[]string{"gzip"}, []string{"gzip"}, true},
+		{[]string{"X-Gzip"}, []string{"gzip"}, true},
+		{[]string{"gzip, br"}, []string{"gzip", "br"}, true},
+		{[]string{"deflate", "br"}, []string{"deflate", "br"}, true},
+		{[]string{"compress"}, []string{"compress"}, false},
+		{[]string{"gzip", "compress"}, []string{"gzip", "compress"}, false},
+	}
+
+	for _, tc := range cases {
+		header := http.Header{}
+		for _, v := range tc.header {
+			header.Add("Content-Encoding", v)
+		}
+
+		encodings := contentEncodings(header)
+
+		assert.Equal(t, tc.encodings, encodings)
+		assert.Equal(t, tc.decode, canDecode(encodings))
+	}
+}
+
+func TestCompressionResponse(t *testing.T) {
+	newResp := func(t *testing.T, encoding string, body []byte) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			Header: http.Header{
+				"Content-Encoding": {encoding},
+				"Content-Type":     {"application/json"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(body)),
+		})
+	}
+
+	for _, encoding := range supportedEncodings {
+		t.Run(encoding, func(t *testing.T) {
+			body, err := compressContent(encoding, []byte(`{"foo":123}`))
+			require.NoError(t, err)
+
+			resp := newResp(t, encoding, body)
+
+			resp.ContentEncoding(encoding)
+			resp.chain.assertNotFailed(t)
+
+			resp.Body().Equal(`{"foo":123}`)
+			resp.JSON().Object().ValueEqual("foo", 123)
+			resp.chain.assertNotFailed(t)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		resp := newResp(t, "compress", []byte("raw"))
+
+		resp.Body().Equal("raw")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		resp := newResp(t, "gzip", []byte("not gzip"))
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		body, err := compressContent("gzip", []byte("line1\nline2\n"))
+		require.NoError(t, err)
+
+		config := Config{
+			Reporter: newMockReporter(t),
+		}.withDefaults()
+
+		resp := newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("Response()", config),
+			httpResp: &http.Response{
+				Header: http.Header{
+					"Content-Encoding": {"gzip"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader(body)),
+			},
+			streaming: true,
+		})
+
+		stream := resp.BodyStream()
+		stream.ReadLine().Equal("line1")
+		stream.ReadLine().Equal("line2")
+		stream.Terminates()
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("uncompressed by transport", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			Header:       http.Header{},
+			Body:         ioutil.NopCloser(strings.NewReader("hello")),
+			Uncompressed: true,
+		})
+
+		resp.ContentEncoding("gzip")
+		resp.chain.assertNotFailed(t)
+
+		resp.Body().Equal("hello")
+		resp.chain.assertNotFailed(t)
+	})
+}
+
+func TestCompressionRequest(t *testing.T) {
+	newConfig := func(t *testing.T) Config {
+		return Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						encodings := contentEncodings(r.Header)
+
+						body, _ := ioutil.ReadAll(r.Body)
+						if canDecode(encodings) {
+							body, _ = decompressContent(encodings, body)
+						}
+
+						w.Header().Set("X-Content-Encoding",
+							r.Header.Get("Content-Encoding"))
+						_, _ = w.Write(body)
+					})),
+			},
+		}
+	}
+
+	for _, encoding := range supportedEncodings {
+		t.Run(encoding, func(t *testing.T) {
+			req := NewRequestC(newConfig(t), "POST", "http://example.com")
+			req.WithCompression(encoding).WithText("hello, world!")
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			resp.Header("X-Content-Encoding").Equal(encoding)
+			resp.Body().Equal("hello, world!")
+		})
+	}
+
+	t.Run("no body", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "GET", "http://example.com")
+		req.WithCompression("gzip")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.Header("X-Content-Encoding").Empty()
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "POST", "http://example.com")
+		req.WithCompression("compress")
+		req.chain.assertFailed(t)
+	})
+}
@@ -0,0 +1,219 @@
+package httpexpect
+
+import (
+	"errors"
+)
+
+// ResponseCondition runs assertions on response depending on predicate,
+// see Response.If.
+type ResponseCondition struct {
+	chain   *chain
+	resp    *Response
+	matched bool
+}
+
+// If evaluates given predicate on response and returns ResponseCondition,
+// that can be used to run assertions only when predicate is true (Then)
+// or false (Else).
+//
+// If there are any failed assertions in the predicate, the predicate is
+// considered false, without causing test failure. This allows to use
+// regular assertions as predicates.
+//
+// Failed assertions inside Then and Else functions are reported as usual
+// and mark response as failed.
+//
+// Example:
+//
+//	resp := e.GET("/features").Expect()
+//
+//	resp.If(func(resp *httpexpect.Response) bool {
+//	    resp.Header("X-Feature-Flags").Contains("new-billing")
+//	    return true
+//	}).Then(func(resp *httpexpect.Response) {
+//	    resp.JSON().Object().ContainsKey("billing")
+//	}).Else(func(resp *httpexpect.Response) {
+//	    resp.JSON().Object().NotContainsKey("billing")
+//	})
+func (r *Response) If(pred func(resp *Response) bool) *ResponseCondition {
+	r.chain.enter("If()")
+	defer r.chain.leave()
+
+	cond := &ResponseCondition{
+		resp: r,
+	}
+
+	if r.chain.failed() {
+		cond.chain = r.chain.clone()
+		return cond
+	}
+
+	if pred == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		cond.chain = r.chain.clone()
+		return cond
+	}
+
+	predResp := *r
+	predResp.chain = r.chain.clone()
+	predResp.chain.setSeverity(SeverityLog)
+	predFailed := false
+	predResp.chain.setFailCallback(func() {
+		predFailed = true
+	})
+
+	cond.matched = pred(&predResp) && !predFailed
+
+	// Failures are usually reported by children (e.g. Object returned by
+	// JSON), so we use fail callback inherited by children to detect them.
+	parentCb := r.chain.failCb
+	cond.chain = r.chain.clone()
+	cond.chain.setFailCallback(func() {
+		r.chain.setFailed()
+		if parentCb != nil {
+			parentCb()
+		}
+	})
+
+	return cond
+}
+
+// Then invokes given function if predicate passed to Response.If is true.
+//
+// Example:
+//
+//	resp.If(func(resp *httpexpect.Response) bool {
+//	    return resp.Raw().StatusCode == http.StatusOK
+//	}).Then(func(resp *httpexpect.Response) {
+//	    resp.JSON().Object().ContainsKey("items")
+//	})
+func (c *ResponseCondition) Then(fn func(resp *Response)) *ResponseCondition {
+	c.chain.enter("Then()")
+	defer c.chain.leave()
+
+	c.run(fn, c.matched)
+	return c
+}
+
+// Else invokes given function if predicate passed to Response.If is false.
+//
+// Example:
+//
+//	resp.If(func(resp *httpexpect.Response) bool {
+//	    return resp.Raw().StatusCode == http.StatusOK
+//	}).Then(func(resp *httpexpect.Response) {
+//	    resp.JSON().Object().ContainsKey("items")
+//	}).Else(func(resp *httpexpect.Response) {
+//	    resp.JSON().Object().ContainsKey("error")
+//	})
+func (c *ResponseCondition) Else(fn func(resp *Response)) *ResponseCondition {
+	c.chain.enter("Else()")
+	defer c.chain.leave()
+
+	c.run(fn, !c.matched)
+	return c
+}
+
+func (c *ResponseCondition) run(fn func(resp *Response), enabled bool) {
+	if c.chain.failed() {
+		return
+	}
+
+	if fn == nil {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return
+	}
+
+	if !enabled {
+		return
+	}
+
+	resp := *c.resp
+	resp.chain = c.chain.clone()
+
+	fn(&resp)
+}
+
+// IfPresent invokes given function if value is present, i.e. is not null.
+//
+// It allows to check optional values without breaking the chain.
+// To check optional object field, that may be missing, use Object.IfPresent.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"nickname": nil})
+//
+//	value.Path("$.nickname").IfPresent(func(value *httpexpect.Value) {
+//	    value.String().NotEmpty()
+//	})
+func (v *Value) IfPresent(fn func(value *Value)) *Value {
+	v.chain.enter("IfPresent()")
+	defer v.chain.leave()
+
+	if v.chain.failed() {
+		return v
+	}
+
+	if fn == nil {
+		v.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return v
+	}
+
+	if v.value != nil {
+		fn(newValue(v.chain, v.value))
+	}
+
+	return v
+}
+
+// IfPresent invokes given function with object value for given key,
+// if object contains the key and the value is not null.
+//
+// Unlike Value, it doesn't report failure if key is missing.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"name": "john"})
+//
+//	object.IfPresent("nickname", func(value *httpexpect.Value) {
+//	    value.String().NotEmpty()
+//	})
+func (o *Object) IfPresent(key string, fn func(value *Value)) *Object {
+	o.chain.enter("IfPresent(%q)", key)
+	defer o.chain.leave()
+
+	if o.chain.failed() {
+		return o
+	}
+
+	if fn == nil {
+		o.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return o
+	}
+
+	if value, ok := o.value[key]; ok && value != nil {
+		fn(newValue(o.chain, value))
+	}
+
+	return o
+}
@@ -0,0 +1,212 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type conditionPathHandler struct {
+	path []string
+}
+
+func (h *conditionPathHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *conditionPathHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.path = append([]string(nil), ctx.Path...)
+}
+
+func newConditionResponse(reporter Reporter, flags string) *Response {
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type":    []string{"application/json"},
+			"X-Feature-Flags": []string{flags},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{"billing": {}}`)),
+	}
+
+	return NewResponse(reporter, httpResp)
+}
+
+func TestResponseConditionThen(t *testing.T) {
+	cases := []struct {
+		name     string
+		flags    string
+		wantThen bool
+		wantElse bool
+	}{
+		{
+			name:     "predicate true",
+			flags:    "new-billing",
+			wantThen: true,
+		},
+		{
+			name:     "predicate false",
+			flags:    "",
+			wantElse: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := newConditionResponse(reporter, tc.flags)
+
+			calledThen := false
+			calledElse := false
+
+			resp.If(func(resp *Response) bool {
+				// failed assertion makes predicate false
+				resp.Header("X-Feature-Flags").Contains("new-billing")
+				return true
+			}).Then(func(resp *Response) {
+				calledThen = true
+				resp.JSON().Object().ContainsKey("billing")
+			}).Else(func(resp *Response) {
+				calledElse = true
+			})
+
+			assert.Equal(t, tc.wantThen, calledThen)
+			assert.Equal(t, tc.wantElse, calledElse)
+
+			assert.False(t, reporter.reported)
+			resp.chain.assertNotFailed(t)
+		})
+	}
+}
+
+func TestResponseConditionFailure(t *testing.T) {
+	handler := &conditionPathHandler{}
+
+	chain := newChainWithConfig("test", Config{
+		AssertionHandler: handler,
+	}.withDefaults())
+
+	resp := newResponse(responseOpts{
+		config: newMockConfig(newMockReporter(t)),
+		chain:  chain,
+		httpResp: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+		},
+	})
+	resp.chain.assertNotFailed(t)
+
+	resp.If(func(resp *Response) bool {
+		return true
+	}).Then(func(resp *Response) {
+		resp.Status(http.StatusNotFound)
+	})
+
+	resp.chain.assertFailed(t)
+
+	assert.Equal(t, []string{"test", "If()", "Then()", "Status()"}, handler.path)
+}
+
+func TestResponseConditionUsage(t *testing.T) {
+	t.Run("nil predicate", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newConditionResponse(reporter, "")
+
+		called := false
+		resp.If(nil).Then(func(resp *Response) {
+			called = true
+		})
+
+		assert.False(t, called)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newConditionResponse(reporter, "")
+
+		resp.If(func(resp *Response) bool {
+			return true
+		}).Then(nil)
+
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestValueIfPresent(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("present", func(t *testing.T) {
+		value := NewValue(reporter, "foo")
+
+		called := false
+		value.IfPresent(func(value *Value) {
+			called = true
+			value.String().Equal("foo")
+			value.chain.assertNotFailed(t)
+		})
+
+		assert.True(t, called)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		value := NewValue(reporter, nil)
+
+		called := false
+		value.IfPresent(func(value *Value) {
+			called = true
+		})
+
+		assert.False(t, called)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		value := NewValue(reporter, "foo")
+
+		value.IfPresent(nil)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestObjectIfPresent(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	object := NewObject(reporter, map[string]interface{}{
+		"name":     "john",
+		"nickname": nil,
+	})
+
+	cases := []struct {
+		key        string
+		wantCalled bool
+	}{
+		{key: "name", wantCalled: true},
+		{key: "nickname", wantCalled: false},
+		{key: "missing", wantCalled: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.key, func(t *testing.T) {
+			called := false
+			object.IfPresent(tc.key, func(value *Value) {
+				called = true
+				value.String().NotEmpty()
+				value.chain.assertNotFailed(t)
+			})
+
+			assert.Equal(t, tc.wantCalled, called)
+			object.chain.assertNotFailed(t)
+		})
+	}
+
+	object.IfPresent("name", nil)
+	object.chain.assertFailed(t)
+}
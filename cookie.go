@@ -2,7 +2,10 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -236,3 +239,263 @@ func (c *Cookie) MaxAge() *Duration {
 		return newDuration(c.chain, &age)
 	}
 }
+
+// HaveExpires succeeds if cookie has Expires field.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HaveExpires()
+func (c *Cookie) HaveExpires() *Cookie {
+	c.chain.enter("HaveExpires()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("Expires", !c.value.Expires.IsZero(), true)
+
+	return c
+}
+
+// NotHaveExpires succeeds if cookie does not have Expires field.
+//
+// Cookie without both Expires and Max-Age fields is a session cookie.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHaveExpires()
+//	cookie.NotHaveMaxAge()
+func (c *Cookie) NotHaveExpires() *Cookie {
+	c.chain.enter("NotHaveExpires()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("Expires", !c.value.Expires.IsZero(), false)
+
+	return c
+}
+
+// HaveSecure succeeds if cookie has Secure flag.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HaveSecure()
+func (c *Cookie) HaveSecure() *Cookie {
+	c.chain.enter("HaveSecure()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("Secure", c.value.Secure, true)
+
+	return c
+}
+
+// NotHaveSecure succeeds if cookie does not have Secure flag.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHaveSecure()
+func (c *Cookie) NotHaveSecure() *Cookie {
+	c.chain.enter("NotHaveSecure()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("Secure", c.value.Secure, false)
+
+	return c
+}
+
+// HaveHttpOnly succeeds if cookie has HttpOnly flag.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HaveHttpOnly()
+func (c *Cookie) HaveHttpOnly() *Cookie {
+	c.chain.enter("HaveHttpOnly()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("HttpOnly", c.value.HttpOnly, true)
+
+	return c
+}
+
+// NotHaveHttpOnly succeeds if cookie does not have HttpOnly flag.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHaveHttpOnly()
+func (c *Cookie) NotHaveHttpOnly() *Cookie {
+	c.chain.enter("NotHaveHttpOnly()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("HttpOnly", c.value.HttpOnly, false)
+
+	return c
+}
+
+// HavePartitioned succeeds if cookie has Partitioned flag (CHIPS).
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HaveSecure().HavePartitioned()
+func (c *Cookie) HavePartitioned() *Cookie {
+	c.chain.enter("HavePartitioned()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("Partitioned", cookiePartitioned(c.value), true)
+
+	return c
+}
+
+// NotHavePartitioned succeeds if cookie does not have Partitioned flag.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHavePartitioned()
+func (c *Cookie) NotHavePartitioned() *Cookie {
+	c.chain.enter("NotHavePartitioned()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.checkAttribute("Partitioned", cookiePartitioned(c.value), false)
+
+	return c
+}
+
+// SameSite returns a new String instance with cookie SameSite policy.
+//
+// Returned value is "Strict", "Lax", "None", or empty string if
+// SameSite attribute is absent or has unknown value.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.SameSite().Equal("Strict")
+func (c *Cookie) SameSite() *String {
+	c.chain.enter("SameSite()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, cookieSameSite(c.value.SameSite))
+}
+
+// HaveSameSite succeeds if cookie has given SameSite policy.
+//
+// http.SameSiteDefaultMode matches cookies without SameSite attribute
+// or with unknown value.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HaveSameSite(http.SameSiteStrictMode)
+func (c *Cookie) HaveSameSite(mode http.SameSite) *Cookie {
+	c.chain.enter("HaveSameSite()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	actual, expected := cookieSameSite(c.value.SameSite), cookieSameSite(mode)
+
+	if actual != expected {
+		c.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				errors.New("expected: cookie has given SameSite policy"),
+			},
+		})
+	}
+
+	return c
+}
+
+func (c *Cookie) checkAttribute(name string, present, want bool) {
+	switch {
+	case want && !present:
+		c.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				fmt.Errorf("expected: cookie has %s field", name),
+			},
+		})
+
+	case !want && present:
+		c.chain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				fmt.Errorf("expected: cookie does not have %s field", name),
+			},
+		})
+	}
+}
+
+func cookieSameSite(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	default:
+		return ""
+	}
+}
+
+// Check Partitioned attribute. Newer Go versions parse it into a separate
+// field of http.Cookie, and older versions put it into Unparsed.
+func cookiePartitioned(cookie *http.Cookie) bool {
+	field := reflect.ValueOf(cookie).Elem().FieldByName("Partitioned")
+	if field.IsValid() && field.Kind() == reflect.Bool && field.Bool() {
+		return true
+	}
+
+	for _, attr := range cookie.Unparsed {
+		if strings.EqualFold(strings.TrimSpace(attr), "Partitioned") {
+			return true
+		}
+	}
+
+	return false
+}
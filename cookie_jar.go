@@ -1,8 +1,14 @@
 package httpexpect
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/net/publicsuffix"
 )
@@ -12,8 +18,18 @@ import (
 // Returned jar is implemented in net/http/cookiejar. PublicSuffixList is
 // implemented in golang.org/x/net/publicsuffix.
 //
+// In addition, returned jar remembers all cookie attributes (expiration,
+// flags, etc.), which are reported by Expect.Cookie, and can be cleared
+// using Expect.ClearCookies.
+//
 // Note that this jar ignores cookies when request url is empty.
 func NewJar() http.CookieJar {
+	return &cookieJar{
+		jar: newStdJar(),
+	}
+}
+
+func newStdJar() http.CookieJar {
 	jar, err := cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -22,3 +38,411 @@ func NewJar() http.CookieJar {
 	}
 	return jar
 }
+
+// Cookie jar which delegates cookie handling to net/http/cookiejar,
+// and additionally keeps original cookies with all their attributes.
+type cookieJar struct {
+	mu      sync.Mutex
+	jar     http.CookieJar
+	entries []cookieEntry
+}
+
+type cookieEntry struct {
+	cookie   *http.Cookie
+	hostOnly bool
+}
+
+func (j *cookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.jar.SetCookies(u, cookies)
+
+	now := time.Now()
+
+	for _, c := range cookies {
+		entry := cookieEntry{
+			cookie: &http.Cookie{},
+		}
+		*entry.cookie = *c
+
+		if entry.cookie.Domain == "" {
+			entry.cookie.Domain = u.Hostname()
+			entry.hostOnly = true
+		}
+		entry.cookie.Domain = strings.ToLower(
+			strings.TrimPrefix(entry.cookie.Domain, "."))
+
+		if entry.cookie.Path == "" || entry.cookie.Path[0] != '/' {
+			entry.cookie.Path = cookieDefaultPath(u.Path)
+		}
+
+		if entry.cookie.MaxAge > 0 {
+			entry.cookie.Expires = now.Add(
+				time.Duration(entry.cookie.MaxAge) * time.Second)
+		}
+
+		j.removeEntry(entry.cookie)
+
+		if entry.cookie.MaxAge < 0 ||
+			(!entry.cookie.Expires.IsZero() && !entry.cookie.Expires.After(now)) {
+			continue
+		}
+
+		j.entries = append(j.entries, entry)
+	}
+}
+
+func (j *cookieJar) removeEntry(c *http.Cookie) {
+	for i, e := range j.entries {
+		if e.cookie.Name == c.Name && e.cookie.Domain == c.Domain &&
+			e.cookie.Path == c.Path {
+			j.entries = append(j.entries[:i], j.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+func (j *cookieJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.jar.Cookies(u)
+}
+
+// Return cookies that would be sent to given url, with all their attributes.
+func (j *cookieJar) fullCookies(u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	host := strings.ToLower(u.Hostname())
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	used := make(map[int]bool)
+
+	var result []*http.Cookie
+
+	for _, c := range j.jar.Cookies(u) {
+		found := -1
+
+		for i, e := range j.entries {
+			if used[i] || e.cookie.Name != c.Name || e.cookie.Value != c.Value {
+				continue
+			}
+			if !e.domainMatch(host) || !cookiePathMatch(path, e.cookie.Path) {
+				continue
+			}
+			if found < 0 || len(e.cookie.Path) > len(j.entries[found].cookie.Path) {
+				found = i
+			}
+		}
+
+		if found < 0 {
+			result = append(result, c)
+			continue
+		}
+
+		used[found] = true
+
+		cookie := *j.entries[found].cookie
+		result = append(result, &cookie)
+	}
+
+	return result
+}
+
+func (j *cookieJar) clear() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.jar = newStdJar()
+	j.entries = nil
+}
+
+func (e cookieEntry) domainMatch(host string) bool {
+	if host == e.cookie.Domain {
+		return true
+	}
+	return !e.hostOnly && strings.HasSuffix(host, "."+e.cookie.Domain)
+}
+
+// Path matching, as defined in RFC 6265, section 5.1.4.
+func cookiePathMatch(reqPath, cookiePath string) bool {
+	if reqPath == cookiePath {
+		return true
+	}
+	if !strings.HasPrefix(reqPath, cookiePath) {
+		return false
+	}
+	return strings.HasSuffix(cookiePath, "/") || reqPath[len(cookiePath)] == '/'
+}
+
+// Default path, as defined in RFC 6265, section 5.1.4.
+func cookieDefaultPath(path string) string {
+	if path == "" || path[0] != '/' {
+		return "/"
+	}
+
+	i := strings.LastIndex(path, "/")
+	if i == 0 {
+		return "/"
+	}
+
+	return path[:i]
+}
+
+// Cookies returns a new Array instance with names of all cookies which
+// are currently stored in the cookie jar and would be sent to given url.
+//
+// If url is relative, it is resolved against Config.BaseURL.
+//
+// Cookie jar is taken from Config.Client, which should be *http.Client
+// with non-nil Jar.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.POST("/login").WithForm(credentials).
+//	    Expect().
+//	    Status(http.StatusOK)
+//
+//	e.Cookies("/").ContainsOnly("session")
+func (e *Expect) Cookies(url string) *Array {
+	e.chain.enter("Cookies(%q)", url)
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return newArray(e.chain, nil)
+	}
+
+	cookies, ok := e.jarCookies(url)
+	if !ok {
+		return newArray(e.chain, nil)
+	}
+
+	names := []interface{}{}
+	for _, c := range cookies {
+		names = append(names, c.Name)
+	}
+
+	return newArray(e.chain, names)
+}
+
+// Cookie returns a new Cookie instance with specified cookie from the cookie
+// jar, which would be sent to given url.
+//
+// If url is relative, it is resolved against Config.BaseURL.
+//
+// If jar was created by NewJar, returned cookie has all attributes (domain,
+// path, expiration, flags) as they were set by server. For other jars, only
+// name and value are available.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.POST("/login").WithForm(credentials).
+//	    Expect().
+//	    Status(http.StatusOK)
+//
+//	e.Cookie("/", "session").Expires().Gt(time.Now())
+func (e *Expect) Cookie(url, name string) *Cookie {
+	e.chain.enter("Cookie(%q, %q)", url, name)
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return newCookie(e.chain, nil)
+	}
+
+	cookies, ok := e.jarCookies(url)
+	if !ok {
+		return newCookie(e.chain, nil)
+	}
+
+	names := []string{}
+	for _, c := range cookies {
+		if c.Name == name {
+			return newCookie(e.chain, c)
+		}
+		names = append(names, c.Name)
+	}
+
+	e.chain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{names},
+		Expected: &AssertionValue{name},
+		Errors: []error{
+			errors.New("expected: cookie jar contains cookie with given name"),
+		},
+	})
+
+	return newCookie(e.chain, nil)
+}
+
+// SetCookie stores given cookies into the cookie jar, as if they were
+// set by server in response to request to given url.
+//
+// If url is relative, it is resolved against Config.BaseURL.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.SetCookie("/", &http.Cookie{Name: "session", Value: token})
+//
+//	e.GET("/profile").
+//	    Expect().
+//	    Status(http.StatusOK)
+func (e *Expect) SetCookie(url string, cookies ...*http.Cookie) *Expect {
+	e.chain.enter("SetCookie(%q)", url)
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return e
+	}
+
+	for _, c := range cookies {
+		if c == nil {
+			e.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil cookie argument"),
+				},
+			})
+			return e
+		}
+	}
+
+	jar, ok := e.cookieJar()
+	if !ok {
+		return e
+	}
+
+	u, ok := e.cookieURL(url)
+	if !ok {
+		return e
+	}
+
+	jar.SetCookies(u, cookies)
+
+	return e
+}
+
+// ClearCookies removes all cookies from the cookie jar.
+//
+// Cookie jar should be created by NewJar; other jars don't support
+// clearing.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.ClearCookies()
+//
+//	e.GET("/profile").
+//	    Expect().
+//	    Status(http.StatusUnauthorized)
+func (e *Expect) ClearCookies() *Expect {
+	e.chain.enter("ClearCookies()")
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return e
+	}
+
+	jar, ok := e.cookieJar()
+	if !ok {
+		return e
+	}
+
+	ownJar, ok := jar.(*cookieJar)
+	if !ok {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					"ClearCookies() can be used only with jar created by NewJar(), got %T",
+					jar),
+			},
+		})
+		return e
+	}
+
+	ownJar.clear()
+
+	return e
+}
+
+func (e *Expect) jarCookies(url string) ([]*http.Cookie, bool) {
+	jar, ok := e.cookieJar()
+	if !ok {
+		return nil, false
+	}
+
+	u, ok := e.cookieURL(url)
+	if !ok {
+		return nil, false
+	}
+
+	if ownJar, ok := jar.(*cookieJar); ok {
+		return ownJar.fullCookies(u), true
+	}
+
+	return jar.Cookies(u), true
+}
+
+func (e *Expect) cookieJar() (http.CookieJar, bool) {
+	var jar http.CookieJar
+
+	client := e.config.Client
+	if pc, ok := client.(*profileClient); ok {
+		client = pc.client
+	}
+
+	if httpClient, ok := client.(*http.Client); ok {
+		jar = httpClient.Jar
+	}
+
+	if jar == nil {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"cookie jar can be inspected only if Client is *http.Client" +
+						" with non-nil Jar"),
+			},
+		})
+		return nil, false
+	}
+
+	return jar, true
+}
+
+func (e *Expect) cookieURL(urlStr string) (*url.URL, bool) {
+	u, err := url.Parse(urlStr)
+	if err == nil && !u.IsAbs() {
+		var base *url.URL
+		base, err = url.Parse(e.config.BaseURL)
+		if err == nil {
+			u = base.ResolveReference(u)
+		}
+	}
+
+	if err != nil {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid url argument"),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return u, true
+}
@@ -0,0 +1,197 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCookieJarAttributes(t *testing.T) {
+	jar := NewJar().(*cookieJar)
+
+	u, err := url.Parse("https://example.com/api/login")
+	require.NoError(t, err)
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	jar.SetCookies(u, []*http.Cookie{
+		{Name: "session", Value: "s1", Expires: expires, HttpOnly: true},
+		{Name: "pref", Value: "p1", Path: "/", Domain: ".Example.com", Secure: true},
+		{Name: "ttl", Value: "t1", Path: "/", MaxAge: 60},
+	})
+
+	t.Run("attributes", func(t *testing.T) {
+		cookies := jar.fullCookies(u)
+		require.Equal(t, 3, len(cookies))
+
+		byName := map[string]*http.Cookie{}
+		for _, c := range cookies {
+			byName[c.Name] = c
+		}
+
+		assert.Equal(t, "s1", byName["session"].Value)
+		assert.Equal(t, "/api", byName["session"].Path)
+		assert.Equal(t, "example.com", byName["session"].Domain)
+		assert.True(t, byName["session"].HttpOnly)
+		assert.True(t, expires.Equal(byName["session"].Expires))
+
+		assert.Equal(t, "example.com", byName["pref"].Domain)
+		assert.True(t, byName["pref"].Secure)
+
+		assert.False(t, byName["ttl"].Expires.IsZero())
+	})
+
+	t.Run("path and domain", func(t *testing.T) {
+		other, err := url.Parse("https://sub.example.com/")
+		require.NoError(t, err)
+
+		cookies := jar.fullCookies(other)
+		require.Equal(t, 1, len(cookies))
+
+		assert.Equal(t, "pref", cookies[0].Name)
+		assert.True(t, cookies[0].Secure)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		jar.SetCookies(u, []*http.Cookie{
+			{Name: "ttl", Value: "", Path: "/", MaxAge: -1},
+		})
+
+		assert.Equal(t, 2, len(jar.fullCookies(u)))
+		assert.Equal(t, 2, len(jar.Cookies(u)))
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		jar.clear()
+
+		assert.Equal(t, 0, len(jar.fullCookies(u)))
+		assert.Equal(t, 0, len(jar.Cookies(u)))
+	})
+}
+
+func TestCookieJarPath(t *testing.T) {
+	assert.Equal(t, "/", cookieDefaultPath(""))
+	assert.Equal(t, "/", cookieDefaultPath("/"))
+	assert.Equal(t, "/", cookieDefaultPath("/foo"))
+	assert.Equal(t, "/foo", cookieDefaultPath("/foo/bar"))
+
+	assert.True(t, cookiePathMatch("/foo", "/foo"))
+	assert.True(t, cookiePathMatch("/foo/bar", "/foo"))
+	assert.True(t, cookiePathMatch("/foo/bar", "/"))
+	assert.False(t, cookiePathMatch("/foobar", "/foo"))
+	assert.False(t, cookiePathMatch("/", "/foo"))
+}
+
+func TestCookieJarExpect(t *testing.T) {
+	handler := http.NewServeMux()
+
+	handler.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{
+			Name:     "session",
+			Value:    "secret",
+			Path:     "/",
+			Expires:  time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+			HttpOnly: true,
+		})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	handler.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("session"); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("session flow", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+				Jar:       NewJar(),
+			},
+		})
+
+		e.Cookies("/").Empty()
+
+		e.POST("/login").Expect().Status(http.StatusNoContent)
+
+		e.Cookies("/").ContainsOnly("session")
+		e.Cookies("http://other.com/").Empty()
+
+		cookie := e.Cookie("/profile", "session")
+		cookie.Value().Equal("secret")
+		cookie.Path().Equal("/")
+		cookie.Domain().Equal("example.com")
+		cookie.Expires().Equal(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.True(t, cookie.Raw().HttpOnly)
+
+		e.GET("/profile").Expect().Status(http.StatusOK)
+
+		e.ClearCookies()
+		e.Cookies("/").Empty()
+
+		e.GET("/profile").Expect().Status(http.StatusUnauthorized)
+
+		e.SetCookie("/", &http.Cookie{Name: "session", Value: "manual"})
+		e.Cookie("/", "session").Value().Equal("manual")
+
+		e.GET("/profile").Expect().Status(http.StatusOK)
+
+		e.chain.assertNotFailed(t)
+
+		e.Cookie("/", "missing")
+		e.chain.assertFailed(t)
+	})
+
+	t.Run("std jar", func(t *testing.T) {
+		jar, err := cookiejar.New(nil)
+		require.NoError(t, err)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+				Jar:       jar,
+			},
+		})
+
+		e.POST("/login").Expect().Status(http.StatusNoContent)
+
+		e.Cookies("/").ContainsOnly("session")
+		e.Cookie("/", "session").Value().Equal("secret")
+		e.chain.assertNotFailed(t)
+
+		e.ClearCookies()
+		e.chain.assertFailed(t)
+	})
+
+	t.Run("no jar", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+		})
+
+		e.Cookies("/")
+		e.chain.assertFailed(t)
+	})
+
+	t.Run("nil cookie", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+		})
+
+		e.SetCookie("/", nil)
+		e.chain.assertFailed(t)
+	})
+}
@@ -24,9 +24,19 @@ func TestCookieFailed(t *testing.T) {
 		assert.NotNil(t, value.Path())
 		assert.NotNil(t, value.Expires())
 		assert.NotNil(t, value.MaxAge())
+		assert.NotNil(t, value.SameSite())
 
 		value.HaveMaxAge()
 		value.NotHaveMaxAge()
+		value.HaveExpires()
+		value.NotHaveExpires()
+		value.HaveSecure()
+		value.NotHaveSecure()
+		value.HaveHttpOnly()
+		value.NotHaveHttpOnly()
+		value.HavePartitioned()
+		value.NotHavePartitioned()
+		value.HaveSameSite(http.SameSiteStrictMode)
 	}
 
 	t.Run("failed_chain", func(t *testing.T) {
@@ -149,3 +159,105 @@ func TestCookieMaxAge(t *testing.T) {
 		value.MaxAge().Equal(3 * time.Second).chain.assertNotFailed(t)
 	})
 }
+
+func TestCookieAttributes(t *testing.T) {
+	parse := func(t *testing.T, header string) *http.Cookie {
+		resp := http.Response{Header: http.Header{}}
+		resp.Header.Add("Set-Cookie", header)
+
+		cookies := resp.Cookies()
+		require.Equal(t, 1, len(cookies))
+
+		return cookies[0]
+	}
+
+	t.Run("all", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewCookie(reporter, parse(t,
+			"name=value; Expires=Wed, 21 Oct 2065 07:28:00 GMT;"+
+				" Secure; HttpOnly; SameSite=Strict; Partitioned"))
+
+		value.HaveExpires()
+		value.HaveSecure()
+		value.HaveHttpOnly()
+		value.HavePartitioned()
+		value.HaveSameSite(http.SameSiteStrictMode)
+		value.SameSite().Equal("Strict")
+		value.chain.assertNotFailed(t)
+
+		for _, fn := range []func() *Cookie{
+			value.NotHaveExpires,
+			value.NotHaveSecure,
+			value.NotHaveHttpOnly,
+			value.NotHavePartitioned,
+			func() *Cookie { return value.HaveSameSite(http.SameSiteLaxMode) },
+		} {
+			fn()
+			value.chain.assertFailed(t)
+			value.chain.clearFailed()
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewCookie(reporter, parse(t, "name=value"))
+
+		value.NotHaveExpires()
+		value.NotHaveSecure()
+		value.NotHaveHttpOnly()
+		value.NotHavePartitioned()
+		value.HaveSameSite(http.SameSiteDefaultMode)
+		value.SameSite().Empty()
+		value.chain.assertNotFailed(t)
+
+		for _, fn := range []func() *Cookie{
+			value.HaveExpires,
+			value.HaveSecure,
+			value.HaveHttpOnly,
+			value.HavePartitioned,
+			func() *Cookie { return value.HaveSameSite(http.SameSiteNoneMode) },
+		} {
+			fn()
+			value.chain.assertFailed(t)
+			value.chain.clearFailed()
+		}
+	})
+
+	t.Run("samesite", func(t *testing.T) {
+		cases := []struct {
+			header string
+			mode   http.SameSite
+			str    string
+		}{
+			{"name=value; SameSite=Lax", http.SameSiteLaxMode, "Lax"},
+			{"name=value; SameSite=Strict", http.SameSiteStrictMode, "Strict"},
+			{"name=value; SameSite=None", http.SameSiteNoneMode, "None"},
+			{"name=value; SameSite", http.SameSiteDefaultMode, ""},
+			{"name=value", http.SameSite(0), ""},
+		}
+
+		for _, tc := range cases {
+			reporter := newMockReporter(t)
+
+			value := NewCookie(reporter, parse(t, tc.header))
+
+			value.HaveSameSite(tc.mode)
+			value.SameSite().Equal(tc.str)
+			value.chain.assertNotFailed(t)
+		}
+	})
+
+	t.Run("partitioned unparsed", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewCookie(reporter, &http.Cookie{
+			Name:     "name",
+			Unparsed: []string{"partitioned"},
+		})
+
+		value.HavePartitioned()
+		value.chain.assertNotFailed(t)
+	})
+}
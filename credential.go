@@ -0,0 +1,266 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Credential is a named set of credentials, e.g. valid, expired, revoked,
+// or lacking required scope, together with expected outcome of request
+// authorized with them. Name identifies credential in failure reports.
+type Credential struct {
+	// Name of credential, e.g. "expired token".
+	Name string
+
+	// Apply adds credential to request.
+	// If nil, request is sent as is.
+	Apply func(req *Request)
+
+	// Expected response status.
+	// If zero, status is not checked.
+	Status int
+
+	// Expected "type" member of problem details (RFC 9457) in response
+	// body. Response should have JSON-compatible Content-Type, e.g.
+	// "application/problem+json".
+	// If empty, body is not checked.
+	Problem string
+
+	// Check performs additional assertions on response.
+	// May be nil.
+	Check func(resp *Response)
+}
+
+// BearerCredential returns credential that sets "Authorization: Bearer"
+// header with given token.
+//
+// Example:
+//
+//	httpexpect.BearerCredential("expired", expiredToken, http.StatusUnauthorized)
+func BearerCredential(name, token string, status int) Credential {
+	return HeaderCredential(name, "Authorization", "Bearer "+token, status)
+}
+
+// BasicCredential returns credential that sets basic authentication
+// header with given username and password.
+//
+// Example:
+//
+//	httpexpect.BasicCredential("wrong password", "john", "foo", http.StatusUnauthorized)
+func BasicCredential(name, username, password string, status int) Credential {
+	return Credential{
+		Name: name,
+		Apply: func(req *Request) {
+			req.WithBasicAuth(username, password)
+		},
+		Status: status,
+	}
+}
+
+// HeaderCredential returns credential that sets given header, e.g.
+// API key. Header replaces the one added by request template or by
+// Expect builders, if any.
+//
+// Example:
+//
+//	httpexpect.HeaderCredential("revoked key", "X-API-Key", revokedKey, http.StatusForbidden)
+func HeaderCredential(name, header, value string, status int) Credential {
+	return Credential{
+		Name: name,
+		Apply: func(req *Request) {
+			req.httpReq.Header.Del(header)
+			req.WithHeader(header, value)
+		},
+		Status: status,
+	}
+}
+
+// AnonymousCredential returns credential that removes Authorization
+// header from request, if it was added by request template or by Expect
+// builders.
+//
+// Example:
+//
+//	httpexpect.AnonymousCredential(http.StatusUnauthorized)
+func AnonymousCredential(status int) Credential {
+	return Credential{
+		Name: "anonymous",
+		Apply: func(req *Request) {
+			req.httpReq.Header.Del("Authorization")
+		},
+		Status: status,
+	}
+}
+
+// CredentialCheck sends the same request multiple times, every time
+// with a different credential applied, and checks that every response
+// has outcome expected for that credential.
+//
+// Every request is named after credential (see Request.WithName), so that
+// failures identify which credential caused them.
+type CredentialCheck struct {
+	chain  *chain
+	expect *Expect
+
+	credentials []Credential
+
+	request func(e *Expect) *Request
+}
+
+// CredentialMatrix returns a new CredentialCheck instance for given
+// credentials.
+//
+// Example:
+//
+//	wrongScope := httpexpect.BearerCredential(
+//	    "wrong scope", readOnlyToken, http.StatusForbidden)
+//	wrongScope.Problem = "https://example.com/problems/insufficient-scope"
+//
+//	httpexpect.CredentialMatrix(e,
+//	    httpexpect.BearerCredential("valid", validToken, http.StatusOK),
+//	    httpexpect.BearerCredential("expired", expiredToken, http.StatusUnauthorized),
+//	    wrongScope,
+//	    httpexpect.AnonymousCredential(http.StatusUnauthorized),
+//	).
+//	    Request(func(e *httpexpect.Expect) *httpexpect.Request {
+//	        return e.DELETE("/users/{id}", 1)
+//	    }).
+//	    Run()
+func CredentialMatrix(e *Expect, credentials ...Credential) *CredentialCheck {
+	if e == nil {
+		panic("CredentialMatrix: Expect is nil")
+	}
+
+	c := &CredentialCheck{
+		expect:      e,
+		credentials: append([]Credential(nil), credentials...),
+	}
+
+	c.chain = e.chain.clone()
+	c.chain.enter("CredentialMatrix()")
+	defer c.chain.leave()
+
+	if len(credentials) == 0 {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("expected at least one credential"),
+			},
+		})
+		return c
+	}
+
+	for i, cred := range credentials {
+		if cred.Name == "" {
+			c.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unexpected empty name of credential %d", i),
+				},
+			})
+			return c
+		}
+	}
+
+	return c
+}
+
+// Request sets function that constructs request template. It's invoked
+// once for every credential.
+//
+// Function should construct request using given Expect instance, but
+// should not send it.
+//
+// Example:
+//
+//	check.Request(func(e *httpexpect.Expect) *httpexpect.Request {
+//	    return e.GET("/admin/users")
+//	})
+func (c *CredentialCheck) Request(fn func(e *Expect) *Request) *CredentialCheck {
+	c.chain.enter("Request()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	if fn == nil {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return c
+	}
+
+	c.request = fn
+
+	return c
+}
+
+// Run sends request for every credential and checks that response has
+// expected status and problem type, and invokes credential Check.
+//
+// Example:
+//
+//	check.Run()
+func (c *CredentialCheck) Run() *CredentialCheck {
+	c.chain.enter("Run()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	if c.request == nil {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("request template is not set, use Request()"),
+			},
+		})
+		return c
+	}
+
+	for _, cred := range c.credentials {
+		req := c.request(c.expect)
+		if req == nil {
+			c.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("request function returned nil"),
+				},
+			})
+			return c
+		}
+
+		name := fmt.Sprintf("credential=%s", cred.Name)
+		if prev := req.chain.context.RequestName; prev != "" {
+			name = prev + " " + name
+		}
+
+		req.WithName(name)
+
+		if cred.Apply != nil && !req.chain.failed() {
+			cred.Apply(req)
+		}
+
+		resp := req.Expect()
+
+		if cred.Status != 0 {
+			resp.Status(cred.Status)
+		}
+
+		if cred.Problem != "" {
+			resp.JSON(ContentOpts{MediaType: "application/*json"}).
+				Object().ValueEqual("type", cred.Problem)
+		}
+
+		if cred.Check != nil {
+			cred.Check(resp)
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,167 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialMatrix(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		headers []string
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		key := r.Header.Get("X-API-Key")
+
+		mu.Lock()
+		headers = append(headers, auth+"|"+key)
+		mu.Unlock()
+
+		problem := func(status int, typ string) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"type":   typ,
+				"status": status,
+			})
+		}
+
+		user, pass, basic := r.BasicAuth()
+
+		switch {
+		case key != "" && key != "valid":
+			problem(http.StatusUnauthorized, "invalid-credentials")
+		case auth == "Bearer valid" || key == "valid" ||
+			(basic && user == "john" && pass == "secret"):
+			w.WriteHeader(http.StatusOK)
+		case auth == "Bearer readonly":
+			problem(http.StatusForbidden, "insufficient-scope")
+		case auth == "" && key == "":
+			problem(http.StatusUnauthorized, "unauthenticated")
+		default:
+			problem(http.StatusUnauthorized, "invalid-credentials")
+		}
+	})
+
+	newExpect := func(reporter Reporter) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}).Builder(func(req *Request) {
+			req.WithHeader("Authorization", "Bearer valid")
+		})
+	}
+
+	t.Run("matrix", func(t *testing.T) {
+		headers = nil
+		reporter := newMockReporter(t)
+
+		var names []string
+
+		CredentialMatrix(newExpect(reporter),
+			BearerCredential("valid", "valid", http.StatusOK),
+			BearerCredential("expired", "expired", http.StatusUnauthorized),
+			Credential{
+				Name:    "wrong scope",
+				Apply:   BearerCredential("", "readonly", 0).Apply,
+				Status:  http.StatusForbidden,
+				Problem: "insufficient-scope",
+				Check: func(resp *Response) {
+					names = append(names, resp.chain.context.RequestName)
+				},
+			},
+			BasicCredential("basic", "john", "secret", http.StatusOK),
+			AnonymousCredential(http.StatusUnauthorized),
+		).
+			Request(func(e *Expect) *Request {
+				return e.GET("/users")
+			}).
+			Run()
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, []string{"credential=wrong scope"}, names)
+		assert.Equal(t, "Bearer expired|", headers[1])
+		assert.Equal(t, "|", headers[4])
+	})
+
+	t.Run("header", func(t *testing.T) {
+		headers = nil
+		reporter := newMockReporter(t)
+
+		CredentialMatrix(newExpect(reporter),
+			HeaderCredential("revoked key", "X-API-Key", "revoked", http.StatusUnauthorized),
+		).
+			Request(func(e *Expect) *Request {
+				return e.GET("/users").WithHeader("X-API-Key", "valid")
+			}).
+			Run()
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, []string{"Bearer valid|revoked"}, headers)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		CredentialMatrix(newExpect(reporter),
+			Credential{
+				Name:    "expired",
+				Apply:   func(req *Request) {},
+				Status:  http.StatusOK,
+				Problem: "invalid-credentials",
+			},
+		).
+			Request(func(e *Expect) *Request {
+				return e.GET("/users")
+			}).
+			Run()
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("problem", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		CredentialMatrix(newExpect(reporter),
+			Credential{
+				Name:    "expired",
+				Apply:   AnonymousCredential(0).Apply,
+				Problem: "invalid-credentials",
+			},
+		).
+			Request(func(e *Expect) *Request {
+				return e.GET("/users")
+			}).
+			Run()
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		CredentialMatrix(newExpect(reporter))
+		assert.True(t, reporter.reported)
+
+		reporter = newMockReporter(t)
+		CredentialMatrix(newExpect(reporter), Credential{Status: http.StatusOK})
+		assert.True(t, reporter.reported)
+
+		reporter = newMockReporter(t)
+		CredentialMatrix(newExpect(reporter), AnonymousCredential(http.StatusOK)).
+			Run()
+		assert.True(t, reporter.reported)
+
+		assert.Panics(t, func() {
+			CredentialMatrix(nil)
+		})
+	})
+}
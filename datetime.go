@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -43,6 +44,10 @@ func (dt *DateTime) Raw() time.Time {
 
 // Equal succeeds if DateTime is equal to given value.
 //
+// Time points are compared as instants, so values in different time zones
+// are equal if they denote the same moment. Use EqualWithZone to require
+// the same time zone offset as well.
+//
 // Example:
 //
 //	dt := NewDateTime(t, time.Unix(0, 1))
@@ -97,6 +102,56 @@ func (dt *DateTime) NotEqual(value time.Time) *DateTime {
 	return dt
 }
 
+// EqualWithZone succeeds if DateTime is equal to given value and both
+// have the same time zone offset.
+//
+// Unlike Equal, it fails if time points denote the same moment but are
+// expressed in different time zones.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+//	dt.EqualWithZone(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+func (dt *DateTime) EqualWithZone(value time.Time) *DateTime {
+	dt.chain.enter("EqualWithZone()")
+	defer dt.chain.leave()
+
+	if dt.chain.failed() {
+		return dt
+	}
+
+	if !dt.value.Equal(value) {
+		dt.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: time points are equal"),
+			},
+		})
+		return dt
+	}
+
+	_, actualOffset := dt.value.Zone()
+	_, expectedOffset := value.Zone()
+
+	if actualOffset != expectedOffset {
+		dt.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: time points have equal time zone offsets"),
+				fmt.Errorf("actual offset %s, expected offset %s",
+					time.Duration(actualOffset)*time.Second,
+					time.Duration(expectedOffset)*time.Second),
+			},
+		})
+	}
+
+	return dt
+}
+
 // Gt succeeds if DateTime is greater than given value.
 //
 // Example:
@@ -209,6 +264,106 @@ func (dt *DateTime) Le(value time.Time) *DateTime {
 	return dt
 }
 
+// Before succeeds if DateTime is before given value.
+// It's the same as Lt.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Unix(0, 1))
+//	dt.Before(time.Unix(0, 2))
+func (dt *DateTime) Before(value time.Time) *DateTime {
+	dt.chain.enter("Before()")
+	defer dt.chain.leave()
+
+	if dt.chain.failed() {
+		return dt
+	}
+
+	if !dt.value.Before(value) {
+		dt.chain.fail(AssertionFailure{
+			Type:     AssertLt,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: time point is before given time"),
+			},
+		})
+	}
+
+	return dt
+}
+
+// After succeeds if DateTime is after given value.
+// It's the same as Gt.
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Unix(0, 2))
+//	dt.After(time.Unix(0, 1))
+func (dt *DateTime) After(value time.Time) *DateTime {
+	dt.chain.enter("After()")
+	defer dt.chain.leave()
+
+	if dt.chain.failed() {
+		return dt
+	}
+
+	if !dt.value.After(value) {
+		dt.chain.fail(AssertionFailure{
+			Type:     AssertGt,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: time point is after given time"),
+			},
+		})
+	}
+
+	return dt
+}
+
+// Within succeeds if DateTime differs from given value by no more than
+// given delta, i.e. is within range [value-delta; value+delta].
+//
+// Example:
+//
+//	resp.Header("Date").AsDateTime().Within(time.Now(), time.Minute)
+func (dt *DateTime) Within(value time.Time, delta time.Duration) *DateTime {
+	dt.chain.enter("Within()")
+	defer dt.chain.leave()
+
+	if dt.chain.failed() {
+		return dt
+	}
+
+	if delta < 0 {
+		dt.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative delta argument"),
+			},
+		})
+		return dt
+	}
+
+	min, max := value.Add(-delta), value.Add(delta)
+
+	if dt.value.Before(min) || dt.value.After(max) {
+		dt.chain.fail(AssertionFailure{
+			Type:     AssertInRange,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{AssertionRange{min, max}},
+			Errors: []error{
+				errors.New("expected: time point is within given delta"),
+				fmt.Errorf("time points differ by %s, allowed delta is %s",
+					absDuration(dt.value.Sub(value)), delta),
+			},
+		})
+	}
+
+	return dt
+}
+
 // InRange succeeds if DateTime is within given range [min; max].
 //
 // Example:
@@ -268,3 +423,10 @@ func (dt *DateTime) NotInRange(min, max time.Time) *DateTime {
 
 	return dt
 }
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
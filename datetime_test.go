@@ -25,6 +25,10 @@ func TestDateTimeFailed(t *testing.T) {
 	value.Le(tm)
 	value.InRange(tm, tm)
 	value.NotInRange(tm, tm)
+	value.EqualWithZone(tm)
+	value.Before(tm)
+	value.After(tm)
+	value.Within(tm, time.Second)
 }
 
 func TestDateTimeEqual(t *testing.T) {
@@ -156,3 +160,80 @@ func TestDateTimeInRange(t *testing.T) {
 	value.chain.assertNotFailed(t)
 	value.chain.clearFailed()
 }
+
+func TestDateTimeEqualWithZone(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	utc := time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC)
+	est := utc.In(time.FixedZone("EST", -5*60*60))
+
+	value := NewDateTime(reporter, utc)
+
+	value.Equal(est)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualWithZone(utc)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualWithZone(est)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualWithZone(utc.Add(time.Second))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestDateTimeBeforeAfter(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewDateTime(reporter, time.Unix(0, 1234))
+
+	value.Before(time.Unix(0, 1234+1))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Before(time.Unix(0, 1234))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.After(time.Unix(0, 1234-1))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.After(time.Unix(0, 1234))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestDateTimeWithin(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewDateTime(reporter, time.Unix(100, 0))
+
+	value.Within(time.Unix(100, 0), 0)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Within(time.Unix(105, 0), 5*time.Second)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Within(time.Unix(95, 0), 5*time.Second)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Within(time.Unix(106, 0), 5*time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Within(time.Unix(94, 0), 5*time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Within(time.Unix(100, 0), -time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
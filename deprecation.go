@@ -0,0 +1,262 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deprecated succeeds if response contains valid Deprecation header,
+// i.e. the resource is deprecated.
+//
+// Deprecation header may be either a structured date, e.g. "@1688169599"
+// (RFC 9745), or, as in earlier drafts, HTTP-date or "true".
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Deprecated()
+func (r *Response) Deprecated() *Response {
+	r.chain.enter("Deprecated()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	value := r.httpResp.Header.Get("Deprecation")
+
+	if value == "" {
+		r.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{headerNames(r.httpResp.Header)},
+			Expected: &AssertionValue{"Deprecation"},
+			Errors: []error{
+				errors.New(`expected: response contains "Deprecation" header`),
+			},
+		})
+		return r
+	}
+
+	r.parseDeprecation(value)
+
+	return r
+}
+
+// NotDeprecated succeeds if response doesn't contain Deprecation header.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.NotDeprecated()
+func (r *Response) NotDeprecated() *Response {
+	r.chain.enter("NotDeprecated()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if _, ok := r.httpResp.Header["Deprecation"]; ok {
+		r.chain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{headerNames(r.httpResp.Header)},
+			Expected: &AssertionValue{"Deprecation"},
+			Errors: []error{
+				errors.New(`expected: response does not contain "Deprecation" header`),
+			},
+		})
+	}
+
+	return r
+}
+
+// Deprecation returns a new DateTime instance with date from Deprecation
+// header, i.e. the moment when the resource was or will be deprecated.
+//
+// If header is missing, malformed, or doesn't contain date (legacy "true"
+// form), failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Deprecation().Le(time.Now())
+func (r *Response) Deprecation() *DateTime {
+	r.chain.enter("Deprecation()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newDateTime(r.chain, time.Unix(0, 0))
+	}
+
+	value := r.httpResp.Header.Get("Deprecation")
+
+	date, ok := r.parseDeprecation(value)
+	if !ok {
+		return newDateTime(r.chain, time.Unix(0, 0))
+	}
+
+	if date == nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: "Deprecation" header contains date`),
+			},
+		})
+		return newDateTime(r.chain, time.Unix(0, 0))
+	}
+
+	return newDateTime(r.chain, *date)
+}
+
+// Sunset returns a new DateTime instance with date from Sunset header
+// (RFC 8594), i.e. the moment when the resource will become unresponsive.
+//
+// If header is missing or is not a valid HTTP-date, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Sunset().Gt(time.Now().AddDate(0, 6, 0))
+func (r *Response) Sunset() *DateTime {
+	r.chain.enter("Sunset()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newDateTime(r.chain, time.Unix(0, 0))
+	}
+
+	value := r.httpResp.Header.Get("Sunset")
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Sunset" response header`),
+				err,
+			},
+		})
+		return newDateTime(r.chain, time.Unix(0, 0))
+	}
+
+	return newDateTime(r.chain, date)
+}
+
+// DeprecationLink returns a new String instance with target of the first
+// link with "deprecation" relation type from Link header. Such link points
+// to documentation of deprecation policy.
+//
+// If there is no such link, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.DeprecationLink().HasPrefix("https://developer.example.com/")
+func (r *Response) DeprecationLink() *String {
+	r.chain.enter("DeprecationLink()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newString(r.chain, "")
+	}
+
+	return newString(r.chain, r.findLink("deprecation"))
+}
+
+// SunsetLink returns a new String instance with target of the first link
+// with "sunset" relation type from Link header. Such link points to
+// documentation of sunset policy (RFC 8594).
+//
+// If there is no such link, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.SunsetLink().NotEmpty()
+func (r *Response) SunsetLink() *String {
+	r.chain.enter("SunsetLink()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newString(r.chain, "")
+	}
+
+	return newString(r.chain, r.findLink("sunset"))
+}
+
+// Parse Deprecation header value. Returns nil date for legacy "true" form.
+func (r *Response) parseDeprecation(value string) (*time.Time, bool) {
+	if value == "" {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: response contains "Deprecation" header`),
+			},
+		})
+		return nil, false
+	}
+
+	if value == "true" {
+		return nil, true
+	}
+
+	var (
+		date time.Time
+		err  error
+	)
+
+	if strings.HasPrefix(value, "@") {
+		var sec int64
+		sec, err = strconv.ParseInt(value[1:], 10, 64)
+		if err == nil {
+			date = time.Unix(sec, 0).UTC()
+		}
+	} else {
+		date, err = http.ParseTime(value)
+	}
+
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Deprecation" response header`),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return &date, true
+}
+
+func (r *Response) findLink(rel string) string {
+	links, ok := r.getLinks()
+	if !ok {
+		return ""
+	}
+
+	for _, l := range links {
+		if l.hasRel(rel) {
+			return l.url
+		}
+	}
+
+	r.chain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{r.httpResp.Header.Values("Link")},
+		Expected: &AssertionValue{fmt.Sprintf("rel=%q", rel)},
+		Errors: []error{
+			fmt.Errorf(`expected: "Link" response header contains %q relation`, rel),
+		},
+	})
+
+	return ""
+}
@@ -0,0 +1,125 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDeprecationFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	resp := newResponse(responseOpts{
+		config:   newMockConfig(newMockReporter(t)),
+		chain:    chain,
+		httpResp: &http.Response{},
+	})
+
+	resp.Deprecated()
+	resp.NotDeprecated()
+	resp.Deprecation().chain.assertFailed(t)
+	resp.Sunset().chain.assertFailed(t)
+	resp.DeprecationLink().chain.assertFailed(t)
+	resp.SunsetLink().chain.assertFailed(t)
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header{
+			"Deprecation": {"@1688169599"},
+			"Sunset":      {"Sun, 30 Jun 2024 23:59:59 GMT"},
+			"Link": {
+				`<https://example.com/v2>; rel="successor-version"`,
+				`<https://example.com/deprecation>; rel=deprecation; type="text/html",` +
+					` <https://example.com/sunset>; rel="sunset"`,
+			},
+		},
+	})
+
+	resp.Deprecated()
+	resp.chain.assertNotFailed(t)
+
+	resp.NotDeprecated()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Deprecation().Equal(time.Date(2023, 6, 30, 23, 59, 59, 0, time.UTC))
+	resp.chain.assertNotFailed(t)
+
+	resp.Sunset().
+		Equal(time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC)).
+		Gt(resp.Deprecation().Raw())
+	resp.chain.assertNotFailed(t)
+
+	resp.DeprecationLink().Equal("https://example.com/deprecation")
+	resp.SunsetLink().Equal("https://example.com/sunset")
+	resp.chain.assertNotFailed(t)
+}
+
+func TestDeprecationFormats(t *testing.T) {
+	cases := []struct {
+		value   string
+		valid   bool
+		hasDate bool
+	}{
+		{"@0", true, true},
+		{"Sun, 30 Jun 2024 23:59:59 GMT", true, true},
+		{"true", true, false},
+		{"@abc", false, false},
+		{"yesterday", false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				Header: http.Header{"Deprecation": {tc.value}},
+			})
+
+			resp.Deprecated()
+			if tc.valid {
+				resp.chain.assertNotFailed(t)
+			} else {
+				resp.chain.assertFailed(t)
+			}
+			resp.chain.clearFailed()
+
+			resp.Deprecation()
+			if tc.hasDate {
+				resp.chain.assertNotFailed(t)
+			} else {
+				resp.chain.assertFailed(t)
+			}
+		})
+	}
+}
+
+func TestDeprecationMissing(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header{
+			"Sunset": {"tomorrow"},
+			"Link":   {`<https://example.com/next>; rel=next`},
+		},
+	})
+
+	resp.NotDeprecated()
+	resp.chain.assertNotFailed(t)
+
+	for _, fn := range []func(){
+		func() { resp.Deprecated() },
+		func() { resp.Deprecation() },
+		func() { resp.Sunset() },
+		func() { resp.DeprecationLink() },
+		func() { resp.SunsetLink() },
+	} {
+		fn()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	}
+}
@@ -0,0 +1,334 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Digest algorithms supported by Request.WithDigestHeader and verified
+// in responses. Names are lowercase, as registered in IANA "Hash
+// Algorithms for HTTP Digest Fields" registry.
+var digestAlgorithms = map[string]func() hash.Hash{
+	"md5":     md5.New,
+	"sha":     sha1.New,
+	"sha-256": sha256.New,
+	"sha-512": sha512.New,
+}
+
+// Algorithms that can be used in Request.WithDigestHeader.
+var requestDigestAlgorithms = []string{"md5", "sha-256", "sha-512"}
+
+// Single digest value from response header.
+type digestValue struct {
+	header    string
+	algorithm string
+	value     []byte
+}
+
+// Returns digests from integrity headers of response, with unsupported
+// algorithms skipped. If partial is true, only digests of content itself
+// are returned, since representation digests cover whole representation.
+func responseDigests(header http.Header, partial bool) ([]digestValue, error) {
+	var digests []digestValue
+
+	for _, v := range header.Values("Content-MD5") {
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Content-MD5 header %q: %w", v, err)
+		}
+		digests = append(digests, digestValue{"Content-MD5", "md5", b})
+	}
+
+	names := []string{"Content-Digest"}
+	if !partial {
+		names = append(names, "Repr-Digest", "Digest")
+	}
+
+	for _, name := range names {
+		for _, v := range header.Values(name) {
+			for _, item := range strings.Split(v, ",") {
+				d, err := parseDigestItem(name, item)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s header %q: %w", name, v, err)
+				}
+				if d != nil {
+					digests = append(digests, *d)
+				}
+			}
+		}
+	}
+
+	return digests, nil
+}
+
+// Parses single dictionary member, e.g. "sha-256=:base64:" (RFC 9530)
+// or "SHA-256=base64" (RFC 3230, used by Digest header).
+func parseDigestItem(header, item string) (*digestValue, error) {
+	item = strings.TrimSpace(item)
+	if item == "" {
+		return nil, nil
+	}
+
+	// strip parameters
+	if i := strings.IndexByte(item, ';'); i >= 0 {
+		item = item[:i]
+	}
+
+	eq := strings.IndexByte(item, '=')
+	if eq <= 0 {
+		return nil, errors.New("expected algorithm=value")
+	}
+
+	algorithm := strings.ToLower(strings.TrimSpace(item[:eq]))
+	encoded := strings.TrimSpace(item[eq+1:])
+
+	if _, ok := digestAlgorithms[algorithm]; !ok {
+		return nil, nil
+	}
+
+	if header != "Digest" {
+		if len(encoded) < 2 || encoded[0] != ':' || encoded[len(encoded)-1] != ':' {
+			return nil, errors.New("expected byte sequence enclosed in colons")
+		}
+		encoded = encoded[1 : len(encoded)-1]
+	}
+
+	value, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &digestValue{header, algorithm, value}, nil
+}
+
+func computeDigest(algorithm string, content []byte) []byte {
+	h := digestAlgorithms[algorithm]()
+	_, _ = h.Write(content)
+	return h.Sum(nil)
+}
+
+// Header names that carry digest of response body.
+var digestHeaders = []string{
+	"Content-MD5",
+	"Content-Digest",
+	"Repr-Digest",
+	"Digest",
+}
+
+func hasDigestHeaders(header http.Header) bool {
+	for _, name := range digestHeaders {
+		if len(header.Values(name)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if digests of response can be verified against response body.
+func canVerifyDigests(resp *http.Response) bool {
+	if resp.Request != nil && resp.Request.Method == http.MethodHead {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotModified:
+		return false
+	}
+
+	// body was decoded by transport, and original bytes are lost
+	return !resp.Uncompressed
+}
+
+// Verifies integrity headers against body as it was received, i.e.
+// before content codings were removed.
+func verifyDigests(chain *chain, resp *http.Response, content []byte) {
+	digests, err := responseDigests(resp.Header,
+		resp.StatusCode == http.StatusPartialContent)
+
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{resp.Header},
+			Errors: []error{
+				errors.New("expected: valid integrity headers"),
+				err,
+			},
+		})
+		return
+	}
+
+	for _, d := range digests {
+		actual := computeDigest(d.algorithm, content)
+
+		if !bytes.Equal(actual, d.value) {
+			chain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{base64.StdEncoding.EncodeToString(actual)},
+				Expected: &AssertionValue{base64.StdEncoding.EncodeToString(d.value)},
+				Errors: []error{
+					fmt.Errorf(
+						"expected: %s digest from %s header matches response body",
+						d.algorithm, d.header),
+				},
+			})
+			return
+		}
+	}
+}
+
+// VerifyDigest succeeds if integrity headers of response match response
+// body. Content-MD5, Digest (RFC 3230), and Content-Digest and Repr-Digest
+// (RFC 9530) headers are supported, with "md5", "sha", "sha-256", and
+// "sha-512" algorithms; other algorithms are ignored.
+//
+// Unless Config.DisableDigestCheck is set, this check is performed
+// automatically for every non-streamed response. VerifyDigest can be used
+// to check streamed response, in which case the rest of stream is read.
+// It also fails if response doesn't have any integrity headers.
+//
+// Digests are computed over body as it was received, before content
+// codings are removed. If body was decompressed by http.Transport (see
+// http.Response.Uncompressed), digests can't be verified and failure is
+// reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.VerifyDigest()
+func (r *Response) VerifyDigest() *Response {
+	r.chain.enter("VerifyDigest()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if !hasDigestHeaders(r.httpResp.Header) {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{headerNames(r.httpResp.Header)},
+			Errors: []error{
+				errors.New("expected: response has integrity headers"),
+			},
+		})
+		return r
+	}
+
+	if r.httpResp.Uncompressed {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New(
+					"can't verify digest of response decompressed by transport"),
+			},
+		})
+		return r
+	}
+
+	content := r.readContent()
+	if r.chain.failed() {
+		return r
+	}
+
+	if r.rawContent != nil {
+		content = r.rawContent
+	} else if r.streaming && canDecode(contentEncodings(r.httpResp.Header)) {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("can't verify digest of decoded streamed response"),
+			},
+		})
+		return r
+	}
+
+	verifyDigests(r.chain, r.httpResp, content)
+
+	return r
+}
+
+// WithDigestHeader enables integrity header for request body, computed
+// with given algorithm when request is sent.
+//
+// For "sha-256" and "sha-512", Content-Digest header (RFC 9530) is set.
+// For "md5", Content-MD5 header is set. Digest is computed over body as
+// it is sent, i.e. after compression (see WithCompression). Requests
+// without body are not affected.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/bucket/object")
+//	req.WithDigestHeader("sha-256")
+//	req.WithBytes(data)
+func (r *Request) WithDigestHeader(algorithm string) *Request {
+	r.chain.enter("WithDigestHeader()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	algorithm = strings.ToLower(algorithm)
+
+	supported := false
+	for _, a := range requestDigestAlgorithms {
+		if a == algorithm {
+			supported = true
+		}
+	}
+
+	if !supported {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					`unsupported digest algorithm %q, expected one of: %s`,
+					algorithm, strings.Join(requestDigestAlgorithms, ", ")),
+			},
+		})
+		return r
+	}
+
+	r.digestAlgorithm = algorithm
+
+	return r
+}
+
+func (r *Request) setDigestHeader() bool {
+	content, err := ioutil.ReadAll(r.httpReq.Body)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read request body"),
+				err,
+			},
+		})
+		return false
+	}
+
+	r.httpReq.Body = ioutil.NopCloser(bytes.NewReader(content))
+	r.httpReq.ContentLength = int64(len(content))
+
+	digest := base64.StdEncoding.EncodeToString(
+		computeDigest(r.digestAlgorithm, content))
+
+	if r.digestAlgorithm == "md5" {
+		r.httpReq.Header.Set("Content-MD5", digest)
+	} else {
+		r.httpReq.Header.Set("Content-Digest",
+			fmt.Sprintf("%s=:%s:", r.digestAlgorithm, digest))
+	}
+
+	return true
+}
@@ -0,0 +1,278 @@
+package httpexpect
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// WithDigestAuth enables HTTP Digest Authentication (RFC 7616) with
+// given username and password.
+//
+// Request is first sent without credentials. If server responds with
+// 401 Unauthorized and Digest challenge in WWW-Authenticate header,
+// Authorization header is computed from the challenge, and request is
+// sent again. Response of the second request is returned. If server
+// doesn't respond with a supported challenge, its response is returned
+// as is.
+//
+// Supported algorithms are MD5, MD5-sess, SHA-256, and SHA-256-sess,
+// with "auth" quality of protection or without it.
+//
+// WithDigestAuth overrides WithNTLMAuth, and vice versa.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/device/status")
+//	req.WithDigestAuth("admin", "secret")
+func (r *Request) WithDigestAuth(username, password string) *Request {
+	r.chain.enter("WithDigestAuth()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if r.wsUpgrade {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected WithDigestAuth for websocket request"),
+			},
+		})
+		return r
+	}
+
+	r.ntlmAuth = nil
+	r.digestAuth = &digestCredentials{
+		username: username,
+		password: password,
+	}
+
+	return r
+}
+
+type digestCredentials struct {
+	username string
+	password string
+}
+
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+}
+
+// Send request, and if server responds with digest challenge, send it
+// again with Authorization header.
+func (r *Request) doDigestAuth() (*http.Response, error) {
+	resp, err := r.config.Client.Do(r.httpReq)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge, ok := parseDigestChallenge(resp.Header.Values("WWW-Authenticate"))
+	if !ok {
+		return resp, nil
+	}
+
+	auth, err := r.digestAuth.authorize(challenge, r.httpReq.Method,
+		r.httpReq.URL.RequestURI())
+	if err != nil {
+		return resp, nil
+	}
+
+	if resp.Body != nil {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	if body, ok := r.httpReq.Body.(rewindBody); ok {
+		body.Rewind()
+	}
+
+	r.httpReq.Header.Set("Authorization", auth)
+
+	return r.config.Client.Do(r.httpReq)
+}
+
+func (c *digestCredentials) authorize(
+	challenge digestChallenge, method, uri string,
+) (string, error) {
+	var newHash func() hash.Hash
+
+	algorithm := strings.ToUpper(challenge.algorithm)
+
+	switch strings.TrimSuffix(algorithm, "-SESS") {
+	case "", "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm %q", challenge.algorithm)
+	}
+
+	h := func(s string) string {
+		hh := newHash()
+		_, _ = io.WriteString(hh, s)
+		return hex.EncodeToString(hh.Sum(nil))
+	}
+
+	cnonceBytes := make([]byte, 16)
+	if _, err := rand.Read(cnonceBytes); err != nil {
+		return "", err
+	}
+	cnonce := hex.EncodeToString(cnonceBytes)
+
+	const nc = "00000001"
+
+	ha1 := h(c.username + ":" + challenge.realm + ":" + c.password)
+	if strings.HasSuffix(algorithm, "-SESS") {
+		ha1 = h(ha1 + ":" + challenge.nonce + ":" + cnonce)
+	}
+
+	ha2 := h(method + ":" + uri)
+
+	var response string
+	if challenge.qop == "auth" {
+		response = h(strings.Join([]string{
+			ha1, challenge.nonce, nc, cnonce, challenge.qop, ha2,
+		}, ":"))
+	} else {
+		response = h(ha1 + ":" + challenge.nonce + ":" + ha2)
+	}
+
+	params := []string{
+		fmt.Sprintf("username=%s", quoteDigestParam(c.username)),
+		fmt.Sprintf("realm=%s", quoteDigestParam(challenge.realm)),
+		fmt.Sprintf("nonce=%s", quoteDigestParam(challenge.nonce)),
+		fmt.Sprintf("uri=%s", quoteDigestParam(uri)),
+	}
+
+	if challenge.algorithm != "" {
+		params = append(params, "algorithm="+challenge.algorithm)
+	}
+
+	params = append(params, fmt.Sprintf("response=%s", quoteDigestParam(response)))
+
+	if challenge.opaque != "" {
+		params = append(params, fmt.Sprintf("opaque=%s", quoteDigestParam(challenge.opaque)))
+	}
+
+	if challenge.qop == "auth" {
+		params = append(params,
+			"qop=auth",
+			"nc="+nc,
+			fmt.Sprintf("cnonce=%s", quoteDigestParam(cnonce)))
+	}
+
+	return "Digest " + strings.Join(params, ", "), nil
+}
+
+// Find first Digest challenge with supported parameters.
+func parseDigestChallenge(headers []string) (digestChallenge, bool) {
+	for _, header := range headers {
+		scheme, rest := header, ""
+		if i := strings.IndexAny(header, " \t"); i >= 0 {
+			scheme, rest = header[:i], header[i+1:]
+		}
+
+		if !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+
+		params := parseAuthParams(rest)
+
+		challenge := digestChallenge{
+			realm:     params["realm"],
+			nonce:     params["nonce"],
+			opaque:    params["opaque"],
+			algorithm: params["algorithm"],
+		}
+
+		if challenge.nonce == "" {
+			continue
+		}
+
+		if qop, ok := params["qop"]; ok {
+			for _, q := range strings.Split(qop, ",") {
+				if strings.TrimSpace(q) == "auth" {
+					challenge.qop = "auth"
+				}
+			}
+			// only auth-int is offered, which is not supported
+			if challenge.qop == "" {
+				continue
+			}
+		}
+
+		return challenge, true
+	}
+
+	return digestChallenge{}, false
+}
+
+// Parse comma-separated list of key=value and key="quoted value" pairs.
+func parseAuthParams(s string) map[string]string {
+	params := map[string]string{}
+
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return params
+		}
+
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return params
+		}
+
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " \t")
+
+		var value string
+
+		if strings.HasPrefix(s, `"`) {
+			var b strings.Builder
+
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				b.WriteByte(s[i])
+			}
+
+			value = b.String()
+			if i < len(s) {
+				i++
+			}
+			s = s[i:]
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			value = strings.TrimSpace(s[:end])
+			s = s[end:]
+		}
+
+		params[key] = value
+	}
+}
+
+var digestParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func quoteDigestParam(s string) string {
+	return `"` + digestParamEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,269 @@
+package httpexpect
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type digestAuthHandler struct {
+	t         *testing.T
+	challenge string
+	algorithm string
+	username  string
+	password  string
+	requests  int
+	bodies    []string
+}
+
+func (h *digestAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.requests++
+
+	body, _ := ioutil.ReadAll(r.Body)
+	h.bodies = append(h.bodies, string(body))
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Digest ") {
+		w.Header().Set("WWW-Authenticate", h.challenge)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	params := parseAuthParams(strings.TrimPrefix(auth, "Digest "))
+
+	newHash := md5.New
+	if strings.HasPrefix(strings.ToUpper(h.algorithm), "SHA-256") {
+		newHash = sha256.New
+	}
+
+	hs := func(s string) string {
+		return digestTestHash(newHash, s)
+	}
+
+	ha1 := hs(h.username + ":" + params["realm"] + ":" + h.password)
+	if strings.HasSuffix(strings.ToUpper(h.algorithm), "-SESS") {
+		ha1 = hs(ha1 + ":" + params["nonce"] + ":" + params["cnonce"])
+	}
+
+	ha2 := hs(r.Method + ":" + params["uri"])
+
+	var expected string
+	if params["qop"] != "" {
+		expected = hs(strings.Join([]string{
+			ha1, params["nonce"], params["nc"], params["cnonce"], params["qop"], ha2,
+		}, ":"))
+	} else {
+		expected = hs(ha1 + ":" + params["nonce"] + ":" + ha2)
+	}
+
+	assert.Equal(h.t, h.username, params["username"])
+	assert.Equal(h.t, r.URL.RequestURI(), params["uri"])
+
+	if params["response"] != expected {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("X-Opaque", params["opaque"])
+	w.WriteHeader(http.StatusOK)
+}
+
+func digestTestHash(newHash func() hash.Hash, s string) string {
+	h := newHash()
+	_, _ = io.WriteString(h, s)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestDigestAuthFailed(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter)
+	config := newMockConfig(reporter)
+
+	chain.fail(mockFailure())
+
+	req := newRequest(chain, config, "GET", "")
+
+	req.WithDigestAuth("user", "pass")
+
+	req.chain.assertFailed(t)
+	assert.Nil(t, req.digestAuth)
+}
+
+func TestDigestAuthUsage(t *testing.T) {
+	reporter := newMockReporter(t)
+	config := newMockConfig(reporter)
+
+	req := NewRequestC(config, "GET", "/")
+
+	req.WithWebsocketUpgrade()
+	req.WithDigestAuth("user", "pass")
+
+	req.chain.assertFailed(t)
+}
+
+func TestDigestAuthChallenge(t *testing.T) {
+	cases := []struct {
+		name      string
+		challenge string
+		algorithm string
+	}{
+		{
+			name:      "rfc2069",
+			challenge: `Digest realm="test", nonce="abc"`,
+		},
+		{
+			name: "md5",
+			challenge: `Digest realm="test@example.com", qop="auth,auth-int",` +
+				` nonce="dcd98b7102dd2f0e8b11d0f600bfb0c093",` +
+				` opaque="5ccc069c403ebaf9f0171e9517f40e41"`,
+		},
+		{
+			name: "md5-sess",
+			challenge: `Digest realm="test", qop="auth", algorithm=MD5-sess,` +
+				` nonce="abc"`,
+			algorithm: "MD5-sess",
+		},
+		{
+			name: "sha-256",
+			challenge: `Digest realm="http-auth@example.org", qop="auth, auth-int",` +
+				` algorithm=SHA-256,` +
+				` nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v",` +
+				` opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`,
+			algorithm: "SHA-256",
+		},
+		{
+			name:      "sha-256-sess",
+			challenge: `Digest realm="test", qop=auth, algorithm=SHA-256-sess, nonce="abc"`,
+			algorithm: "SHA-256-sess",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &digestAuthHandler{
+				t:         t,
+				challenge: tc.challenge,
+				algorithm: tc.algorithm,
+				username:  "Mufasa",
+				password:  "Circle of Life",
+			}
+
+			e, _ := newMockExpect(t, handler)
+
+			resp := e.POST("/dir/index.html").
+				WithQuery("q", "a b").
+				WithDigestAuth("Mufasa", "Circle of Life").
+				WithText("hello").
+				Expect()
+
+			resp.Status(http.StatusOK)
+			resp.chain.assertNotFailed(t)
+
+			assert.Equal(t, 2, handler.requests)
+
+			// body is sent again with credentials
+			assert.Equal(t, []string{"hello", "hello"}, handler.bodies)
+		})
+	}
+}
+
+func TestDigestAuthWrongPassword(t *testing.T) {
+	handler := &digestAuthHandler{
+		t:         t,
+		challenge: `Digest realm="test", qop="auth", nonce="abc"`,
+		username:  "user",
+		password:  "pass",
+	}
+
+	e, _ := newMockExpect(t, handler)
+
+	e.GET("/").
+		WithDigestAuth("user", "wrong").
+		Expect().
+		Status(http.StatusForbidden).
+		chain.assertNotFailed(t)
+
+	assert.Equal(t, 2, handler.requests)
+}
+
+func TestDigestAuthNoChallenge(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		header string
+	}{
+		{
+			name:   "ok",
+			status: http.StatusOK,
+		},
+		{
+			name:   "basic challenge",
+			status: http.StatusUnauthorized,
+			header: `Basic realm="test"`,
+		},
+		{
+			name:   "unsupported qop",
+			status: http.StatusUnauthorized,
+			header: `Digest realm="test", qop="auth-int", nonce="abc"`,
+		},
+		{
+			name:   "unsupported algorithm",
+			status: http.StatusUnauthorized,
+			header: `Digest realm="test", algorithm=SHA-512-256, nonce="abc"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if tc.header != "" {
+					w.Header().Set("WWW-Authenticate", tc.header)
+				}
+				w.WriteHeader(tc.status)
+			})
+
+			e, _ := newMockExpect(t, handler)
+
+			e.GET("/").
+				WithDigestAuth("user", "pass").
+				Expect().
+				Status(tc.status).
+				chain.assertNotFailed(t)
+
+			assert.Equal(t, 1, requests)
+		})
+	}
+}
+
+func TestDigestAuthParse(t *testing.T) {
+	challenge, ok := parseDigestChallenge([]string{
+		`Basic realm="basic"`,
+		`Digest realm="a \"quoted\", realm", nonce=xyz, opaque="o", qop="auth-int,auth"`,
+	})
+
+	require.True(t, ok)
+
+	assert.Equal(t, digestChallenge{
+		realm:  `a "quoted", realm`,
+		nonce:  "xyz",
+		opaque: "o",
+		qop:    "auth",
+	}, challenge)
+
+	_, ok = parseDigestChallenge([]string{`Digest realm="test"`})
+	assert.False(t, ok)
+
+	assert.Equal(t, `"a \"b\" \\c"`, quoteDigestParam(`a "b" \c`))
+}
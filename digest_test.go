@@ -0,0 +1,233 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestParse(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+	b64 := base64.StdEncoding.EncodeToString(sum[:])
+
+	cases := []struct {
+		name    string
+		header  http.Header
+		partial bool
+		count   int
+		fail    bool
+	}{
+		{"none", http.Header{}, false, 0, false},
+		{"content-digest",
+			http.Header{"Content-Digest": {"sha-256=:" + b64 + ":"}}, false, 1, false},
+		{"repr-digest with params",
+			http.Header{"Repr-Digest": {"sha-256=:" + b64 + ":;foo=bar"}}, false, 1, false},
+		{"legacy digest",
+			http.Header{"Digest": {"SHA-256=" + b64 + ", unixsum=30637"}}, false, 1, false},
+		{"unknown algorithm",
+			http.Header{"Content-Digest": {"sha3-256=:" + b64 + ":"}}, false, 0, false},
+		{"partial",
+			http.Header{
+				"Content-Digest": {"sha-256=:" + b64 + ":"},
+				"Repr-Digest":    {"sha-256=:" + b64 + ":"},
+			}, true, 1, false},
+		{"missing colons",
+			http.Header{"Content-Digest": {"sha-256=" + b64}}, false, 0, true},
+		{"bad base64",
+			http.Header{"Content-Md5": {"???"}}, false, 0, true},
+		{"no value",
+			http.Header{"Content-Digest": {"sha-256"}}, false, 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			digests, err := responseDigests(tc.header, tc.partial)
+			if tc.fail {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, digests, tc.count)
+		})
+	}
+}
+
+func TestDigestResponse(t *testing.T) {
+	body := []byte("hello, world!")
+
+	md5sum := md5.Sum(body)
+	sha256sum := sha256.Sum256(body)
+	sha512sum := sha512.Sum512(body)
+
+	b64 := base64.StdEncoding.EncodeToString
+
+	newResp := func(t *testing.T, header http.Header, body []byte) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		for _, header := range []http.Header{
+			{"Content-Md5": {b64(md5sum[:])}},
+			{"Content-Digest": {"sha-256=:" + b64(sha256sum[:]) + ":"}},
+			{"Repr-Digest": {"sha-512=:" + b64(sha512sum[:]) + ":"}},
+			{"Digest": {"SHA-256=" + b64(sha256sum[:])}},
+		} {
+			resp := newResp(t, header, body)
+			resp.chain.assertNotFailed(t)
+
+			resp.VerifyDigest()
+			resp.chain.assertNotFailed(t)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		resp := newResp(t, http.Header{
+			"Content-Digest": {"sha-256=:" + b64(sha256sum[:]) + ":"},
+		}, []byte("tampered"))
+
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		config := Config{
+			Reporter:           newMockReporter(t),
+			DisableDigestCheck: true,
+		}.withDefaults()
+
+		resp := newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("Response()", config),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Md5": {b64(md5sum[:])},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader([]byte("tampered"))),
+			},
+		})
+		resp.chain.assertNotFailed(t)
+
+		resp.VerifyDigest()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		compressed, err := compressContent("gzip", body)
+		require.NoError(t, err)
+
+		sum := sha256.Sum256(compressed)
+
+		resp := newResp(t, http.Header{
+			"Content-Encoding": {"gzip"},
+			"Content-Digest":   {"sha-256=:" + b64(sum[:]) + ":"},
+		}, compressed)
+		resp.chain.assertNotFailed(t)
+
+		resp.Body().Equal(string(body))
+		resp.VerifyDigest()
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusNotModified,
+			Header: http.Header{
+				"Content-Md5": {b64(md5sum[:])},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(nil)),
+		})
+		resp.chain.assertNotFailed(t)
+
+		resp = NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Repr-Digest": {"sha-256=:" + b64(sha256sum[:]) + ":"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(body[:5])),
+		})
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("no headers", func(t *testing.T) {
+		resp := newResp(t, http.Header{}, body)
+		resp.chain.assertNotFailed(t)
+
+		resp.VerifyDigest()
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestDigestRequest(t *testing.T) {
+	newConfig := func(t *testing.T) Config {
+		return Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						body, _ := ioutil.ReadAll(r.Body)
+
+						// echo digest, so that response is verified as well
+						for _, name := range []string{"Content-Md5", "Content-Digest"} {
+							if v := r.Header.Get(name); v != "" {
+								w.Header().Set(name, v)
+							}
+						}
+						_, _ = w.Write(body)
+					})),
+			},
+		}
+	}
+
+	body := []byte("hello, world!")
+
+	for _, algorithm := range []string{"md5", "sha-256", "SHA-512"} {
+		t.Run(algorithm, func(t *testing.T) {
+			req := NewRequestC(newConfig(t), "PUT", "http://example.com")
+			req.WithDigestHeader(algorithm).WithBytes(body)
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			resp.VerifyDigest()
+			resp.chain.assertNotFailed(t)
+		})
+	}
+
+	t.Run("header value", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "PUT", "http://example.com")
+		req.WithDigestHeader("sha-256").WithBytes(body)
+
+		sum := sha256.Sum256(body)
+
+		resp := req.Expect()
+		resp.Header("Content-Digest").
+			Equal("sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "PUT", "http://example.com")
+		req.WithCompression("gzip").WithDigestHeader("sha-256").WithBytes(body)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "PUT", "http://example.com")
+		req.WithDigestHeader("crc32")
+		req.chain.assertFailed(t)
+	})
+}
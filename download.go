@@ -0,0 +1,339 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WithRange adds byte range to Range request header.
+//
+// Range starts at offset from and ends at offset to, inclusive.
+// If to is -1, range continues until the end of content.
+// If from is negative and to is -1, range covers last -from bytes.
+//
+// WithRange may be called multiple times to request multiple ranges,
+// which are usually returned in "multipart/byteranges" response,
+// see Response.Ranges.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/file")
+//	req.WithRange(0, 99)   // first 100 bytes
+//	req.WithRange(200, -1) // from 200th byte to the end
+//	req.WithRange(-50, -1) // last 50 bytes
+//	// Range: bytes=0-99,200-,-50
+func (r *Request) WithRange(from, to int64) *Request {
+	r.chain.enter("WithRange()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	var spec string
+
+	switch {
+	case from >= 0 && to == -1:
+		spec = fmt.Sprintf("%d-", from)
+	case from >= 0 && to >= from:
+		spec = fmt.Sprintf("%d-%d", from, to)
+	case from < 0 && to == -1:
+		spec = fmt.Sprintf("-%d", -from)
+	default:
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid byte range: from=%d, to=%d", from, to),
+			},
+		})
+		return r
+	}
+
+	if hdr := r.httpReq.Header.Get("Range"); hdr != "" {
+		r.httpReq.Header.Set("Range", hdr+","+spec)
+	} else {
+		r.httpReq.Header.Set("Range", "bytes="+spec)
+	}
+
+	return r
+}
+
+// ContentLengthEquals succeeds if response has Content-Length header
+// equal to given value, and if size of response body (as it was received,
+// before content codings were removed) matches it.
+//
+// Body size is not checked for responses to HEAD requests and for
+// streamed responses.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ContentLengthEquals(1024)
+func (r *Response) ContentLengthEquals(size int64) *Response {
+	r.chain.enter("ContentLengthEquals()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	header := r.httpResp.Header.Get("Content-Length")
+
+	length, err := strconv.ParseInt(header, 10, 64)
+	if err != nil || length < 0 {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: response has valid Content-Length header"),
+			},
+		})
+		return r
+	}
+
+	r.checkEqual(`"Content-Length" header`, size, length)
+
+	if r.chain.failed() || r.streaming || r.httpResp.Uncompressed ||
+		(r.httpResp.Request != nil && r.httpResp.Request.Method == http.MethodHead) {
+		return r
+	}
+
+	content := r.content
+	if r.rawContent != nil {
+		content = r.rawContent
+	}
+
+	r.checkEqual("body size", size, int64(len(content)))
+
+	return r
+}
+
+// StatusPartialContent succeeds if response has 206 (Partial Content)
+// status and its ranges are well-formed.
+//
+// Response should either have Content-Range header and contain single
+// range in body, or have "multipart/byteranges" Content-Type, with every
+// part having Content-Range header. Size of every range should match
+// its Content-Range.
+//
+// Example:
+//
+//	resp := e.GET("/file").WithRange(0, 99).Expect()
+//	resp.StatusPartialContent()
+//	resp.Header("Content-Range").Equal("bytes 0-99/1000")
+func (r *Response) StatusPartialContent() *Response {
+	r.chain.enter("StatusPartialContent()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	r.checkEqual("http status",
+		statusCodeText(http.StatusPartialContent),
+		statusCodeText(r.httpResp.StatusCode))
+
+	if r.chain.failed() {
+		return r
+	}
+
+	r.getRanges()
+
+	return r
+}
+
+// Ranges returns a new Array instance with byte ranges of partial
+// response.
+//
+// Every element of array is an object with the following fields:
+//   - "first" - offset of first byte of range
+//   - "last" - offset of last byte of range, inclusive
+//   - "total" - complete length of content, or -1 if unknown
+//   - "content_type" - Content-Type of part (for multipart response)
+//     or response
+//   - "body" - range contents
+//
+// Ranges fails if response has no Content-Range header and is not
+// a "multipart/byteranges" response, see StatusPartialContent.
+//
+// Example:
+//
+//	resp := e.GET("/file").WithRange(0, 9).WithRange(20, 29).Expect()
+//	ranges := resp.StatusPartialContent().Ranges()
+//
+//	ranges.Length().Equal(2)
+//	ranges.Element(1).Object().ValueEqual("first", 20)
+//	ranges.Element(1).Object().Value("body").String().Length().Equal(10)
+func (r *Response) Ranges() *Array {
+	r.chain.enter("Ranges()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	ranges, ok := r.getRanges()
+	if !ok {
+		return newArray(r.chain, nil)
+	}
+
+	items := make([]interface{}, 0, len(ranges))
+	for _, rng := range ranges {
+		items = append(items, map[string]interface{}{
+			"first":        rng.first,
+			"last":         rng.last,
+			"total":        rng.total,
+			"content_type": rng.contentType,
+			"body":         string(rng.body),
+		})
+	}
+
+	return newArray(r.chain, items)
+}
+
+// Single range of partial response.
+type byteRange struct {
+	first       int64
+	last        int64
+	total       int64
+	contentType string
+	body        []byte
+}
+
+func (r *Response) getRanges() ([]byteRange, bool) {
+	var (
+		ranges []byteRange
+		err    error
+	)
+
+	mediaType, params, _ := mime.ParseMediaType(r.httpResp.Header.Get("Content-Type"))
+
+	switch {
+	case r.httpResp.Header.Get("Content-Range") != "":
+		ranges, err = parseSingleRange(r.httpResp.Header, r.readContent())
+
+	case mediaType == "multipart/byteranges":
+		ranges, err = parseMultipartRanges(r.readContent(), params["boundary"])
+
+	default:
+		err = errors.New(
+			"response has neither Content-Range header" +
+				" nor multipart/byteranges Content-Type")
+	}
+
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpResp.Header},
+			Errors: []error{
+				errors.New("expected: valid partial content response"),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return ranges, true
+}
+
+func parseSingleRange(header http.Header, content []byte) ([]byteRange, error) {
+	rng, err := parseContentRange(header.Get("Content-Range"), content)
+	if err != nil {
+		return nil, err
+	}
+
+	rng.contentType = header.Get("Content-Type")
+
+	return []byteRange{rng}, nil
+}
+
+func parseMultipartRanges(content []byte, boundary string) ([]byteRange, error) {
+	if boundary == "" {
+		return nil, errors.New("missing multipart boundary")
+	}
+
+	var ranges []byteRange
+
+	reader := multipart.NewReader(bytes.NewReader(content), boundary)
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		rng, err := parseContentRange(part.Header.Get("Content-Range"), body)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", len(ranges), err)
+		}
+
+		rng.contentType = part.Header.Get("Content-Type")
+
+		ranges = append(ranges, rng)
+	}
+
+	if len(ranges) == 0 {
+		return nil, errors.New("multipart/byteranges response has no parts")
+	}
+
+	return ranges, nil
+}
+
+// Parses Content-Range value, e.g. "bytes 0-99/1000" or "bytes 0-99/*",
+// and checks that it matches body size.
+func parseContentRange(value string, body []byte) (byteRange, error) {
+	rng := byteRange{body: body}
+
+	spec := strings.TrimSpace(value)
+	if !strings.HasPrefix(spec, "bytes ") {
+		return rng, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	spec = strings.TrimSpace(strings.TrimPrefix(spec, "bytes "))
+
+	slash := strings.IndexByte(spec, '/')
+	dash := strings.IndexByte(spec, '-')
+	if slash < 0 || dash < 0 || dash > slash {
+		return rng, fmt.Errorf("invalid Content-Range %q", value)
+	}
+
+	var err1, err2, err3 error
+
+	rng.first, err1 = strconv.ParseInt(spec[:dash], 10, 64)
+	rng.last, err2 = strconv.ParseInt(spec[dash+1:slash], 10, 64)
+
+	if total := spec[slash+1:]; total == "*" {
+		rng.total = -1
+	} else {
+		rng.total, err3 = strconv.ParseInt(total, 10, 64)
+	}
+
+	if err1 != nil || err2 != nil || err3 != nil ||
+		rng.first < 0 || rng.last < rng.first ||
+		(rng.total >= 0 && rng.last >= rng.total) {
+		return rng, fmt.Errorf("invalid Content-Range %q", value)
+	}
+
+	if size := rng.last - rng.first + 1; size != int64(len(body)) {
+		return rng, fmt.Errorf(
+			"Content-Range %q defines %d bytes, but body has %d bytes",
+			value, size, len(body))
+	}
+
+	return rng, nil
+}
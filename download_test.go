@@ -0,0 +1,149 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createDownloadHandler(content string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader(content))
+	})
+}
+
+func TestDownloadWithRange(t *testing.T) {
+	reporter := newMockReporter(t)
+	config := newMockConfig(reporter)
+
+	req1 := NewRequestC(config, "GET", "url").
+		WithRange(0, 99).
+		WithRange(200, -1).
+		WithRange(-50, -1)
+	req1.chain.assertNotFailed(t)
+	assert.Equal(t, "bytes=0-99,200-,-50", req1.httpReq.Header.Get("Range"))
+
+	req2 := NewRequestC(config, "GET", "url").WithRange(10, 5)
+	req2.chain.assertFailed(t)
+
+	req3 := NewRequestC(config, "GET", "url").WithRange(-10, 5)
+	req3.chain.assertFailed(t)
+}
+
+func TestDownloadSingleRange(t *testing.T) {
+	e, _ := newMockExpect(t, createDownloadHandler("0123456789abcdef"))
+
+	resp := e.GET("/file").WithRange(2, 5).Expect()
+
+	resp.StatusPartialContent()
+	resp.ContentLengthEquals(4)
+	resp.Body().Equal("2345")
+	resp.chain.assertNotFailed(t)
+
+	ranges := resp.Ranges()
+	ranges.chain.assertNotFailed(t)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"first":        2.0,
+			"last":         5.0,
+			"total":        16.0,
+			"content_type": "text/plain",
+			"body":         "2345",
+		},
+	}, ranges.Raw())
+}
+
+func TestDownloadMultipleRanges(t *testing.T) {
+	e, _ := newMockExpect(t, createDownloadHandler("0123456789abcdef"))
+
+	resp := e.GET("/file").WithRange(0, 1).WithRange(-3, -1).Expect()
+
+	resp.StatusPartialContent()
+	resp.chain.assertNotFailed(t)
+
+	ranges := resp.Ranges()
+	ranges.Length().Equal(2)
+	ranges.Element(0).Object().ValueEqual("body", "01")
+	ranges.Element(1).Object().ValueEqual("first", 13)
+	ranges.Element(1).Object().ValueEqual("body", "def")
+	ranges.chain.assertNotFailed(t)
+}
+
+func TestDownloadFullContent(t *testing.T) {
+	e, _ := newMockExpect(t, createDownloadHandler("hello"))
+
+	resp := e.GET("/file").Expect()
+
+	resp.ContentLengthEquals(5)
+	resp.Body().Checksum("sha-256",
+		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+	resp.chain.assertNotFailed(t)
+
+	resp.ContentLengthEquals(6)
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.StatusPartialContent()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Ranges()
+	resp.chain.assertFailed(t)
+}
+
+func TestDownloadInvalidRanges(t *testing.T) {
+	cases := []struct {
+		name   string
+		header http.Header
+		body   string
+	}{
+		{
+			name:   "bad unit",
+			header: http.Header{"Content-Range": {"items 0-1/10"}},
+			body:   "01",
+		},
+		{
+			name:   "bad order",
+			header: http.Header{"Content-Range": {"bytes 5-1/10"}},
+			body:   "01",
+		},
+		{
+			name:   "beyond total",
+			header: http.Header{"Content-Range": {"bytes 8-10/10"}},
+			body:   "890",
+		},
+		{
+			name:   "size mismatch",
+			header: http.Header{"Content-Range": {"bytes 0-9/*"}},
+			body:   "01",
+		},
+		{
+			name: "no boundary",
+			header: http.Header{
+				"Content-Type": {"multipart/byteranges"},
+			},
+			body: "01",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := NewResponse(newMockReporter(t), &http.Response{
+				StatusCode:    http.StatusPartialContent,
+				Header:        tc.header,
+				ContentLength: int64(len(tc.body)),
+				Body:          ioutil.NopCloser(bytes.NewBufferString(tc.body)),
+			})
+
+			resp.StatusPartialContent()
+			resp.chain.assertFailed(t)
+		})
+	}
+}
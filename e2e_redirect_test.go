@@ -167,6 +167,32 @@ func testRedirects(t *testing.T, createFn func(Reporter) *Expect) {
 			WithMaxRedirects(0).
 			Expect().chain.assertNotFailed(t)
 	})
+
+	t.Run("redirect-chain", func(t *testing.T) {
+		e := createFn(NewAssertReporter(t))
+
+		resp := e.GET("/double_redirect").
+			Expect().
+			Status(http.StatusOK)
+
+		hops := resp.RedirectChain()
+		hops.Length().Equal(2)
+
+		first := hops.Element(0).Object()
+		first.ValueEqual("method", "GET")
+		first.ValueEqual("status", http.StatusTemporaryRedirect)
+		first.Value("url").String().HasSuffix("/double_redirect")
+		first.Value("location").String().HasSuffix("/redirect308")
+
+		second := hops.Element(1).Object()
+		second.ValueEqual("status", http.StatusPermanentRedirect)
+		second.Value("url").String().HasSuffix("/redirect308")
+		second.Value("location").String().HasSuffix("/content")
+
+		e.GET("/content").
+			Expect().
+			RedirectChain().Empty()
+	})
 }
 
 func TestE2ERedirectLive(t *testing.T) {
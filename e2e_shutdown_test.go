@@ -0,0 +1,78 @@
+package httpexpect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createShutdownHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		_, _ = w.Write([]byte("done"))
+	})
+
+	mux.HandleFunc("/fast", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	return mux
+}
+
+func TestE2EShutdownGraceful(t *testing.T) {
+	server := httptest.NewServer(createShutdownHandler())
+	defer server.Close()
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	GracefulShutdown(e, func() error {
+		return server.Config.Shutdown(context.Background())
+	}).
+		InFlight(func(e *Expect) *Response {
+			return e.GET("/slow").Expect().Status(http.StatusOK)
+		}).
+		InFlight(func(e *Expect) *Response {
+			return e.GET("/slow").Expect().Status(http.StatusOK)
+		}).
+		Refuse(func(e *Expect) *Request {
+			return e.GET("/fast")
+		}).
+		Run()
+
+	assert.False(t, reporter.reported)
+}
+
+func TestE2EShutdownAbrupt(t *testing.T) {
+	server := httptest.NewServer(createShutdownHandler())
+	defer server.Close()
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	GracefulShutdown(e, func() error {
+		server.CloseClientConnections()
+		return nil
+	}).
+		InFlight(func(e *Expect) *Response {
+			return e.GET("/slow").Expect()
+		}).
+		WithTimeout(time.Second).
+		Run()
+
+	assert.True(t, reporter.reported)
+}
@@ -113,3 +113,32 @@ func TestE2ETimeoutLargeBody(t *testing.T) {
 			String()
 	}
 }
+
+func TestE2ETimeoutExpectDeadline(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	handler := createTimeoutHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	r := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: r,
+	})
+
+	start := time.Now()
+
+	e.GET("/sleep").
+		WithMaxRetries(5).
+		WithRetryPolicy(RetryAllErrors).
+		WithExpectTimeout(50 * time.Millisecond).
+		Expect()
+
+	assert.True(t, r.reported)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
@@ -2,10 +2,12 @@ package httpexpect
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
@@ -138,3 +140,59 @@ func TestE2EAutoTLSBinderFast(t *testing.T) {
 		})
 	}
 }
+
+func TestE2EMutualTLS(t *testing.T) {
+	certPEM, keyPEM, clientCert := newTestCert(t, "client.example.com")
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCert)
+
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+		Client:   server.Client(),
+	})
+
+	resp := e.GET("/").
+		WithClientCert(certPEM, keyPEM).
+		Expect()
+
+	resp.Status(http.StatusOK).
+		Body().Equal("client.example.com")
+
+	state := resp.TLS()
+	state.Version().NotEmpty()
+	state.CipherSuite().NotEmpty()
+
+	cert := state.PeerCertificate()
+	cert.ValidFor("127.0.0.1")
+	cert.NotAfter().Gt(time.Now())
+
+	assert.False(t, reporter.reported)
+
+	reporter = newMockReporter(t)
+
+	e = WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+		Client:   server.Client(),
+	})
+
+	e.GET("/").Expect()
+
+	assert.True(t, reporter.reported)
+}
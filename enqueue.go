@@ -0,0 +1,236 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// PendingResponse is a response of request that is enqueued but not yet
+// sent, see Request.Enqueue.
+type PendingResponse struct {
+	chain *chain
+	req   *Request
+	resp  *Response
+}
+
+// Queue of requests shared by Expect instance and its copies.
+type requestQueue struct {
+	mu      sync.Mutex
+	pending []*PendingResponse
+}
+
+func (q *requestQueue) push(p *PendingResponse) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = append(q.pending, p)
+}
+
+func (q *requestQueue) takeAll() []*PendingResponse {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pending := q.pending
+	q.pending = nil
+
+	return pending
+}
+
+// Enqueue adds request to the queue of Expect instance, which created it,
+// instead of sending it immediately. Returns PendingResponse, which can be
+// used to get response after the queue is flushed.
+//
+// Enqueued requests are sent by Expect.Flush or Expect.FlushConcurrent.
+// This allows to prepare test data using many requests without waiting
+// for every response before sending next request.
+//
+// Request should not be used after calling Enqueue.
+//
+// Example:
+//
+//	alice := e.POST("/users").WithJSON(alice).Enqueue()
+//	bob := e.POST("/users").WithJSON(bob).Enqueue()
+//
+//	e.FlushConcurrent(10)
+//
+//	alice.Response().Status(http.StatusCreated)
+//	bob.Response().Status(http.StatusCreated)
+func (r *Request) Enqueue() *PendingResponse {
+	r.chain.enter("Enqueue()")
+	defer r.chain.leave()
+
+	p := &PendingResponse{
+		req: r,
+	}
+
+	if r.chain.failed() {
+		p.chain = r.chain.clone()
+		return p
+	}
+
+	if r.queue == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected Enqueue for request not created by Expect"),
+			},
+		})
+		p.chain = r.chain.clone()
+		return p
+	}
+
+	p.chain = r.chain.clone()
+
+	r.queue.push(p)
+
+	return p
+}
+
+// Response returns Response of enqueued request.
+//
+// If the queue wasn't flushed yet, failure is reported.
+//
+// Example:
+//
+//	pending := e.GET("/users").Enqueue()
+//	e.Flush()
+//	pending.Response().Status(http.StatusOK)
+func (p *PendingResponse) Response() *Response {
+	p.chain.enter("Response()")
+	defer p.chain.leave()
+
+	if p.chain.failed() {
+		return newResponse(responseOpts{
+			config: p.req.config,
+			chain:  p.chain,
+		})
+	}
+
+	if p.resp == nil {
+		p.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected Response for request that was not sent yet," +
+					" use Expect.Flush to send enqueued requests"),
+			},
+		})
+		return newResponse(responseOpts{
+			config: p.req.config,
+			chain:  p.chain,
+		})
+	}
+
+	return p.resp
+}
+
+// Flush sends all requests enqueued by Request.Enqueue, one by one, in the
+// order they were enqueued.
+//
+// Requests are sent as usual, including retries and printers, and their
+// failures are reported as usual. Matchers, registered by Expect.Matcher
+// or Request.WithMatcher, are invoked after all requests are sent, in the
+// same order.
+//
+// Example:
+//
+//	for _, user := range users {
+//	    e.POST("/users").WithJSON(user).Enqueue()
+//	}
+//
+//	e.Flush()
+func (e *Expect) Flush() *Expect {
+	e.chain.enter("Flush()")
+	defer e.chain.leave()
+
+	e.flush(1)
+
+	return e
+}
+
+// FlushConcurrent is like Flush, but sends up to n enqueued requests in
+// parallel.
+//
+// Requests share client of Expect instance, so when default http.Client
+// is used, connections are reused from its pool. Matchers are still
+// invoked sequentially, in the order requests were enqueued, after all
+// requests are sent.
+//
+// Example:
+//
+//	for _, user := range users {
+//	    e.POST("/users").WithJSON(user).Enqueue()
+//	}
+//
+//	e.FlushConcurrent(10)
+func (e *Expect) FlushConcurrent(n int) *Expect {
+	e.chain.enter("FlushConcurrent(%d)", n)
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return e
+	}
+
+	if n < 1 {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive concurrency: %d", n),
+			},
+		})
+		return e
+	}
+
+	e.flush(n)
+
+	return e
+}
+
+func (e *Expect) flush(concurrency int) {
+	if e.queue == nil || e.chain.failed() {
+		return
+	}
+
+	pending := e.queue.takeAll()
+
+	// Matchers are detached while requests are sent and invoked
+	// afterwards. Requests with expected failure need their matchers
+	// to decide whether request failed, so they keep them.
+	matchers := make([][]func(*Response), len(pending))
+
+	for i, p := range pending {
+		if p.req.expectedFailure == "" {
+			matchers[i] = p.req.matchers
+			p.req.matchers = nil
+		}
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency && w < len(pending); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				pending[i].resp = pending[i].req.Expect()
+			}
+		}()
+	}
+
+	for i := range pending {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	for i, p := range pending {
+		for _, matcher := range matchers[i] {
+			matcher(p.resp)
+		}
+	}
+}
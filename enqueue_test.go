@@ -0,0 +1,198 @@
+package httpexpect
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueFailed(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter)
+	config := newMockConfig(reporter)
+
+	chain.fail(mockFailure())
+
+	req := newRequest(chain, config, "GET", "")
+
+	pending := req.Enqueue()
+	pending.chain.assertFailed(t)
+	pending.Response().chain.assertFailed(t)
+}
+
+func TestEnqueueUsage(t *testing.T) {
+	t.Run("standalone request", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "GET", "/")
+
+		pending := req.Enqueue()
+		pending.chain.assertFailed(t)
+		pending.Response().chain.assertFailed(t)
+
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("not flushed", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: reporter,
+		})
+
+		pending := e.GET("/").Enqueue()
+		pending.chain.assertNotFailed(t)
+
+		pending.Response().chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("invalid concurrency", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/").Enqueue()
+
+		e.FlushConcurrent(0).chain.assertFailed(t)
+		assert.Nil(t, client.req)
+	})
+}
+
+func TestEnqueueFlush(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		events = append(events, "send "+r.URL.Path)
+		mu.Unlock()
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	m := e.Matcher(func(resp *Response) {
+		events = append(events, "match "+resp.Raw().Request.URL.Path)
+	})
+
+	p1 := m.GET("/a").Enqueue()
+	p2 := m.GET("/b").Enqueue()
+	p3 := e.GET("/missing").
+		WithMatcher(func(resp *Response) {
+			resp.Status(http.StatusOK)
+		}).
+		Enqueue()
+
+	assert.Empty(t, events)
+
+	m.Flush().chain.assertNotFailed(t)
+
+	assert.Equal(t, []string{
+		"send /a",
+		"send /b",
+		"send /missing",
+		"match /a",
+		"match /b",
+	}, events)
+
+	p1.Response().chain.assertNotFailed(t)
+	p2.Response().chain.assertNotFailed(t)
+	p3.Response().chain.assertFailed(t)
+
+	assert.True(t, reporter.reported)
+
+	assert.Same(t, p1.Response(), p1.Response())
+
+	// queue is empty after flush
+	events = nil
+
+	e.Flush()
+	assert.Empty(t, events)
+}
+
+func TestEnqueueFlushConcurrent(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inflight int
+		maxIn    int
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inflight++
+		if inflight > maxIn {
+			maxIn = inflight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inflight--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	var matched []int
+
+	var pending []*PendingResponse
+
+	for i := 0; i < 20; i++ {
+		index := i
+
+		pending = append(pending, e.POST("/users").
+			WithJSON(map[string]interface{}{"id": i}).
+			WithMatcher(func(resp *Response) {
+				matched = append(matched, index)
+			}).
+			Enqueue())
+	}
+
+	e.FlushConcurrent(5).chain.assertNotFailed(t)
+
+	assert.LessOrEqual(t, maxIn, 5)
+	assert.Greater(t, maxIn, 1)
+
+	require.Equal(t, 20, len(matched))
+	for i := range matched {
+		assert.Equal(t, i, matched[i])
+	}
+
+	for _, p := range pending {
+		p.Response().Status(http.StatusCreated).chain.assertNotFailed(t)
+	}
+}
@@ -35,6 +35,12 @@ func newEnvironment(parent *chain) *Environment {
 	}
 }
 
+func (e *Environment) copyFrom(other *Environment) {
+	for k, v := range other.data {
+		e.data[k] = v
+	}
+}
+
 // Put saves the value with key in the environment.
 //
 // Example:
@@ -81,8 +81,11 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
 )
 
 // Expect is a toplevel object that contains user Config and allows
@@ -92,6 +95,7 @@ type Expect struct {
 	chain    *chain
 	builders []func(*Request)
 	matchers []func(*Response)
+	queue    *requestQueue
 }
 
 // Config contains various settings.
@@ -119,6 +123,17 @@ type Config struct {
 	// Useful for Google App Engine testing for example.
 	RequestFactory RequestFactory
 
+	// RequestTransformer is invoked for every request right before it's
+	// sent, after all With* calls and transforms attached by
+	// Request.WithTransformer.
+	// May be nil.
+	//
+	// It receives final http.Request, with encoded URL, headers, and body,
+	// so it can be used to compute signatures over the whole request, e.g.
+	// HMAC of request body. Transformer may read request body; the body is
+	// rewound after transformer returns.
+	RequestTransformer func(*http.Request)
+
 	// Client is used to send http.Request and receive http.Response.
 	// May be nil.
 	//
@@ -150,6 +165,13 @@ type Config struct {
 	// for per-request timeout.
 	Context context.Context
 
+	// RateLimit defines client-side throttling of requests to every host.
+	// May be nil.
+	//
+	// If non-nil, requests exceeding the limit are delayed before sending,
+	// including retry attempts and WebSocket handshakes.
+	RateLimit *RateLimit
+
 	// Reporter is used to report formatted failure messages.
 	// Should NOT be nil, unless custom AssertionHandler is used.
 	//
@@ -192,19 +214,187 @@ type Config struct {
 	// set Reporter. Use AssertionHandler for more precise control of reports.
 	AssertionHandler AssertionHandler
 
+	// TrackLocations enables capturing source location of every chained
+	// call, e.g. every Expect(), JSON(), Object(), etc.
+	// May be false.
+	//
+	// By default, on failure, only location of the failed assertion itself
+	// is reported in AssertionContext.PathLocations. If TrackLocations is
+	// true, locations of all elements of the assertion path are reported,
+	// which helps when chain is built across helper functions, but makes
+	// every assertion noticeably slower.
+	TrackLocations bool
+
 	// Printers are used to print requests and responses.
 	// May be nil.
 	//
 	// If printer implements WebsocketPrinter interface, it will be also used
 	// to print WebSocket messages.
 	//
-	// You can use CompactPrinter, DebugPrinter, CurlPrinter, or provide
-	// custom implementation.
+	// You can use CompactPrinter, DebugPrinter, CurlPrinter, HARPrinter,
+	// or provide custom implementation.
 	//
 	// You can also use builtin printers with alternative Logger if you're happy
 	// with their format, but want to send logs somewhere else than *testing.T.
 	Printers []Printer
 
+	// RedactionRules define sensitive data that should be hidden in logs
+	// and reports.
+	// May be nil.
+	//
+	// If non-nil, rules are applied to requests, responses, and WebSocket
+	// messages before passing them to Printers, and to Formatter of the
+	// automatically constructed DefaultAssertionHandler.
+	RedactionRules *RedactionRules
+
+	// Capture saves raw bytes of failed requests to files.
+	// May be nil.
+	//
+	// If non-nil, every request records bytes written to and read from its
+	// connection, and when any assertion of request or its response fails,
+	// they are saved by Capture. Client should dial connections via Capture,
+	// see Capture.Transport.
+	Capture *Capture
+
+	// MetricsSink receives metrics of requests: counts, latencies, and
+	// failures per endpoint.
+	// May be nil.
+	//
+	// You can use Metrics, which can export collected metrics as summary
+	// file or push them to Prometheus Pushgateway, or provide custom
+	// implementation.
+	MetricsSink MetricsSink
+
+	// Invariants collects results of invariants registered by
+	// Expect.Invariant across test suite.
+	// May be nil.
+	//
+	// If non-nil, the same instance is usually shared by all tests, and
+	// inspected when the test run is finished to see which invariants held.
+	Invariants *Invariants
+
+	// OnFailure is invoked every time an assertion fails.
+	// May be nil.
+	//
+	// It allows to gather additional diagnostics automatically when test
+	// fails, e.g. fetch server logs or /debug endpoints, or dump database
+	// state. It is invoked before the failure is passed to AssertionHandler,
+	// so it runs even if Reporter stops the test (like RequireReporter).
+	//
+	// Only failures with SeverityError are passed to OnFailure; failures
+	// of predicates (e.g. in Array.Filter) and warnings are not. Inside
+	// Expect.SoftAssertions, OnFailure is invoked once for the combined
+	// failure.
+	//
+	// Hook receives Expect created from the same Config, but with hooks
+	// disabled. Hook should use it to send requests and make assertions,
+	// so that they don't invoke hooks again. Hooks may be invoked
+	// concurrently when Expect is shared between goroutines.
+	OnFailure func(e *Expect, ctx *AssertionContext, failure *AssertionFailure)
+
+	// OnSuccess is invoked every time an assertion succeeds.
+	// May be nil.
+	//
+	// See OnFailure for details.
+	OnSuccess func(e *Expect, ctx *AssertionContext)
+
+	// JSONNumber enables precise decoding of JSON numbers.
+	// May be false.
+	//
+	// By default, all JSON numbers are decoded into float64, so integers
+	// beyond 2^53 (e.g. 64-bit IDs) and long decimals (e.g. monetary amounts)
+	// are silently rounded. If JSONNumber is true, response and message bodies
+	// are decoded using json.Number, and numbers that can't be exactly
+	// represented by float64 are preserved in canonical values as json.Number.
+	// Number matchers then use exact comparison for them, see Number.IsInt,
+	// Number.AsInt, and Number.EqualDecimal.
+	JSONNumber bool
+
+	// StrictDecoding enables strict decoding of JSON values into structs.
+	// May be false.
+	//
+	// By default, Value.Decode, Object.Decode, and Array.Decode silently
+	// ignore JSON object fields that don't match any field of the target
+	// struct. If StrictDecoding is true, such fields are reported as failure,
+	// which helps to catch unexpected additions to response payload.
+	StrictDecoding bool
+
+	// Validator is used to validate structs after decoding.
+	// May be nil.
+	//
+	// If non-nil, Value.Decode, Object.Decode, and Array.Decode invoke it
+	// for the target struct (or for every struct element of the target
+	// slice) after successful decoding, and report returned error as
+	// failure. *validator.Validate from github.com/go-playground/validator
+	// implements this interface and checks "validate" struct tags.
+	Validator StructValidator
+
+	// IgnorePaths defines fields excluded from object comparisons.
+	// May be nil.
+	//
+	// Paths are applied by Object.Equal, Object.NotEqual, Object.EqualFile,
+	// and Object.EqualIgnoring to both compared objects, and are relative to
+	// the object being compared. Every path is a dot-separated list of object
+	// keys and array indexes, and asterisk matches any key or index, e.g.
+	// "id", "meta.trace_id", or "items.*.created_at".
+	//
+	// It's useful to exclude volatile fields, like IDs, timestamps, and
+	// traces, without deleting them from both sides manually.
+	IgnorePaths []string
+
+	// Marshaler is used to encode values to JSON in WithJSON and
+	// Websocket.WriteJSON, and to canonicalize values passed to matchers.
+	// May be nil.
+	//
+	// If nil, json.Marshal is used. Use DefaultMarshaler with registered
+	// types to get predictable representation of custom types, e.g.
+	// decimals or protobuf well-known types, see DefaultMarshaler.
+	Marshaler Marshaler
+
+	// FormEncoder is used to convert objects to form fields in WithForm
+	// and WithFormField.
+	// May be nil.
+	//
+	// If nil, DefaultFormEncoder is used, which encodes nested fields using
+	// dot notation. Use NestedFormEncoder for nested bracket syntax.
+	FormEncoder FormEncoder
+
+	// StrictContentType enables strict checking of JSON media type.
+	// May be false.
+	//
+	// By default, Response.JSON accepts any media type specified via
+	// ContentOpts. If StrictContentType is true, Response.JSON also fails
+	// if response media type is not JSON-compatible, i.e. is neither
+	// "application/json" nor "application/*+json".
+	StrictContentType bool
+
+	// PathEscaping defines how path parameters are percent-encoded.
+	// May be zero (PathEscapeDefault).
+	//
+	// If PathEscapeSegments is used, every path parameter is escaped as
+	// a single path segment, and percent-encoded sequences in path template
+	// are preserved. See PathEscaping for details.
+	PathEscaping PathEscaping
+
+	// AllowUnresolvedPath disables failure when request path still has
+	// named parameters, e.g. "{id}", that were not substituted by pathargs,
+	// WithPath, WithPathParam, WithPathParams, or WithPathObject.
+	// May be false.
+	//
+	// By default, Request.Expect reports failure for such requests. If
+	// AllowUnresolvedPath is true, parameters are left in path as is and
+	// urlencoded, e.g. "/users/{id}" becomes "/users/%7Bid%7D", which was
+	// the behavior of older versions.
+	AllowUnresolvedPath bool
+
+	// DisableDigestCheck disables automatic verification of response
+	// integrity headers. May be false.
+	//
+	// By default, if response has Content-MD5, Digest, Content-Digest,
+	// or Repr-Digest header, response body is verified against it when
+	// response is received, see Response.VerifyDigest.
+	DisableDigestCheck bool
+
 	// Environment provides a container for arbitrary data shared between tests.
 	// May be nil.
 	//
@@ -215,6 +405,22 @@ type Config struct {
 	// If Environment is nil, a new empty environment is automatically created
 	// when Expect instance is constructed.
 	Environment *Environment
+
+	// Routes defines registry of named routes, used by Expect.Route and
+	// Expect.Named. May be nil.
+	//
+	// If Routes is nil, a new empty registry is automatically created
+	// when Expect instance is constructed. To collect route coverage
+	// across multiple tests, share the same Routes instance between them.
+	Routes *Routes
+
+	// Manifest records endpoints, schemas, and files used by assertions,
+	// and enabled features, see Expect.Manifest. May be nil.
+	//
+	// If Manifest is nil, a new empty manifest is automatically created
+	// when Expect instance is constructed. To build manifest of the whole
+	// run, share the same Manifest instance between tests.
+	Manifest *Manifest
 }
 
 func (config Config) withDefaults() Config {
@@ -222,6 +428,18 @@ func (config Config) withDefaults() Config {
 		config.RequestFactory = DefaultRequestFactory{}
 	}
 
+	if config.FormEncoder == nil {
+		config.FormEncoder = DefaultFormEncoder{}
+	}
+
+	if config.Routes == nil {
+		config.Routes = NewRoutes()
+	}
+
+	if config.Manifest == nil {
+		config.Manifest = NewManifest()
+	}
+
 	if config.Client == nil {
 		config.Client = &http.Client{
 			Jar: NewJar(),
@@ -241,9 +459,14 @@ func (config Config) withDefaults() Config {
 			panic("either Reporter or AssertionHandler should be non-nil")
 		}
 
+		formatter := config.Formatter
+		if config.RedactionRules != nil {
+			formatter = config.RedactionRules.WrapFormatter(formatter)
+		}
+
 		config.AssertionHandler = &DefaultAssertionHandler{
 			Reporter:  config.Reporter,
-			Formatter: config.Formatter,
+			Formatter: formatter,
 		}
 	}
 
@@ -262,6 +485,10 @@ func (config *Config) validate() {
 	if config.AssertionHandler == nil {
 		panic("Config.AssertionHandler is nil")
 	}
+
+	if config.RateLimit != nil && !(config.RateLimit.PerSecond > 0) {
+		panic("Config.RateLimit.PerSecond is not positive")
+	}
 }
 
 // RequestFactory is used to create all http.Request objects.
@@ -323,13 +550,22 @@ type Logger interface {
 }
 
 // TestingTB is a subset of testing.TB interface used by httpexpect.
-// You can use *testing.T or pass custom implementation.
+// You can use *testing.T, *testing.B, or pass custom implementation.
 type TestingTB interface {
 	Reporter
 	Logger
 	Name() string // Returns current test name.
 }
 
+// StructValidator is used to validate decoded structs, see Config.Validator.
+// *validator.Validate from github.com/go-playground/validator implements
+// this interface.
+type StructValidator interface {
+	// Struct returns nil if struct is valid, or error describing
+	// violations otherwise. s is a pointer to struct.
+	Struct(s interface{}) error
+}
+
 // Deprecated: use TestingTB instead.
 type LoggerReporter interface {
 	Logger
@@ -410,9 +646,12 @@ func WithConfig(config Config) *Expect {
 
 	config.validate()
 
+	config.Manifest.addFeatures(config)
+
 	return &Expect{
 		chain:  newChainWithConfig("", config),
 		config: config,
+		queue:  &requestQueue{},
 	}
 }
 
@@ -441,6 +680,171 @@ func (e *Expect) clone() *Expect {
 	return &ret
 }
 
+// Clone returns an isolated copy of Expect instance.
+//
+// Returned copy shares Config (including Client, Printers, and
+// AssertionHandler) and all attached builders and matchers, but has its
+// own chain and its own Environment. Environment of the copy is initialized
+// with a copy of parent's data, and changes made by the copy are not
+// visible to the parent and vice versa.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//	e.Env().Put("token", token)
+//
+//	c := e.Clone()
+//	c.Env().Put("token", "other") // doesn't affect e
+func (e *Expect) Clone() *Expect {
+	return e.fork(e.config)
+}
+
+// WithTestName returns an isolated copy of Expect instance bound to
+// given test, usually a subtest's *testing.T.
+//
+// It works like Clone, but in addition sets Config.TestName to t.Name(),
+// and if AssertionHandler was constructed automatically from Reporter,
+// rebinds Reporter to t. AssertReporter and RequireReporter are
+// re-created for t, and a Reporter which is itself a TestingTB (e.g.
+// *testing.T) is replaced with t. Similarly, CompactPrinter, CurlPrinter,
+// and DebugPrinter, which write to parent test of t, are re-created to
+// write to t. Custom AssertionHandler, custom Reporter, and other Printers
+// are kept as is.
+//
+// This allows to use single Expect instance in table-driven tests with
+// t.Run() and t.Parallel(), so that failures are reported to the
+// subtest where they happened.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	for _, tc := range cases {
+//	    tc := tc
+//	    t.Run(tc.name, func(t *testing.T) {
+//	        t.Parallel()
+//
+//	        e := e.WithTestName(t)
+//
+//	        e.GET(tc.path).
+//	            Expect().
+//	            Status(tc.status)
+//	    })
+//	}
+func (e *Expect) WithTestName(t TestingTB) *Expect {
+	config := e.config
+
+	config.TestName = t.Name()
+
+	if h, ok := config.AssertionHandler.(*DefaultAssertionHandler); ok &&
+		sameReporter(h.Reporter, config.Reporter) {
+		reporter := rebindReporter(config.Reporter, t)
+
+		config.Reporter = reporter
+		config.AssertionHandler = &DefaultAssertionHandler{
+			Formatter: h.Formatter,
+			Reporter:  reporter,
+			Logger:    h.Logger,
+		}
+	}
+
+	config.Printers = rebindPrinters(config.Printers, t)
+
+	return e.fork(config)
+}
+
+func (e *Expect) fork(config Config) *Expect {
+	ret := e.clone()
+
+	config.Environment = nil
+
+	ret.chain = newChainWithConfig("", config)
+	ret.chain.getEnv().copyFrom(e.Env())
+
+	config.Environment = ret.chain.getEnv()
+	ret.config = config
+
+	ret.queue = &requestQueue{}
+
+	return ret
+}
+
+func sameReporter(a, b Reporter) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta != tb || !ta.Comparable() {
+		return false
+	}
+
+	return a == b
+}
+
+func rebindReporter(reporter Reporter, t TestingTB) Reporter {
+	switch reporter.(type) {
+	case *AssertReporter:
+		return NewAssertReporter(t)
+
+	case *RequireReporter:
+		if rt, ok := t.(require.TestingT); ok {
+			return NewRequireReporter(rt)
+		}
+		return reporter
+
+	case TestingTB:
+		return t
+
+	default:
+		return reporter
+	}
+}
+
+func rebindPrinters(printers []Printer, t TestingTB) []Printer {
+	if len(printers) == 0 {
+		return printers
+	}
+
+	ret := make([]Printer, 0, len(printers))
+
+	for _, printer := range printers {
+		switch p := printer.(type) {
+		case CompactPrinter:
+			if isParentTest(p.logger, t) {
+				p.logger = t
+			}
+			printer = p
+
+		case CurlPrinter:
+			if isParentTest(p.logger, t) {
+				p.logger = t
+			}
+			printer = p
+
+		case DebugPrinter:
+			if isParentTest(p.logger, t) {
+				p.logger = t
+			}
+			printer = p
+		}
+
+		ret = append(ret, printer)
+	}
+
+	return ret
+}
+
+// Check if logger is a test, which is parent of t.
+func isParentTest(logger Logger, t TestingTB) bool {
+	parent, ok := logger.(TestingTB)
+	if !ok {
+		return false
+	}
+
+	return strings.HasPrefix(t.Name(), parent.Name()+"/")
+}
+
 // Builder returns a copy of Expect instance with given builder attached to it.
 // Returned copy contains all previously attached builders plus a new one.
 // Builders are invoked from Request method, after constructing every new request.
@@ -501,8 +905,14 @@ func (e *Expect) Request(method, path string, pathargs ...interface{}) *Request
 	e.chain.enter("Request(%q)", method)
 	defer e.chain.leave()
 
+	return e.newRequest(method, path, pathargs...)
+}
+
+func (e *Expect) newRequest(method, path string, pathargs ...interface{}) *Request {
 	req := newRequest(e.chain, e.config, method, path, pathargs...)
 
+	req.queue = e.queue
+
 	for _, builder := range e.builders {
 		builder(req)
 	}
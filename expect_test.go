@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExpectMethods(t *testing.T) {
@@ -340,6 +341,196 @@ func TestExpectBranches(t *testing.T) {
 	e4.chain.assertNotFailed(t)
 }
 
+func TestExpectClone(t *testing.T) {
+	client := &mockClient{}
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		Client:   client,
+		Reporter: reporter,
+	})
+
+	e = e.Builder(func(req *Request) {
+		req.WithHeader("X-Builder", "1")
+	})
+
+	e.Env().Put("key", "parent")
+
+	c := e.Clone()
+
+	assert.Equal(t, "parent", c.Env().GetString("key"))
+
+	c.Env().Put("key", "child")
+	c.Env().Put("other", "child")
+
+	assert.Equal(t, "parent", e.Env().GetString("key"))
+	assert.False(t, e.Env().Has("other"))
+
+	c.GET("/path").Expect().chain.assertNotFailed(t)
+	assert.Equal(t, "1", client.req.Header.Get("X-Builder"))
+
+	c.chain.setFailed()
+	e.chain.assertNotFailed(t)
+}
+
+type mockTestingTB struct {
+	*mockReporter
+	name string
+}
+
+func (m *mockTestingTB) Logf(message string, args ...interface{}) {
+}
+
+func (m *mockTestingTB) Name() string {
+	return m.name
+}
+
+func TestExpectWithTestName(t *testing.T) {
+	t.Run("assert_reporter", func(t *testing.T) {
+		parent := &mockTestingTB{newMockReporter(t), "Parent"}
+		child := &mockTestingTB{newMockReporter(t), "Parent/Child"}
+
+		e := WithConfig(Config{
+			TestName: parent.Name(),
+			Client:   &mockClient{},
+			Reporter: NewAssertReporter(parent),
+		})
+
+		c := e.WithTestName(child)
+
+		assert.Equal(t, "Parent/Child", c.config.TestName)
+		assert.Equal(t, "Parent/Child", c.chain.context.TestName)
+		assert.Equal(t, "Parent", e.chain.context.TestName)
+
+		c.Value(1).Equal(2)
+
+		assert.False(t, parent.reported)
+		assert.True(t, child.reported)
+	})
+
+	t.Run("testing_tb_reporter", func(t *testing.T) {
+		parent := &mockTestingTB{newMockReporter(t), "Parent"}
+		child := &mockTestingTB{newMockReporter(t), "Parent/Child"}
+
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: parent,
+		})
+
+		e.WithTestName(child).Value(1).Equal(2)
+
+		assert.False(t, parent.reported)
+		assert.True(t, child.reported)
+	})
+
+	t.Run("printers", func(t *testing.T) {
+		parent := &mockTestingTB{newMockReporter(t), "Parent"}
+		child := &mockTestingTB{newMockReporter(t), "Parent/Child"}
+		other := &mockTestingTB{newMockReporter(t), "Other"}
+		logger := newMockLogger(t)
+
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: NewAssertReporter(parent),
+			Printers: []Printer{
+				NewCompactPrinter(parent),
+				NewCurlPrinter(parent),
+				NewDebugPrinter(parent, true),
+				NewCompactPrinter(other),
+				NewCompactPrinter(logger),
+			},
+		})
+
+		c := e.WithTestName(child)
+
+		require.Equal(t, 5, len(c.config.Printers))
+
+		assert.Same(t, child, c.config.Printers[0].(CompactPrinter).logger)
+		assert.Same(t, child, c.config.Printers[1].(CurlPrinter).logger)
+		assert.Same(t, child, c.config.Printers[2].(DebugPrinter).logger)
+		assert.Same(t, other, c.config.Printers[3].(CompactPrinter).logger)
+		assert.Same(t, logger, c.config.Printers[4].(CompactPrinter).logger)
+
+		// parent is not modified
+		assert.Same(t, parent, e.config.Printers[0].(CompactPrinter).logger)
+	})
+
+	t.Run("custom_handler", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		child := &mockTestingTB{newMockReporter(t), "Child"}
+
+		e := WithConfig(Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		})
+
+		e.WithTestName(child).Value(1).Equal(2)
+
+		assert.False(t, child.reported)
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, "Child", handler.ctx.TestName)
+	})
+}
+
+type hookOrderReporter struct {
+	order *[]string
+}
+
+func (r *hookOrderReporter) Errorf(message string, args ...interface{}) {
+	*r.order = append(*r.order, "report")
+}
+
+func TestExpectHooks(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	mux.HandleFunc("/debug", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("debug info"))
+	})
+
+	var (
+		order     []string
+		successes int
+		debug     string
+	)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: &hookOrderReporter{&order},
+		Client: &http.Client{
+			Transport: NewBinder(mux),
+		},
+		OnSuccess: func(e *Expect, ctx *AssertionContext) {
+			successes++
+		},
+		OnFailure: func(e *Expect, ctx *AssertionContext, failure *AssertionFailure) {
+			order = append(order, "hook")
+
+			// hooks are not invoked for assertions made by hook
+			debug = e.GET("/debug").Expect().
+				Status(http.StatusTeapot).
+				Body().Raw()
+		},
+	})
+
+	e.GET("/users").Expect().Status(http.StatusOK)
+
+	assert.Equal(t, []string{"hook", "report", "report"}, order)
+	assert.Equal(t, "debug info", debug)
+	assert.NotZero(t, successes)
+
+	order = nil
+	successes = 0
+
+	e.GET("/debug").Expect().Status(http.StatusOK)
+
+	assert.Empty(t, order)
+	assert.NotZero(t, successes)
+}
+
 func TestExpectStdCompat(_ *testing.T) {
 	Default(&testing.T{}, "")
 	Default(&testing.B{}, "")
@@ -0,0 +1,135 @@
+package httpexpect
+
+import (
+	"strings"
+)
+
+// FailureCode is a stable machine-readable identifier of assertion failure.
+//
+// Code has form "<subject>.<kind>", where subject describes what was checked
+// (e.g. "status", "header", "body", "value"), and kind describes how
+// the check failed (e.g. "mismatch", "key_missing", "schema_violation").
+//
+// Codes don't depend on error messages and values, so they can be used to
+// aggregate and triage failures across many test runs.
+//
+// Examples:
+//
+//	status.mismatch
+//	header.key_missing
+//	body.schema_violation
+//	value.out_of_range
+type FailureCode string
+
+// Subject returns the part of code before the dot, e.g. "status".
+func (c FailureCode) Subject() string {
+	if i := strings.IndexByte(string(c), '.'); i >= 0 {
+		return string(c[:i])
+	}
+	return ""
+}
+
+// Kind returns the part of code after the dot, e.g. "mismatch".
+func (c FailureCode) Kind() string {
+	if i := strings.IndexByte(string(c), '.'); i >= 0 {
+		return string(c[i+1:])
+	}
+	return string(c)
+}
+
+// Subjects of failure codes.
+const (
+	failureSubjectStatus    = "status"
+	failureSubjectHeader    = "header"
+	failureSubjectCookie    = "cookie"
+	failureSubjectBody      = "body"
+	failureSubjectWebsocket = "websocket"
+	failureSubjectRequest   = "request"
+	failureSubjectValue     = "value"
+)
+
+// Kinds of failure codes, indexed by assertion type.
+var failureKinds = map[AssertionType]string{
+	AssertUsage:              "usage",
+	AssertOperation:          "operation",
+	AssertType:               "type_mismatch",
+	AssertNotType:            "type_unexpected",
+	AssertValid:              "invalid",
+	AssertNotValid:           "unexpected_valid",
+	AssertNil:                "not_nil",
+	AssertNotNil:             "nil",
+	AssertEmpty:              "not_empty",
+	AssertNotEmpty:           "empty",
+	AssertEqual:              "mismatch",
+	AssertNotEqual:           "unexpected_match",
+	AssertLt:                 "out_of_bounds",
+	AssertLe:                 "out_of_bounds",
+	AssertGt:                 "out_of_bounds",
+	AssertGe:                 "out_of_bounds",
+	AssertInRange:            "out_of_range",
+	AssertNotInRange:         "in_range",
+	AssertMatchSchema:        "schema_violation",
+	AssertNotMatchSchema:     "schema_unexpected_match",
+	AssertMatchPath:          "path_mismatch",
+	AssertNotMatchPath:       "path_unexpected_match",
+	AssertMatchRegexp:        "regexp_mismatch",
+	AssertNotMatchRegexp:     "regexp_unexpected_match",
+	AssertMatchFormat:        "format_mismatch",
+	AssertNotMatchFormat:     "format_unexpected_match",
+	AssertContainsKey:        "key_missing",
+	AssertNotContainsKey:     "key_unexpected",
+	AssertContainsElement:    "element_missing",
+	AssertNotContainsElement: "element_unexpected",
+	AssertContainsSubset:     "subset_missing",
+	AssertNotContainsSubset:  "subset_unexpected",
+	AssertBelongs:            "not_allowed",
+	AssertNotBelongs:         "forbidden",
+}
+
+// Path prefixes of assertions that define failure subject, in order of
+// precedence. The innermost matching assertion in path wins.
+var failureSubjects = []struct {
+	prefix  string
+	subject string
+}{
+	{"Status", failureSubjectStatus},
+	{"Header(", failureSubjectHeader},
+	{"Headers(", failureSubjectHeader},
+	{"ContentType", failureSubjectHeader},
+	{"ContentEncoding", failureSubjectHeader},
+	{"TransferEncoding", failureSubjectHeader},
+	{"Deprecat", failureSubjectHeader},
+	{"Sunset", failureSubjectHeader},
+	{"Cookie", failureSubjectCookie},
+	{"JSON", failureSubjectBody},
+	{"Body", failureSubjectBody},
+	{"Text", failureSubjectBody},
+	{"Form", failureSubjectBody},
+	{"XML", failureSubjectBody},
+	{"NoContent", failureSubjectBody},
+	{"Websocket", failureSubjectWebsocket},
+	{"Expect(", failureSubjectRequest},
+	{"Request(", failureSubjectRequest},
+}
+
+// Build failure code from assertion path and type.
+func makeFailureCode(path []string, typ AssertionType) FailureCode {
+	kind, ok := failureKinds[typ]
+	if !ok {
+		kind = "unknown"
+	}
+
+	return FailureCode(failureSubject(path) + "." + kind)
+}
+
+func failureSubject(path []string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		for _, s := range failureSubjects {
+			if strings.HasPrefix(path[i], s.prefix) {
+				return s.subject
+			}
+		}
+	}
+
+	return failureSubjectValue
+}
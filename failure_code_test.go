@@ -0,0 +1,148 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureCodeParts(t *testing.T) {
+	code := FailureCode("header.key_missing")
+
+	assert.Equal(t, "header", code.Subject())
+	assert.Equal(t, "key_missing", code.Kind())
+
+	code = FailureCode("mismatch")
+
+	assert.Equal(t, "", code.Subject())
+	assert.Equal(t, "mismatch", code.Kind())
+}
+
+func TestFailureCodeMake(t *testing.T) {
+	cases := []struct {
+		name string
+		path []string
+		typ  AssertionType
+		code FailureCode
+	}{
+		{
+			name: "status",
+			path: []string{`Request("GET")`, "Expect()", "Status()"},
+			typ:  AssertEqual,
+			code: "status.mismatch",
+		},
+		{
+			name: "status range",
+			path: []string{`Request("GET")`, "Expect()", "StatusRange()"},
+			typ:  AssertBelongs,
+			code: "status.not_allowed",
+		},
+		{
+			name: "header",
+			path: []string{"Response()", `Header("X-Id")`, "IsEqual()"},
+			typ:  AssertEqual,
+			code: "header.mismatch",
+		},
+		{
+			name: "headers",
+			path: []string{"Response()", "Headers()", "ContainsKey()"},
+			typ:  AssertContainsKey,
+			code: "header.key_missing",
+		},
+		{
+			name: "cookie",
+			path: []string{"Response()", `Cookie("session")`},
+			typ:  AssertContainsElement,
+			code: "cookie.element_missing",
+		},
+		{
+			name: "json schema",
+			path: []string{"Response()", "JSON()", "Schema()"},
+			typ:  AssertMatchSchema,
+			code: "body.schema_violation",
+		},
+		{
+			name: "innermost wins",
+			path: []string{"Response()", "JSON()", "Object()", `Value("status")`},
+			typ:  AssertNotNil,
+			code: "body.nil",
+		},
+		{
+			name: "request",
+			path: []string{`Request("GET")`, "Expect()"},
+			typ:  AssertOperation,
+			code: "request.operation",
+		},
+		{
+			name: "websocket",
+			path: []string{`Request("GET")`, "Expect()", "Websocket()", "Subprotocol()"},
+			typ:  AssertEqual,
+			code: "websocket.mismatch",
+		},
+		{
+			name: "value",
+			path: []string{"Number()", "InRange()"},
+			typ:  AssertInRange,
+			code: "value.out_of_range",
+		},
+		{
+			name: "empty path",
+			path: nil,
+			typ:  AssertUsage,
+			code: "value.usage",
+		},
+		{
+			name: "unknown type",
+			path: nil,
+			typ:  AssertionType(999),
+			code: "value.unknown",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.code, makeFailureCode(tc.path, tc.typ))
+		})
+	}
+}
+
+func TestFailureCodeKinds(t *testing.T) {
+	for typ := AssertUsage; typ <= AssertNotBelongs; typ++ {
+		kind, ok := failureKinds[typ]
+		assert.True(t, ok, typ.String())
+		assert.NotEmpty(t, kind, typ.String())
+	}
+}
+
+func TestFailureCodeChain(t *testing.T) {
+	t.Run("filled", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := newCheckResponse(handler, http.Header{})
+
+		resp.Status(http.StatusNotFound)
+		resp.chain.assertFailed(t)
+
+		assert.NotNil(t, handler.failure)
+		assert.Equal(t, FailureCode("status.mismatch"), handler.failure.Code)
+	})
+
+	t.Run("explicit", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		chain.fail(AssertionFailure{
+			Type:   AssertOperation,
+			Code:   "custom.code",
+			Errors: []error{errors.New("test")},
+		})
+
+		assert.NotNil(t, handler.failure)
+		assert.Equal(t, FailureCode("custom.code"), handler.failure.Code)
+	})
+}
@@ -0,0 +1,280 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FanOutExpect issues the same requests against multiple targets and
+// checks that their responses are equivalent.
+//
+// It's useful for parity testing during migrations, when old and new
+// deployments of a service should behave identically.
+//
+// Responses are compared by status code, headers, and body. JSON bodies
+// are compared structurally, other bodies are compared byte-by-byte.
+// Date and Content-Length headers are always ignored; other headers and
+// JSON fields can be excluded from comparison using IgnoreHeaders and
+// IgnoreFields.
+type FanOutExpect struct {
+	chain   *chain
+	targets []*Expect
+
+	ignoreHeaders map[string]bool
+	ignoreFields  [][]string
+}
+
+// FanOut returns a new FanOutExpect instance, which compares responses
+// of other targets with responses of primary target.
+//
+// Example:
+//
+//	oldAPI := httpexpect.Default(t, "http://old.example.com")
+//	newAPI := httpexpect.Default(t, "http://new.example.com")
+//
+//	fan := httpexpect.FanOut(oldAPI, newAPI).
+//	    IgnoreHeaders("Server").
+//	    IgnoreFields("meta.request_id", "items.*.updated_at")
+//
+//	fan.Compare(func(e *httpexpect.Expect) *httpexpect.Response {
+//	    return e.GET("/users").WithQuery("limit", 10).Expect()
+//	})
+func FanOut(primary *Expect, others ...*Expect) *FanOutExpect {
+	f := &FanOutExpect{
+		ignoreHeaders: map[string]bool{
+			"Date":           true,
+			"Content-Length": true,
+		},
+	}
+
+	if primary == nil {
+		panic("FanOut: primary target is nil")
+	}
+
+	f.chain = primary.chain.clone()
+	f.chain.enter("FanOut()")
+
+	f.targets = append(f.targets, primary)
+
+	for _, e := range others {
+		if e == nil {
+			f.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil target argument"),
+				},
+			})
+			continue
+		}
+		f.targets = append(f.targets, e)
+	}
+
+	if len(f.targets) < 2 {
+		f.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("expected at least two targets"),
+			},
+		})
+	}
+
+	f.chain.leave()
+
+	return f
+}
+
+// IgnoreHeaders excludes given headers from comparison.
+//
+// Example:
+//
+//	fan := httpexpect.FanOut(oldAPI, newAPI).
+//	    IgnoreHeaders("Server", "X-Request-Id")
+func (f *FanOutExpect) IgnoreHeaders(names ...string) *FanOutExpect {
+	for _, name := range names {
+		f.ignoreHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+	return f
+}
+
+// IgnoreFields excludes given fields of JSON body from comparison.
+//
+// Field path is a dot-separated list of object keys and array indexes.
+// Asterisk matches any key or index.
+//
+// Example:
+//
+//	fan := httpexpect.FanOut(oldAPI, newAPI).
+//	    IgnoreFields("meta.request_id", "items.*.updated_at")
+func (f *FanOutExpect) IgnoreFields(paths ...string) *FanOutExpect {
+	for _, path := range paths {
+		f.ignoreFields = append(f.ignoreFields, strings.Split(path, "."))
+	}
+	return f
+}
+
+// Compare invokes given function for every target, and checks that
+// responses returned by other targets are equivalent to the response
+// returned by primary target.
+//
+// Function should construct and send request using given Expect instance
+// and return resulting response.
+//
+// If a request itself fails (e.g. due to network error), it's reported
+// by the corresponding Expect instance, and comparison is skipped.
+//
+// Example:
+//
+//	fan.Compare(func(e *httpexpect.Expect) *httpexpect.Response {
+//	    return e.POST("/orders").WithJSON(order).Expect()
+//	})
+func (f *FanOutExpect) Compare(fn func(e *Expect) *Response) *FanOutExpect {
+	f.chain.enter("Compare()")
+	defer f.chain.leave()
+
+	if f.chain.failed() {
+		return f
+	}
+
+	if fn == nil {
+		f.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return f
+	}
+
+	responses := make([]*Response, len(f.targets))
+	for i, e := range f.targets {
+		responses[i] = fn(e)
+	}
+
+	for _, resp := range responses {
+		if resp == nil || resp.chain.failed() || resp.httpResp == nil {
+			return f
+		}
+	}
+
+	expected := f.snapshot(responses[0])
+
+	for i, resp := range responses[1:] {
+		actual := f.snapshot(resp)
+
+		if !reflect.DeepEqual(expected, actual) {
+			f.chain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{actual},
+				Expected: &AssertionValue{expected},
+				Errors: []error{
+					fmt.Errorf(
+						"expected: response of target #%d is equivalent"+
+							" to response of primary target", i+1),
+				},
+			})
+			return f
+		}
+	}
+
+	return f
+}
+
+// Build comparable representation of response.
+func (f *FanOutExpect) snapshot(resp *Response) map[string]interface{} {
+	return snapshotResponse(f.chain, resp, f.ignoreHeaders, f.ignoreFields)
+}
+
+// Build comparable representation of response, with given headers and
+// JSON fields excluded.
+func snapshotResponse(
+	chain *chain, resp *Response,
+	ignoreHeaders map[string]bool, ignoreFields [][]string,
+) map[string]interface{} {
+	headers := map[string]interface{}{}
+	for name, values := range resp.httpResp.Header {
+		name = http.CanonicalHeaderKey(name)
+		if ignoreHeaders[name] {
+			continue
+		}
+		list := []interface{}{}
+		for _, v := range values {
+			list = append(list, v)
+		}
+		headers[name] = list
+	}
+
+	return map[string]interface{}{
+		"status":  strconv.Itoa(resp.httpResp.StatusCode),
+		"headers": headers,
+		"body":    snapshotBody(chain, resp, ignoreFields),
+	}
+}
+
+func snapshotBody(chain *chain, resp *Response, ignoreFields [][]string) interface{} {
+	mediaType, _, _ := mime.ParseMediaType(resp.httpResp.Header.Get("Content-Type"))
+
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		if value, err := jsonDecode(chain, resp.readContent()); err == nil {
+			if chain.jsonNumber {
+				value = canonJSONNumbers(value)
+			}
+			for _, path := range ignoreFields {
+				value = removeField(value, path)
+			}
+			return value
+		}
+	}
+
+	return string(resp.readContent())
+}
+
+func removeField(value interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+
+	key, rest := path[0], path[1:]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if key != "*" && key != k {
+				continue
+			}
+			if len(rest) == 0 {
+				delete(v, k)
+			} else {
+				v[k] = removeField(child, rest)
+			}
+		}
+		return v
+
+	case []interface{}:
+		if key == "*" {
+			if len(rest) == 0 {
+				return []interface{}{}
+			}
+			for i, child := range v {
+				v[i] = removeField(child, rest)
+			}
+			return v
+		}
+
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(v) {
+			return v
+		}
+		if len(rest) == 0 {
+			return append(v[:i:i], v[i+1:]...)
+		}
+		v[i] = removeField(v[i], rest)
+		return v
+	}
+
+	return value
+}
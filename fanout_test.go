@@ -0,0 +1,154 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFanOutTarget(t *testing.T, handler http.HandlerFunc) *Expect {
+	return WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+}
+
+func fanOutJSONHandler(
+	status int, headers map[string]string, body string,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestFanOutCompare(t *testing.T) {
+	get := func(e *Expect) *Response {
+		return e.GET("/").Expect()
+	}
+
+	t.Run("equivalent", func(t *testing.T) {
+		fan := FanOut(
+			newFanOutTarget(t, fanOutJSONHandler(200,
+				map[string]string{"Content-Length": "21"},
+				`{"a": 1, "b": [1, 2]}`)),
+			newFanOutTarget(t, fanOutJSONHandler(200,
+				map[string]string{"Content-Length": "23"},
+				`{"b": [1, 2], "a": 1.0}`)),
+		)
+
+		fan.Compare(get)
+		fan.chain.assertNotFailed(t)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		fan := FanOut(
+			newFanOutTarget(t, fanOutJSONHandler(200, nil, `{}`)),
+			newFanOutTarget(t, fanOutJSONHandler(201, nil, `{}`)),
+		)
+
+		fan.Compare(get)
+		fan.chain.assertFailed(t)
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		primary := newFanOutTarget(t,
+			fanOutJSONHandler(200, map[string]string{"Server": "old"}, `{}`))
+		secondary := newFanOutTarget(t,
+			fanOutJSONHandler(200, map[string]string{"Server": "new"}, `{}`))
+
+		fan := FanOut(primary, secondary)
+		fan.Compare(get)
+		fan.chain.assertFailed(t)
+
+		fan = FanOut(primary, secondary).IgnoreHeaders("server")
+		fan.Compare(get)
+		fan.chain.assertNotFailed(t)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		primary := newFanOutTarget(t, fanOutJSONHandler(200, nil,
+			`{"id": 1, "meta": {"ts": 100}, "items": [{"v": 1, "ts": 1}]}`))
+		secondary := newFanOutTarget(t, fanOutJSONHandler(200, nil,
+			`{"id": 1, "meta": {"ts": 200}, "items": [{"v": 1, "ts": 2}]}`))
+
+		fan := FanOut(primary, secondary)
+		fan.Compare(get)
+		fan.chain.assertFailed(t)
+
+		fan = FanOut(primary, secondary).IgnoreFields("meta.ts", "items.*.ts")
+		fan.Compare(get)
+		fan.chain.assertNotFailed(t)
+	})
+
+	t.Run("text body", func(t *testing.T) {
+		text := func(body string) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte(body))
+			}
+		}
+
+		fan := FanOut(newFanOutTarget(t, text("a")), newFanOutTarget(t, text("a")))
+		fan.Compare(get)
+		fan.chain.assertNotFailed(t)
+
+		fan = FanOut(newFanOutTarget(t, text("a")), newFanOutTarget(t, text("b")))
+		fan.Compare(get)
+		fan.chain.assertFailed(t)
+	})
+
+	t.Run("multiple targets", func(t *testing.T) {
+		fan := FanOut(
+			newFanOutTarget(t, fanOutJSONHandler(200, nil, `[1]`)),
+			newFanOutTarget(t, fanOutJSONHandler(200, nil, `[1]`)),
+			newFanOutTarget(t, fanOutJSONHandler(200, nil, `[2]`)),
+		)
+
+		fan.Compare(get)
+		fan.chain.assertFailed(t)
+	})
+}
+
+func TestFanOutUsage(t *testing.T) {
+	e := newFanOutTarget(t, fanOutJSONHandler(200, nil, `{}`))
+
+	FanOut(e).chain.assertFailed(t)
+	FanOut(e, nil).chain.assertFailed(t)
+
+	fan := FanOut(e, e)
+	fan.Compare(nil)
+	fan.chain.assertFailed(t)
+
+	assert.Panics(t, func() {
+		FanOut(nil, e)
+	})
+}
+
+func TestFanOutRemoveField(t *testing.T) {
+	value := map[string]interface{}{
+		"a": map[string]interface{}{"b": 1.0, "c": 2.0},
+		"d": []interface{}{1.0, 2.0, 3.0},
+	}
+
+	value = removeField(value, []string{"a", "b"}).(map[string]interface{})
+	value = removeField(value, []string{"d", "1"}).(map[string]interface{})
+	value = removeField(value, []string{"x", "y"}).(map[string]interface{})
+
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{"c": 2.0},
+		"d": []interface{}{1.0, 3.0},
+	}, value)
+
+	value = removeField(value, []string{"*"}).(map[string]interface{})
+
+	assert.Equal(t, map[string]interface{}{}, value)
+}
@@ -0,0 +1,239 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"text/template"
+	"time"
+)
+
+// Data passed to template engine when fixture file is loaded.
+//
+// Fixture files are text/template templates. Available fields are:
+//   - {{.Now}} - current time in RFC 3339 format; methods of time.Time
+//     may be used too, e.g. {{.Now.Unix}} or {{.Now.Format "2006-01-02"}}
+//   - {{.Env.key}} - value from Environment associated with assertion
+//
+// Available functions are:
+//   - {{getenv "NAME"}} - value of OS environment variable
+type fixtureData struct {
+	Now fixtureTime
+	Env map[string]interface{}
+}
+
+type fixtureTime struct {
+	time.Time
+}
+
+func (t fixtureTime) String() string {
+	return t.Format(time.RFC3339)
+}
+
+var fixtureFuncs = template.FuncMap{
+	"getenv": os.Getenv,
+}
+
+// Read fixture file and execute it as a template.
+func loadFixture(chain *chain, path string) ([]byte, bool) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to read fixture file %q", path),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	if chain.manifest != nil {
+		chain.manifest.addFile("fixture", path)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).
+		Funcs(fixtureFuncs).
+		Option("missingkey=error").
+		Parse(string(content))
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				fmt.Errorf("invalid template in fixture file %q", path),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	data := fixtureData{
+		Now: fixtureTime{time.Now()},
+		Env: map[string]interface{}{},
+	}
+
+	if env := chain.getEnv(); env != nil {
+		data.Env = env.data
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				fmt.Errorf("failed to execute template in fixture file %q", path),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return buf.Bytes(), true
+}
+
+// Load fixture file and decode JSON from it.
+func loadJSONFixture(chain *chain, path string) (interface{}, bool) {
+	content, ok := loadFixture(chain, path)
+	if !ok {
+		return nil, false
+	}
+
+	value, err := jsonDecode(chain, content)
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				fmt.Errorf("invalid json in fixture file %q", path),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return value, true
+}
+
+// WithJSONFile sets Content-Type header to "application/json; charset=utf-8"
+// and sets body to JSON loaded from given fixture file.
+//
+// File is processed as text/template template before sending. Template
+// may use {{.Now}} for current time, {{.Env.key}} for values from
+// Environment, and {{getenv "NAME"}} for OS environment variables.
+//
+// If file can't be read, template is invalid, or result is not valid JSON,
+// failure is reported.
+//
+// Example:
+//
+//	// testdata/create_user.json:
+//	// {"name": "john", "created_at": "{{.Now}}", "org": "{{.Env.org}}"}
+//
+//	e.Env().Put("org", "acme")
+//	e.POST("/users").
+//	    WithJSONFile("testdata/create_user.json").
+//	    Expect().
+//	    Status(http.StatusCreated)
+func (r *Request) WithJSONFile(path string) *Request {
+	r.chain.enter("WithJSONFile()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	value, ok := loadJSONFixture(r.chain, path)
+	if !ok {
+		return r
+	}
+
+	b, err := marshalJSON(r.config.Marshaler, value)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("invalid json object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType("WithJSONFile()", "application/json; charset=utf-8", false)
+	r.setBody("WithJSONFile()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
+// EqualFile succeeds if object is equal to JSON object loaded from given
+// fixture file. Before comparison, both object and loaded value are
+// converted to canonical form. Paths from Config.IgnorePaths are excluded
+// from comparison.
+//
+// File is processed as text/template template before loading, see
+// Request.WithJSONFile for available fields and functions.
+//
+// Example:
+//
+//	// testdata/expected_user.json:
+//	// {"id": {{.Env.user_id}}, "name": "john"}
+//
+//	e.Env().Put("user_id", 42)
+//	e.GET("/users/42").
+//	    Expect().
+//	    JSON().Object().EqualFile("testdata/expected_user.json")
+func (o *Object) EqualFile(path string) *Object {
+	o.chain.enter("EqualFile()")
+	defer o.chain.leave()
+
+	if o.chain.failed() {
+		return o
+	}
+
+	value, ok := loadJSONFixture(o.chain, path)
+	if !ok {
+		return o
+	}
+
+	if _, isMap := value.(map[string]interface{}); !isMap {
+		o.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: fixture file %q contains json object", path),
+			},
+		})
+		return o
+	}
+
+	expected, ok := canonMap(o.chain, value)
+	if !ok {
+		return o
+	}
+
+	actual, expected, ok := o.withoutIgnored(expected, o.chain.ignorePaths)
+	if !ok {
+		return o
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		o.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				fmt.Errorf("expected: object is equal to fixture file %q", path),
+			},
+		})
+	}
+
+	return o
+}
@@ -0,0 +1,187 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFixture(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestFixtureFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	config := newMockConfig(newMockReporter(t))
+
+	req := newRequest(chain, config, "POST", "")
+	req.WithJSONFile("missing.json")
+	req.chain.assertFailed(t)
+
+	obj := newObject(chain, map[string]interface{}{})
+	obj.EqualFile("missing.json")
+	obj.chain.assertFailed(t)
+}
+
+func TestFixtureWithJSONFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.Setenv("HTTPEXPECT_FIXTURE_TEST", "from-os"))
+	defer os.Unsetenv("HTTPEXPECT_FIXTURE_TEST")
+
+	var (
+		gotType string
+		gotBody map[string]interface{}
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotType = r.Header.Get("Content-Type")
+		b, _ := ioutil.ReadAll(r.Body)
+		gotBody = nil
+		_ = json.Unmarshal(b, &gotBody)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	t.Run("template", func(t *testing.T) {
+		path := writeFixture(t, dir, "create.json", `{
+			"name": "john",
+			"org": "{{.Env.org}}",
+			"os": "{{getenv "HTTPEXPECT_FIXTURE_TEST"}}",
+			"created_at": "{{.Now}}",
+			"year": {{.Now.Year}}
+		}`)
+
+		e.Env().Put("org", "acme")
+
+		req := e.POST("/users").WithJSONFile(path)
+		req.Expect().Status(http.StatusOK)
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "application/json; charset=utf-8", gotType)
+		require.NotNil(t, gotBody)
+
+		assert.Equal(t, "john", gotBody["name"])
+		assert.Equal(t, "acme", gotBody["org"])
+		assert.Equal(t, "from-os", gotBody["os"])
+		assert.Equal(t, float64(time.Now().Year()), gotBody["year"])
+
+		created, err := time.Parse(time.RFC3339, gotBody["created_at"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), created, time.Minute)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		req := e.POST("/users").WithJSONFile(filepath.Join(dir, "missing.json"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		path := writeFixture(t, dir, "bad_template.json", `{"name": "{{.Env.org"}`)
+
+		req := e.POST("/users").WithJSONFile(path)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		path := writeFixture(t, dir, "missing_key.json", `{"name": "{{.Env.nope}}"}`)
+
+		req := e.POST("/users").WithJSONFile(path)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		path := writeFixture(t, dir, "bad_json.json", `{"name": }`)
+
+		req := e.POST("/users").WithJSONFile(path)
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestFixtureEqualFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	reporter := newMockReporter(t)
+
+	expected := writeFixture(t, dir, "expected.json",
+		`{"id": {{.Env.id}}, "name": "john", "tags": ["a", "b"]}`)
+
+	t.Run("equal", func(t *testing.T) {
+		obj := NewObject(reporter, map[string]interface{}{
+			"id":   42,
+			"name": "john",
+			"tags": []interface{}{"a", "b"},
+		})
+		obj.chain.getEnv().Put("id", 42)
+
+		obj.EqualFile(expected)
+		obj.chain.assertNotFailed(t)
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		obj := NewObject(reporter, map[string]interface{}{
+			"id":   43,
+			"name": "john",
+			"tags": []interface{}{"a", "b"},
+		})
+		obj.chain.getEnv().Put("id", 42)
+
+		obj.EqualFile(expected)
+		obj.chain.assertFailed(t)
+	})
+
+	t.Run("ignore paths", func(t *testing.T) {
+		config := newMockConfig(reporter)
+		config.IgnorePaths = []string{"name"}
+
+		obj := newObject(newChainWithConfig("test", config), map[string]interface{}{
+			"id":   42,
+			"name": "bob",
+			"tags": []interface{}{"a", "b"},
+		})
+		obj.chain.getEnv().Put("id", 42)
+
+		obj.EqualFile(expected)
+		obj.chain.assertNotFailed(t)
+	})
+
+	t.Run("not object", func(t *testing.T) {
+		path := writeFixture(t, dir, "array.json", `[1, 2]`)
+
+		obj := NewObject(reporter, map[string]interface{}{})
+
+		obj.EqualFile(path)
+		obj.chain.assertFailed(t)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		obj := NewObject(reporter, map[string]interface{}{})
+
+		obj.EqualFile(filepath.Join(dir, "missing.json"))
+		obj.chain.assertFailed(t)
+	})
+}
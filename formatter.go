@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"reflect"
 	"strings"
 	"text/template"
@@ -39,6 +41,9 @@ type DefaultFormatter struct {
 	// Exclude assertion path from failure report.
 	DisablePaths bool
 
+	// Exclude source locations of assertion path from failure report.
+	DisableLocations bool
+
 	// Exclude diff from failure report.
 	DisableDiffs bool
 
@@ -46,6 +51,15 @@ type DefaultFormatter struct {
 	// Use zero for default width, and negative value to disable wrapping.
 	LineWidth int
 
+	// Highlight diff, expected and actual values using ANSI colors.
+	// Default is ColorModeNever.
+	ColorMode ColorMode
+
+	// Destination of formatted messages, inspected by ColorModeAuto.
+	// If nil, messages are assumed to be reported to test log (e.g. via
+	// AssertReporter), which is written to stdout of test binary.
+	ColorOutput io.Writer
+
 	// If not empty, used to format success messages.
 	// If empty, default template is used.
 	SuccessTemplate string
@@ -60,6 +74,21 @@ type DefaultFormatter struct {
 	TemplateFuncs template.FuncMap
 }
 
+// ColorMode defines whether DefaultFormatter and printers use ANSI colors.
+type ColorMode int
+
+const (
+	// Never use colors.
+	ColorModeNever ColorMode = iota
+
+	// Use colors if output is a terminal and NO_COLOR environment
+	// variable is not set.
+	ColorModeAuto
+
+	// Always use colors.
+	ColorModeAlways
+)
+
 // FormatSuccess implements Formatter.FormatSuccess.
 func (f *DefaultFormatter) FormatSuccess(ctx *AssertionContext) string {
 	if f.SuccessTemplate != "" {
@@ -87,11 +116,23 @@ func (f *DefaultFormatter) FormatFailure(
 // FormatData defines data passed to template engine when DefaultFormatter
 // formats assertion. You can use these fields in your custom templates.
 type FormatData struct {
-	TestName    string
-	RequestName string
+	TestName        string
+	RequestName     string
+	ExpectedFailure string
+
+	CaseName string
+	HaveCase bool
+	CaseData string
+
+	HaveElement  bool
+	ElementIndex int
+	ElementKey   string
+	ElementValue string
 
 	AssertPath     []string
+	AssertTrail    []string
 	AssertType     string
+	AssertCode     string
 	AssertSeverity string
 
 	Errors []string
@@ -114,7 +155,8 @@ type FormatData struct {
 	HaveDiff bool
 	Diff     string
 
-	LineWidth int
+	LineWidth    int
+	EnableColors bool
 }
 
 const (
@@ -164,6 +206,7 @@ func (f *DefaultFormatter) buildFormatData(
 
 	if failure != nil {
 		data.AssertType = failure.Type.String()
+		data.AssertCode = string(failure.Code)
 		data.AssertSeverity = failure.Severity.String()
 
 		f.fillErrors(&data, ctx, failure)
@@ -196,10 +239,30 @@ func (f *DefaultFormatter) fillDescription(
 	if !f.DisableNames {
 		data.TestName = ctx.TestName
 		data.RequestName = ctx.RequestName
+		data.ExpectedFailure = ctx.ExpectedFailure
+
+		if ctx.Case != nil {
+			data.HaveCase = true
+			data.CaseName = ctx.Case.Name
+			if ctx.Case.Data != nil {
+				data.CaseData = formatValue(ctx.Case.Data)
+			}
+		}
 	}
 
 	if !f.DisablePaths {
 		data.AssertPath = ctx.Path
+
+		if ctx.Element != nil {
+			data.HaveElement = true
+			data.ElementIndex = ctx.Element.Index
+			data.ElementKey = ctx.Element.Key
+			data.ElementValue = formatValue(ctx.Element.Value)
+		}
+
+		if !f.DisableLocations {
+			data.AssertTrail = formatTrail(ctx.Path, ctx.PathLocations)
+		}
 	}
 
 	if f.LineWidth != 0 {
@@ -207,6 +270,67 @@ func (f *DefaultFormatter) fillDescription(
 	} else {
 		data.LineWidth = defaultLineWidth
 	}
+
+	data.EnableColors = f.enableColors()
+}
+
+// Groups consecutive path elements called from the same location,
+// and formats every group as a line with the location aligned.
+func formatTrail(path []string, locations []string) []string {
+	if len(locations) != len(path) {
+		return nil
+	}
+
+	type group struct {
+		path     string
+		location string
+	}
+
+	var (
+		groups []group
+		known  bool
+	)
+
+	for n, elem := range path {
+		if locations[n] != "" {
+			known = true
+		}
+		if n != 0 && locations[n] == locations[n-1] {
+			groups[len(groups)-1].path += "." + elem
+		} else {
+			groups = append(groups, group{elem, locations[n]})
+		}
+	}
+
+	if !known {
+		return nil
+	}
+
+	width := 0
+	for _, g := range groups {
+		if len(g.path) > width {
+			width = len(g.path)
+		}
+	}
+
+	lines := make([]string, 0, len(groups))
+	for _, g := range groups {
+		if g.location == "" {
+			lines = append(lines, g.path)
+		} else {
+			lines = append(lines,
+				fmt.Sprintf("%-*s  at %s", width, g.path, g.location))
+		}
+	}
+
+	return lines
+}
+
+func (f *DefaultFormatter) enableColors() bool {
+	if f.ColorOutput != nil {
+		return enableColors(f.ColorMode, f.ColorOutput)
+	}
+	return enableColors(f.ColorMode, os.Stdout)
 }
 
 func (f *DefaultFormatter) fillErrors(
@@ -568,7 +692,107 @@ const (
 	defaultLineWidth = 60
 )
 
+var defaultColors = map[string]string{
+	"red":     "\033[31m",
+	"green":   "\033[32m",
+	"yellow":  "\033[33m",
+	"blue":    "\033[34m",
+	"magenta": "\033[35m",
+	"cyan":    "\033[36m",
+	"bold":    "\033[1m",
+}
+
+const colorReset = "\033[0m"
+
+// Decide whether to use colors when writing to given output,
+// which may be a writer or a logger.
+func enableColors(mode ColorMode, output interface{}) bool {
+	switch mode {
+	case ColorModeAlways:
+		return true
+
+	case ColorModeAuto:
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			return false
+		}
+		if os.Getenv("TERM") == "dumb" {
+			return false
+		}
+		return isTerminal(output)
+
+	default:
+		return false
+	}
+}
+
+func isTerminal(output interface{}) bool {
+	switch out := output.(type) {
+	case *os.File:
+		stat, err := out.Stat()
+		if err != nil {
+			return false
+		}
+		return stat.Mode()&os.ModeCharDevice != 0
+
+	case TestingTB:
+		// testing package writes test log to stdout of test binary
+		return isTerminal(os.Stdout)
+
+	default:
+		return false
+	}
+}
+
+func colorize(enable bool, name string, s string) string {
+	code, ok := defaultColors[name]
+	if !enable || !ok || s == "" {
+		return s
+	}
+
+	var sb strings.Builder
+
+	for n, line := range strings.Split(s, "\n") {
+		if n != 0 {
+			sb.WriteString("\n")
+		}
+		if line == "" {
+			continue
+		}
+		sb.WriteString(code)
+		sb.WriteString(line)
+		sb.WriteString(colorReset)
+	}
+
+	return sb.String()
+}
+
+func colorizeDiff(enable bool, s string) string {
+	if !enable {
+		return s
+	}
+
+	var sb strings.Builder
+
+	for n, line := range strings.Split(s, "\n") {
+		if n != 0 {
+			sb.WriteString("\n")
+		}
+		switch {
+		case strings.HasPrefix(line, "+"):
+			sb.WriteString(colorize(true, "green", line))
+		case strings.HasPrefix(line, "-"):
+			sb.WriteString(colorize(true, "red", line))
+		default:
+			sb.WriteString(line)
+		}
+	}
+
+	return sb.String()
+}
+
 var defaultTemplateFuncs = template.FuncMap{
+	"color":     colorize,
+	"colordiff": colorizeDiff,
 	"indent": func(s string) string {
 		var sb strings.Builder
 
@@ -631,9 +855,9 @@ var defaultSuccessTemplate = `[OK] {{ join .AssertPath .LineWidth }}`
 var defaultFailureTemplate = `
 {{- range $n, $err := .Errors }}
 {{ if eq $n 0 -}}
-{{ wrap $err $.LineWidth }}
+{{ wrap $err $.LineWidth | color $.EnableColors "bold" }}
 {{- else -}}
-{{ wrap $err $.LineWidth | indent }}
+{{ wrap $err $.LineWidth | indent | color $.EnableColors "bold" }}
 {{- end -}}
 {{- end -}}
 {{- if .TestName }}
@@ -644,11 +868,42 @@ test name: {{ .TestName }}
 
 request name: {{ .RequestName }}
 {{- end -}}
+{{- if .ExpectedFailure }}
+
+expected failure: {{ .ExpectedFailure }}
+{{- end -}}
+{{- if eq .AssertSeverity "SeverityWarning" }}
+
+severity: warning (does not fail the test)
+{{- end -}}
+{{- if .HaveCase }}
+
+table case: {{ .CaseName }}
+{{- if .CaseData }}
+{{ .CaseData | indent }}
+{{- end -}}
+{{- end -}}
+{{- if .HaveElement }}
+
+{{ if ge .ElementIndex 0 -}}
+array element: {{ .ElementIndex }}
+{{- else -}}
+object value: {{ printf "%q" .ElementKey }}
+{{- end }}
+{{ .ElementValue | indent }}
+{{- end -}}
 {{- if .AssertPath }}
 
 assertion:
 {{ join .AssertPath .LineWidth | indent }}
 {{- end -}}
+{{- if .AssertTrail }}
+
+trail:
+{{- range $n, $line := .AssertTrail }}
+{{ $line | indent }}
+{{- end -}}
+{{- end -}}
 {{- if .HaveExpected }}
 
 {{ if .IsNegation }}denied
@@ -656,13 +911,13 @@ assertion:
 {{- else }}expected
 {{- end }} {{ .ExpectedKind }}:
 {{- range $n, $exp := .Expected }}
-{{ $exp | indent }}
+{{ $exp | indent | color $.EnableColors "green" }}
 {{- end -}}
 {{- end -}}
 {{- if .HaveActual }}
 
 actual value:
-{{ .Actual | indent }}
+{{ .Actual | indent | color $.EnableColors "red" }}
 {{- end -}}
 {{- if .HaveReference }}
 
@@ -677,6 +932,6 @@ allowed delta:
 {{- if .HaveDiff }}
 
 diff:
-{{ .Diff | indent }}
+{{ .Diff | colordiff $.EnableColors | indent }}
 {{- end -}}
 `
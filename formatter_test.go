@@ -1,12 +1,18 @@
 package httpexpect
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type typedNil int
@@ -93,3 +99,250 @@ func TestFormatDiff(t *testing.T) {
 	checkOK(map[string]interface{}{"a": 1}, map[string]interface{}{})
 	checkOK([]interface{}{"a"}, []interface{}{})
 }
+
+func TestFormatColors(t *testing.T) {
+	failure := &AssertionFailure{
+		Type:     AssertEqual,
+		Errors:   []error{errors.New("values are not equal")},
+		Actual:   &AssertionValue{map[string]interface{}{"a": 1}},
+		Expected: &AssertionValue{map[string]interface{}{"a": 2}},
+	}
+
+	t.Run("never", func(t *testing.T) {
+		f := &DefaultFormatter{
+			ColorMode: ColorModeNever,
+		}
+
+		s := f.FormatFailure(&AssertionContext{}, failure)
+		assert.NotContains(t, s, "\033[")
+	})
+
+	t.Run("always", func(t *testing.T) {
+		f := &DefaultFormatter{
+			ColorMode: ColorModeAlways,
+		}
+
+		s := f.FormatFailure(&AssertionContext{}, failure)
+		t.Logf("\n%s", s)
+		assert.Contains(t, s, "\033[31m")
+		assert.Contains(t, s, "\033[32m")
+		assert.Contains(t, s, colorReset)
+	})
+
+	t.Run("auto_no_color", func(t *testing.T) {
+		os.Setenv("NO_COLOR", "1")
+		defer os.Unsetenv("NO_COLOR")
+
+		f := &DefaultFormatter{
+			ColorMode: ColorModeAuto,
+		}
+
+		s := f.FormatFailure(&AssertionContext{}, failure)
+		assert.NotContains(t, s, "\033[")
+	})
+
+	t.Run("auto_output", func(t *testing.T) {
+		file, err := ioutil.TempFile("", "httpexpect")
+		require.NoError(t, err)
+		defer os.Remove(file.Name())
+		defer file.Close()
+
+		for _, output := range []io.Writer{&bytes.Buffer{}, file} {
+			f := &DefaultFormatter{
+				ColorMode:   ColorModeAuto,
+				ColorOutput: output,
+			}
+
+			s := f.FormatFailure(&AssertionContext{}, failure)
+			assert.NotContains(t, s, "\033[")
+		}
+	})
+
+	t.Run("enableColors", func(t *testing.T) {
+		assert.False(t, enableColors(ColorModeNever, os.Stdout))
+		assert.True(t, enableColors(ColorModeAlways, &bytes.Buffer{}))
+		assert.False(t, enableColors(ColorModeAuto, &bytes.Buffer{}))
+		assert.False(t, enableColors(ColorModeAuto, nil))
+		assert.Equal(t, isTerminal(os.Stdout), isTerminal(t))
+	})
+
+	t.Run("colorize", func(t *testing.T) {
+		assert.Equal(t, "foo", colorize(false, "red", "foo"))
+		assert.Equal(t, "foo", colorize(true, "unknown", "foo"))
+		assert.Equal(t, "\033[31mfoo\033[0m\n\n\033[31mbar\033[0m",
+			colorize(true, "red", "foo\n\nbar"))
+	})
+
+	t.Run("colorizeDiff", func(t *testing.T) {
+		assert.Equal(t, "+a\n-b\n c", colorizeDiff(false, "+a\n-b\n c"))
+		assert.Equal(t, "\033[32m+a\033[0m\n\033[31m-b\033[0m\n c",
+			colorizeDiff(true, "+a\n-b\n c"))
+	})
+}
+
+func TestFormatTrail(t *testing.T) {
+	failure := &AssertionFailure{
+		Type:   AssertValid,
+		Errors: []error{errors.New("test")},
+		Actual: &AssertionValue{"test"},
+	}
+
+	ctx := &AssertionContext{
+		Path: []string{
+			`Request("GET")`, `Expect()`, `JSON()`, `Object()`, `Value("x")`,
+		},
+		PathLocations: []string{
+			"foo_test.go:10", "foo_test.go:10", "foo_test.go:10",
+			"helper_test.go:20", "helper_test.go:21",
+		},
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		f := &DefaultFormatter{}
+
+		s := f.FormatFailure(ctx, failure)
+		t.Logf("\n%s", s)
+
+		assert.Contains(t, s, "\ntrail:\n"+
+			"  Request(\"GET\").Expect().JSON()  at foo_test.go:10\n"+
+			"  Object()                        at helper_test.go:20\n"+
+			"  Value(\"x\")                      at helper_test.go:21\n")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		f := &DefaultFormatter{
+			DisableLocations: true,
+		}
+
+		s := f.FormatFailure(ctx, failure)
+		assert.NotContains(t, s, "trail:")
+		assert.NotContains(t, s, "foo_test.go")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.Nil(t, formatTrail([]string{"a", "b"}, []string{"", ""}))
+		assert.Nil(t, formatTrail([]string{"a", "b"}, nil))
+		assert.Equal(t, []string{"a.b", "c    at x.go:1"},
+			formatTrail([]string{"a", "b", "c"}, []string{"", "", "x.go:1"}))
+	})
+}
+
+func TestFormatWarning(t *testing.T) {
+	f := &DefaultFormatter{
+		ColorMode: ColorModeNever,
+	}
+
+	for _, severity := range []AssertionSeverity{SeverityError, SeverityLog} {
+		s := f.FormatFailure(&AssertionContext{}, &AssertionFailure{
+			Type:     AssertEmpty,
+			Severity: severity,
+			Errors:   []error{errors.New("expected: header is empty")},
+			Actual:   &AssertionValue{"true"},
+		})
+		assert.NotContains(t, s, "warning")
+	}
+
+	s := f.FormatFailure(&AssertionContext{}, &AssertionFailure{
+		Type:     AssertEmpty,
+		Severity: SeverityWarning,
+		Errors:   []error{errors.New("expected: header is empty")},
+		Actual:   &AssertionValue{"true"},
+	})
+	t.Logf("\n%s", s)
+	assert.Contains(t, s, "severity: warning")
+}
+
+func TestFormatElement(t *testing.T) {
+	failure := &AssertionFailure{
+		Type:   AssertNotEmpty,
+		Errors: []error{errors.New("expected: string is non-empty")},
+		Actual: &AssertionValue{""},
+	}
+
+	f := &DefaultFormatter{
+		ColorMode: ColorModeNever,
+	}
+
+	t.Run("no element", func(t *testing.T) {
+		s := f.FormatFailure(&AssertionContext{}, failure)
+		assert.NotContains(t, s, "array element:")
+	})
+
+	t.Run("element", func(t *testing.T) {
+		s := f.FormatFailure(&AssertionContext{
+			Element: &AssertionElement{
+				Index: 2,
+				Value: map[string]interface{}{"name": ""},
+			},
+		}, failure)
+		t.Logf("\n%s", s)
+		assert.Contains(t, s, "array element: 2")
+		assert.Contains(t, s, `"name": ""`)
+	})
+
+	t.Run("object value", func(t *testing.T) {
+		s := f.FormatFailure(&AssertionContext{
+			Element: &AssertionElement{
+				Index: -1,
+				Key:   "user-1",
+				Value: "",
+			},
+		}, failure)
+		t.Logf("\n%s", s)
+		assert.Contains(t, s, `object value: "user-1"`)
+		assert.NotContains(t, s, "array element:")
+	})
+
+	t.Run("disable paths", func(t *testing.T) {
+		f := &DefaultFormatter{
+			ColorMode:    ColorModeNever,
+			DisablePaths: true,
+		}
+
+		s := f.FormatFailure(&AssertionContext{
+			Element: &AssertionElement{Index: 2},
+		}, failure)
+		assert.NotContains(t, s, "array element:")
+	})
+}
+
+func TestFormatCase(t *testing.T) {
+	failure := &AssertionFailure{
+		Type:   AssertValid,
+		Errors: []error{errors.New("bad status")},
+		Actual: &AssertionValue{500},
+	}
+
+	f := &DefaultFormatter{
+		ColorMode: ColorModeNever,
+	}
+
+	t.Run("no case", func(t *testing.T) {
+		s := f.FormatFailure(&AssertionContext{}, failure)
+		assert.NotContains(t, s, "table case:")
+	})
+
+	t.Run("case", func(t *testing.T) {
+		s := f.FormatFailure(&AssertionContext{
+			Case: &Case{
+				Name: "bad id",
+				Data: map[string]interface{}{"status": 400},
+			},
+		}, failure)
+		t.Logf("\n%s", s)
+		assert.Contains(t, s, "table case: bad id")
+		assert.Contains(t, s, `"status": 400`)
+	})
+
+	t.Run("disable names", func(t *testing.T) {
+		f := &DefaultFormatter{
+			ColorMode:    ColorModeNever,
+			DisableNames: true,
+		}
+
+		s := f.FormatFailure(&AssertionContext{
+			Case: &Case{Name: "bad id"},
+		}, failure)
+		assert.NotContains(t, s, "table case:")
+	})
+}
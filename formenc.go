@@ -0,0 +1,285 @@
+package httpexpect
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ajg/form"
+)
+
+// FormEncoder is used to convert objects passed to Request.WithForm and
+// Request.WithFormField into form fields.
+//
+// DefaultFormEncoder is used by default. NestedFormEncoder can be used
+// for servers that expect nested bracket syntax.
+type FormEncoder interface {
+	Encode(object interface{}) (url.Values, error)
+}
+
+// DefaultFormEncoder is the default FormEncoder implementation.
+//
+// It uses github.com/ajg/form, which encodes nested fields using dot
+// notation (e.g. "a.b.0=1"). Structs may contain "form" struct tag.
+type DefaultFormEncoder struct{}
+
+// Encode implements FormEncoder.Encode.
+func (DefaultFormEncoder) Encode(object interface{}) (url.Values, error) {
+	return form.EncodeToValues(object)
+}
+
+// FormArrayStyle defines how NestedFormEncoder encodes arrays of scalars.
+type FormArrayStyle int
+
+const (
+	// FormArrayRepeat repeats the key for every element: "a=1&a=2".
+	FormArrayRepeat FormArrayStyle = iota
+
+	// FormArrayBrackets appends empty brackets to the key: "a[]=1&a[]=2".
+	// This is what Rails, PHP, and express (qs) expect.
+	FormArrayBrackets
+
+	// FormArrayComma joins elements with comma: "a=1,2".
+	FormArrayComma
+
+	// FormArrayIndexed appends element index to the key: "a[0]=1&a[1]=2".
+	FormArrayIndexed
+)
+
+// NestedFormEncoder is FormEncoder that uses nested bracket syntax,
+// like "user[name]=foo&user[roles][]=admin".
+//
+// Nested maps and structs are encoded as "parent[child]". Arrays of scalars
+// are encoded according to ArrayStyle. Arrays of maps, structs, or arrays
+// are always encoded with indexes, e.g. "items[0][id]=1", since otherwise
+// elements can't be told apart.
+//
+// Structs may contain "form" struct tag, similar to "json" struct tag
+// for json.Marshal(), with "omitempty" option. Values implementing
+// encoding.TextMarshaler (e.g. time.Time) are encoded as text; other
+// scalars are converted to strings using fmt.Sprint().
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    BaseURL:  "http://example.com",
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	    FormEncoder: httpexpect.NestedFormEncoder{
+//	        ArrayStyle: httpexpect.FormArrayBrackets,
+//	    },
+//	})
+//
+//	e.POST("/users").
+//	    WithForm(map[string]interface{}{
+//	        "user": map[string]interface{}{
+//	            "name":  "john",
+//	            "roles": []string{"admin", "dev"},
+//	        },
+//	    })
+//	// body is "user[name]=john&user[roles][]=admin&user[roles][]=dev"
+type NestedFormEncoder struct {
+	ArrayStyle FormArrayStyle
+}
+
+// Encode implements FormEncoder.Encode.
+func (enc NestedFormEncoder) Encode(object interface{}) (url.Values, error) {
+	v := indirectFormValue(reflect.ValueOf(object))
+	if !v.IsValid() {
+		return url.Values{}, nil
+	}
+
+	if v.Kind() != reflect.Map && v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected map or struct, got %s", v.Type())
+	}
+
+	values := url.Values{}
+
+	if err := enc.encode(values, "", v); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func (enc NestedFormEncoder) encode(values url.Values, key string, v reflect.Value) error {
+	v = indirectFormValue(v)
+	if !v.IsValid() {
+		return nil
+	}
+
+	if isFormScalar(v) {
+		s, err := formScalar(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		values.Add(key, s)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			name := fmt.Sprint(iter.Key().Interface())
+			if err := enc.encode(values, nestedFormKey(key, name), iter.Value()); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		for _, field := range structFields(v.Type(), "form") {
+			fv, ok := fieldByIndex(v, field.index)
+			if !ok {
+				continue
+			}
+			if field.omitEmpty && isEmptyValue(fv) {
+				continue
+			}
+			if err := enc.encode(values, nestedFormKey(key, field.name), fv); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if key == "" {
+			return errors.New("unexpected array without key")
+		}
+		return enc.encodeArray(values, key, v)
+
+	default:
+		return fmt.Errorf("%s: unsupported type %s", key, v.Type())
+	}
+
+	return nil
+}
+
+func (enc NestedFormEncoder) encodeArray(values url.Values, key string, v reflect.Value) error {
+	scalars := true
+	for i := 0; i < v.Len(); i++ {
+		elem := indirectFormValue(v.Index(i))
+		if elem.IsValid() && !isFormScalar(elem) {
+			scalars = false
+			break
+		}
+	}
+
+	if !scalars || enc.ArrayStyle == FormArrayIndexed {
+		for i := 0; i < v.Len(); i++ {
+			err := enc.encode(values, nestedFormKey(key, strconv.Itoa(i)), v.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var elems []string
+	for i := 0; i < v.Len(); i++ {
+		elem := indirectFormValue(v.Index(i))
+		if !elem.IsValid() {
+			continue
+		}
+		s, err := formScalar(elem)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		elems = append(elems, s)
+	}
+
+	switch enc.ArrayStyle {
+	case FormArrayBrackets:
+		values[key+"[]"] = append(values[key+"[]"], elems...)
+
+	case FormArrayComma:
+		values.Add(key, strings.Join(elems, ","))
+
+	default:
+		values[key] = append(values[key], elems...)
+	}
+
+	return nil
+}
+
+// Dereferences pointers and interfaces; returns invalid value for nil.
+func indirectFormValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func nestedFormKey(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "[" + child + "]"
+}
+
+// Checks if value is encoded as single form value.
+func isFormScalar(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+
+	case reflect.Slice:
+		// []byte
+		return v.Type().Elem().Kind() == reflect.Uint8
+	}
+
+	return false
+}
+
+func formScalar(v reflect.Value) (string, error) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+
+	if v.Kind() == reflect.Slice {
+		return string(v.Bytes()), nil
+	}
+
+	return fmt.Sprint(v.Interface()), nil
+}
+
+// Checks if value passed to WithFormField should be encoded using
+// FormEncoder instead of fmt.Sprint().
+func isFormComposite(value interface{}) bool {
+	v := indirectFormValue(reflect.ValueOf(value))
+	if !v.IsValid() || isFormScalar(v) {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		return !v.Type().Implements(stringerType)
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormEncoderDefault(t *testing.T) {
+	values, err := DefaultFormEncoder{}.Encode(map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": []int{1, 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"a.b.0": {"1"},
+		"a.b.1": {"2"},
+	}, values)
+}
+
+func TestFormEncoderNested(t *testing.T) {
+	type Address struct {
+		City string `form:"city"`
+		Zip  string `form:"zip,omitempty"`
+	}
+
+	type User struct {
+		Name      string    `form:"name"`
+		Roles     []string  `form:"roles"`
+		Address   Address   `form:"address"`
+		Addresses []Address `form:"addresses"`
+		Born      time.Time `form:"born"`
+		Secret    string    `form:"-"`
+	}
+
+	object := map[string]interface{}{
+		"user": &User{
+			Name:      "john",
+			Roles:     []string{"admin", "dev"},
+			Address:   Address{City: "Paris"},
+			Addresses: []Address{{City: "A", Zip: "1"}, {City: "B"}},
+			Born:      time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC),
+			Secret:    "secret",
+		},
+	}
+
+	common := url.Values{
+		"user[name]":               {"john"},
+		"user[address][city]":      {"Paris"},
+		"user[addresses][0][city]": {"A"},
+		"user[addresses][0][zip]":  {"1"},
+		"user[addresses][1][city]": {"B"},
+		"user[born]":               {"2000-01-02T03:04:05Z"},
+	}
+
+	tests := []struct {
+		style FormArrayStyle
+		roles url.Values
+	}{
+		{
+			style: FormArrayRepeat,
+			roles: url.Values{"user[roles]": {"admin", "dev"}},
+		},
+		{
+			style: FormArrayBrackets,
+			roles: url.Values{"user[roles][]": {"admin", "dev"}},
+		},
+		{
+			style: FormArrayComma,
+			roles: url.Values{"user[roles]": {"admin,dev"}},
+		},
+		{
+			style: FormArrayIndexed,
+			roles: url.Values{"user[roles][0]": {"admin"}, "user[roles][1]": {"dev"}},
+		},
+	}
+
+	for _, tc := range tests {
+		values, err := NestedFormEncoder{ArrayStyle: tc.style}.Encode(object)
+		assert.NoError(t, err)
+
+		expected := url.Values{}
+		for k, v := range common {
+			expected[k] = v
+		}
+		for k, v := range tc.roles {
+			expected[k] = v
+		}
+
+		assert.Equal(t, expected, values, "style %d", tc.style)
+	}
+}
+
+func TestFormEncoderNestedValues(t *testing.T) {
+	enc := NestedFormEncoder{ArrayStyle: FormArrayBrackets}
+
+	t.Run("scalars", func(t *testing.T) {
+		values, err := enc.Encode(map[string]interface{}{
+			"int":    1,
+			"float":  1.5,
+			"bool":   true,
+			"bytes":  []byte("raw"),
+			"nil":    nil,
+			"ptr":    (*int)(nil),
+			"nested": map[string]interface{}{"a": map[int]string{1: "b"}},
+			"matrix": [][]int{{1, 2}, {3}},
+			"nils":   []interface{}{"x", nil, "y"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, url.Values{
+			"int":          {"1"},
+			"float":        {"1.5"},
+			"bool":         {"true"},
+			"bytes":        {"raw"},
+			"nested[a][1]": {"b"},
+			"matrix[0][]":  {"1", "2"},
+			"matrix[1][]":  {"3"},
+			"nils[]":       {"x", "y"},
+		}, values)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		values, err := enc.Encode(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, url.Values{}, values)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := enc.Encode(123)
+		assert.Error(t, err)
+
+		_, err = enc.Encode([]int{1})
+		assert.Error(t, err)
+
+		_, err = enc.Encode(map[string]interface{}{"f": func() {}})
+		assert.Error(t, err)
+	})
+}
+
+func TestFormEncoderRequest(t *testing.T) {
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		Client:   client,
+		Reporter: reporter,
+		FormEncoder: NestedFormEncoder{
+			ArrayStyle: FormArrayBrackets,
+		},
+	}
+
+	t.Run("urlencoded", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "http://example.com").
+			WithForm(map[string]interface{}{
+				"user": map[string]interface{}{"name": "john"},
+			}).
+			WithFormField("tags", []string{"a", "b"}).
+			WithFormField("n", 1)
+
+		var form url.Values
+		client.cb = func(req *http.Request) {
+			assert.NoError(t, req.ParseForm())
+			form = req.PostForm
+		}
+
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.Equal(t, "application/x-www-form-urlencoded",
+			client.req.Header.Get("Content-Type"))
+
+		assert.Equal(t, url.Values{
+			"user[name]": {"john"},
+			"tags[]":     {"a", "b"},
+			"n":          {"1"},
+		}, form)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		req := NewRequestC(config, "POST", "http://example.com").
+			WithMultipart().
+			WithFormField("tags", []string{"a", "b"}).
+			WithForm(map[string]interface{}{
+				"user": map[string]interface{}{"name": "john"},
+			})
+
+		var form url.Values
+		client.cb = func(req *http.Request) {
+			assert.NoError(t, req.ParseMultipartForm(1<<20))
+			form = req.MultipartForm.Value
+		}
+
+		req.Expect().chain.assertNotFailed(t)
+
+		assert.Equal(t, url.Values{
+			"user[name]": {"john"},
+			"tags[]":     {"a", "b"},
+		}, form)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		NewRequestC(config, "POST", "http://example.com").
+			WithFormField("f", []interface{}{func() {}}).
+			chain.assertFailed(t)
+
+		NewRequestC(config, "POST", "http://example.com").
+			WithForm(123).
+			chain.assertFailed(t)
+	})
+}
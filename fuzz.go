@@ -0,0 +1,210 @@
+//go:build go1.18
+// +build go1.18
+
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"testing"
+)
+
+// Fuzzer runs requests with inputs generated by Go native fuzzing,
+// see Fuzz.
+type Fuzzer struct {
+	f       *testing.F
+	bodies  []interface{}
+	queries []url.Values
+}
+
+// Fuzz returns a new Fuzzer instance, that turns API handler into
+// fuzzing target of Go native fuzzing.
+//
+// Every fuzzing input consists of request body and query string.
+// Seed corpus is built from given seed bodies and query seeds added
+// by WithSeedQuery. Seed bodies of type []byte and string are used
+// as is, and other values are marshaled to JSON.
+//
+// Fuzzing engine mutates seeds, and every mutated body is sent as is
+// with "application/json" content type, even if it's not valid JSON
+// anymore; mutated query string is parsed leniently, keeping valid
+// parameters.
+//
+// Example:
+//
+//	func FuzzCreateUser(f *testing.F) {
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        Reporter: httpexpect.NewAssertReporter(f),
+//	        Client: &http.Client{
+//	            Transport: httpexpect.NewBinder(handler),
+//	        },
+//	    })
+//
+//	    httpexpect.Fuzz(f, map[string]interface{}{
+//	        "name": "john",
+//	        "age":  30,
+//	    }).
+//	        WithSeedQuery(url.Values{"dry_run": {"true"}}).
+//	        Run(e, "POST", "/users", func(resp *httpexpect.Response) {
+//	            if resp.Raw().StatusCode >= 400 {
+//	                resp.JSON().Object().ContainsKey("error")
+//	            }
+//	        })
+//	}
+func Fuzz(f *testing.F, seedBodies ...interface{}) *Fuzzer {
+	return &Fuzzer{
+		f:      f,
+		bodies: seedBodies,
+	}
+}
+
+// WithSeedQuery adds query parameters to seed corpus.
+//
+// Every seed query is combined with every seed body.
+//
+// Example:
+//
+//	fz.WithSeedQuery(url.Values{"limit": {"10"}}, url.Values{"page": {"2"}})
+func (fz *Fuzzer) WithSeedQuery(queries ...url.Values) *Fuzzer {
+	fz.queries = append(fz.queries, queries...)
+	return fz
+}
+
+// Run adds seed corpus and runs fuzzing target, that sends request with
+// given method and path for every input.
+//
+// Every response is checked to have no 5xx status code, and then passed
+// to fn, which may assert additional invariants, like format of error
+// responses. fn may be nil.
+//
+// Requests are made using a copy of Expect, prepared for fuzzing:
+//
+//   - Config.TestName and Reporter are bound to *testing.T of every
+//     input, like in WithTestName
+//   - Printers are disabled, since fuzzing makes a lot of requests
+//
+// Run should be called once per fuzz test, after all seeds are added.
+func (fz *Fuzzer) Run(e *Expect, method, path string, fn func(resp *Response)) {
+	e.chain.enter("Fuzz()")
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return
+	}
+
+	if fz.f == nil {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return
+	}
+
+	bodies, err := fz.seedBodies()
+	if err != nil {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid seed body"),
+				err,
+			},
+		})
+		return
+	}
+
+	queries := fz.seedQueries()
+
+	for _, body := range bodies {
+		for _, query := range queries {
+			fz.f.Add(body, query)
+		}
+	}
+
+	fz.f.Fuzz(func(t *testing.T, body []byte, query string) {
+		fe := e.WithTestName(t)
+		fe.config.Printers = nil
+
+		fuzzRequest(fe, method, path, body, query, fn)
+	})
+}
+
+func (fz *Fuzzer) seedBodies() ([][]byte, error) {
+	if len(fz.bodies) == 0 {
+		return [][]byte{nil}, nil
+	}
+
+	bodies := make([][]byte, 0, len(fz.bodies))
+
+	for i, seed := range fz.bodies {
+		switch s := seed.(type) {
+		case []byte:
+			bodies = append(bodies, s)
+
+		case string:
+			bodies = append(bodies, []byte(s))
+
+		default:
+			b, err := json.Marshal(s)
+			if err != nil {
+				return nil, fmt.Errorf("seed body %d: %w", i, err)
+			}
+			bodies = append(bodies, b)
+		}
+	}
+
+	return bodies, nil
+}
+
+func (fz *Fuzzer) seedQueries() []string {
+	if len(fz.queries) == 0 {
+		return []string{""}
+	}
+
+	queries := make([]string, 0, len(fz.queries))
+
+	for _, q := range fz.queries {
+		queries = append(queries, q.Encode())
+	}
+
+	return queries
+}
+
+// Send single fuzzing input and check invariants.
+func fuzzRequest(
+	e *Expect, method, path string, body []byte, query string, fn func(*Response),
+) {
+	req := e.Request(method, path)
+
+	// mutated query may be malformed, keep parameters that were parsed
+	values, _ := url.ParseQuery(query)
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range values[k] {
+			req.WithQuery(k, v)
+		}
+	}
+
+	if len(body) != 0 {
+		req.WithHeader("Content-Type", "application/json").
+			WithBytes(body)
+	}
+
+	resp := req.Expect()
+
+	resp.NotStatusRange(Status5xx)
+
+	if fn != nil {
+		fn(resp)
+	}
+}
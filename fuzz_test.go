@@ -0,0 +1,205 @@
+//go:build go1.18
+// +build go1.18
+
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fuzzHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		var user map[string]interface{}
+
+		b, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &user); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"bad json"}`))
+			return
+		}
+
+		if r.URL.Query().Get("panic") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"internal"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	})
+}
+
+func FuzzUserHandler(f *testing.F) {
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(f),
+		Client: &http.Client{
+			Transport: NewBinder(fuzzHandler()),
+		},
+	})
+
+	Fuzz(f,
+		map[string]interface{}{"name": "john"},
+		`{"name": "bob", "age": 30}`,
+		[]byte(`{"name"`),
+	).
+		WithSeedQuery(url.Values{"dry_run": {"true"}}, url.Values{}).
+		Run(e, "POST", "/users", func(resp *Response) {
+			if resp.Raw().StatusCode >= 400 {
+				resp.JSON().Object().ContainsKey("error")
+			}
+		})
+}
+
+func TestFuzzRequest(t *testing.T) {
+	var (
+		query   string
+		body    string
+		ctype   string
+		invoked bool
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		ctype = r.Header.Get("Content-Type")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+		fuzzHandler().ServeHTTP(w, r)
+	})
+
+	newExpect := func(reporter Reporter) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		fuzzRequest(newExpect(reporter), "POST", "/users",
+			[]byte(`{"name":"john"}`), "b=2&a=1",
+			func(resp *Response) {
+				invoked = true
+				resp.Status(http.StatusCreated)
+			})
+
+		assert.False(t, reporter.reported)
+		assert.True(t, invoked)
+		assert.Equal(t, "a=1&b=2", query)
+		assert.Equal(t, "application/json", ctype)
+		assert.Equal(t, `{"name":"john"}`, body)
+	})
+
+	t.Run("malformed input", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		fuzzRequest(newExpect(reporter), "POST", "/users",
+			[]byte(`{"na`), "a=1&%zz&b=2", nil)
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, "a=1&b=2", query)
+		assert.Equal(t, `{"na`, body)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		fuzzRequest(newExpect(reporter), "POST", "/users", nil, "", nil)
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, "", query)
+		assert.Equal(t, "", ctype)
+		assert.Equal(t, "", body)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		fuzzRequest(newExpect(reporter), "POST", "/users",
+			[]byte(`{}`), "panic=1", nil)
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("callback failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		fuzzRequest(newExpect(reporter), "POST", "/users",
+			[]byte(`{`), "",
+			func(resp *Response) {
+				resp.JSON().Object().ContainsKey("message")
+			})
+
+		assert.True(t, reporter.reported)
+	})
+}
+
+func TestFuzzSeeds(t *testing.T) {
+	t.Run("bodies", func(t *testing.T) {
+		fz := Fuzz(nil,
+			map[string]interface{}{"a": 1},
+			"raw",
+			[]byte("bytes"),
+			nil,
+		)
+
+		bodies, err := fz.seedBodies()
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]byte{
+			[]byte(`{"a":1}`),
+			[]byte("raw"),
+			[]byte("bytes"),
+			[]byte("null"),
+		}, bodies)
+	})
+
+	t.Run("no bodies", func(t *testing.T) {
+		bodies, err := Fuzz(nil).seedBodies()
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]byte{nil}, bodies)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, err := Fuzz(nil, func() {}).seedBodies()
+		assert.Error(t, err)
+	})
+
+	t.Run("queries", func(t *testing.T) {
+		fz := Fuzz(nil)
+		assert.Equal(t, []string{""}, fz.seedQueries())
+
+		fz.WithSeedQuery(url.Values{"b": {"2"}, "a": {"1"}}, url.Values{})
+		assert.Equal(t, []string{"a=1&b=2", ""}, fz.seedQueries())
+	})
+}
+
+func TestFuzzUsage(t *testing.T) {
+	t.Run("nil fuzz", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := WithConfig(Config{
+			Reporter: reporter,
+		})
+
+		Fuzz(nil).Run(e, "GET", "/", nil)
+
+		assert.True(t, reporter.reported)
+	})
+}
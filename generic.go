@@ -0,0 +1,113 @@
+//go:build go1.18
+// +build go1.18
+
+package httpexpect
+
+import (
+	"reflect"
+)
+
+// GetValue decodes value into a new variable of type T and returns it.
+//
+// Value is decoded the same way as by Value.Decode, so T may be any type
+// that can be passed to json.Unmarshal, usually a struct, a slice, or
+// a scalar type. Config.StrictDecoding and Config.Validator are applied.
+//
+// If value can't be decoded into T, failure is reported and zero value
+// of T is returned.
+//
+// Example:
+//
+//	type User struct {
+//		ID   int64  `json:"id"`
+//		Name string `json:"name"`
+//	}
+//
+//	user := httpexpect.GetValue[User](e.GET("/users/john").Expect().JSON())
+//
+//	e.DELETE("/users/{id}", user.ID).Expect().Status(http.StatusNoContent)
+func GetValue[T any](v *Value) T {
+	var result T
+
+	v.chain.enter("GetValue[%s]()", typeName[T]())
+	defer v.chain.leave()
+
+	if v.chain.failed() {
+		return result
+	}
+
+	jsonUnmarshal(v.chain, v.value, &result)
+	if v.chain.failed() {
+		var zero T
+		return zero
+	}
+
+	return result
+}
+
+// GetObject is similar to GetValue, but decodes Object.
+//
+// Example:
+//
+//	ids := httpexpect.GetObject[map[string]int64](object)
+func GetObject[T any](o *Object) T {
+	var result T
+
+	o.chain.enter("GetObject[%s]()", typeName[T]())
+	defer o.chain.leave()
+
+	if o.chain.failed() {
+		return result
+	}
+
+	jsonUnmarshal(o.chain, o.value, &result)
+	if o.chain.failed() {
+		var zero T
+		return zero
+	}
+
+	return result
+}
+
+// Iter decodes every element of array into type T and returns slice
+// of decoded elements.
+//
+// Elements are decoded the same way as by GetValue. If some element can't
+// be decoded, failure is reported, naming index of the element, and nil
+// is returned.
+//
+// Example:
+//
+//	array := e.GET("/users").Expect().JSON().Array()
+//
+//	for _, user := range httpexpect.Iter[User](array) {
+//	    e.GET("/users/{id}", user.ID).Expect().Status(http.StatusOK)
+//	}
+func Iter[T any](a *Array) []T {
+	a.chain.enter("Iter[%s]()", typeName[T]())
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return nil
+	}
+
+	result := make([]T, 0, len(a.value))
+
+	for index, element := range a.value {
+		a.chain.replace("Iter[%s][%d]", typeName[T](), index)
+
+		var item T
+		jsonUnmarshal(a.chain, element, &item)
+		if a.chain.failed() {
+			return nil
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
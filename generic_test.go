@@ -0,0 +1,141 @@
+//go:build go1.18
+// +build go1.18
+
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type genericUser struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type genericPathHandler struct {
+	path []string
+}
+
+func (h *genericPathHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *genericPathHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.path = append([]string(nil), ctx.Path...)
+}
+
+func TestGenericFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	assert.Equal(t, "", GetValue[string](newValue(chain, "foo")))
+
+	assert.Equal(t, genericUser{},
+		GetObject[genericUser](newObject(chain, map[string]interface{}{})))
+
+	assert.Nil(t, Iter[int](newArray(chain, []interface{}{1})))
+}
+
+func TestGenericGetValue(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("scalar", func(t *testing.T) {
+		value := NewValue(reporter, 123)
+
+		assert.Equal(t, 123, GetValue[int](value))
+		value.chain.assertNotFailed(t)
+
+		assert.Equal(t, 123.0, GetValue[float64](value))
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		value := NewValue(reporter, map[string]interface{}{
+			"id":   42,
+			"name": "john",
+		})
+
+		assert.Equal(t, genericUser{ID: 42, Name: "john"},
+			GetValue[genericUser](value))
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		value := NewValue(reporter, []interface{}{"a"})
+
+		assert.Equal(t, []interface{}{"a"}, GetValue[interface{}](value))
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		value := NewValue(reporter, "foo")
+
+		assert.Equal(t, 0, GetValue[int](value))
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("fraction", func(t *testing.T) {
+		value := NewValue(reporter, 1.5)
+
+		assert.Equal(t, 0, GetValue[int](value))
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestGenericGetObject(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	object := NewObject(reporter, map[string]interface{}{
+		"alice": 1,
+		"bob":   2,
+	})
+
+	assert.Equal(t, map[string]int64{"alice": 1, "bob": 2},
+		GetObject[map[string]int64](object))
+	object.chain.assertNotFailed(t)
+
+	assert.Nil(t, GetObject[map[string]string](object))
+	object.chain.assertFailed(t)
+}
+
+func TestGenericIter(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("structs", func(t *testing.T) {
+		array := NewArray(reporter, []interface{}{
+			map[string]interface{}{"id": 1, "name": "alice"},
+			map[string]interface{}{"id": 2, "name": "bob"},
+		})
+
+		assert.Equal(t, []genericUser{
+			{ID: 1, Name: "alice"},
+			{ID: 2, Name: "bob"},
+		}, Iter[genericUser](array))
+		array.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		array := NewArray(reporter, []interface{}{})
+
+		assert.Equal(t, []string{}, Iter[string](array))
+		array.chain.assertNotFailed(t)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		handler := &genericPathHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		array := newArray(chain, []interface{}{"a", 2, "c"})
+
+		assert.Nil(t, Iter[string](array))
+		array.chain.assertFailed(t)
+
+		assert.Equal(t, []string{"test", "Iter[string][1]"}, handler.path)
+	})
+}
@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/ajg/form v1.5.1
+	github.com/andybalholm/brotli v1.0.4
 	github.com/fasthttp/websocket v1.4.3-rc.6
 	github.com/fatih/structs v1.1.0
 	github.com/google/go-querystring v1.1.0
@@ -16,8 +17,9 @@ require (
 	github.com/xeipuuv/gojsonschema v1.2.0
 	github.com/yalp/jsonpath v0.0.0-20180802001716-5cc68e5049a0
 	github.com/yudai/gojsondiff v1.0.0
+	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 	golang.org/x/net v0.0.0-20220225172249-27dd8689420f
-	moul.io/http2curl/v2 v2.3.0
+	gopkg.in/yaml.v2 v2.2.8
 )
 
 require (
@@ -25,8 +27,8 @@ require (
 	github.com/mattn/go-colorable v0.1.2 // indirect
 	github.com/onsi/ginkgo v1.10.1 // indirect
 	github.com/onsi/gomega v1.7.0 // indirect
+	github.com/sergi/go-diff v1.0.0 // indirect
 	github.com/xeipuuv/gojsonpointer v0.0.0-20190905194746-02993c407bfb // indirect
 	github.com/yudai/golcs v0.0.0-20170316035057-ecda9a501e82 // indirect
 	github.com/yudai/pp v2.0.1+incompatible // indirect
-	gopkg.in/yaml.v2 v2.2.8 // indirect
 )
@@ -0,0 +1,337 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GoldenNormalizer transforms JSON value before it is compared with or
+// written to golden file. See Object.EqualGolden.
+//
+// Normalizer receives value in canonical form, i.e. one of nil, bool,
+// float64 (or json.Number), string, []interface{}, and
+// map[string]interface{}. Normalizer may modify value in place.
+type GoldenNormalizer func(value interface{}) interface{}
+
+// IgnoreFields returns normalizer that removes given fields.
+//
+// Field path is a dot-separated list of object keys and array indexes.
+// Asterisk matches any key or index.
+//
+// Example:
+//
+//	obj.EqualGolden("testdata/user.golden.json",
+//	    httpexpect.IgnoreFields("id", "created_at", "items.*.updated_at"))
+func IgnoreFields(paths ...string) GoldenNormalizer {
+	return func(value interface{}) interface{} {
+		for _, path := range paths {
+			value = removeField(value, strings.Split(path, "."))
+		}
+		return value
+	}
+}
+
+// SortArrays returns normalizer that sorts arrays found by given path.
+//
+// Path has the same syntax as in IgnoreFields; empty path means root
+// value. If key is non-empty, arrays should contain objects, which are
+// sorted by value of given key. Otherwise, array elements are sorted by
+// their own values. Numbers are compared numerically, other values are
+// compared by their JSON representation.
+//
+// Example:
+//
+//	obj.EqualGolden("testdata/users.golden.json",
+//	    httpexpect.SortArrays("users", "name"),
+//	    httpexpect.SortArrays("users.*.roles", ""))
+func SortArrays(path, key string) GoldenNormalizer {
+	var fields []string
+	if path != "" {
+		fields = strings.Split(path, ".")
+	}
+
+	return func(value interface{}) interface{} {
+		return mapField(value, fields, func(v interface{}) interface{} {
+			arr, ok := v.([]interface{})
+			if !ok {
+				return v
+			}
+
+			sortKey := func(elem interface{}) interface{} {
+				if key == "" {
+					return elem
+				}
+				if obj, ok := elem.(map[string]interface{}); ok {
+					return obj[key]
+				}
+				return nil
+			}
+
+			sort.SliceStable(arr, func(i, j int) bool {
+				return goldenLess(sortKey(arr[i]), sortKey(arr[j]))
+			})
+
+			return arr
+		})
+	}
+}
+
+// RoundFloats returns normalizer that rounds all numbers to given number
+// of digits after decimal point.
+//
+// Example:
+//
+//	obj.EqualGolden("testdata/stats.golden.json",
+//	    httpexpect.RoundFloats(2))
+func RoundFloats(precision int) GoldenNormalizer {
+	scale := math.Pow(10, float64(precision))
+
+	round := func(f float64) float64 {
+		return math.Round(f*scale) / scale
+	}
+
+	var walk func(value interface{}) interface{}
+
+	walk = func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				v[k] = walk(child)
+			}
+			return v
+
+		case []interface{}:
+			for i, child := range v {
+				v[i] = walk(child)
+			}
+			return v
+
+		case float64:
+			return round(v)
+
+		case json.Number:
+			if f, err := v.Float64(); err == nil {
+				return json.Number(strconv.FormatFloat(round(f), 'f', -1, 64))
+			}
+			return v
+		}
+
+		return value
+	}
+
+	return walk
+}
+
+// EqualGolden succeeds if object is equal to JSON object stored in given
+// golden file, after both are transformed by given normalizers.
+//
+// If test binary defines boolean "update" flag and it is set, e.g. when
+// running "go test -update", golden file is (re)written with normalized
+// object instead, and check always succeeds. Flag should be defined by
+// tests, for example:
+//
+//	var _ = flag.Bool("update", false, "update golden files")
+//
+// Golden file is written as indented JSON with sorted keys.
+//
+// Example:
+//
+//	e.GET("/users/42").
+//	    Expect().
+//	    JSON().Object().
+//	    EqualGolden("testdata/user.golden.json",
+//	        httpexpect.IgnoreFields("id", "created_at"),
+//	        httpexpect.RoundFloats(2))
+func (o *Object) EqualGolden(path string, normalizers ...GoldenNormalizer) *Object {
+	o.chain.enter("EqualGolden()")
+	defer o.chain.leave()
+
+	if o.chain.failed() {
+		return o
+	}
+
+	for _, n := range normalizers {
+		if n == nil {
+			o.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil normalizer"),
+				},
+			})
+			return o
+		}
+	}
+
+	if o.chain.manifest != nil {
+		o.chain.manifest.addFile("golden", path)
+	}
+
+	// canonMap returns a copy, so normalizers may modify it
+	actual, ok := canonMap(o.chain, o.value)
+	if !ok {
+		return o
+	}
+
+	actualValue := normalizeGolden(actual, normalizers)
+
+	if goldenUpdate() {
+		if err := writeGoldenFile(path, actualValue); err != nil {
+			o.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to write golden file %q", path),
+					err,
+				},
+			})
+		}
+		return o
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = errors.New("file not found, run tests with -update flag to create it")
+		}
+		o.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to read golden file %q", path),
+				err,
+			},
+		})
+		return o
+	}
+
+	decoded, err := jsonDecode(o.chain, content)
+	if err != nil {
+		o.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				fmt.Errorf("invalid json in golden file %q", path),
+				err,
+			},
+		})
+		return o
+	}
+
+	expected, ok := canonValue(o.chain, decoded)
+	if !ok {
+		return o
+	}
+
+	expectedValue := normalizeGolden(expected, normalizers)
+
+	if !reflect.DeepEqual(expectedValue, actualValue) {
+		o.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actualValue},
+			Expected: &AssertionValue{expectedValue},
+			Errors: []error{
+				fmt.Errorf("expected: object is equal to golden file %q", path),
+			},
+		})
+	}
+
+	return o
+}
+
+func normalizeGolden(value interface{}, normalizers []GoldenNormalizer) interface{} {
+	for _, n := range normalizers {
+		value = n(value)
+	}
+	return value
+}
+
+// Check if "update" flag is defined and set.
+func goldenUpdate() bool {
+	f := flag.Lookup("update")
+	if f == nil {
+		return false
+	}
+
+	update, _ := strconv.ParseBool(f.Value.String())
+	return update
+}
+
+func writeGoldenFile(path string, value interface{}) error {
+	b, err := json.MarshalIndent(value, "", defaultIndent)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
+
+// Apply fn to values found by given path.
+func mapField(
+	value interface{}, path []string, fn func(interface{}) interface{},
+) interface{} {
+	if len(path) == 0 {
+		return fn(value)
+	}
+
+	key, rest := path[0], path[1:]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if key == "*" || key == k {
+				v[k] = mapField(child, rest, fn)
+			}
+		}
+
+	case []interface{}:
+		if key == "*" {
+			for i, child := range v {
+				v[i] = mapField(child, rest, fn)
+			}
+			return v
+		}
+
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(v) {
+			v[i] = mapField(v[i], rest, fn)
+		}
+	}
+
+	return value
+}
+
+func goldenLess(a, b interface{}) bool {
+	fa, aNum := goldenNumber(a)
+	fb, bNum := goldenNumber(b)
+
+	if aNum && bNum {
+		return fa < fb
+	}
+
+	sa, _ := json.Marshal(a)
+	sb, _ := json.Marshal(b)
+
+	return string(sa) < string(sb)
+}
+
+func goldenNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,212 @@
+package httpexpect
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ = flag.Bool("update", false, "update golden files")
+
+func setGoldenUpdate(t *testing.T, update bool) {
+	value := "false"
+	if update {
+		value = "true"
+	}
+	require.NoError(t, flag.Set("update", value))
+}
+
+func TestGoldenFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	obj := newObject(chain, map[string]interface{}{})
+
+	obj.EqualGolden("missing.json")
+	obj.chain.assertFailed(t)
+}
+
+func TestGoldenEqual(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	reporter := newMockReporter(t)
+
+	path := filepath.Join(dir, "sub", "user.golden.json")
+
+	value := func(id int, score float64) map[string]interface{} {
+		return map[string]interface{}{
+			"id":    id,
+			"name":  "john",
+			"score": score,
+			"roles": []interface{}{"admin", "dev"},
+		}
+	}
+
+	normalizers := []GoldenNormalizer{
+		IgnoreFields("id"),
+		SortArrays("roles", ""),
+		RoundFloats(2),
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		obj := NewObject(reporter, value(1, 0.5))
+
+		obj.EqualGolden(path, normalizers...)
+		obj.chain.assertFailed(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		setGoldenUpdate(t, true)
+		defer setGoldenUpdate(t, false)
+
+		obj := NewObject(reporter, value(1, 0.123))
+
+		obj.EqualGolden(path, normalizers...)
+		obj.chain.assertNotFailed(t)
+
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.JSONEq(t,
+			`{"name": "john", "score": 0.12, "roles": ["admin", "dev"]}`,
+			string(content))
+	})
+
+	t.Run("equal", func(t *testing.T) {
+		obj := NewObject(reporter, map[string]interface{}{
+			"id":    2,
+			"name":  "john",
+			"score": 0.1249,
+			"roles": []interface{}{"dev", "admin"},
+		})
+
+		obj.EqualGolden(path, normalizers...)
+		obj.chain.assertNotFailed(t)
+
+		// object itself is not modified by normalizers
+		assert.Equal(t, 2.0, obj.Raw()["id"])
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		obj := NewObject(reporter, value(1, 0.5))
+
+		obj.EqualGolden(path, normalizers...)
+		obj.chain.assertFailed(t)
+	})
+
+	t.Run("without normalizers", func(t *testing.T) {
+		obj := NewObject(reporter, value(1, 0.12))
+
+		obj.EqualGolden(path)
+		obj.chain.assertFailed(t)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.golden.json")
+		require.NoError(t, ioutil.WriteFile(bad, []byte(`{`), 0644))
+
+		obj := NewObject(reporter, value(1, 0.12))
+
+		obj.EqualGolden(bad)
+		obj.chain.assertFailed(t)
+	})
+
+	t.Run("nil normalizer", func(t *testing.T) {
+		obj := NewObject(reporter, value(1, 0.12))
+
+		obj.EqualGolden(path, nil)
+		obj.chain.assertFailed(t)
+	})
+}
+
+func TestGoldenNormalizers(t *testing.T) {
+	t.Run("IgnoreFields", func(t *testing.T) {
+		value := map[string]interface{}{
+			"id": 1.0,
+			"items": []interface{}{
+				map[string]interface{}{"id": 2.0, "name": "a"},
+				map[string]interface{}{"id": 3.0, "name": "b"},
+			},
+		}
+
+		result := IgnoreFields("id", "items.*.id")(value)
+
+		assert.Equal(t, map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b"},
+			},
+		}, result)
+	})
+
+	t.Run("SortArrays by key", func(t *testing.T) {
+		value := map[string]interface{}{
+			"users": []interface{}{
+				map[string]interface{}{"name": "bob", "age": 30.0},
+				map[string]interface{}{"name": "alice", "age": 9.0},
+				map[string]interface{}{"name": "carol", "age": 10.0},
+			},
+		}
+
+		byName := SortArrays("users", "name")(value)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "alice", "age": 9.0},
+			map[string]interface{}{"name": "bob", "age": 30.0},
+			map[string]interface{}{"name": "carol", "age": 10.0},
+		}, byName.(map[string]interface{})["users"])
+
+		byAge := SortArrays("users", "age")(value)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "alice", "age": 9.0},
+			map[string]interface{}{"name": "carol", "age": 10.0},
+			map[string]interface{}{"name": "bob", "age": 30.0},
+		}, byAge.(map[string]interface{})["users"])
+	})
+
+	t.Run("SortArrays root", func(t *testing.T) {
+		result := SortArrays("", "")([]interface{}{3.0, 1.0, 2.0})
+
+		assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, result)
+	})
+
+	t.Run("SortArrays nested", func(t *testing.T) {
+		value := map[string]interface{}{
+			"users": []interface{}{
+				map[string]interface{}{"roles": []interface{}{"b", "a"}},
+				map[string]interface{}{"roles": []interface{}{"d", "c"}},
+			},
+		}
+
+		result := SortArrays("users.*.roles", "")(value)
+
+		assert.Equal(t, map[string]interface{}{
+			"users": []interface{}{
+				map[string]interface{}{"roles": []interface{}{"a", "b"}},
+				map[string]interface{}{"roles": []interface{}{"c", "d"}},
+			},
+		}, result)
+	})
+
+	t.Run("RoundFloats", func(t *testing.T) {
+		value := map[string]interface{}{
+			"a": 1.23456,
+			"b": []interface{}{0.1 + 0.2, "str"},
+			"c": 7.0,
+		}
+
+		result := RoundFloats(2)(value)
+
+		assert.Equal(t, map[string]interface{}{
+			"a": 1.23,
+			"b": []interface{}{0.3, "str"},
+			"c": 7.0,
+		}, result)
+	})
+}
@@ -0,0 +1,431 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GRPCStatus provides methods to inspect google.rpc.Status error payload,
+// returned by gRPC-gateway and other gRPC-to-JSON proxies.
+//
+// Status is a JSON object with "code", "message", and "details" fields.
+// Details are google.protobuf.Any messages, represented in JSON as objects
+// with "@type" field holding type URL, and other fields holding message
+// fields.
+//
+// Example payload:
+//
+//	{
+//	  "code": 3,
+//	  "message": "invalid name",
+//	  "details": [
+//	    {
+//	      "@type": "type.googleapis.com/google.rpc.BadRequest",
+//	      "fieldViolations": [{"field": "name", "description": "empty"}]
+//	    }
+//	  ]
+//	}
+type GRPCStatus struct {
+	chain *chain
+	value map[string]interface{}
+	code  int
+}
+
+// GRPC status codes names, indexed by code.
+var grpcCodeNames = []string{
+	"OK",
+	"CANCELLED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+// NewGRPCStatus returns a new GRPCStatus instance.
+//
+// reporter should not be nil. If value is not a valid status payload,
+// failure is reported.
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, map[string]interface{}{
+//	    "code":    5,
+//	    "message": "user not found",
+//	})
+//	status.CodeName().Equal("NOT_FOUND")
+func NewGRPCStatus(reporter Reporter, value map[string]interface{}) *GRPCStatus {
+	return newGRPCStatus(newChainWithDefaults("GRPCStatus()", reporter), value)
+}
+
+func newGRPCStatus(parent *chain, val map[string]interface{}) *GRPCStatus {
+	s := &GRPCStatus{chain: parent.clone()}
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if val == nil {
+		s.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil status"),
+			},
+		})
+		return s
+	}
+
+	value, ok := canonMap(s.chain, val)
+	if !ok {
+		return s
+	}
+
+	code, ok := grpcStatusCode(value["code"])
+	if !ok {
+		s.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New(`expected: status has integer "code" field`),
+			},
+		})
+		return s
+	}
+
+	if msg, ok := value["message"]; ok {
+		if _, ok := msg.(string); !ok {
+			s.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{val},
+				Errors: []error{
+					errors.New(`expected: status "message" field is string`),
+				},
+			})
+			return s
+		}
+	}
+
+	if details, ok := value["details"]; ok {
+		if _, ok := details.([]interface{}); !ok {
+			s.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{val},
+				Errors: []error{
+					errors.New(`expected: status "details" field is array`),
+				},
+			})
+			return s
+		}
+	}
+
+	s.value = value
+	s.code = code
+
+	return s
+}
+
+func grpcStatusCode(value interface{}) (int, bool) {
+	var f float64
+
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+		f = float64(n)
+	case string:
+		// code may be encoded as enum name
+		for i, name := range grpcCodeNames {
+			if name == v {
+				return i, true
+			}
+		}
+		return 0, false
+	default:
+		return 0, false
+	}
+
+	if f != float64(int(f)) || f < 0 {
+		return 0, false
+	}
+
+	return int(f), true
+}
+
+// GRPCStatus returns a new GRPCStatus instance with response body parsed
+// as google.rpc.Status JSON payload.
+//
+// GRPCStatus succeeds if response contains "application/json" Content-Type
+// header with empty or "utf-8" charset, and if body is a JSON object with
+// integer "code" field.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	status := resp.GRPCStatus()
+//	status.CodeName().Equal("INVALID_ARGUMENT")
+//	status.Message().Contains("name")
+//	status.Detail("google.rpc.BadRequest").
+//	    Value("fieldViolations").Array().Length().Equal(1)
+func (r *Response) GRPCStatus(options ...ContentOpts) *GRPCStatus {
+	r.chain.enter("GRPCStatus()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newGRPCStatus(r.chain, nil)
+	}
+
+	if len(options) > 1 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newGRPCStatus(r.chain, nil)
+	}
+
+	value := r.getJSON(options...)
+	if r.chain.failed() {
+		return newGRPCStatus(r.chain, nil)
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: response body is JSON object"),
+			},
+		})
+		return newGRPCStatus(r.chain, nil)
+	}
+
+	return newGRPCStatus(r.chain, obj)
+}
+
+// Raw returns underlying value attached to GRPCStatus.
+// This is the value originally passed to NewGRPCStatus, converted to
+// canonical form.
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, value)
+//	assert.Equal(t, 5.0, status.Raw()["code"])
+func (s *GRPCStatus) Raw() map[string]interface{} {
+	return s.value
+}
+
+// Code returns a new Number instance with status code.
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, value)
+//	status.Code().Equal(5)
+func (s *GRPCStatus) Code() *Number {
+	s.chain.enter("Code()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newNumber(s.chain, 0)
+	}
+
+	return newNumber(s.chain, float64(s.code))
+}
+
+// CodeName returns a new String instance with canonical name of status
+// code, e.g. "NOT_FOUND". Unknown codes are formatted as "CODE(N)".
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, value)
+//	status.CodeName().Equal("NOT_FOUND")
+func (s *GRPCStatus) CodeName() *String {
+	s.chain.enter("CodeName()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newString(s.chain, "")
+	}
+
+	return newString(s.chain, grpcCodeName(s.code))
+}
+
+func grpcCodeName(code int) string {
+	if code >= 0 && code < len(grpcCodeNames) {
+		return grpcCodeNames[code]
+	}
+	return fmt.Sprintf("CODE(%d)", code)
+}
+
+// HaveCode succeeds if status has given code name.
+//
+// Code name is case-insensitive, e.g. "NOT_FOUND" or "not_found".
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, value)
+//	status.HaveCode("NOT_FOUND")
+func (s *GRPCStatus) HaveCode(name string) *GRPCStatus {
+	s.chain.enter("HaveCode(%q)", name)
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	known := false
+	for _, n := range grpcCodeNames {
+		if strings.EqualFold(n, name) {
+			known = true
+			break
+		}
+	}
+
+	if !known {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected code name %q", name),
+			},
+		})
+		return s
+	}
+
+	if actual := grpcCodeName(s.code); !strings.EqualFold(actual, name) {
+		s.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{strings.ToUpper(name)},
+			Errors: []error{
+				errors.New("expected: status has given code"),
+			},
+		})
+	}
+
+	return s
+}
+
+// Message returns a new String instance with status message.
+// If message is absent, empty string is used.
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, value)
+//	status.Message().Equal("user not found")
+func (s *GRPCStatus) Message() *String {
+	s.chain.enter("Message()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newString(s.chain, "")
+	}
+
+	msg, _ := s.value["message"].(string)
+
+	return newString(s.chain, msg)
+}
+
+// Details returns a new Array instance with status details.
+// If details are absent, empty array is used.
+//
+// Every element is an object with "@type" field and message fields.
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, value)
+//	status.Details().Length().Equal(1)
+func (s *GRPCStatus) Details() *Array {
+	s.chain.enter("Details()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newArray(s.chain, nil)
+	}
+
+	details, _ := s.value["details"].([]interface{})
+	if details == nil {
+		details = []interface{}{}
+	}
+
+	return newArray(s.chain, details)
+}
+
+// Detail returns a new Object instance with the first status detail
+// of given type, unpacked from google.protobuf.Any.
+//
+// Type may be either full type URL, e.g.
+// "type.googleapis.com/google.rpc.BadRequest", or message name, e.g.
+// "google.rpc.BadRequest". Returned object has all detail fields except
+// "@type".
+//
+// If there is no detail of given type, failure is reported.
+//
+// Example:
+//
+//	status := NewGRPCStatus(t, value)
+//	status.Detail("google.rpc.ErrorInfo").
+//	    Value("reason").String().Equal("API_DISABLED")
+func (s *GRPCStatus) Detail(typeName string) *Object {
+	s.chain.enter("Detail(%q)", typeName)
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newObject(s.chain, nil)
+	}
+
+	details, _ := s.value["details"].([]interface{})
+
+	types := []interface{}{}
+
+	for _, d := range details {
+		obj, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		typeURL, _ := obj["@type"].(string)
+		types = append(types, typeURL)
+
+		if typeURL != typeName && !strings.HasSuffix(typeURL, "/"+typeName) {
+			continue
+		}
+
+		unpacked := make(map[string]interface{}, len(obj))
+		for k, v := range obj {
+			if k != "@type" {
+				unpacked[k] = v
+			}
+		}
+
+		return newObject(s.chain, unpacked)
+	}
+
+	s.chain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{types},
+		Expected: &AssertionValue{typeName},
+		Errors: []error{
+			errors.New("expected: status has detail of given type"),
+		},
+	})
+
+	return newObject(s.chain, nil)
+}
@@ -0,0 +1,190 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGRPCStatusFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newGRPCStatus(chain, map[string]interface{}{"code": 5})
+
+	value.chain.assertFailed(t)
+
+	assert.Nil(t, value.Raw())
+
+	value.Code().chain.assertFailed(t)
+	value.CodeName().chain.assertFailed(t)
+	value.Message().chain.assertFailed(t)
+	value.Details().chain.assertFailed(t)
+	value.Detail("google.rpc.BadRequest").chain.assertFailed(t)
+	value.HaveCode("NOT_FOUND")
+}
+
+func TestGRPCStatusGetters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewGRPCStatus(reporter, map[string]interface{}{
+		"code":    3,
+		"message": "invalid name",
+		"details": []interface{}{
+			map[string]interface{}{
+				"@type":  "type.googleapis.com/google.rpc.ErrorInfo",
+				"reason": "BAD_NAME",
+			},
+			map[string]interface{}{
+				"@type": "type.googleapis.com/google.rpc.BadRequest",
+				"fieldViolations": []interface{}{
+					map[string]interface{}{"field": "name", "description": "empty"},
+				},
+			},
+		},
+	})
+
+	value.chain.assertNotFailed(t)
+
+	value.Code().Equal(3)
+	value.CodeName().Equal("INVALID_ARGUMENT")
+	value.HaveCode("INVALID_ARGUMENT")
+	value.HaveCode("invalid_argument")
+	value.Message().Equal("invalid name")
+	value.Details().Length().Equal(2)
+
+	value.Detail("google.rpc.ErrorInfo").Equal(map[string]interface{}{
+		"reason": "BAD_NAME",
+	})
+	value.Detail("type.googleapis.com/google.rpc.BadRequest").
+		Value("fieldViolations").Array().Length().Equal(1)
+
+	value.chain.assertNotFailed(t)
+
+	value.HaveCode("NOT_FOUND")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.HaveCode("NO_SUCH_CODE")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Detail("google.rpc.RetryInfo")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Detail("rpc.BadRequest")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestGRPCStatusParse(t *testing.T) {
+	t.Run("minimal", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewGRPCStatus(reporter, map[string]interface{}{"code": 0})
+
+		value.CodeName().Equal("OK")
+		value.Message().Empty()
+		value.Details().Empty()
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("enum name", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewGRPCStatus(reporter, map[string]interface{}{"code": "NOT_FOUND"})
+
+		value.Code().Equal(5)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewGRPCStatus(reporter, map[string]interface{}{"code": 42})
+
+		value.CodeName().Equal("CODE(42)")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := map[string]map[string]interface{}{
+			"nil":           nil,
+			"no code":       {"message": "oops"},
+			"float code":    {"code": 1.5},
+			"negative code": {"code": -1},
+			"bad code name": {"code": "NOPE"},
+			"bad message":   {"code": 1, "message": 123},
+			"bad details":   {"code": 1, "details": "oops"},
+		}
+
+		for name, val := range cases {
+			t.Run(name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				value := NewGRPCStatus(reporter, val)
+				value.chain.assertFailed(t)
+			})
+		}
+	})
+}
+
+func TestGRPCStatusResponse(t *testing.T) {
+	newResp := func(contentType, body string) *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Header: http.Header{
+				"Content-Type": []string{contentType},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, newResp("application/json",
+			`{"code": 3, "message": "bad", "details": []}`))
+
+		status := resp.GRPCStatus()
+		status.HaveCode("INVALID_ARGUMENT")
+		status.Message().Equal("bad")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("not object", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, newResp("application/json", `[1, 2]`))
+
+		resp.GRPCStatus()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, newResp("text/plain", `{"code": 3}`))
+
+		resp.GRPCStatus()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("content opts", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, newResp("application/problem+json", `{"code": 3}`))
+
+		resp.GRPCStatus(ContentOpts{MediaType: "application/problem+json"}).
+			Code().Equal(3)
+		resp.chain.assertNotFailed(t)
+
+		resp.GRPCStatus(ContentOpts{}, ContentOpts{})
+		resp.chain.assertFailed(t)
+	})
+}
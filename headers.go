@@ -0,0 +1,213 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Headers provides methods to inspect attached http.Header map.
+//
+// Headers embeds Object, so all Object methods can be used as well.
+// Every header is represented as an array of its values.
+//
+// Unlike Object, Value, Values, ContainsKey, and NotContainsKey methods
+// of Headers match header names case-insensitively.
+type Headers struct {
+	*Object
+	header http.Header
+}
+
+// NewHeaders returns a new Headers instance.
+//
+// Both reporter and value should not be nil. If value is nil, failure is
+// reported.
+//
+// Example:
+//
+//	headers := NewHeaders(t, http.Header{"Content-Type": {"text/plain"}})
+//	headers.ContainsKey("content-type")
+//	headers.NotContainsKey("X-Powered-By")
+func NewHeaders(reporter Reporter, value http.Header) *Headers {
+	return newHeaders(newChainWithDefaults("Headers()", reporter), value)
+}
+
+func newHeaders(parent *chain, val http.Header) *Headers {
+	if val == nil {
+		return &Headers{Object: newObject(parent, nil)}
+	}
+
+	value := make(map[string]interface{}, len(val))
+	for key, values := range val {
+		list := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			list = append(list, v)
+		}
+		value[key] = list
+	}
+
+	return &Headers{
+		Object: newObject(parent, value),
+		header: val,
+	}
+}
+
+// Values returns a new Array instance with all values of given header.
+// Header name is matched case-insensitively.
+//
+// If header is not present, failure is reported.
+//
+// Example:
+//
+//	headers := NewHeaders(t, resp.Header)
+//	headers.Values("Set-Cookie").Length().Equal(2)
+func (h *Headers) Values(name string) *Array {
+	h.chain.enter("Values(%q)", name)
+	defer h.chain.leave()
+
+	if h.chain.failed() {
+		return newArray(h.chain, nil)
+	}
+
+	values, ok := h.lookup(name)
+	if !ok {
+		h.failMissing(name)
+		return newArray(h.chain, nil)
+	}
+
+	return newArray(h.chain, values)
+}
+
+// Value returns a new Value instance with array of all values of given
+// header. Header name is matched case-insensitively.
+//
+// If header is not present, failure is reported.
+//
+// Example:
+//
+//	headers := NewHeaders(t, resp.Header)
+//	headers.Value("content-type").Array().Elements("application/json")
+func (h *Headers) Value(name string) *Value {
+	h.chain.enter("Value(%q)", name)
+	defer h.chain.leave()
+
+	if h.chain.failed() {
+		return newValue(h.chain, nil)
+	}
+
+	values, ok := h.lookup(name)
+	if !ok {
+		h.failMissing(name)
+		return newValue(h.chain, nil)
+	}
+
+	return newValue(h.chain, values)
+}
+
+// ContainsKey succeeds if given header is present.
+// Header name is matched case-insensitively.
+//
+// Example:
+//
+//	headers := NewHeaders(t, resp.Header)
+//	headers.ContainsKey("strict-transport-security")
+func (h *Headers) ContainsKey(name string) *Headers {
+	h.chain.enter("ContainsKey()")
+	defer h.chain.leave()
+
+	if h.chain.failed() {
+		return h
+	}
+
+	if _, ok := h.lookup(name); !ok {
+		h.failMissing(name)
+	}
+
+	return h
+}
+
+// NotContainsKey succeeds if given header is not present.
+// Header name is matched case-insensitively.
+//
+// Useful to check that server doesn't leak forbidden headers.
+//
+// Example:
+//
+//	headers := NewHeaders(t, resp.Header)
+//	headers.NotContainsKey("X-Powered-By")
+func (h *Headers) NotContainsKey(name string) *Headers {
+	h.chain.enter("NotContainsKey()")
+	defer h.chain.leave()
+
+	if h.chain.failed() {
+		return h
+	}
+
+	if _, ok := h.lookup(name); ok {
+		h.chain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{h.names()},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: headers do not contain given header"),
+			},
+		})
+	}
+
+	return h
+}
+
+// Collect values of all keys that match name case-insensitively.
+func (h *Headers) lookup(name string) ([]interface{}, bool) {
+	var (
+		values []interface{}
+		found  bool
+	)
+
+	keys := make([]string, 0, len(h.header))
+	for key := range h.header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !strings.EqualFold(key, name) {
+			continue
+		}
+		found = true
+		for _, v := range h.header[key] {
+			values = append(values, v)
+		}
+	}
+
+	if found && values == nil {
+		values = []interface{}{}
+	}
+
+	return values, found
+}
+
+func (h *Headers) names() []string {
+	return headerNames(h.header)
+}
+
+func headerNames(header http.Header) []string {
+	names := make([]string, 0, len(header))
+	for key := range header {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (h *Headers) failMissing(name string) {
+	h.chain.fail(AssertionFailure{
+		Type:     AssertContainsKey,
+		Actual:   &AssertionValue{h.names()},
+		Expected: &AssertionValue{name},
+		Errors: []error{
+			errors.New("expected: headers contain given header"),
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHeadersFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newHeaders(chain, http.Header{"X-Foo": {"bar"}})
+
+	value.chain.assertFailed(t)
+
+	value.Values("X-Foo").chain.assertFailed(t)
+	value.Value("X-Foo").chain.assertFailed(t)
+	value.ContainsKey("X-Foo")
+	value.NotContainsKey("X-Bar")
+}
+
+func TestHeadersNil(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewHeaders(reporter, nil)
+	value.chain.assertFailed(t)
+}
+
+func TestHeadersGetters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewHeaders(reporter, http.Header{
+		"Content-Type": {"application/json"},
+		"Set-Cookie":   {"a=1", "b=2"},
+	})
+
+	value.chain.assertNotFailed(t)
+
+	value.Values("Set-Cookie").Elements("a=1", "b=2")
+	value.Values("set-cookie").Length().Equal(2)
+	value.Value("CONTENT-TYPE").Array().Elements("application/json")
+	value.Object.Value("Content-Type").Array().Elements("application/json")
+
+	value.chain.assertNotFailed(t)
+
+	value.Values("X-Foo")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Value("X-Foo")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestHeadersContainsKey(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewHeaders(reporter, http.Header{
+		"X-Powered-By": {"PHP"},
+		"Server":       {"nginx"},
+	})
+
+	value.ContainsKey("Server")
+	value.chain.assertNotFailed(t)
+
+	value.ContainsKey("x-powered-by")
+	value.chain.assertNotFailed(t)
+
+	value.NotContainsKey("Strict-Transport-Security")
+	value.chain.assertNotFailed(t)
+
+	value.ContainsKey("Strict-Transport-Security")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotContainsKey("X-POWERED-BY")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestHeadersNonCanonical(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewHeaders(reporter, http.Header{
+		"x-trace": {"1"},
+		"X-Trace": {"2"},
+	})
+
+	value.Values("X-TRACE").Equal([]interface{}{"2", "1"})
+	value.chain.assertNotFailed(t)
+}
@@ -0,0 +1,250 @@
+package httpexpect
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Invariants collects results of invariants registered by Expect.Invariant
+// across test suite. See Config.Invariants.
+//
+// For every invariant, Invariants counts how many responses were checked,
+// and how many of them violated the invariant. When the test run is
+// finished, Results or Write can be used to see which invariants held.
+//
+// Invariants is safe for concurrent use. Zero value is ready to use.
+//
+// Example:
+//
+//	var invariants = &httpexpect.Invariants{}
+//
+//	func TestMain(m *testing.M) {
+//	    code := m.Run()
+//	    invariants.Write(os.Stdout)
+//	    os.Exit(code)
+//	}
+//
+//	func TestSomething(t *testing.T) {
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        Reporter:   httpexpect.NewAssertReporter(t),
+//	        Invariants: invariants,
+//	    })
+//	}
+type Invariants struct {
+	mu      sync.Mutex
+	results map[string]*InvariantResult
+}
+
+// InvariantResult describes results of single invariant, collected
+// by Invariants.
+type InvariantResult struct {
+	// Invariant name, as passed to Expect.Invariant.
+	Name string
+
+	// Number of responses checked by invariant.
+	Checked int
+
+	// Number of responses that violated invariant.
+	Violated int
+
+	// Distinct requests (method and path template) which responses
+	// violated invariant, in order of first violation.
+	Violations []string
+}
+
+// Held returns true if invariant was checked at least once and was
+// never violated.
+func (r InvariantResult) Held() bool {
+	return r.Checked != 0 && r.Violated == 0
+}
+
+// Results returns results of all invariants, sorted by name.
+func (inv *Invariants) Results() []InvariantResult {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	results := make([]InvariantResult, 0, len(inv.results))
+
+	for _, res := range inv.results {
+		cp := *res
+		cp.Violations = append([]string(nil), res.Violations...)
+
+		results = append(results, cp)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	return results
+}
+
+// Reset removes all collected results.
+func (inv *Invariants) Reset() {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	inv.results = nil
+}
+
+// Write writes human-readable summary of results to given writer.
+//
+// Example output:
+//
+//	invariant "error body": held, 120 responses checked
+//	invariant "json content": violated by 2 of 120 responses
+//	  GET /users/{id}
+//	  DELETE /users/{id}
+func (inv *Invariants) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for _, res := range inv.Results() {
+		switch {
+		case res.Checked == 0:
+			fmt.Fprintf(bw, "invariant %q: not checked\n", res.Name)
+
+		case res.Violated == 0:
+			fmt.Fprintf(bw, "invariant %q: held, %d responses checked\n",
+				res.Name, res.Checked)
+
+		default:
+			fmt.Fprintf(bw, "invariant %q: violated by %d of %d responses\n",
+				res.Name, res.Violated, res.Checked)
+
+			for _, v := range res.Violations {
+				fmt.Fprintf(bw, "  %s\n", v)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (inv *Invariants) register(name string) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	inv.result(name)
+}
+
+func (inv *Invariants) observe(name string, violation string, violated bool) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	res := inv.result(name)
+
+	res.Checked++
+
+	if !violated {
+		return
+	}
+
+	res.Violated++
+
+	if violation == "" {
+		return
+	}
+
+	for _, v := range res.Violations {
+		if v == violation {
+			return
+		}
+	}
+	res.Violations = append(res.Violations, violation)
+}
+
+func (inv *Invariants) result(name string) *InvariantResult {
+	if inv.results == nil {
+		inv.results = make(map[string]*InvariantResult)
+	}
+
+	res := inv.results[name]
+
+	if res == nil {
+		res = &InvariantResult{
+			Name: name,
+		}
+		inv.results[name] = res
+	}
+
+	return res
+}
+
+// Invariant returns a copy of Expect instance with given invariant attached
+// to it. Returned copy contains all previously attached matchers and
+// invariants plus a new one.
+//
+// Like matchers, invariants are invoked from Request.Expect method for
+// every response, and their failures are reported as usual. Additionally,
+// if Config.Invariants is set, result of every check is recorded there
+// under given name, which allows to see which invariants held across the
+// whole test suite.
+//
+// Invariant is not checked if request or one of the previous matchers
+// already failed.
+//
+// Example:
+//
+//	e = e.Invariant("error body", func(resp *httpexpect.Response) {
+//	    if resp.Raw().StatusCode >= 400 {
+//	        obj := resp.JSON().Object()
+//	        obj.ContainsKey("code")
+//	        obj.ContainsKey("message")
+//	    }
+//	})
+func (e *Expect) Invariant(name string, fn func(*Response)) *Expect {
+	ret := e.clone()
+
+	if inv := e.config.Invariants; inv != nil {
+		inv.register(name)
+	}
+
+	ret.matchers = append(ret.matchers, func(resp *Response) {
+		checkInvariant(name, fn, resp)
+	})
+
+	return ret
+}
+
+// Invoke invariant on a copy of the response and record whether it held.
+func checkInvariant(name string, fn func(*Response), resp *Response) {
+	resp.chain.enter("Invariant(%q)", name)
+	defer resp.chain.leave()
+
+	if resp.chain.failed() {
+		return
+	}
+
+	violated := false
+
+	respCopy := *resp
+	respCopy.chain = resp.chain.clone()
+
+	parentCb := resp.chain.failCb
+
+	respCopy.chain.setFailCallback(func() {
+		violated = true
+		resp.chain.setFailed()
+		if parentCb != nil {
+			parentCb()
+		}
+	})
+
+	fn(&respCopy)
+
+	if inv := resp.config.Invariants; inv != nil {
+		inv.observe(name, invariantViolation(resp), violated)
+	}
+}
+
+func invariantViolation(resp *Response) string {
+	req := resp.chain.context.Request
+	if req == nil || req.httpReq == nil {
+		return ""
+	}
+
+	return req.httpReq.Method + " " + req.pathTemplate
+}
@@ -0,0 +1,205 @@
+package httpexpect
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type invariantPathHandler struct {
+	paths [][]string
+}
+
+func (h *invariantPathHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *invariantPathHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.paths = append(h.paths, append([]string(nil), ctx.Path...))
+}
+
+func TestInvariantsResults(t *testing.T) {
+	inv := &Invariants{}
+
+	inv.register("unused")
+
+	inv.observe("json", "GET /users", false)
+	inv.observe("json", "GET /users/{id}", true)
+	inv.observe("json", "GET /users/{id}", true)
+	inv.observe("json", "", true)
+
+	inv.observe("error body", "POST /users", false)
+
+	results := inv.Results()
+	require.Equal(t, 3, len(results))
+
+	assert.Equal(t, InvariantResult{
+		Name:    "error body",
+		Checked: 1,
+	}, results[0])
+
+	assert.Equal(t, InvariantResult{
+		Name:       "json",
+		Checked:    4,
+		Violated:   3,
+		Violations: []string{"GET /users/{id}"},
+	}, results[1])
+
+	assert.Equal(t, InvariantResult{
+		Name: "unused",
+	}, results[2])
+
+	assert.True(t, results[0].Held())
+	assert.False(t, results[1].Held())
+	assert.False(t, results[2].Held())
+
+	var buf bytes.Buffer
+	require.NoError(t, inv.Write(&buf))
+
+	assert.Equal(t,
+		"invariant \"error body\": held, 1 responses checked\n"+
+			"invariant \"json\": violated by 3 of 4 responses\n"+
+			"  GET /users/{id}\n"+
+			"invariant \"unused\": not checked\n",
+		buf.String())
+
+	inv.Reset()
+	assert.Empty(t, inv.Results())
+}
+
+func TestInvariantExpect(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404}`))
+	})
+
+	mux.HandleFunc("/posts/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404, "message": "not found"}`))
+	})
+
+	invariants := &Invariants{}
+
+	handler := &invariantPathHandler{}
+
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Invariants:       invariants,
+		Client: &http.Client{
+			Transport: NewBinder(mux),
+		},
+	})
+
+	e = e.Invariant("error body", func(resp *Response) {
+		if resp.Raw().StatusCode >= 400 {
+			obj := resp.JSON().Object()
+			obj.ContainsKey("code")
+			obj.ContainsKey("message")
+		}
+	})
+
+	e = e.Invariant("json", func(resp *Response) {
+		resp.ContentType("application/json")
+	})
+
+	resp := e.GET("/users").Expect()
+	resp.chain.assertNotFailed(t)
+
+	resp = e.GET("/posts/{id}", 1).Expect()
+	resp.chain.assertNotFailed(t)
+
+	resp = e.GET("/users/{id}", 1).Expect()
+	resp.chain.assertFailed(t)
+
+	assert.Equal(t, [][]string{
+		{
+			`Request("GET")`,
+			"Expect()",
+			`Invariant("error body")`,
+			"JSON()",
+			"Object()",
+			"ContainsKey()",
+		},
+	}, handler.paths)
+
+	assert.Equal(t, []InvariantResult{
+		{
+			Name:       "error body",
+			Checked:    3,
+			Violated:   1,
+			Violations: []string{"GET /users/{id}"},
+		},
+		// not checked after "error body" failed
+		{
+			Name:    "json",
+			Checked: 2,
+		},
+	}, invariants.Results())
+}
+
+func TestInvariantSkipped(t *testing.T) {
+	invariants := &Invariants{}
+
+	e := WithConfig(Config{
+		BaseURL:    "http://example.com",
+		Reporter:   newMockReporter(t),
+		Invariants: invariants,
+		Client: &http.Client{
+			Transport: NewBinder(http.NotFoundHandler()),
+		},
+	})
+
+	invoked := false
+
+	e.Matcher(func(resp *Response) {
+		resp.Status(http.StatusOK)
+	}).
+		Invariant("never", func(resp *Response) {
+			invoked = true
+		}).
+		GET("/").
+		Expect().
+		chain.assertFailed(t)
+
+	assert.False(t, invoked)
+
+	assert.Equal(t, []InvariantResult{
+		{Name: "never"},
+	}, invariants.Results())
+}
+
+func TestInvariantNoCollector(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(http.NotFoundHandler()),
+		},
+	})
+
+	e.Invariant("ok", func(resp *Response) {
+		resp.Status(http.StatusOK)
+	}).
+		GET("/").
+		Expect().
+		chain.assertFailed(t)
+
+	assert.True(t, reporter.reported)
+}
@@ -1,15 +1,43 @@
 package httpexpect
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"regexp"
+	"strings"
 
 	"github.com/xeipuuv/gojsonschema"
 	"github.com/yalp/jsonpath"
 )
 
+// Decode JSON document into a generic value.
+// If chain is in JSONNumber mode, numbers are decoded into json.Number.
+func jsonDecode(chain *chain, data []byte) (interface{}, error) {
+	var value interface{}
+
+	if !chain.jsonNumber {
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+
+	return value, nil
+}
+
 func jsonPath(chain *chain, value interface{}, path string) *Value {
 	if chain.failed() {
 		return newValue(chain, nil)
@@ -80,6 +108,7 @@ func jsonSchema(chain *chain, value, schema interface{}) {
 	valueLoader := gojsonschema.NewGoLoader(value)
 
 	result, err := gojsonschema.Validate(schemaLoader, valueLoader)
+
 	if err != nil {
 		chain.fail(AssertionFailure{
 			Type:   AssertValid,
@@ -92,6 +121,10 @@ func jsonSchema(chain *chain, value, schema interface{}) {
 		return
 	}
 
+	if chain.manifest != nil {
+		recordSchema(chain.manifest, schemaLoader, schemaData)
+	}
+
 	if !result.Valid() {
 		errors := []error{
 			errors.New("expected: value matches given json schema"),
@@ -107,3 +140,144 @@ func jsonSchema(chain *chain, value, schema interface{}) {
 		})
 	}
 }
+
+// Record schema used for validation in manifest.
+func recordSchema(manifest *Manifest, loader gojsonschema.JSONLoader, schema interface{}) {
+	source := "inline"
+
+	if ref, ok := schema.(string); ok {
+		source = ref
+		if strings.HasPrefix(ref, "file://") {
+			manifest.addFile("json-schema", ref)
+		}
+	}
+
+	var id, version string
+
+	if data, err := loader.LoadJSON(); err == nil {
+		if obj, ok := data.(map[string]interface{}); ok {
+			id, _ = obj["$id"].(string)
+			if id == "" {
+				id, _ = obj["id"].(string)
+			}
+			version, _ = obj["$schema"].(string)
+		}
+	}
+
+	manifest.addSchema("json", source, id, version)
+}
+
+// Decode canonical value into target, which should be a non-nil pointer.
+// If chain is in StrictDecoding mode, fields of JSON objects that don't
+// match any field of target struct are reported as failure.
+func jsonUnmarshal(chain *chain, value, target interface{}) {
+	if chain.failed() {
+		return
+	}
+
+	if rv := reflect.ValueOf(target); rv.Kind() != reflect.Ptr || rv.IsNil() {
+		chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected target: should be a non-nil pointer"),
+			},
+		})
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: marshalable value"),
+				err,
+			},
+		})
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if chain.jsonNumber {
+		dec.UseNumber()
+	}
+	if chain.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(target); err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: value can be decoded into %s",
+					reflect.TypeOf(target).Elem()),
+				err,
+			},
+		})
+		return
+	}
+
+	if chain.validator != nil {
+		jsonValidate(chain, value, target)
+	}
+}
+
+// Run validator for decoded struct, or for every struct in decoded slice.
+func jsonValidate(chain *chain, value, target interface{}) {
+	validate := func(ptr reflect.Value, index int) bool {
+		if err := chain.validator.Struct(ptr.Interface()); err != nil {
+			var msg error
+			if index < 0 {
+				msg = fmt.Errorf("expected: decoded %s is valid",
+					ptr.Type().Elem())
+			} else {
+				msg = fmt.Errorf("expected: decoded %s at index %d is valid",
+					ptr.Type().Elem(), index)
+			}
+			chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					msg,
+					err,
+				},
+			})
+			return false
+		}
+		return true
+	}
+
+	// pointer to struct, or element of slice
+	structPtr := func(v reflect.Value) (reflect.Value, bool) {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct || !v.CanAddr() {
+			return v, false
+		}
+		return v.Addr(), true
+	}
+
+	rv := reflect.ValueOf(target).Elem()
+
+	if ptr, ok := structPtr(rv); ok {
+		validate(ptr, -1)
+		return
+	}
+
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			if ptr, ok := structPtr(rv.Index(i)); ok {
+				if !validate(ptr, i) {
+					return
+				}
+			}
+		}
+	}
+}
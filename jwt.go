@@ -0,0 +1,673 @@
+package httpexpect
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JWT provides methods to inspect attached JSON Web Token.
+//
+// Only signed tokens (JWS compact serialization) are supported.
+// Encrypted tokens (JWE) are reported as invalid.
+type JWT struct {
+	chain  *chain
+	raw    string
+	header map[string]interface{}
+	claims map[string]interface{}
+	signed []byte
+	sig    []byte
+}
+
+// NewJWT returns a new JWT instance.
+//
+// reporter should not be nil. If token can't be parsed, failure is reported.
+//
+// Example:
+//
+//	token := NewJWT(t, "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0.signature")
+//	token.Claims().Value("sub").String().Equal("john")
+func NewJWT(reporter Reporter, token string) *JWT {
+	return newJWT(newChainWithDefaults("JWT()", reporter), token)
+}
+
+func newJWT(parent *chain, token string) *JWT {
+	j := &JWT{chain: parent.clone(), raw: token}
+
+	if j.chain.failed() {
+		return j
+	}
+
+	parts := strings.Split(token, ".")
+
+	if len(parts) == 5 {
+		j.failToken("expected: signed token (JWS), got encrypted token (JWE)")
+		return j
+	}
+	if len(parts) != 3 {
+		j.failToken("expected: token consists of three dot-separated parts")
+		return j
+	}
+
+	header, ok := j.decodePart(parts[0], "header")
+	if !ok {
+		return j
+	}
+
+	if alg, _ := header["alg"].(string); alg == "" {
+		j.failToken(`expected: token header contains "alg" field`)
+		return j
+	}
+
+	claims, ok := j.decodePart(parts[1], "claims")
+	if !ok {
+		return j
+	}
+
+	sig, err := decodeBase64URL(parts[2])
+	if err != nil {
+		j.failToken("expected: token signature is valid base64url", err)
+		return j
+	}
+
+	j.header = header
+	j.claims = claims
+	j.signed = []byte(parts[0] + "." + parts[1])
+	j.sig = sig
+
+	return j
+}
+
+func (j *JWT) decodePart(part, name string) (map[string]interface{}, bool) {
+	data, err := decodeBase64URL(part)
+	if err != nil {
+		j.failToken(fmt.Sprintf("expected: token %s is valid base64url", name), err)
+		return nil, false
+	}
+
+	value, err := jsonDecode(j.chain, data)
+	if err != nil {
+		j.failToken(fmt.Sprintf("expected: token %s is valid json", name), err)
+		return nil, false
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		j.failToken(fmt.Sprintf("expected: token %s is json object", name))
+		return nil, false
+	}
+
+	return obj, true
+}
+
+func (j *JWT) failToken(message string, errs ...error) {
+	j.chain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{j.raw},
+		Errors: append([]error{errors.New(message)}, errs...),
+	})
+}
+
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// Raw returns underlying token string attached to JWT.
+// This is the value originally passed to NewJWT.
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	assert.Equal(t, str, token.Raw())
+func (j *JWT) Raw() string {
+	return j.raw
+}
+
+// Header returns a new Object instance with token header (JOSE header).
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.Header().Value("alg").String().Equal("RS256")
+func (j *JWT) Header() *Object {
+	j.chain.enter("Header()")
+	defer j.chain.leave()
+
+	if j.chain.failed() {
+		return newObject(j.chain, nil)
+	}
+
+	return newObject(j.chain, j.header)
+}
+
+// Claims returns a new Object instance with token claims (payload).
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.Claims().Value("sub").String().Equal("john")
+//	token.Claims().ContainsKey("exp")
+func (j *JWT) Claims() *Object {
+	j.chain.enter("Claims()")
+	defer j.chain.leave()
+
+	if j.chain.failed() {
+		return newObject(j.chain, nil)
+	}
+
+	return newObject(j.chain, j.claims)
+}
+
+// ExpiresAt returns a new DateTime instance with "exp" claim.
+//
+// If token doesn't have "exp" claim or it's not a number, failure
+// is reported.
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.ExpiresAt().Within(time.Now().Add(time.Hour), time.Minute)
+func (j *JWT) ExpiresAt() *DateTime {
+	j.chain.enter("ExpiresAt()")
+	defer j.chain.leave()
+
+	return j.timeClaim("exp")
+}
+
+// IssuedAt returns a new DateTime instance with "iat" claim.
+//
+// If token doesn't have "iat" claim or it's not a number, failure
+// is reported.
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.IssuedAt().Le(time.Now())
+func (j *JWT) IssuedAt() *DateTime {
+	j.chain.enter("IssuedAt()")
+	defer j.chain.leave()
+
+	return j.timeClaim("iat")
+}
+
+// NotBefore returns a new DateTime instance with "nbf" claim.
+//
+// If token doesn't have "nbf" claim or it's not a number, failure
+// is reported.
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.NotBefore().Le(time.Now())
+func (j *JWT) NotBefore() *DateTime {
+	j.chain.enter("NotBefore()")
+	defer j.chain.leave()
+
+	return j.timeClaim("nbf")
+}
+
+// Expired succeeds if token has "exp" claim and it's not after current time.
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.Expired()
+func (j *JWT) Expired() *JWT {
+	j.chain.enter("Expired()")
+	defer j.chain.leave()
+
+	if j.chain.failed() {
+		return j
+	}
+
+	exp, ok := j.parseTimeClaim("exp")
+	if !ok {
+		return j
+	}
+
+	if now := time.Now(); exp.After(now) {
+		j.chain.fail(AssertionFailure{
+			Type:     AssertLe,
+			Actual:   &AssertionValue{exp},
+			Expected: &AssertionValue{now},
+			Errors: []error{
+				errors.New("expected: token is expired"),
+			},
+		})
+	}
+
+	return j
+}
+
+// NotExpired succeeds if token is valid at current time, i.e.:
+//   - "exp" claim is absent or is after current time
+//   - "nbf" claim is absent or is not after current time
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.NotExpired()
+func (j *JWT) NotExpired() *JWT {
+	j.chain.enter("NotExpired()")
+	defer j.chain.leave()
+
+	if j.chain.failed() {
+		return j
+	}
+
+	now := time.Now()
+
+	if _, ok := j.claims["exp"]; ok {
+		exp, ok := j.parseTimeClaim("exp")
+		if !ok {
+			return j
+		}
+
+		if !exp.After(now) {
+			j.chain.fail(AssertionFailure{
+				Type:     AssertGt,
+				Actual:   &AssertionValue{exp},
+				Expected: &AssertionValue{now},
+				Errors: []error{
+					errors.New("expected: token is not expired"),
+				},
+			})
+			return j
+		}
+	}
+
+	if _, ok := j.claims["nbf"]; ok {
+		nbf, ok := j.parseTimeClaim("nbf")
+		if !ok {
+			return j
+		}
+
+		if nbf.After(now) {
+			j.chain.fail(AssertionFailure{
+				Type:     AssertLe,
+				Actual:   &AssertionValue{nbf},
+				Expected: &AssertionValue{now},
+				Errors: []error{
+					errors.New("expected: token is already valid (nbf)"),
+				},
+			})
+			return j
+		}
+	}
+
+	return j
+}
+
+func (j *JWT) timeClaim(name string) *DateTime {
+	if j.chain.failed() {
+		return newDateTime(j.chain, time.Unix(0, 0))
+	}
+
+	tm, ok := j.parseTimeClaim(name)
+	if !ok {
+		return newDateTime(j.chain, time.Unix(0, 0))
+	}
+
+	return newDateTime(j.chain, tm)
+}
+
+func (j *JWT) parseTimeClaim(name string) (time.Time, bool) {
+	value, ok := j.claims[name]
+	if !ok {
+		j.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{j.claims},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: token has %q claim", name),
+			},
+		})
+		return time.Time{}, false
+	}
+
+	var secs float64
+
+	switch v := value.(type) {
+	case float64:
+		secs = v
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			ok = false
+		}
+		secs = f
+	default:
+		ok = false
+	}
+
+	if !ok {
+		j.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q claim is numeric date", name),
+			},
+		})
+		return time.Time{}, false
+	}
+
+	whole := int64(secs)
+	frac := int64((secs - float64(whole)) * float64(time.Second))
+
+	return time.Unix(whole, frac), true
+}
+
+// Verify succeeds if token signature is valid for given key.
+//
+// Signature algorithm is taken from "alg" header field. Supported
+// algorithms and corresponding key types are:
+//   - HS256, HS384, HS512: []byte or string (shared secret)
+//   - RS256, RS384, RS512, PS256, PS384, PS512: *rsa.PublicKey
+//   - ES256, ES384, ES512: *ecdsa.PublicKey
+//   - EdDSA: ed25519.PublicKey
+//
+// Private keys and *x509.Certificate are accepted as well; their public
+// keys are used. Unsigned tokens ("alg": "none") never pass verification.
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.Verify([]byte("secret"))
+//
+//	token := NewJWT(t, str)
+//	token.Verify(&privateKey.PublicKey)
+func (j *JWT) Verify(key interface{}) *JWT {
+	j.chain.enter("Verify()")
+	defer j.chain.leave()
+
+	if j.chain.failed() {
+		return j
+	}
+
+	if key == nil {
+		j.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil key argument"),
+			},
+		})
+		return j
+	}
+
+	if err := j.verify(key); err != nil {
+		j.failToken("expected: token signature is valid", err)
+	}
+
+	return j
+}
+
+// VerifyJWKS succeeds if token signature is valid for one of the keys from
+// JSON Web Key Set, downloaded from given URL.
+//
+// If token header has "kid" field, only the key with the same identifier
+// is used. Otherwise, all keys are tried.
+//
+// JWKS is fetched using http.DefaultClient, independently of client used
+// by Expect.
+//
+// Example:
+//
+//	token := NewJWT(t, str)
+//	token.VerifyJWKS("https://auth.example.com/.well-known/jwks.json")
+func (j *JWT) VerifyJWKS(url string) *JWT {
+	j.chain.enter("VerifyJWKS(%q)", url)
+	defer j.chain.leave()
+
+	if j.chain.failed() {
+		return j
+	}
+
+	keys, err := fetchJWKS(url)
+	if err != nil {
+		j.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to fetch JWKS"),
+				err,
+			},
+		})
+		return j
+	}
+
+	kid, _ := j.header["kid"].(string)
+
+	var lastErr error = errors.New("no matching keys in JWKS")
+
+	for _, jwk := range keys {
+		if kid != "" && jwk.Kid != kid {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if err := j.verify(key); err != nil {
+			lastErr = err
+			continue
+		}
+
+		return j
+	}
+
+	j.failToken("expected: token signature is valid for JWKS", lastErr)
+
+	return j
+}
+
+func (j *JWT) verify(key interface{}) error {
+	switch k := key.(type) {
+	case *x509.Certificate:
+		key = k.PublicKey
+	case interface{ Public() crypto.PublicKey }:
+		key = k.Public()
+	case string:
+		key = []byte(k)
+	}
+
+	alg, _ := j.header["alg"].(string)
+
+	keyMismatch := func() error {
+		return fmt.Errorf("key of type %T can't be used with algorithm %q", key, alg)
+	}
+
+	hashFn := func(h crypto.Hash) []byte {
+		hasher := h.New()
+		_, _ = hasher.Write(j.signed)
+		return hasher.Sum(nil)
+	}
+
+	switch alg {
+	case "HS256", "HS384", "HS512":
+		secret, ok := key.([]byte)
+		if !ok {
+			return keyMismatch()
+		}
+		mac := hmac.New(jwtHash(alg).New, secret)
+		_, _ = mac.Write(j.signed)
+		if !hmac.Equal(mac.Sum(nil), j.sig) {
+			return errors.New("signature mismatch")
+		}
+
+	case "RS256", "RS384", "RS512":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return keyMismatch()
+		}
+		h := jwtHash(alg)
+		return rsa.VerifyPKCS1v15(pub, h, hashFn(h), j.sig)
+
+	case "PS256", "PS384", "PS512":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return keyMismatch()
+		}
+		h := jwtHash(alg)
+		return rsa.VerifyPSS(pub, h, hashFn(h), j.sig, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthAuto,
+		})
+
+	case "ES256", "ES384", "ES512":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return keyMismatch()
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(j.sig) != 2*size {
+			return errors.New("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(j.sig[:size])
+		s := new(big.Int).SetBytes(j.sig[size:])
+		if !ecdsa.Verify(pub, hashFn(jwtHash(alg)), r, s) {
+			return errors.New("signature mismatch")
+		}
+
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return keyMismatch()
+		}
+		if !ed25519.Verify(pub, j.signed, j.sig) {
+			return errors.New("signature mismatch")
+		}
+
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	return nil
+}
+
+func jwtHash(alg string) crypto.Hash {
+	switch alg[2:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+// JSON Web Key, as defined in RFC 7517.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+func fetchJWKS(url string) ([]jwk, error) {
+	resp, err := http.Get(url) // #nosec G107
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	return jwks.Keys, nil
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	field := func(name, value string) (*big.Int, error) {
+		b, err := decodeBase64URL(value)
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("invalid %q field of JWK %q", name, k.Kid)
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := field("n", k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := field("e", k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q of JWK %q", k.Crv, k.Kid)
+		}
+		x, err := field("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := field("y", k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q of JWK %q", k.Crv, k.Kid)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid \"x\" field of JWK %q", k.Kid)
+		}
+		return ed25519.PublicKey(x), nil
+
+	case "oct":
+		secret, err := decodeBase64URL(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("invalid \"k\" field of JWK %q", k.Kid)
+		}
+		return secret, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q of JWK %q", k.Kty, k.Kid)
+}
@@ -0,0 +1,392 @@
+package httpexpect
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestJWT(
+	t *testing.T, header, claims map[string]interface{}, key interface{},
+) string {
+	enc := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	signed := enc(header) + "." + enc(claims)
+
+	alg := header["alg"].(string)
+
+	digest := func(h crypto.Hash) []byte {
+		hasher := h.New()
+		_, _ = hasher.Write([]byte(signed))
+		return hasher.Sum(nil)
+	}
+
+	var sig []byte
+	var err error
+
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(jwtHash(alg).New, k)
+		_, _ = mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		if alg[0] == 'P' {
+			sig, err = rsa.SignPSS(rand.Reader, k, jwtHash(alg), digest(jwtHash(alg)),
+				&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			sig, err = rsa.SignPKCS1v15(rand.Reader, k, jwtHash(alg),
+				digest(jwtHash(alg)))
+		}
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest(jwtHash(alg)))
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(sig[size-len(rb):size], rb)
+		copy(sig[2*size-len(sb):], sb)
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(signed))
+	}
+	require.NoError(t, err)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWTFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newJWT(chain, "a.b.c")
+
+	value.chain.assertFailed(t)
+
+	value.Header().chain.assertFailed(t)
+	value.Claims().chain.assertFailed(t)
+	value.ExpiresAt().chain.assertFailed(t)
+	value.IssuedAt().chain.assertFailed(t)
+	value.NotBefore().chain.assertFailed(t)
+	value.Expired()
+	value.NotExpired()
+	value.Verify([]byte("secret"))
+	value.VerifyJWKS("http://example.com")
+}
+
+func TestJWTParse(t *testing.T) {
+	token := makeTestJWT(t,
+		map[string]interface{}{"alg": "HS256", "typ": "JWT"},
+		map[string]interface{}{"sub": "john", "admin": true},
+		[]byte("secret"))
+
+	t.Run("valid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, token)
+		value.chain.assertNotFailed(t)
+
+		assert.Equal(t, token, value.Raw())
+
+		value.Header().Value("alg").String().Equal("HS256")
+		value.Claims().Value("sub").String().Equal("john")
+		value.Claims().Value("admin").Boolean().True()
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		for _, str := range []string{token, "Bearer " + token, "bearer  " + token} {
+			reporter := newMockReporter(t)
+
+			value := NewString(reporter, str).AsJWT()
+			value.chain.assertNotFailed(t)
+
+			assert.Equal(t, token, value.Raw())
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		b64 := func(s string) string {
+			return base64.RawURLEncoding.EncodeToString([]byte(s))
+		}
+
+		cases := map[string]string{
+			"empty":          "",
+			"two parts":      "a.b",
+			"jwe":            "a.b.c.d.e",
+			"bad header b64": "!!!." + b64(`{}`) + ".",
+			"bad header":     b64(`{"alg"`) + "." + b64(`{}`) + ".",
+			"no alg":         b64(`{"typ":"JWT"}`) + "." + b64(`{}`) + ".",
+			"claims array":   b64(`{"alg":"HS256"}`) + "." + b64(`[1]`) + ".",
+			"bad signature":  b64(`{"alg":"HS256"}`) + "." + b64(`{}`) + ".!!!",
+		}
+
+		for name, str := range cases {
+			t.Run(name, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				value := NewJWT(reporter, str)
+				value.chain.assertFailed(t)
+
+				value.Claims().chain.assertFailed(t)
+			})
+		}
+	})
+}
+
+func TestJWTTimes(t *testing.T) {
+	now := time.Now()
+
+	hs := map[string]interface{}{"alg": "HS256"}
+	key := []byte("secret")
+
+	t.Run("valid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t, hs, map[string]interface{}{
+			"iat": now.Add(-time.Minute).Unix(),
+			"nbf": now.Add(-time.Minute).Unix(),
+			"exp": now.Add(time.Hour).Unix(),
+		}, key))
+
+		value.ExpiresAt().Within(now.Add(time.Hour), time.Second)
+		value.IssuedAt().Within(now.Add(-time.Minute), time.Second)
+		value.NotBefore().Within(now.Add(-time.Minute), time.Second)
+		value.chain.assertNotFailed(t)
+
+		value.NotExpired()
+		value.chain.assertNotFailed(t)
+
+		value.Expired()
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t, hs, map[string]interface{}{
+			"exp": now.Add(-time.Hour).Unix(),
+		}, key))
+
+		value.Expired()
+		value.chain.assertNotFailed(t)
+
+		value.NotExpired()
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("not yet valid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t, hs, map[string]interface{}{
+			"nbf": now.Add(time.Hour).Unix(),
+		}, key))
+
+		value.NotExpired()
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("no claims", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t, hs, map[string]interface{}{}, key))
+
+		value.NotExpired()
+		value.chain.assertNotFailed(t)
+
+		value.ExpiresAt().chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.Expired()
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("invalid claim", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t, hs, map[string]interface{}{
+			"exp": "tomorrow",
+		}, key))
+
+		value.NotExpired()
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestJWTVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cases := []struct {
+		alg      string
+		signKey  interface{}
+		key      interface{}
+		wrongKey interface{}
+	}{
+		{"HS256", []byte("secret"), []byte("secret"), []byte("other")},
+		{"HS384", []byte("secret"), "secret", []byte("other")},
+		{"HS512", []byte("secret"), []byte("secret"), &rsaKey.PublicKey},
+		{"RS256", rsaKey, &rsaKey.PublicKey, &otherRSAKey.PublicKey},
+		{"RS512", rsaKey, rsaKey, []byte("secret")},
+		{"PS256", rsaKey, &rsaKey.PublicKey, &otherRSAKey.PublicKey},
+		{"ES256", ecKey, &ecKey.PublicKey, &rsaKey.PublicKey},
+		{"EdDSA", edKey, edPub, &ecKey.PublicKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.alg, func(t *testing.T) {
+			token := makeTestJWT(t,
+				map[string]interface{}{"alg": tc.alg},
+				map[string]interface{}{"sub": "john"},
+				tc.signKey)
+
+			reporter := newMockReporter(t)
+
+			value := NewJWT(reporter, token)
+
+			value.Verify(tc.key)
+			value.chain.assertNotFailed(t)
+
+			value.Verify(tc.wrongKey)
+			value.chain.assertFailed(t)
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t,
+			map[string]interface{}{"alg": "none"},
+			map[string]interface{}{}, nil))
+
+		value.Verify([]byte(""))
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("nil key", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{}, []byte("secret")))
+
+		value.Verify(nil)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestJWTVerifyJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	b64 := func(b []byte) string {
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/jwks.json" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []interface{}{
+					map[string]interface{}{
+						"kty": "oct",
+						"kid": "hmac",
+						"k":   b64([]byte("secret")),
+					},
+					map[string]interface{}{
+						"kty": "RSA",
+						"kid": "rsa",
+						"n":   b64(rsaKey.N.Bytes()),
+						"e":   b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+					},
+				},
+			})
+		}))
+	defer server.Close()
+
+	jwksURL := server.URL + "/jwks.json"
+
+	t.Run("kid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t,
+			map[string]interface{}{"alg": "RS256", "kid": "rsa"},
+			map[string]interface{}{}, rsaKey))
+
+		value.VerifyJWKS(jwksURL)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("no kid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t,
+			map[string]interface{}{"alg": "RS256"},
+			map[string]interface{}{}, rsaKey))
+
+		value.VerifyJWKS(jwksURL)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("wrong kid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t,
+			map[string]interface{}{"alg": "HS256", "kid": "rsa"},
+			map[string]interface{}{}, []byte("secret")))
+
+		value.VerifyJWKS(jwksURL)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{}, []byte("other")))
+
+		value.VerifyJWKS(jwksURL)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, makeTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{}, []byte("secret")))
+
+		value.VerifyJWKS(server.URL + "/missing.json")
+		value.chain.assertFailed(t)
+	})
+}
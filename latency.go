@@ -0,0 +1,299 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Latency collects round-trip times of multiple responses and provides
+// methods to inspect their statistics, e.g. percentiles.
+//
+// It is useful for SLO-style assertions over repeated requests.
+// Latency is safe for concurrent use, so it may be shared between
+// parallel tests.
+//
+// Example:
+//
+//	lat := e.Latency()
+//
+//	for i := 0; i < 100; i++ {
+//	    lat.Add(e.GET("/path").Expect())
+//	}
+//
+//	lat.Percentile(95).Lt(100 * time.Millisecond)
+//	lat.Max().Lt(time.Second)
+type Latency struct {
+	chain *chain
+
+	mu     sync.Mutex
+	values []time.Duration
+}
+
+// NewLatency returns a new empty Latency instance.
+//
+// reporter should not be nil.
+//
+// Example:
+//
+//	lat := NewLatency(t)
+//	lat.AddDuration(10 * time.Millisecond)
+func NewLatency(reporter Reporter) *Latency {
+	return newLatency(newChainWithDefaults("Latency()", reporter))
+}
+
+func newLatency(parent *chain) *Latency {
+	return &Latency{chain: parent.clone()}
+}
+
+// Latency returns a new empty Latency instance.
+//
+// Example:
+//
+//	lat := e.Latency()
+//	lat.Add(e.GET("/path").Expect())
+func (e *Expect) Latency() *Latency {
+	e.chain.enter("Latency()")
+	defer e.chain.leave()
+
+	return newLatency(e.chain)
+}
+
+// Raw returns a copy of collected durations, in order they were added.
+func (l *Latency) Raw() []time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]time.Duration(nil), l.values...)
+}
+
+// Add appends round-trip time of given response.
+//
+// If response has no round-trip time, failure is reported.
+//
+// Example:
+//
+//	lat.Add(e.GET("/path").Expect())
+func (l *Latency) Add(resp *Response) *Latency {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("Add()")
+	defer l.chain.leave()
+
+	if l.chain.failed() {
+		return l
+	}
+
+	if resp == nil {
+		l.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil response argument"),
+			},
+		})
+		return l
+	}
+
+	if resp.rtt == nil {
+		l.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{resp.rtt},
+			Errors: []error{
+				errors.New("expected: response has round-trip time"),
+			},
+		})
+		return l
+	}
+
+	l.values = append(l.values, *resp.rtt)
+
+	return l
+}
+
+// AddDuration appends given duration.
+//
+// Example:
+//
+//	lat.AddDuration(10 * time.Millisecond)
+func (l *Latency) AddDuration(d time.Duration) *Latency {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("AddDuration()")
+	defer l.chain.leave()
+
+	if l.chain.failed() {
+		return l
+	}
+
+	l.values = append(l.values, d)
+
+	return l
+}
+
+// Count returns a new Number instance with number of collected durations.
+//
+// Example:
+//
+//	lat.Count().Equal(100)
+func (l *Latency) Count() *Number {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("Count()")
+	defer l.chain.leave()
+
+	if l.chain.failed() {
+		return newNumber(l.chain, 0)
+	}
+
+	return newNumber(l.chain, float64(len(l.values)))
+}
+
+// Min returns a new Duration instance with minimum duration.
+//
+// Example:
+//
+//	lat.Min().Gt(time.Millisecond)
+func (l *Latency) Min() *Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("Min()")
+	defer l.chain.leave()
+
+	return l.stat(func(sorted []time.Duration) time.Duration {
+		return sorted[0]
+	})
+}
+
+// Max returns a new Duration instance with maximum duration.
+//
+// Example:
+//
+//	lat.Max().Lt(time.Second)
+func (l *Latency) Max() *Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("Max()")
+	defer l.chain.leave()
+
+	return l.stat(func(sorted []time.Duration) time.Duration {
+		return sorted[len(sorted)-1]
+	})
+}
+
+// Mean returns a new Duration instance with arithmetic mean of durations.
+//
+// Example:
+//
+//	lat.Mean().Lt(50 * time.Millisecond)
+func (l *Latency) Mean() *Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("Mean()")
+	defer l.chain.leave()
+
+	return l.stat(func(sorted []time.Duration) time.Duration {
+		var sum float64
+		for _, d := range sorted {
+			sum += float64(d)
+		}
+		return time.Duration(sum / float64(len(sorted)))
+	})
+}
+
+// Median returns a new Duration instance with median duration.
+// It's the same as Percentile(50).
+//
+// Example:
+//
+//	lat.Median().Lt(50 * time.Millisecond)
+func (l *Latency) Median() *Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("Median()")
+	defer l.chain.leave()
+
+	return l.stat(func(sorted []time.Duration) time.Duration {
+		return percentile(sorted, 50)
+	})
+}
+
+// Percentile returns a new Duration instance with given percentile of
+// durations, computed using nearest-rank method.
+//
+// p should be in range (0; 100].
+//
+// Example:
+//
+//	lat.Percentile(99).Lt(200 * time.Millisecond)
+func (l *Latency) Percentile(p float64) *Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.chain.enter("Percentile(%v)", p)
+	defer l.chain.leave()
+
+	if l.chain.failed() {
+		return newDuration(l.chain, nil)
+	}
+
+	if !(p > 0 && p <= 100) {
+		l.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected percentile %v, should be in range (0; 100]", p),
+			},
+		})
+		return newDuration(l.chain, nil)
+	}
+
+	return l.stat(func(sorted []time.Duration) time.Duration {
+		return percentile(sorted, p)
+	})
+}
+
+func (l *Latency) stat(fn func(sorted []time.Duration) time.Duration) *Duration {
+	if l.chain.failed() {
+		return newDuration(l.chain, nil)
+	}
+
+	if len(l.values) == 0 {
+		l.chain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{l.values},
+			Errors: []error{
+				errors.New("expected: at least one duration collected"),
+			},
+		})
+		return newDuration(l.chain, nil)
+	}
+
+	sorted := append([]time.Duration(nil), l.values...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	d := fn(sorted)
+
+	return newDuration(l.chain, &d)
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
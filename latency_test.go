@@ -0,0 +1,121 @@
+package httpexpect
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	lat := newLatency(chain)
+
+	lat.Add(nil)
+	lat.AddDuration(time.Second)
+
+	assert.NotNil(t, lat.Count())
+	assert.NotNil(t, lat.Min())
+	assert.NotNil(t, lat.Max())
+	assert.NotNil(t, lat.Mean())
+	assert.NotNil(t, lat.Median())
+	assert.NotNil(t, lat.Percentile(50))
+	assert.Empty(t, lat.Raw())
+
+	lat.chain.assertFailed(t)
+}
+
+func TestLatencyStats(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	lat := NewLatency(reporter)
+
+	for i := 10; i >= 1; i-- {
+		lat.AddDuration(time.Duration(i) * time.Millisecond)
+	}
+
+	lat.Count().Equal(10)
+
+	lat.Min().Equal(1 * time.Millisecond)
+	lat.Max().Equal(10 * time.Millisecond)
+	lat.Mean().Equal(5500 * time.Microsecond)
+	lat.Median().Equal(5 * time.Millisecond)
+
+	lat.Percentile(90).Equal(9 * time.Millisecond)
+	lat.Percentile(95).Equal(10 * time.Millisecond)
+	lat.Percentile(100).Equal(10 * time.Millisecond)
+	lat.Percentile(0.1).Equal(1 * time.Millisecond)
+
+	lat.chain.assertNotFailed(t)
+
+	assert.Equal(t, 10*time.Millisecond, lat.Raw()[0])
+
+	lat.Percentile(95).Lt(5 * time.Millisecond)
+	lat.chain.assertNotFailed(t)
+}
+
+func TestLatencyConcurrent(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	lat := NewLatency(reporter)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lat.AddDuration(time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	lat.Count().Equal(10)
+	lat.chain.assertNotFailed(t)
+}
+
+func TestLatencyResponses(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		Client:   &mockClient{resp: http.Response{StatusCode: http.StatusOK}},
+		Reporter: reporter,
+	})
+
+	lat := e.Latency()
+
+	for i := 0; i < 3; i++ {
+		lat.Add(e.GET("/path").Expect())
+	}
+
+	lat.Count().Equal(3)
+	lat.Percentile(99).Lt(time.Minute)
+	lat.chain.assertNotFailed(t)
+
+	lat.Add(NewResponse(reporter, &http.Response{}))
+	lat.chain.assertFailed(t)
+}
+
+func TestLatencyErrors(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		lat := NewLatency(newMockReporter(t))
+		lat.Median()
+		lat.chain.assertFailed(t)
+	})
+
+	t.Run("nil_response", func(t *testing.T) {
+		lat := NewLatency(newMockReporter(t))
+		lat.Add(nil)
+		lat.chain.assertFailed(t)
+	})
+
+	for _, p := range []float64{0, -1, 101} {
+		lat := NewLatency(newMockReporter(t))
+		lat.AddDuration(time.Second)
+		lat.Percentile(p)
+		lat.chain.assertFailed(t)
+	}
+}
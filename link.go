@@ -0,0 +1,190 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Links returns a new Array instance with links from Link headers of
+// response (RFC 8288).
+//
+// Every element is an Object with "url" key, containing link target
+// resolved against request URL, and keys for link parameters, e.g. "rel"
+// or "title". Parameter names are converted to lower case.
+//
+// If response has no Link headers, empty array is returned.
+// If Link header is malformed, method fails.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Links().Length().Equal(2)
+//	resp.Links().Element(0).Object().ValueEqual("rel", "next")
+func (r *Response) Links() *Array {
+	r.chain.enter("Links()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	links, ok := r.getLinks()
+	if !ok {
+		return newArray(r.chain, nil)
+	}
+
+	result := []interface{}{}
+
+	for _, link := range links {
+		target, ok := r.resolveLink(link.url)
+		if !ok {
+			return newArray(r.chain, nil)
+		}
+
+		obj := map[string]interface{}{}
+		for name, value := range link.params {
+			obj[name] = value
+		}
+		obj["url"] = target.String()
+
+		result = append(result, obj)
+	}
+
+	return newArray(r.chain, result)
+}
+
+// Link returns a new String instance with URL of link with given relation
+// type from Link headers of response (RFC 8288), e.g. "next" or "self".
+//
+// Relation type is case-insensitive. Relative URLs are resolved against
+// request URL. If there are several links with given relation type, the
+// first one is used.
+//
+// If response has no such link, method fails.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Link("next").Equal("https://example.com/items?page=2")
+func (r *Response) Link(rel string) *String {
+	r.chain.enter("Link(%q)", rel)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newString(r.chain, "")
+	}
+
+	link := r.findLink(rel)
+	if r.chain.failed() {
+		return newString(r.chain, "")
+	}
+
+	target, ok := r.resolveLink(link)
+	if !ok {
+		return newString(r.chain, "")
+	}
+
+	return newString(r.chain, target.String())
+}
+
+// Follow returns a new GET Request to URL of link with given relation type
+// from Link headers of response (RFC 8288). See Link for details.
+//
+// Request inherits config of request that produced this response, so it
+// uses the same client, reporter, printers, and so on.
+//
+// If response has no such link, method fails.
+//
+// Example:
+//
+//	resp := e.GET("/orders/1").Expect()
+//	resp.Follow("customer").Expect().Status(http.StatusOK)
+func (r *Response) Follow(rel string) *Request {
+	r.chain.enter("Follow(%q)", rel)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newRequest(r.chain, r.config, http.MethodGet, "")
+	}
+
+	link := r.findLink(rel)
+	if r.chain.failed() {
+		return newRequest(r.chain, r.config, http.MethodGet, "")
+	}
+
+	target, ok := r.resolveLink(link)
+	if !ok {
+		return newRequest(r.chain, r.config, http.MethodGet, "")
+	}
+
+	req := newRequest(r.chain, r.config, http.MethodGet, "")
+	req.httpReq.URL = target
+	req.pathTemplate = target.Path
+
+	return req
+}
+
+// Find link with given relation type and return its resolved URL.
+// Returns found=false if there is no such link, and ok=false if
+// failure was reported.
+func (r *Response) lookupLink(rel string) (target *url.URL, found bool, ok bool) {
+	links, ok := r.getLinks()
+	if !ok {
+		return nil, false, false
+	}
+
+	for _, link := range links {
+		if link.hasRel(rel) {
+			target, ok := r.resolveLink(link.url)
+			return target, ok, ok
+		}
+	}
+
+	return nil, false, true
+}
+
+func (r *Response) getLinks() ([]headerLink, bool) {
+	var links []headerLink
+
+	for _, value := range r.httpResp.Header.Values("Link") {
+		l, err := parseLinkHeader(value)
+		if err != nil {
+			r.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					errors.New(`invalid "Link" response header`),
+					err,
+				},
+			})
+			return nil, false
+		}
+
+		links = append(links, l...)
+	}
+
+	return links, true
+}
+
+// Parse link target and resolve it against request URL.
+func (r *Response) resolveLink(link string) (*url.URL, bool) {
+	target, err := url.Parse(link)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{link},
+			Errors: []error{
+				errors.New("expected: valid URL in Link header"),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	if req := r.httpResp.Request; req != nil && req.URL != nil {
+		target = req.URL.ResolveReference(target)
+	}
+
+	return target, true
+}
@@ -0,0 +1,121 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newLinkResponse(t *testing.T, links ...string) (*Response, *mockReporter) {
+	reporter := newMockReporter(t)
+
+	req, err := http.NewRequest("GET", "http://example.com/orders/1?x=y", nil)
+	assert.NoError(t, err)
+
+	resp := NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Link": links},
+		Request:    req,
+	})
+
+	return resp, reporter
+}
+
+func TestLinkLinks(t *testing.T) {
+	t.Run("links", func(t *testing.T) {
+		resp, _ := newLinkResponse(t,
+			`</customers/7>; rel="customer"; title="Customer", <../orders?page=2>; rel=next`,
+			`<https://cdn.example.com/style.css>; rel=preload; nopush`,
+		)
+
+		resp.Links().Equal([]interface{}{
+			map[string]interface{}{
+				"url":   "http://example.com/customers/7",
+				"rel":   "customer",
+				"title": "Customer",
+			},
+			map[string]interface{}{
+				"url": "http://example.com/orders?page=2",
+				"rel": "next",
+			},
+			map[string]interface{}{
+				"url":    "https://cdn.example.com/style.css",
+				"rel":    "preload",
+				"nopush": true,
+			},
+		})
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		resp, _ := newLinkResponse(t)
+
+		resp.Links().Empty()
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp, reporter := newLinkResponse(t, `/customers/7; rel=customer`)
+
+		resp.Links()
+		resp.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+}
+
+func TestLinkLink(t *testing.T) {
+	resp, _ := newLinkResponse(t,
+		`</customers/7>; rel="customer"`,
+		`<https://example.com/orders?page=2>; rel="next last"`,
+	)
+
+	resp.Link("customer").Equal("http://example.com/customers/7")
+	resp.Link("NEXT").Equal("https://example.com/orders?page=2")
+	resp.Link("last").Equal("https://example.com/orders?page=2")
+	resp.chain.assertNotFailed(t)
+
+	resp.Link("prev")
+	resp.chain.assertFailed(t)
+}
+
+func TestLinkFollow(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orders/1":
+			w.Header().Add("Link", `</customers/7>; rel="customer"`)
+			w.Header().Add("Link", `<http://example.com/orders/1>; rel="self"`)
+		case "/customers/7":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name": "John"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Client:   &http.Client{Transport: NewBinder(handler)},
+		Reporter: reporter,
+	})
+
+	resp := e.GET("/orders/1").Expect()
+
+	resp.Follow("customer").Expect().
+		Status(http.StatusOK).
+		JSON().Object().ValueEqual("name", "John")
+
+	resp.Follow("self").Expect().
+		Status(http.StatusOK).
+		Link("customer").Equal("http://example.com/customers/7")
+
+	resp.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+
+	req := resp.Follow("missing")
+	req.chain.assertFailed(t)
+	resp.chain.assertFailed(t)
+	assert.True(t, reporter.reported)
+}
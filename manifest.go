@@ -0,0 +1,377 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Manifest records what a test run actually covered: endpoints that
+// received responses, schemas used for validation, files read by
+// assertions, and enabled library features.
+//
+// Manifest is filled automatically by Expect instances which share it via
+// Config.Manifest, and can be written as JSON at the end of the run, e.g.
+// to prove to compliance pipelines what the E2E suite exercised.
+//
+// Manifest is safe for concurrent use.
+//
+// Example:
+//
+//	var manifest = httpexpect.NewManifest()
+//
+//	func TestMain(m *testing.M) {
+//	    code := m.Run()
+//	    if err := manifest.WriteFile("manifest.json"); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    os.Exit(code)
+//	}
+//
+//	func TestSomething(t *testing.T) {
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        Reporter: httpexpect.NewAssertReporter(t),
+//	        Manifest: manifest,
+//	    })
+//	}
+type Manifest struct {
+	mu        sync.Mutex
+	started   time.Time
+	features  map[string]bool
+	endpoints map[manifestEndpointKey]*ManifestEndpoint
+	schemas   map[manifestSchemaKey]*ManifestSchema
+	files     map[ManifestFile]bool
+}
+
+// ManifestEndpoint describes endpoint that received at least one response.
+type ManifestEndpoint struct {
+	// Request method.
+	Method string `json:"method"`
+
+	// Request host.
+	Host string `json:"host"`
+
+	// Request path template, as passed to Expect.Request, before
+	// substitution of path parameters.
+	Path string `json:"path"`
+
+	// Number of received responses.
+	Calls int `json:"calls"`
+
+	// Number of received responses per status code.
+	Statuses map[string]int `json:"statuses"`
+}
+
+// ManifestSchema describes schema that was used for validation.
+type ManifestSchema struct {
+	// Schema kind: "json" for JSON Schema, "xsd" for XML Schema.
+	Kind string `json:"kind"`
+
+	// Schema location (URL or file path), or "inline" if schema was
+	// passed as value.
+	Source string `json:"source"`
+
+	// Schema identifier: "$id" of JSON Schema, or targetNamespace of
+	// XML Schema. Empty if not specified by schema.
+	ID string `json:"id,omitempty"`
+
+	// Schema version: "$schema" of JSON Schema (i.e. draft), or version
+	// attribute of XML Schema. Empty if not specified by schema.
+	Version string `json:"version,omitempty"`
+
+	// Number of performed validations.
+	Validations int `json:"validations"`
+}
+
+// ManifestFile describes file read or written by assertions.
+type ManifestFile struct {
+	// File kind: "json-schema", "xsd", or "baseline".
+	Kind string `json:"kind"`
+
+	// File path or URL.
+	Path string `json:"path"`
+}
+
+type manifestEndpointKey struct {
+	method string
+	host   string
+	path   string
+}
+
+type manifestSchemaKey struct {
+	kind    string
+	source  string
+	id      string
+	version string
+}
+
+type manifestData struct {
+	Started   time.Time          `json:"started"`
+	Finished  time.Time          `json:"finished"`
+	Versions  map[string]string  `json:"versions"`
+	Features  []string           `json:"features"`
+	Endpoints []ManifestEndpoint `json:"endpoints"`
+	Schemas   []ManifestSchema   `json:"schemas"`
+	Files     []ManifestFile     `json:"files"`
+}
+
+// NewManifest returns a new empty Manifest instance.
+func NewManifest() *Manifest {
+	return &Manifest{
+		started:   time.Now(),
+		features:  map[string]bool{},
+		endpoints: map[manifestEndpointKey]*ManifestEndpoint{},
+		schemas:   map[manifestSchemaKey]*ManifestSchema{},
+		files:     map[ManifestFile]bool{},
+	}
+}
+
+// Features returns sorted list of library features enabled by config of
+// any Expect instance using this manifest, e.g. "StrictDecoding" or
+// "RateLimit". Features are named after Config fields.
+func (m *Manifest) Features() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	features := make([]string, 0, len(m.features))
+	for name := range m.features {
+		features = append(features, name)
+	}
+	sort.Strings(features)
+
+	return features
+}
+
+// Endpoints returns endpoints that received responses, sorted by host,
+// path, and method.
+func (m *Manifest) Endpoints() []ManifestEndpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endpoints := make([]ManifestEndpoint, 0, len(m.endpoints))
+	for _, ep := range m.endpoints {
+		cp := *ep
+		cp.Statuses = make(map[string]int, len(ep.Statuses))
+		for status, n := range ep.Statuses {
+			cp.Statuses[status] = n
+		}
+		endpoints = append(endpoints, cp)
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		a, b := endpoints[i], endpoints[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+
+	return endpoints
+}
+
+// Schemas returns schemas used for validation, sorted by kind and source.
+func (m *Manifest) Schemas() []ManifestSchema {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	schemas := make([]ManifestSchema, 0, len(m.schemas))
+	for _, s := range m.schemas {
+		schemas = append(schemas, *s)
+	}
+
+	sort.Slice(schemas, func(i, j int) bool {
+		a, b := schemas[i], schemas[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Version < b.Version
+	})
+
+	return schemas
+}
+
+// Files returns files used by assertions, sorted by kind and path.
+func (m *Manifest) Files() []ManifestFile {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	files := make([]ManifestFile, 0, len(m.files))
+	for f := range m.files {
+		files = append(files, f)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Kind != files[j].Kind {
+			return files[i].Kind < files[j].Kind
+		}
+		return files[i].Path < files[j].Path
+	})
+
+	return files
+}
+
+// WriteFile writes manifest as JSON to file with given path.
+// If file exists, it's truncated.
+func (m *Manifest) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Write writes manifest as JSON to given writer.
+//
+// Besides endpoints, schemas, files, and features, JSON contains start
+// time (when manifest was created), finish time (when Write was called),
+// and versions of Go and httpexpect.
+func (m *Manifest) Write(w io.Writer) error {
+	data := manifestData{
+		Finished:  time.Now(),
+		Versions:  manifestVersions(),
+		Features:  m.Features(),
+		Endpoints: m.Endpoints(),
+		Schemas:   m.Schemas(),
+		Files:     m.Files(),
+	}
+
+	m.mu.Lock()
+	data.Started = m.started
+	m.mu.Unlock()
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(data)
+}
+
+func (m *Manifest) addFeatures(config Config) {
+	features := map[string]bool{
+		"RequestTransformer": config.RequestTransformer != nil,
+		"JSONNumber":         config.JSONNumber,
+		"StrictDecoding":     config.StrictDecoding,
+		"Validator":          config.Validator != nil,
+		"StrictContentType":  config.StrictContentType,
+		"DisableDigestCheck": config.DisableDigestCheck,
+		"PathEscaping":       config.PathEscaping != PathEscapeDefault,
+		"RateLimit":          config.RateLimit != nil,
+		"RedactionRules":     config.RedactionRules != nil,
+		"Capture":            config.Capture != nil,
+		"OnFailure":          config.OnFailure != nil,
+		"OnSuccess":          config.OnSuccess != nil,
+		"MetricsSink":        config.MetricsSink != nil,
+		"Invariants":         config.Invariants != nil,
+		"Marshaler":          config.Marshaler != nil,
+		"Printers":           len(config.Printers) != 0,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, enabled := range features {
+		if enabled {
+			m.features[name] = true
+		}
+	}
+}
+
+func (m *Manifest) addEndpoint(method, host, path string, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := manifestEndpointKey{method: method, host: host, path: path}
+
+	ep := m.endpoints[key]
+	if ep == nil {
+		ep = &ManifestEndpoint{
+			Method:   method,
+			Host:     host,
+			Path:     path,
+			Statuses: map[string]int{},
+		}
+		m.endpoints[key] = ep
+	}
+
+	ep.Calls++
+	ep.Statuses[strconv.Itoa(status)]++
+}
+
+func (m *Manifest) addSchema(kind, source, id, version string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := manifestSchemaKey{kind: kind, source: source, id: id, version: version}
+
+	s := m.schemas[key]
+	if s == nil {
+		s = &ManifestSchema{
+			Kind:    kind,
+			Source:  source,
+			ID:      id,
+			Version: version,
+		}
+		m.schemas[key] = s
+	}
+
+	s.Validations++
+}
+
+func (m *Manifest) addFile(kind, path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.files[ManifestFile{Kind: kind, Path: path}] = true
+}
+
+func manifestVersions() map[string]string {
+	versions := map[string]string{
+		"go": runtime.Version(),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range append([]*debug.Module{&info.Main}, info.Deps...) {
+			if dep.Path == "github.com/gavv/httpexpect/v2" {
+				versions["httpexpect"] = dep.Version
+				break
+			}
+		}
+	}
+
+	return versions
+}
+
+// Manifest returns Manifest associated with Expect instance.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.GET("/users").Expect().JSON().Schema(schema)
+//
+//	for _, ep := range e.Manifest().Endpoints() {
+//	    fmt.Println(ep.Method, ep.Path, ep.Calls)
+//	}
+func (e *Expect) Manifest() *Manifest {
+	return e.config.Manifest
+}
@@ -0,0 +1,179 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestEndpoints(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/2" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	e.GET("/users/{id}", 1).Expect()
+	e.GET("/users/{id}", 2).Expect()
+	e.DELETE("/users/{id}", 1).Expect()
+	e.GET("/users/{id}").Expect() // not sent
+
+	assert.Equal(t, []ManifestEndpoint{
+		{
+			Method:   "DELETE",
+			Host:     "example.com",
+			Path:     "/users/{id}",
+			Calls:    1,
+			Statuses: map[string]int{"200": 1},
+		},
+		{
+			Method:   "GET",
+			Host:     "example.com",
+			Path:     "/users/{id}",
+			Calls:    2,
+			Statuses: map[string]int{"200": 1, "404": 1},
+		},
+	}, e.Manifest().Endpoints())
+}
+
+func TestManifestShared(t *testing.T) {
+	manifest := NewManifest()
+
+	e1 := WithConfig(Config{
+		Reporter: newMockReporter(t),
+		Manifest: manifest,
+	})
+
+	e2 := WithConfig(Config{
+		Reporter:       newMockReporter(t),
+		Manifest:       manifest,
+		StrictDecoding: true,
+		RateLimit:      &RateLimit{PerSecond: 10},
+	})
+
+	assert.Same(t, manifest, e1.Manifest())
+	assert.Same(t, manifest, e2.Manifest())
+	assert.Same(t, manifest, e2.Clone().Manifest())
+
+	assert.Equal(t, []string{"RateLimit", "StrictDecoding"}, manifest.Features())
+
+	assert.True(t,
+		WithConfig(Config{Reporter: newMockReporter(t)}).Manifest() !=
+			WithConfig(Config{Reporter: newMockReporter(t)}).Manifest())
+}
+
+func TestManifestSchemas(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	schemaPath := filepath.Join(dir, "user.json")
+	require.NoError(t, ioutil.WriteFile(schemaPath, []byte(`{
+		"$id": "https://example.com/user.json",
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object"
+	}`), 0644))
+
+	xsdPath := filepath.Join(dir, "user.xsd")
+	require.NoError(t, ioutil.WriteFile(xsdPath, []byte(
+		`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"`+
+			` targetNamespace="urn:user" version="1.2">`+
+			`<xs:element name="user" type="xs:string"/>`+
+			`</xs:schema>`), 0644))
+
+	schemaURL := "file://" + filepath.ToSlash(schemaPath)
+	if runtime.GOOS == "windows" {
+		schemaURL = "file:///" + filepath.ToSlash(schemaPath)
+	}
+
+	e := WithConfig(Config{
+		Reporter: newMockReporter(t),
+	})
+
+	e.Value(map[string]interface{}{}).Schema(schemaURL)
+	e.Value(map[string]interface{}{}).Schema(schemaURL)
+	e.Value("foo").Schema(`{"type": "string"}`)
+	e.Value("foo").Schema(`{"type": `) // invalid schema is not recorded
+
+	newXML(e.chain, []byte(`<user xmlns="urn:user">john</user>`)).
+		ValidateXSD(xsdPath).
+		chain.assertNotFailed(t)
+
+	assert.Equal(t, []ManifestSchema{
+		{
+			Kind:        "json",
+			Source:      schemaURL,
+			ID:          "https://example.com/user.json",
+			Version:     "http://json-schema.org/draft-07/schema#",
+			Validations: 2,
+		},
+		{
+			Kind:        "json",
+			Source:      "inline",
+			Validations: 1,
+		},
+		{
+			Kind:        "xsd",
+			Source:      xsdPath,
+			ID:          "urn:user",
+			Version:     "1.2",
+			Validations: 1,
+		},
+	}, e.Manifest().Schemas())
+
+	assert.Equal(t, []ManifestFile{
+		{Kind: "json-schema", Path: schemaURL},
+		{Kind: "xsd", Path: xsdPath},
+	}, e.Manifest().Files())
+}
+
+func TestManifestWrite(t *testing.T) {
+	manifest := NewManifest()
+
+	manifest.addFeatures(Config{JSONNumber: true})
+	manifest.addEndpoint("GET", "example.com", "/foo", 200)
+	manifest.addSchema("json", "inline", "", "")
+	manifest.addFile("baseline", "testdata/foo.json")
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, manifest.Write(buf))
+
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &data))
+
+	assert.Equal(t, []interface{}{"JSONNumber"}, data["features"])
+	assert.Equal(t, 1, len(data["endpoints"].([]interface{})))
+	assert.Equal(t, 1, len(data["schemas"].([]interface{})))
+	assert.Equal(t, 1, len(data["files"].([]interface{})))
+	assert.Equal(t, runtime.Version(),
+		data["versions"].(map[string]interface{})["go"])
+	assert.NotEmpty(t, data["started"])
+	assert.NotEmpty(t, data["finished"])
+
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "manifest.json")
+	require.NoError(t, manifest.WriteFile(path))
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(content))
+}
@@ -0,0 +1,456 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Marshaler encodes Go values to JSON.
+//
+// Marshaler is used to canonicalize values passed to matchers (e.g. in
+// Object.Equal or Array.Contains), and to encode bodies in
+// Request.WithJSON and Websocket.WriteJSON.
+//
+// DefaultMarshaler is used by default.
+type Marshaler interface {
+	Marshal(value interface{}) ([]byte, error)
+}
+
+// DefaultMarshaler is the default Marshaler implementation.
+//
+// It uses json.Marshal, but allows to register encoders for custom types,
+// which is useful for types that you can't modify and that don't have
+// predictable JSON representation, like decimals, time wrappers, or
+// protobuf well-known types.
+//
+// Registered encoders are applied to values of given type everywhere
+// in the encoded value, including struct fields, map values, and slice
+// elements. Struct fields are encoded according to "json" struct tags,
+// the same way as json.Marshal does.
+//
+// DefaultMarshaler is safe for concurrent use. Zero value is ready
+// to use.
+type DefaultMarshaler struct {
+	mu       sync.RWMutex
+	encoders map[reflect.Type]func(interface{}) (interface{}, error)
+}
+
+// RegisterType registers encoder for type of given sample value.
+//
+// When a value of this type is encountered, encoder is invoked, and its
+// result is marshaled instead of the value. If encoder returns error,
+// marshaling fails.
+//
+// Example:
+//
+//	marshaler := &httpexpect.DefaultMarshaler{}
+//
+//	marshaler.RegisterType(decimal.Decimal{},
+//	    func(value interface{}) (interface{}, error) {
+//	        return json.Number(value.(decimal.Decimal).String()), nil
+//	    })
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    BaseURL:   "http://example.com",
+//	    Reporter:  httpexpect.NewAssertReporter(t),
+//	    Marshaler: marshaler,
+//	})
+func (m *DefaultMarshaler) RegisterType(
+	sample interface{}, encoder func(value interface{}) (interface{}, error),
+) {
+	if sample == nil {
+		panic("DefaultMarshaler.RegisterType: sample is nil")
+	}
+	if encoder == nil {
+		panic("DefaultMarshaler.RegisterType: encoder is nil")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// map is never modified after creation, so that Marshal can use it
+	// without holding the lock
+	encoders := make(map[reflect.Type]func(interface{}) (interface{}, error))
+	for typ, enc := range m.encoders {
+		encoders[typ] = enc
+	}
+	encoders[reflect.TypeOf(sample)] = encoder
+
+	m.encoders = encoders
+}
+
+// Marshal implements Marshaler.Marshal.
+func (m *DefaultMarshaler) Marshal(value interface{}) ([]byte, error) {
+	m.mu.RLock()
+	encoders := m.encoders
+	m.mu.RUnlock()
+
+	if len(encoders) == 0 || value == nil {
+		return json.Marshal(value)
+	}
+
+	r := &typeReplacer{
+		encoders: encoders,
+		affected: make(map[reflect.Type]bool),
+	}
+
+	replaced, err := r.replace(reflect.ValueOf(value))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(replaced)
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Replaces values of registered types during single Marshal call.
+type typeReplacer struct {
+	encoders map[reflect.Type]func(interface{}) (interface{}, error)
+	affected map[reflect.Type]bool
+}
+
+// Checks if values of type may contain values of registered types,
+// and thus should be traversed.
+func (m *typeReplacer) isAffected(typ reflect.Type) bool {
+	if affected, ok := m.affected[typ]; ok {
+		return affected
+	}
+
+	// break cycles of recursive types
+	m.affected[typ] = false
+
+	affected := false
+
+	if _, ok := m.encoders[typ]; ok {
+		affected = true
+	} else if typ.Implements(jsonMarshalerType) ||
+		reflect.PtrTo(typ).Implements(jsonMarshalerType) {
+		affected = false
+	} else {
+		switch typ.Kind() {
+		case reflect.Interface:
+			affected = true
+
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			affected = m.isAffected(typ.Elem())
+
+		case reflect.Struct:
+			for i := 0; i < typ.NumField(); i++ {
+				if m.isAffected(typ.Field(i).Type) {
+					affected = true
+					break
+				}
+			}
+		}
+	}
+
+	m.affected[typ] = affected
+
+	return affected
+}
+
+// Returns value in which values of registered types are replaced with
+// results of their encoders.
+func (m *typeReplacer) replace(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	typ := v.Type()
+
+	if encoder, ok := m.encoders[typ]; ok {
+		if typ.Kind() == reflect.Ptr && v.IsNil() {
+			return nil, nil
+		}
+		return encoder(v.Interface())
+	}
+
+	if !m.isAffected(typ) {
+		return v.Interface(), nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return m.replace(v.Elem())
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return m.replaceElements(v)
+
+	case reflect.Array:
+		return m.replaceElements(v)
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return m.replaceMap(v)
+
+	case reflect.Struct:
+		return m.replaceStruct(v)
+	}
+
+	return v.Interface(), nil
+}
+
+func (m *typeReplacer) replaceElements(v reflect.Value) (interface{}, error) {
+	out := make([]interface{}, v.Len())
+
+	for i := range out {
+		elem, err := m.replace(v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = elem
+	}
+
+	return out, nil
+}
+
+func (m *typeReplacer) replaceMap(v reflect.Value) (interface{}, error) {
+	// keep key type, so that keys are encoded by json.Marshal as usual
+	out := reflect.MakeMapWithSize(
+		reflect.MapOf(v.Type().Key(), reflect.TypeOf((*interface{})(nil)).Elem()),
+		v.Len())
+
+	iter := v.MapRange()
+	for iter.Next() {
+		elem, err := m.replace(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if elem == nil {
+			out.SetMapIndex(iter.Key(), reflect.Zero(out.Type().Elem()))
+		} else {
+			out.SetMapIndex(iter.Key(), reflect.ValueOf(elem))
+		}
+	}
+
+	return out.Interface(), nil
+}
+
+func (m *typeReplacer) replaceStruct(v reflect.Value) (interface{}, error) {
+	out := make(map[string]interface{})
+
+	for _, field := range structFields(v.Type(), "json") {
+		fv, ok := fieldByIndex(v, field.index)
+		if !ok {
+			continue
+		}
+
+		if field.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		value, err := m.replace(fv)
+		if err != nil {
+			return nil, err
+		}
+
+		if field.quoted {
+			if b, err := json.Marshal(value); err == nil {
+				switch fv.Kind() {
+				case reflect.Bool,
+					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+					reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.String:
+					value = string(b)
+				}
+			}
+		}
+
+		out[field.name] = value
+	}
+
+	return out, nil
+}
+
+// Struct field with its name and options resolved from struct tag.
+type structField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	quoted    bool
+}
+
+// Returns fields of struct type that are encoded by json.Marshal, including
+// promoted fields of embedded structs, resolved using the same rules.
+// tagName defines struct tag with field names and options, e.g. "json".
+func structFields(typ reflect.Type, tagName string) []structField {
+	var fields []structField
+
+	type queued struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var current []queued
+	next := []queued{{typ: typ}}
+
+	visited := map[reflect.Type]bool{}
+
+	for len(next) != 0 {
+		current, next = next, nil
+
+		var level []structField
+
+		for _, q := range current {
+			if visited[q.typ] {
+				continue
+			}
+			visited[q.typ] = true
+
+			for i := 0; i < q.typ.NumField(); i++ {
+				sf := q.typ.Field(i)
+
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+
+				if sf.Anonymous {
+					if sf.PkgPath != "" && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if sf.PkgPath != "" {
+					continue
+				}
+
+				tag := sf.Tag.Get(tagName)
+				if tag == "-" {
+					continue
+				}
+
+				name, opts := tag, ""
+				if i := strings.IndexByte(tag, ','); i >= 0 {
+					name, opts = tag[:i], tag[i:]
+				}
+
+				index := make([]int, len(q.index)+1)
+				copy(index, q.index)
+				index[len(q.index)] = i
+
+				if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+					next = append(next, queued{ft, index})
+					continue
+				}
+
+				field := structField{
+					name:      name,
+					index:     index,
+					tagged:    name != "",
+					omitEmpty: strings.Contains(opts, ",omitempty"),
+					quoted:    strings.Contains(opts, ",string"),
+				}
+				if field.name == "" {
+					field.name = sf.Name
+				}
+
+				level = append(level, field)
+			}
+		}
+
+		fields = append(fields, dominantFields(fields, level)...)
+	}
+
+	return fields
+}
+
+// Resolves conflicts of fields at the same embedding depth: if there are
+// multiple fields with the same name, tagged field wins, or if there
+// are no or several tagged fields, all of them are dropped. Fields hidden
+// by shallower fields are dropped as well.
+func dominantFields(shallower []structField, level []structField) []structField {
+	hidden := map[string]bool{}
+	for _, f := range shallower {
+		hidden[f.name] = true
+	}
+
+	byName := map[string][]structField{}
+	var names []string
+
+	for _, f := range level {
+		if hidden[f.name] {
+			continue
+		}
+		if _, ok := byName[f.name]; !ok {
+			names = append(names, f.name)
+		}
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	var out []structField
+
+	for _, name := range names {
+		candidates := byName[name]
+		if len(candidates) == 1 {
+			out = append(out, candidates[0])
+			continue
+		}
+
+		var tagged []structField
+		for _, f := range candidates {
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+		if len(tagged) == 1 {
+			out = append(out, tagged[0])
+		}
+	}
+
+	return out
+}
+
+// Like Value.FieldByIndex, but returns false when traversing nil pointer
+// to embedded struct.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// Same as in encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Marshal value using marshaler, or json.Marshal if marshaler is nil.
+func marshalJSON(marshaler Marshaler, value interface{}) ([]byte, error) {
+	if marshaler == nil {
+		return json.Marshal(value)
+	}
+
+	return marshaler.Marshal(value)
+}
@@ -0,0 +1,223 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDecimal struct {
+	unscaled int64
+	scale    int
+}
+
+func (d testDecimal) String() string {
+	s := strconv.FormatInt(d.unscaled, 10)
+	if d.scale == 0 {
+		return s
+	}
+	for len(s) <= d.scale {
+		s = "0" + s
+	}
+	return s[:len(s)-d.scale] + "." + s[len(s)-d.scale:]
+}
+
+func newTestMarshaler() *DefaultMarshaler {
+	m := &DefaultMarshaler{}
+
+	m.RegisterType(testDecimal{}, func(value interface{}) (interface{}, error) {
+		return json.Number(value.(testDecimal).String()), nil
+	})
+
+	return m
+}
+
+func TestMarshalerDefault(t *testing.T) {
+	type Inner struct {
+		A int `json:"a"`
+	}
+
+	type Outer struct {
+		Inner
+		B string    `json:"b,omitempty"`
+		C time.Time `json:"c"`
+		D []int
+		e int
+	}
+
+	values := []interface{}{
+		nil,
+		123,
+		"str",
+		[]interface{}{1, "a"},
+		map[string]interface{}{"a": 1},
+		Outer{Inner{1}, "", time.Unix(0, 0).UTC(), []int{1, 2}, 3},
+		&Outer{},
+	}
+
+	m := &DefaultMarshaler{}
+
+	for _, value := range values {
+		expected, err := json.Marshal(value)
+		assert.NoError(t, err)
+
+		actual, err := m.Marshal(value)
+		assert.NoError(t, err)
+
+		assert.Equal(t, string(expected), string(actual))
+	}
+}
+
+func TestMarshalerRegisterType(t *testing.T) {
+	m := newTestMarshaler()
+
+	t.Run("value", func(t *testing.T) {
+		b, err := m.Marshal(testDecimal{12345, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, `123.45`, string(b))
+	})
+
+	t.Run("containers", func(t *testing.T) {
+		value := map[string]interface{}{
+			"slice": []testDecimal{{1, 1}, {25, 1}},
+			"array": [1]*testDecimal{{5, 0}},
+			"map":   map[int]testDecimal{1: {1, 3}},
+			"nil":   (*testDecimal)(nil),
+			"other": []byte("abc"),
+		}
+
+		b, err := m.Marshal(value)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"slice": [0.1, 2.5],
+			"array": [5],
+			"map":   {"1": 0.001},
+			"nil":   null,
+			"other": "YWJj"
+		}`, string(b))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type Base struct {
+			ID    int         `json:"id"`
+			Price testDecimal `json:"price"`
+		}
+
+		type Item struct {
+			Base
+			*testExtra
+			Name     string       `json:"name"`
+			Discount *testDecimal `json:"discount,omitempty"`
+			Count    int          `json:"count,string"`
+			Skipped  testDecimal  `json:"-"`
+			Untagged testDecimal
+			hidden   testDecimal
+		}
+
+		b, err := m.Marshal(Item{
+			Base:     Base{ID: 1, Price: testDecimal{999, 2}},
+			Name:     "foo",
+			Count:    3,
+			Untagged: testDecimal{1, 0},
+		})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id":       1,
+			"price":    9.99,
+			"name":     "foo",
+			"count":    "3",
+			"Untagged": 1
+		}`, string(b))
+
+		b, err = m.Marshal(&Item{
+			testExtra: &testExtra{Note: testDecimal{7, 0}},
+			Discount:  &testDecimal{5, 1},
+		})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"id":       0,
+			"price":    0,
+			"note":     7,
+			"name":     "",
+			"discount": 0.5,
+			"count":    "0",
+			"Untagged": 0
+		}`, string(b))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		m := &DefaultMarshaler{}
+
+		m.RegisterType(testDecimal{}, func(value interface{}) (interface{}, error) {
+			return nil, errors.New("test error")
+		})
+
+		_, err := m.Marshal([]testDecimal{{1, 0}})
+		assert.Error(t, err)
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		assert.Panics(t, func() {
+			m.RegisterType(nil, func(interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		})
+		assert.Panics(t, func() {
+			m.RegisterType(testDecimal{}, nil)
+		})
+	})
+}
+
+type testExtra struct {
+	Note testDecimal `json:"note"`
+}
+
+func TestMarshalerConfig(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	config := Config{
+		Client:    &mockClient{},
+		Reporter:  reporter,
+		Marshaler: newTestMarshaler(),
+	}.withDefaults()
+
+	t.Run("canonicalization", func(t *testing.T) {
+		chain := newChainWithConfig("test", config)
+
+		object := newObject(chain, map[string]interface{}{
+			"price": 1.5,
+		})
+
+		object.Equal(map[string]interface{}{
+			"price": testDecimal{15, 1},
+		})
+		object.chain.assertNotFailed(t)
+
+		object.Equal(map[string]interface{}{
+			"price": testDecimal{16, 1},
+		})
+		object.chain.assertFailed(t)
+	})
+
+	t.Run("request body", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := config
+		config.Client = client
+
+		req := NewRequestC(config, "POST", "/path").
+			WithJSON(map[string]interface{}{
+				"price": testDecimal{1999, 2},
+			})
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.Body().Equal(`{"price":19.99}`)
+		resp.chain.assertNotFailed(t)
+	})
+}
@@ -0,0 +1,248 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Matcher checks arbitrary value, see Value.Match.
+//
+// Matcher allows to plug custom or third-party matching logic into
+// assertion chain. Adapters for common matcher libraries are provided
+// by FromGomega and FromDiff.
+type Matcher interface {
+	// Match returns nil if actual value matches, or error describing
+	// mismatch otherwise.
+	//
+	// Actual value is in canonical form, i.e. one of nil, bool, float64
+	// (or json.Number), string, []interface{}, and map[string]interface{}.
+	Match(actual interface{}) error
+
+	// Description returns human-readable description of matcher,
+	// e.g. "is a valid user". It is included in failure report.
+	Description() string
+}
+
+// NewMatcher returns Matcher with given description that invokes fn.
+//
+// Example:
+//
+//	positive := httpexpect.NewMatcher("is positive number",
+//	    func(actual interface{}) error {
+//	        if n, ok := actual.(float64); !ok || n <= 0 {
+//	            return fmt.Errorf("got %v", actual)
+//	        }
+//	        return nil
+//	    })
+//
+//	value.Match(positive)
+func NewMatcher(description string, fn func(actual interface{}) error) Matcher {
+	return &funcMatcher{
+		description: description,
+		fn:          fn,
+	}
+}
+
+type funcMatcher struct {
+	description string
+	fn          func(actual interface{}) error
+}
+
+func (m *funcMatcher) Match(actual interface{}) error {
+	return m.fn(actual)
+}
+
+func (m *funcMatcher) Description() string {
+	return m.description
+}
+
+// GomegaMatcher defines subset of gomega types.GomegaMatcher interface
+// used by FromGomega. Any gomega matcher implements it.
+type GomegaMatcher interface {
+	Match(actual interface{}) (success bool, err error)
+	FailureMessage(actual interface{}) (message string)
+}
+
+// FromGomega returns Matcher that invokes given gomega matcher.
+//
+// httpexpect doesn't depend on gomega; any value implementing
+// GomegaMatcher interface can be used.
+//
+// Example:
+//
+//	import . "github.com/onsi/gomega"
+//
+//	value.Match(httpexpect.FromGomega(HaveKeyWithValue("name", "john")))
+func FromGomega(m GomegaMatcher) Matcher {
+	if m == nil {
+		return nil
+	}
+
+	return &gomegaMatcher{
+		matcher: m,
+	}
+}
+
+type gomegaMatcher struct {
+	matcher GomegaMatcher
+}
+
+func (m *gomegaMatcher) Match(actual interface{}) error {
+	success, err := m.matcher.Match(actual)
+	if err != nil {
+		return err
+	}
+	if !success {
+		return errors.New(m.matcher.FailureMessage(actual))
+	}
+	return nil
+}
+
+func (m *gomegaMatcher) Description() string {
+	return fmt.Sprintf("matches %T", m.matcher)
+}
+
+// FromDiff returns Matcher that compares actual value with expected value
+// using given diff function. Match succeeds if diff returns empty string.
+//
+// diff is invoked with expected and actual values, in this order, and
+// should return human-readable difference. It can be used to plug
+// github.com/google/go-cmp comparison with arbitrary options.
+//
+// Expected value is converted to canonical form before comparison.
+//
+// Example:
+//
+//	import "github.com/google/go-cmp/cmp"
+//	import "github.com/google/go-cmp/cmp/cmpopts"
+//
+//	value.Match(httpexpect.FromDiff(expected,
+//	    func(x, y interface{}) string {
+//	        return cmp.Diff(x, y, cmpopts.EquateApprox(0, 0.01))
+//	    }))
+func FromDiff(expected interface{}, diff func(x, y interface{}) string) Matcher {
+	if diff == nil {
+		return nil
+	}
+
+	return &diffMatcher{
+		expected: expected,
+		diff:     diff,
+	}
+}
+
+type diffMatcher struct {
+	expected interface{}
+	diff     func(x, y interface{}) string
+}
+
+func (m *diffMatcher) Match(actual interface{}) error {
+	if d := m.diff(m.expected, actual); d != "" {
+		return fmt.Errorf("diff (-expected +actual):\n%s", d)
+	}
+	return nil
+}
+
+func (m *diffMatcher) Description() string {
+	return "is equal to expected value"
+}
+
+func (m *diffMatcher) canonicalize(chain *chain) (Matcher, bool) {
+	expected, ok := canonValue(chain, m.expected)
+	if !ok {
+		return nil, false
+	}
+
+	return &diffMatcher{
+		expected: expected,
+		diff:     m.diff,
+	}, true
+}
+
+// Implemented by matchers holding expected value, which should be converted
+// to canonical form using chain settings before matching.
+type matcherCanonicalizer interface {
+	canonicalize(chain *chain) (Matcher, bool)
+}
+
+// Match succeeds if value is matched by given Matcher.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"id": 123})
+//	value.Match(httpexpect.NewMatcher("has id", func(actual interface{}) error {
+//	    if _, ok := actual.(map[string]interface{})["id"]; !ok {
+//	        return errors.New("id is missing")
+//	    }
+//	    return nil
+//	}))
+func (v *Value) Match(m Matcher) *Value {
+	v.chain.enter("Match()")
+	defer v.chain.leave()
+
+	matchValue(v.chain, v.value, m)
+	return v
+}
+
+// Match succeeds if object is matched by given Matcher.
+// See Value.Match for details.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": 123})
+//	object.Match(matcher)
+func (o *Object) Match(m Matcher) *Object {
+	o.chain.enter("Match()")
+	defer o.chain.leave()
+
+	matchValue(o.chain, o.value, m)
+	return o
+}
+
+// Match succeeds if array is matched by given Matcher.
+// See Value.Match for details.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{1, 2, 3})
+//	array.Match(matcher)
+func (a *Array) Match(m Matcher) *Array {
+	a.chain.enter("Match()")
+	defer a.chain.leave()
+
+	matchValue(a.chain, a.value, m)
+	return a
+}
+
+func matchValue(chain *chain, value interface{}, m Matcher) {
+	if chain.failed() {
+		return
+	}
+
+	if m == nil {
+		chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil matcher"),
+			},
+		})
+		return
+	}
+
+	if c, ok := m.(matcherCanonicalizer); ok {
+		if m, ok = c.canonicalize(chain); !ok {
+			return
+		}
+	}
+
+	if err := m.Match(value); err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: value %s", m.Description()),
+				err,
+			},
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockGomegaMatcher struct {
+	expected interface{}
+	err      error
+}
+
+func (m *mockGomegaMatcher) Match(actual interface{}) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return reflect.DeepEqual(m.expected, actual), nil
+}
+
+func (m *mockGomegaMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n    %v\nto equal\n    %v", actual, m.expected)
+}
+
+func TestMatcherFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	called := false
+
+	m := NewMatcher("never", func(interface{}) error {
+		called = true
+		return nil
+	})
+
+	newValue(chain, nil).Match(m)
+	newObject(chain, map[string]interface{}{}).Match(m)
+	newArray(chain, []interface{}{}).Match(m)
+
+	assert.False(t, called)
+}
+
+func TestMatcherFunc(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	positive := NewMatcher("is positive", func(actual interface{}) error {
+		if n, ok := actual.(float64); !ok || n <= 0 {
+			return fmt.Errorf("got %v", actual)
+		}
+		return nil
+	})
+
+	NewValue(reporter, 1).Match(positive).chain.assertNotFailed(t)
+	NewValue(reporter, -1).Match(positive).chain.assertFailed(t)
+	NewValue(reporter, "1").Match(positive).chain.assertFailed(t)
+
+	NewValue(reporter, 1).Match(nil).chain.assertFailed(t)
+
+	var got interface{}
+
+	capture := NewMatcher("any", func(actual interface{}) error {
+		got = actual
+		return nil
+	})
+
+	NewObject(reporter, map[string]interface{}{"a": 1}).
+		Match(capture).chain.assertNotFailed(t)
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, got)
+
+	NewArray(reporter, []interface{}{1, "b"}).
+		Match(capture).chain.assertNotFailed(t)
+	assert.Equal(t, []interface{}{1.0, "b"}, got)
+}
+
+func TestMatcherFailure(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	chain := newChainWithConfig("test", Config{
+		AssertionHandler: handler,
+	}.withDefaults())
+
+	m := NewMatcher("is a valid user", func(actual interface{}) error {
+		return errors.New("name is missing")
+	})
+
+	newObject(chain, map[string]interface{}{}).Match(m)
+
+	require.NotNil(t, handler.failure)
+	assert.Equal(t, AssertValid, handler.failure.Type)
+	require.Equal(t, 2, len(handler.failure.Errors))
+	assert.Equal(t, "expected: value is a valid user",
+		handler.failure.Errors[0].Error())
+	assert.Equal(t, "name is missing",
+		handler.failure.Errors[1].Error())
+}
+
+func TestMatcherGomega(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	NewValue(reporter, "foo").
+		Match(FromGomega(&mockGomegaMatcher{expected: "foo"})).
+		chain.assertNotFailed(t)
+
+	NewValue(reporter, "bar").
+		Match(FromGomega(&mockGomegaMatcher{expected: "foo"})).
+		chain.assertFailed(t)
+
+	NewValue(reporter, "foo").
+		Match(FromGomega(&mockGomegaMatcher{err: errors.New("invalid")})).
+		chain.assertFailed(t)
+
+	NewValue(reporter, "foo").
+		Match(FromGomega(nil)).
+		chain.assertFailed(t)
+}
+
+func TestMatcherDiff(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	var gotExpected interface{}
+
+	diff := func(x, y interface{}) string {
+		gotExpected = x
+		if reflect.DeepEqual(x, y) {
+			return ""
+		}
+		return fmt.Sprintf("%v != %v", x, y)
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		NewObject(reporter, map[string]interface{}{"name": "john", "age": 30}).
+			Match(FromDiff(user{Name: "john", Age: 30}, diff)).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t,
+			map[string]interface{}{"name": "john", "age": 30.0}, gotExpected)
+	})
+
+	t.Run("not equal", func(t *testing.T) {
+		NewObject(reporter, map[string]interface{}{"name": "bob", "age": 30}).
+			Match(FromDiff(user{Name: "john", Age: 30}, diff)).
+			chain.assertFailed(t)
+	})
+
+	t.Run("invalid expected", func(t *testing.T) {
+		NewValue(reporter, nil).
+			Match(FromDiff(func() {}, diff)).
+			chain.assertFailed(t)
+	})
+
+	t.Run("nil diff", func(t *testing.T) {
+		NewValue(reporter, nil).
+			Match(FromDiff(nil, nil)).
+			chain.assertFailed(t)
+	})
+
+	t.Run("json number", func(t *testing.T) {
+		config := newMockConfig(reporter)
+		config.JSONNumber = true
+
+		chain := newChainWithConfig("test", config)
+
+		newValue(chain, json.Number("1")).
+			Match(FromDiff(1, diff)).
+			chain.assertNotFailed(t)
+	})
+}
@@ -0,0 +1,426 @@
+package httpexpect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsSink receives metrics of requests made during test run.
+// See Config.MetricsSink.
+//
+// Metrics implements this interface.
+type MetricsSink interface {
+	// ObserveRequest is invoked when response is received, or when request
+	// failed to be sent.
+	ObserveRequest(sample MetricsSample)
+
+	// ObserveFailure is invoked when request or its response has failed
+	// assertions. It's invoked at most once per request.
+	ObserveFailure(sample MetricsSample)
+}
+
+// MetricsSample describes single request, passed to MetricsSink.
+type MetricsSample struct {
+	// Request method.
+	Method string
+
+	// Request host.
+	Host string
+
+	// Request path template, as passed to Expect.Request, before
+	// substitution of path parameters.
+	Path string
+
+	// Response status code.
+	// Zero if response was not received.
+	Status int
+
+	// Response round-trip time.
+	// Zero if response was not received.
+	Latency time.Duration
+}
+
+// Metrics is a MetricsSink that collects request counts, latencies, and
+// failures per endpoint.
+//
+// Endpoint is identified by request method, host, and path template, so
+// requests with different path arguments are accounted together.
+//
+// When the test run is finished, collected metrics can be written as JSON
+// summary using WriteFile or Write, exported in Prometheus text format using
+// WritePrometheus, or pushed to Prometheus Pushgateway using Push. This is
+// useful when test suite is also used as smoke or load canary.
+//
+// Metrics is safe for concurrent use. Zero value is ready to use.
+//
+// Example:
+//
+//	var metrics = &httpexpect.Metrics{}
+//
+//	func TestMain(m *testing.M) {
+//	    code := m.Run()
+//	    if err := metrics.Push("http://pushgateway:9091", "canary"); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    os.Exit(code)
+//	}
+//
+//	func TestSomething(t *testing.T) {
+//	    e := httpexpect.WithConfig(httpexpect.Config{
+//	        Reporter:    httpexpect.NewAssertReporter(t),
+//	        MetricsSink: metrics,
+//	    })
+//	}
+type Metrics struct {
+	// Upper bounds of latency buckets, in ascending order.
+	// If nil, DefaultHistogramBuckets is used.
+	// Should not be changed after first request is observed.
+	Buckets []time.Duration
+
+	mu        sync.Mutex
+	endpoints map[metricsEndpointKey]*MetricsEndpoint
+}
+
+// MetricsEndpoint describes metrics of single endpoint, collected by Metrics.
+type MetricsEndpoint struct {
+	// Request method.
+	Method string
+
+	// Request host.
+	Host string
+
+	// Request path template.
+	Path string
+
+	// Number of requests, including those that failed to be sent.
+	Requests int
+
+	// Number of requests with failed assertions.
+	Failures int
+
+	// Number of requests per status code.
+	// Zero code is used for requests that failed to be sent.
+	Statuses map[int]int
+
+	// Statistics of round-trip times of received responses.
+	LatencyCount int
+	LatencySum   time.Duration
+	LatencyMin   time.Duration
+	LatencyMax   time.Duration
+
+	// Number of responses in every latency bucket.
+	// Buckets are not cumulative, and the last bucket has no upper bound.
+	Buckets []LatencyBucket
+}
+
+type metricsEndpointKey struct {
+	method string
+	host   string
+	path   string
+}
+
+// ObserveRequest implements MetricsSink.ObserveRequest.
+func (m *Metrics) ObserveRequest(sample MetricsSample) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ep := m.endpoint(sample)
+
+	ep.Requests++
+	ep.Statuses[sample.Status]++
+
+	if sample.Status == 0 {
+		return
+	}
+
+	if ep.LatencyCount == 0 || sample.Latency < ep.LatencyMin {
+		ep.LatencyMin = sample.Latency
+	}
+	if ep.LatencyCount == 0 || sample.Latency > ep.LatencyMax {
+		ep.LatencyMax = sample.Latency
+	}
+
+	ep.LatencyCount++
+	ep.LatencySum += sample.Latency
+
+	i := sort.Search(len(ep.Buckets)-1, func(i int) bool {
+		return sample.Latency <= ep.Buckets[i].Le
+	})
+	ep.Buckets[i].Count++
+}
+
+// ObserveFailure implements MetricsSink.ObserveFailure.
+func (m *Metrics) ObserveFailure(sample MetricsSample) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoint(sample).Failures++
+}
+
+// Endpoints returns metrics of all endpoints, sorted by path, method,
+// and host.
+func (m *Metrics) Endpoints() []MetricsEndpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]MetricsEndpoint, 0, len(m.endpoints))
+
+	for _, ep := range m.endpoints {
+		cp := *ep
+
+		cp.Statuses = make(map[int]int, len(ep.Statuses))
+		for code, n := range ep.Statuses {
+			cp.Statuses[code] = n
+		}
+
+		cp.Buckets = append([]LatencyBucket(nil), ep.Buckets...)
+
+		result = append(result, cp)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		if result[i].Method != result[j].Method {
+			return result[i].Method < result[j].Method
+		}
+		return result[i].Host < result[j].Host
+	})
+
+	return result
+}
+
+// Reset removes all collected metrics.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.endpoints = nil
+}
+
+// WriteFile writes JSON summary of metrics to file with given path.
+// If file exists, it's truncated.
+func (m *Metrics) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Write writes JSON summary of metrics to given writer.
+func (m *Metrics) Write(w io.Writer) error {
+	out := []jsonMetricsEndpoint{}
+
+	for _, ep := range m.Endpoints() {
+		je := jsonMetricsEndpoint{
+			Method:   ep.Method,
+			Host:     ep.Host,
+			Path:     ep.Path,
+			Requests: ep.Requests,
+			Failures: ep.Failures,
+			Statuses: map[string]int{},
+		}
+
+		for code, n := range ep.Statuses {
+			je.Statuses[strconv.Itoa(code)] = n
+		}
+
+		if ep.LatencyCount != 0 {
+			je.MinMs = durationMillis(ep.LatencyMin)
+			je.MaxMs = durationMillis(ep.LatencyMax)
+			je.MeanMs = durationMillis(ep.LatencySum / time.Duration(ep.LatencyCount))
+		}
+
+		out = append(out, je)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", defaultIndent)
+
+	return enc.Encode(out)
+}
+
+// WritePrometheus writes metrics to given writer in Prometheus text
+// exposition format.
+//
+// The following metrics are written, labeled by method, host, and path:
+//
+//   - httpexpect_requests_total: counter of requests, also labeled by
+//     status code
+//   - httpexpect_failures_total: counter of requests with failed assertions
+//   - httpexpect_request_duration_seconds: histogram of round-trip times
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	endpoints := m.Endpoints()
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# HELP httpexpect_requests_total %s\n",
+		"Number of requests made by tests.")
+	fmt.Fprintf(bw, "# TYPE httpexpect_requests_total counter\n")
+
+	for _, ep := range endpoints {
+		codes := make([]int, 0, len(ep.Statuses))
+		for code := range ep.Statuses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+
+		for _, code := range codes {
+			fmt.Fprintf(bw, "httpexpect_requests_total{%s,status=%q} %d\n",
+				metricsLabels(ep), strconv.Itoa(code), ep.Statuses[code])
+		}
+	}
+
+	fmt.Fprintf(bw, "# HELP httpexpect_failures_total %s\n",
+		"Number of requests with failed assertions.")
+	fmt.Fprintf(bw, "# TYPE httpexpect_failures_total counter\n")
+
+	for _, ep := range endpoints {
+		fmt.Fprintf(bw, "httpexpect_failures_total{%s} %d\n",
+			metricsLabels(ep), ep.Failures)
+	}
+
+	fmt.Fprintf(bw, "# HELP httpexpect_request_duration_seconds %s\n",
+		"Round-trip time of requests made by tests.")
+	fmt.Fprintf(bw, "# TYPE httpexpect_request_duration_seconds histogram\n")
+
+	for _, ep := range endpoints {
+		labels := metricsLabels(ep)
+
+		cumulative := 0
+		for i, b := range ep.Buckets {
+			cumulative += b.Count
+
+			le := "+Inf"
+			if i != len(ep.Buckets)-1 {
+				le = strconv.FormatFloat(b.Le.Seconds(), 'g', -1, 64)
+			}
+
+			fmt.Fprintf(bw, "httpexpect_request_duration_seconds_bucket{%s,le=%q} %d\n",
+				labels, le, cumulative)
+		}
+
+		fmt.Fprintf(bw, "httpexpect_request_duration_seconds_sum{%s} %s\n",
+			labels, strconv.FormatFloat(ep.LatencySum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(bw, "httpexpect_request_duration_seconds_count{%s} %d\n",
+			labels, ep.LatencyCount)
+	}
+
+	return bw.Flush()
+}
+
+// Push pushes metrics to Prometheus Pushgateway with given base URL,
+// under given job name, replacing previously pushed metrics of the job.
+//
+// Example:
+//
+//	err := metrics.Push("http://pushgateway:9091", "api-canary")
+func (m *Metrics) Push(gatewayURL, job string) error {
+	if job == "" {
+		return errors.New("unexpected empty job name")
+	}
+
+	var buf bytes.Buffer
+	if err := m.WritePrometheus(&buf); err != nil {
+		return err
+	}
+
+	pushURL := strings.TrimSuffix(gatewayURL, "/") +
+		"/metrics/job/" + url.PathEscape(job)
+
+	req, err := http.NewRequest(http.MethodPut, pushURL, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected pushgateway response status: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (m *Metrics) endpoint(sample MetricsSample) *MetricsEndpoint {
+	key := metricsEndpointKey{
+		method: sample.Method,
+		host:   sample.Host,
+		path:   sample.Path,
+	}
+
+	if m.endpoints == nil {
+		m.endpoints = make(map[metricsEndpointKey]*MetricsEndpoint)
+	}
+
+	ep := m.endpoints[key]
+
+	if ep == nil {
+		buckets := m.Buckets
+		if buckets == nil {
+			buckets = DefaultHistogramBuckets
+		}
+
+		ep = &MetricsEndpoint{
+			Method:   sample.Method,
+			Host:     sample.Host,
+			Path:     sample.Path,
+			Statuses: make(map[int]int),
+		}
+
+		for _, le := range buckets {
+			ep.Buckets = append(ep.Buckets, LatencyBucket{Le: le})
+		}
+		ep.Buckets = append(ep.Buckets, LatencyBucket{})
+
+		m.endpoints[key] = ep
+	}
+
+	return ep
+}
+
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func metricsLabels(ep MetricsEndpoint) string {
+	return fmt.Sprintf(`method="%s",host="%s",path="%s"`,
+		metricsLabelEscaper.Replace(ep.Method),
+		metricsLabelEscaper.Replace(ep.Host),
+		metricsLabelEscaper.Replace(ep.Path))
+}
+
+type jsonMetricsEndpoint struct {
+	Method   string         `json:"method"`
+	Host     string         `json:"host"`
+	Path     string         `json:"path"`
+	Requests int            `json:"requests"`
+	Failures int            `json:"failures"`
+	Statuses map[string]int `json:"statuses"`
+	MinMs    float64        `json:"min_ms"`
+	MaxMs    float64        `json:"max_ms"`
+	MeanMs   float64        `json:"mean_ms"`
+}
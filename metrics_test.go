@@ -0,0 +1,244 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsEndpoints(t *testing.T) {
+	m := &Metrics{
+		Buckets: []time.Duration{5 * time.Millisecond, 10 * time.Millisecond},
+	}
+
+	users := MetricsSample{Method: "GET", Host: "example.com", Path: "/users/{id}"}
+
+	for _, rtt := range []time.Duration{3, 7, 30} {
+		sample := users
+		sample.Status = http.StatusOK
+		sample.Latency = rtt * time.Millisecond
+		m.ObserveRequest(sample)
+	}
+
+	m.ObserveRequest(users)
+	m.ObserveFailure(users)
+
+	m.ObserveRequest(MetricsSample{
+		Method: "POST", Host: "example.com", Path: "/users",
+		Status: http.StatusCreated, Latency: time.Second,
+	})
+
+	endpoints := m.Endpoints()
+	require.Equal(t, 2, len(endpoints))
+
+	assert.Equal(t, MetricsEndpoint{
+		Method:       "POST",
+		Host:         "example.com",
+		Path:         "/users",
+		Requests:     1,
+		Statuses:     map[int]int{201: 1},
+		LatencyCount: 1,
+		LatencySum:   time.Second,
+		LatencyMin:   time.Second,
+		LatencyMax:   time.Second,
+		Buckets: []LatencyBucket{
+			{Le: 5 * time.Millisecond, Count: 0},
+			{Le: 10 * time.Millisecond, Count: 0},
+			{Le: 0, Count: 1},
+		},
+	}, endpoints[0])
+
+	assert.Equal(t, MetricsEndpoint{
+		Method:       "GET",
+		Host:         "example.com",
+		Path:         "/users/{id}",
+		Requests:     4,
+		Failures:     1,
+		Statuses:     map[int]int{0: 1, 200: 3},
+		LatencyCount: 3,
+		LatencySum:   40 * time.Millisecond,
+		LatencyMin:   3 * time.Millisecond,
+		LatencyMax:   30 * time.Millisecond,
+		Buckets: []LatencyBucket{
+			{Le: 5 * time.Millisecond, Count: 1},
+			{Le: 10 * time.Millisecond, Count: 1},
+			{Le: 0, Count: 1},
+		},
+	}, endpoints[1])
+
+	m.Reset()
+	assert.Empty(t, m.Endpoints())
+}
+
+func TestMetricsWrite(t *testing.T) {
+	m := &Metrics{
+		Buckets: []time.Duration{10 * time.Millisecond},
+	}
+
+	sample := MetricsSample{
+		Method: "GET", Host: "example.com", Path: `/"quoted"`,
+		Status: http.StatusOK, Latency: 5 * time.Millisecond,
+	}
+
+	m.ObserveRequest(sample)
+	m.ObserveFailure(sample)
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "metrics.json")
+
+		require.NoError(t, m.WriteFile(path))
+
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		var out []map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &out))
+
+		assert.Equal(t, []map[string]interface{}{
+			{
+				"method":   "GET",
+				"host":     "example.com",
+				"path":     `/"quoted"`,
+				"requests": 1.0,
+				"failures": 1.0,
+				"statuses": map[string]interface{}{"200": 1.0},
+				"min_ms":   5.0,
+				"max_ms":   5.0,
+				"mean_ms":  5.0,
+			},
+		}, out)
+	})
+
+	t.Run("prometheus", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, m.WritePrometheus(&buf))
+
+		labels := `method="GET",host="example.com",path="/\"quoted\""`
+
+		for _, line := range []string{
+			"# TYPE httpexpect_requests_total counter",
+			`httpexpect_requests_total{` + labels + `,status="200"} 1`,
+			"# TYPE httpexpect_failures_total counter",
+			`httpexpect_failures_total{` + labels + `} 1`,
+			"# TYPE httpexpect_request_duration_seconds histogram",
+			`httpexpect_request_duration_seconds_bucket{` + labels + `,le="0.01"} 1`,
+			`httpexpect_request_duration_seconds_bucket{` + labels + `,le="+Inf"} 1`,
+			`httpexpect_request_duration_seconds_sum{` + labels + `} 0.005`,
+			`httpexpect_request_duration_seconds_count{` + labels + `} 1`,
+		} {
+			assert.Contains(t, strings.Split(buf.String(), "\n"), line)
+		}
+	})
+
+	t.Run("bad path", func(t *testing.T) {
+		err := m.WriteFile(filepath.Join(t.TempDir(), "missing", "metrics.json"))
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
+
+func TestMetricsPush(t *testing.T) {
+	m := &Metrics{}
+
+	m.ObserveRequest(MetricsSample{
+		Method: "GET", Host: "example.com", Path: "/users",
+		Status: http.StatusOK, Latency: time.Millisecond,
+	})
+
+	t.Run("success", func(t *testing.T) {
+		var (
+			method string
+			path   string
+			body   string
+		)
+
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				path = r.URL.EscapedPath()
+				b, _ := ioutil.ReadAll(r.Body)
+				body = string(b)
+			}))
+		defer server.Close()
+
+		require.NoError(t, m.Push(server.URL+"/", "api canary"))
+
+		assert.Equal(t, http.MethodPut, method)
+		assert.Equal(t, "/metrics/job/api%20canary", path)
+		assert.Contains(t, body, "httpexpect_requests_total{")
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			}))
+		defer server.Close()
+
+		assert.Error(t, m.Push(server.URL, "canary"))
+	})
+
+	t.Run("empty job", func(t *testing.T) {
+		assert.Error(t, m.Push("http://localhost", ""))
+	})
+}
+
+func TestMetricsSink(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	metrics := &Metrics{}
+
+	e := WithConfig(Config{
+		BaseURL:     "http://example.com",
+		Reporter:    newMockReporter(t),
+		MetricsSink: metrics,
+		Client: &http.Client{
+			Transport: NewBinder(mux),
+		},
+	})
+
+	e.GET("/users/{id}", 1).Expect().Status(http.StatusOK)
+
+	// failed assertions of single request are accounted once
+	resp := e.GET("/users/{id}", 2).Expect()
+	resp.Status(http.StatusNotFound)
+	resp.Header("X-Missing").NotEmpty()
+
+	// failed requests are not sent and not accounted
+	e.GET("/users/{id}", 3).WithJSON(func() {}).Expect()
+
+	e.Request("GET", "/users").
+		WithClient(&mockClient{err: errors.New("network error")}).
+		Expect()
+
+	endpoints := metrics.Endpoints()
+	require.Equal(t, 2, len(endpoints))
+
+	assert.Equal(t, "/users", endpoints[0].Path)
+	assert.Equal(t, 1, endpoints[0].Requests)
+	assert.Equal(t, 1, endpoints[0].Failures)
+	assert.Equal(t, map[int]int{0: 1}, endpoints[0].Statuses)
+	assert.Equal(t, 0, endpoints[0].LatencyCount)
+
+	assert.Equal(t, "GET", endpoints[1].Method)
+	assert.Equal(t, "example.com", endpoints[1].Host)
+	assert.Equal(t, "/users/{id}", endpoints[1].Path)
+	assert.Equal(t, 2, endpoints[1].Requests)
+	assert.Equal(t, 1, endpoints[1].Failures)
+	assert.Equal(t, map[int]int{200: 2}, endpoints[1].Statuses)
+	assert.Equal(t, 2, endpoints[1].LatencyCount)
+}
@@ -65,6 +65,23 @@ func newMockConfig(r Reporter) Config {
 	return Config{Reporter: r}.withDefaults()
 }
 
+// Create Expect which sends both HTTP and websocket requests directly
+// to given handler and reports failures to returned mock reporter.
+func newMockExpect(t *testing.T, handler http.Handler) (*Expect, *mockReporter) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		WebsocketDialer: NewWebsocketDialer(handler),
+	})
+
+	return e, reporter
+}
+
 func newMockChain(t *testing.T) *chain {
 	return newChainWithDefaults("test", newMockReporter(t))
 }
@@ -0,0 +1,214 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"sort"
+)
+
+// FormFile returns a new Object instance with the first file sent in given
+// field of "multipart/form-data" response body.
+//
+// Object has the following fields:
+//   - "filename": file name from Content-Disposition
+//   - "content_type": file Content-Type, may be empty
+//   - "size": file size in bytes
+//   - "content": file content, as a string
+//
+// If response is not a multipart form, or field doesn't contain files,
+// failure is reported.
+//
+// Example:
+//
+//	resp := e.POST("/echo").
+//	    WithMultipart().
+//	    WithFileBytes("avatar", "me.png", data).
+//	    Expect()
+//
+//	file := resp.FormFile("avatar")
+//	file.ValueEqual("filename", "me.png")
+//	file.ValueEqual("size", len(data))
+func (r *Response) FormFile(field string) *Object {
+	r.chain.enter("FormFile(%q)", field)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newObject(r.chain, nil)
+	}
+
+	files := r.getFormFiles(field)
+	if files == nil {
+		return newObject(r.chain, nil)
+	}
+
+	return newObject(r.chain, files[0].(map[string]interface{}))
+}
+
+// FormFiles returns a new Array instance with all files sent in given
+// field of "multipart/form-data" response body, in order of appearance.
+//
+// Every element is an object with the same fields as returned by FormFile.
+//
+// If response is not a multipart form, or field doesn't contain files,
+// failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.FormFiles("attachments").Length().Equal(2)
+func (r *Response) FormFiles(field string) *Array {
+	r.chain.enter("FormFiles(%q)", field)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	files := r.getFormFiles(field)
+	if files == nil {
+		return newArray(r.chain, nil)
+	}
+
+	return newArray(r.chain, files)
+}
+
+func (r *Response) getFormFiles(field string) []interface{} {
+	form := r.getMultipartForm()
+	if form == nil {
+		return nil
+	}
+
+	headers := form.File[field]
+	if len(headers) == 0 {
+		names := make([]interface{}, 0, len(form.File))
+		for name := range form.File {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return names[i].(string) < names[j].(string)
+		})
+
+		r.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{names},
+			Expected: &AssertionValue{field},
+			Errors: []error{
+				fmt.Errorf("expected: multipart form contains files in field %q", field),
+			},
+		})
+		return nil
+	}
+
+	files := make([]interface{}, 0, len(headers))
+
+	for _, fh := range headers {
+		content, err := readFormFile(fh)
+		if err != nil {
+			r.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to read file %q from multipart form", fh.Filename),
+					err,
+				},
+			})
+			return nil
+		}
+
+		files = append(files, map[string]interface{}{
+			"filename":     fh.Filename,
+			"content_type": fh.Header.Get("Content-Type"),
+			"size":         len(content),
+			"content":      string(content),
+		})
+	}
+
+	return files
+}
+
+func readFormFile(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
+
+// Checks if response body should be decoded as multipart form.
+func (r *Response) isMultipartForm(options ...ContentOpts) bool {
+	if len(options) != 0 && options[0].MediaType != "" {
+		return options[0].MediaType == "multipart/form-data"
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.httpResp.Header.Get("Content-Type"))
+
+	return mediaType == "multipart/form-data"
+}
+
+func (r *Response) getMultipartForm(options ...ContentOpts) *multipart.Form {
+	if !r.checkContentOptions(options, "multipart/form-data") {
+		return nil
+	}
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	_, params, _ := mime.ParseMediaType(contentType)
+
+	boundary := params["boundary"]
+	if boundary == "" {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: []error{
+				errors.New(`missing boundary in "Content-Type" response header`),
+			},
+		})
+		return nil
+	}
+
+	content := r.readContent()
+	if r.chain.failed() {
+		return nil
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(content), boundary)
+
+	// keep all parts in memory, content is already buffered anyway
+	form, err := reader.ReadForm(int64(len(content)) + 1<<20)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				errors.New("failed to decode multipart form"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return form
+}
+
+func multipartValues(form *multipart.Form) map[string]interface{} {
+	object := make(map[string]interface{}, len(form.Value))
+
+	for key, values := range form.Value {
+		if len(values) == 1 {
+			object[key] = values[0]
+			continue
+		}
+		list := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			list = append(list, v)
+		}
+		object[key] = list
+	}
+
+	return object
+}
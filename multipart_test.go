@@ -0,0 +1,130 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultipartResponse(t *testing.T, fill func(*multipart.Writer)) *Response {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fill(mw)
+	require.NoError(t, mw.Close())
+
+	return NewResponse(newMockReporter(t), &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {mw.FormDataContentType()},
+		},
+		Body: ioutil.NopCloser(&buf),
+	})
+}
+
+func TestMultipartForm(t *testing.T) {
+	resp := newMultipartResponse(t, func(mw *multipart.Writer) {
+		_ = mw.WriteField("a", "1")
+		_ = mw.WriteField("b", "2")
+		_ = mw.WriteField("b", "3")
+
+		fw, _ := mw.CreateFormFile("file", "test.txt")
+		_, _ = fw.Write([]byte("hello"))
+	})
+
+	form := resp.Form()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"a": "1",
+		"b": []interface{}{"2", "3"},
+	}, form.Raw())
+
+	resp.Form(ContentOpts{MediaType: "multipart/form-data"})
+	resp.chain.assertNotFailed(t)
+
+	resp.Form(ContentOpts{MediaType: "application/x-www-form-urlencoded"})
+	resp.chain.assertFailed(t)
+}
+
+func TestMultipartFormFiles(t *testing.T) {
+	resp := newMultipartResponse(t, func(mw *multipart.Writer) {
+		_ = mw.WriteField("a", "1")
+
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="files"; filename="a.json"`)
+		h.Set("Content-Type", "application/json")
+		fw, _ := mw.CreatePart(h)
+		_, _ = fw.Write([]byte(`{"a":1}`))
+
+		fw, _ = mw.CreateFormFile("files", "b.txt")
+		_, _ = fw.Write([]byte("bb"))
+	})
+
+	file := resp.FormFile("files")
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, map[string]interface{}{
+		"filename":     "a.json",
+		"content_type": "application/json",
+		"size":         7.0,
+		"content":      `{"a":1}`,
+	}, file.Raw())
+
+	files := resp.FormFiles("files")
+	resp.chain.assertNotFailed(t)
+
+	files.Length().Equal(2)
+	files.Element(1).Object().ValueEqual("filename", "b.txt")
+	files.Element(1).Object().ValueEqual("content", "bb")
+	resp.chain.assertNotFailed(t)
+
+	resp.FormFile("a")
+	resp.chain.assertFailed(t)
+}
+
+func TestMultipartFormErrors(t *testing.T) {
+	t.Run("not multipart", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/x-www-form-urlencoded"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("a=1")),
+		})
+
+		resp.FormFile("file")
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("no boundary", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"multipart/form-data"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("")),
+		})
+
+		resp.Form()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"multipart/form-data; boundary=xyz"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("garbage")),
+		})
+
+		resp.Form()
+		resp.chain.assertFailed(t)
+	})
+}
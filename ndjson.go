@@ -0,0 +1,199 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Media types of newline-delimited JSON, accepted by Response.JSONLines
+// by default.
+var jsonLinesMediaTypes = []string{
+	"application/x-ndjson",
+	"application/ndjson",
+	"application/jsonl",
+	"application/x-jsonlines",
+	"application/jsonlines",
+}
+
+// JSONLines returns a new Array instance with values decoded from
+// newline-delimited JSON (NDJSON, JSON Lines) response body, one element
+// per line.
+//
+// JSONLines succeeds if response contains Content-Type header with one
+// of NDJSON media types ("application/x-ndjson", "application/ndjson",
+// "application/jsonl", "application/x-jsonlines", "application/jsonlines")
+// and empty or "utf-8" charset, and if every non-empty line is a valid
+// JSON value. Empty lines are skipped.
+//
+// If some line can't be decoded, failure is reported with its number
+// (starting from 1).
+//
+// To decode lines incrementally while they're received, use
+// Request.WithStreaming and BodyStream.JSONLine instead.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	lines := resp.JSONLines()
+//	lines.Length().Equal(2)
+//	lines.Element(0).Object().ValueEqual("id", 1)
+//	resp.JSONLines(ContentOpts{
+//	  MediaType: "text/plain",
+//	}).NotEmpty()
+func (r *Response) JSONLines(options ...ContentOpts) *Array {
+	r.chain.enter("JSONLines()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	if len(options) > 1 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newArray(r.chain, nil)
+	}
+
+	if len(options) != 0 && options[0].MediaType != "" {
+		if !r.checkContentOptions(options, "") {
+			return newArray(r.chain, nil)
+		}
+	} else if !r.checkJSONLinesType(options...) {
+		return newArray(r.chain, nil)
+	}
+
+	content := r.readContent()
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	values := []interface{}{}
+
+	for n, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		value, ok := decodeJSONLine(r.chain, line, n+1)
+		if !ok {
+			return newArray(r.chain, nil)
+		}
+
+		values = append(values, value)
+	}
+
+	return newArray(r.chain, values)
+}
+
+func (r *Response) checkJSONLinesType(options ...ContentOpts) bool {
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: []error{
+				errors.New(`invalid "Content-Type" response header`),
+				err,
+			},
+		})
+		return false
+	}
+
+	for _, expected := range jsonLinesMediaTypes {
+		if strings.EqualFold(mediaType, expected) {
+			return r.checkContentOptions(options, mediaType)
+		}
+	}
+
+	expected := AssertionList{}
+	for _, mt := range jsonLinesMediaTypes {
+		expected = append(expected, mt)
+	}
+
+	r.chain.fail(AssertionFailure{
+		Type:     AssertBelongs,
+		Actual:   &AssertionValue{mediaType},
+		Expected: &AssertionValue{expected},
+		Errors: []error{
+			errors.New(`unexpected media type in "Content-Type" response header`),
+		},
+	})
+
+	return false
+}
+
+// JSONLine reads next non-empty line from stream and returns a new Value
+// instance with JSON value decoded from it.
+//
+// If stream ends before non-empty line is received, timeout expires,
+// or line is not a valid JSON, failure is reported. Line numbers in
+// failure messages are counted from the beginning of stream (starting
+// from 1).
+//
+// Example:
+//
+//	resp := e.GET("/export").WithStreaming().Expect()
+//	stream := resp.BodyStream().WithTimeout(time.Second)
+//	stream.JSONLine().Object().ValueEqual("id", 1)
+//	stream.JSONLine().Object().ValueEqual("id", 2)
+//	stream.Terminates()
+func (s *BodyStream) JSONLine() *Value {
+	s.chain.enter("JSONLine()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newValue(s.chain, nil)
+	}
+
+	for {
+		line, err := s.reader.readLine(s.timeout)
+		if err != nil {
+			s.failRead(line, err)
+			return newValue(s.chain, nil)
+		}
+
+		lineNum := s.reader.lines
+		if !bytes.HasSuffix(line, []byte("\n")) {
+			lineNum++
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		value, ok := decodeJSONLine(s.chain, line, lineNum)
+		if !ok {
+			return newValue(s.chain, nil)
+		}
+
+		return newValue(s.chain, value)
+	}
+}
+
+func decodeJSONLine(chain *chain, line []byte, lineNum int) (interface{}, bool) {
+	value, err := jsonDecode(chain, line)
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(line)},
+			Errors: []error{
+				fmt.Errorf("failed to decode json at line %d", lineNum),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return value, true
+}
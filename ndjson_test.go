@@ -0,0 +1,147 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newJSONLinesResponse(
+	t *testing.T, handler AssertionHandler, header http.Header, body string,
+) *Response {
+	config := Config{
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+	}.withDefaults()
+
+	return newResponse(responseOpts{
+		config: config,
+		chain:  newChainWithConfig("Response()", config),
+		httpResp: &http.Response{
+			Header: header,
+			Body:   ioutil.NopCloser(strings.NewReader(body)),
+		},
+	})
+}
+
+func TestJSONLinesResponse(t *testing.T) {
+	newResp := func(t *testing.T, contentType, body string) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			Header: http.Header{
+				"Content-Type": {contentType},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		resp := newResp(t, "application/x-ndjson",
+			"{\"id\": 1}\n\n[2, 3]\r\n\"four\"\n")
+
+		lines := resp.JSONLines()
+		resp.chain.assertNotFailed(t)
+
+		lines.Equal([]interface{}{
+			map[string]interface{}{"id": 1},
+			[]interface{}{2, 3},
+			"four",
+		})
+		lines.chain.assertNotFailed(t)
+	})
+
+	t.Run("media types", func(t *testing.T) {
+		for _, mt := range jsonLinesMediaTypes {
+			resp := newResp(t, mt+"; charset=utf-8", `{}`)
+
+			resp.JSONLines().Length().Equal(1)
+			resp.chain.assertNotFailed(t)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		resp := newResp(t, "application/jsonl", "")
+
+		resp.JSONLines().Empty()
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bad line", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := newJSONLinesResponse(t, handler, http.Header{
+			"Content-Type": {"application/x-ndjson"},
+		}, "{}\n\n{bad}\n")
+
+		resp.JSONLines()
+		resp.chain.assertFailed(t)
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Contains(t, handler.failure.Errors[0].Error(), "line 3")
+		}
+	})
+
+	t.Run("bad type", func(t *testing.T) {
+		resp := newResp(t, "application/json", `{}`)
+
+		resp.JSONLines()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("bad charset", func(t *testing.T) {
+		resp := newResp(t, "application/x-ndjson; charset=latin1", `{}`)
+
+		resp.JSONLines()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("content opts", func(t *testing.T) {
+		resp := newResp(t, "text/plain", "1\n2\n")
+
+		resp.JSONLines(ContentOpts{MediaType: "text/plain"}).Length().Equal(2)
+		resp.chain.assertNotFailed(t)
+
+		resp.JSONLines(ContentOpts{}, ContentOpts{})
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestJSONLinesStream(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			Body: ioutil.NopCloser(strings.NewReader("{\"id\": 1}\n\n{\"id\": 2}")),
+		})
+
+		stream := resp.BodyStream().WithTimeout(time.Second)
+
+		stream.JSONLine().Object().ValueEqual("id", 1)
+		stream.JSONLine().Object().ValueEqual("id", 2)
+		stream.chain.assertNotFailed(t)
+
+		stream.JSONLine()
+		stream.chain.assertFailed(t)
+	})
+
+	t.Run("bad line", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		resp := newJSONLinesResponse(t, handler, nil, "1\n2\n\nbad")
+
+		stream := resp.BodyStream()
+
+		stream.JSONLine().Number().Equal(1)
+		stream.JSONLine().Number().Equal(2)
+		stream.chain.assertNotFailed(t)
+
+		stream.JSONLine()
+		stream.chain.assertFailed(t)
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Contains(t, handler.failure.Errors[0].Error(), "line 4")
+		}
+	})
+}
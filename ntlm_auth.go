@@ -0,0 +1,477 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4"
+)
+
+// WithNTLMAuth enables NTLM authentication (NTLMv2) with given domain,
+// username, and password. Domain may be empty.
+//
+// NTLM authenticates connection rather than request, so request is sent
+// using a dedicated connection: if Config.Client is *http.Client with
+// *http.Transport (or default transport), the transport is cloned for
+// this request and allowed to dial only one connection per host, which
+// is closed when response body is closed. If server closes connection
+// between negotiate and authenticate messages (e.g. sends "Connection:
+// close" with challenge), request fails instead of sending authenticate
+// message over a new connection. Other clients are used as is, and it's
+// up to them to keep the connection.
+//
+// Request is first sent with NTLM negotiate message. If server responds
+// with 401 Unauthorized and NTLM challenge in WWW-Authenticate header,
+// request is sent again with authenticate message computed from the
+// challenge, and response of the second request is returned. Otherwise,
+// the first response is returned as is.
+//
+// WithNTLMAuth overrides WithDigestAuth, and vice versa.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/intranet")
+//	req.WithNTLMAuth("CORP", "john", "secret")
+func (r *Request) WithNTLMAuth(domain, username, password string) *Request {
+	r.chain.enter("WithNTLMAuth()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if r.wsUpgrade {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected WithNTLMAuth for websocket request"),
+			},
+		})
+		return r
+	}
+
+	if username == "" {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty username"),
+			},
+		})
+		return r
+	}
+
+	r.digestAuth = nil
+	r.ntlmAuth = &ntlmCredentials{
+		domain:   domain,
+		username: username,
+		password: password,
+	}
+
+	return r
+}
+
+type ntlmCredentials struct {
+	domain   string
+	username string
+	password string
+}
+
+// Negotiate flags, see MS-NLMP, section 2.2.2.5.
+const (
+	ntlmNegotiateUnicode     = 0x00000001
+	ntlmNegotiateOEM         = 0x00000002
+	ntlmRequestTarget        = 0x00000004
+	ntlmNegotiateNTLM        = 0x00000200
+	ntlmNegotiateAlwaysSign  = 0x00008000
+	ntlmNegotiateExtendedSec = 0x00080000
+	ntlmNegotiate128         = 0x20000000
+	ntlmNegotiate56          = 0x80000000
+)
+
+// Message types.
+const (
+	ntlmNegotiateType    = 1
+	ntlmChallengeType    = 2
+	ntlmAuthenticateType = 3
+)
+
+// AV pair ids in target info, see MS-NLMP, section 2.2.2.1.
+const (
+	ntlmAvEOL       = 0
+	ntlmAvTimestamp = 7
+)
+
+const ntlmClientFlags = ntlmNegotiateUnicode | ntlmNegotiateOEM |
+	ntlmRequestTarget | ntlmNegotiateNTLM | ntlmNegotiateAlwaysSign |
+	ntlmNegotiateExtendedSec | ntlmNegotiate128 | ntlmNegotiate56
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+type ntlmChallenge struct {
+	flags           uint32
+	serverChallenge []byte
+	targetInfo      []byte
+}
+
+// Send negotiate message, and if server responds with challenge, send
+// authenticate message using the same connection.
+func (r *Request) doNTLMAuth() (*http.Response, error) {
+	client, closeConns := ntlmClient(r.config.Client)
+
+	r.httpReq.Header.Set("Authorization",
+		"NTLM "+base64.StdEncoding.EncodeToString(ntlmNegotiateMessage()))
+
+	resp, err := client.Do(r.httpReq)
+	if err != nil {
+		closeConns()
+		return resp, err
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return ntlmWrapResponse(resp, closeConns), nil
+	}
+
+	challenge, ok := parseNTLMChallenge(resp.Header.Values("WWW-Authenticate"))
+	if !ok {
+		return ntlmWrapResponse(resp, closeConns), nil
+	}
+
+	msg, err := r.ntlmAuth.authenticate(challenge, r.nowFn())
+	if err != nil {
+		return ntlmWrapResponse(resp, closeConns), nil
+	}
+
+	// body must be fully read, so that connection can be reused
+	if resp.Body != nil {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	if body, ok := r.httpReq.Body.(rewindBody); ok {
+		body.Rewind()
+	}
+
+	r.httpReq.Header.Set("Authorization",
+		"NTLM "+base64.StdEncoding.EncodeToString(msg))
+
+	resp, err = client.Do(r.httpReq)
+	if err != nil {
+		closeConns()
+		return resp, err
+	}
+
+	return ntlmWrapResponse(resp, closeConns), nil
+}
+
+// Make client that doesn't share connections with other requests.
+// Returns function that closes connections of returned client.
+func ntlmClient(client Client) (Client, func()) {
+	httpClient, ok := client.(*http.Client)
+	if !ok {
+		return client, func() {}
+	}
+
+	var transport *http.Transport
+
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+			transport = dt.Clone()
+		}
+	case *http.Transport:
+		transport = t.Clone()
+	}
+
+	if transport == nil {
+		return client, func() {}
+	}
+
+	// deprecated Dial and DialTLS are honored if set, as http.Transport does
+	transport.DialContext = ntlmPinDial(
+		transport.DialContext, transport.Dial) //nolint
+	transport.Dial = nil //nolint
+
+	if transport.DialTLSContext != nil || transport.DialTLS != nil { //nolint
+		transport.DialTLSContext = ntlmPinDial(
+			transport.DialTLSContext, transport.DialTLS) //nolint
+		transport.DialTLS = nil //nolint
+	}
+
+	transport.DisableKeepAlives = false
+	transport.MaxConnsPerHost = 1
+
+	clientCopy := *httpClient
+	clientCopy.Transport = transport
+
+	return &clientCopy, transport.CloseIdleConnections
+}
+
+var errNTLMConnClosed = errors.New(
+	"NTLM connection was closed before authentication was complete")
+
+// Wrap dial function so that it refuses to dial the same address twice.
+// This pins connection: if it's closed by server, authenticate message
+// is not silently sent over a new, unauthenticated connection.
+func ntlmPinDial(
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error),
+	dial func(network, addr string) (net.Conn, error),
+) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	switch {
+	case dialContext != nil:
+	case dial != nil:
+		dialContext = func(_ context.Context, network, addr string) (net.Conn, error) {
+			return dial(network, addr)
+		}
+	default:
+		dialContext = (&net.Dialer{}).DialContext
+	}
+
+	var mu sync.Mutex
+	dialed := map[string]bool{}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if dialed[addr] {
+			return nil, errNTLMConnClosed
+		}
+		dialed[addr] = true
+
+		return dialContext(ctx, network, addr)
+	}
+}
+
+// Close connections of dedicated client when response body is closed.
+func ntlmWrapResponse(resp *http.Response, closeConns func()) *http.Response {
+	if resp.Body == nil {
+		closeConns()
+		return resp
+	}
+
+	resp.Body = &ntlmResponseBody{
+		ReadCloser: resp.Body,
+		closeConns: closeConns,
+	}
+
+	return resp
+}
+
+type ntlmResponseBody struct {
+	io.ReadCloser
+	closeConns func()
+}
+
+func (b *ntlmResponseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.closeConns()
+	return err
+}
+
+func ntlmNegotiateMessage() []byte {
+	msg := make([]byte, 32)
+
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], ntlmNegotiateType)
+	binary.LittleEndian.PutUint32(msg[12:], ntlmClientFlags)
+
+	// domain and workstation fields are left empty
+
+	return msg
+}
+
+// Find NTLM challenge in WWW-Authenticate headers.
+func parseNTLMChallenge(headers []string) (ntlmChallenge, bool) {
+	for _, header := range headers {
+		fields := strings.Fields(header)
+		if len(fields) != 2 || !strings.EqualFold(fields[0], "NTLM") {
+			continue
+		}
+
+		msg, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(msg) < 32 ||
+			!bytes.Equal(msg[:8], ntlmSignature) ||
+			binary.LittleEndian.Uint32(msg[8:]) != ntlmChallengeType {
+			continue
+		}
+
+		challenge := ntlmChallenge{
+			flags:           binary.LittleEndian.Uint32(msg[20:]),
+			serverChallenge: msg[24:32],
+		}
+
+		if len(msg) >= 48 {
+			challenge.targetInfo, _ = ntlmField(msg, 40)
+		}
+
+		return challenge, true
+	}
+
+	return ntlmChallenge{}, false
+}
+
+// Read variable-length field described by 8-byte header at given offset.
+func ntlmField(msg []byte, offset int) ([]byte, bool) {
+	if len(msg) < offset+8 {
+		return nil, false
+	}
+
+	length := int(binary.LittleEndian.Uint16(msg[offset:]))
+	start := int(binary.LittleEndian.Uint32(msg[offset+4:]))
+
+	if start < 0 || start+length > len(msg) {
+		return nil, false
+	}
+
+	return msg[start : start+length], true
+}
+
+func (c *ntlmCredentials) authenticate(
+	challenge ntlmChallenge, now time.Time,
+) ([]byte, error) {
+	clientChallenge := make([]byte, 8)
+	if _, err := rand.Read(clientChallenge); err != nil {
+		return nil, err
+	}
+
+	timestamp, serverTime := ntlmTimestamp(challenge.targetInfo)
+	if !serverTime {
+		timestamp = ntlmFileTime(now)
+	}
+
+	nt, lm := ntlmV2Response(c.domain, c.username, c.password,
+		challenge.serverChallenge, clientChallenge, timestamp, challenge.targetInfo)
+
+	// when server provides timestamp, LM response should be empty
+	if serverTime {
+		lm = make([]byte, 24)
+	}
+
+	fields := [][]byte{
+		lm,
+		nt,
+		ntlmUnicode(c.domain),
+		ntlmUnicode(c.username),
+		nil, // workstation
+		nil, // encrypted session key
+	}
+
+	const headerLen = 64
+
+	msg := make([]byte, headerLen)
+
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], ntlmAuthenticateType)
+
+	offset := headerLen
+	for n, field := range fields {
+		pos := 12 + n*8
+
+		binary.LittleEndian.PutUint16(msg[pos:], uint16(len(field)))
+		binary.LittleEndian.PutUint16(msg[pos+2:], uint16(len(field)))
+		binary.LittleEndian.PutUint32(msg[pos+4:], uint32(offset))
+
+		msg = append(msg, field...)
+		offset += len(field)
+	}
+
+	binary.LittleEndian.PutUint32(msg[60:], challenge.flags&ntlmClientFlags|
+		ntlmNegotiateUnicode)
+
+	return msg, nil
+}
+
+// Compute NTLMv2 and LMv2 responses, as defined in MS-NLMP, section 3.3.2.
+func ntlmV2Response(
+	domain, username, password string,
+	serverChallenge, clientChallenge, timestamp, targetInfo []byte,
+) ([]byte, []byte) {
+	key := hmacMD5(ntlmHash(password), ntlmUnicode(strings.ToUpper(username)+domain))
+
+	var temp bytes.Buffer
+
+	temp.Write([]byte{1, 1, 0, 0, 0, 0, 0, 0})
+	temp.Write(timestamp)
+	temp.Write(clientChallenge)
+	temp.Write([]byte{0, 0, 0, 0})
+	temp.Write(targetInfo)
+	temp.Write([]byte{0, 0, 0, 0})
+
+	proof := hmacMD5(key, append(append([]byte(nil), serverChallenge...), temp.Bytes()...))
+
+	nt := append(proof, temp.Bytes()...)
+
+	lm := hmacMD5(key, append(append([]byte(nil), serverChallenge...), clientChallenge...))
+	lm = append(lm, clientChallenge...)
+
+	return nt, lm
+}
+
+// Find MsvAvTimestamp in target info.
+func ntlmTimestamp(targetInfo []byte) ([]byte, bool) {
+	for len(targetInfo) >= 4 {
+		id := binary.LittleEndian.Uint16(targetInfo)
+		length := int(binary.LittleEndian.Uint16(targetInfo[2:]))
+
+		if id == ntlmAvEOL || len(targetInfo) < 4+length {
+			break
+		}
+
+		if id == ntlmAvTimestamp && length == 8 {
+			return targetInfo[4:12], true
+		}
+
+		targetInfo = targetInfo[4+length:]
+	}
+
+	return nil, false
+}
+
+// Convert time to Windows FILETIME, i.e. number of 100ns intervals
+// since January 1, 1601.
+func ntlmFileTime(t time.Time) []byte {
+	const epochDelta = 116444736000000000
+
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, uint64(t.UnixNano()/100+epochDelta))
+
+	return buf
+}
+
+func ntlmUnicode(s string) []byte {
+	codes := utf16.Encode([]rune(s))
+
+	buf := make([]byte, len(codes)*2)
+	for n, code := range codes {
+		binary.LittleEndian.PutUint16(buf[n*2:], code)
+	}
+
+	return buf
+}
+
+func hmacMD5(key, data []byte) []byte {
+	mac := hmac.New(md5.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Compute NT hash of password, see MS-NLMP, section 3.3.1.
+func ntlmHash(password string) []byte {
+	h := md4.New()
+	h.Write(ntlmUnicode(password))
+	return h.Sum(nil)
+}
@@ -0,0 +1,340 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNTLMAuthFailed(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter)
+	config := newMockConfig(reporter)
+
+	chain.fail(mockFailure())
+
+	req := newRequest(chain, config, "GET", "")
+
+	req.WithNTLMAuth("domain", "user", "pass")
+
+	req.chain.assertFailed(t)
+	assert.Nil(t, req.ntlmAuth)
+}
+
+func TestNTLMAuthUsage(t *testing.T) {
+	t.Run("websocket", func(t *testing.T) {
+		req := NewRequestC(newMockConfig(newMockReporter(t)), "GET", "/")
+
+		req.WithWebsocketUpgrade()
+		req.WithNTLMAuth("domain", "user", "pass")
+
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("empty username", func(t *testing.T) {
+		req := NewRequestC(newMockConfig(newMockReporter(t)), "GET", "/")
+
+		req.WithNTLMAuth("domain", "", "pass")
+
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("override digest", func(t *testing.T) {
+		req := NewRequestC(newMockConfig(newMockReporter(t)), "GET", "/")
+
+		req.WithDigestAuth("user", "pass")
+		req.WithNTLMAuth("domain", "user", "pass")
+
+		assert.Nil(t, req.digestAuth)
+		assert.NotNil(t, req.ntlmAuth)
+
+		req.WithDigestAuth("user", "pass")
+
+		assert.NotNil(t, req.digestAuth)
+		assert.Nil(t, req.ntlmAuth)
+	})
+}
+
+func TestNTLMAuthHash(t *testing.T) {
+	// MS-NLMP, section 4.2.2.1.2
+	assert.Equal(t, "a4f49c406510bdcab6824ee7c30fd852",
+		hex.EncodeToString(ntlmHash("Password")))
+}
+
+func TestNTLMAuthResponse(t *testing.T) {
+	// MS-NLMP, section 4.2.4
+	unhex := func(s string) []byte {
+		b, err := hex.DecodeString(strings.Replace(s, " ", "", -1))
+		require.NoError(t, err)
+		return b
+	}
+
+	targetInfo := unhex("02 00 0c 00 44 00 6f 00 6d 00 61 00 69 00 6e 00" +
+		"01 00 0c 00 53 00 65 00 72 00 76 00 65 00 72 00 00 00 00 00")
+
+	nt, lm := ntlmV2Response("Domain", "User", "Password",
+		unhex("0123456789abcdef"), unhex("aaaaaaaaaaaaaaaa"),
+		make([]byte, 8), targetInfo)
+
+	assert.Equal(t, "68cd0ab851e51c96aabc927bebef6a1c", hex.EncodeToString(nt[:16]))
+	assert.Equal(t,
+		"86c35097ac9cec102554764a57cccc19aaaaaaaaaaaaaaaa", hex.EncodeToString(lm))
+}
+
+// Server which performs NTLM handshake and verifies response using
+// password of given user.
+type ntlmAuthHandler struct {
+	t        *testing.T
+	password string
+
+	mu         sync.Mutex
+	challenges map[string][]byte // by remote address
+	bodies     []string
+}
+
+var ntlmTestChallenge = []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+func (h *ntlmAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+	h.bodies = append(h.bodies, string(body))
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "NTLM ") {
+		w.Header().Set("WWW-Authenticate", "NTLM")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	msg, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "NTLM "))
+	require.NoError(h.t, err)
+	require.True(h.t, bytes.HasPrefix(msg, ntlmSignature))
+
+	switch binary.LittleEndian.Uint32(msg[8:]) {
+	case ntlmNegotiateType:
+		h.challenges[r.RemoteAddr] = ntlmTestChallenge
+
+		w.Header().Set("WWW-Authenticate",
+			"NTLM "+base64.StdEncoding.EncodeToString(ntlmTestChallengeMessage()))
+		w.WriteHeader(http.StatusUnauthorized)
+
+	case ntlmAuthenticateType:
+		// authenticate message must be sent over the same connection
+		serverChallenge, ok := h.challenges[r.RemoteAddr]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		nt, _ := ntlmField(msg, 20)
+		domain, _ := ntlmField(msg, 28)
+		user, _ := ntlmField(msg, 36)
+
+		key := hmacMD5(ntlmHash(h.password),
+			append(bytes.ToUpper(user), domain...))
+
+		proof := hmacMD5(key, append(append([]byte(nil), serverChallenge...), nt[16:]...))
+
+		if !bytes.Equal(proof, nt[:16]) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func ntlmTestChallengeMessage() []byte {
+	targetInfo := []byte{ntlmAvTimestamp, 0, 8, 0, 1, 2, 3, 4, 5, 6, 7, 8, 0, 0, 0, 0}
+
+	msg := make([]byte, 48)
+
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], ntlmChallengeType)
+	binary.LittleEndian.PutUint32(msg[20:], ntlmClientFlags)
+	copy(msg[24:], ntlmTestChallenge)
+
+	binary.LittleEndian.PutUint16(msg[40:], uint16(len(targetInfo)))
+	binary.LittleEndian.PutUint16(msg[42:], uint16(len(targetInfo)))
+	binary.LittleEndian.PutUint32(msg[44:], 48)
+
+	return append(msg, targetInfo...)
+}
+
+func TestNTLMAuthHandshake(t *testing.T) {
+	handler := &ntlmAuthHandler{
+		t:          t,
+		password:   "secret",
+		challenges: map[string][]byte{},
+	}
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Client:   &http.Client{},
+	})
+
+	t.Run("success", func(t *testing.T) {
+		handler.bodies = nil
+
+		e.POST("/").
+			WithNTLMAuth("Domain", "user", "secret").
+			WithText("hello").
+			Expect().
+			Status(http.StatusOK).
+			chain.assertNotFailed(t)
+
+		// body is sent again with authenticate message
+		assert.Equal(t, []string{"hello", "hello"}, handler.bodies)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		e.GET("/").
+			WithNTLMAuth("Domain", "user", "wrong").
+			Expect().
+			Status(http.StatusForbidden).
+			chain.assertNotFailed(t)
+	})
+
+	t.Run("no auth", func(t *testing.T) {
+		e.GET("/").
+			Expect().
+			Status(http.StatusUnauthorized).
+			chain.assertNotFailed(t)
+	})
+}
+
+func TestNTLMAuthConnection(t *testing.T) {
+	newServer := func(handler http.Handler) (*httptest.Server, *int32) {
+		var conns int32
+
+		server := httptest.NewUnstartedServer(handler)
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&conns, 1)
+			}
+		}
+		return server, &conns
+	}
+
+	newHandler := func() *ntlmAuthHandler {
+		return &ntlmAuthHandler{
+			t:          t,
+			password:   "secret",
+			challenges: map[string][]byte{},
+		}
+	}
+
+	t.Run("single connection", func(t *testing.T) {
+		handler := newHandler()
+
+		server, conns := newServer(handler)
+		server.Start()
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{},
+		})
+
+		e.GET("/").
+			WithNTLMAuth("Domain", "user", "secret").
+			Expect().
+			Status(http.StatusOK).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(conns))
+	})
+
+	t.Run("connection closed with challenge", func(t *testing.T) {
+		handler := newHandler()
+
+		server, conns := newServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Connection", "close")
+				handler.ServeHTTP(w, r)
+			}))
+		server.Start()
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{},
+		})
+
+		e.GET("/").
+			WithNTLMAuth("Domain", "user", "secret").
+			Expect().
+			chain.assertFailed(t)
+
+		// authenticate message is not sent over a new connection
+		assert.Equal(t, 1, len(handler.bodies))
+		assert.Equal(t, int32(1), atomic.LoadInt32(conns))
+	})
+
+	t.Run("idle connection closed", func(t *testing.T) {
+		handler := newHandler()
+
+		server, conns := newServer(handler)
+		server.Config.IdleTimeout = time.Nanosecond
+		server.Start()
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+			Client:   &http.Client{},
+		})
+
+		e.GET("/").
+			WithNTLMAuth("Domain", "user", "secret").
+			Expect().
+			chain.assertFailed(t)
+
+		assert.Equal(t, 1, len(handler.bodies))
+		assert.Equal(t, int32(1), atomic.LoadInt32(conns))
+	})
+}
+
+func TestNTLMAuthNoChallenge(t *testing.T) {
+	requests := 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	e, _ := newMockExpect(t, handler)
+
+	e.GET("/").
+		WithNTLMAuth("", "user", "pass").
+		Expect().
+		Status(http.StatusUnauthorized).
+		chain.assertNotFailed(t)
+
+	assert.Equal(t, 1, requests)
+}
@@ -1,15 +1,24 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"reflect"
+	"strings"
 )
 
 // Number provides methods to inspect attached float64 value
 // (Go representation of JSON number).
+//
+// If Config.JSONNumber is enabled, Number also keeps exact representation
+// of integers that can't be represented by float64 without rounding.
 type Number struct {
 	chain *chain
 	value float64
+	exact json.Number
 }
 
 // NewNumber returns a new Number instance.
@@ -24,7 +33,12 @@ func NewNumber(reporter Reporter, value float64) *Number {
 }
 
 func newNumber(parent *chain, val float64) *Number {
-	return &Number{parent.clone(), val}
+	return &Number{chain: parent.clone(), value: val}
+}
+
+func newNumberExact(parent *chain, val json.Number) *Number {
+	f, _ := val.Float64()
+	return &Number{chain: parent.clone(), value: f, exact: val}
 }
 
 // Raw returns underlying value attached to Number.
@@ -43,7 +57,7 @@ func (n *Number) Path(path string) *Value {
 	n.chain.enter("Path(%q)", path)
 	defer n.chain.leave()
 
-	return jsonPath(n.chain, n.value, path)
+	return jsonPath(n.chain, n.raw(), path)
 }
 
 // Schema is similar to Value.Schema.
@@ -51,14 +65,15 @@ func (n *Number) Schema(schema interface{}) *Number {
 	n.chain.enter("Schema()")
 	defer n.chain.leave()
 
-	jsonSchema(n.chain, n.value, schema)
+	jsonSchema(n.chain, n.raw(), schema)
 	return n
 }
 
 // Equal succeeds if number is equal to given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If number holds exact integer (see
+// Config.JSONNumber) and value is an integer, they are compared exactly.
 //
 // Example:
 //
@@ -73,6 +88,20 @@ func (n *Number) Equal(value interface{}) *Number {
 		return n
 	}
 
+	if eq, ok := n.exactEqual(value); ok {
+		if !eq {
+			n.chain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{n.exact},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: numbers are equal"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(n.chain, value)
 	if !ok {
 		return n
@@ -110,6 +139,20 @@ func (n *Number) NotEqual(value interface{}) *Number {
 		return n
 	}
 
+	if eq, ok := n.exactEqual(value); ok {
+		if eq {
+			n.chain.fail(AssertionFailure{
+				Type:     AssertNotEqual,
+				Actual:   &AssertionValue{n.exact},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: numbers are non-equal"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(n.chain, value)
 	if !ok {
 		return n
@@ -451,3 +494,475 @@ func (n *Number) NotInRange(min, max interface{}) *Number {
 
 	return n
 }
+
+// EqualDecimal succeeds if number is equal to given decimal number.
+//
+// Unlike Equal, comparison is performed with arbitrary precision. This is
+// useful when Config.JSONNumber is enabled and numbers can't be represented
+// by float64 without rounding, e.g. monetary amounts.
+//
+// Numbers without exact representation are compared using their shortest
+// decimal representation, e.g. float64(0.1) is equal to "0.1".
+//
+// Example:
+//
+//	resp.JSON().Object().Value("amount").Number().
+//	    EqualDecimal("123456789012345678.99")
+func (n *Number) EqualDecimal(value string) *Number {
+	n.chain.enter("EqualDecimal()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return n
+	}
+
+	expected, ok := n.parseDecimal(value)
+	if !ok {
+		return n
+	}
+
+	if n.decimal().Cmp(expected) != 0 {
+		n.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{n.raw()},
+			Expected: &AssertionValue{json.Number(value)},
+			Errors: []error{
+				errors.New("expected: numbers are equal"),
+			},
+		})
+	}
+
+	return n
+}
+
+// NotEqualDecimal succeeds if number is not equal to given decimal number.
+//
+// Comparison is performed with arbitrary precision, see EqualDecimal.
+//
+// Example:
+//
+//	resp.JSON().Object().Value("amount").Number().
+//	    NotEqualDecimal("123456789012345678.98")
+func (n *Number) NotEqualDecimal(value string) *Number {
+	n.chain.enter("NotEqualDecimal()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return n
+	}
+
+	expected, ok := n.parseDecimal(value)
+	if !ok {
+		return n
+	}
+
+	if n.decimal().Cmp(expected) == 0 {
+		n.chain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{n.raw()},
+			Expected: &AssertionValue{json.Number(value)},
+			Errors: []error{
+				errors.New("expected: numbers are non-equal"),
+			},
+		})
+	}
+
+	return n
+}
+
+func (n *Number) parseDecimal(value string) (*big.Rat, bool) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || strings.Contains(value, "/") {
+		n.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid decimal number %q", value),
+			},
+		})
+		return nil, false
+	}
+
+	if n.exact == "" && (math.IsNaN(n.value) || math.IsInf(n.value, 0)) {
+		n.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: finite number"),
+			},
+		})
+		return nil, false
+	}
+
+	return r, true
+}
+
+// IsInt succeeds if number is an integer and fits into signed integer
+// of given size in bits.
+//
+// bits should be one of 8, 16, 32, or 64. If omitted, 64 is used.
+//
+// Example:
+//
+//	number := NewNumber(t, 1000000)
+//	number.IsInt()
+//	number.IsInt(32)
+func (n *Number) IsInt(bits ...int) *Number {
+	n.chain.enter("IsInt()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return n
+	}
+
+	size, ok := n.bitSize(bits)
+	if !ok {
+		return n
+	}
+
+	if !n.checkInt(true) {
+		return n
+	}
+
+	min, max := intRange(size)
+	n.checkRange(min, max, true)
+
+	return n
+}
+
+// NotInt succeeds if number is not an integer or doesn't fit into signed
+// integer of given size in bits.
+//
+// bits should be one of 8, 16, 32, or 64. If omitted, 64 is used.
+//
+// Example:
+//
+//	number := NewNumber(t, 123.4)
+//	number.NotInt()
+func (n *Number) NotInt(bits ...int) *Number {
+	n.chain.enter("NotInt()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return n
+	}
+
+	size, ok := n.bitSize(bits)
+	if !ok {
+		return n
+	}
+
+	if !n.checkInt(false) {
+		return n
+	}
+
+	min, max := intRange(size)
+	n.checkRange(min, max, false)
+
+	return n
+}
+
+// IsUint succeeds if number is a non-negative integer and fits into
+// unsigned integer of given size in bits.
+//
+// bits should be one of 8, 16, 32, or 64. If omitted, 64 is used.
+//
+// Example:
+//
+//	number := NewNumber(t, 255)
+//	number.IsUint(8)
+func (n *Number) IsUint(bits ...int) *Number {
+	n.chain.enter("IsUint()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return n
+	}
+
+	size, ok := n.bitSize(bits)
+	if !ok {
+		return n
+	}
+
+	if !n.checkInt(true) {
+		return n
+	}
+
+	min, max := uintRange(size)
+	n.checkRange(min, max, true)
+
+	return n
+}
+
+// NotUint succeeds if number is not a non-negative integer or doesn't fit
+// into unsigned integer of given size in bits.
+//
+// bits should be one of 8, 16, 32, or 64. If omitted, 64 is used.
+//
+// Example:
+//
+//	number := NewNumber(t, -1)
+//	number.NotUint()
+func (n *Number) NotUint(bits ...int) *Number {
+	n.chain.enter("NotUint()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return n
+	}
+
+	size, ok := n.bitSize(bits)
+	if !ok {
+		return n
+	}
+
+	if !n.checkInt(false) {
+		return n
+	}
+
+	min, max := uintRange(size)
+	n.checkRange(min, max, false)
+
+	return n
+}
+
+// AsInt returns number as int64.
+//
+// If number is not an integer or doesn't fit into int64, failure is
+// reported and zero is returned.
+//
+// Unlike int64(Raw()), AsInt doesn't lose precision for large integers
+// when Config.JSONNumber is enabled.
+//
+// Example:
+//
+//	id := e.GET("/users/me").Expect().
+//	    JSON().Object().Value("id").Number().AsInt()
+//
+//	e.GET("/users/{id}", id).Expect().Status(http.StatusOK)
+func (n *Number) AsInt() int64 {
+	n.chain.enter("AsInt()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return 0
+	}
+
+	if !n.checkInt(true) {
+		return 0
+	}
+
+	min, max := intRange(64)
+	if !n.checkRange(min, max, true) {
+		return 0
+	}
+
+	v, _ := n.integer()
+
+	return v.Int64()
+}
+
+// AsUint returns number as uint64.
+//
+// If number is not a non-negative integer or doesn't fit into uint64,
+// failure is reported and zero is returned.
+//
+// Example:
+//
+//	size := resp.JSON().Object().Value("size").Number().AsUint()
+func (n *Number) AsUint() uint64 {
+	n.chain.enter("AsUint()")
+	defer n.chain.leave()
+
+	if n.chain.failed() {
+		return 0
+	}
+
+	if !n.checkInt(true) {
+		return 0
+	}
+
+	min, max := uintRange(64)
+	if !n.checkRange(min, max, true) {
+		return 0
+	}
+
+	v, _ := n.integer()
+
+	return v.Uint64()
+}
+
+func (n *Number) raw() interface{} {
+	if n.exact != "" {
+		return n.exact
+	}
+	return n.value
+}
+
+// Get integer value of number, if number is an integer.
+func (n *Number) integer() (*big.Int, bool) {
+	if n.exact == "" &&
+		(math.IsNaN(n.value) || math.IsInf(n.value, 0)) {
+		return nil, false
+	}
+
+	r := n.decimal()
+	if !r.IsInt() {
+		return nil, false
+	}
+
+	return new(big.Int).Set(r.Num()), true
+}
+
+// Get decimal value of number.
+// If number holds exact representation, it's used. Otherwise, float64 value
+// is converted using its shortest decimal representation.
+func (n *Number) decimal() *big.Rat {
+	if n.exact != "" {
+		if r, ok := new(big.Rat).SetString(string(n.exact)); ok {
+			return r
+		}
+	}
+	return floatDecimal(n.value)
+}
+
+// Compare exact number with given value, if value has exact representation
+// as well (integer, float, or json.Number).
+func (n *Number) exactEqual(value interface{}) (eq bool, ok bool) {
+	if n.exact == "" {
+		return false, false
+	}
+
+	expected, ok := exactDecimal(value)
+	if !ok {
+		return false, false
+	}
+
+	return n.decimal().Cmp(expected) == 0, true
+}
+
+func exactDecimal(value interface{}) (*big.Rat, bool) {
+	if num, ok := value.(json.Number); ok {
+		return new(big.Rat).SetString(string(num))
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())), true
+
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return floatDecimal(f), true
+	}
+
+	return nil, false
+}
+
+func (n *Number) bitSize(bits []int) (int, bool) {
+	if len(bits) > 1 {
+		n.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple bits arguments"),
+			},
+		})
+		return 0, false
+	}
+
+	if len(bits) == 0 {
+		return 64, true
+	}
+
+	switch bits[0] {
+	case 8, 16, 32, 64:
+		return bits[0], true
+	}
+
+	n.chain.fail(AssertionFailure{
+		Type: AssertUsage,
+		Errors: []error{
+			fmt.Errorf("unexpected bits argument %d, should be 8, 16, 32, or 64",
+				bits[0]),
+		},
+	})
+	return 0, false
+}
+
+// Check if number is (or is not) an integer.
+// Returns false if failure was reported or if result is already known.
+func (n *Number) checkInt(want bool) bool {
+	_, isInt := n.integer()
+
+	if want && !isInt {
+		n.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.raw()},
+			Errors: []error{
+				errors.New("expected: number is an integer"),
+			},
+		})
+		return false
+	}
+
+	// not an integer, so NotInt and NotUint succeed
+	if !want && !isInt {
+		return false
+	}
+
+	return true
+}
+
+// Check if integer is (or is not) within range.
+// Returns false if failure was reported.
+func (n *Number) checkRange(min, max *big.Int, want bool) bool {
+	v, _ := n.integer()
+
+	inRange := v.Cmp(min) >= 0 && v.Cmp(max) <= 0
+
+	if want && !inRange {
+		n.chain.fail(AssertionFailure{
+			Type:     AssertInRange,
+			Actual:   &AssertionValue{n.raw()},
+			Expected: &AssertionValue{AssertionRange{min, max}},
+			Errors: []error{
+				errors.New("expected: integer fits into given type"),
+			},
+		})
+		return false
+	}
+
+	if !want && inRange {
+		n.chain.fail(AssertionFailure{
+			Type:     AssertNotInRange,
+			Actual:   &AssertionValue{n.raw()},
+			Expected: &AssertionValue{AssertionRange{min, max}},
+			Errors: []error{
+				errors.New("expected: number is not an integer of given type"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func intRange(bits int) (*big.Int, *big.Int) {
+	max := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	min := new(big.Int).Neg(max)
+	max.Sub(max, big.NewInt(1))
+	return min, max
+}
+
+func uintRange(bits int) (*big.Int, *big.Int) {
+	max := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	max.Sub(max, big.NewInt(1))
+	return big.NewInt(0), max
+}
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"math"
 	"testing"
 
@@ -369,3 +370,192 @@ func TestNumberConvertInRange(t *testing.T) {
 	value.chain.assertFailed(t)
 	value.chain.clearFailed()
 }
+
+func TestNumberIsInt(t *testing.T) {
+	cases := []struct {
+		value  float64
+		bits   []int
+		isInt  bool
+		isUint bool
+	}{
+		{value: 0, isInt: true, isUint: true},
+		{value: 123, isInt: true, isUint: true},
+		{value: -123, isInt: true, isUint: false},
+		{value: 123.5, isInt: false, isUint: false},
+		{value: math.NaN(), isInt: false, isUint: false},
+		{value: math.Inf(+1), isInt: false, isUint: false},
+		{value: 127, bits: []int{8}, isInt: true, isUint: true},
+		{value: 128, bits: []int{8}, isInt: false, isUint: true},
+		{value: 256, bits: []int{8}, isInt: false, isUint: false},
+		{value: -128, bits: []int{8}, isInt: true, isUint: false},
+		{value: -129, bits: []int{8}, isInt: false, isUint: false},
+		{value: math.MaxUint32, bits: []int{32}, isInt: false, isUint: true},
+		{value: 1e19, isInt: false, isUint: true},
+		{value: 1e20, isInt: false, isUint: false},
+	}
+
+	for _, tc := range cases {
+		reporter := newMockReporter(t)
+
+		value := NewNumber(reporter, tc.value)
+
+		value.IsInt(tc.bits...)
+		assert.Equal(t, !tc.isInt, value.chain.failed(), tc.value)
+		value.chain.clearFailed()
+
+		value.NotInt(tc.bits...)
+		assert.Equal(t, tc.isInt, value.chain.failed(), tc.value)
+		value.chain.clearFailed()
+
+		value.IsUint(tc.bits...)
+		assert.Equal(t, !tc.isUint, value.chain.failed(), tc.value)
+		value.chain.clearFailed()
+
+		value.NotUint(tc.bits...)
+		assert.Equal(t, tc.isUint, value.chain.failed(), tc.value)
+		value.chain.clearFailed()
+	}
+
+	reporter := newMockReporter(t)
+
+	value := NewNumber(reporter, 1)
+
+	value.IsInt(7)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsInt(8, 16)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestNumberAsInt(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewNumber(reporter, -123)
+
+	assert.Equal(t, int64(-123), value.AsInt())
+	value.chain.assertNotFailed(t)
+
+	assert.Equal(t, uint64(0), value.AsUint())
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value = NewNumber(reporter, 123.5)
+
+	assert.Equal(t, int64(0), value.AsInt())
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value = NewNumber(reporter, 1e19)
+
+	assert.Equal(t, int64(0), value.AsInt())
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	assert.Equal(t, uint64(1e19), value.AsUint())
+	value.chain.assertNotFailed(t)
+}
+
+func TestNumberExact(t *testing.T) {
+	chain := newMockChain(t)
+
+	value := newNumberExact(chain, json.Number("1234567890123456789"))
+
+	assert.Equal(t, int64(1234567890123456789), value.AsInt())
+	assert.Equal(t, uint64(1234567890123456789), value.AsUint())
+	value.chain.assertNotFailed(t)
+
+	value.Equal(int64(1234567890123456789))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Equal(json.Number("1234567890123456789"))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Equal(int64(1234567890123456788))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqual(int64(1234567890123456788))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqual(uint64(1234567890123456789))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsInt()
+	value.IsUint()
+	value.chain.assertNotFailed(t)
+
+	value.IsInt(32)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value = newNumberExact(chain, json.Number("18446744073709551616"))
+
+	value.IsUint()
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotUint()
+	value.chain.assertNotFailed(t)
+}
+
+func TestNumberEqualDecimal(t *testing.T) {
+	chain := newMockChain(t)
+
+	value := newNumberExact(chain, json.Number("123456789012345678.99"))
+
+	value.EqualDecimal("123456789012345678.99")
+	value.EqualDecimal("123456789012345678.990")
+	value.EqualDecimal("1.2345678901234567899e17")
+	value.chain.assertNotFailed(t)
+
+	value.Equal(json.Number("123456789012345678.99"))
+	value.chain.assertNotFailed(t)
+
+	value.EqualDecimal("123456789012345678.98")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Equal(123456789012345678.99)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualDecimal("123456789012345678.98")
+	value.chain.assertNotFailed(t)
+
+	value.NotEqualDecimal("123456789012345678.99")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsInt()
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualDecimal("bad")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualDecimal("1/3")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	reporter := newMockReporter(t)
+
+	number := NewNumber(reporter, 0.1)
+
+	number.EqualDecimal("0.1")
+	number.chain.assertNotFailed(t)
+
+	number.NotEqualDecimal("0.1000000000000000055511151231257827")
+	number.chain.assertNotFailed(t)
+
+	number = NewNumber(reporter, math.Inf(+1))
+
+	number.EqualDecimal("1")
+	number.chain.assertFailed(t)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 )
 
 // Object provides methods to inspect attached map[string]interface{} object
@@ -55,6 +56,26 @@ func (o *Object) Raw() map[string]interface{} {
 	return o.value
 }
 
+// Decode is similar to Value.Decode.
+//
+// Example:
+//
+//	type User struct {
+//		Name string `json:"name"`
+//	}
+//
+//	var user User
+//	object := NewObject(t, map[string]interface{}{"name": "john"})
+//	object.Decode(&user)
+func (o *Object) Decode(target interface{}) *Object {
+	o.chain.enter("Decode()")
+	defer o.chain.leave()
+
+	jsonUnmarshal(o.chain, o.value, target)
+
+	return o
+}
+
 // Path is similar to Value.Path.
 func (o *Object) Path(path string) *Value {
 	o.chain.enter("Path(%q)", path)
@@ -114,17 +135,8 @@ func (o *Object) Values() *Array {
 		return newArray(o.chain, nil)
 	}
 
-	keys := []string{}
-	for k := range o.value {
-		keys = append(keys, k)
-	}
-
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i] < keys[j]
-	})
-
 	values := []interface{}{}
-	for _, k := range keys {
+	for _, k := range o.sortedKeys() {
 		values = append(values, o.value[k])
 	}
 
@@ -192,9 +204,12 @@ func (o *Object) Iter() map[string]Value {
 	return obj
 }
 
-// Every runs the passed function for all the key value pairs in the object.
+// Every runs the passed function for all the key value pairs in the object,
+// in order of sorted keys.
 //
 // If assertion inside function fails, the original Object is marked failed.
+// Failure report includes key and value of failed element, see
+// AssertionContext.Element.
 //
 // Every will execute the function for all values in the object irrespective
 // of assertion failures for some values in the object.
@@ -226,16 +241,18 @@ func (o *Object) Every(fn func(key string, value *Value)) *Object {
 
 	chainFailure := false
 
-	for key, val := range o.value {
+	for _, key := range o.sortedKeys() {
+		val := o.value[key]
+
 		valueChain := o.chain.clone()
 		valueChain.replace("Every[%q]", key)
+		valueChain.setElement(AssertionElement{Index: -1, Key: key, Value: val})
 
 		valueChain.setFailCallback(func() {
 			chainFailure = true
 		})
 
 		fn(key, newValue(valueChain, val))
-
 	}
 
 	if chainFailure {
@@ -246,7 +263,7 @@ func (o *Object) Every(fn func(key string, value *Value)) *Object {
 }
 
 // Filter accepts a function that returns a boolean. The function is ran
-// over the object items. If the function returns true, the item passes
+// over the object items, in order of sorted keys. If the function returns true, the item passes
 // the filter and is added to the new object of filtered items. If false,
 // the value is skipped (or in other words filtered out). After iterating
 // through all the items of the original object, the new filtered object
@@ -287,7 +304,9 @@ func (o *Object) Filter(fn func(key string, value *Value) bool) *Object {
 
 	filteredObject := make(map[string]interface{})
 
-	for key, element := range o.value {
+	for _, key := range o.sortedKeys() {
+		element := o.value[key]
+
 		valueChain := o.chain.clone()
 		valueChain.setSeverity(SeverityLog)
 		chainFailed := false
@@ -295,6 +314,7 @@ func (o *Object) Filter(fn func(key string, value *Value) bool) *Object {
 			chainFailed = true
 		})
 		valueChain.replace("Filter[%q]", key)
+		valueChain.setElement(AssertionElement{Index: -1, Key: key, Value: element})
 		if fn(key, newValue(valueChain, element)) && !chainFailed {
 			filteredObject[key] = element
 		}
@@ -398,6 +418,7 @@ func (o *Object) NotEmpty() *Object {
 
 // Equal succeeds if object is equal to given value.
 // Before comparison, both object and value are converted to canonical form.
+// Paths from Config.IgnorePaths are excluded from comparison.
 //
 // value should be map[string]interface{} or struct.
 //
@@ -418,10 +439,15 @@ func (o *Object) Equal(value interface{}) *Object {
 		return o
 	}
 
-	if !reflect.DeepEqual(expected, o.value) {
+	actual, expected, ok := o.withoutIgnored(expected, o.chain.ignorePaths)
+	if !ok {
+		return o
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
 		o.chain.fail(AssertionFailure{
 			Type:     AssertEqual,
-			Actual:   &AssertionValue{o.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: maps are equal"),
@@ -432,8 +458,66 @@ func (o *Object) Equal(value interface{}) *Object {
 	return o
 }
 
+// EqualIgnoring succeeds if object is equal to given value, excluding
+// given fields from comparison. Before comparison, both object and value
+// are converted to canonical form.
+//
+// Every path is a dot-separated list of object keys and array indexes,
+// and asterisk matches any key or index. Fields are removed from copies
+// of both object and value, so object itself is not modified. Paths from
+// Config.IgnorePaths are excluded too.
+//
+// value should be map[string]interface{} or struct.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//	    "id": 123, "name": "john", "tags": []interface{}{
+//	        map[string]interface{}{"id": 1, "name": "admin"},
+//	    },
+//	})
+//	object.EqualIgnoring(map[string]interface{}{
+//	    "name": "john", "tags": []interface{}{
+//	        map[string]interface{}{"name": "admin"},
+//	    },
+//	}, "id", "tags.*.id")
+func (o *Object) EqualIgnoring(value interface{}, paths ...string) *Object {
+	o.chain.enter("EqualIgnoring()")
+	defer o.chain.leave()
+
+	if o.chain.failed() {
+		return o
+	}
+
+	expected, ok := canonMap(o.chain, value)
+	if !ok {
+		return o
+	}
+
+	ignored := append(splitPaths(paths), o.chain.ignorePaths...)
+
+	actual, expected, ok := o.withoutIgnored(expected, ignored)
+	if !ok {
+		return o
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		o.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				fmt.Errorf("expected: maps are equal, ignoring %v", paths),
+			},
+		})
+	}
+
+	return o
+}
+
 // NotEqual succeeds if object is not equal to given value.
 // Before comparison, both object and value are converted to canonical form.
+// Paths from Config.IgnorePaths are excluded from comparison.
 //
 // value should be map[string]interface{} or struct.
 //
@@ -454,10 +538,15 @@ func (o *Object) NotEqual(value interface{}) *Object {
 		return o
 	}
 
-	if reflect.DeepEqual(expected, o.value) {
+	actual, expected, ok := o.withoutIgnored(expected, o.chain.ignorePaths)
+	if !ok {
+		return o
+	}
+
+	if reflect.DeepEqual(expected, actual) {
 		o.chain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
-			Actual:   &AssertionValue{o.value},
+			Actual:   &AssertionValue{actual},
 			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: maps are non-equal"),
@@ -586,6 +675,9 @@ func (o *Object) NotContainsValue(value interface{}) *Object {
 // ContainsSubset succeeds if given value is a subset of object.
 // Before comparison, both object and value are converted to canonical form.
 //
+// Nested arrays should match exactly; use ContainsSubtree for recursive
+// subset matching of arrays.
+//
 // value should be map[string]interface{} or struct.
 //
 // Example:
@@ -787,6 +879,17 @@ func (o *Object) ValueNotEqual(key string, value interface{}) *Object {
 	return o.NotValueEqual(key, value)
 }
 
+func (o *Object) sortedKeys() []string {
+	keys := make([]string, 0, len(o.value))
+	for k := range o.value {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
 func (o *Object) containsKey(arg string) bool {
 	for k := range o.value {
 		if k == arg {
@@ -837,3 +940,33 @@ func checkSubset(outer, inner map[string]interface{}) bool {
 	}
 	return true
 }
+
+// Remove ignored fields from copy of object value and from expected value,
+// which should be already a copy (returned from canonMap).
+func (o *Object) withoutIgnored(
+	expected map[string]interface{}, paths [][]string,
+) (map[string]interface{}, map[string]interface{}, bool) {
+	if len(paths) == 0 {
+		return o.value, expected, true
+	}
+
+	actual, ok := canonMap(o.chain, o.value)
+	if !ok {
+		return nil, nil, false
+	}
+
+	for _, path := range paths {
+		removeField(actual, path)
+		removeField(expected, path)
+	}
+
+	return actual, expected, true
+}
+
+func splitPaths(paths []string) [][]string {
+	var ret [][]string
+	for _, path := range paths {
+		ret = append(ret, strings.Split(path, "."))
+	}
+	return ret
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestObjectFailed(t *testing.T) {
@@ -12,6 +13,8 @@ func TestObjectFailed(t *testing.T) {
 
 		value.Path("$")
 		value.Schema("")
+		value.Match(nil)
+		value.IfPresent("foo", func(*Value) {})
 
 		assert.NotNil(t, value.Keys())
 		assert.NotNil(t, value.Values())
@@ -22,12 +25,14 @@ func TestObjectFailed(t *testing.T) {
 		value.NotEmpty()
 		value.Equal(nil)
 		value.NotEqual(nil)
+		value.EqualIgnoring(nil, "foo")
 		value.ContainsKey("foo")
 		value.NotContainsKey("foo")
 		value.ContainsValue("foo")
 		value.NotContainsValue("foo")
 		value.ContainsSubset(nil)
 		value.NotContainsSubset(nil)
+		value.ContainsSubtree(nil)
 		value.ValueEqual("foo", nil)
 		value.NotValueEqual("foo", nil)
 		value.Every(func(_ string, value *Value) {
@@ -289,6 +294,185 @@ func TestObjectEqualStruct(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestObjectEqualIgnoring(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	newValue := func() *Object {
+		return NewObject(reporter, map[string]interface{}{
+			"id":   123,
+			"name": "john",
+			"meta": map[string]interface{}{
+				"trace_id": "abc",
+				"version":  1,
+			},
+			"tags": []interface{}{
+				map[string]interface{}{"id": 1, "name": "admin"},
+				map[string]interface{}{"id": 2, "name": "dev"},
+			},
+		})
+	}
+
+	expected := map[string]interface{}{
+		"id":   456,
+		"name": "john",
+		"meta": map[string]interface{}{
+			"trace_id": "def",
+			"version":  1,
+		},
+		"tags": []interface{}{
+			map[string]interface{}{"id": 3, "name": "admin"},
+			map[string]interface{}{"id": 4, "name": "dev"},
+		},
+	}
+
+	t.Run("all ignored", func(t *testing.T) {
+		value := newValue()
+
+		value.EqualIgnoring(expected, "id", "meta.trace_id", "tags.*.id")
+		value.chain.assertNotFailed(t)
+
+		assert.Equal(t, 123.0, value.Raw()["id"])
+		assert.Equal(t, "abc", value.Raw()["meta"].(map[string]interface{})["trace_id"])
+	})
+
+	t.Run("partially ignored", func(t *testing.T) {
+		value := newValue()
+
+		value.EqualIgnoring(expected, "id", "meta.trace_id")
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("missing on one side", func(t *testing.T) {
+		value := newValue()
+
+		value.EqualIgnoring(map[string]interface{}{
+			"name": "john",
+			"meta": map[string]interface{}{
+				"version": 1,
+			},
+			"tags": []interface{}{
+				map[string]interface{}{"name": "admin"},
+				map[string]interface{}{"name": "dev"},
+			},
+		}, "id", "meta.trace_id", "tags.*.id")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("no paths", func(t *testing.T) {
+		value := newValue()
+
+		value.EqualIgnoring(expected)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestObjectIgnorePaths(t *testing.T) {
+	config := newMockConfig(newMockReporter(t))
+	config.IgnorePaths = []string{"id", "items.*.created_at"}
+
+	newValue := func() *Object {
+		return newObject(newChainWithConfig("test", config),
+			map[string]interface{}{
+				"id":   1,
+				"name": "john",
+				"items": []interface{}{
+					map[string]interface{}{"sku": "a", "created_at": "2020"},
+				},
+			})
+	}
+
+	t.Run("Equal", func(t *testing.T) {
+		value := newValue()
+
+		value.Equal(map[string]interface{}{
+			"id":   2,
+			"name": "john",
+			"items": []interface{}{
+				map[string]interface{}{"sku": "a", "created_at": "2021"},
+			},
+		})
+		value.chain.assertNotFailed(t)
+
+		value.Equal(map[string]interface{}{
+			"name": "bob",
+		})
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("NotEqual", func(t *testing.T) {
+		value := newValue()
+
+		value.NotEqual(map[string]interface{}{
+			"id":   2,
+			"name": "john",
+			"items": []interface{}{
+				map[string]interface{}{"sku": "a"},
+			},
+		})
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("EqualIgnoring", func(t *testing.T) {
+		value := newValue()
+
+		value.EqualIgnoring(map[string]interface{}{
+			"id": 2,
+			"items": []interface{}{
+				map[string]interface{}{"sku": "a"},
+			},
+		}, "name")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		value := newValue()
+
+		value.Equal(map[string]interface{}{})
+		value.chain.assertFailed(t)
+
+		assert.Equal(t, 1.0, value.Raw()["id"])
+	})
+}
+
+func TestObjectDecode(t *testing.T) {
+	type (
+		Bar struct {
+			Baz []bool `json:"baz"`
+		}
+
+		S struct {
+			Foo int `json:"foo"`
+			Bar Bar `json:"bar"`
+		}
+	)
+
+	data := map[string]interface{}{
+		"foo": 123,
+		"bar": map[string]interface{}{
+			"baz": []interface{}{true, false},
+			"qux": "extra",
+		},
+	}
+
+	for _, strict := range []bool{false, true} {
+		config := Config{
+			Reporter:       newMockReporter(t),
+			StrictDecoding: strict,
+		}.withDefaults()
+
+		var s S
+		value := newObject(newChainWithConfig("Object()", config), data)
+		value.Decode(&s)
+
+		if strict {
+			value.chain.assertFailed(t)
+		} else {
+			value.chain.assertNotFailed(t)
+			assert.Equal(t, S{Foo: 123, Bar: Bar{Baz: []bool{true, false}}}, s)
+		}
+	}
+}
+
 func TestObjectContainsKey(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -842,6 +1026,41 @@ func TestObjectEvery(t *testing.T) {
 		object.chain.assertFailed(ts)
 		assert.Equal(t, 2, invoked)
 	})
+
+	t.Run("Sorted keys", func(ts *testing.T) {
+		reporter := newMockReporter(ts)
+		object := NewObject(reporter, map[string]interface{}{
+			"c": 1, "a": 2, "d": 3, "b": 4,
+		})
+		var keys []string
+		object.Every(func(key string, _ *Value) {
+			keys = append(keys, key)
+		})
+		assert.Equal(ts, []string{"a", "b", "c", "d"}, keys)
+	})
+
+	t.Run("Failed value in context", func(ts *testing.T) {
+		handler := &elementContextHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		object := newObject(chain, map[string]interface{}{
+			"user-1": map[string]interface{}{"name": "alice"},
+			"user-2": map[string]interface{}{"name": ""},
+		})
+		object.Every(func(_ string, val *Value) {
+			val.Object().Value("name").String().NotEmpty()
+		})
+		object.chain.assertFailed(ts)
+
+		require.Equal(ts, 1, len(handler.failures))
+		require.NotNil(ts, handler.failures[0].Element)
+		assert.Equal(ts, -1, handler.failures[0].Element.Index)
+		assert.Equal(ts, "user-2", handler.failures[0].Element.Key)
+		assert.Equal(ts, map[string]interface{}{"name": ""},
+			handler.failures[0].Element.Value)
+	})
 }
 
 func TestObjectTransform(t *testing.T) {
@@ -0,0 +1,577 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// PaginateOpts defines how Request.Paginate walks a paginated endpoint.
+type PaginateOpts struct {
+	// Items extracts items of a single page from response.
+	// If nil, response body is expected to be a JSON array.
+	Items func(resp *Response) *Array
+
+	// Next returns request for the next page, or nil if the page is last.
+	// Should not be nil.
+	Next func(resp *Response) *Request
+
+	// Maximum number of pages to fetch. If pagination doesn't terminate
+	// after that many pages, failure is reported.
+	// If zero, 100 is used.
+	MaxPages int
+}
+
+const defaultMaxPages = 100
+
+// Pages provides methods to inspect items collected from all pages of a
+// paginated endpoint.
+type Pages struct {
+	chain *chain
+	pages [][]interface{}
+}
+
+func newPages(parent *chain, pages [][]interface{}) *Pages {
+	return &Pages{parent.clone(), pages}
+}
+
+// Paginate sends request, and then keeps fetching next pages using
+// opts.Next until it returns nil. Items of every page are extracted using
+// opts.Items and collected into a new Pages instance.
+//
+// If any page request fails, or pagination doesn't terminate after
+// opts.MaxPages pages, failure is reported.
+//
+// Example:
+//
+//	pages := e.GET("/items").WithQuery("page", 1).
+//	    Paginate(httpexpect.PaginateOpts{
+//	        Items: func(resp *httpexpect.Response) *httpexpect.Array {
+//	            return resp.JSON().Object().Value("items").Array()
+//	        },
+//	        Next: func(resp *httpexpect.Response) *httpexpect.Request {
+//	            next := resp.JSON().Object().Value("next_page")
+//	            if next.Raw() == nil {
+//	                return nil
+//	            }
+//	            return e.GET("/items").WithQuery("page", next.Raw())
+//	        },
+//	    })
+//
+//	pages.Total().Equal(42)
+//	pages.NoDuplicates("id")
+//	pages.All().Element(0).Object().ValueEqual("id", 1)
+func (r *Request) Paginate(opts PaginateOpts) *Pages {
+	r.chain.enter("Paginate()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newPages(r.chain, nil)
+	}
+
+	if opts.Next == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil PaginateOpts.Next"),
+			},
+		})
+		return newPages(r.chain, nil)
+	}
+
+	if opts.MaxPages < 0 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative PaginateOpts.MaxPages"),
+			},
+		})
+		return newPages(r.chain, nil)
+	}
+
+	maxPages := opts.MaxPages
+	if maxPages == 0 {
+		maxPages = defaultMaxPages
+	}
+
+	// Failures are usually reported by child matchers (e.g. Array returned
+	// by Items), so we use fail callback inherited by children to detect them.
+	failed := false
+	parentCb := r.chain.failCb
+	failCb := func() {
+		failed = true
+		if parentCb != nil {
+			parentCb()
+		}
+	}
+	defer r.chain.setFailCallback(parentCb)
+
+	var pages [][]interface{}
+
+	req := r
+
+	for {
+		if len(pages) == maxPages {
+			r.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf(
+						"pagination did not terminate after %d pages", maxPages),
+				},
+			})
+			return newPages(r.chain, pages)
+		}
+
+		req.chain.setFailCallback(failCb)
+
+		resp := req.Expect()
+
+		var items *Array
+		if opts.Items != nil {
+			items = opts.Items(resp)
+		} else {
+			items = resp.JSON().Array()
+		}
+
+		if failed || resp.chain.failed() || items == nil || items.chain.failed() {
+			r.chain.setFailed()
+			return newPages(r.chain, pages)
+		}
+
+		pages = append(pages, items.Raw())
+
+		req = opts.Next(resp)
+		if failed {
+			r.chain.setFailed()
+			return newPages(r.chain, pages)
+		}
+		if req == nil {
+			break
+		}
+	}
+
+	return newPages(r.chain, pages)
+}
+
+// NextPageLink returns function for PaginateOpts.Next, which follows
+// URL from Link header with rel="next" (RFC 8288). Relative URLs are
+// resolved against URL of the current page.
+//
+// Next page request is created using given Expect instance, so its
+// builders and matchers are applied, and has the same method and headers
+// as request of the current page. Pagination stops when response has no
+// "next" link.
+//
+// Example:
+//
+//	pages := e.GET("/items").Paginate(httpexpect.PaginateOpts{
+//	    Next: httpexpect.NextPageLink(e),
+//	})
+func NextPageLink(e *Expect) func(resp *Response) *Request {
+	return func(resp *Response) *Request {
+		resp.chain.enter("NextPageLink()")
+		defer resp.chain.leave()
+
+		if resp.chain.failed() || !checkNextPageResponse(resp) {
+			return nil
+		}
+
+		target, found, ok := resp.lookupLink("next")
+		if !ok || !found {
+			return nil
+		}
+
+		return nextPageRequest(e, resp, target)
+	}
+}
+
+// NextPageCursor returns function for PaginateOpts.Next, which reads
+// cursor from JSON response body and repeats request of the current page
+// with query parameter param set to the cursor.
+//
+// field defines path to the cursor in the body, e.g. "meta", "next_cursor"
+// for {"meta": {"next_cursor": "abc"}}. Cursor can be a string or a number,
+// so it can be used for offset-based pagination too, when server returns
+// offset of the next page.
+//
+// Next page request is created using given Expect instance, so its
+// builders and matchers are applied, and has the same method and headers
+// as request of the current page. Pagination stops when the cursor is
+// missing, null, or empty string.
+//
+// Example:
+//
+//	pages := e.GET("/items").Paginate(httpexpect.PaginateOpts{
+//	    Items: func(resp *httpexpect.Response) *httpexpect.Array {
+//	        return resp.JSON().Object().Value("items").Array()
+//	    },
+//	    Next: httpexpect.NextPageCursor(e, "cursor", "next_cursor"),
+//	})
+func NextPageCursor(e *Expect, param string, field ...string) func(resp *Response) *Request {
+	return func(resp *Response) *Request {
+		resp.chain.enter("NextPageCursor()")
+		defer resp.chain.leave()
+
+		if resp.chain.failed() || !checkNextPageResponse(resp) {
+			return nil
+		}
+
+		if len(field) == 0 {
+			resp.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected empty cursor field path"),
+				},
+			})
+			return nil
+		}
+
+		body := resp.JSON()
+		if body.chain.failed() {
+			return nil
+		}
+
+		value := body.Raw()
+		for _, key := range field {
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			value = obj[key]
+		}
+
+		var cursor string
+
+		switch v := value.(type) {
+		case nil:
+			return nil
+		case string:
+			cursor = v
+		case float64:
+			cursor = strconv.FormatFloat(v, 'f', -1, 64)
+		case json.Number:
+			cursor = v.String()
+		default:
+			resp.chain.fail(AssertionFailure{
+				Type:   AssertType,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: cursor is a string or a number"),
+				},
+			})
+			return nil
+		}
+
+		if cursor == "" {
+			return nil
+		}
+
+		target := *resp.httpResp.Request.URL
+
+		query := target.Query()
+		query.Set(param, cursor)
+		target.RawQuery = query.Encode()
+
+		return nextPageRequest(e, resp, &target)
+	}
+}
+
+func checkNextPageResponse(resp *Response) bool {
+	if resp.httpResp == nil || resp.httpResp.Request == nil ||
+		resp.httpResp.Request.URL == nil {
+		resp.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("response has no associated request"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// Create request for next page with given URL, reusing method and headers
+// of request of the current page.
+func nextPageRequest(e *Expect, resp *Response, target *url.URL) *Request {
+	prevReq := resp.httpResp.Request
+
+	req := e.newRequest(prevReq.Method, "")
+	if req.chain.failed() {
+		return req
+	}
+
+	req.httpReq.URL = prevReq.URL.ResolveReference(target)
+	req.pathTemplate = req.httpReq.URL.Path
+
+	for k, v := range prevReq.Header {
+		req.httpReq.Header[k] = append([]string(nil), v...)
+	}
+
+	return req
+}
+
+// Raw returns items of every page.
+func (p *Pages) Raw() [][]interface{} {
+	return p.pages
+}
+
+// PageCount returns a new Number instance with number of fetched pages.
+//
+// Example:
+//
+//	pages.PageCount().Equal(5)
+func (p *Pages) PageCount() *Number {
+	p.chain.enter("PageCount()")
+	defer p.chain.leave()
+
+	if p.chain.failed() {
+		return newNumber(p.chain, 0)
+	}
+
+	return newNumber(p.chain, float64(len(p.pages)))
+}
+
+// Page returns a new Array instance with items of page with given index.
+//
+// Example:
+//
+//	pages.Page(0).Length().Equal(10)
+func (p *Pages) Page(index int) *Array {
+	p.chain.enter("Page(%d)", index)
+	defer p.chain.leave()
+
+	if p.chain.failed() {
+		return newArray(p.chain, nil)
+	}
+
+	if index < 0 || index >= len(p.pages) {
+		p.chain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(p.pages) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid page index"),
+			},
+		})
+		return newArray(p.chain, nil)
+	}
+
+	return newArray(p.chain, p.pages[index])
+}
+
+// All returns a new Array instance with items of all pages concatenated.
+//
+// Example:
+//
+//	pages.All().Length().Equal(42)
+func (p *Pages) All() *Array {
+	p.chain.enter("All()")
+	defer p.chain.leave()
+
+	if p.chain.failed() {
+		return newArray(p.chain, nil)
+	}
+
+	return newArray(p.chain, p.items())
+}
+
+// Total returns a new Number instance with total number of items on
+// all pages.
+//
+// Example:
+//
+//	pages.Total().Equal(42)
+func (p *Pages) Total() *Number {
+	p.chain.enter("Total()")
+	defer p.chain.leave()
+
+	if p.chain.failed() {
+		return newNumber(p.chain, 0)
+	}
+
+	return newNumber(p.chain, float64(len(p.items())))
+}
+
+// NoDuplicates succeeds if no item appears more than once across all pages.
+//
+// If field is given, items should be objects and are compared by value of
+// that field (e.g. "id"). Otherwise, whole items are compared.
+//
+// Example:
+//
+//	pages.NoDuplicates("id")
+func (p *Pages) NoDuplicates(field ...string) *Pages {
+	p.chain.enter("NoDuplicates()")
+	defer p.chain.leave()
+
+	if p.chain.failed() {
+		return p
+	}
+
+	if len(field) > 1 {
+		p.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple field arguments"),
+			},
+		})
+		return p
+	}
+
+	type location struct {
+		page  int
+		index int
+	}
+
+	seen := map[string]location{}
+
+	for pageIdx, page := range p.pages {
+		for itemIdx, item := range page {
+			key, ok := p.itemKey(item, field)
+			if !ok {
+				return p
+			}
+
+			if prev, dup := seen[key]; dup {
+				p.chain.fail(AssertionFailure{
+					Type:     AssertNotContainsElement,
+					Actual:   &AssertionValue{p.items()},
+					Expected: &AssertionValue{item},
+					Errors: []error{
+						errors.New("expected: no duplicate items across pages"),
+						fmt.Errorf(
+							"item %d on page %d duplicates item %d on page %d",
+							itemIdx, pageIdx, prev.index, prev.page),
+					},
+				})
+				return p
+			}
+
+			seen[key] = location{pageIdx, itemIdx}
+		}
+	}
+
+	return p
+}
+
+// Ordered succeeds if items of all pages, concatenated, are ordered
+// according to given less function, i.e. there is no item which is less
+// than its predecessor. This allows to check that ordering is stable
+// across page boundaries.
+//
+// Example:
+//
+//	pages.Ordered(func(a, b *httpexpect.Value) bool {
+//	    return a.Object().Value("id").Number().Raw() <
+//	        b.Object().Value("id").Number().Raw()
+//	})
+func (p *Pages) Ordered(less func(a, b *Value) bool) *Pages {
+	p.chain.enter("Ordered()")
+	defer p.chain.leave()
+
+	if p.chain.failed() {
+		return p
+	}
+
+	if less == nil {
+		p.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return p
+	}
+
+	items := p.items()
+
+	for i := 1; i < len(items); i++ {
+		prev := newValue(p.chain, items[i-1])
+		curr := newValue(p.chain, items[i])
+
+		if less(curr, prev) {
+			page, index := p.locate(i)
+			p.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{items},
+				Errors: []error{
+					errors.New("expected: items are ordered across pages"),
+					fmt.Errorf(
+						"item %d on page %d is less than its predecessor", index, page),
+				},
+			})
+			return p
+		}
+	}
+
+	return p
+}
+
+func (p *Pages) items() []interface{} {
+	items := []interface{}{}
+	for _, page := range p.pages {
+		items = append(items, page...)
+	}
+	return items
+}
+
+func (p *Pages) locate(n int) (int, int) {
+	for pageIdx, page := range p.pages {
+		if n < len(page) {
+			return pageIdx, n
+		}
+		n -= len(page)
+	}
+	return -1, -1
+}
+
+func (p *Pages) itemKey(item interface{}, field []string) (string, bool) {
+	value := item
+
+	if len(field) != 0 {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			p.chain.fail(AssertionFailure{
+				Type:   AssertType,
+				Actual: &AssertionValue{item},
+				Errors: []error{
+					errors.New("expected: items are objects"),
+				},
+			})
+			return "", false
+		}
+
+		value, ok = obj[field[0]]
+		if !ok {
+			p.chain.fail(AssertionFailure{
+				Type:     AssertContainsKey,
+				Actual:   &AssertionValue{item},
+				Expected: &AssertionValue{field[0]},
+				Errors: []error{
+					errors.New("expected: items contain given key"),
+				},
+			})
+			return "", false
+		}
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		p.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to marshal item"),
+				err,
+			},
+		})
+		return "", false
+	}
+
+	return string(b), true
+}
@@ -0,0 +1,321 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createPaginationHandler(pages [][]interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		body := map[string]interface{}{
+			"items": pages[page],
+		}
+		if page+1 < len(pages) {
+			body["next"] = page + 1
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
+
+func paginationOpts(e *Expect) PaginateOpts {
+	return PaginateOpts{
+		Items: func(resp *Response) *Array {
+			return resp.JSON().Object().Value("items").Array()
+		},
+		Next: func(resp *Response) *Request {
+			next, ok := resp.JSON().Object().Raw()["next"]
+			if !ok {
+				return nil
+			}
+			return e.GET("/items").WithQuery("page", next)
+		},
+	}
+}
+
+func TestPagesFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	pages := newPages(chain, nil)
+
+	assert.NotNil(t, pages.PageCount())
+	assert.NotNil(t, pages.Page(0))
+	assert.NotNil(t, pages.All())
+	assert.NotNil(t, pages.Total())
+	assert.NotNil(t, pages.NoDuplicates("id"))
+	assert.NotNil(t, pages.Ordered(func(a, b *Value) bool { return false }))
+
+	pages.chain.assertFailed(t)
+}
+
+func TestPagesWalk(t *testing.T) {
+	e, reporter := newMockExpect(t, createPaginationHandler([][]interface{}{
+		{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 2}},
+		{map[string]interface{}{"id": 3}, map[string]interface{}{"id": 4}},
+		{map[string]interface{}{"id": 5}},
+	}))
+
+	pages := e.GET("/items").WithQuery("page", 0).Paginate(paginationOpts(e))
+	pages.chain.assertNotFailed(t)
+
+	pages.PageCount().Equal(3)
+	pages.Total().Equal(5)
+	pages.Page(1).Length().Equal(2)
+	pages.All().Element(4).Object().ValueEqual("id", 5)
+
+	pages.NoDuplicates("id")
+	pages.NoDuplicates()
+	pages.Ordered(func(a, b *Value) bool {
+		return a.Object().Value("id").Number().Raw() <
+			b.Object().Value("id").Number().Raw()
+	})
+
+	pages.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+	assert.Equal(t, 3, len(pages.Raw()))
+
+	pages.Page(3)
+	pages.chain.assertFailed(t)
+}
+
+func TestPagesDefaultItems(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[1, 2, 3]`))
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Client:   &http.Client{Transport: NewBinder(handler)},
+		Reporter: newMockReporter(t),
+	})
+
+	pages := e.GET("/items").Paginate(PaginateOpts{
+		Next: func(resp *Response) *Request {
+			return nil
+		},
+	})
+
+	pages.All().Equal([]interface{}{1, 2, 3})
+	pages.chain.assertNotFailed(t)
+}
+
+func TestPagesDuplicates(t *testing.T) {
+	e, _ := newMockExpect(t, createPaginationHandler([][]interface{}{
+		{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 2}},
+		{map[string]interface{}{"id": 2}, map[string]interface{}{"id": 3}},
+	}))
+
+	pages := e.GET("/items").WithQuery("page", 0).Paginate(paginationOpts(e))
+	pages.chain.assertNotFailed(t)
+
+	pages.NoDuplicates("id")
+	pages.chain.assertFailed(t)
+	pages.chain.clearFailed()
+
+	pages.NoDuplicates("missing")
+	pages.chain.assertFailed(t)
+}
+
+func TestPagesUnordered(t *testing.T) {
+	e, _ := newMockExpect(t, createPaginationHandler([][]interface{}{
+		{1, 2},
+		{2, 1},
+	}))
+
+	pages := e.GET("/items").WithQuery("page", 0).Paginate(paginationOpts(e))
+	pages.chain.assertNotFailed(t)
+
+	pages.Ordered(func(a, b *Value) bool {
+		return a.Number().Raw() < b.Number().Raw()
+	})
+	pages.chain.assertFailed(t)
+}
+
+func TestPagesErrors(t *testing.T) {
+	t.Run("nil_next", func(t *testing.T) {
+		e, _ := newMockExpect(t, createPaginationHandler([][]interface{}{{1}}))
+
+		pages := e.GET("/items").Paginate(PaginateOpts{})
+		pages.chain.assertFailed(t)
+	})
+
+	t.Run("negative_max_pages", func(t *testing.T) {
+		e, _ := newMockExpect(t, createPaginationHandler([][]interface{}{{1}}))
+
+		opts := paginationOpts(e)
+		opts.MaxPages = -1
+
+		pages := e.GET("/items").Paginate(opts)
+		pages.chain.assertFailed(t)
+	})
+
+	t.Run("not_terminating", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createPaginationHandler([][]interface{}{{1}}))
+
+		opts := paginationOpts(e)
+		opts.MaxPages = 3
+		opts.Next = func(resp *Response) *Request {
+			return e.GET("/items").WithQuery("page", 0)
+		}
+
+		pages := e.GET("/items").WithQuery("page", 0).Paginate(opts)
+		pages.chain.assertFailed(t)
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 3, len(pages.Raw()))
+	})
+
+	t.Run("bad_items", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createPaginationHandler([][]interface{}{{1}, {2}}))
+
+		opts := paginationOpts(e)
+		opts.Items = func(resp *Response) *Array {
+			return resp.JSON().Object().Value("missing").Array()
+		}
+
+		pages := e.GET("/items").WithQuery("page", 0).Paginate(opts)
+		pages.chain.assertFailed(t)
+
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 0, len(pages.Raw()))
+	})
+}
+
+func TestPagesNextPageLink(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		switch page {
+		case 0:
+			w.Header().Set("Link",
+				`<http://example.com/items?page=1>; rel="next", </items?page=2>; rel=last`)
+		case 1:
+			w.Header().Set("Link", `</items?page=2>; rel="last next"`)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, "[%d]", page)
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Client:   &http.Client{Transport: NewBinder(handler)},
+		Reporter: reporter,
+	})
+
+	pages := e.GET("/items").
+		WithHeader("Authorization", "secret").
+		Paginate(PaginateOpts{
+			Next: NextPageLink(e),
+		})
+
+	pages.All().Equal([]interface{}{0, 1, 2})
+	pages.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+}
+
+func TestPagesNextPageCursor(t *testing.T) {
+	t.Run("cursor", func(t *testing.T) {
+		cursors := map[string]interface{}{"": "abc", "abc": "def", "def": nil}
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("cursor")
+
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"items": []interface{}{cursor},
+				"meta":  map[string]interface{}{"next": cursors[cursor]},
+			})
+		})
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: NewBinder(handler)},
+			Reporter: newMockReporter(t),
+		})
+
+		pages := e.GET("/items").WithQuery("limit", 10).Paginate(PaginateOpts{
+			Items: func(resp *Response) *Array {
+				return resp.JSON().Object().Value("items").Array()
+			},
+			Next: NextPageCursor(e, "cursor", "meta", "next"),
+		})
+
+		pages.All().Equal([]interface{}{"", "abc", "def"})
+		pages.chain.assertNotFailed(t)
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+			body := map[string]interface{}{
+				"items": []interface{}{offset, offset + 1},
+			}
+			if offset < 4 {
+				body["next_offset"] = offset + 2
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(body)
+		})
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: NewBinder(handler)},
+			Reporter: newMockReporter(t),
+		})
+
+		pages := e.GET("/items").Paginate(PaginateOpts{
+			Items: func(resp *Response) *Array {
+				return resp.JSON().Object().Value("items").Array()
+			},
+			Next: NextPageCursor(e, "offset", "next_offset"),
+		})
+
+		pages.PageCount().Equal(3)
+		pages.All().Equal([]interface{}{0, 1, 2, 3, 4, 5})
+		pages.NoDuplicates()
+		pages.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items": [1], "next": true}`))
+		})
+
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Client:   &http.Client{Transport: NewBinder(handler)},
+			Reporter: reporter,
+		})
+
+		pages := e.GET("/items").Paginate(PaginateOpts{
+			Items: func(resp *Response) *Array {
+				return resp.JSON().Object().Value("items").Array()
+			},
+			Next: NextPageCursor(e, "cursor", "next"),
+		})
+
+		pages.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+}
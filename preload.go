@@ -0,0 +1,326 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// EarlyHints returns a new Array instance with headers of all
+// "103 Early Hints" informational responses received before the final
+// response, in order of arrival.
+//
+// Every element is an object with the same layout as in Response.Headers,
+// i.e. every header is represented as an array of its values.
+//
+// Early hints are collected using net/http/httptrace, so they're available
+// only when request is sent by a client that supports httptrace, e.g.
+// http.Client with default transport. If no early hints were received,
+// empty array is returned.
+//
+// Example:
+//
+//	resp := req.Expect()
+//	resp.EarlyHints().Length().Equal(1)
+//	resp.EarlyHints().Element(0).Object().ContainsKey("Link")
+func (r *Response) EarlyHints() *Array {
+	r.chain.enter("EarlyHints()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	hints := []interface{}{}
+
+	for _, header := range r.earlyHints() {
+		value := make(map[string]interface{}, len(header))
+		for key, values := range header {
+			list := make([]interface{}, 0, len(values))
+			for _, v := range values {
+				list = append(list, v)
+			}
+			value[key] = list
+		}
+		hints = append(hints, value)
+	}
+
+	return newArray(r.chain, hints)
+}
+
+// PreloadHints returns a new Array instance with all preload hints sent
+// by server in "Link" headers of "103 Early Hints" responses and of the
+// final response.
+//
+// Only links with "preload" or "modulepreload" relation type are included.
+// Every element is an object with the following fields:
+//   - "url": link target, as written in header
+//   - "rel": link relation type
+//   - "source": "early-hints" or "response", where link was found
+//
+// and all link parameters, e.g. "as", "type", "crossorigin". Parameter
+// names are lower-cased. Parameters without value, e.g. "nopush", are
+// represented as true.
+//
+// Note that HTTP/2 server push is disabled by net/http client, so pushed
+// resources can't be observed; however, servers usually announce pushes
+// using the same preload links, which can be checked here.
+//
+// If some "Link" header is malformed, failure is reported.
+//
+// Example:
+//
+//	resp := req.Expect()
+//	hints := resp.PreloadHints()
+//	hints.Length().Equal(2)
+//	hints.Element(0).Object().
+//	    ValueEqual("url", "/style.css").
+//	    ValueEqual("as", "style")
+func (r *Response) PreloadHints() *Array {
+	r.chain.enter("PreloadHints()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	hints := []interface{}{}
+
+	type linkSource struct {
+		name   string
+		header http.Header
+	}
+
+	var sources []linkSource
+
+	for _, header := range r.earlyHints() {
+		sources = append(sources, linkSource{"early-hints", header})
+	}
+
+	sources = append(sources, linkSource{"response", r.httpResp.Header})
+
+	for _, src := range sources {
+		for _, value := range src.header.Values("Link") {
+			links, err := parseLinkHeader(value)
+			if err != nil {
+				r.chain.fail(AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{value},
+					Errors: []error{
+						errors.New(`invalid "Link" response header`),
+						err,
+					},
+				})
+				return newArray(r.chain, nil)
+			}
+
+			for _, l := range links {
+				rel := l.rel()
+				if rel == "" {
+					continue
+				}
+
+				hint := map[string]interface{}{}
+				for k, v := range l.params {
+					hint[k] = v
+				}
+
+				hint["url"] = l.url
+				hint["rel"] = rel
+				hint["source"] = src.name
+
+				hints = append(hints, hint)
+			}
+		}
+	}
+
+	return newArray(r.chain, hints)
+}
+
+func (r *Response) earlyHints() []http.Header {
+	if r.timing == nil {
+		return nil
+	}
+
+	r.timing.mu.Lock()
+	defer r.timing.mu.Unlock()
+
+	return append([]http.Header(nil), r.timing.earlyHints...)
+}
+
+// Single link from "Link" header, see RFC 8288.
+type headerLink struct {
+	url    string
+	params map[string]interface{}
+}
+
+// Returns preload relation type of link, or empty string.
+func (l headerLink) rel() string {
+	for _, r := range []string{"preload", "modulepreload"} {
+		if l.hasRel(r) {
+			return r
+		}
+	}
+
+	return ""
+}
+
+// Checks if link has given relation type.
+// Relation types are space-separated and case-insensitive.
+func (l headerLink) hasRel(rel string) bool {
+	value, _ := l.params["rel"].(string)
+
+	for _, r := range strings.Fields(value) {
+		if strings.EqualFold(r, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parses "Link" header value, e.g.:
+//
+//	</style.css>; rel=preload; as=style, </app.js>; rel="preload"; as=script
+func parseLinkHeader(value string) ([]headerLink, error) {
+	var links []headerLink
+
+	p := linkParser{s: value}
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+
+		if !p.consume('<') {
+			return nil, fmt.Errorf("expected '<' at position %d", p.pos)
+		}
+
+		end := strings.IndexByte(p.s[p.pos:], '>')
+		if end < 0 {
+			return nil, errors.New("unterminated link target")
+		}
+
+		link := headerLink{
+			url:    strings.TrimSpace(p.s[p.pos : p.pos+end]),
+			params: map[string]interface{}{},
+		}
+		p.pos += end + 1
+
+		for {
+			p.skipSpace()
+			if !p.consume(';') {
+				break
+			}
+			p.skipSpace()
+
+			name := strings.ToLower(p.token())
+			if name == "" {
+				return nil, fmt.Errorf("expected parameter name at position %d", p.pos)
+			}
+
+			p.skipSpace()
+			if !p.consume('=') {
+				// first occurrence of parameter wins
+				if _, ok := link.params[name]; !ok {
+					link.params[name] = true
+				}
+				continue
+			}
+			p.skipSpace()
+
+			var val string
+			if p.peek() == '"' {
+				v, err := p.quoted()
+				if err != nil {
+					return nil, err
+				}
+				val = v
+			} else {
+				val = p.token()
+			}
+
+			if _, ok := link.params[name]; !ok {
+				link.params[name] = val
+			}
+		}
+
+		links = append(links, link)
+
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("expected ',' at position %d", p.pos)
+		}
+	}
+
+	return links, nil
+}
+
+type linkParser struct {
+	s   string
+	pos int
+}
+
+func (p *linkParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *linkParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *linkParser) consume(c byte) bool {
+	if !p.eof() && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *linkParser) skipSpace() {
+	for !p.eof() && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *linkParser) token() string {
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t;,=\"", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *linkParser) quoted() (string, error) {
+	var b strings.Builder
+
+	p.pos++ // opening quote
+
+	for !p.eof() {
+		c := p.s[p.pos]
+		p.pos++
+
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", errors.New("unterminated quoted string")
+			}
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", errors.New("unterminated quoted string")
+}
@@ -0,0 +1,144 @@
+package httpexpect
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreloadFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	resp := newResponse(responseOpts{
+		config:   newMockConfig(newMockReporter(t)),
+		chain:    chain,
+		httpResp: &http.Response{},
+	})
+
+	resp.EarlyHints().chain.assertFailed(t)
+	resp.PreloadHints().chain.assertFailed(t)
+}
+
+func TestPreloadHints(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header{
+			"Link": {
+				`</style.css>; rel=preload; as=style, </next>; rel=next`,
+				`</app.js>; rel="modulepreload"; crossorigin; as=script; as=style`,
+				`<https://cdn.example.com/font.woff2>; REL="preload prefetch";` +
+					` type="font/woff2"; title="a \"quoted\", title"`,
+			},
+		},
+	})
+
+	hints := resp.PreloadHints()
+	resp.chain.assertNotFailed(t)
+
+	hints.Equal([]interface{}{
+		map[string]interface{}{
+			"url":    "/style.css",
+			"rel":    "preload",
+			"as":     "style",
+			"source": "response",
+		},
+		map[string]interface{}{
+			"url":         "/app.js",
+			"rel":         "modulepreload",
+			"as":          "script",
+			"crossorigin": true,
+			"source":      "response",
+		},
+		map[string]interface{}{
+			"url":    "https://cdn.example.com/font.woff2",
+			"rel":    "preload",
+			"type":   "font/woff2",
+			"title":  `a "quoted", title`,
+			"source": "response",
+		},
+	})
+	hints.chain.assertNotFailed(t)
+
+	resp.EarlyHints().Empty()
+	resp.chain.assertNotFailed(t)
+}
+
+func TestPreloadHintsInvalid(t *testing.T) {
+	cases := []string{
+		`/style.css; rel=preload`,
+		`</style.css; rel=preload`,
+		`</style.css>; rel="preload`,
+		`</style.css> rel=preload`,
+		`</style.css>; =preload`,
+	}
+
+	for _, value := range cases {
+		t.Run(value, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				Header: http.Header{"Link": {value}},
+			})
+
+			resp.PreloadHints()
+			resp.chain.assertFailed(t)
+		})
+	}
+}
+
+func TestPreloadEarlyHints(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = http.ReadRequest(bufio.NewReader(conn))
+
+		_, _ = conn.Write([]byte("HTTP/1.1 103 Early Hints\r\n" +
+			"Link: </style.css>; rel=preload; as=style\r\n" +
+			"\r\n" +
+			"HTTP/1.1 200 OK\r\n" +
+			"Link: </app.js>; rel=preload; as=script\r\n" +
+			"Content-Length: 0\r\n" +
+			"Connection: close\r\n" +
+			"\r\n"))
+	}()
+
+	e := WithConfig(Config{
+		BaseURL:  "http://" + l.Addr().String(),
+		Reporter: newMockReporter(t),
+	})
+
+	resp := e.GET("/").Expect()
+	resp.chain.assertNotFailed(t)
+
+	hints := resp.EarlyHints()
+	hints.Length().Equal(1)
+	hints.Element(0).Object().Value("Link").Array().
+		Elements("</style.css>; rel=preload; as=style")
+
+	preload := resp.PreloadHints()
+	preload.Length().Equal(2)
+	preload.Element(0).Object().
+		ValueEqual("url", "/style.css").
+		ValueEqual("source", "early-hints")
+	preload.Element(1).Object().
+		ValueEqual("url", "/app.js").
+		ValueEqual("source", "response")
+
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, 1, len(resp.earlyHints()))
+}
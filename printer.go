@@ -2,18 +2,27 @@ package httpexpect
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httputil"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
-	"moul.io/http2curl/v2"
 )
 
 // Printer is used to print requests and responses.
-// CompactPrinter, DebugPrinter, and CurlPrinter implement this interface.
+// CompactPrinter, DebugPrinter, CurlPrinter, and HARPrinter implement
+// this interface.
 type Printer interface {
 	// Request is called before request is sent.
 	// It is allowed to read and close request body, or ignore it.
@@ -40,21 +49,44 @@ type WebsocketPrinter interface {
 	WebsocketRead(typ int, content []byte, closeCode int)
 }
 
+// PrinterOpts defines additional options for CompactPrinter and DebugPrinter.
+type PrinterOpts struct {
+	// Highlight request and status lines and header names using ANSI
+	// colors. ColorModeAuto enables colors if logger is a test (e.g.
+	// *testing.T) and test log is written to a terminal.
+	// Default is ColorModeNever.
+	ColorMode ColorMode
+}
+
 // CompactPrinter implements Printer.
 // Prints requests in compact form. Does not print responses.
 type CompactPrinter struct {
 	logger Logger
+	colors ColorMode
 }
 
 // NewCompactPrinter returns a new CompactPrinter given a logger.
-func NewCompactPrinter(logger Logger) CompactPrinter {
-	return CompactPrinter{logger}
+//
+// Example:
+//
+//	printer := NewCompactPrinter(t, PrinterOpts{
+//	    ColorMode: ColorModeAuto,
+//	})
+func NewCompactPrinter(logger Logger, opts ...PrinterOpts) CompactPrinter {
+	p := CompactPrinter{logger: logger}
+
+	for _, o := range opts {
+		p.colors = o.ColorMode
+	}
+
+	return p
 }
 
 // Request implements Printer.Request.
 func (p CompactPrinter) Request(req *http.Request) {
 	if req != nil {
-		p.logger.Logf("%s %s", req.Method, req.URL)
+		enable := enableColors(p.colors, p.logger)
+		p.logger.Logf("%s %s", colorize(enable, "bold", req.Method), req.URL)
 	}
 }
 
@@ -63,25 +95,69 @@ func (CompactPrinter) Response(*http.Response, time.Duration) {
 }
 
 // CurlPrinter implements Printer.
-// Uses http2curl to dump requests as curl commands that can be inserted
-// into terminal.
+// Dumps requests as curl commands that can be inserted into terminal.
+//
+// Generated commands reproduce request method, URL, headers, cookies,
+// and body. Multipart forms are converted to -F arguments, and files
+// are referenced using @file syntax. HTTP/2 requests get --http2 flag.
 type CurlPrinter struct {
 	logger Logger
+	jar    http.CookieJar
+	output io.Writer
+}
+
+// CurlOpts defines additional options for CurlPrinter.
+type CurlOpts struct {
+	// If non-nil, cookies stored in jar for request URL are added to
+	// generated commands. Usually you want to pass here the same jar
+	// that is used by Config.Client.
+	Jar http.CookieJar
+
+	// If non-nil, commands are written to Output, one per line, instead
+	// of logger. Useful to produce a shell script reproducing test.
+	Output io.Writer
 }
 
 // NewCurlPrinter returns a new CurlPrinter given a logger.
-func NewCurlPrinter(logger Logger) CurlPrinter {
-	return CurlPrinter{logger}
+//
+// Example:
+//
+//	script, _ := os.Create("requests.sh")
+//	defer script.Close()
+//
+//	printer := NewCurlPrinter(nil, CurlOpts{
+//	    Jar:    jar,
+//	    Output: script,
+//	})
+func NewCurlPrinter(logger Logger, opts ...CurlOpts) CurlPrinter {
+	p := CurlPrinter{logger: logger}
+
+	for _, o := range opts {
+		if o.Jar != nil {
+			p.jar = o.Jar
+		}
+		if o.Output != nil {
+			p.output = o.Output
+		}
+	}
+
+	return p
 }
 
 // Request implements Printer.Request.
 func (p CurlPrinter) Request(req *http.Request) {
 	if req != nil {
-		cmd, err := http2curl.GetCurlCommand(req)
+		cmd, err := curlCommand(req, p.jar)
 		if err != nil {
 			panic(err)
 		}
-		p.logger.Logf("%s", cmd.String())
+		if p.output != nil {
+			if _, err := fmt.Fprintln(p.output, cmd); err != nil {
+				panic(err)
+			}
+		} else {
+			p.logger.Logf("%s", cmd)
+		}
 	}
 }
 
@@ -89,18 +165,171 @@ func (p CurlPrinter) Request(req *http.Request) {
 func (CurlPrinter) Response(*http.Response, time.Duration) {
 }
 
+func curlCommand(req *http.Request, jar http.CookieJar) (string, error) {
+	if req.URL == nil {
+		return "", errors.New("invalid request: URL is nil")
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		_ = req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+		body = b
+	}
+
+	args := []string{"curl"}
+
+	switch {
+	case req.ProtoMajor == 2:
+		args = append(args, "--http2")
+	case req.ProtoMajor == 1 && req.ProtoMinor == 0:
+		args = append(args, "--http1.0")
+	}
+
+	if req.URL.Scheme == "https" {
+		args = append(args, "-k")
+	}
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	args = append(args, "-X", curlQuote(method))
+
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	isMultipart := strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != ""
+
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		switch http.CanonicalHeaderKey(k) {
+		case "Cookie", "Content-Length":
+			continue
+		case "Content-Type":
+			if isMultipart {
+				continue
+			}
+		}
+		args = append(args, "-H",
+			curlQuote(fmt.Sprintf("%s: %s", k, strings.Join(req.Header[k], " "))))
+	}
+
+	if req.Host != "" && req.Host != req.URL.Host {
+		args = append(args, "-H", curlQuote("Host: "+req.Host))
+	}
+
+	if cookies := curlCookies(req, jar); cookies != "" {
+		args = append(args, "-b", curlQuote(cookies))
+	}
+
+	if len(body) != 0 {
+		if isMultipart {
+			formArgs, err := curlMultipart(body, params["boundary"])
+			if err != nil {
+				return "", err
+			}
+			args = append(args, formArgs...)
+		} else {
+			args = append(args, "--data-binary", curlQuote(string(body)))
+		}
+	}
+
+	args = append(args, curlQuote(req.URL.String()))
+
+	return strings.Join(args, " "), nil
+}
+
+func curlCookies(req *http.Request, jar http.CookieJar) string {
+	var pairs []string
+
+	seen := map[string]bool{}
+
+	for _, c := range req.Cookies() {
+		seen[c.Name] = true
+		pairs = append(pairs, c.Name+"="+c.Value)
+	}
+
+	if jar != nil {
+		for _, c := range jar.Cookies(req.URL) {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				pairs = append(pairs, c.Name+"="+c.Value)
+			}
+		}
+	}
+
+	return strings.Join(pairs, "; ")
+}
+
+func curlMultipart(body []byte, boundary string) ([]string, error) {
+	var args []string
+
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FileName() != "" {
+			args = append(args, "-F",
+				curlQuote(fmt.Sprintf("%s=@%s", part.FormName(), part.FileName())))
+		} else {
+			value, err := ioutil.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			// --form-string doesn't treat leading '@' or '<' and ';type='
+			// in value as file references or options, unlike -F
+			args = append(args, "--form-string",
+				curlQuote(fmt.Sprintf("%s=%s", part.FormName(), value)))
+		}
+	}
+
+	return args, nil
+}
+
+func curlQuote(s string) string {
+	return `'` + strings.Replace(s, `'`, `'\''`, -1) + `'`
+}
+
 // DebugPrinter implements Printer and WebsocketPrinter.
 // Uses net/http/httputil to dump both requests and responses.
 // Also prints all websocket messages.
 type DebugPrinter struct {
 	logger Logger
 	body   bool
+	colors ColorMode
 }
 
 // NewDebugPrinter returns a new DebugPrinter given a logger and body
 // flag. If body is true, request and response body is also printed.
-func NewDebugPrinter(logger Logger, body bool) DebugPrinter {
-	return DebugPrinter{logger, body}
+//
+// Example:
+//
+//	printer := NewDebugPrinter(t, true, PrinterOpts{
+//	    ColorMode: ColorModeAuto,
+//	})
+func NewDebugPrinter(logger Logger, body bool, opts ...PrinterOpts) DebugPrinter {
+	p := DebugPrinter{logger: logger, body: body}
+
+	for _, o := range opts {
+		p.colors = o.ColorMode
+	}
+
+	return p
 }
 
 // Request implements Printer.Request.
@@ -113,7 +342,12 @@ func (p DebugPrinter) Request(req *http.Request) {
 	if err != nil {
 		panic(err)
 	}
-	p.logger.Logf("%s", dump)
+
+	if enableColors(p.colors, p.logger) {
+		p.logger.Logf("%s", colorizeDump(string(dump), "blue"))
+	} else {
+		p.logger.Logf("%s", dump)
+	}
 }
 
 // Response implements Printer.Response.
@@ -130,17 +364,24 @@ func (p DebugPrinter) Response(resp *http.Response, duration time.Duration) {
 	text := strings.Replace(string(dump), "\r\n", "\n", -1)
 	lines := strings.SplitN(text, "\n", 2)
 
-	p.logger.Logf("%s %s\n%s", lines[0], duration, lines[1])
+	if enableColors(p.colors, p.logger) {
+		text = lines[0] + " " + duration.String() + "\n" + lines[1]
+		p.logger.Logf("%s", colorizeDump(text, statusColor(resp.StatusCode)))
+	} else {
+		p.logger.Logf("%s %s\n%s", lines[0], duration, lines[1])
+	}
 }
 
 // WebsocketWrite implements WebsocketPrinter.WebsocketWrite.
 func (p DebugPrinter) WebsocketWrite(typ int, content []byte, closeCode int) {
+	enable := enableColors(p.colors, p.logger)
+
 	b := &bytes.Buffer{}
-	fmt.Fprintf(b, "-> Sent: %s", wsMessageType(typ))
+	title := fmt.Sprintf("-> Sent: %s", wsMessageType(typ))
 	if typ == websocket.CloseMessage {
-		fmt.Fprintf(b, " %s", wsCloseCode(closeCode))
+		title += fmt.Sprintf(" %s", wsCloseCode(closeCode))
 	}
-	fmt.Fprint(b, "\n")
+	fmt.Fprintf(b, "%s\n", colorize(enable, "magenta", title))
 	if len(content) > 0 {
 		if typ == websocket.BinaryMessage {
 			fmt.Fprintf(b, "%v\n", content)
@@ -154,12 +395,14 @@ func (p DebugPrinter) WebsocketWrite(typ int, content []byte, closeCode int) {
 
 // WebsocketRead implements WebsocketPrinter.WebsocketRead.
 func (p DebugPrinter) WebsocketRead(typ int, content []byte, closeCode int) {
+	enable := enableColors(p.colors, p.logger)
+
 	b := &bytes.Buffer{}
-	fmt.Fprintf(b, "<- Received: %s", wsMessageType(typ))
+	title := fmt.Sprintf("<- Received: %s", wsMessageType(typ))
 	if typ == websocket.CloseMessage {
-		fmt.Fprintf(b, " %s", wsCloseCode(closeCode))
+		title += fmt.Sprintf(" %s", wsCloseCode(closeCode))
 	}
-	fmt.Fprint(b, "\n")
+	fmt.Fprintf(b, "%s\n", colorize(enable, "blue", title))
 	if len(content) > 0 {
 		if typ == websocket.BinaryMessage {
 			fmt.Fprintf(b, "%v\n", content)
@@ -170,3 +413,324 @@ func (p DebugPrinter) WebsocketRead(typ int, content []byte, closeCode int) {
 	fmt.Fprintf(b, "\n")
 	p.logger.Logf(b.String())
 }
+
+// Highlight start line and header names of HTTP message dump.
+func colorizeDump(text string, startColor string) string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+
+	head, body := text, ""
+	if n := strings.Index(text, "\n\n"); n >= 0 {
+		head, body = text[:n], text[n:]
+	}
+
+	lines := strings.Split(head, "\n")
+	for n, line := range lines {
+		if n == 0 {
+			lines[n] = colorize(true, startColor, line)
+		} else if i := strings.IndexByte(line, ':'); i > 0 {
+			lines[n] = colorize(true, "cyan", line[:i]) + line[i:]
+		}
+	}
+
+	return strings.Join(lines, "\n") + body
+}
+
+func statusColor(code int) string {
+	switch {
+	case code >= 400:
+		return "red"
+	case code >= 300:
+		return "yellow"
+	case code >= 200:
+		return "green"
+	default:
+		return "blue"
+	}
+}
+
+// HARPrinter implements Printer.
+// Records requests and responses in HTTP Archive (HAR) 1.2 format.
+//
+// Unlike other printers, HARPrinter doesn't print anything to log.
+// Instead, it accumulates entries in memory, and you can write them to
+// a .har file using WriteFile. Such file can be loaded into browser
+// devtools or API tools for debugging.
+//
+// HARPrinter is safe for concurrent use.
+//
+// Example:
+//
+//	har := httpexpect.NewHARPrinter()
+//	defer har.WriteFile("test.har")
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	    Printers: []httpexpect.Printer{har},
+//	})
+type HARPrinter struct {
+	mu      sync.Mutex
+	entries []*harEntry
+	pending []*harEntry
+}
+
+// NewHARPrinter returns a new HARPrinter.
+func NewHARPrinter() *HARPrinter {
+	return &HARPrinter{}
+}
+
+type harLog struct {
+	Log struct {
+		Version string      `json:"version"`
+		Creator harCreator  `json:"creator"`
+		Entries []*harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+
+	httpReq *http.Request
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Request implements Printer.Request.
+func (p *HARPrinter) Request(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	entry := &harEntry{
+		StartedDateTime: time.Now().Format(time.RFC3339Nano),
+		httpReq:         req,
+	}
+
+	entry.Request = harRequest{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: harProto(req.Proto),
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(req.Header),
+		QueryString: harHeaders(req.URL.Query()),
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+
+	for _, c := range req.Cookies() {
+		entry.Request.Cookies = append(entry.Request.Cookies,
+			harNameValue{Name: c.Name, Value: c.Value})
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ := ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		entry.Request.BodySize = len(body)
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     string(body),
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entries = append(p.entries, entry)
+	p.pending = append(p.pending, entry)
+}
+
+// Response implements Printer.Response.
+func (p *HARPrinter) Response(resp *http.Response, duration time.Duration) {
+	if resp == nil {
+		return
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, _ = ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry := p.popPending(resp.Request)
+	if entry == nil {
+		return
+	}
+
+	ms := float64(duration) / float64(time.Millisecond)
+
+	entry.Time = ms
+	entry.Timings = harTimings{Wait: ms}
+
+	entry.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: harProto(resp.Proto),
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(resp.Header),
+		Content: harContent{
+			Size:     len(body),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     string(body),
+		},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+
+	for _, c := range resp.Cookies() {
+		entry.Response.Cookies = append(entry.Response.Cookies,
+			harNameValue{Name: c.Name, Value: c.Value})
+	}
+}
+
+func (p *HARPrinter) popPending(req *http.Request) *harEntry {
+	idx := -1
+
+	for n, entry := range p.pending {
+		if req != nil && entry.httpReq == req {
+			idx = n
+			break
+		}
+	}
+
+	if idx < 0 && len(p.pending) != 0 {
+		idx = 0
+	}
+
+	if idx < 0 {
+		return nil
+	}
+
+	entry := p.pending[idx]
+	p.pending = append(p.pending[:idx], p.pending[idx+1:]...)
+
+	return entry
+}
+
+// Write writes HAR document with all recorded entries to given writer.
+func (p *HARPrinter) Write(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var log harLog
+
+	log.Log.Version = "1.2"
+	log.Log.Creator = harCreator{Name: "httpexpect", Version: "2"}
+	log.Log.Entries = p.entries
+
+	if log.Log.Entries == nil {
+		log.Log.Entries = []*harEntry{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", defaultIndent)
+
+	return enc.Encode(log)
+}
+
+// WriteFile writes HAR document with all recorded entries to file with
+// given path. If file exists, it's truncated.
+func (p *HARPrinter) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Reset removes all recorded entries.
+func (p *HARPrinter) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entries = nil
+	p.pending = nil
+}
+
+func harHeaders(header map[string][]string) []harNameValue {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ret := []harNameValue{}
+
+	for _, k := range keys {
+		for _, v := range header[k] {
+			ret = append(ret, harNameValue{Name: k, Value: v})
+		}
+	}
+
+	return ret
+}
+
+func harProto(proto string) string {
+	if proto == "" {
+		return "HTTP/1.1"
+	}
+	return proto
+}
@@ -2,9 +2,20 @@ package httpexpect
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCompactPrinter(t *testing.T) {
@@ -25,6 +36,126 @@ func TestCompactPrinter(t *testing.T) {
 	printer.Response(nil, 0)
 }
 
+func TestCurlPrinter(t *testing.T) {
+	printer := NewCurlPrinter(t)
+
+	body1 := bytes.NewBufferString("body1")
+
+	req1, _ := http.NewRequest("GET", "http://example.com", body1)
+	req2, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	printer.Request(req1)
+	printer.Request(req2)
+	printer.Request(nil)
+
+	printer.Response(&http.Response{}, 0)
+	printer.Response(nil, 0)
+}
+
+func TestCurlPrinterCommand(t *testing.T) {
+	logger := newMockLogger(t)
+
+	printer := NewCurlPrinter(logger)
+
+	req, _ := http.NewRequest("PURGE", "http://example.com/path?a=1",
+		bytes.NewBufferString("it's body"))
+	req.Header.Set("X-B", "b")
+	req.Header.Set("X-A", "a")
+	req.AddCookie(&http.Cookie{Name: "foo", Value: "bar"})
+
+	printer.Request(req)
+
+	assert.Equal(t,
+		`curl -X 'PURGE' -H 'X-A: a' -H 'X-B: b' -b 'foo=bar' `+
+			`--data-binary 'it'\''s body' 'http://example.com/path?a=1'`,
+		logger.lastMessage)
+
+	b, err := ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "it's body", string(b))
+}
+
+func TestCurlPrinterHTTP2(t *testing.T) {
+	logger := newMockLogger(t)
+
+	printer := NewCurlPrinter(logger)
+
+	req, _ := http.NewRequest("GET", "https://example.com", nil)
+	req.Proto = "HTTP/2.0"
+	req.ProtoMajor = 2
+	req.ProtoMinor = 0
+
+	printer.Request(req)
+
+	assert.Equal(t,
+		`curl --http2 -k -X 'GET' 'https://example.com'`,
+		logger.lastMessage)
+}
+
+func TestCurlPrinterMultipart(t *testing.T) {
+	logger := newMockLogger(t)
+
+	printer := NewCurlPrinter(logger)
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	require.NoError(t, mw.WriteField("name", "john"))
+	require.NoError(t, mw.WriteField("path", "@/etc/passwd"))
+	require.NoError(t, mw.WriteField("note", "<file;type=text/plain"))
+	fw, err := mw.CreateFormFile("avatar", "avatar.png")
+	require.NoError(t, err)
+	_, err = fw.Write([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req, _ := http.NewRequest("POST", "http://example.com/upload", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	printer.Request(req)
+
+	assert.Equal(t,
+		`curl -X 'POST' --form-string 'name=john' `+
+			`--form-string 'path=@/etc/passwd' `+
+			`--form-string 'note=<file;type=text/plain' `+
+			`-F 'avatar=@avatar.png' 'http://example.com/upload'`,
+		logger.lastMessage)
+}
+
+func TestCurlPrinterOptions(t *testing.T) {
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	u, _ := url.Parse("http://example.com")
+	jar.SetCookies(u, []*http.Cookie{
+		{Name: "session", Value: "123"},
+		{Name: "foo", Value: "jar"},
+	})
+
+	logger := newMockLogger(t)
+
+	var output bytes.Buffer
+
+	printer := NewCurlPrinter(logger, CurlOpts{
+		Jar:    jar,
+		Output: &output,
+	})
+
+	req1, _ := http.NewRequest("GET", "http://example.com/a", nil)
+	req1.AddCookie(&http.Cookie{Name: "foo", Value: "bar"})
+
+	req2, _ := http.NewRequest("DELETE", "http://example.com/b", nil)
+
+	printer.Request(req1)
+	printer.Request(req2)
+
+	assert.False(t, logger.logged)
+	assert.Equal(t,
+		"curl -X 'GET' -b 'foo=bar; session=123' 'http://example.com/a'\n"+
+			"curl -X 'DELETE' -b 'session=123; foo=jar' 'http://example.com/b'\n",
+		output.String())
+}
+
 func TestDebugPrinter(t *testing.T) {
 	printer := NewDebugPrinter(t, true)
 
@@ -42,3 +173,193 @@ func TestDebugPrinter(t *testing.T) {
 	printer.Response(&http.Response{}, 0)
 	printer.Response(nil, 0)
 }
+
+func TestPrinterColors(t *testing.T) {
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+		req.Header.Set("X-Test", "foo")
+		return req
+	}
+
+	newResponse := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     http.Header{"X-Test": {"foo"}},
+		}
+	}
+
+	t.Run("never", func(t *testing.T) {
+		logger := newMockLogger(t)
+
+		NewCompactPrinter(logger).Request(newRequest())
+		assert.NotContains(t, logger.lastMessage, "\033[")
+
+		printer := NewDebugPrinter(logger, true)
+
+		printer.Request(newRequest())
+		assert.NotContains(t, logger.lastMessage, "\033[")
+
+		printer.Response(newResponse(), 0)
+		assert.NotContains(t, logger.lastMessage, "\033[")
+
+		printer.WebsocketWrite(websocket.TextMessage, []byte("foo"), 0)
+		assert.NotContains(t, logger.lastMessage, "\033[")
+	})
+
+	t.Run("always", func(t *testing.T) {
+		logger := newMockLogger(t)
+		opts := PrinterOpts{ColorMode: ColorModeAlways}
+
+		NewCompactPrinter(logger, opts).Request(newRequest())
+		assert.Equal(t, "\033[1mGET\033[0m http://example.com", logger.lastMessage)
+
+		printer := NewDebugPrinter(logger, true, opts)
+
+		printer.Request(newRequest())
+		assert.Contains(t, logger.lastMessage,
+			"\033[34mGET / HTTP/1.1\033[0m\n")
+		assert.Contains(t, logger.lastMessage,
+			"\n\033[36mX-Test\033[0m: foo\n")
+		assert.NotContains(t, logger.lastMessage, "\r\n")
+
+		printer.Response(newResponse(), time.Second)
+		assert.Contains(t, logger.lastMessage,
+			"\033[31mHTTP/1.1 404 Not Found 1s\033[0m\n")
+		assert.Contains(t, logger.lastMessage,
+			"\n\033[36mX-Test\033[0m: foo\n")
+
+		printer.WebsocketWrite(websocket.TextMessage, []byte("foo"), 0)
+		assert.Equal(t, "\033[35m-> Sent: text(1)\033[0m\nfoo\n\n", logger.lastMessage)
+
+		printer.WebsocketRead(websocket.TextMessage, []byte("foo"), 0)
+		assert.Equal(t, "\033[34m<- Received: text(1)\033[0m\nfoo\n\n",
+			logger.lastMessage)
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		// logger is not a test, so there is no terminal behind it
+		logger := newMockLogger(t)
+		opts := PrinterOpts{ColorMode: ColorModeAuto}
+
+		NewCompactPrinter(logger, opts).Request(newRequest())
+		assert.NotContains(t, logger.lastMessage, "\033[")
+
+		NewDebugPrinter(logger, true, opts).Request(newRequest())
+		assert.NotContains(t, logger.lastMessage, "\033[")
+	})
+
+	t.Run("rebind", func(t *testing.T) {
+		config := Config{
+			Printers: []Printer{
+				NewDebugPrinter(newMockLogger(t), true,
+					PrinterOpts{ColorMode: ColorModeAlways}),
+			},
+		}
+
+		rebound := rebindPrinters(config.Printers, t)
+		assert.Equal(t, ColorModeAlways, rebound[0].(DebugPrinter).colors)
+	})
+
+	t.Run("statusColor", func(t *testing.T) {
+		assert.Equal(t, "blue", statusColor(101))
+		assert.Equal(t, "green", statusColor(200))
+		assert.Equal(t, "yellow", statusColor(302))
+		assert.Equal(t, "red", statusColor(404))
+		assert.Equal(t, "red", statusColor(500))
+	})
+}
+
+func TestHARPrinter(t *testing.T) {
+	printer := NewHARPrinter()
+
+	req1, _ := http.NewRequest("POST", "http://example.com/path?b=2&a=1",
+		bytes.NewBufferString("body1"))
+	req1.Header.Set("Content-Type", "text/plain")
+	req1.AddCookie(&http.Cookie{Name: "session", Value: "123"})
+
+	req2, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	printer.Request(req1)
+	printer.Response(&http.Response{
+		StatusCode: http.StatusCreated,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+			"Set-Cookie":   {"foo=bar"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{"ok":true}`)),
+	}, 2*time.Millisecond)
+
+	printer.Request(req2)
+	printer.Response(&http.Response{StatusCode: http.StatusOK}, 0)
+
+	printer.Request(nil)
+	printer.Response(nil, 0)
+	printer.Response(&http.Response{StatusCode: http.StatusOK}, 0)
+
+	var buf bytes.Buffer
+	require.NoError(t, printer.Write(&buf))
+
+	var har map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+
+	log := har["log"].(map[string]interface{})
+	assert.Equal(t, "1.2", log["version"])
+
+	entries := log["entries"].([]interface{})
+	require.Equal(t, 2, len(entries))
+
+	entry1 := entries[0].(map[string]interface{})
+	assert.Equal(t, 2.0, entry1["time"])
+
+	request1 := entry1["request"].(map[string]interface{})
+	assert.Equal(t, "POST", request1["method"])
+	assert.Equal(t, "http://example.com/path?b=2&a=1", request1["url"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "a", "value": "1"},
+		map[string]interface{}{"name": "b", "value": "2"},
+	}, request1["queryString"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "session", "value": "123"},
+	}, request1["cookies"])
+	assert.Equal(t, map[string]interface{}{
+		"mimeType": "text/plain",
+		"text":     "body1",
+	}, request1["postData"])
+
+	response1 := entry1["response"].(map[string]interface{})
+	assert.Equal(t, 201.0, response1["status"])
+	assert.Equal(t, "Created", response1["statusText"])
+	assert.Equal(t, map[string]interface{}{
+		"size":     11.0,
+		"mimeType": "application/json",
+		"text":     `{"ok":true}`,
+	}, response1["content"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "foo", "value": "bar"},
+	}, response1["cookies"])
+
+	entry2 := entries[1].(map[string]interface{})
+	request2 := entry2["request"].(map[string]interface{})
+	assert.Nil(t, request2["postData"])
+	response2 := entry2["response"].(map[string]interface{})
+	assert.Equal(t, 200.0, response2["status"])
+
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.har")
+	require.NoError(t, printer.WriteFile(path))
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, buf.String(), string(b))
+
+	printer.Reset()
+
+	buf.Reset()
+	require.NoError(t, printer.Write(&buf))
+	assert.Contains(t, buf.String(), `"entries": []`)
+}
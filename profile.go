@@ -0,0 +1,306 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigProfile defines environment-specific settings, which can be loaded
+// from file or environment variables and converted to Config.
+//
+// Profiles allow to run the same compiled test suite against different
+// environments (e.g. dev and staging) without code changes.
+//
+// Use ConfigFromFile or ConfigFromEnv to load profile and construct Config.
+type ConfigProfile struct {
+	// Base URL prepended to all requests, see Config.BaseURL.
+	BaseURL string `yaml:"base_url"`
+
+	// Timeout of every request, in time.ParseDuration format, e.g. "30s".
+	// If empty, there is no timeout.
+	Timeout string `yaml:"timeout"`
+
+	// Headers added to every request, unless request already has them.
+	Headers map[string]string `yaml:"headers"`
+
+	// TLS settings.
+	TLS ConfigProfileTLS `yaml:"tls"`
+
+	// Printer used to print requests and responses.
+	// One of: "none", "compact", "debug", "curl".
+	// If empty, "none" is used.
+	Printer string `yaml:"printer"`
+}
+
+// ConfigProfileTLS defines TLS settings of ConfigProfile.
+type ConfigProfileTLS struct {
+	// Disable verification of server certificate.
+	Insecure bool `yaml:"insecure"`
+
+	// Path to PEM file with CA certificates used to verify server.
+	// If empty, system CA pool is used.
+	CAFile string `yaml:"ca_file"`
+
+	// Paths to PEM files with client certificate and key.
+	// Both should be either set or empty.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+
+	// Server name used to verify server certificate.
+	// If empty, host from request URL is used.
+	ServerName string `yaml:"server_name"`
+}
+
+// ConfigFromFile loads ConfigProfile from YAML (or JSON) file and returns
+// Config constructed from it for given test.
+//
+// Unknown fields in file are treated as errors.
+//
+// Example file:
+//
+//	base_url: https://staging.example.com
+//	timeout: 30s
+//	headers:
+//	  X-Api-Key: secret
+//	tls:
+//	  ca_file: /etc/ssl/staging-ca.pem
+//	printer: compact
+//
+// Example:
+//
+//	func TestSomething(t *testing.T) {
+//	    config, err := httpexpect.ConfigFromFile(t, os.Getenv("TESTSUITE_PROFILE"))
+//	    if err != nil {
+//	        t.Fatal(err)
+//	    }
+//
+//	    e := httpexpect.WithConfig(config)
+//	}
+func ConfigFromFile(t TestingTB, path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var profile ConfigProfile
+
+	if err := yaml.UnmarshalStrict(data, &profile); err != nil {
+		return Config{}, fmt.Errorf("can't parse profile %s: %s", path, err)
+	}
+
+	return profile.Config(t)
+}
+
+// ConfigFromEnv loads ConfigProfile from environment variables with given
+// prefix and returns Config constructed from it for given test.
+//
+// Variables:
+//   - <PREFIX>_BASE_URL
+//   - <PREFIX>_TIMEOUT
+//   - <PREFIX>_HEADER_<NAME>, where underscores in NAME are replaced with
+//     dashes, e.g. <PREFIX>_HEADER_X_API_KEY defines X-Api-Key header
+//   - <PREFIX>_TLS_INSECURE, boolean
+//   - <PREFIX>_TLS_CA_FILE
+//   - <PREFIX>_TLS_CERT_FILE
+//   - <PREFIX>_TLS_KEY_FILE
+//   - <PREFIX>_TLS_SERVER_NAME
+//   - <PREFIX>_PRINTER
+//
+// Example:
+//
+//	// TESTSUITE_BASE_URL=https://staging.example.com
+//	// TESTSUITE_PRINTER=debug
+//	config, err := httpexpect.ConfigFromEnv(t, "TESTSUITE")
+func ConfigFromEnv(t TestingTB, prefix string) (Config, error) {
+	prefix = strings.TrimSuffix(prefix, "_") + "_"
+
+	getenv := func(name string) string {
+		return os.Getenv(prefix + name)
+	}
+
+	profile := ConfigProfile{
+		BaseURL: getenv("BASE_URL"),
+		Timeout: getenv("TIMEOUT"),
+		Printer: getenv("PRINTER"),
+		TLS: ConfigProfileTLS{
+			CAFile:     getenv("TLS_CA_FILE"),
+			CertFile:   getenv("TLS_CERT_FILE"),
+			KeyFile:    getenv("TLS_KEY_FILE"),
+			ServerName: getenv("TLS_SERVER_NAME"),
+		},
+	}
+
+	if s := getenv("TLS_INSECURE"); s != "" {
+		insecure, err := strconv.ParseBool(s)
+		if err != nil {
+			return Config{}, fmt.Errorf("can't parse %sTLS_INSECURE: %s", prefix, err)
+		}
+		profile.TLS.Insecure = insecure
+	}
+
+	for _, kv := range os.Environ() {
+		kv := strings.SplitN(kv, "=", 2)
+		if len(kv) != 2 || !strings.HasPrefix(kv[0], prefix+"HEADER_") {
+			continue
+		}
+
+		name := strings.TrimPrefix(kv[0], prefix+"HEADER_")
+		if name == "" {
+			continue
+		}
+
+		if profile.Headers == nil {
+			profile.Headers = make(map[string]string)
+		}
+		profile.Headers[strings.Replace(name, "_", "-", -1)] = kv[1]
+	}
+
+	return profile.Config(t)
+}
+
+// Config returns Config constructed from profile for given test.
+//
+// Returned config uses AssertReporter for t, and t as logger of printer.
+// It may be further adjusted before passing to WithConfig.
+//
+// Example:
+//
+//	profile := httpexpect.ConfigProfile{
+//	    BaseURL: "http://localhost:8080",
+//	    Printer: "debug",
+//	}
+//
+//	config, err := profile.Config(t)
+func (p ConfigProfile) Config(t TestingTB) (Config, error) {
+	var timeout time.Duration
+
+	if p.Timeout != "" {
+		d, err := time.ParseDuration(p.Timeout)
+		if err != nil {
+			return Config{}, fmt.Errorf("can't parse timeout: %s", err)
+		}
+		if d < 0 {
+			return Config{}, errors.New("timeout is negative")
+		}
+		timeout = d
+	}
+
+	tlsConfig, err := p.TLS.tlsConfig()
+	if err != nil {
+		return Config{}, err
+	}
+
+	var printers []Printer
+
+	switch strings.ToLower(p.Printer) {
+	case "", "none":
+	case "compact":
+		printers = append(printers, NewCompactPrinter(t))
+	case "debug":
+		printers = append(printers, NewDebugPrinter(t, true))
+	case "curl":
+		printers = append(printers, NewCurlPrinter(t))
+	default:
+		return Config{}, fmt.Errorf("unknown printer %q", p.Printer)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	var client Client = &http.Client{
+		Transport: transport,
+		Jar:       NewJar(),
+		Timeout:   timeout,
+	}
+
+	if len(p.Headers) != 0 {
+		headers := make(http.Header)
+		for k, v := range p.Headers {
+			headers.Set(k, v)
+		}
+		client = &profileClient{
+			client:  client,
+			headers: headers,
+		}
+	}
+
+	return Config{
+		TestName: t.Name(),
+		BaseURL:  p.BaseURL,
+		Client:   client,
+		WebsocketDialer: &websocket.Dialer{
+			TLSClientConfig:  tlsConfig,
+			HandshakeTimeout: timeout,
+		},
+		Reporter: NewAssertReporter(t),
+		Printers: printers,
+	}, nil
+}
+
+func (p ConfigProfileTLS) tlsConfig() (*tls.Config, error) {
+	if !p.Insecure && p.CAFile == "" && p.CertFile == "" &&
+		p.KeyFile == "" && p.ServerName == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: p.Insecure, // #nosec G402
+		ServerName:         p.ServerName,
+	}
+
+	if p.CAFile != "" {
+		data, err := ioutil.ReadFile(p.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("can't load CA certificates from %s", p.CAFile)
+		}
+
+		config.RootCAs = pool
+	}
+
+	if (p.CertFile == "") != (p.KeyFile == "") {
+		return nil, errors.New("client certificate and key should be set together")
+	}
+
+	if p.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(p.CertFile, p.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// Client that adds default headers to requests.
+type profileClient struct {
+	client  Client
+	headers http.Header
+}
+
+func (c *profileClient) Do(req *http.Request) (*http.Response, error) {
+	for k, v := range c.headers {
+		if _, ok := req.Header[k]; !ok {
+			req.Header[k] = v
+		}
+	}
+
+	return c.client.Do(req)
+}
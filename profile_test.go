@@ -0,0 +1,173 @@
+package httpexpect
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProfileFile(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func TestProfileFromFile(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		path := writeProfileFile(t, "profile.yaml", `
+base_url: http://example.com
+timeout: 5s
+headers:
+  X-Api-Key: secret
+printer: compact
+`)
+
+		config, err := ConfigFromFile(t, path)
+		require.NoError(t, err)
+
+		assert.Equal(t, t.Name(), config.TestName)
+		assert.Equal(t, "http://example.com", config.BaseURL)
+		assert.NotNil(t, config.Reporter)
+		assert.NotNil(t, config.WebsocketDialer)
+		require.Equal(t, 1, len(config.Printers))
+		assert.IsType(t, CompactPrinter{}, config.Printers[0])
+
+		client, ok := config.Client.(*profileClient)
+		require.True(t, ok)
+		assert.Equal(t, "secret", client.headers.Get("X-Api-Key"))
+
+		httpClient, ok := client.client.(*http.Client)
+		require.True(t, ok)
+		assert.Equal(t, 5*time.Second, httpClient.Timeout)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := writeProfileFile(t, "profile.json",
+			`{"base_url": "http://example.com", "printer": "curl"}`)
+
+		config, err := ConfigFromFile(t, path)
+		require.NoError(t, err)
+
+		assert.Equal(t, "http://example.com", config.BaseURL)
+		require.Equal(t, 1, len(config.Printers))
+		assert.IsType(t, CurlPrinter{}, config.Printers[0])
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := map[string]string{
+			"unknown field":   "base_url: http://example.com\nbase_uri: oops\n",
+			"bad timeout":     "timeout: soon\n",
+			"neg timeout":     "timeout: -1s\n",
+			"unknown printer": "printer: fancy\n",
+			"cert w/o key":    "tls:\n  cert_file: cert.pem\n",
+			"missing ca":      "tls:\n  ca_file: /nonexistent/ca.pem\n",
+		}
+
+		for name, content := range cases {
+			t.Run(name, func(t *testing.T) {
+				path := writeProfileFile(t, "profile.yaml", content)
+
+				_, err := ConfigFromFile(t, path)
+				assert.Error(t, err)
+			})
+		}
+
+		_, err := ConfigFromFile(t, "/nonexistent/profile.yaml")
+		assert.Error(t, err)
+	})
+}
+
+func TestProfileFromEnv(t *testing.T) {
+	env := map[string]string{
+		"HTTPEXPECT_TEST_BASE_URL":         "http://example.com",
+		"HTTPEXPECT_TEST_TIMEOUT":          "2s",
+		"HTTPEXPECT_TEST_HEADER_X_API_KEY": "secret",
+		"HTTPEXPECT_TEST_TLS_INSECURE":     "true",
+		"HTTPEXPECT_TEST_PRINTER":          "debug",
+	}
+
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+	}
+	defer func() {
+		for k := range env {
+			_ = os.Unsetenv(k)
+		}
+	}()
+
+	config, err := ConfigFromEnv(t, "HTTPEXPECT_TEST")
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://example.com", config.BaseURL)
+	require.Equal(t, 1, len(config.Printers))
+	assert.IsType(t, DebugPrinter{}, config.Printers[0])
+
+	client, ok := config.Client.(*profileClient)
+	require.True(t, ok)
+	assert.Equal(t, "secret", client.headers.Get("X-Api-Key"))
+
+	httpClient, ok := client.client.(*http.Client)
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Second, httpClient.Timeout)
+
+	transport, ok := httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.NotNil(t, transport.TLSClientConfig)
+	assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+
+	require.NoError(t, os.Setenv("HTTPEXPECT_TEST_TLS_INSECURE", "maybe"))
+
+	_, err = ConfigFromEnv(t, "HTTPEXPECT_TEST")
+	assert.Error(t, err)
+}
+
+func TestProfileRequests(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Api-Key", r.Header.Get("X-Api-Key"))
+			w.Header().Set("X-Token", r.Header.Get("X-Token"))
+		}))
+	defer server.Close()
+
+	caFile := writeProfileFile(t, "ca.pem", string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})))
+
+	profile := ConfigProfile{
+		BaseURL: server.URL,
+		Headers: map[string]string{
+			"X-Api-Key": "default-key",
+			"X-Token":   "default-token",
+		},
+		TLS: ConfigProfileTLS{
+			CAFile: caFile,
+		},
+	}
+
+	config, err := profile.Config(t)
+	require.NoError(t, err)
+
+	e := WithConfig(config)
+
+	resp := e.GET("/").
+		WithHeader("X-Token", "custom-token").
+		Expect().
+		Status(http.StatusOK)
+
+	resp.Header("X-Api-Key").Equal("default-key")
+	resp.Header("X-Token").Equal("custom-token")
+}
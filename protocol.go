@@ -0,0 +1,189 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// NewH2CClient returns a new http.Client that sends requests using HTTP/2
+// over cleartext TCP connection (h2c) with prior knowledge, i.e. without
+// HTTP/1.1 upgrade. Client has a non-nil Jar.
+//
+// Server should support h2c, e.g. using golang.org/x/net/http2/h2c.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    BaseURL:  "http://example.com",
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	    Client:   httpexpect.NewH2CClient(),
+//	})
+//
+//	e.GET("/path").Expect().HTTP2()
+func NewH2CClient() *http.Client {
+	return &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(context.Background(), network, addr)
+			},
+		},
+		Jar: NewJar(),
+	}
+}
+
+// NewHTTP2Client returns a new http.Client that sends requests using
+// HTTP/2 over TLS. Unlike default client, it doesn't fall back to HTTP/1.1
+// if server doesn't support HTTP/2. Client has a non-nil Jar.
+//
+// tlsConfig may be nil.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    BaseURL:  "https://example.com",
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	    Client:   httpexpect.NewHTTP2Client(&tls.Config{
+//	        RootCAs: pool,
+//	    }),
+//	})
+//
+//	e.GET("/path").Expect().HTTP2()
+func NewHTTP2Client(tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Transport: &http2.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+		Jar: NewJar(),
+	}
+}
+
+// Proto succeeds if response protocol version is equal to given one,
+// e.g. "HTTP/1.1" or "HTTP/2.0".
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Proto("HTTP/1.1")
+func (r *Response) Proto(proto string) *Response {
+	r.chain.enter("Proto()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	if !ok {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					`unexpected protocol version %q, expected "HTTP/{major}.{minor}"`,
+					proto),
+			},
+		})
+		return r
+	}
+
+	if r.httpResp.ProtoMajor != major || r.httpResp.ProtoMinor != minor {
+		r.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{r.proto()},
+			Expected: &AssertionValue{proto},
+			Errors: []error{
+				errors.New("expected: response protocol versions are equal"),
+			},
+		})
+	}
+
+	return r
+}
+
+// HTTP1 succeeds if response protocol major version is 1, i.e. response
+// was received using HTTP/1.0 or HTTP/1.1.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HTTP1()
+func (r *Response) HTTP1() *Response {
+	r.chain.enter("HTTP1()")
+	defer r.chain.leave()
+
+	r.checkProtoMajor(1)
+
+	return r
+}
+
+// HTTP2 succeeds if response protocol major version is 2, i.e. response
+// was received using HTTP/2.
+//
+// Note that default http.Client uses HTTP/2 only for https:// requests,
+// if server supports it. To force HTTP/2, use NewHTTP2Client, or
+// NewH2CClient for cleartext connections.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HTTP2()
+func (r *Response) HTTP2() *Response {
+	r.chain.enter("HTTP2()")
+	defer r.chain.leave()
+
+	r.checkProtoMajor(2)
+
+	return r
+}
+
+// HTTP3 succeeds if response protocol major version is 3.
+//
+// This is a protocol assertion only: httpexpect doesn't provide HTTP/3
+// transport, and net/http doesn't support HTTP/3, so neither default
+// client nor NewHTTP2Client, NewH2CClient, and Binder ever produce such
+// response. HTTP3 is useful only if Config.Client implements HTTP/3 on
+// its own and reports it in response ProtoMajor.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HTTP3()
+func (r *Response) HTTP3() *Response {
+	r.chain.enter("HTTP3()")
+	defer r.chain.leave()
+
+	r.checkProtoMajor(3)
+
+	return r
+}
+
+func (r *Response) checkProtoMajor(major int) {
+	if r.chain.failed() {
+		return
+	}
+
+	if r.httpResp.ProtoMajor != major {
+		r.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{r.proto()},
+			Expected: &AssertionValue{fmt.Sprintf("HTTP/%d", major)},
+			Errors: []error{
+				errors.New("expected: response protocol major versions are equal"),
+			},
+		})
+	}
+}
+
+func (r *Response) proto() string {
+	if r.httpResp.Proto != "" {
+		return r.httpResp.Proto
+	}
+	return fmt.Sprintf("HTTP/%d.%d", r.httpResp.ProtoMajor, r.httpResp.ProtoMinor)
+}
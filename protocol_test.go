@@ -0,0 +1,153 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestResponseProto(t *testing.T) {
+	newResp := func(t *testing.T, major, minor int, proto string) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			Proto:      proto,
+			ProtoMajor: major,
+			ProtoMinor: minor,
+		})
+	}
+
+	cases := []struct {
+		name  string
+		major int
+		minor int
+		proto string
+		http1 bool
+		http2 bool
+		http3 bool
+	}{
+		{"http10", 1, 0, "HTTP/1.0", true, false, false},
+		{"http11", 1, 1, "HTTP/1.1", true, false, false},
+		{"http2", 2, 0, "HTTP/2.0", false, true, false},
+		{"http3", 3, 0, "HTTP/3.0", false, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := newResp(t, tc.major, tc.minor, tc.proto)
+
+			resp.Proto(tc.proto)
+			resp.chain.assertNotFailed(t)
+
+			checks := []struct {
+				fn func() *Response
+				ok bool
+			}{
+				{resp.HTTP1, tc.http1},
+				{resp.HTTP2, tc.http2},
+				{resp.HTTP3, tc.http3},
+			}
+
+			for _, check := range checks {
+				check.fn()
+				if check.ok {
+					resp.chain.assertNotFailed(t)
+				} else {
+					resp.chain.assertFailed(t)
+				}
+				resp.chain.clearFailed()
+			}
+		})
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		resp := newResp(t, 1, 1, "HTTP/1.1")
+
+		resp.Proto("HTTP/1.0")
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("empty proto string", func(t *testing.T) {
+		resp := newResp(t, 2, 0, "")
+
+		resp.Proto("HTTP/2.0")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := newResp(t, 1, 1, "HTTP/1.1")
+
+		resp.Proto("HTTP/x")
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponseProtoBinder(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	e.GET("/").Expect().HTTP1().Proto("HTTP/1.1").
+		chain.assertNotFailed(t)
+
+	e.GET("/").WithProto("HTTP/2.0").Expect().HTTP2().
+		chain.assertNotFailed(t)
+}
+
+func TestE2EProtocolH2C(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+
+	server := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Client:   NewH2CClient(),
+	})
+
+	resp := e.GET("/").Expect()
+	resp.chain.assertNotFailed(t)
+
+	resp.HTTP2()
+	resp.Body().Equal("HTTP/2.0")
+	resp.chain.assertNotFailed(t)
+}
+
+func TestE2EProtocolHTTP2(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+
+	server := httptest.NewUnstartedServer(handler)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Client: NewHTTP2Client(&tls.Config{
+			InsecureSkipVerify: true,
+		}),
+	})
+
+	resp := e.GET("/").Expect()
+	resp.chain.assertNotFailed(t)
+
+	resp.HTTP2()
+	resp.Body().Equal("HTTP/2.0")
+	resp.chain.assertNotFailed(t)
+}
@@ -0,0 +1,410 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitStatus provides methods to inspect server-side rate limit
+// advertised in response headers.
+//
+// The following headers are recognized:
+//   - RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, and
+//     RateLimit-Policy (IETF draft)
+//   - combined RateLimit header, e.g. "limit=100, remaining=50, reset=30",
+//     or `"default";r=50;t=30` (later IETF drafts)
+//   - X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset
+//     (de-facto standard; reset may be either number of seconds or
+//     Unix timestamp)
+//   - Retry-After (number of seconds or HTTP-date)
+//
+// Absolute times are converted to durations relative to Date header,
+// or to current time if Date header is missing.
+type RateLimitStatus struct {
+	chain *chain
+
+	limit      *int64
+	remaining  *int64
+	reset      *time.Duration
+	retryAfter *time.Duration
+	policy     *string
+}
+
+// NewRateLimitStatus returns a new RateLimitStatus instance for rate
+// limit headers contained in given header map.
+//
+// reporter and header should not be nil.
+//
+// Example:
+//
+//	rl := NewRateLimitStatus(t, http.Header{
+//	    "X-Ratelimit-Remaining": {"10"},
+//	})
+//	rl.Remaining().Gt(0)
+func NewRateLimitStatus(reporter Reporter, header http.Header) *RateLimitStatus {
+	return newRateLimitStatus(
+		newChainWithDefaults("RateLimitStatus()", reporter), header, time.Now())
+}
+
+func newRateLimitStatus(parent *chain, header http.Header, now time.Time) *RateLimitStatus {
+	rl := &RateLimitStatus{chain: parent.clone()}
+
+	if header == nil {
+		rl.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: non-nil header"),
+			},
+		})
+		return rl
+	}
+
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		now = date
+	}
+
+	if err := rl.parse(header, now); err != nil {
+		rl.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: valid rate limit headers"),
+				err,
+			},
+		})
+	}
+
+	return rl
+}
+
+// Limit returns a new Number instance with maximum number of requests
+// allowed in current window.
+//
+// If limit is not advertised, method fails.
+//
+// Example:
+//
+//	rl := NewRateLimitStatus(t, header)
+//	rl.Limit().Equal(100)
+func (rl *RateLimitStatus) Limit() *Number {
+	rl.chain.enter("Limit()")
+	defer rl.chain.leave()
+
+	if rl.chain.failed() {
+		return newNumber(rl.chain, 0)
+	}
+
+	if rl.limit == nil {
+		rl.failMissing("limit")
+		return newNumber(rl.chain, 0)
+	}
+
+	return newNumber(rl.chain, float64(*rl.limit))
+}
+
+// Remaining returns a new Number instance with number of requests
+// remaining in current window.
+//
+// If remaining quota is not advertised, method fails.
+//
+// Example:
+//
+//	rl := NewRateLimitStatus(t, header)
+//	rl.Remaining().Gt(0)
+func (rl *RateLimitStatus) Remaining() *Number {
+	rl.chain.enter("Remaining()")
+	defer rl.chain.leave()
+
+	if rl.chain.failed() {
+		return newNumber(rl.chain, 0)
+	}
+
+	if rl.remaining == nil {
+		rl.failMissing("remaining quota")
+		return newNumber(rl.chain, 0)
+	}
+
+	return newNumber(rl.chain, float64(*rl.remaining))
+}
+
+// Reset returns a new Duration instance with time left until quota
+// is reset.
+//
+// If reset time is not advertised, method fails.
+//
+// Example:
+//
+//	rl := NewRateLimitStatus(t, header)
+//	rl.Reset().Le(time.Minute)
+func (rl *RateLimitStatus) Reset() *Duration {
+	rl.chain.enter("Reset()")
+	defer rl.chain.leave()
+
+	if rl.chain.failed() {
+		return newDuration(rl.chain, nil)
+	}
+
+	if rl.reset == nil {
+		rl.failMissing("reset time")
+		return newDuration(rl.chain, nil)
+	}
+
+	return newDuration(rl.chain, rl.reset)
+}
+
+// RetryAfter returns a new Duration instance with time client should
+// wait before sending next request, from Retry-After header.
+//
+// If Retry-After header is missing, method fails.
+//
+// Example:
+//
+//	resp := e.GET("/path").Expect().Status(http.StatusTooManyRequests)
+//	resp.RateLimit().RetryAfter().InRange(time.Second, time.Minute)
+func (rl *RateLimitStatus) RetryAfter() *Duration {
+	rl.chain.enter("RetryAfter()")
+	defer rl.chain.leave()
+
+	if rl.chain.failed() {
+		return newDuration(rl.chain, nil)
+	}
+
+	if rl.retryAfter == nil {
+		rl.failMissing("Retry-After")
+		return newDuration(rl.chain, nil)
+	}
+
+	return newDuration(rl.chain, rl.retryAfter)
+}
+
+// Policy returns a new String instance with RateLimit-Policy header.
+//
+// If header is missing, method fails.
+//
+// Example:
+//
+//	rl := NewRateLimitStatus(t, header)
+//	rl.Policy().Equal("100;w=60")
+func (rl *RateLimitStatus) Policy() *String {
+	rl.chain.enter("Policy()")
+	defer rl.chain.leave()
+
+	if rl.chain.failed() {
+		return newString(rl.chain, "")
+	}
+
+	if rl.policy == nil {
+		rl.failMissing("RateLimit-Policy")
+		return newString(rl.chain, "")
+	}
+
+	return newString(rl.chain, *rl.policy)
+}
+
+func (rl *RateLimitStatus) failMissing(what string) {
+	rl.chain.fail(AssertionFailure{
+		Type: AssertValid,
+		Actual: &AssertionValue{map[string]interface{}{
+			"limit":       rl.limit,
+			"remaining":   rl.remaining,
+			"reset":       rl.reset,
+			"retry_after": rl.retryAfter,
+		}},
+		Errors: []error{
+			fmt.Errorf("expected: rate limit headers advertise %s", what),
+		},
+	})
+}
+
+func (rl *RateLimitStatus) parse(header http.Header, now time.Time) error {
+	var err error
+
+	// Separate IETF headers.
+	if rl.limit, err = rateLimitInt(header, "RateLimit-Limit"); err != nil {
+		return err
+	}
+	if rl.remaining, err = rateLimitInt(header, "RateLimit-Remaining"); err != nil {
+		return err
+	}
+	if rl.reset, err = rateLimitSeconds(header, "RateLimit-Reset"); err != nil {
+		return err
+	}
+
+	if value := header.Get("RateLimit-Policy"); value != "" {
+		rl.policy = &value
+	}
+
+	// Combined IETF header.
+	if values := header.Values("RateLimit"); len(values) != 0 {
+		if err := rl.parseCombined(values); err != nil {
+			return err
+		}
+	}
+
+	if rl.limit == nil && rl.policy != nil {
+		if q, ok := rateLimitParams([]string{*rl.policy})["q"]; ok {
+			if n, err := strconv.ParseInt(q, 10, 64); err == nil && n >= 0 {
+				rl.limit = &n
+			}
+		}
+	}
+
+	// De-facto standard headers.
+	if rl.limit == nil {
+		if rl.limit, err = rateLimitInt(header, "X-RateLimit-Limit"); err != nil {
+			return err
+		}
+	}
+	if rl.remaining == nil {
+		if rl.remaining, err = rateLimitInt(header, "X-RateLimit-Remaining"); err != nil {
+			return err
+		}
+	}
+	if rl.reset == nil {
+		if rl.reset, err = rateLimitReset(header, "X-RateLimit-Reset", now); err != nil {
+			return err
+		}
+	}
+
+	if rl.retryAfter, err = retryAfterDelay(header, now); err != nil {
+		return err
+	}
+
+	if rl.limit == nil && rl.remaining == nil && rl.reset == nil &&
+		rl.retryAfter == nil && rl.policy == nil {
+		return errors.New("response has no rate limit headers")
+	}
+
+	return nil
+}
+
+func (rl *RateLimitStatus) parseCombined(values []string) error {
+	for key, value := range rateLimitParams(values) {
+		var target **int64
+
+		switch key {
+		case "limit":
+			target = &rl.limit
+		case "remaining", "r":
+			target = &rl.remaining
+		case "reset", "t":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid RateLimit %s value %q", key, value)
+			}
+			d := time.Duration(n) * time.Second
+			rl.reset = &d
+			continue
+		default:
+			continue
+		}
+
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid RateLimit %s value %q", key, value)
+		}
+		*target = &n
+	}
+
+	return nil
+}
+
+// Collect key=value parameters from comma-separated and semicolon-separated
+// items, e.g. "limit=100, remaining=50" or `"default";r=50;t=30`.
+func rateLimitParams(values []string) map[string]string {
+	params := map[string]string{}
+
+	for _, item := range splitHeaderList(values) {
+		for _, param := range strings.Split(item, ";") {
+			eq := strings.IndexByte(param, '=')
+			if eq < 0 {
+				continue
+			}
+
+			key := strings.ToLower(strings.TrimSpace(param[:eq]))
+			value := strings.Trim(strings.TrimSpace(param[eq+1:]), `"`)
+
+			if _, ok := params[key]; !ok {
+				params[key] = value
+			}
+		}
+	}
+
+	return params
+}
+
+func rateLimitInt(header http.Header, name string) (*int64, error) {
+	value := strings.TrimSpace(header.Get(name))
+	if value == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid %s header %q", name, value)
+	}
+
+	return &n, nil
+}
+
+func rateLimitSeconds(header http.Header, name string) (*time.Duration, error) {
+	n, err := rateLimitInt(header, name)
+	if n == nil || err != nil {
+		return nil, err
+	}
+
+	d := time.Duration(*n) * time.Second
+	return &d, nil
+}
+
+// Values that look like Unix timestamps (after 2001) are treated as
+// absolute time, and smaller values as number of seconds.
+func rateLimitReset(header http.Header, name string, now time.Time) (*time.Duration, error) {
+	n, err := rateLimitInt(header, name)
+	if n == nil || err != nil {
+		return nil, err
+	}
+
+	if *n < 1e9 {
+		d := time.Duration(*n) * time.Second
+		return &d, nil
+	}
+
+	d := time.Unix(*n, 0).Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return &d, nil
+}
+
+// Parse Retry-After header, which is either number of seconds or HTTP-date.
+func retryAfterDelay(header http.Header, now time.Time) (*time.Duration, error) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return nil, nil
+	}
+
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n < 0 {
+			return nil, fmt.Errorf("invalid Retry-After header %q", value)
+		}
+		d := time.Duration(n) * time.Second
+		return &d, nil
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Retry-After header %q", value)
+	}
+
+	d := t.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return &d, nil
+}
@@ -0,0 +1,238 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitStatusFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	rl := newRateLimitStatus(chain, http.Header{
+		"Ratelimit-Limit": {"100"},
+	}, time.Now())
+
+	rl.Limit().chain.assertFailed(t)
+	rl.Remaining().chain.assertFailed(t)
+	rl.Reset().chain.assertFailed(t)
+	rl.RetryAfter().chain.assertFailed(t)
+	rl.Policy().chain.assertFailed(t)
+}
+
+func TestRateLimitStatusConstructors(t *testing.T) {
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		rl := NewRateLimitStatus(reporter, http.Header{
+			"X-Ratelimit-Remaining": {"10"},
+		})
+		rl.Remaining().Equal(10)
+		rl.chain.assertNotFailed(t)
+	})
+
+	t.Run("nil header", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		rl := NewRateLimitStatus(reporter, nil)
+		rl.chain.assertFailed(t)
+	})
+
+	t.Run("no headers", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		rl := NewRateLimitStatus(reporter, http.Header{
+			"Content-Type": {"text/plain"},
+		})
+		rl.chain.assertFailed(t)
+	})
+}
+
+func TestRateLimitStatusHeaders(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		header    http.Header
+		limit     float64
+		remaining float64
+		reset     time.Duration
+		policy    string
+	}{
+		{
+			name: "ietf",
+			header: http.Header{
+				"Ratelimit-Limit":     {"100"},
+				"Ratelimit-Remaining": {"50"},
+				"Ratelimit-Reset":     {"30"},
+				"Ratelimit-Policy":    {"100;w=60"},
+			},
+			limit:     100,
+			remaining: 50,
+			reset:     30 * time.Second,
+			policy:    "100;w=60",
+		},
+		{
+			name: "ietf combined",
+			header: http.Header{
+				"Ratelimit": {"limit=100, remaining=50, reset=30"},
+			},
+			limit:     100,
+			remaining: 50,
+			reset:     30 * time.Second,
+		},
+		{
+			name: "ietf structured",
+			header: http.Header{
+				"Ratelimit":        {`"default";r=50;t=30`},
+				"Ratelimit-Policy": {`"default";q=100;w=60`},
+			},
+			limit:     100,
+			remaining: 50,
+			reset:     30 * time.Second,
+			policy:    `"default";q=100;w=60`,
+		},
+		{
+			name: "x-ratelimit seconds",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"100"},
+				"X-Ratelimit-Remaining": {"50"},
+				"X-Ratelimit-Reset":     {"30"},
+			},
+			limit:     100,
+			remaining: 50,
+			reset:     30 * time.Second,
+		},
+		{
+			name: "x-ratelimit timestamp",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"100"},
+				"X-Ratelimit-Remaining": {"50"},
+				"X-Ratelimit-Reset":     {"1577836830"},
+			},
+			limit:     100,
+			remaining: 50,
+			reset:     30 * time.Second,
+		},
+		{
+			name: "x-ratelimit timestamp with date",
+			header: http.Header{
+				"Date":                  {"Wed, 01 Jan 2020 00:00:20 GMT"},
+				"X-Ratelimit-Limit":     {"100"},
+				"X-Ratelimit-Remaining": {"50"},
+				"X-Ratelimit-Reset":     {"1577836830"},
+			},
+			limit:     100,
+			remaining: 50,
+			reset:     10 * time.Second,
+		},
+		{
+			name: "ietf preferred",
+			header: http.Header{
+				"Ratelimit-Limit":       {"100"},
+				"Ratelimit-Remaining":   {"50"},
+				"Ratelimit-Reset":       {"30"},
+				"X-Ratelimit-Limit":     {"200"},
+				"X-Ratelimit-Remaining": {"150"},
+				"X-Ratelimit-Reset":     {"130"},
+			},
+			limit:     100,
+			remaining: 50,
+			reset:     30 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+			rl := newRateLimitStatus(
+				newChainWithDefaults("test", reporter), tc.header, now)
+
+			rl.Limit().Equal(tc.limit)
+			rl.Remaining().Equal(tc.remaining)
+			rl.Reset().Equal(tc.reset)
+
+			if tc.policy != "" {
+				rl.Policy().Equal(tc.policy)
+			}
+
+			rl.chain.assertNotFailed(t)
+		})
+	}
+}
+
+func TestRateLimitStatusRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("seconds", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		rl := newRateLimitStatus(newChainWithDefaults("test", reporter),
+			http.Header{"Retry-After": {"120"}}, now)
+
+		rl.RetryAfter().Equal(2 * time.Minute)
+		rl.chain.assertNotFailed(t)
+
+		rl.Remaining()
+		rl.chain.assertFailed(t)
+	})
+
+	t.Run("http date", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		rl := newRateLimitStatus(newChainWithDefaults("test", reporter),
+			http.Header{"Retry-After": {"Wed, 01 Jan 2020 00:01:00 GMT"}}, now)
+
+		rl.RetryAfter().Equal(time.Minute)
+		rl.chain.assertNotFailed(t)
+	})
+
+	t.Run("past date", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		rl := newRateLimitStatus(newChainWithDefaults("test", reporter),
+			http.Header{"Retry-After": {"Tue, 31 Dec 2019 00:00:00 GMT"}}, now)
+
+		rl.RetryAfter().Equal(time.Duration(0))
+		rl.chain.assertNotFailed(t)
+	})
+}
+
+func TestRateLimitStatusInvalid(t *testing.T) {
+	headers := []http.Header{
+		{"Ratelimit-Limit": {"abc"}},
+		{"Ratelimit-Remaining": {"-1"}},
+		{"Ratelimit": {"limit=1, remaining=x"}},
+		{"Ratelimit": {`"default";t=-5`}},
+		{"X-Ratelimit-Reset": {"soon"}},
+		{"Retry-After": {"tomorrow"}},
+	}
+
+	for _, header := range headers {
+		reporter := newMockReporter(t)
+		rl := NewRateLimitStatus(reporter, header)
+		rl.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	}
+}
+
+func TestRateLimitStatusResponse(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"Retry-After":           {"5"},
+		},
+	})
+
+	rl := resp.RateLimit()
+	rl.Remaining().Equal(0)
+	rl.RetryAfter().Equal(5 * time.Second)
+	rl.chain.assertNotFailed(t)
+
+	resp = NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	})
+
+	resp.RateLimit().chain.assertFailed(t)
+}
@@ -0,0 +1,209 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a single JSON line written by RecordPrinter and
+// RecordAssertionHandler.
+type Record struct {
+	// Time when record was created.
+	Time time.Time `json:"time"`
+
+	// Kind of record: "request", "response", or "assertion".
+	Kind string `json:"kind"`
+
+	// Request method and URL ("request" and "response" records).
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+
+	// Response status and round-trip duration in milliseconds
+	// ("response" records).
+	Status   int     `json:"status,omitempty"`
+	Duration float64 `json:"duration_ms,omitempty"`
+
+	// Assertion details ("assertion" records).
+	Assertion *ReportEntry `json:"assertion,omitempty"`
+}
+
+// Write record as JSON line in a single Write call, so that concurrent
+// records are not interleaved and are not split by RotatingFile.
+func writeRecord(mu *sync.Mutex, w io.Writer, rec Record) {
+	if w == nil {
+		return
+	}
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	b = append(b, '\n')
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	_, _ = w.Write(b)
+}
+
+// RecordPrinter implements Printer. It writes every request and response
+// as a JSON line (see Record) to given writer.
+//
+// Bodies are not written and nothing is kept in memory, so RecordPrinter
+// is suitable for long-running programs, e.g. with RotatingFile.
+//
+// Example:
+//
+//	file, _ := httpexpect.NewRotatingFile("requests.log",
+//	    httpexpect.RotatingFileOpts{MaxSize: 10 << 20, Compress: true})
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    Reporter: reporter,
+//	    Printers: []httpexpect.Printer{
+//	        httpexpect.NewRecordPrinter(file),
+//	    },
+//	})
+type RecordPrinter struct {
+	mu     sync.Mutex
+	writer io.Writer
+	now    func() time.Time
+}
+
+// NewRecordPrinter returns a new RecordPrinter given a writer.
+func NewRecordPrinter(w io.Writer) *RecordPrinter {
+	return &RecordPrinter{writer: w, now: time.Now}
+}
+
+// Request implements Printer.Request.
+func (p *RecordPrinter) Request(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	writeRecord(&p.mu, p.writer, Record{
+		Time:   p.now(),
+		Kind:   "request",
+		Method: req.Method,
+		URL:    req.URL.String(),
+	})
+}
+
+// Response implements Printer.Response.
+func (p *RecordPrinter) Response(resp *http.Response, duration time.Duration) {
+	if resp == nil {
+		return
+	}
+
+	rec := Record{
+		Time:     p.now(),
+		Kind:     "response",
+		Status:   resp.StatusCode,
+		Duration: float64(duration) / float64(time.Millisecond),
+	}
+
+	if resp.Request != nil {
+		rec.Method = resp.Request.Method
+		rec.URL = resp.Request.URL.String()
+	}
+
+	writeRecord(&p.mu, p.writer, rec)
+}
+
+// RecordAssertionHandler is an AssertionHandler that writes every assertion
+// as a JSON line (see Record) to Writer, and then forwards it to Handler.
+//
+// Unlike ReportAssertionHandler, it doesn't keep assertions in memory,
+// so it's suitable for long-running programs, e.g. with RotatingFile.
+//
+// Example:
+//
+//	file, _ := httpexpect.NewRotatingFile("assertions.log",
+//	    httpexpect.RotatingFileOpts{Interval: time.Hour, MaxBackups: 48})
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    AssertionHandler: &httpexpect.RecordAssertionHandler{
+//	        Writer:        file,
+//	        SkipSuccesses: true,
+//	        Handler:       &httpexpect.DefaultAssertionHandler{...},
+//	    },
+//	})
+type RecordAssertionHandler struct {
+	// Writer receives records.
+	// May be nil.
+	Writer io.Writer
+
+	// Handler receives all assertions after they're written.
+	// May be nil.
+	Handler AssertionHandler
+
+	// Formatter is used to format failure messages stored in records.
+	// May be nil. If nil, only error messages are stored.
+	Formatter Formatter
+
+	// Don't write records for succeeded assertions.
+	SkipSuccesses bool
+
+	mu sync.Mutex
+}
+
+// Success implements AssertionHandler.Success.
+func (h *RecordAssertionHandler) Success(ctx *AssertionContext) {
+	if !h.SkipSuccesses {
+		writeRecord(&h.mu, h.Writer, Record{
+			Time: time.Now(),
+			Kind: "assertion",
+			Assertion: &ReportEntry{
+				TestName:    ctx.TestName,
+				RequestName: ctx.RequestName,
+				Path:        append([]string(nil), ctx.Path...),
+				Success:     true,
+			},
+		})
+	}
+
+	if h.Handler != nil {
+		h.Handler.Success(ctx)
+	}
+}
+
+// Failure implements AssertionHandler.Failure.
+func (h *RecordAssertionHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	entry := &ReportEntry{
+		TestName:    ctx.TestName,
+		RequestName: ctx.RequestName,
+		Path:        append([]string(nil), ctx.Path...),
+		Success:     false,
+		Type:        failure.Type.String(),
+		Code:        string(failure.Code),
+		Severity:    failure.Severity.String(),
+	}
+
+	for _, err := range failure.Errors {
+		if err == nil {
+			continue
+		}
+		entry.Errors = append(entry.Errors, err.Error())
+	}
+
+	if h.Formatter != nil {
+		entry.Message = h.Formatter.FormatFailure(ctx, failure)
+	} else {
+		entry.Message = strings.Join(entry.Errors, "\n")
+	}
+
+	writeRecord(&h.mu, h.Writer, Record{
+		Time:      time.Now(),
+		Kind:      "assertion",
+		Assertion: entry,
+	})
+
+	if h.Handler != nil {
+		h.Handler.Failure(ctx, failure)
+	}
+}
@@ -0,0 +1,131 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []Record {
+	var records []Record
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec Record
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+func TestRecordPrinter(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	p := NewRecordPrinter(buf)
+
+	req := &http.Request{
+		Method: "GET",
+		URL:    &url.URL{Scheme: "http", Host: "example.com", Path: "/test"},
+	}
+
+	p.Request(req)
+	p.Response(&http.Response{
+		StatusCode: http.StatusTeapot,
+		Request:    req,
+	}, 1500*time.Microsecond)
+
+	p.Request(nil)
+	p.Response(nil, 0)
+
+	records := decodeRecords(t, buf)
+	require.Equal(t, 2, len(records))
+
+	assert.Equal(t, "request", records[0].Kind)
+	assert.Equal(t, "GET", records[0].Method)
+	assert.Equal(t, "http://example.com/test", records[0].URL)
+	assert.False(t, records[0].Time.IsZero())
+
+	assert.Equal(t, "response", records[1].Kind)
+	assert.Equal(t, "GET", records[1].Method)
+	assert.Equal(t, http.StatusTeapot, records[1].Status)
+	assert.Equal(t, 1.5, records[1].Duration)
+}
+
+func TestRecordAssertionHandler(t *testing.T) {
+	ctx := &AssertionContext{
+		TestName:    "TestFoo",
+		RequestName: "login",
+		Path:        []string{"Request(\"GET\")", "Expect()", "Status()"},
+	}
+
+	failure := &AssertionFailure{
+		Type:   AssertEqual,
+		Code:   "status.mismatch",
+		Errors: []error{errors.New("unexpected status")},
+	}
+
+	t.Run("records", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		backend := &mockAssertionHandler{}
+
+		h := &RecordAssertionHandler{
+			Writer:  buf,
+			Handler: backend,
+		}
+
+		h.Success(ctx)
+		assert.Equal(t, ctx, backend.ctx)
+
+		h.Failure(ctx, failure)
+		assert.Equal(t, failure, backend.failure)
+
+		records := decodeRecords(t, buf)
+		require.Equal(t, 2, len(records))
+
+		assert.Equal(t, "assertion", records[0].Kind)
+		assert.True(t, records[0].Assertion.Success)
+		assert.Equal(t, ctx.Path, records[0].Assertion.Path)
+
+		assert.Equal(t, "assertion", records[1].Kind)
+		assert.False(t, records[1].Assertion.Success)
+		assert.Equal(t, "login", records[1].Assertion.RequestName)
+		assert.Equal(t, "status.mismatch", records[1].Assertion.Code)
+		assert.Equal(t, []string{"unexpected status"}, records[1].Assertion.Errors)
+		assert.Equal(t, "unexpected status", records[1].Assertion.Message)
+	})
+
+	t.Run("skip successes", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		h := &RecordAssertionHandler{
+			Writer:        buf,
+			Formatter:     &DefaultFormatter{},
+			SkipSuccesses: true,
+		}
+
+		h.Success(ctx)
+		h.Failure(ctx, failure)
+
+		records := decodeRecords(t, buf)
+		require.Equal(t, 1, len(records))
+		assert.False(t, records[0].Assertion.Success)
+		assert.Contains(t, records[0].Assertion.Message, "unexpected status")
+	})
+
+	t.Run("nil writer", func(t *testing.T) {
+		h := &RecordAssertionHandler{}
+
+		assert.NotPanics(t, func() {
+			h.Success(ctx)
+			h.Failure(ctx, failure)
+		})
+	})
+}
@@ -0,0 +1,475 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RedactionRules defines sensitive data that should never appear in logs
+// and reports, e.g. Authorization headers, passwords, and tokens.
+//
+// When set in Config.RedactionRules, rules are applied to requests and
+// responses passed to all Printers (including WebSocket messages), and to
+// values and messages produced by Formatter of the automatically
+// constructed DefaultAssertionHandler.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	    Printers: []httpexpect.Printer{
+//	        httpexpect.NewDebugPrinter(t, true),
+//	    },
+//	    RedactionRules: &httpexpect.RedactionRules{
+//	        Headers:   []string{"Authorization", "Cookie", "Set-Cookie"},
+//	        JSONPaths: []string{"$.password", "$..token"},
+//	        Patterns:  []*regexp.Regexp{regexp.MustCompile(`Bearer \S+`)},
+//	    },
+//	})
+type RedactionRules struct {
+	// Names of headers whose values should be hidden.
+	// Matched case-insensitively. Also applied to keys of JSON objects
+	// and maps, so that e.g. Response.Headers() values are redacted too.
+	Headers []string
+
+	// JSON paths of values that should be hidden in JSON bodies and
+	// JSON values. Supported syntax: "$.a.b", "$.a[0]", "$.a[*].b",
+	// "$.*", and recursive descent "$..b".
+	JSONPaths []string
+
+	// Regular expressions matching sensitive substrings. Applied to
+	// URLs, header values, bodies, and formatted messages.
+	Patterns []*regexp.Regexp
+
+	// String used instead of hidden data.
+	// If empty, "[REDACTED]" is used.
+	Replacement string
+}
+
+const defaultRedactionReplacement = "[REDACTED]"
+
+// WrapFormatter returns Formatter that applies redaction rules to
+// assertion values and messages, and then delegates formatting
+// to given formatter.
+//
+// Config.RedactionRules are applied automatically to DefaultAssertionHandler
+// constructed by Config. Use WrapFormatter when you construct
+// AssertionHandler manually.
+//
+// Example:
+//
+//	handler := &httpexpect.DefaultAssertionHandler{
+//	    Formatter: rules.WrapFormatter(&httpexpect.DefaultFormatter{}),
+//	    Reporter:  httpexpect.NewAssertReporter(t),
+//	}
+func (rules *RedactionRules) WrapFormatter(formatter Formatter) Formatter {
+	return &redactFormatter{
+		formatter: formatter,
+		rules:     rules,
+	}
+}
+
+type redactFormatter struct {
+	formatter Formatter
+	rules     *RedactionRules
+}
+
+func (f *redactFormatter) FormatSuccess(ctx *AssertionContext) string {
+	return f.rules.redactString(f.formatter.FormatSuccess(ctx))
+}
+
+func (f *redactFormatter) FormatFailure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) string {
+	redacted := *failure
+
+	redacted.Errors = nil
+	for _, err := range failure.Errors {
+		if err != nil {
+			err = &redactedError{
+				msg: f.rules.redactString(err.Error()),
+				err: err,
+			}
+		}
+		redacted.Errors = append(redacted.Errors, err)
+	}
+
+	redacted.Actual = f.rules.redactAssertionValue(failure.Actual)
+	redacted.Expected = f.rules.redactAssertionValue(failure.Expected)
+	redacted.Reference = f.rules.redactAssertionValue(failure.Reference)
+	redacted.Delta = f.rules.redactAssertionValue(failure.Delta)
+
+	return f.rules.redactString(f.formatter.FormatFailure(ctx, &redacted))
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Get rules for assertion handler: its own rules if set, or otherwise
+// Config.RedactionRules of the request that assertion belongs to.
+// May return nil.
+func contextRedactionRules(rules *RedactionRules, ctx *AssertionContext) *RedactionRules {
+	if rules == nil && ctx.Request != nil {
+		rules = ctx.Request.config.RedactionRules
+	}
+	return rules
+}
+
+func (rules *RedactionRules) replacement() string {
+	if rules.Replacement != "" {
+		return rules.Replacement
+	}
+	return defaultRedactionReplacement
+}
+
+func (rules *RedactionRules) isHeader(name string) bool {
+	for _, h := range rules.Headers {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rules *RedactionRules) redactString(s string) string {
+	for _, re := range rules.Patterns {
+		if re != nil {
+			s = re.ReplaceAllLiteralString(s, rules.replacement())
+		}
+	}
+	return s
+}
+
+func (rules *RedactionRules) redactHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	ret := http.Header{}
+
+	for k, values := range header {
+		for _, v := range values {
+			if rules.isHeader(k) {
+				ret[k] = append(ret[k], rules.replacement())
+			} else {
+				ret[k] = append(ret[k], rules.redactString(v))
+			}
+		}
+	}
+
+	return ret
+}
+
+func (rules *RedactionRules) redactURL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+
+	s := u.String()
+	if r := rules.redactString(s); r != s {
+		if parsed, err := url.Parse(r); err == nil {
+			return parsed
+		}
+	}
+
+	ret := *u
+	return &ret
+}
+
+func (rules *RedactionRules) redactBody(body []byte) []byte {
+	if len(rules.JSONPaths) != 0 || len(rules.Headers) != 0 {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err == nil {
+			redacted := rules.redactJSON(value)
+			if b, err := json.Marshal(redacted); err == nil {
+				body = b
+			}
+		}
+	}
+
+	if len(rules.Patterns) != 0 {
+		body = []byte(rules.redactString(string(body)))
+	}
+
+	return body
+}
+
+func (rules *RedactionRules) redactRequest(req *http.Request) *http.Request {
+	ret := req.Clone(req.Context())
+
+	ret.URL = rules.redactURL(req.URL)
+	ret.Header = rules.redactHeader(req.Header)
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var body []byte
+		if bw, ok := req.Body.(rewindBody); ok {
+			if rd, err := bw.GetBody(); err == nil {
+				body, _ = ioutil.ReadAll(rd)
+			}
+		} else if req.GetBody != nil {
+			if rd, err := req.GetBody(); err == nil {
+				body, _ = ioutil.ReadAll(rd)
+			}
+		}
+
+		body = rules.redactBody(body)
+
+		ret.Body = newBodyWrapper(ioutil.NopCloser(bytes.NewReader(body)), nil)
+		ret.ContentLength = int64(len(body))
+	}
+
+	return ret
+}
+
+func (rules *RedactionRules) redactResponse(resp *http.Response) *http.Response {
+	ret := *resp
+
+	ret.Header = rules.redactHeader(resp.Header)
+	ret.Trailer = rules.redactHeader(resp.Trailer)
+
+	if resp.Request != nil {
+		ret.Request = rules.redactRequest(resp.Request)
+	}
+
+	// streamed body can't be redacted without consuming it
+	if bw, ok := resp.Body.(*bodyWrapper); ok {
+		var body []byte
+		if rd, err := bw.GetBody(); err == nil {
+			body, _ = ioutil.ReadAll(rd)
+		}
+
+		body = rules.redactBody(body)
+
+		ret.Body = newBodyWrapper(ioutil.NopCloser(bytes.NewReader(body)), nil)
+		ret.ContentLength = int64(len(body))
+	} else if resp.Body != nil {
+		ret.Body = nil
+		ret.ContentLength = -1
+	}
+
+	return &ret
+}
+
+func (rules *RedactionRules) redactAssertionValue(v *AssertionValue) *AssertionValue {
+	if v == nil {
+		return nil
+	}
+
+	return &AssertionValue{rules.redactValue(v.Value)}
+}
+
+func (rules *RedactionRules) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return rules.redactString(v)
+
+	case []byte:
+		return rules.redactBody(v)
+
+	case http.Header:
+		return rules.redactHeader(v)
+
+	case *http.Header:
+		if v == nil {
+			return v
+		}
+		h := rules.redactHeader(*v)
+		return &h
+
+	case map[string]interface{}, []interface{}:
+		return rules.redactJSON(copyJSON(v))
+
+	case AssertionList:
+		ret := AssertionList{}
+		for _, e := range v {
+			ret = append(ret, rules.redactValue(e))
+		}
+		return ret
+
+	case AssertionRange:
+		return AssertionRange{
+			Min: rules.redactValue(v.Min),
+			Max: rules.redactValue(v.Max),
+		}
+
+	default:
+		return value
+	}
+}
+
+// Applies rules to JSON value in-place.
+func (rules *RedactionRules) redactJSON(value interface{}) interface{} {
+	for _, p := range rules.JSONPaths {
+		if segs, ok := parseRedactPath(p); ok {
+			value = redactPath(value, segs, rules.replacement())
+		}
+	}
+
+	return rules.redactJSONStrings(value)
+}
+
+func (rules *RedactionRules) redactJSONStrings(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if rules.isHeader(k) {
+				v[k] = rules.replacement()
+			} else {
+				v[k] = rules.redactJSONStrings(e)
+			}
+		}
+		return v
+
+	case []interface{}:
+		for i, e := range v {
+			v[i] = rules.redactJSONStrings(e)
+		}
+		return v
+
+	case string:
+		return rules.redactString(v)
+
+	default:
+		return value
+	}
+}
+
+func copyJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			ret[k] = copyJSON(e)
+		}
+		return ret
+
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, e := range v {
+			ret[i] = copyJSON(e)
+		}
+		return ret
+
+	default:
+		return value
+	}
+}
+
+type redactSegment struct {
+	key       string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+func parseRedactPath(path string) ([]redactSegment, bool) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+
+	var segs []redactSegment
+
+	for len(path) != 0 {
+		var seg redactSegment
+
+		switch {
+		case strings.HasPrefix(path, ".."):
+			seg.recursive = true
+			path = path[2:]
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+		}
+
+		if strings.HasPrefix(path, "[") {
+			end := strings.Index(path, "]")
+			if end < 0 {
+				return nil, false
+			}
+			inner := path[1:end]
+			path = path[end+1:]
+
+			switch {
+			case inner == "*":
+				seg.wildcard = true
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') &&
+				inner[len(inner)-1] == inner[0]:
+				seg.key = inner[1 : len(inner)-1]
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, false
+				}
+				seg.index = n
+				seg.isIndex = true
+			}
+		} else {
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			name := path[:end]
+			path = path[end:]
+
+			if name == "" {
+				return nil, false
+			}
+			if name == "*" {
+				seg.wildcard = true
+			} else {
+				seg.key = name
+			}
+		}
+
+		segs = append(segs, seg)
+	}
+
+	return segs, len(segs) != 0
+}
+
+func redactPath(value interface{}, segs []redactSegment, replacement string) interface{} {
+	if len(segs) == 0 {
+		return replacement
+	}
+
+	seg := segs[0]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if seg.wildcard || (!seg.isIndex && seg.key == k) {
+				v[k] = redactPath(e, segs[1:], replacement)
+			} else if seg.recursive {
+				v[k] = redactPath(e, segs, replacement)
+			}
+		}
+		return v
+
+	case []interface{}:
+		for i, e := range v {
+			if seg.wildcard || (seg.isIndex && seg.index == i) {
+				v[i] = redactPath(e, segs[1:], replacement)
+			} else if seg.recursive {
+				v[i] = redactPath(e, segs, replacement)
+			}
+		}
+		return v
+
+	default:
+		return value
+	}
+}
@@ -0,0 +1,310 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactionPaths(t *testing.T) {
+	cases := []struct {
+		path     string
+		input    string
+		expected string
+	}{
+		{
+			path:     "$.password",
+			input:    `{"password":"secret","name":"john"}`,
+			expected: `{"name":"john","password":"***"}`,
+		},
+		{
+			path:     "user.token",
+			input:    `{"user":{"token":"abc","id":1},"token":"keep"}`,
+			expected: `{"token":"keep","user":{"id":1,"token":"***"}}`,
+		},
+		{
+			path:     "$..token",
+			input:    `{"a":{"b":[{"token":"x"},{"token":"y"}]},"token":"z"}`,
+			expected: `{"a":{"b":[{"token":"***"},{"token":"***"}]},"token":"***"}`,
+		},
+		{
+			path:     "$.items[*].key",
+			input:    `{"items":[{"key":1},{"key":2}]}`,
+			expected: `{"items":[{"key":"***"},{"key":"***"}]}`,
+		},
+		{
+			path:     "$.items[1]",
+			input:    `{"items":[1,2,3]}`,
+			expected: `{"items":[1,"***",3]}`,
+		},
+		{
+			path:     "$['secret'].*",
+			input:    `{"secret":{"a":1,"b":2}}`,
+			expected: `{"secret":{"a":"***","b":"***"}}`,
+		},
+		{
+			path:     "$.missing",
+			input:    `{"a":1}`,
+			expected: `{"a":1}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			rules := &RedactionRules{
+				JSONPaths:   []string{tc.path},
+				Replacement: "***",
+			}
+
+			assert.Equal(t, tc.expected,
+				string(rules.redactBody([]byte(tc.input))))
+		})
+	}
+
+	for _, path := range []string{"", "$", "$.a[", "$.a[x]", "$.a..", "$.."} {
+		_, ok := parseRedactPath(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestRedactionRequest(t *testing.T) {
+	rules := &RedactionRules{
+		Headers:   []string{"authorization"},
+		JSONPaths: []string{"$.password"},
+		Patterns:  []*regexp.Regexp{regexp.MustCompile(`key=\w+`)},
+	}
+
+	req, err := http.NewRequest("POST", "http://example.com/path?key=123",
+		strings.NewReader(`{"password":"secret"}`))
+	require.NoError(t, err)
+
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Trace", "key=abc")
+	req.Body = newBodyWrapper(req.Body, nil)
+
+	redacted := rules.redactRequest(req)
+
+	assert.Equal(t, "[REDACTED]", redacted.Header.Get("Authorization"))
+	assert.Equal(t, "[REDACTED]", redacted.Header.Get("X-Trace"))
+	assert.Equal(t, "http://example.com/path?[REDACTED]", redacted.URL.String())
+
+	body, err := ioutil.ReadAll(redacted.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"password":"[REDACTED]"}`, string(body))
+
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	assert.Equal(t, "http://example.com/path?key=123", req.URL.String())
+
+	body, err = ioutil.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"password":"secret"}`, string(body))
+}
+
+func TestRedactionResponse(t *testing.T) {
+	rules := &RedactionRules{
+		Headers:   []string{"Set-Cookie"},
+		JSONPaths: []string{"$..token"},
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Set-Cookie":   {"session=123"},
+			"Content-Type": {"application/json"},
+		},
+		Body: newBodyWrapper(ioutil.NopCloser(
+			bytes.NewBufferString(`{"data":{"token":"abc"}}`)), nil),
+	}
+
+	redacted := rules.redactResponse(resp)
+
+	assert.Equal(t, "[REDACTED]", redacted.Header.Get("Set-Cookie"))
+	assert.Equal(t, "application/json", redacted.Header.Get("Content-Type"))
+
+	body, err := ioutil.ReadAll(redacted.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":{"token":"[REDACTED]"}}`, string(body))
+
+	body, err = ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":{"token":"abc"}}`, string(body))
+
+	streamed := &mockBody{reader: bytes.NewBufferString("stream")}
+
+	redacted = rules.redactResponse(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       streamed,
+	})
+
+	assert.Nil(t, redacted.Body)
+	assert.Equal(t, int64(-1), redacted.ContentLength)
+
+	body, err = ioutil.ReadAll(streamed)
+	require.NoError(t, err)
+	assert.Equal(t, "stream", string(body))
+}
+
+func TestRedactionStreaming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Token", "secret")
+
+			_, _ = w.Write([]byte("first\n"))
+			w.(http.Flusher).Flush()
+
+			// endless stream
+			<-r.Context().Done()
+		}))
+	defer server.Close()
+
+	logger := newMockLogger(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Printers: []Printer{
+			NewDebugPrinter(logger, true),
+		},
+		RedactionRules: &RedactionRules{
+			Headers: []string{"X-Token"},
+		},
+	})
+
+	done := make(chan *Response, 1)
+
+	go func() {
+		done <- e.GET("/").WithStreaming().Expect()
+	}()
+
+	var resp *Response
+
+	select {
+	case resp = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("printer consumed streamed body")
+	}
+
+	resp.chain.assertNotFailed(t)
+
+	assert.True(t, logger.logged)
+	assert.NotContains(t, logger.lastMessage, "secret")
+	assert.Contains(t, logger.lastMessage, "[REDACTED]")
+
+	stream := resp.BodyStream().WithTimeout(5 * time.Second)
+
+	stream.ReadLine().Equal("first")
+	stream.chain.assertNotFailed(t)
+
+	stream.Close()
+}
+
+func TestRedactionFormatter(t *testing.T) {
+	rules := &RedactionRules{
+		Headers:   []string{"Authorization"},
+		JSONPaths: []string{"$.password"},
+		Patterns:  []*regexp.Regexp{regexp.MustCompile(`Bearer \S+`)},
+	}
+
+	formatter := rules.WrapFormatter(&DefaultFormatter{})
+
+	actual := map[string]interface{}{
+		"password":      "secret1",
+		"Authorization": "secret2",
+		"name":          "john",
+	}
+
+	failure := &AssertionFailure{
+		Type: AssertEqual,
+		Errors: []error{
+			errors.New("header is Bearer secret3"),
+		},
+		Actual:   &AssertionValue{actual},
+		Expected: &AssertionValue{"Bearer secret4"},
+	}
+
+	msg := formatter.FormatFailure(&AssertionContext{}, failure)
+
+	assert.NotContains(t, msg, "secret")
+	assert.Contains(t, msg, "john")
+	assert.Contains(t, msg, "[REDACTED]")
+
+	assert.Equal(t, "secret1", actual["password"])
+	assert.Equal(t, "header is Bearer secret3", failure.Errors[0].Error())
+}
+
+func TestRedactionValues(t *testing.T) {
+	rules := &RedactionRules{
+		Headers:  []string{"Authorization"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`secret`)},
+	}
+
+	assert.Equal(t, "[REDACTED]", rules.redactValue("secret"))
+	assert.Equal(t, []byte("[REDACTED]"), rules.redactValue([]byte("secret")))
+	assert.Equal(t, 123, rules.redactValue(123))
+
+	assert.Equal(t,
+		http.Header{"Authorization": {"[REDACTED]"}},
+		rules.redactValue(http.Header{"Authorization": {"token"}}))
+
+	assert.Equal(t,
+		[]interface{}{"[REDACTED]", 1.0},
+		rules.redactValue([]interface{}{"secret", 1.0}))
+
+	assert.Equal(t,
+		AssertionList{"[REDACTED]", "other"},
+		rules.redactValue(AssertionList{"secret", "other"}))
+
+	assert.Equal(t,
+		AssertionRange{Min: "[REDACTED]", Max: 1},
+		rules.redactValue(AssertionRange{Min: "secret", Max: 1}))
+}
+
+func TestRedactionE2E(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"token":"response-secret"}`))
+		}))
+	defer server.Close()
+
+	har := NewHARPrinter()
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+		Printers: []Printer{har},
+		RedactionRules: &RedactionRules{
+			Headers:   []string{"Authorization"},
+			JSONPaths: []string{"$.password", "$.token"},
+		},
+	})
+
+	resp := e.POST("/login").
+		WithHeader("Authorization", "Bearer header-secret").
+		WithJSON(map[string]interface{}{"password": "request-secret"}).
+		Expect()
+
+	resp.JSON().Object().ValueEqual("token", "response-secret")
+	resp.chain.assertNotFailed(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, har.Write(&buf))
+
+	var decoded interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	assert.NotContains(t, buf.String(), "secret")
+	assert.Contains(t, buf.String(), "[REDACTED]")
+}
@@ -0,0 +1,290 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RedirectChain returns a new Array instance with intermediate responses
+// received while following redirects, in order in which they were received.
+// Final response is not included, so array is empty if there were no
+// redirects.
+//
+// Every element is an object with the following fields:
+//   - "method": method of request that caused the response
+//   - "url": URL of request that caused the response
+//   - "status": response status code
+//   - "location": value of Location header
+//   - "cookies": object mapping names of cookies set by response to values
+//
+// Note that bodies of intermediate responses are not available, since
+// http.Client closes them when following redirects.
+//
+// Example:
+//
+//	resp := e.POST("/login").WithForm(creds).Expect()
+//
+//	hops := resp.RedirectChain()
+//	hops.Length().Equal(2)
+//	hops.Element(0).Object().ValueEqual("status", http.StatusSeeOther)
+//	hops.Element(0).Object().Value("cookies").Object().ContainsKey("session")
+//	hops.Element(1).Object().ValueEqual("location", "/dashboard")
+func (r *Response) RedirectChain() *Array {
+	r.chain.enter("RedirectChain()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newArray(r.chain, nil)
+	}
+
+	return newArray(r.chain, redirectHops(r.httpResp))
+}
+
+// Walks from final response back to the first one. When following
+// redirects, http.Client stores response that caused redirect in
+// Response field of the next request.
+func redirectHops(resp *http.Response) []interface{} {
+	var responses []*http.Response
+
+	for req := resp.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		responses = append(responses, req.Response)
+	}
+
+	hops := []interface{}{}
+
+	for i := len(responses) - 1; i >= 0; i-- {
+		hops = append(hops, redirectHop(responses[i]))
+	}
+
+	return hops
+}
+
+func redirectHop(resp *http.Response) map[string]interface{} {
+	method, url := "", ""
+	if resp.Request != nil {
+		method = resp.Request.Method
+		if resp.Request.URL != nil {
+			url = resp.Request.URL.String()
+		}
+	}
+
+	cookies := map[string]interface{}{}
+	for _, c := range resp.Cookies() {
+		cookies[c.Name] = c.Value
+	}
+
+	return map[string]interface{}{
+		"method":   method,
+		"url":      url,
+		"status":   resp.StatusCode,
+		"location": resp.Header.Get("Location"),
+		"cookies":  cookies,
+	}
+}
+
+// RedirectChecks defines security checks applied when following redirects.
+// Checks can be combined using bitwise OR.
+type RedirectChecks int
+
+const (
+	// RedirectRefuseDowngrade fails request if it's redirected from
+	// https:// URL to http:// URL.
+	RedirectRefuseDowngrade RedirectChecks = 1 << iota
+
+	// RedirectStripAuth removes Authorization, WWW-Authenticate, and
+	// Cookie headers when request is redirected to another origin, i.e.
+	// when scheme, host, or port differs from original request.
+	//
+	// http.Client strips these headers only when host changes and is not
+	// a subdomain of original host, so credentials may still be sent e.g.
+	// over plain http:// to the same host. Cookies from Config.Client jar
+	// are not affected.
+	RedirectStripAuth
+
+	// RedirectForbidAuthLeak fails request if Authorization header would
+	// be sent when request is redirected to another origin.
+	//
+	// Combined with RedirectStripAuth, it verifies that header was stripped.
+	// Alone, it detects leaks of credentials by server redirects.
+	RedirectForbidAuthLeak
+
+	// RedirectStrict enables all checks.
+	RedirectStrict = RedirectRefuseDowngrade | RedirectStripAuth | RedirectForbidAuthLeak
+)
+
+// Headers removed by RedirectStripAuth.
+var redirectAuthHeaders = []string{
+	"Authorization",
+	"Www-Authenticate",
+	"Cookie",
+	"Cookie2",
+}
+
+// WithRedirectChecks enables security checks for redirects.
+// See RedirectChecks for details.
+//
+// Violations of checks are reported as failures, and redirect is not
+// followed. Also, if WithMaxRedirects is used, exceeding redirect limit
+// is reported as a structured failure with the number of redirects.
+//
+// This method can be used only if Client interface points to
+// *http.Client struct, since we rely on it in redirect handling.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "https://example.com/login")
+//	req.WithBasicAuth("user", "pass")
+//	req.WithRedirectChecks(RedirectRefuseDowngrade | RedirectStripAuth)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRedirectChecks(checks RedirectChecks) *Request {
+	r.chain.enter("WithRedirectChecks()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if checks&^RedirectStrict != 0 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected redirect checks %d", checks),
+			},
+		})
+		return r
+	}
+
+	r.redirectChecks = checks
+
+	return r
+}
+
+// Returned from CheckRedirect to report failure instead of generic
+// error of http.Client.
+type redirectViolation struct {
+	failure AssertionFailure
+}
+
+func (v *redirectViolation) Error() string {
+	return v.failure.Errors[0].Error()
+}
+
+// CheckRedirect function used when redirect limit or checks are set.
+// next is invoked when neither limit is set nor checks fail; if nil,
+// default http.Client limit is used.
+func (r *Request) checkRedirect(
+	next func(*http.Request, []*http.Request) error,
+) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if r.maxRedirects >= 0 && len(via) > r.maxRedirects {
+			return &redirectViolation{AssertionFailure{
+				Type:     AssertLe,
+				Actual:   &AssertionValue{len(via)},
+				Expected: &AssertionValue{r.maxRedirects},
+				Errors: []error{
+					fmt.Errorf("stopped after %d redirects", r.maxRedirects),
+					fmt.Errorf("redirect chain: %s", redirectURLs(req, via)),
+				},
+			}}
+		}
+
+		if r.redirectChecks != 0 && len(via) != 0 {
+			if err := r.checkRedirectSecurity(req, via); err != nil {
+				return err
+			}
+		}
+
+		if r.maxRedirects >= 0 {
+			return nil
+		}
+
+		if next != nil {
+			return next(req, via)
+		}
+
+		// same as default policy of http.Client
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		return nil
+	}
+}
+
+func (r *Request) checkRedirectSecurity(req *http.Request, via []*http.Request) error {
+	prev := via[len(via)-1]
+
+	if r.redirectChecks&RedirectRefuseDowngrade != 0 &&
+		prev.URL.Scheme == "https" && req.URL.Scheme == "http" {
+		return &redirectViolation{AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{req.URL.String()},
+			Errors: []error{
+				fmt.Errorf("expected: redirect from %q doesn't downgrade to http",
+					prev.URL.String()),
+			},
+		}}
+	}
+
+	crossOrigin := !sameOrigin(via[0].URL, req.URL)
+
+	if crossOrigin && r.redirectChecks&RedirectStripAuth != 0 {
+		for _, name := range redirectAuthHeaders {
+			req.Header.Del(name)
+		}
+	}
+
+	if crossOrigin && r.redirectChecks&RedirectForbidAuthLeak != 0 &&
+		req.Header.Get("Authorization") != "" {
+		return &redirectViolation{AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{headerNames(req.Header)},
+			Expected: &AssertionValue{"Authorization"},
+			Errors: []error{
+				fmt.Errorf(
+					"expected: Authorization header is not sent"+
+						" on cross-origin redirect from %q to %q",
+					via[0].URL.String(), req.URL.String()),
+			},
+		}}
+	}
+
+	return nil
+}
+
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Hostname(), b.Hostname()) &&
+		urlPort(a) == urlPort(b)
+}
+
+func urlPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "https", "wss":
+		return "443"
+	default:
+		return "80"
+	}
+}
+
+func redirectURLs(req *http.Request, via []*http.Request) string {
+	requests := make([]*http.Request, 0, len(via)+1)
+	requests = append(requests, via...)
+	requests = append(requests, req)
+
+	urls := make([]string, 0, len(requests))
+	for _, r := range requests {
+		if r != nil && r.URL != nil {
+			urls = append(urls, r.URL.String())
+		}
+	}
+
+	return strings.Join(urls, " -> ")
+}
@@ -0,0 +1,223 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseRedirectChain(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+		http.Redirect(w, r, "/callback", http.StatusSeeOther)
+	})
+
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "state", Value: "ok"})
+		http.Redirect(w, r, "/home", http.StatusFound)
+	})
+
+	mux.HandleFunc("/home", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("home"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	resp := e.POST("/login").Expect()
+	resp.chain.assertNotFailed(t)
+
+	hops := resp.RedirectChain()
+	resp.chain.assertNotFailed(t)
+
+	hops.Equal([]interface{}{
+		map[string]interface{}{
+			"method":   "POST",
+			"url":      server.URL + "/login",
+			"status":   http.StatusSeeOther,
+			"location": "/callback",
+			"cookies": map[string]interface{}{
+				"session": "abc",
+			},
+		},
+		map[string]interface{}{
+			"method":   "GET",
+			"url":      server.URL + "/callback",
+			"status":   http.StatusFound,
+			"location": "/home",
+			"cookies": map[string]interface{}{
+				"state": "ok",
+			},
+		},
+	})
+	hops.chain.assertNotFailed(t)
+
+	raw := NewResponse(reporter, &http.Response{})
+	raw.RedirectChain().Empty()
+	raw.chain.assertNotFailed(t)
+}
+
+func TestRequestRedirectChecks(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+		}))
+	defer target.Close()
+
+	origin := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/same":
+				http.Redirect(w, r, "/final", http.StatusFound)
+			case "/final":
+				_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+			case "/loop":
+				http.Redirect(w, r, "/loop", http.StatusFound)
+			default:
+				http.Redirect(w, r, target.URL+"/final", http.StatusFound)
+			}
+		}))
+	defer origin.Close()
+
+	secure := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL+"/final", http.StatusFound)
+		}))
+	defer secure.Close()
+
+	newExpectURL := func(handler AssertionHandler, baseURL string) *Expect {
+		return WithConfig(Config{
+			BaseURL:          baseURL,
+			AssertionHandler: handler,
+			Client:           secure.Client(),
+		})
+	}
+
+	newExpect := func(handler AssertionHandler) *Expect {
+		return newExpectURL(handler, origin.URL)
+	}
+
+	t.Run("no checks", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		newExpect(handler).GET("/cross").
+			WithHeader("Authorization", "secret").
+			Expect().
+			Body().Equal("secret")
+
+		assert.Nil(t, handler.failure)
+	})
+
+	t.Run("strip auth", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		newExpect(handler).GET("/cross").
+			WithHeader("Authorization", "secret").
+			WithRedirectChecks(RedirectStripAuth).
+			Expect().
+			Body().Empty()
+
+		newExpect(handler).GET("/same").
+			WithHeader("Authorization", "secret").
+			WithRedirectChecks(RedirectStripAuth).
+			Expect().
+			Body().Equal("secret")
+
+		assert.Nil(t, handler.failure)
+	})
+
+	t.Run("forbid auth leak", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		newExpect(handler).GET("/cross").
+			WithHeader("Authorization", "secret").
+			WithRedirectChecks(RedirectForbidAuthLeak).
+			Expect()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertNotContainsKey, handler.failure.Type)
+		assert.Equal(t, "Authorization", handler.failure.Expected.Value)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		newExpect(handler).GET("/cross").
+			WithHeader("Authorization", "secret").
+			WithRedirectChecks(RedirectStrict).
+			Expect().
+			Body().Empty()
+
+		assert.Nil(t, handler.failure)
+	})
+
+	t.Run("refuse downgrade", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		newExpectURL(handler, secure.URL).GET("/login").
+			Expect().
+			Status(http.StatusOK)
+
+		assert.Nil(t, handler.failure)
+
+		newExpectURL(handler, secure.URL).GET("/login").
+			WithRedirectChecks(RedirectRefuseDowngrade).
+			Expect()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Equal(t, target.URL+"/final", handler.failure.Actual.Value)
+	})
+
+	t.Run("max redirects", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		newExpect(handler).GET("/loop").
+			WithMaxRedirects(3).
+			Expect()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertLe, handler.failure.Type)
+		assert.Equal(t, 4, handler.failure.Actual.Value)
+		assert.Equal(t, 3, handler.failure.Expected.Value)
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		newExpect(handler).GET("/loop").
+			WithRedirectChecks(RedirectStrict).
+			Expect()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		req := newExpect(handler).GET("/same").
+			WithRedirectChecks(RedirectChecks(1 << 10))
+		req.chain.assertFailed(t)
+
+		req = NewRequestC(Config{
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+		}, "GET", "/same").
+			WithRedirectChecks(RedirectStrict)
+		req.Expect()
+		req.chain.assertFailed(t)
+	})
+}
@@ -0,0 +1,492 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RepeatedRequest sends the same request multiple times, possibly
+// concurrently, see Request.Repeat.
+type RepeatedRequest struct {
+	chain       *chain
+	req         *Request
+	count       int
+	concurrency int
+}
+
+// Repeat returns RepeatedRequest, that sends this request given number
+// of times and aggregates results.
+//
+// It allows light load testing using the same DSL. Use Concurrency to
+// send requests in parallel, and Expect to send them and get
+// AggregateResponse.
+//
+// Request should not be used after calling Repeat.
+//
+// Example:
+//
+//	agg := e.GET("/users").
+//	    Repeat(1000).
+//	    Concurrency(50).
+//	    Expect()
+//
+//	agg.ErrorRate().Le(0.01)
+//	agg.StatusCount(http.StatusOK).Ge(990)
+//	agg.Latency().Percentile(99).Lt(200 * time.Millisecond)
+func (r *Request) Repeat(count int) *RepeatedRequest {
+	r.chain.enter("Repeat(%d)", count)
+	defer r.chain.leave()
+
+	rr := &RepeatedRequest{
+		req:         r,
+		count:       count,
+		concurrency: 1,
+	}
+
+	if r.chain.failed() {
+		rr.chain = r.chain.clone()
+		return rr
+	}
+
+	if count < 1 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive repeat count: %d", count),
+			},
+		})
+	} else if r.wsUpgrade {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected Repeat for websocket request"),
+			},
+		})
+	}
+
+	rr.chain = r.chain.clone()
+
+	return rr
+}
+
+// Concurrency sets maximum number of requests sent in parallel.
+// Default is 1, i.e. requests are sent sequentially.
+//
+// Example:
+//
+//	agg := e.GET("/users").Repeat(1000).Concurrency(50).Expect()
+func (rr *RepeatedRequest) Concurrency(n int) *RepeatedRequest {
+	rr.chain.enter("Concurrency(%d)", n)
+	defer rr.chain.leave()
+
+	if rr.chain.failed() {
+		return rr
+	}
+
+	if n < 1 {
+		rr.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-positive concurrency: %d", n),
+			},
+		})
+		return rr
+	}
+
+	rr.concurrency = n
+
+	return rr
+}
+
+// Expect sends all requests, waits until they're finished, and returns
+// AggregateResponse.
+//
+// Every request is sent as usual, including retries, printers, and
+// matchers registered by Expect.Matcher or Request.WithMatcher. Failures
+// of individual requests are not reported; instead, request is counted
+// as failed, and AggregateResponse can be used to check error rate.
+// Request is failed if it couldn't be sent, response wasn't received,
+// or any matcher failed.
+//
+// Config.OnFailure and Config.OnSuccess are not invoked for individual
+// requests either. They are invoked for assertions on AggregateResponse,
+// so that number of hook calls doesn't depend on number of requests or
+// concurrency.
+//
+// Example:
+//
+//	agg := e.GET("/users").
+//	    WithMatcher(func(resp *httpexpect.Response) {
+//	        resp.StatusRange(httpexpect.Status2xx)
+//	    }).
+//	    Repeat(100).
+//	    Expect()
+//
+//	agg.ErrorRate().Equal(0)
+func (rr *RepeatedRequest) Expect() *AggregateResponse {
+	rr.chain.enter("Expect()")
+	defer rr.chain.leave()
+
+	if rr.chain.failed() {
+		return newAggregateResponse(rr.chain, nil, 0)
+	}
+
+	body, ok := rr.prepareBody()
+	if !ok {
+		return newAggregateResponse(rr.chain, nil, 0)
+	}
+
+	results := make([]repeatResult, rr.count)
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for w := 0; w < rr.concurrency && w < rr.count; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				results[index] = rr.send(index, body)
+			}
+		}()
+	}
+
+	for index := 0; index < rr.count; index++ {
+		indexes <- index
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return newAggregateResponse(rr.chain, results, time.Since(start))
+}
+
+// Encode form and read request body, so that it can be sent many times.
+func (rr *RepeatedRequest) prepareBody() ([]byte, bool) {
+	r := rr.req
+
+	if !r.encodeForm() {
+		rr.chain.setFailed()
+		return nil, false
+	}
+
+	if r.httpReq.Body == nil || r.httpReq.Body == http.NoBody {
+		return nil, true
+	}
+
+	body, err := ioutil.ReadAll(r.httpReq.Body)
+	if err != nil {
+		rr.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read request body"),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	_ = r.httpReq.Body.Close()
+	r.httpReq.Body = nil
+
+	return body, true
+}
+
+// Send single request using a copy of Request that collects failures
+// instead of reporting them.
+func (rr *RepeatedRequest) send(index int, body []byte) repeatResult {
+	handler := &repeatHandler{}
+
+	req := *rr.req
+
+	req.chain = rr.chain.clone()
+	req.chain.handler = handler
+	req.chain.hooks = nil
+	req.chain.replace("Expect[%d]", index)
+	req.chain.setRequest(&req)
+
+	req.httpReq = rr.req.httpReq.Clone(rr.req.httpReq.Context())
+	if body != nil {
+		req.httpReq.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	resp := req.Expect()
+
+	result := repeatResult{
+		failures: handler.failures,
+	}
+
+	if resp.httpResp != nil {
+		result.resp = resp.httpResp
+	}
+	if resp.rtt != nil {
+		rtt := *resp.rtt
+		result.rtt = &rtt
+	}
+
+	return result
+}
+
+type repeatResult struct {
+	resp     *http.Response
+	rtt      *time.Duration
+	failures []*AssertionFailure
+}
+
+// AssertionHandler that collects failures of a single repeated request.
+type repeatHandler struct {
+	mu       sync.Mutex
+	failures []*AssertionFailure
+}
+
+func (h *repeatHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *repeatHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, failure)
+}
+
+// AggregateResponse provides methods to inspect results of requests
+// sent by RepeatedRequest.Expect.
+type AggregateResponse struct {
+	chain    *chain
+	results  []repeatResult
+	duration time.Duration
+}
+
+func newAggregateResponse(
+	parent *chain, results []repeatResult, duration time.Duration,
+) *AggregateResponse {
+	return &AggregateResponse{
+		chain:    parent.clone(),
+		results:  results,
+		duration: duration,
+	}
+}
+
+// Raw returns underlying http.Response objects of all requests, in order
+// they were started. Response is nil if it wasn't received.
+func (a *AggregateResponse) Raw() []*http.Response {
+	ret := make([]*http.Response, len(a.results))
+	for i, res := range a.results {
+		ret[i] = res.resp
+	}
+	return ret
+}
+
+// Requests returns a new Number instance with total number of requests.
+//
+// Example:
+//
+//	agg.Requests().Equal(1000)
+func (a *AggregateResponse) Requests() *Number {
+	a.chain.enter("Requests()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return newNumber(a.chain, 0)
+	}
+
+	return newNumber(a.chain, float64(len(a.results)))
+}
+
+// Failed returns a new Number instance with number of failed requests.
+//
+// Example:
+//
+//	agg.Failed().Le(10)
+func (a *AggregateResponse) Failed() *Number {
+	a.chain.enter("Failed()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return newNumber(a.chain, 0)
+	}
+
+	return newNumber(a.chain, float64(a.countFailed()))
+}
+
+// ErrorRate returns a new Number instance with ratio of failed requests
+// to total number of requests, from 0 to 1.
+//
+// Example:
+//
+//	agg.ErrorRate().Le(0.01)
+func (a *AggregateResponse) ErrorRate() *Number {
+	a.chain.enter("ErrorRate()")
+	defer a.chain.leave()
+
+	if a.chain.failed() || len(a.results) == 0 {
+		return newNumber(a.chain, 0)
+	}
+
+	return newNumber(a.chain, float64(a.countFailed())/float64(len(a.results)))
+}
+
+// Errors returns a new Array instance with error messages of failed
+// requests, one string per failed request, in order requests were started.
+//
+// Example:
+//
+//	agg.Errors().Empty()
+func (a *AggregateResponse) Errors() *Array {
+	a.chain.enter("Errors()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return newArray(a.chain, nil)
+	}
+
+	errs := []interface{}{}
+	for i, res := range a.results {
+		if len(res.failures) != 0 {
+			errs = append(errs,
+				fmt.Sprintf("request %d: %s", i, joinFailures(res.failures)))
+		}
+	}
+
+	return newArray(a.chain, errs)
+}
+
+// Statuses returns a new Object instance with distribution of response
+// status codes. Keys are status codes, and values are number of responses.
+// Requests without response are not accounted.
+//
+// Example:
+//
+//	agg.Statuses().Keys().ContainsOnly("200", "429")
+func (a *AggregateResponse) Statuses() *Object {
+	a.chain.enter("Statuses()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return newObject(a.chain, nil)
+	}
+
+	counts := map[int]int{}
+	for _, res := range a.results {
+		if res.resp != nil {
+			counts[res.resp.StatusCode]++
+		}
+	}
+
+	codes := make([]int, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	statuses := map[string]interface{}{}
+	for _, code := range codes {
+		statuses[strconv.Itoa(code)] = float64(counts[code])
+	}
+
+	return newObject(a.chain, statuses)
+}
+
+// StatusCount returns a new Number instance with number of responses
+// with given status code.
+//
+// Example:
+//
+//	agg.StatusCount(http.StatusTooManyRequests).Le(10)
+func (a *AggregateResponse) StatusCount(status int) *Number {
+	a.chain.enter("StatusCount(%d)", status)
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return newNumber(a.chain, 0)
+	}
+
+	count := 0
+	for _, res := range a.results {
+		if res.resp != nil && res.resp.StatusCode == status {
+			count++
+		}
+	}
+
+	return newNumber(a.chain, float64(count))
+}
+
+// Latency returns a new Latency instance with round-trip times of all
+// received responses.
+//
+// Example:
+//
+//	agg.Latency().Percentile(95).Lt(100 * time.Millisecond)
+func (a *AggregateResponse) Latency() *Latency {
+	a.chain.enter("Latency()")
+	defer a.chain.leave()
+
+	lat := newLatency(a.chain)
+
+	for _, res := range a.results {
+		if res.rtt != nil {
+			lat.values = append(lat.values, *res.rtt)
+		}
+	}
+
+	return lat
+}
+
+// Duration returns a new Duration instance with total time spent sending
+// all requests.
+//
+// Example:
+//
+//	agg.Duration().Lt(time.Minute)
+func (a *AggregateResponse) Duration() *Duration {
+	a.chain.enter("Duration()")
+	defer a.chain.leave()
+
+	if a.chain.failed() {
+		return newDuration(a.chain, nil)
+	}
+
+	d := a.duration
+	return newDuration(a.chain, &d)
+}
+
+// Throughput returns a new Number instance with number of requests
+// per second.
+//
+// Example:
+//
+//	agg.Throughput().Ge(100)
+func (a *AggregateResponse) Throughput() *Number {
+	a.chain.enter("Throughput()")
+	defer a.chain.leave()
+
+	if a.chain.failed() || a.duration <= 0 {
+		return newNumber(a.chain, 0)
+	}
+
+	return newNumber(a.chain, float64(len(a.results))/a.duration.Seconds())
+}
+
+func (a *AggregateResponse) countFailed() int {
+	failed := 0
+	for _, res := range a.results {
+		if len(res.failures) != 0 {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,297 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepeatFailed(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	config := Config{
+		Client:   &mockClient{},
+		Reporter: reporter,
+	}
+
+	req := NewRequestC(config, "GET", "/")
+	req.chain.fail(mockFailure())
+
+	rr := req.Repeat(10).Concurrency(2)
+	rr.chain.assertFailed(t)
+
+	agg := rr.Expect()
+	agg.chain.assertFailed(t)
+
+	assert.Empty(t, agg.Raw())
+
+	agg.Requests().chain.assertFailed(t)
+	agg.Failed().chain.assertFailed(t)
+	agg.ErrorRate().chain.assertFailed(t)
+	agg.Errors().chain.assertFailed(t)
+	agg.Statuses().chain.assertFailed(t)
+	agg.StatusCount(200).chain.assertFailed(t)
+	agg.Latency().chain.assertFailed(t)
+	agg.Duration().chain.assertFailed(t)
+	agg.Throughput().chain.assertFailed(t)
+}
+
+func TestRepeatUsage(t *testing.T) {
+	config := Config{
+		Client:   &mockClient{},
+		Reporter: newMockReporter(t),
+	}
+
+	cases := []struct {
+		name string
+		fn   func(req *Request) *RepeatedRequest
+	}{
+		{
+			name: "zero count",
+			fn: func(req *Request) *RepeatedRequest {
+				return req.Repeat(0)
+			},
+		},
+		{
+			name: "negative concurrency",
+			fn: func(req *Request) *RepeatedRequest {
+				return req.Repeat(10).Concurrency(-1)
+			},
+		},
+		{
+			name: "websocket",
+			fn: func(req *Request) *RepeatedRequest {
+				return req.WithWebsocketUpgrade().Repeat(10)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			cfg := config
+			cfg.Client = client
+
+			rr := tc.fn(NewRequestC(cfg, "GET", "/"))
+			rr.chain.assertFailed(t)
+
+			rr.Expect().chain.assertFailed(t)
+			assert.Nil(t, client.req)
+		})
+	}
+}
+
+func TestRepeatExpect(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inflight int
+		maxIn    int
+		counter  int32
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inflight++
+		if inflight > maxIn {
+			maxIn = inflight
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inflight--
+		mu.Unlock()
+
+		if atomic.AddInt32(&counter, 1)%10 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	agg := e.GET("/users").
+		WithMatcher(func(resp *Response) {
+			resp.StatusRange(Status2xx)
+		}).
+		Repeat(100).
+		Concurrency(5).
+		Expect()
+
+	agg.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+
+	assert.Equal(t, int32(100), atomic.LoadInt32(&counter))
+	assert.LessOrEqual(t, maxIn, 5)
+	assert.Greater(t, maxIn, 1)
+
+	assert.Equal(t, 100, len(agg.Raw()))
+
+	agg.Requests().Equal(100)
+	agg.Failed().Equal(10)
+	agg.ErrorRate().EqualDelta(0.1, 1e-9)
+	agg.Errors().Length().Equal(10)
+	agg.Statuses().Equal(map[string]interface{}{
+		"200": 90,
+		"500": 10,
+	})
+	agg.StatusCount(http.StatusOK).Equal(90)
+	agg.StatusCount(http.StatusNotFound).Equal(0)
+	agg.Latency().Count().Equal(100)
+	agg.Latency().Percentile(50).Ge(time.Millisecond)
+	agg.Duration().Gt(0)
+	agg.Throughput().Gt(0)
+
+	agg.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+
+	agg.ErrorRate().Equal(0)
+	assert.True(t, reporter.reported)
+}
+
+func TestRepeatHooks(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	for _, concurrency := range []int{1, 10} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			var failures int32
+
+			e := WithConfig(Config{
+				BaseURL:  "http://example.com",
+				Reporter: newMockReporter(t),
+				Client: &http.Client{
+					Transport: NewBinder(handler),
+				},
+				OnFailure: func(e *Expect, ctx *AssertionContext, failure *AssertionFailure) {
+					atomic.AddInt32(&failures, 1)
+				},
+			})
+
+			agg := e.GET("/users").
+				WithMatcher(func(resp *Response) {
+					resp.Status(http.StatusOK)
+				}).
+				Repeat(50).
+				Concurrency(concurrency).
+				Expect()
+
+			agg.chain.assertNotFailed(t)
+			agg.Failed().Equal(50)
+
+			// individual failures don't invoke hook
+			assert.Equal(t, int32(0), atomic.LoadInt32(&failures))
+
+			// aggregate failure does
+			agg.ErrorRate().Equal(0)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&failures))
+		})
+	}
+}
+
+func TestRepeatBody(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+
+		mu.Lock()
+		bodies = append(bodies, r.Header.Get("Content-Type")+" "+string(b))
+		mu.Unlock()
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	t.Run("json", func(t *testing.T) {
+		bodies = nil
+
+		agg := e.POST("/users").
+			WithJSON(map[string]interface{}{"name": "john"}).
+			Repeat(3).
+			Concurrency(3).
+			Expect()
+
+		agg.Failed().Equal(0)
+
+		assert.Equal(t, []string{
+			`application/json; charset=utf-8 {"name":"john"}`,
+			`application/json; charset=utf-8 {"name":"john"}`,
+			`application/json; charset=utf-8 {"name":"john"}`,
+		}, bodies)
+	})
+
+	t.Run("form", func(t *testing.T) {
+		bodies = nil
+
+		agg := e.POST("/users").
+			WithFormField("name", "john").
+			Repeat(2).
+			Expect()
+
+		agg.Failed().Equal(0)
+
+		assert.Equal(t, []string{
+			"application/x-www-form-urlencoded name=john",
+			"application/x-www-form-urlencoded name=john",
+		}, bodies)
+	})
+}
+
+func TestRepeatTransportError(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &mockClient{
+			err: errors.New("network error"),
+		},
+	})
+
+	agg := e.GET("/users").Repeat(3).Expect()
+
+	agg.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+
+	agg.Failed().Equal(3)
+	agg.ErrorRate().Equal(1)
+	agg.Statuses().Empty()
+	agg.Latency().Count().Equal(0)
+
+	errs := agg.Errors().Raw()
+	require.Equal(t, 3, len(errs))
+	assert.Contains(t, errs[0], "request 0: ")
+	assert.Contains(t, errs[0], "network error")
+
+	assert.Equal(t, []*http.Response{nil, nil, nil}, agg.Raw())
+}
@@ -3,7 +3,7 @@ package httpexpect
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -16,6 +16,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ajg/form"
@@ -31,20 +32,36 @@ type Request struct {
 	config Config
 	chain  *chain
 
+	// If true, response is in soft mode, see Expect.SoftAssertions.
+	// Request itself is never in soft mode, so that failed request
+	// is never sent.
+	soft bool
+
 	redirectPolicy RedirectPolicy
+	redirectChecks RedirectChecks
 	maxRedirects   int
 
 	retryPolicy   RetryPolicy
 	maxRetries    int
 	minRetryDelay time.Duration
 	maxRetryDelay time.Duration
+	maxRetryAfter time.Duration
 	sleepFn       func(d time.Duration) <-chan time.Time
+	nowFn         func() time.Time
+
+	timeout       time.Duration
+	expectTimeout time.Duration
 
-	timeout time.Duration
+	expectedFailure string
 
-	httpReq *http.Request
-	path    string
-	query   url.Values
+	timing  *timingTrace
+	capture *captureRecord
+
+	httpReq      *http.Request
+	path         string
+	pathTemplate string
+	pathParams   []string // named parameters not substituted yet
+	query        url.Values
 
 	form      url.Values
 	formbuf   *bytes.Buffer
@@ -54,10 +71,23 @@ type Request struct {
 	typeSetter string
 	forceType  bool
 
-	wsUpgrade bool
+	wsUpgrade   bool
+	streaming   bool
+	compression string
+	clientCert  *tls.Certificate
+
+	digestAlgorithm string
+	digestAuth      *digestCredentials
+	ntlmAuth        *ntlmCredentials
+
+	accept []acceptRange
 
 	transforms []func(*http.Request)
 	matchers   []func(*Response)
+	signers    []requestSigner
+
+	// Queue of Expect which created request, see Enqueue.
+	queue *requestQueue
 }
 
 // Deprecated: use NewRequestC instead.
@@ -72,8 +102,8 @@ func NewRequest(config Config, method, path string, pathargs ...interface{}) *Re
 // Simple interpolation is allowed for {named} parameters in path:
 //   - if pathargs is given, it's used to substitute first len(pathargs) parameters,
 //     regardless of their names
-//   - if WithPath() or WithPathObject() is called, it's used to substitute given
-//     parameters by name
+//   - if WithPath(), WithPathParam(), WithPathParams(), or WithPathObject()
+//     is called, it's used to substitute given parameters by name
 //
 // For example:
 //
@@ -87,6 +117,9 @@ func NewRequest(config Config, method, path string, pathargs ...interface{}) *Re
 //	req.WithPath("repo", "httpexpect")
 //	// path will be "/repos/gavv/httpexpect"
 //
+// If some parameters are still not substituted when Expect() is called,
+// failure is reported, unless Config.AllowUnresolvedPath is set.
+//
 // After interpolation, path is urlencoded and appended to Config.BaseURL,
 // separated by slash. If BaseURL ends with a slash and path (after interpolation)
 // starts with a slash, only single slash is inserted.
@@ -121,8 +154,14 @@ func newRequest(
 		sleepFn: func(d time.Duration) <-chan time.Time {
 			return time.After(d)
 		},
+		nowFn: time.Now,
 	}
 
+	r.soft = r.chain.soft
+	r.chain.soft = false
+
+	r.pathTemplate = path
+
 	r.initPath(path, pathargs...)
 	r.initReq(method)
 
@@ -145,12 +184,13 @@ func (r *Request) initPath(path string, pathargs ...interface{}) {
 					},
 				})
 			} else {
-				mustWrite(w, fmt.Sprint(pathargs[n]))
+				mustWrite(w, r.pathValue(pathargs[n]))
 			}
 		} else {
 			mustWrite(w, "{")
 			mustWrite(w, k)
 			mustWrite(w, "}")
+			r.pathParams = append(r.pathParams, k)
 		}
 		n++
 		return nil
@@ -439,6 +479,92 @@ const (
 	FollowRedirectsWithoutBody
 )
 
+// WithExpectTimeout sets a timeout for the whole Expect() call.
+//
+// Unlike WithTimeout, which limits a single attempt of sending request,
+// this timeout covers sending request, all retries and redirects, and
+// all matchers attached to the request (including polling helpers).
+//
+// If timeout is exceeded, Expect() reports failure and returns failed
+// Response, instead of blocking test. Context of the request is cancelled
+// as well, so that retries stop and in-flight request is aborted.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithMaxRetries(10)
+//	req.WithExpectTimeout(time.Duration(5)*time.Second)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithExpectTimeout(timeout time.Duration) *Request {
+	r.chain.enter("WithExpectTimeout()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if timeout <= 0 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive timeout argument"),
+			},
+		})
+		return r
+	}
+
+	r.expectTimeout = timeout
+
+	return r
+}
+
+// ExpectedFailure marks request as known to fail, e.g. because endpoint
+// is broken or flaky and the issue is tracked by given ticket.
+//
+// Expect() then inverts reporting:
+//   - if sending request or any matcher fails, failures are reported as
+//     known failures: with SeverityLog and with AssertionContext.ExpectedFailure
+//     set to ticket, so they don't fail the test
+//   - if request and all matchers succeed, failure is reported, so that
+//     annotation is removed when the issue is fixed
+//
+// Only matchers registered via WithMatcher or Expect.Matcher, and checks
+// performed by Expect itself, are taken into account when deciding whether
+// expected failure happened. Assertions performed on returned Response are
+// reported as known failures too, but don't affect the decision, so put
+// checks for the broken behavior into matchers.
+//
+// Example:
+//
+//	e.GET("/reports").
+//	    ExpectedFailure("JIRA-123").
+//	    WithMatcher(func(resp *httpexpect.Response) {
+//	        resp.Status(http.StatusOK)
+//	    }).
+//	    Expect()
+func (r *Request) ExpectedFailure(ticket string) *Request {
+	r.chain.enter("ExpectedFailure()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if ticket == "" {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty ticket argument"),
+			},
+		})
+		return r
+	}
+
+	r.expectedFailure = ticket
+
+	return r
+}
+
 // WithRedirectPolicy sets policy for redirection response handling.
 //
 // How redirect is handled depends on both response status code and
@@ -642,6 +768,46 @@ func (r *Request) WithRetryDelay(minDelay, maxDelay time.Duration) *Request {
 	return r
 }
 
+// WithRetryAfter enables honoring of Retry-After header during retries.
+//
+// When enabled, responses with 429 (Too Many Requests) status code are
+// retried too, unless retry policy is DontRetry. If response has
+// Retry-After header, next attempt is delayed by the time it specifies,
+// but not longer than maxDelay, instead of the delay configured by
+// WithRetryDelay().
+//
+// Number of attempts is still limited by WithMaxRetries().
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxRetries(3)
+//	req.WithRetryAfter(time.Minute)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryAfter(maxDelay time.Duration) *Request {
+	r.chain.enter("WithRetryAfter()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if maxDelay <= 0 {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{maxDelay},
+			Errors: []error{
+				errors.New("invalid non-positive argument"),
+			},
+		})
+		return r
+	}
+
+	r.maxRetryAfter = maxDelay
+
+	return r
+}
+
 // WithWebsocketUpgrade enables upgrades the connection to websocket.
 //
 // At least the following fields are added to the request header:
@@ -808,6 +974,86 @@ func (r *Request) WithPathObject(object interface{}) *Request {
 	return r
 }
 
+// WithPathParam substitutes named parameter in url path.
+//
+// It's an alias for WithPath.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/users/{id}")
+//	req.WithPathParam("id", 123)
+//	// path will be "/users/123"
+func (r *Request) WithPathParam(name string, value interface{}) *Request {
+	r.chain.enter("WithPathParam()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if value == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.withPath(name, value)
+
+	return r
+}
+
+// WithPathParams substitutes multiple named parameters in url path.
+//
+// It's like calling WithPathParam for every map entry. Parameters are
+// substituted in order of their names.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/repos/{user}/{repo}")
+//	req.WithPathParams(map[string]interface{}{
+//	    "user": "gavv",
+//	    "repo": "httpexpect",
+//	})
+//	// path will be "/repos/gavv/httpexpect"
+func (r *Request) WithPathParams(params map[string]interface{}) *Request {
+	r.chain.enter("WithPathParams()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if params[name] == nil {
+			r.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unexpected nil value for path parameter %q", name),
+				},
+			})
+			return r
+		}
+
+		r.withPath(name, params[name])
+
+		if r.chain.failed() {
+			return r
+		}
+	}
+
+	return r
+}
+
 func (r *Request) withPath(key string, value interface{}) {
 	found := false
 
@@ -821,7 +1067,7 @@ func (r *Request) withPath(key string, value interface{}) {
 					},
 				})
 			} else {
-				mustWrite(w, fmt.Sprint(value))
+				mustWrite(w, r.pathValue(value))
 				found = true
 			}
 		} else {
@@ -855,6 +1101,16 @@ func (r *Request) withPath(key string, value interface{}) {
 	}
 
 	r.path = path
+
+	// Track substitution using template keys instead of re-parsing
+	// interpolated path, which may contain braces in substituted values.
+	var unresolved []string
+	for _, k := range r.pathParams {
+		if !strings.EqualFold(k, key) {
+			unresolved = append(unresolved, k)
+		}
+	}
+	r.pathParams = unresolved
 }
 
 // WithQuery adds query parameter to request URL.
@@ -969,6 +1225,114 @@ func (r *Request) WithQueryObject(object interface{}) *Request {
 	return r
 }
 
+// WithQueryStruct adds query parameters encoded from given struct to
+// request URL, similar to how WithJSON encodes request body.
+//
+// object should be struct or pointer to struct, otherwise failure is
+// reported. If struct fields have "url" struct tags, it's encoded using
+// github.com/google/go-querystring, which supports the following:
+//   - "omitempty" option to skip zero values
+//   - slices, encoded as repeated parameters by default, or using
+//     "comma", "space", "semicolon", "brackets", or "numbered" options
+//   - time.Time, encoded as RFC3339 by default, or using "unix",
+//     "unixmilli", or "unixnano" options, or "layout" struct tag
+//
+// Otherwise, if struct fields have "form" struct tags, it's encoded using
+// github.com/ajg/form, the same way as WithForm encodes request body.
+//
+// Example:
+//
+//	type Filter struct {
+//	    Status []string  `url:"status,comma"`
+//	    Since  time.Time `url:"since" layout:"2006-01-02"`
+//	    Limit  int       `url:"limit,omitempty"`
+//	}
+//
+//	req := NewRequestC(config, "GET", "http://example.com/issues")
+//	req.WithQueryStruct(Filter{
+//	    Status: []string{"open", "closed"},
+//	    Since:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+//	})
+//	// URL is now http://example.com/issues?since=2020-01-01&status=open%2Cclosed
+func (r *Request) WithQueryStruct(object interface{}) *Request {
+	r.chain.enter("WithQueryStruct()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	v := reflect.ValueOf(object)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("expected struct or pointer to struct, got %T", object),
+			},
+		})
+		return r
+	}
+
+	var (
+		q   url.Values
+		err error
+	)
+	if !hasStructTag(v.Type(), "url") && hasStructTag(v.Type(), "form") {
+		q, err = form.EncodeToValues(v.Interface())
+	} else {
+		q, err = query.Values(v.Interface())
+	}
+
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid query struct"),
+				err,
+			},
+		})
+		return r
+	}
+
+	if r.query == nil {
+		r.query = make(url.Values)
+	}
+	for k, v := range q {
+		r.query[k] = append(r.query[k], v...)
+	}
+
+	return r
+}
+
+// Checks if any field of struct type, including fields of embedded
+// structs, has given struct tag.
+func hasStructTag(typ reflect.Type, tag string) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		if _, ok := field.Tag.Lookup(tag); ok {
+			return true
+		}
+
+		if field.Anonymous {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && hasStructTag(ft, tag) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // WithQueryString parses given query string and adds it to request URL.
 //
 // Example:
@@ -1087,6 +1451,95 @@ func (r *Request) WithHeader(k, v string) *Request {
 	return r
 }
 
+// WithAccept sets Accept header to given list of media ranges, and attaches
+// a matcher that checks that response honored it.
+//
+// Every media range may contain "*" wildcards and parameters, including
+// quality value, e.g. "application/json", "text/*;q=0.5", or "*/*".
+//
+// When Expect is called, the matcher checks that the most specific media
+// range matching response Content-Type has non-zero quality, so that e.g.
+// "text/*, text/html;q=0" rejects HTML responses. Responses without
+// Content-Type and body, e.g. 204 No Content, are not checked.
+//
+// If WithAccept is called multiple times, the last call wins.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithAccept("application/*+json", "text/plain;q=0.5")
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithAccept(mediaRanges ...string) *Request {
+	r.chain.enter("WithAccept()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if len(mediaRanges) == 0 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty media ranges list"),
+			},
+		})
+		return r
+	}
+
+	ranges := make([]acceptRange, 0, len(mediaRanges))
+
+	for _, value := range mediaRanges {
+		rng, err := parseAcceptRange(value)
+		if err != nil {
+			r.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("invalid media range %q", value),
+					err,
+				},
+			})
+			return r
+		}
+		ranges = append(ranges, rng)
+	}
+
+	acceptable := false
+	for _, rng := range ranges {
+		if rng.quality != 0 {
+			acceptable = true
+		}
+	}
+
+	if !acceptable {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected media ranges list with zero quality values"),
+			},
+		})
+		return r
+	}
+
+	if r.accept == nil {
+		r.matchers = append(r.matchers, func(resp *Response) {
+			resp.chain.enter("WithAccept()")
+			defer resp.chain.leave()
+
+			if resp.chain.failed() {
+				return
+			}
+
+			resp.checkAccept(r.accept)
+		})
+	}
+
+	r.accept = ranges
+	r.httpReq.Header.Set("Accept", strings.Join(mediaRanges, ", "))
+
+	return r
+}
+
 func (r *Request) withHeader(k, v string) {
 	switch http.CanonicalHeaderKey(k) {
 	case "Host":
@@ -1232,6 +1685,74 @@ func (r *Request) WithProto(proto string) *Request {
 	return r
 }
 
+// WithStreaming disables buffering of response body.
+//
+// By default, Expect reads the whole response body before returning.
+// With streaming enabled, Expect returns as soon as response headers
+// are received, and body can be read incrementally using
+// Response.BodyStream, e.g. for NDJSON streams or long polling.
+//
+// Other body methods, like Response.Body or Response.JSON, still can be
+// used; they wait until the rest of the stream is received.
+//
+// Printers don't print streamed response body.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/events")
+//	resp := req.WithStreaming().Expect()
+//	resp.BodyStream().WithTimeout(time.Second).ReadLine().NotEmpty()
+func (r *Request) WithStreaming() *Request {
+	r.chain.enter("WithStreaming()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	r.streaming = true
+
+	return r
+}
+
+// WithCompression enables compression of request body with given
+// content coding and sets Content-Encoding header.
+//
+// Supported codings are "gzip", "deflate", and "br". Body is compressed
+// when request is sent, regardless of which method was used to set it;
+// body set by WithChunked is read entirely before compression.
+// Requests without body are not affected.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "http://example.com/upload")
+//	req.WithCompression("gzip")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+func (r *Request) WithCompression(encoding string) *Request {
+	r.chain.enter("WithCompression()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if !isSupportedEncoding(encoding) {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(
+					`unsupported content coding %q, expected one of: %s`,
+					encoding, strings.Join(supportedEncodings, ", ")),
+			},
+		})
+		return r
+	}
+
+	r.compression = encoding
+
+	return r
+}
+
 // WithChunked enables chunked encoding and sets request body reader.
 //
 // Expect() will read all available data from given reader. Content-Length
@@ -1273,6 +1794,99 @@ func (r *Request) WithChunked(reader io.Reader) *Request {
 	return r
 }
 
+// WithBodyFile sets request body to contents of given file.
+//
+// File is streamed when request is sent, without loading it into memory.
+// Content-Length is set to file size. If request is retried or redirected,
+// file is opened again and sent from the beginning.
+//
+// If file can't be accessed, failure is reported.
+//
+// Note that if request body should be processed before sending (e.g. when
+// WithCompression or WithDigestHeader is used), or if it should be printed
+// (e.g. by DebugPrinter), it's read into memory anyway.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/upload")
+//	req.WithHeader("Content-Type", "application/octet-stream")
+//	req.WithBodyFile("testdata/large.bin")
+func (r *Request) WithBodyFile(path string) *Request {
+	r.chain.enter("WithBodyFile()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() {
+		err = fmt.Errorf("%s is a directory", path)
+	}
+
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to access body file %q", path),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setStreamBody("WithBodyFile()", newFileStreamBody(path), info.Size())
+
+	return r
+}
+
+// WithBodyReader sets request body to data read from given reader.
+//
+// Reader is streamed when request is sent, without loading it into memory.
+// If size is non-negative, it's used as Content-Length; otherwise, length
+// is unknown and "chunked" Transfer-Encoding is used.
+//
+// If reader implements io.Seeker, body can be sent multiple times: when
+// request is retried or redirected, reader is rewound to its initial
+// position. Otherwise, sending body again fails.
+//
+// As with WithBodyFile, body is read into memory if it should be processed
+// or printed before sending.
+//
+// Example:
+//
+//	fh, _ := os.Open("data")
+//	defer fh.Close()
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/upload")
+//	req.WithBodyReader(fh, -1)
+func (r *Request) WithBodyReader(reader io.Reader, size int64) *Request {
+	r.chain.enter("WithBodyReader()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if reader == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil reader"),
+			},
+		})
+		return r
+	}
+
+	if size < 0 {
+		size = -1
+	}
+
+	r.setStreamBody("WithBodyReader()", newReaderStreamBody(reader), size)
+
+	return r
+}
+
 // WithBytes sets request body to given slice of bytes.
 //
 // Example:
@@ -1319,7 +1933,8 @@ func (r *Request) WithText(s string) *Request {
 }
 
 // WithJSON sets Content-Type header to "application/json; charset=utf-8"
-// and sets body to object, marshaled using json.Marshal().
+// and sets body to object, marshaled using json.Marshal(), or
+// Config.Marshaler if it's set.
 //
 // Example:
 //
@@ -1340,7 +1955,7 @@ func (r *Request) WithJSON(object interface{}) *Request {
 		return r
 	}
 
-	b, err := json.Marshal(object)
+	b, err := marshalJSON(r.config.Marshaler, object)
 
 	if err != nil {
 		r.chain.fail(AssertionFailure{
@@ -1362,11 +1977,15 @@ func (r *Request) WithJSON(object interface{}) *Request {
 
 // WithForm sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
-// object to url.Values using github.com/ajg/form, and adds it to request body.
+// object to url.Values using Config.FormEncoder, and adds it to request body.
 //
 // Various object types are supported, including maps and structs. Structs may
 // contain "form" struct tag, similar to "json" struct tag for json.Marshal().
-// See https://github.com/ajg/form for details.
+//
+// By default, DefaultFormEncoder is used, which is based on
+// https://github.com/ajg/form and encodes nested fields using dot notation.
+// NestedFormEncoder may be used to get nested bracket syntax instead,
+// e.g. "a[b][]=1". See FormEncoder for details.
 //
 // Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
 // If WithMultipart() is called, it should be called first.
@@ -1390,7 +2009,7 @@ func (r *Request) WithForm(object interface{}) *Request {
 		return r
 	}
 
-	f, err := form.EncodeToValues(object)
+	f, err := r.formEncoder().Encode(object)
 
 	if err != nil {
 		r.chain.fail(AssertionFailure{
@@ -1404,8 +2023,21 @@ func (r *Request) WithForm(object interface{}) *Request {
 		return r
 	}
 
+	r.addForm("WithForm()", f)
+
+	return r
+}
+
+func (r *Request) formEncoder() FormEncoder {
+	if r.config.FormEncoder != nil {
+		return r.config.FormEncoder
+	}
+	return DefaultFormEncoder{}
+}
+
+func (r *Request) addForm(method string, f url.Values) {
 	if r.multipart != nil {
-		r.setType("WithForm()", "multipart/form-data", false)
+		r.setType(method, "multipart/form-data", false)
 
 		var keys []string
 		for k := range f {
@@ -1414,19 +2046,21 @@ func (r *Request) WithForm(object interface{}) *Request {
 		sort.Strings(keys)
 
 		for _, k := range keys {
-			if err := r.multipart.WriteField(k, f[k][0]); err != nil {
-				r.chain.fail(AssertionFailure{
-					Type: AssertOperation,
-					Errors: []error{
-						fmt.Errorf("failed to write multipart form field %q", k),
-						err,
-					},
-				})
-				return r
+			for _, v := range f[k] {
+				if err := r.multipart.WriteField(k, v); err != nil {
+					r.chain.fail(AssertionFailure{
+						Type: AssertOperation,
+						Errors: []error{
+							fmt.Errorf("failed to write multipart form field %q", k),
+							err,
+						},
+					})
+					return
+				}
 			}
 		}
 	} else {
-		r.setType("WithForm()", "application/x-www-form-urlencoded", false)
+		r.setType(method, "application/x-www-form-urlencoded", false)
 
 		if r.form == nil {
 			r.form = make(url.Values)
@@ -1435,14 +2069,17 @@ func (r *Request) WithForm(object interface{}) *Request {
 			r.form[k] = append(r.form[k], v...)
 		}
 	}
-
-	return r
 }
 
 // WithFormField sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
 // value to string using fmt.Sprint(), and adds it to request body.
 //
+// If value is a map, struct, or slice (other than []byte), it is converted
+// to multiple fields using Config.FormEncoder, the same way as if WithForm
+// was called with object having single field key. This way, nested fields
+// and arrays are encoded according to the configured syntax.
+//
 // Multiple WithForm(), WithFormField(), and WithFile() calls may be combined.
 // If WithMultipart() is called, it should be called first.
 //
@@ -1451,6 +2088,11 @@ func (r *Request) WithForm(object interface{}) *Request {
 //	req := NewRequestC(config, "PUT", "http://example.com/path")
 //	req.WithFormField("foo", 123).
 //	    WithFormField("bar", 456)
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithFormField("tags", []string{"a", "b"})
+//	// with NestedFormEncoder{ArrayStyle: FormArrayBrackets},
+//	// body is "tags[]=a&tags[]=b"
 func (r *Request) WithFormField(key string, value interface{}) *Request {
 	r.chain.enter("WithFormField()")
 	defer r.chain.leave()
@@ -1459,6 +2101,26 @@ func (r *Request) WithFormField(key string, value interface{}) *Request {
 		return r
 	}
 
+	if isFormComposite(value) {
+		f, err := r.formEncoder().Encode(map[string]interface{}{key: value})
+
+		if err != nil {
+			r.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					fmt.Errorf("invalid value of form field %q", key),
+					err,
+				},
+			})
+			return r
+		}
+
+		r.addForm("WithFormField()", f)
+
+		return r
+	}
+
 	if r.multipart != nil {
 		r.setType("WithFormField()", "multipart/form-data", false)
 
@@ -1660,8 +2322,57 @@ func (r *Request) Expect() *Response {
 	r.chain.enter("Expect()")
 	defer r.chain.leave()
 
+	if r.config.Capture != nil && !r.chain.failed() {
+		r.capture = newCaptureRecord(r.config.Capture, r.captureName())
+
+		// Response inherits fail callback, so failures of response
+		// assertions are captured too.
+		parentCb := r.chain.failCb
+		r.chain.setFailCallback(func() {
+			r.capture.save()
+			if parentCb != nil {
+				parentCb()
+			}
+		})
+		defer r.chain.setFailCallback(parentCb)
+	}
+
+	if r.config.MetricsSink != nil && !r.chain.failed() {
+		// Response inherits fail callback, so failures of response
+		// assertions are accounted too.
+		reported := false
+		parentCb := r.chain.failCb
+		r.chain.setFailCallback(func() {
+			if !reported {
+				reported = true
+				r.config.MetricsSink.ObserveFailure(r.metricsSample())
+			}
+			if parentCb != nil {
+				parentCb()
+			}
+		})
+		defer r.chain.setFailCallback(parentCb)
+	}
+
+	run := r.expect
+	if r.expectTimeout > 0 && !r.chain.failed() {
+		run = r.expectWithTimeout
+	}
+
+	if r.expectedFailure != "" && !r.chain.failed() {
+		return r.expectFailure(run)
+	}
+
+	return run()
+}
+
+func (r *Request) expect() *Response {
 	resp := r.roundTrip()
 
+	if r.capture != nil {
+		r.capture.takeSnapshot()
+	}
+
 	if resp == nil {
 		return newResponse(responseOpts{
 			config: r.config,
@@ -1669,14 +2380,211 @@ func (r *Request) Expect() *Response {
 		})
 	}
 
+	r.chain.setResponse(resp)
+
+	if r.config.MetricsSink != nil {
+		sample := r.metricsSample()
+		sample.Status = resp.httpResp.StatusCode
+		if resp.rtt != nil {
+			sample.Latency = *resp.rtt
+		}
+		r.config.MetricsSink.ObserveRequest(sample)
+	}
+
+	if r.config.Manifest != nil {
+		r.config.Manifest.addEndpoint(r.httpReq.Method, r.httpReq.URL.Host,
+			r.pathTemplate, resp.httpResp.StatusCode)
+	}
+
+	start := time.Now()
+
 	for _, matcher := range r.matchers {
 		matcher(resp)
 	}
 
+	if phases := r.chain.context.RequestPhases; phases != nil {
+		phases.Match = time.Since(start)
+	}
+
 	return resp
 }
 
+func (r *Request) metricsSample() MetricsSample {
+	sample := MetricsSample{
+		Path: r.pathTemplate,
+	}
+
+	if r.httpReq != nil {
+		sample.Method = r.httpReq.Method
+		if r.httpReq.URL != nil {
+			sample.Host = r.httpReq.URL.Host
+		}
+	}
+
+	return sample
+}
+
+func (r *Request) expectFailure(run func() *Response) *Response {
+	origSeverity := r.chain.severity
+	origCb := r.chain.failCb
+
+	failed := false
+
+	// Failures are usually reported by child matchers, so we use severity,
+	// context, and fail callback inherited by children.
+	r.chain.setSeverity(SeverityLog)
+	r.chain.setExpectedFailure(r.expectedFailure)
+	r.chain.setFailCallback(func() {
+		failed = true
+		if origCb != nil {
+			origCb()
+		}
+	})
+
+	resp := run()
+
+	r.chain.setSeverity(origSeverity)
+	r.chain.setExpectedFailure("")
+	r.chain.setFailCallback(origCb)
+
+	if !failed {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("expected failure %q did not happen", r.expectedFailure),
+				errors.New("request and matchers succeeded;" +
+					" remove ExpectedFailure if the issue is fixed"),
+			},
+		})
+		resp.chain.setFailed()
+	}
+
+	return resp
+}
+
+func (r *Request) captureName() string {
+	name := r.chain.context.TestName
+
+	if r.chain.context.RequestName != "" {
+		if name != "" {
+			name += "-"
+		}
+		name += r.chain.context.RequestName
+	}
+
+	if name == "" && r.httpReq != nil {
+		name = r.httpReq.Method
+	}
+
+	return name
+}
+
+func (r *Request) expectWithTimeout() *Response {
+	origCtx := r.config.Context
+
+	parentCtx := origCtx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+
+	ctx, cancelFn := context.WithTimeout(parentCtx, r.expectTimeout)
+	defer cancelFn()
+
+	// Expect runs in background goroutine using a separate chain, which
+	// stops forwarding assertions to the handler after timeout, so that
+	// late failures from abandoned goroutine don't pollute test report.
+	handler := &expectTimeoutHandler{
+		handler: r.chain.handler,
+	}
+
+	bgReq := *r
+
+	bgReq.config.Context = ctx
+	bgReq.chain = r.chain.clone()
+	bgReq.chain.handler = handler
+
+	done := make(chan *Response, 1)
+
+	go func() {
+		done <- bgReq.expect()
+	}()
+
+	timer := time.NewTimer(r.expectTimeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-done:
+		origChain := r.chain
+
+		*r = bgReq
+		r.chain = origChain
+		r.config.Context = origCtx
+
+		if bgReq.chain.failed() {
+			r.chain.setFailed()
+		}
+
+		return resp
+
+	case <-timer.C:
+		handler.expire()
+
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("expect timeout exceeded"),
+				fmt.Errorf(
+					"request and matchers did not complete within %s",
+					r.expectTimeout),
+			},
+		})
+
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  r.chain,
+		})
+	}
+}
+
+type expectTimeoutHandler struct {
+	mu      sync.Mutex
+	handler AssertionHandler
+	expired bool
+}
+
+func (h *expectTimeoutHandler) Success(ctx *AssertionContext) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.expired {
+		h.handler.Success(ctx)
+	}
+}
+
+func (h *expectTimeoutHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.expired {
+		h.handler.Failure(ctx, failure)
+	}
+}
+
+func (h *expectTimeoutHandler) expire() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.expired = true
+}
+
 func (r *Request) roundTrip() *Response {
+	phases := &RequestPhases{}
+	r.chain.setRequestPhases(phases)
+
+	start := time.Now()
+
 	if !r.encodeRequest() {
 		return nil
 	}
@@ -1691,6 +2599,19 @@ func (r *Request) roundTrip() *Response {
 		transform(r.httpReq)
 	}
 
+	if r.config.RequestTransformer != nil {
+		r.applyRequestTransformer()
+	}
+
+	if len(r.signers) != 0 {
+		if !r.signRequest() {
+			return nil
+		}
+	}
+
+	phases.Encode = time.Since(start)
+	start = time.Now()
+
 	var (
 		httpResp *http.Response
 		websock  *websocket.Conn
@@ -1702,17 +2623,52 @@ func (r *Request) roundTrip() *Response {
 		httpResp, elapsed = r.sendRequest()
 	}
 
+	phases.Send = time.Since(start)
+
 	if httpResp == nil {
+		if r.config.MetricsSink != nil {
+			r.config.MetricsSink.ObserveRequest(r.metricsSample())
+		}
 		return nil
 	}
 
-	return newResponse(responseOpts{
+	start = time.Now()
+	defer func() {
+		phases.Receive = time.Since(start)
+	}()
+
+	resp := newResponse(responseOpts{
 		config:    r.config,
 		chain:     r.chain,
 		httpResp:  httpResp,
 		websocket: websock,
 		rtt:       []time.Duration{elapsed},
+		timing:    r.timing,
+		streaming: r.streaming,
 	})
+
+	resp.chain.soft = r.soft
+
+	return resp
+}
+
+// Invoke Config.RequestTransformer, allowing it to read request body.
+func (r *Request) applyRequestTransformer() {
+	body := r.httpReq.Body
+
+	if body != nil && body != http.NoBody {
+		if _, ok := body.(rewindBody); !ok {
+			body = newBodyWrapper(body, nil)
+			r.httpReq.Body = body
+		}
+	}
+
+	r.config.RequestTransformer(r.httpReq)
+
+	// if transformer replaced body, we keep new one as is
+	if rb, ok := body.(rewindBody); ok && r.httpReq.Body == body {
+		rb.Rewind()
+	}
 }
 
 func (r *Request) encodeRequest() bool {
@@ -1720,12 +2676,73 @@ func (r *Request) encodeRequest() bool {
 		return false
 	}
 
-	r.httpReq.URL.Path = concatPaths(r.httpReq.URL.Path, r.path)
+	if len(r.pathParams) != 0 && !r.config.AllowUnresolvedPath {
+		keys := make([]string, 0, len(r.pathParams))
+		for _, k := range r.pathParams {
+			keys = append(keys, "{"+k+"}")
+		}
+
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unresolved path parameters in %q: %s",
+					r.pathTemplate, strings.Join(keys, ", ")),
+				errors.New("use pathargs, WithPathParam(), WithPathParams()," +
+					" or WithPathObject() to substitute them"),
+			},
+		})
+		return false
+	}
+
+	if err := r.normalizeURL(r.httpReq.URL, r.path); err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpReq.URL.String()},
+			Errors: []error{
+				errors.New("invalid request URL"),
+				err,
+			},
+		})
+		return false
+	}
 
 	if r.query != nil {
 		r.httpReq.URL.RawQuery = r.query.Encode()
 	}
 
+	if !r.encodeForm() {
+		return false
+	}
+
+	if r.httpReq.Body == nil {
+		r.httpReq.Body = http.NoBody
+	}
+
+	if r.compression != "" && r.httpReq.Body != http.NoBody {
+		if !r.compressBody() {
+			return false
+		}
+	}
+
+	if r.digestAlgorithm != "" && r.httpReq.Body != http.NoBody {
+		if !r.setDigestHeader() {
+			return false
+		}
+	}
+
+	if r.config.Context != nil {
+		r.httpReq = r.httpReq.WithContext(r.config.Context)
+	}
+
+	r.setupRedirects()
+	r.setupClientCert()
+
+	return true
+}
+
+// Set request body from form fields, if any.
+// Form fields are cleared, so repeated calls have no effect.
+func (r *Request) encodeForm() bool {
 	if r.multipart != nil {
 		if err := r.multipart.Close(); err != nil {
 			r.chain.fail(AssertionFailure{
@@ -1745,15 +2762,33 @@ func (r *Request) encodeRequest() bool {
 		r.setBody("WithForm() or WithFormField()", strings.NewReader(s), len(s), false)
 	}
 
-	if r.httpReq.Body == nil {
-		r.httpReq.Body = http.NoBody
+	r.multipart = nil
+	r.form = nil
+
+	return true
+}
+
+func (r *Request) compressBody() bool {
+	content, err := ioutil.ReadAll(r.httpReq.Body)
+	if err == nil {
+		content, err = compressContent(r.compression, content)
 	}
 
-	if r.config.Context != nil {
-		r.httpReq = r.httpReq.WithContext(r.config.Context)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to compress request body with %q coding",
+					r.compression),
+				err,
+			},
+		})
+		return false
 	}
 
-	r.setupRedirects()
+	r.httpReq.Body = ioutil.NopCloser(bytes.NewReader(content))
+	r.httpReq.ContentLength = int64(len(content))
+	r.httpReq.Header.Set("Content-Encoding", r.compression)
 
 	return true
 }
@@ -1793,10 +2828,22 @@ func (r *Request) sendRequest() (*http.Response, time.Duration) {
 	}
 
 	resp, elapsed, err := r.retryRequest(func() (*http.Response, error) {
+		if r.digestAuth != nil {
+			return r.doDigestAuth()
+		}
+		if r.ntlmAuth != nil {
+			return r.doNTLMAuth()
+		}
 		return r.config.Client.Do(r.httpReq)
 	})
 
 	if err != nil {
+		var violation *redirectViolation
+		if errors.As(err, &violation) {
+			r.chain.fail(violation.failure)
+			return nil, 0
+		}
+
 		r.chain.fail(AssertionFailure{
 			Type: AssertOperation,
 			Errors: []error{
@@ -1852,22 +2899,36 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	*http.Response, time.Duration, error,
 ) {
 	if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
-		if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
+		if _, ok := r.httpReq.Body.(rewindBody); !ok {
+			r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
+		} else if _, ok := r.httpReq.Body.(*streamBody); ok && len(r.config.Printers) != 0 {
+			// printers consume body, so it should be read into memory
 			r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
 		}
 	}
 
-	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
+	reqBody, _ := r.httpReq.Body.(rewindBody)
+
+	baseCtx := r.httpReq.Context()
 
 	delay := r.minRetryDelay
 	i := 0
 
 	for {
-		for _, printer := range r.config.Printers {
-			if reqBody != nil {
-				reqBody.Rewind()
+		if len(r.config.Printers) != 0 {
+			printReq := r.httpReq
+			if r.config.RedactionRules != nil {
+				printReq = r.config.RedactionRules.redactRequest(r.httpReq)
+			}
+
+			printBody, _ := printReq.Body.(rewindBody)
+
+			for _, printer := range r.config.Printers {
+				if printBody != nil {
+					printBody.Rewind()
+				}
+				printer.Request(printReq)
 			}
-			printer.Request(r.httpReq)
 		}
 
 		if reqBody != nil {
@@ -1876,33 +2937,60 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 
 		var cancelFn context.CancelFunc
 
+		ctx := baseCtx
 		if r.timeout > 0 {
-			var ctx context.Context
-			if r.config.Context != nil {
-				ctx, cancelFn = context.WithTimeout(r.config.Context, r.timeout)
-			} else {
-				ctx, cancelFn = context.WithTimeout(context.Background(), r.timeout)
+			ctx, cancelFn = context.WithTimeout(ctx, r.timeout)
+		}
+
+		if r.config.RateLimit != nil {
+			err := r.config.RateLimit.wait(ctx, r.httpReq.URL.Hostname())
+			if err != nil {
+				if cancelFn != nil {
+					cancelFn()
+				}
+				return nil, 0, err
 			}
+		}
 
-			r.httpReq = r.httpReq.WithContext(ctx)
+		if r.capture != nil {
+			ctx = r.capture.withContext(ctx)
 		}
 
+		r.timing = newTimingTrace()
+		r.httpReq = r.httpReq.WithContext(r.timing.withContext(ctx))
+
 		start := time.Now()
 		resp, err := reqFunc()
 		elapsed := time.Since(start)
 
 		if resp != nil && resp.Body != nil {
-			resp.Body = newBodyWrapper(resp.Body, cancelFn)
+			if r.streaming {
+				resp.Body = &cancelCloser{resp.Body, cancelFn}
+			} else {
+				resp.Body = newBodyWrapper(resp.Body, cancelFn)
+			}
 		} else if cancelFn != nil {
 			cancelFn()
 		}
 
-		if resp != nil {
+		if resp != nil && len(r.config.Printers) != 0 {
+			printResp := resp
+			if r.streaming {
+				// don't consume streamed body
+				printResp = &http.Response{}
+				*printResp = *resp
+				printResp.Body = nil
+				printResp.ContentLength = -1
+			}
+			if r.config.RedactionRules != nil {
+				printResp = r.config.RedactionRules.redactResponse(printResp)
+			}
+
 			for _, printer := range r.config.Printers {
-				if resp.Body != nil {
-					resp.Body.(*bodyWrapper).Rewind()
+				if printResp.Body != nil {
+					printResp.Body.(*bodyWrapper).Rewind()
 				}
-				printer.Response(resp, elapsed)
+				printer.Response(printResp, elapsed)
 			}
 		}
 
@@ -1915,6 +3003,8 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			return resp, elapsed, err
 		}
 
+		sleep := r.retryAfter(resp, delay)
+
 		if resp != nil && resp.Body != nil {
 			resp.Body.Close()
 		}
@@ -1923,10 +3013,10 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			select {
 			case <-configCtx.Done():
 				return nil, elapsed, configCtx.Err()
-			case <-r.sleepFn(delay):
+			case <-r.sleepFn(sleep):
 			}
 		} else {
-			<-r.sleepFn(delay)
+			<-r.sleepFn(sleep)
 		}
 
 		delay *= 2
@@ -1953,6 +3043,11 @@ func (r *Request) shouldRetry(resp *http.Response, err error) bool {
 		isHTTPError = resp.StatusCode >= 400 && resp.StatusCode <= 599
 	}
 
+	if r.maxRetryAfter > 0 && r.retryPolicy != DontRetry &&
+		resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
 	switch r.retryPolicy {
 	case DontRetry:
 		break
@@ -1970,6 +3065,30 @@ func (r *Request) shouldRetry(resp *http.Response, err error) bool {
 	return false
 }
 
+// Return delay before next attempt: value of Retry-After header if it's
+// honored and present, or given default delay otherwise.
+func (r *Request) retryAfter(resp *http.Response, delay time.Duration) time.Duration {
+	if r.maxRetryAfter <= 0 || resp == nil {
+		return delay
+	}
+
+	now := time.Now()
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		now = date
+	}
+
+	retryAfter, err := retryAfterDelay(resp.Header, now)
+	if retryAfter == nil || err != nil {
+		return delay
+	}
+
+	if *retryAfter > r.maxRetryAfter {
+		return r.maxRetryAfter
+	}
+
+	return *retryAfter
+}
+
 func (r *Request) setupRedirects() {
 	httpClient, _ := r.config.Client.(*http.Client)
 
@@ -1995,8 +3114,20 @@ func (r *Request) setupRedirects() {
 			})
 			return
 		}
+
+		if r.redirectChecks != 0 {
+			r.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New(
+						"WithRedirectChecks() can be used only if Client is *http.Client"),
+				},
+			})
+			return
+		}
 	} else {
-		if r.redirectPolicy != defaultRedirectPolicy || r.maxRedirects != -1 {
+		if r.redirectPolicy != defaultRedirectPolicy || r.maxRedirects != -1 ||
+			r.redirectChecks != 0 {
 			clientCopy := *httpClient
 			httpClient = &clientCopy
 			r.config.Client = &clientCopy
@@ -2007,23 +3138,22 @@ func (r *Request) setupRedirects() {
 		httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		}
-	} else if r.maxRedirects >= 0 {
-		httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-			if len(via) > r.maxRedirects {
-				return fmt.Errorf("stopped after %d redirects", r.maxRedirects)
-			}
-			return nil
+	} else if r.maxRedirects >= 0 || r.redirectChecks != 0 {
+		var next func(*http.Request, []*http.Request) error
+		if r.redirectPolicy == defaultRedirectPolicy {
+			next = httpClient.CheckRedirect
 		}
+		httpClient.CheckRedirect = r.checkRedirect(next)
 	} else if r.redirectPolicy != defaultRedirectPolicy {
 		httpClient.CheckRedirect = nil
 	}
 
 	if r.redirectPolicy == FollowAllRedirects {
 		if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
-			if _, ok := r.httpReq.Body.(*bodyWrapper); !ok {
+			if _, ok := r.httpReq.Body.(rewindBody); !ok {
 				r.httpReq.Body = newBodyWrapper(r.httpReq.Body, nil)
 			}
-			r.httpReq.GetBody = r.httpReq.Body.(*bodyWrapper).GetBody
+			r.httpReq.GetBody = r.httpReq.Body.(rewindBody).GetBody
 		} else {
 			r.httpReq.GetBody = func() (io.ReadCloser, error) {
 				return http.NoBody, nil
@@ -2069,13 +3199,7 @@ var bodyErr = `ambiguous request body contents:
   then replaced by %s`
 
 func (r *Request) setBody(setter string, reader io.Reader, len int, overwrite bool) {
-	if !overwrite && r.bodySetter != "" {
-		r.chain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				fmt.Errorf(bodyErr, r.bodySetter, setter),
-			},
-		})
+	if !overwrite && !r.checkBodySetter(setter) {
 		return
 	}
 
@@ -2094,6 +3218,36 @@ func (r *Request) setBody(setter string, reader io.Reader, len int, overwrite bo
 	r.bodySetter = setter
 }
 
+func (r *Request) setStreamBody(setter string, body *streamBody, size int64) {
+	if !r.checkBodySetter(setter) {
+		return
+	}
+
+	if size == 0 {
+		r.httpReq.Body = http.NoBody
+		r.httpReq.ContentLength = 0
+	} else {
+		r.httpReq.Body = body
+		r.httpReq.ContentLength = size
+	}
+
+	r.bodySetter = setter
+}
+
+func (r *Request) checkBodySetter(setter string) bool {
+	if r.bodySetter != "" {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf(bodyErr, r.bodySetter, setter),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
 func concatPaths(a, b string) string {
 	if a == "" {
 		return b
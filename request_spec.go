@@ -0,0 +1,206 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Clone returns an independent copy of Request.
+//
+// The copy has all settings, headers, query parameters, form fields, body,
+// and matchers of the original request. Modifications of the copy don't
+// affect the original and vice versa, and both can be sent independently.
+//
+// Clone should be called before Expect. If body was set from an arbitrary
+// reader (e.g. using WithChunked), it is read into memory to be shared by
+// both requests.
+//
+// Example:
+//
+//	base := e.POST("/users").
+//	    WithHeader("Authorization", "Bearer "+token)
+//
+//	base.Clone().WithJSON(alice).Expect().Status(http.StatusCreated)
+//	base.Clone().WithJSON(bob).Expect().Status(http.StatusCreated)
+func (r *Request) Clone() *Request {
+	r.chain.enter("Clone()")
+	defer r.chain.leave()
+
+	return r.clone()
+}
+
+// RequestSpec is an immutable request template, see Request.Spec.
+type RequestSpec struct {
+	mu  sync.Mutex
+	req *Request
+}
+
+// Spec returns RequestSpec, a frozen copy of Request, that can be stored
+// and instantiated multiple times.
+//
+// Further modifications of the original request don't affect the spec.
+// Every call to RequestSpec.Request returns a new independent request,
+// which can be modified and sent as usual.
+//
+// Like Clone, Spec should be called before Expect.
+//
+// Example:
+//
+//	spec := e.GET("/users/{id}").
+//	    WithHeader("Accept", "application/json").
+//	    Spec()
+//
+//	for _, id := range ids {
+//	    spec.Request().
+//	        WithPath("id", id).
+//	        Expect().
+//	        Status(http.StatusOK)
+//	}
+func (r *Request) Spec() *RequestSpec {
+	r.chain.enter("Spec()")
+	defer r.chain.leave()
+
+	return &RequestSpec{
+		req: r.clone(),
+	}
+}
+
+// Request returns a new Request instance created from the spec.
+//
+// It is safe to call Request concurrently.
+func (s *RequestSpec) Request() *Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.req.clone()
+}
+
+// Make a copy of request with its own chain, URL, headers, query, form,
+// and body. Chain path of the request should already include the name
+// of the calling method.
+func (r *Request) clone() *Request {
+	ret := *r
+
+	ret.chain = r.chain.clone()
+	ret.chain.setRequest(&ret)
+
+	if r.chain.failed() {
+		return &ret
+	}
+
+	ret.timing = nil
+	ret.capture = nil
+
+	ret.query = cloneValues(r.query)
+	ret.form = cloneValues(r.form)
+
+	ret.pathParams = append([]string(nil), r.pathParams...)
+	ret.accept = append([]acceptRange(nil), r.accept...)
+	ret.transforms = append([](func(*http.Request))(nil), r.transforms...)
+	ret.matchers = append([](func(*Response))(nil), r.matchers...)
+	ret.signers = append([]requestSigner(nil), r.signers...)
+
+	ret.httpReq = r.httpReq.Clone(r.httpReq.Context())
+
+	if err := r.cloneBody(&ret); err != nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read request body"),
+				err,
+			},
+		})
+		ret.chain = r.chain.clone()
+		ret.chain.setRequest(&ret)
+	}
+
+	return &ret
+}
+
+func (r *Request) cloneBody(ret *Request) error {
+	if r.multipart != nil {
+		// Parts already written to the buffer are copied as is, and the
+		// copy continues with a new writer using the same boundary.
+		ret.formbuf = &bytes.Buffer{}
+		ret.formbuf.Write(r.formbuf.Bytes())
+
+		w := &multipartContinuation{w: ret.formbuf}
+
+		ret.multipart = multipart.NewWriter(w)
+		if err := ret.multipart.SetBoundary(r.multipart.Boundary()); err != nil {
+			return err
+		}
+
+		if r.formbuf.Len() != 0 {
+			// Writer separates parts differently depending on whether it
+			// has written a part before, so we let it write a dummy part,
+			// which is discarded.
+			w.discard = true
+			if _, err := ret.multipart.CreatePart(nil); err != nil {
+				return err
+			}
+			w.discard = false
+		}
+
+		ret.httpReq.Body = ioutil.NopCloser(ret.formbuf)
+		return nil
+	}
+
+	switch body := r.httpReq.Body.(type) {
+	case nil:
+		return nil
+
+	case *streamBody:
+		ret.httpReq.Body = &streamBody{
+			openFunc: body.openFunc,
+		}
+		return nil
+
+	default:
+		if body == http.NoBody {
+			return nil
+		}
+
+		content, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+
+		r.httpReq.Body = ioutil.NopCloser(bytes.NewReader(content))
+		ret.httpReq.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+		return nil
+	}
+}
+
+// Writer used to continue multipart form in a cloned request.
+type multipartContinuation struct {
+	w       io.Writer
+	discard bool
+}
+
+func (mc *multipartContinuation) Write(p []byte) (int, error) {
+	if mc.discard {
+		return len(p), nil
+	}
+	return mc.w.Write(p)
+}
+
+func cloneValues(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+
+	ret := make(url.Values, len(values))
+	for k, v := range values {
+		ret[k] = append([]string(nil), v...)
+	}
+
+	return ret
+}
@@ -0,0 +1,262 @@
+package httpexpect
+
+import (
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type specRecord struct {
+	url    string
+	header string
+	body   string
+	form   map[string]string
+}
+
+type specRecorder struct {
+	mu      sync.Mutex
+	records []specRecord
+}
+
+func (sr *specRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec := specRecord{
+		url:    r.URL.String(),
+		header: r.Header.Get("X-Test"),
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	if mediaType == "multipart/form-data" {
+		rec.form = map[string]string{}
+
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			b, _ := ioutil.ReadAll(part)
+			rec.form[part.FormName()] = string(b)
+		}
+	} else {
+		b, _ := ioutil.ReadAll(r.Body)
+		rec.body = string(b)
+	}
+
+	sr.mu.Lock()
+	sr.records = append(sr.records, rec)
+	sr.mu.Unlock()
+}
+
+func TestRequestSpecFailed(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter)
+	config := newMockConfig(reporter)
+
+	chain.fail(mockFailure())
+
+	req := newRequest(chain, config, "GET", "")
+
+	clone := req.Clone()
+	clone.chain.assertFailed(t)
+	clone.Expect().chain.assertFailed(t)
+
+	spec := req.Spec()
+	spec.Request().chain.assertFailed(t)
+	spec.Request().Expect().chain.assertFailed(t)
+}
+
+func TestRequestClone(t *testing.T) {
+	recorder := &specRecorder{}
+
+	e, _ := newMockExpect(t, recorder)
+
+	var matched []string
+
+	req := e.POST("/users/{id}").
+		WithQuery("a", "1").
+		WithText("hello").
+		WithMatcher(func(resp *Response) {
+			matched = append(matched, "orig")
+		})
+
+	clone := req.Clone().
+		WithPath("id", 2).
+		WithQuery("b", "2").
+		WithHeader("X-Test", "clone").
+		WithMatcher(func(resp *Response) {
+			matched = append(matched, "clone")
+		})
+
+	clone.chain.assertNotFailed(t)
+
+	req.WithPath("id", 1).
+		WithHeader("X-Test", "orig")
+
+	req.Expect().chain.assertNotFailed(t)
+	clone.Expect().chain.assertNotFailed(t)
+
+	assert.Equal(t, []specRecord{
+		{
+			url:    "http://example.com/users/1?a=1",
+			header: "orig",
+			body:   "hello",
+		},
+		{
+			url:    "http://example.com/users/2?a=1&b=2",
+			header: "clone",
+			body:   "hello",
+		},
+	}, recorder.records)
+
+	assert.Equal(t, []string{"orig", "orig", "clone"}, matched)
+}
+
+func TestRequestCloneBody(t *testing.T) {
+	t.Run("chunked", func(t *testing.T) {
+		recorder := &specRecorder{}
+
+		e, _ := newMockExpect(t, recorder)
+
+		req := e.POST("/").
+			WithChunked(strings.NewReader("chunked body"))
+
+		clone := req.Clone()
+
+		req.Expect().chain.assertNotFailed(t)
+		clone.Expect().chain.assertNotFailed(t)
+
+		require.Equal(t, 2, len(recorder.records))
+		assert.Equal(t, "chunked body", recorder.records[0].body)
+		assert.Equal(t, "chunked body", recorder.records[1].body)
+	})
+
+	t.Run("form", func(t *testing.T) {
+		recorder := &specRecorder{}
+
+		e, _ := newMockExpect(t, recorder)
+
+		req := e.POST("/").
+			WithFormField("a", "1")
+
+		clone := req.Clone().WithFormField("b", "2")
+
+		req.Expect().chain.assertNotFailed(t)
+		clone.Expect().chain.assertNotFailed(t)
+
+		require.Equal(t, 2, len(recorder.records))
+		assert.Equal(t, "a=1", recorder.records[0].body)
+		assert.Equal(t, "a=1&b=2", recorder.records[1].body)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		recorder := &specRecorder{}
+
+		e, _ := newMockExpect(t, recorder)
+
+		req := e.POST("/").
+			WithMultipart().
+			WithFormField("a", "1")
+
+		clone := req.Clone().WithFormField("b", "2")
+		empty := req.Clone()
+
+		req.WithFileBytes("c", "c.txt", []byte("3"))
+
+		req.Expect().chain.assertNotFailed(t)
+		clone.Expect().chain.assertNotFailed(t)
+		empty.Expect().chain.assertNotFailed(t)
+
+		require.Equal(t, 3, len(recorder.records))
+		assert.Equal(t, map[string]string{"a": "1", "c": "3"},
+			recorder.records[0].form)
+		assert.Equal(t, map[string]string{"a": "1", "b": "2"},
+			recorder.records[1].form)
+		assert.Equal(t, map[string]string{"a": "1"},
+			recorder.records[2].form)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "body.txt")
+		require.NoError(t, ioutil.WriteFile(path, []byte("file body"), 0600))
+
+		recorder := &specRecorder{}
+
+		e, _ := newMockExpect(t, recorder)
+
+		req := e.POST("/").
+			WithBodyFile(path)
+
+		clone := req.Clone()
+
+		req.Expect().chain.assertNotFailed(t)
+		clone.Expect().chain.assertNotFailed(t)
+
+		require.Equal(t, 2, len(recorder.records))
+		assert.Equal(t, "file body", recorder.records[0].body)
+		assert.Equal(t, "file body", recorder.records[1].body)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: reporter,
+		}, "POST", "/").
+			WithChunked(&mockBody{readErr: errors.New("read error")})
+
+		clone := req.Clone()
+
+		req.chain.assertFailed(t)
+		clone.chain.assertFailed(t)
+	})
+}
+
+func TestRequestSpec(t *testing.T) {
+	recorder := &specRecorder{}
+
+	e, _ := newMockExpect(t, recorder)
+
+	req := e.PUT("/users/{id}").
+		WithHeader("X-Test", "spec").
+		WithJSON(map[string]interface{}{"name": "john"})
+
+	spec := req.Spec()
+
+	req.WithHeader("X-Test", "changed")
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+
+		go func(id int) {
+			defer wg.Done()
+
+			spec.Request().
+				WithPath("id", id).
+				Expect().
+				chain.assertNotFailed(t)
+		}(i)
+	}
+
+	wg.Wait()
+
+	require.Equal(t, 5, len(recorder.records))
+
+	for _, rec := range recorder.records {
+		assert.True(t, strings.HasPrefix(rec.url, "http://example.com/users/"))
+		assert.Equal(t, "spec", rec.header)
+		assert.Equal(t, `{"name":"john"}`, rec.body)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -218,6 +219,71 @@ func TestRequestTransformers(t *testing.T) {
 	})
 }
 
+func TestRequestConfigTransformer(t *testing.T) {
+	var calls []string
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Signature", r.Header.Get("X-Signature"))
+		_, _ = w.Write(b)
+	})
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Reporter: newMockReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+		RequestTransformer: func(r *http.Request) {
+			calls = append(calls, "config")
+
+			b, _ := ioutil.ReadAll(r.Body)
+			r.Header.Set("X-Signature",
+				r.Method+" "+r.URL.RequestURI()+" "+r.Header.Get("X-Test")+" "+string(b))
+		},
+	}
+
+	t.Run("body", func(t *testing.T) {
+		calls = nil
+
+		resp := NewRequestC(config, "POST", "/users").
+			WithQuery("a", 1).
+			WithHeader("X-Test", "foo").
+			WithTransformer(func(r *http.Request) {
+				calls = append(calls, "request")
+			}).
+			WithJSON(map[string]interface{}{"name": "john"}).
+			Expect()
+
+		resp.chain.assertNotFailed(t)
+
+		resp.Header("X-Signature").Equal(`POST /users?a=1 foo {"name":"john"}`)
+		resp.Body().Equal(`{"name":"john"}`)
+
+		assert.Equal(t, []string{"request", "config"}, calls)
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		resp := NewRequestC(config, "GET", "/users").Expect()
+
+		resp.chain.assertNotFailed(t)
+
+		resp.Header("X-Signature").Equal("GET /users  ")
+		resp.Body().Empty()
+	})
+
+	t.Run("chunked body", func(t *testing.T) {
+		resp := NewRequestC(config, "PUT", "/users").
+			WithChunked(strings.NewReader("chunked")).
+			Expect()
+
+		resp.chain.assertNotFailed(t)
+
+		resp.Header("X-Signature").Equal("PUT /users  chunked")
+		resp.Body().Equal("chunked")
+	})
+}
+
 func TestRequestClient(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -423,6 +489,85 @@ func TestRequestURLConcatenate(t *testing.T) {
 	assert.Equal(t, "http://example.com/", empty3.httpReq.URL.String())
 }
 
+func TestRequestURLUnresolved(t *testing.T) {
+	client := &mockClient{}
+
+	config := Config{
+		BaseURL:  "http://example.com/",
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}
+
+	t.Run("pathargs", func(t *testing.T) {
+		client.req = nil
+
+		req := NewRequestC(config, "GET", "/{arg1}/{arg2}", "foo")
+		req.Expect().chain.assertFailed(t)
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("partially substituted", func(t *testing.T) {
+		client.req = nil
+
+		req := NewRequestC(config, "GET", "/{arg1}/{arg2}/{arg3}")
+		req.WithPath("ARG3", "foo")
+		req.WithPathParam("arg2", "bar")
+		req.Expect().chain.assertFailed(t)
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("fully substituted", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/{arg1}/{arg2}/{arg3}")
+		req.WithPathParam("ARG3", "foo")
+		req.WithPathParams(map[string]interface{}{
+			"arg1": "baz",
+			"arg2": 123,
+		})
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, "http://example.com/baz/123/foo",
+			client.req.URL.String())
+	})
+
+	t.Run("braces in value", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/x/{id}")
+		req.WithPathParam("id", "{literal}")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, "http://example.com/x/%7Bliteral%7D",
+			client.req.URL.String())
+
+		req = NewRequestC(config, "GET", "/x/{id}", "{literal}")
+		req.Expect().chain.assertNotFailed(t)
+		assert.Equal(t, "http://example.com/x/%7Bliteral%7D",
+			client.req.URL.String())
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/{arg1}/{arg2}")
+		req.WithPathParam("arg1", "foo")
+
+		clone := req.Clone()
+
+		req.WithPathParam("arg2", "bar")
+		req.Expect().chain.assertNotFailed(t)
+
+		clone.Expect().chain.assertFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/{arg}")
+		req.WithPathParam("arg", nil)
+		req.chain.assertFailed(t)
+
+		req = NewRequestC(config, "GET", "/{arg}")
+		req.WithPathParams(map[string]interface{}{"arg": nil})
+		req.chain.assertFailed(t)
+
+		req = NewRequestC(config, "GET", "/{arg}")
+		req.WithPathParams(map[string]interface{}{"bad": "value"})
+		req.chain.assertFailed(t)
+	})
+}
+
 func TestRequestURLOverwrite(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -486,12 +631,15 @@ func TestRequestURLInterpolate(t *testing.T) {
 		assert.Equal(t, "http://example.com/foo/bar", client.req.URL.String())
 	}
 
-	r1 := NewRequestC(config, "METHOD", "/{arg1}/{arg2}", "foo")
+	allowConfig := config
+	allowConfig.AllowUnresolvedPath = true
+
+	r1 := NewRequestC(allowConfig, "METHOD", "/{arg1}/{arg2}", "foo")
 	r1.Expect().chain.assertNotFailed(t)
 	assert.Equal(t, "http://example.com/foo/%7Barg2%7D",
 		client.req.URL.String())
 
-	r2 := NewRequestC(config, "METHOD", "/{arg1}/{arg2}/{arg3}")
+	r2 := NewRequestC(allowConfig, "METHOD", "/{arg1}/{arg2}/{arg3}")
 	r2.WithPath("ARG3", "foo")
 	r2.WithPath("arg2", "bar")
 	r2.Expect().chain.assertNotFailed(t)
@@ -615,6 +763,87 @@ func TestRequestURLQuery(t *testing.T) {
 		WithQueryString("%").chain.assertFailed(t)
 }
 
+func TestRequestURLQueryStruct(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+		BaseURL:        "http://example.com",
+	}
+
+	t.Run("url tags", func(t *testing.T) {
+		type Filter struct {
+			Status []string  `url:"status,comma"`
+			Labels []string  `url:"label"`
+			Since  time.Time `url:"since" layout:"2006-01-02"`
+			Until  time.Time `url:"until,unix"`
+			Limit  int       `url:"limit,omitempty"`
+			Query  string    `url:"q,omitempty"`
+		}
+
+		req := NewRequestC(config, "GET", "/issues").
+			WithQueryStruct(&Filter{
+				Status: []string{"open", "closed"},
+				Labels: []string{"bug", "ui"},
+				Since:  time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+				Until:  time.Unix(1600000000, 0),
+				Limit:  10,
+			})
+
+		req.Expect()
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, url.Values{
+			"status": {"open,closed"},
+			"label":  {"bug", "ui"},
+			"since":  {"2020-01-02"},
+			"until":  {"1600000000"},
+			"limit":  {"10"},
+		}, client.req.URL.Query())
+	})
+
+	t.Run("form tags", func(t *testing.T) {
+		type Filter struct {
+			Page  int      `form:"page"`
+			Owner string   `form:"owner"`
+			Tag   string   `form:"tag,omitempty"`
+			Langs []string `form:"lang"`
+		}
+
+		req := NewRequestC(config, "GET", "/repos").
+			WithQuery("sort", "name").
+			WithQueryStruct(Filter{2, "gavv", "", []string{"go", "c"}})
+
+		req.Expect()
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, url.Values{
+			"sort":   {"name"},
+			"page":   {"2"},
+			"owner":  {"gavv"},
+			"lang.0": {"go"},
+			"lang.1": {"c"},
+		}, client.req.URL.Query())
+	})
+
+	t.Run("not struct", func(t *testing.T) {
+		NewRequestC(config, "GET", "/path").
+			WithQueryStruct(nil).chain.assertFailed(t)
+
+		NewRequestC(config, "GET", "/path").
+			WithQueryStruct(map[string]interface{}{"a": 1}).chain.assertFailed(t)
+
+		NewRequestC(config, "GET", "/path").
+			WithQueryStruct((*struct{})(nil)).chain.assertFailed(t)
+	})
+}
+
 func TestRequestHeaders(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -864,6 +1093,91 @@ func TestRequestBodyChunkedProto(t *testing.T) {
 	assert.Equal(t, 0, req2.httpReq.ProtoMinor)
 }
 
+func TestRequestBodyFile(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	fh, _ := ioutil.TempFile("", "httpexpect")
+	filename := fh.Name()
+	_, _ = fh.WriteString("body")
+	_ = fh.Close()
+	defer os.Remove(filename)
+
+	req1 := NewRequestC(config, "METHOD", "url")
+
+	req1.WithBodyFile(filename)
+
+	resp := req1.Expect()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, int64(4), client.req.ContentLength)
+	assert.Equal(t, "body", string(resp.content))
+
+	req2 := NewRequestC(config, "METHOD", "url")
+
+	req2.WithBodyFile(filepath.Join(os.TempDir(), "httpexpect-nonexistent"))
+	req2.chain.assertFailed(t)
+
+	req3 := NewRequestC(config, "METHOD", "url")
+
+	req3.WithBodyFile(os.TempDir())
+	req3.chain.assertFailed(t)
+}
+
+func TestRequestBodyReader(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req1 := NewRequestC(config, "METHOD", "url")
+
+	req1.WithBodyReader(strings.NewReader("body"), 4)
+
+	resp1 := req1.Expect()
+	resp1.chain.assertNotFailed(t)
+
+	assert.Equal(t, int64(4), client.req.ContentLength)
+	assert.Equal(t, "body", string(resp1.content))
+
+	req2 := NewRequestC(config, "METHOD", "url")
+
+	req2.WithBodyReader(bytes.NewBufferString("body"), -10)
+
+	resp2 := req2.Expect()
+	resp2.chain.assertNotFailed(t)
+
+	assert.Equal(t, int64(-1), client.req.ContentLength)
+	assert.Equal(t, "body", string(resp2.content))
+
+	req3 := NewRequestC(config, "METHOD", "url")
+
+	req3.WithBodyReader(nil, 0)
+	req3.chain.assertFailed(t)
+
+	req4 := NewRequestC(config, "METHOD", "url")
+
+	req4.WithBytes([]byte("body"))
+	req4.WithBodyReader(strings.NewReader("body"), 4)
+	req4.chain.assertFailed(t)
+}
+
 func TestRequestBodyBytes(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -1720,9 +2034,9 @@ func TestRequestRedirect(t *testing.T) {
 			assert.NotNil(t, httpClient.CheckRedirect)
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-			assert.Equal(t,
-				errors.New("stopped after 1 redirects"),
+			assert.EqualError(t,
 				httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+				"stopped after 1 redirects",
 			)
 
 			// Should do round trip
@@ -1763,9 +2077,9 @@ func TestRequestRedirect(t *testing.T) {
 			assert.NotNil(t, httpClient.CheckRedirect)
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-			assert.Equal(t,
-				errors.New("stopped after 1 redirects"),
+			assert.EqualError(t,
 				httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+				"stopped after 1 redirects",
 			)
 
 			// Should do round trip
@@ -1814,9 +2128,9 @@ func TestRequestRedirect(t *testing.T) {
 			assert.NotNil(t, httpClient.CheckRedirect)
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-			assert.Equal(t,
-				errors.New("stopped after 1 redirects"),
+			assert.EqualError(t,
 				httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+				"stopped after 1 redirects",
 			)
 
 			// Should do round trip
@@ -1864,9 +2178,9 @@ func TestRequestRedirect(t *testing.T) {
 			assert.NotNil(t, httpClient.CheckRedirect)
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-			assert.Equal(t,
-				errors.New("stopped after 1 redirects"),
+			assert.EqualError(t,
 				httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+				"stopped after 1 redirects",
 			)
 
 			// Should do round trip
@@ -1908,9 +2222,9 @@ func TestRequestRedirect(t *testing.T) {
 			assert.NotNil(t, httpClient.CheckRedirect)
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-			assert.Equal(t,
-				errors.New("stopped after 1 redirects"),
+			assert.EqualError(t,
 				httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+				"stopped after 1 redirects",
 			)
 
 			// Should do round trip
@@ -1949,9 +2263,9 @@ func TestRequestRedirect(t *testing.T) {
 			assert.NotNil(t, httpClient.CheckRedirect)
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 			assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-			assert.Equal(t,
-				errors.New("stopped after 1 redirects"),
+			assert.EqualError(t,
 				httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+				"stopped after 1 redirects",
 			)
 
 			// Should do round trip
@@ -1997,9 +2311,9 @@ func TestRequestRedirect(t *testing.T) {
 				assert.NotNil(t, httpClient.CheckRedirect)
 				assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 				assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-				assert.Equal(t,
-					errors.New("stopped after 1 redirects"),
+				assert.EqualError(t,
 					httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+					"stopped after 1 redirects",
 				)
 
 				// Should do round trip
@@ -2054,9 +2368,9 @@ func TestRequestRedirect(t *testing.T) {
 				assert.NotNil(t, httpClient.CheckRedirect)
 				assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 				assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-				assert.Equal(t,
-					errors.New("stopped after 1 redirects"),
+				assert.EqualError(t,
 					httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+					"stopped after 1 redirects",
 				)
 
 				// Should do round trip
@@ -2112,9 +2426,9 @@ func TestRequestRedirect(t *testing.T) {
 				assert.NotNil(t, httpClient.CheckRedirect)
 				assert.Nil(t, httpClient.CheckRedirect(req.httpReq, nil))
 				assert.Nil(t, httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 1)))
-				assert.Equal(t,
-					errors.New("stopped after 1 redirects"),
+				assert.EqualError(t,
 					httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
+					"stopped after 1 redirects",
 				)
 
 				// Should do round trip
@@ -2123,6 +2437,110 @@ func TestRequestRedirect(t *testing.T) {
 	})
 }
 
+func TestRequestExpectTimeout(t *testing.T) {
+	t.Run("completed", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		matched := false
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.WithMatcher(func(resp *Response) {
+			matched = true
+		})
+		req.WithExpectTimeout(time.Minute)
+
+		resp := req.Expect()
+		req.chain.assertNotFailed(t)
+		resp.chain.assertNotFailed(t)
+
+		assert.True(t, matched)
+		assert.NotNil(t, resp.Raw())
+		assert.Nil(t, handler.failure)
+	})
+
+	t.Run("failed_matcher", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.WithMatcher(func(resp *Response) {
+			resp.Status(http.StatusTeapot)
+		})
+		req.WithExpectTimeout(time.Minute)
+
+		req.Expect()
+		req.chain.assertNotFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+	})
+
+	t.Run("slow_client", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		unblock := make(chan struct{})
+		defer close(unblock)
+
+		config := Config{
+			Client: &mockClient{
+				cb: func(req *http.Request) {
+					<-unblock
+				},
+			},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.WithExpectTimeout(10 * time.Millisecond)
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		assert.Nil(t, resp.Raw())
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+	})
+
+	t.Run("slow_matcher", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		unblock := make(chan struct{})
+
+		config := Config{
+			Client:           &mockClient{},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.WithMatcher(func(resp *Response) {
+			<-unblock
+			resp.Status(http.StatusTeapot)
+		})
+		req.WithExpectTimeout(10 * time.Millisecond)
+
+		req.Expect()
+		req.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+
+		failure := handler.failure
+		close(unblock)
+		time.Sleep(10 * time.Millisecond)
+
+		assert.Same(t, failure, handler.failure)
+	})
+}
+
 func TestRequestUsageChecks(t *testing.T) {
 	config := Config{
 		Reporter: newMockReporter(t),
@@ -2158,6 +2576,12 @@ func TestRequestUsageChecks(t *testing.T) {
 		req.chain.assertFailed(t)
 	})
 
+	t.Run("WithExpectTimeout", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithExpectTimeout(0)
+		req.chain.assertFailed(t)
+	})
+
 	t.Run("WithMaxRedirects", func(t *testing.T) {
 		req := NewRequestC(config, "METHOD", "/")
 		req.WithMaxRedirects(-1)
@@ -3040,3 +3464,322 @@ func TestRequestRetry(t *testing.T) {
 		assert.Equal(t, 1, callCount)
 	})
 }
+
+func TestRequestRetryAfter(t *testing.T) {
+	newHandler := func(retryAfter string, failures int) (http.Handler, *int) {
+		callCount := 0
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			if callCount <= failures {
+				if retryAfter != "" {
+					w.Header().Set("Retry-After", retryAfter)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}), &callCount
+	}
+
+	newRequest := func(handler http.Handler, delays *[]time.Duration) *Request {
+		req := NewRequestC(Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}, http.MethodGet, "/url")
+		req.sleepFn = func(d time.Duration) <-chan time.Time {
+			*delays = append(*delays, d)
+			return time.After(0)
+		}
+		return req
+	}
+
+	t.Run("seconds", func(t *testing.T) {
+		handler, callCount := newHandler("3", 2)
+
+		var delays []time.Duration
+		resp := newRequest(handler, &delays).
+			WithMaxRetries(5).
+			WithRetryAfter(time.Minute).
+			Expect()
+
+		resp.Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 3, *callCount)
+		assert.Equal(t, []time.Duration{3 * time.Second, 3 * time.Second}, delays)
+	})
+
+	t.Run("http date", func(t *testing.T) {
+		date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		handler, callCount := newHandler(date, 1)
+
+		var delays []time.Duration
+		resp := newRequest(handler, &delays).
+			WithMaxRetries(5).
+			WithRetryAfter(time.Minute).
+			Expect()
+
+		resp.Status(http.StatusOK)
+		assert.Equal(t, 2, *callCount)
+		assert.Equal(t, []time.Duration{time.Minute}, delays)
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		handler, _ := newHandler("120", 1)
+
+		var delays []time.Duration
+		newRequest(handler, &delays).
+			WithMaxRetries(1).
+			WithRetryAfter(time.Second).
+			Expect().
+			Status(http.StatusOK)
+
+		assert.Equal(t, []time.Duration{time.Second}, delays)
+	})
+
+	t.Run("no header", func(t *testing.T) {
+		handler, _ := newHandler("", 1)
+
+		var delays []time.Duration
+		newRequest(handler, &delays).
+			WithMaxRetries(1).
+			WithRetryDelay(time.Millisecond, time.Second).
+			WithRetryAfter(time.Minute).
+			Expect().
+			Status(http.StatusOK)
+
+		assert.Equal(t, []time.Duration{time.Millisecond}, delays)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		handler, callCount := newHandler("1", 1)
+
+		var delays []time.Duration
+		newRequest(handler, &delays).
+			WithMaxRetries(1).
+			Expect().
+			Status(http.StatusTooManyRequests)
+
+		assert.Equal(t, 1, *callCount)
+		assert.Empty(t, delays)
+	})
+
+	t.Run("dont retry policy", func(t *testing.T) {
+		handler, callCount := newHandler("1", 1)
+
+		var delays []time.Duration
+		newRequest(handler, &delays).
+			WithMaxRetries(1).
+			WithRetryPolicy(DontRetry).
+			WithRetryAfter(time.Minute).
+			Expect().
+			Status(http.StatusTooManyRequests)
+
+		assert.Equal(t, 1, *callCount)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Reporter: newMockReporter(t),
+		}, http.MethodGet, "/url")
+
+		req.WithRetryAfter(0)
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestRequestExpectedFailure(t *testing.T) {
+	t.Run("known_failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		var tickets []string
+
+		config := Config{
+			Client: &mockClient{},
+			AssertionHandler: &mockHandlerCallback{
+				handler: handler,
+				failure: func(ctx *AssertionContext) {
+					tickets = append(tickets, ctx.ExpectedFailure)
+				},
+			},
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.ExpectedFailure("JIRA-123")
+		req.WithMatcher(func(resp *Response) {
+			resp.Status(http.StatusTeapot)
+		})
+
+		resp := req.Expect()
+		req.chain.assertNotFailed(t)
+		resp.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertEqual, handler.failure.Type)
+		assert.Equal(t, SeverityLog, handler.failure.Severity)
+		assert.Equal(t, []string{"JIRA-123"}, tickets)
+
+		req.chain.fail(mockFailure())
+		assert.Equal(t, SeverityError, handler.failure.Severity)
+		assert.Equal(t, []string{"JIRA-123", ""}, tickets)
+	})
+
+	t.Run("unexpected_pass", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.ExpectedFailure("JIRA-123")
+		req.WithMatcher(func(resp *Response) {
+			resp.Status(http.StatusOK)
+		})
+
+		resp := req.Expect()
+		req.chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Equal(t, SeverityError, handler.failure.Severity)
+		assert.Equal(t, "", handler.ctx.ExpectedFailure)
+	})
+
+	t.Run("send_error", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		config := Config{
+			Client:           &mockClient{err: errors.New("error")},
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.ExpectedFailure("JIRA-123")
+		req.WithExpectTimeout(time.Minute)
+
+		req.Expect()
+		req.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Equal(t, SeverityLog, handler.failure.Severity)
+	})
+
+	t.Run("formatter", func(t *testing.T) {
+		ctx := &AssertionContext{ExpectedFailure: "JIRA-123"}
+		failure := mockFailure()
+
+		msg := (&DefaultFormatter{}).FormatFailure(ctx, &failure)
+		assert.Contains(t, msg, "expected failure: JIRA-123")
+	})
+
+	t.Run("empty_ticket", func(t *testing.T) {
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.ExpectedFailure("")
+		req.chain.assertFailed(t)
+	})
+}
+
+type mockHandlerCallback struct {
+	handler AssertionHandler
+	failure func(ctx *AssertionContext)
+}
+
+func (h *mockHandlerCallback) Success(ctx *AssertionContext) {
+	h.handler.Success(ctx)
+}
+
+func (h *mockHandlerCallback) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.failure(ctx)
+	h.handler.Failure(ctx, failure)
+}
+
+func TestRequestAccept(t *testing.T) {
+	newConfig := func(t *testing.T, contentType, body string) Config {
+		return Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if body == "" {
+							w.WriteHeader(http.StatusNoContent)
+							return
+						}
+						w.Header().Set("Content-Type", contentType)
+						_, _ = w.Write([]byte(body))
+					})),
+			},
+		}
+	}
+
+	t.Run("header", func(t *testing.T) {
+		config := newConfig(t, "application/json", "{}")
+
+		req := NewRequestC(config, "GET", "http://example.com")
+		req.WithAccept("text/plain").
+			WithAccept("application/json", "text/*;q=0.5")
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "application/json, text/*;q=0.5",
+			req.httpReq.Header.Get("Accept"))
+		assert.Equal(t, 1, len(req.matchers))
+	})
+
+	t.Run("honored", func(t *testing.T) {
+		cases := []struct {
+			contentType string
+			body        string
+			accept      []string
+			fail        bool
+		}{
+			{"application/json", "{}", []string{"application/json"}, false},
+			{"application/hal+json", "{}", []string{"application/*+json"}, false},
+			{"text/html", "<p>", []string{"application/json", "*/*;q=0.1"}, false},
+			{"", "", []string{"application/json"}, false},
+			{"text/html", "<p>", []string{"application/json"}, true},
+			{"text/html", "<p>", []string{"text/html;q=0", "text/*"}, true},
+		}
+
+		for _, tc := range cases {
+			config := newConfig(t, tc.contentType, tc.body)
+
+			req := NewRequestC(config, "GET", "http://example.com")
+			resp := req.WithAccept(tc.accept...).Expect()
+
+			if tc.fail {
+				resp.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, accept := range [][]string{
+			{},
+			{"json"},
+			{"text/plain;q=2"},
+			{"text/plain;q=x"},
+			{"text/plain;q=0"},
+			{""},
+		} {
+			config := newConfig(t, "", "")
+
+			req := NewRequestC(config, "GET", "http://example.com")
+			req.WithAccept(accept...)
+			req.chain.assertFailed(t)
+		}
+	})
+}
@@ -2,12 +2,12 @@ package httpexpect
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"path"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -26,9 +26,17 @@ type Response struct {
 	httpResp  *http.Response
 	websocket *websocket.Conn
 	rtt       *time.Duration
+	timing    *timingTrace
 
 	content []byte
 	cookies []*http.Cookie
+
+	// body before content codings were removed, set if it was decoded
+	rawContent []byte
+
+	// set if body is not buffered, see Request.WithStreaming
+	streaming bool
+	stream    *streamReader
 }
 
 // NewResponse returns a new Response instance.
@@ -57,6 +65,8 @@ type responseOpts struct {
 	httpResp  *http.Response
 	websocket *websocket.Conn
 	rtt       []time.Duration
+	timing    *timingTrace
+	streaming bool
 }
 
 func newResponse(opts responseOpts) *Response {
@@ -90,8 +100,29 @@ func newResponse(opts responseOpts) *Response {
 
 	r.httpResp = opts.httpResp
 	r.websocket = opts.websocket
+	r.timing = opts.timing
+
+	encodings := contentEncodings(r.httpResp.Header)
 
-	r.content = getContent(r.chain, r.httpResp)
+	if opts.streaming && r.httpResp.Body != nil {
+		body := r.httpResp.Body
+		if canDecode(encodings) {
+			body = &decompressReader{body: body, encodings: encodings}
+		}
+		r.streaming = true
+		r.stream = newStreamReader(body)
+		r.content = []byte{}
+	} else {
+		r.content = getContent(r.chain, r.httpResp)
+		if !r.config.DisableDigestCheck && hasDigestHeaders(r.httpResp.Header) &&
+			canVerifyDigests(r.httpResp) {
+			verifyDigests(r.chain, r.httpResp, r.content)
+		}
+		if canDecode(encodings) {
+			r.rawContent = r.content
+			r.content = decodeContent(r.chain, encodings, r.content)
+		}
+	}
 	r.cookies = r.httpResp.Cookies()
 
 	if len(opts.rtt) > 0 {
@@ -104,6 +135,27 @@ func newResponse(opts responseOpts) *Response {
 	return r
 }
 
+// Returns response body. If body is streamed, reads the rest of stream.
+func (r *Response) readContent() []byte {
+	if !r.streaming {
+		return r.content
+	}
+
+	if _, err := r.stream.readAll(0); err != nil && err != errStreamClosed {
+		r.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read response body"),
+				err,
+			},
+		})
+	}
+
+	r.content = r.stream.consumed
+
+	return r.content
+}
+
 func getContent(chain *chain, resp *http.Response) []byte {
 	if resp.Body == nil {
 		return []byte{}
@@ -134,12 +186,61 @@ func getContent(chain *chain, resp *http.Response) []byte {
 	return content
 }
 
+func decodeContent(chain *chain, encodings []string, content []byte) []byte {
+	if chain.failed() {
+		return content
+	}
+
+	decoded, err := decompressContent(encodings, content)
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to decompress response body with %q coding",
+					strings.Join(encodings, ", ")),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return decoded
+}
+
 // Raw returns underlying http.Response object.
 // This is the value originally passed to NewResponse.
 func (r *Response) Raw() *http.Response {
 	return r.httpResp
 }
 
+// Warn returns a copy of Response, on which failed assertions are reported
+// as warnings instead of errors.
+//
+// Assertions made on returned Response and on all values derived from it
+// are reported to AssertionHandler with SeverityWarning. They don't fail
+// the test and don't mark the original Response as failed. This is useful
+// during migration periods, when a check should be visible before it is
+// enforced.
+//
+// Example:
+//
+//	resp := e.GET("/users").Expect().Status(http.StatusOK)
+//
+//	resp.Warn().Header("Deprecation").Empty()
+//	resp.Warn().JSON().Object().NotContainsKey("legacy_id")
+func (r *Response) Warn() *Response {
+	r.chain.enter("Warn()")
+	defer r.chain.leave()
+
+	warn := *r
+
+	warn.chain = r.chain.clone()
+	warn.chain.setSeverity(SeverityWarning)
+	warn.chain.setFailCallback(nil)
+
+	return &warn
+}
+
 // RoundTripTime returns a new Duration instance with response round-trip time.
 //
 // The returned duration is the time interval starting just before request is
@@ -261,6 +362,43 @@ func (r *Response) StatusRange(rn StatusRange) *Response {
 	return r
 }
 
+// NotStatusRange succeeds if response status doesn't belong to given range.
+//
+// See StatusRange for supported ranges.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.NotStatusRange(Status5xx)
+func (r *Response) NotStatusRange(rn StatusRange) *Response {
+	r.chain.enter("NotStatusRange()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	status := statusCodeText(r.httpResp.StatusCode)
+
+	actual := statusRangeText(r.httpResp.StatusCode)
+	expected := statusRangeText(int(rn))
+
+	if actual != "" && actual == expected {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertNotBelongs,
+			Actual: &AssertionValue{status},
+			Expected: &AssertionValue{AssertionList{
+				statusRangeText(int(rn)),
+			}},
+			Errors: []error{
+				errors.New("expected: http status does not belong to given range"),
+			},
+		})
+	}
+
+	return r
+}
+
 // StatusList succeeds if response matches with any given status code list
 //
 // Example:
@@ -339,24 +477,31 @@ func statusListText(values []int) []interface{} {
 	return statusText
 }
 
-// Headers returns a new Object instance with response header map.
+// Headers returns a new Headers instance with response header map.
+//
+// Headers can be used as Object, and in addition provides case-insensitive
+// access to multi-value headers.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	resp.Headers().Value("Content-Type").String().Equal("application-json")
-func (r *Response) Headers() *Object {
+//	resp.Headers().Value("Content-Type").Array().Elements("application/json")
+//	resp.Headers().Values("Set-Cookie").Length().Equal(2)
+//	resp.Headers().NotContainsKey("X-Powered-By")
+func (r *Response) Headers() *Headers {
 	r.chain.enter("Headers()")
 	defer r.chain.leave()
 
 	if r.chain.failed() {
-		return newObject(r.chain, nil)
+		return newHeaders(r.chain, nil)
 	}
 
-	var value map[string]interface{}
-	value, _ = canonMap(r.chain, r.httpResp.Header)
+	header := r.httpResp.Header
+	if header == nil {
+		header = http.Header{}
+	}
 
-	return newObject(r.chain, value)
+	return newHeaders(r.chain, header)
 }
 
 // Header returns a new String instance with given header field.
@@ -379,6 +524,119 @@ func (r *Response) Header(header string) *String {
 	return newString(r.chain, value)
 }
 
+// Trailers returns a new Headers instance with all trailer fields of
+// response, i.e. header fields sent after body (see http.Response.Trailer).
+//
+// Trailers are available only after the whole body is received. If body
+// is streamed (see Request.WithStreaming), Trailers reads the rest of
+// the stream first.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Trailers().ContainsKey("Grpc-Status")
+//	resp.Trailers().Values("Grpc-Status").Elements("0")
+func (r *Response) Trailers() *Headers {
+	r.chain.enter("Trailers()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newHeaders(r.chain, nil)
+	}
+
+	r.readContent()
+	if r.chain.failed() {
+		return newHeaders(r.chain, nil)
+	}
+
+	trailer := r.httpResp.Trailer
+	if trailer == nil {
+		trailer = http.Header{}
+	}
+
+	return newHeaders(r.chain, trailer)
+}
+
+// Trailer returns a new String instance with given trailer field.
+//
+// Like Trailers, it reads the rest of streamed body first. If trailer
+// is not present, empty string is returned.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Trailer("Grpc-Status").Equal("0")
+//	resp.Trailer("Grpc-Message").Empty()
+func (r *Response) Trailer(trailer string) *String {
+	r.chain.enter("Trailer(%q)", trailer)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newString(r.chain, "")
+	}
+
+	r.readContent()
+	if r.chain.failed() {
+		return newString(r.chain, "")
+	}
+
+	value := r.httpResp.Trailer.Get(trailer)
+
+	return newString(r.chain, value)
+}
+
+// CacheControl returns a new CacheControl instance with parsed Cache-Control
+// header of response.
+//
+// If response has no Cache-Control header, method fails.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CacheControl().Public().Consistent().MaxAge().Equal(time.Hour)
+func (r *Response) CacheControl() *CacheControl {
+	r.chain.enter("CacheControl()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newCacheControl(r.chain, nil)
+	}
+
+	if len(r.httpResp.Header.Values("Cache-Control")) == 0 {
+		r.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{headerNames(r.httpResp.Header)},
+			Expected: &AssertionValue{"Cache-Control"},
+			Errors: []error{
+				errors.New("expected: response contains Cache-Control header"),
+			},
+		})
+		return newCacheControl(r.chain, nil)
+	}
+
+	return newCacheControl(r.chain, r.httpResp.Header)
+}
+
+// RateLimit returns a new RateLimitStatus instance with rate limit parsed
+// from RateLimit-*, X-RateLimit-*, and Retry-After headers of response.
+//
+// If response has none of these headers, method fails.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.RateLimit().Remaining().Gt(0)
+func (r *Response) RateLimit() *RateLimitStatus {
+	r.chain.enter("RateLimit()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newRateLimitStatus(r.chain, nil, time.Now())
+	}
+
+	return newRateLimitStatus(r.chain, r.httpResp.Header, time.Now())
+}
+
 // Cookies returns a new Array instance with all cookie names set by this response.
 // Returned Array contains a String value for every cookie name.
 //
@@ -491,7 +749,7 @@ func (r *Response) Websocket() *Websocket {
 //	resp.Body().NotEmpty()
 //	resp.Body().Length().Equal(100)
 func (r *Response) Body() *String {
-	return newString(r.chain, string(r.content))
+	return newString(r.chain, string(r.readContent()))
 }
 
 // NoContent succeeds if response contains empty Content-Type header and
@@ -507,7 +765,7 @@ func (r *Response) NoContent() *Response {
 	contentType := r.httpResp.Header.Get("Content-Type")
 
 	r.checkEqual(`"Content-Type" header`, "", contentType)
-	r.checkEqual("body", "", string(r.content))
+	r.checkEqual("body", "", string(r.readContent()))
 
 	return r
 }
@@ -543,8 +801,70 @@ func (r *Response) ContentType(mediaType string, charset ...string) *Response {
 	return r
 }
 
+// ContentTypeMatches succeeds if response contains Content-Type header
+// with media type matching given pattern, and given charset.
+//
+// Pattern may contain "*" wildcards, which match any sequence of characters
+// except "/", e.g. "text/*", "application/*+json", or "*/*". Matching is
+// case-insensitive.
+//
+// Charset is checked in the same way as in ContentType: if it's omitted,
+// Content-Type header should contain empty or utf-8 charset.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ContentTypeMatches("application/*+json")
+//	resp.ContentTypeMatches("text/*", "iso-8859-1")
+func (r *Response) ContentTypeMatches(pattern string, charset ...string) *Response {
+	r.chain.enter("ContentTypeMatches()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if len(charset) > 1 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple charset arguments"),
+			},
+		})
+		return r
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil ||
+		(pattern != "*" && !strings.Contains(pattern, "/")) {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid media type pattern %q", pattern),
+			},
+		})
+		return r
+	}
+
+	r.checkContentType(pattern, charset...)
+
+	return r
+}
+
 // ContentEncoding succeeds if response has exactly given Content-Encoding list.
 // Common values are empty, "gzip", "compress", "deflate", "identity" and "br".
+//
+// Response body is decoded automatically if it uses "gzip", "deflate",
+// or "br" coding, but Content-Encoding header is preserved, so it still
+// can be checked.
+//
+// If body was already decoded by http.Transport, which removes the header
+// (see http.Response.Uncompressed), ContentEncoding treats it as "gzip".
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ContentEncoding("gzip")
+//	resp.Body().Equal("hello, world!")
 func (r *Response) ContentEncoding(encoding ...string) *Response {
 	r.chain.enter("ContentEncoding()")
 	defer r.chain.leave()
@@ -553,9 +873,14 @@ func (r *Response) ContentEncoding(encoding ...string) *Response {
 		return r
 	}
 
+	actual := r.httpResp.Header["Content-Encoding"]
+	if len(actual) == 0 && r.httpResp.Uncompressed {
+		actual = []string{"gzip"}
+	}
+
 	r.checkEqual(`"Content-Encoding" header`,
 		encoding,
-		r.httpResp.Header["Content-Encoding"])
+		actual)
 
 	return r
 }
@@ -580,6 +905,8 @@ func (r *Response) TransferEncoding(encoding ...string) *Response {
 // ContentOpts define parameters for matching the response content parameters.
 type ContentOpts struct {
 	// The media type Content-Type part, e.g. "application/json"
+	// May contain "*" wildcards, e.g. "application/*+json",
+	// see Response.ContentTypeMatches.
 	MediaType string
 	// The character set Content-Type part, e.g. "utf-8"
 	Charset string
@@ -619,7 +946,7 @@ func (r *Response) Text(options ...ContentOpts) *String {
 		return newString(r.chain, "")
 	}
 
-	content := string(r.content)
+	content := string(r.readContent())
 
 	return newString(r.chain, content)
 }
@@ -627,8 +954,13 @@ func (r *Response) Text(options ...ContentOpts) *String {
 // Form returns a new Object instance with form decoded from response body.
 //
 // Form succeeds if response contains "application/x-www-form-urlencoded"
-// Content-Type header and if form may be decoded from response body.
-// Decoding is performed using https://github.com/ajg/form.
+// or "multipart/form-data" Content-Type header and if form may be decoded
+// from response body. Urlencoded forms are decoded using
+// https://github.com/ajg/form.
+//
+// For multipart forms, only value fields are included; every field is
+// represented as a string, or as an array of strings if field is repeated.
+// Files can be inspected using FormFile and FormFiles.
 //
 // Example:
 //
@@ -661,11 +993,19 @@ func (r *Response) Form(options ...ContentOpts) *Object {
 }
 
 func (r *Response) getForm(options ...ContentOpts) map[string]interface{} {
+	if r.isMultipartForm(options...) {
+		form := r.getMultipartForm(options...)
+		if form == nil {
+			return nil
+		}
+		return multipartValues(form)
+	}
+
 	if !r.checkContentOptions(options, "application/x-www-form-urlencoded", "") {
 		return nil
 	}
 
-	decoder := form.NewDecoder(bytes.NewReader(r.content))
+	decoder := form.NewDecoder(bytes.NewReader(r.readContent()))
 
 	var object map[string]interface{}
 
@@ -673,7 +1013,7 @@ func (r *Response) getForm(options ...ContentOpts) map[string]interface{} {
 		r.chain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
-				string(r.content),
+				string(r.readContent()),
 			},
 			Errors: []error{
 				errors.New("failed to decode form"),
@@ -726,13 +1066,16 @@ func (r *Response) getJSON(options ...ContentOpts) interface{} {
 		return nil
 	}
 
-	var value interface{}
+	if r.config.StrictContentType && !r.checkJSONCompatible() {
+		return nil
+	}
 
-	if err := json.Unmarshal(r.content, &value); err != nil {
+	value, err := jsonDecode(r.chain, r.readContent())
+	if err != nil {
 		r.chain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
-				string(r.content),
+				string(r.readContent()),
 			},
 			Errors: []error{
 				errors.New("failed to decode json"),
@@ -745,6 +1088,48 @@ func (r *Response) getJSON(options ...ContentOpts) interface{} {
 	return value
 }
 
+// XML returns a new XML instance with response body.
+//
+// XML succeeds if response contains "application/xml" Content-Type header
+// with empty or "utf-8" charset and if response body is a well-formed
+// XML document.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.XML().ValidateXSD("testdata/user.xsd")
+//	resp.XML(ContentOpts{
+//	  MediaType: "text/xml",
+//	}).ValidateXSD("testdata/user.xsd")
+func (r *Response) XML(options ...ContentOpts) *XML {
+	r.chain.enter("XML()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newXML(r.chain, nil)
+	}
+
+	if len(options) > 1 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newXML(r.chain, nil)
+	}
+
+	if !r.checkContentOptions(options, "application/xml") {
+		return newXML(r.chain, nil)
+	}
+
+	if !checkXML(r.chain, r.readContent()) {
+		return newXML(r.chain, nil)
+	}
+
+	return newXML(r.chain, r.readContent())
+}
+
 // JSON returns a new Value instance with JSONP decoded from response body.
 //
 // JSONP succeeds if response contains "application/javascript" Content-Type
@@ -797,13 +1182,13 @@ func (r *Response) getJSONP(callback string, options ...ContentOpts) interface{}
 		return nil
 	}
 
-	m := jsonp.FindSubmatch(r.content)
+	m := jsonp.FindSubmatch(r.readContent())
 
 	if len(m) != 3 || string(m[1]) != callback {
 		r.chain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
-				string(r.content),
+				string(r.readContent()),
 			},
 			Errors: []error{
 				fmt.Errorf(`expected: JSONP body in form of "%s(<valid json>)"`,
@@ -813,13 +1198,12 @@ func (r *Response) getJSONP(callback string, options ...ContentOpts) interface{}
 		return nil
 	}
 
-	var value interface{}
-
-	if err := json.Unmarshal(m[2], &value); err != nil {
+	value, err := jsonDecode(r.chain, m[2])
+	if err != nil {
 		r.chain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
-				string(r.content),
+				string(r.readContent()),
 			},
 			Errors: []error{
 				errors.New("failed to decode json"),
@@ -868,7 +1252,20 @@ func (r *Response) checkContentType(expectedType string, expectedCharset ...stri
 		return false
 	}
 
-	if mediaType != expectedType {
+	if strings.Contains(expectedType, "*") {
+		if !mediaTypeMatches(expectedType, mediaType) {
+			r.chain.fail(AssertionFailure{
+				Type:     AssertMatchFormat,
+				Actual:   &AssertionValue{mediaType},
+				Expected: &AssertionValue{expectedType},
+				Errors: []error{
+					errors.New(
+						`unexpected media type in "Content-Type" response header`),
+				},
+			})
+			return false
+		}
+	} else if mediaType != expectedType {
 		r.chain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{mediaType},
@@ -911,6 +1308,155 @@ func (r *Response) checkContentType(expectedType string, expectedCharset ...stri
 	return true
 }
 
+// JSON-compatible media types, allowed by Config.StrictContentType.
+var jsonMediaTypes = []string{
+	"application/json",
+	"application/*+json",
+}
+
+func (r *Response) checkJSONCompatible() bool {
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	for _, pattern := range jsonMediaTypes {
+		if mediaTypeMatches(pattern, mediaType) {
+			return true
+		}
+	}
+
+	expected := AssertionList{}
+	for _, pattern := range jsonMediaTypes {
+		expected = append(expected, pattern)
+	}
+
+	r.chain.fail(AssertionFailure{
+		Type:     AssertMatchFormat,
+		Actual:   &AssertionValue{mediaType},
+		Expected: &AssertionValue{expected},
+		Errors: []error{
+			errors.New(
+				`expected: "Content-Type" response header has JSON-compatible media type`),
+		},
+	})
+
+	return false
+}
+
+// Accepted media range, parsed from Accept header.
+type acceptRange struct {
+	pattern string
+	quality float64
+}
+
+func parseAcceptRange(value string) (acceptRange, error) {
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		return acceptRange{}, err
+	}
+
+	if mediaType != "*" && !strings.Contains(mediaType, "/") {
+		return acceptRange{}, fmt.Errorf("invalid media range %q", value)
+	}
+
+	rng := acceptRange{
+		pattern: mediaType,
+		quality: 1,
+	}
+
+	if q, ok := params["q"]; ok {
+		rng.quality, err = strconv.ParseFloat(q, 64)
+		if err != nil || rng.quality < 0 || rng.quality > 1 {
+			return acceptRange{}, fmt.Errorf("invalid quality value %q", q)
+		}
+	}
+
+	return rng, nil
+}
+
+func (rng acceptRange) specificity() int {
+	switch {
+	case rng.pattern == "*" || rng.pattern == "*/*":
+		return 0
+	case strings.Contains(rng.pattern, "*"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (r *Response) checkAccept(ranges []acceptRange) bool {
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	// nothing to negotiate, e.g. 204 No Content
+	if contentType == "" && len(r.content) == 0 && !r.streaming {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
+			Errors: []error{
+				errors.New(`invalid "Content-Type" response header`),
+				err,
+			},
+		})
+		return false
+	}
+
+	// the most specific matching range defines quality, so that e.g.
+	// "text/html;q=0" excludes HTML even if "text/*" is accepted
+	var best *acceptRange
+
+	for i, rng := range ranges {
+		if !mediaTypeMatches(rng.pattern, mediaType) {
+			continue
+		}
+		if best == nil || rng.specificity() > best.specificity() {
+			best = &ranges[i]
+		}
+	}
+
+	if best != nil && best.quality != 0 {
+		return true
+	}
+
+	expected := AssertionList{}
+	for _, rng := range ranges {
+		if rng.quality != 0 {
+			expected = append(expected, rng.pattern)
+		}
+	}
+
+	r.chain.fail(AssertionFailure{
+		Type:     AssertMatchFormat,
+		Actual:   &AssertionValue{mediaType},
+		Expected: &AssertionValue{expected},
+		Errors: []error{
+			errors.New(
+				`expected: "Content-Type" response header matches "Accept" request header`),
+		},
+	})
+
+	return false
+}
+
+// Check if media type matches pattern with "*" wildcards.
+func mediaTypeMatches(pattern, mediaType string) bool {
+	pattern = strings.ToLower(pattern)
+	mediaType = strings.ToLower(mediaType)
+
+	if pattern == "*" {
+		pattern = "*/*"
+	}
+
+	ok, err := path.Match(pattern, mediaType)
+
+	return err == nil && ok
+}
+
 func (r *Response) checkEqual(what string, expected, actual interface{}) {
 	if !reflect.DeepEqual(expected, actual) {
 		r.chain.fail(AssertionFailure{
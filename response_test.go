@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -27,21 +28,33 @@ func TestResponseFailed(t *testing.T) {
 		assert.NotNil(t, resp.Form())
 		assert.NotNil(t, resp.JSON())
 		assert.NotNil(t, resp.JSONP(""))
+		assert.NotNil(t, resp.XML())
 		assert.NotNil(t, resp.Websocket())
+		assert.NotNil(t, resp.Warn())
+
+		resp.Warn().chain.assertFailed(t)
+		resp.If(func(*Response) bool { return true }).chain.assertFailed(t)
 
 		resp.Headers().chain.assertFailed(t)
 		resp.Header("foo").chain.assertFailed(t)
 		resp.Cookies().chain.assertFailed(t)
 		resp.Cookie("foo").chain.assertFailed(t)
+		resp.CacheControl().chain.assertFailed(t)
+		resp.RateLimit().chain.assertFailed(t)
+		resp.Links().chain.assertFailed(t)
+		resp.Link("next").chain.assertFailed(t)
+		resp.Follow("next").chain.assertFailed(t)
 		resp.Body().chain.assertFailed(t)
 		resp.Text().chain.assertFailed(t)
 		resp.Form().chain.assertFailed(t)
 		resp.JSON().chain.assertFailed(t)
 		resp.JSONP("").chain.assertFailed(t)
+		resp.XML().chain.assertFailed(t)
 		resp.Websocket().chain.assertFailed(t)
 
 		resp.Status(123)
 		resp.StatusRange(Status2xx)
+		resp.NotStatusRange(Status2xx)
 		resp.StatusList(http.StatusOK, http.StatusBadGateway)
 		resp.NoContent()
 		resp.ContentType("", "")
@@ -204,6 +217,18 @@ func TestResponseStatusRange(t *testing.T) {
 			} else {
 				resp.chain.assertFailed(t)
 			}
+
+			resp = NewResponse(reporter, &http.Response{
+				StatusCode: test.Status,
+			})
+
+			resp.NotStatusRange(r)
+
+			if test.Range == r {
+				resp.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+			}
 		}
 	}
 }
@@ -290,6 +315,144 @@ func TestResponseHeaders(t *testing.T) {
 	resp.Header("Bad-Header").Empty().chain.assertNotFailed(t)
 }
 
+type severityHandler struct {
+	failures []AssertionSeverity
+}
+
+func (h *severityHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *severityHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.failures = append(h.failures, failure.Severity)
+}
+
+func TestResponseWarn(t *testing.T) {
+	handler := &severityHandler{}
+
+	config := Config{
+		AssertionHandler: handler,
+	}.withDefaults()
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Deprecation": {"true"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{"legacy_id": 1}`)),
+	}
+
+	failCalled := false
+
+	chain := newChainWithConfig("test", config)
+	chain.setFailCallback(func() {
+		failCalled = true
+	})
+
+	resp := newResponse(responseOpts{
+		config:   config,
+		chain:    chain,
+		httpResp: httpResp,
+	})
+	resp.chain.assertNotFailed(t)
+
+	warn := resp.Warn()
+
+	warn.Header("Deprecation").Empty()
+	warn.chain.assertNotFailed(t)
+	resp.chain.assertNotFailed(t)
+
+	warn.JSON().Object().NotContainsKey("legacy_id")
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, []AssertionSeverity{SeverityWarning, SeverityWarning},
+		handler.failures)
+	assert.False(t, failCalled)
+
+	resp.Header("Deprecation").Empty()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t,
+		[]AssertionSeverity{SeverityWarning, SeverityWarning, SeverityError},
+		handler.failures)
+	assert.True(t, failCalled)
+}
+
+func TestResponseTrailers(t *testing.T) {
+	t.Run("raw", func(t *testing.T) {
+		trailer := http.Header{
+			"Grpc-Status":  {"0"},
+			"Grpc-Message": {"ok"},
+		}
+
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Trailer:    trailer,
+		})
+
+		resp.Trailers().ContainsKey("grpc-status").chain.assertNotFailed(t)
+		resp.Trailers().NotContainsKey("Content-Type").chain.assertNotFailed(t)
+
+		resp.Trailer("Grpc-Status").Equal("0").chain.assertNotFailed(t)
+		resp.Trailer("grpc-message").Equal("ok").chain.assertNotFailed(t)
+		resp.Trailer("Bad-Trailer").Empty().chain.assertNotFailed(t)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+		})
+
+		resp.Trailers().Empty().chain.assertNotFailed(t)
+		resp.Trailer("Grpc-Status").Empty().chain.assertNotFailed(t)
+	})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "Grpc-Status")
+		_, _ = w.Write([]byte("data"))
+		w.(http.Flusher).Flush()
+		w.Header().Set("Grpc-Status", "0")
+	})
+
+	t.Run("binder", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		resp := e.GET("/").Expect()
+
+		resp.Header("Trailer").Empty()
+		resp.Trailer("Grpc-Status").Equal("0")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("server", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		resp := e.GET("/").Expect()
+		resp.Trailer("Grpc-Status").Equal("0")
+		resp.chain.assertNotFailed(t)
+
+		resp = e.GET("/").WithStreaming().Expect()
+		resp.Trailer("Grpc-Status").Equal("0")
+		resp.Body().Equal("data")
+		resp.chain.assertNotFailed(t)
+	})
+}
+
 func TestResponseCookies(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -1069,6 +1232,62 @@ func TestResponseJSONPCharsetBad(t *testing.T) {
 	assert.Nil(t, resp.JSONP("foo").Raw())
 }
 
+func TestResponseXML(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/xml; charset=utf-8"},
+	}
+
+	body := `<user><name>john</name></user>`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.XML()
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Equal(t, []byte(body), resp.XML().Raw())
+
+	resp.XML(ContentOpts{MediaType: "text/xml"})
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.XML(ContentOpts{Charset: "windows-1251"})
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+}
+
+func TestResponseXMLBadBody(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/xml"},
+	}
+
+	body := `<user><name>john</user>`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.XML()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Nil(t, resp.XML().Raw())
+}
+
 func TestResponseContentOpts(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -1331,3 +1550,185 @@ func TestResponseUsageChecks(t *testing.T) {
 		resp.chain.assertFailed(t)
 	})
 }
+
+func TestResponseJSONNumber(t *testing.T) {
+	body := `{"id": 1234567890123456789, "small": 123, "float": 1.5,
+		"amount": 123456789012345678.99}`
+
+	for _, jsonNumber := range []bool{false, true} {
+		config := Config{
+			Reporter:   newMockReporter(t),
+			JSONNumber: jsonNumber,
+		}.withDefaults()
+
+		resp := newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("Response()", config),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+			},
+		})
+
+		obj := resp.JSON().Object()
+
+		obj.Value("small").Number().Equal(123)
+		obj.Value("float").Number().Equal(1.5)
+		obj.ValueEqual("small", 123)
+		obj.chain.assertNotFailed(t)
+
+		id := obj.Value("id").Number().AsInt()
+		obj.chain.assertNotFailed(t)
+
+		if jsonNumber {
+			assert.Equal(t, int64(1234567890123456789), id)
+
+			obj.ValueEqual("id", int64(1234567890123456789))
+			obj.chain.assertNotFailed(t)
+
+			obj.Value("amount").Number().EqualDecimal("123456789012345678.99")
+			obj.chain.assertNotFailed(t)
+
+			obj.ValueEqual("id", int64(1234567890123456788))
+			obj.chain.assertFailed(t)
+		} else {
+			assert.NotEqual(t, int64(1234567890123456789), id)
+		}
+	}
+}
+
+func TestResponseJSONStrictDecoding(t *testing.T) {
+	type User struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+
+	body := `{"id": 1234567890123456789, "name": "john", "admin": true}`
+
+	for _, strict := range []bool{false, true} {
+		config := Config{
+			Reporter:       newMockReporter(t),
+			JSONNumber:     true,
+			StrictDecoding: strict,
+		}.withDefaults()
+
+		resp := newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("Response()", config),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+			},
+		})
+
+		var user User
+		value := resp.JSON().Decode(&user)
+
+		if strict {
+			value.chain.assertFailed(t)
+		} else {
+			value.chain.assertNotFailed(t)
+			assert.Equal(t, User{ID: 1234567890123456789, Name: "john"}, user)
+		}
+	}
+}
+
+func TestResponseContentTypeMatches(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header{
+			"Content-Type": {"application/problem+json; charset=utf-8"},
+		},
+	})
+
+	for _, pattern := range []string{
+		"application/problem+json",
+		"application/*+json",
+		"APPLICATION/*+JSON",
+		"application/*",
+		"*/*",
+		"*",
+	} {
+		resp.ContentTypeMatches(pattern)
+		resp.chain.assertNotFailed(t)
+	}
+
+	resp.ContentTypeMatches("application/*+json", "UTF-8")
+	resp.chain.assertNotFailed(t)
+
+	for _, pattern := range []string{
+		"application/json",
+		"text/*",
+		"application/*+xml",
+	} {
+		resp.ContentTypeMatches(pattern)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	}
+
+	resp.ContentTypeMatches("application/*+json", "iso-8859-1")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	for _, pattern := range []string{"", "json", "application/["} {
+		resp.ContentTypeMatches(pattern)
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	}
+
+	resp.ContentTypeMatches("application/*", "utf-8", "utf-8")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+}
+
+func TestResponseStrictContentType(t *testing.T) {
+	cases := []struct {
+		contentType string
+		opts        []ContentOpts
+		strict      bool
+		fail        bool
+	}{
+		{"application/json", nil, true, false},
+		{"application/problem+json", []ContentOpts{{MediaType: "application/*+json"}},
+			true, false},
+		{"text/plain", []ContentOpts{{MediaType: "text/plain"}}, false, false},
+		{"text/plain", []ContentOpts{{MediaType: "text/plain"}}, true, true},
+		{"text/plain", []ContentOpts{{MediaType: "*/*"}}, true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.contentType, func(t *testing.T) {
+			config := Config{
+				Reporter:          newMockReporter(t),
+				StrictContentType: tc.strict,
+			}.withDefaults()
+
+			resp := newResponse(responseOpts{
+				config: config,
+				chain:  newChainWithConfig("Response()", config),
+				httpResp: &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type": {tc.contentType},
+					},
+					Body: ioutil.NopCloser(bytes.NewBufferString(`{"a": 1}`)),
+				},
+			})
+
+			resp.JSON(tc.opts...)
+
+			if tc.fail {
+				resp.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package httpexpect
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingFileOpts defines when RotatingFile is rotated and how many
+// rotated files are kept.
+type RotatingFileOpts struct {
+	// Maximum size of file, in bytes. File is rotated before write that
+	// would exceed this size.
+	// If zero, file is not rotated by size.
+	MaxSize int64
+
+	// Maximum time since file was opened. File is rotated on the first
+	// write after this interval elapsed.
+	// If zero, file is not rotated by time.
+	Interval time.Duration
+
+	// Maximum number of rotated files to keep. Oldest files are removed.
+	// If zero, all rotated files are kept.
+	MaxBackups int
+
+	// Compress rotated files with gzip.
+	Compress bool
+}
+
+// RotatingFile is an io.Writer and Logger that appends to a file and
+// rotates it when it grows too large or too old.
+//
+// Rotated file is renamed to "<path>.<timestamp>" (and compressed to
+// "<path>.<timestamp>.gz" if Compress is set), and a new file is opened
+// at original path.
+//
+// RotatingFile is intended for long-running programs built on httpexpect,
+// like synthetic monitors, that should not accumulate unbounded logs.
+// It can be used as Logger for printers and DefaultAssertionHandler, and
+// as Writer for RecordPrinter and RecordAssertionHandler.
+//
+// RotatingFile is safe for concurrent use. Every Write call is written
+// to a single file and is never split between files.
+//
+// Example:
+//
+//	file, err := httpexpect.NewRotatingFile("/var/log/monitor.log",
+//	    httpexpect.RotatingFileOpts{
+//	        MaxSize:    100 << 20,
+//	        Interval:   24 * time.Hour,
+//	        MaxBackups: 7,
+//	        Compress:   true,
+//	    })
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer file.Close()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    Reporter: reporter,
+//	    Printers: []httpexpect.Printer{
+//	        httpexpect.NewCompactPrinter(file),
+//	    },
+//	})
+type RotatingFile struct {
+	path string
+	opts RotatingFileOpts
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openTime time.Time
+
+	now func() time.Time
+}
+
+const rotatingFileTimeFormat = "20060102T150405.000000000"
+
+// NewRotatingFile opens or creates file with given path for appending
+// and returns a new RotatingFile instance.
+func NewRotatingFile(path string, opts RotatingFileOpts) (*RotatingFile, error) {
+	if opts.MaxSize < 0 || opts.Interval < 0 || opts.MaxBackups < 0 {
+		return nil, errors.New("unexpected negative RotatingFileOpts field")
+	}
+
+	f := &RotatingFile{
+		path: path,
+		opts: opts,
+		now:  time.Now,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Write implements io.Writer.
+// It rotates file before writing, if needed.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.needRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Logf implements Logger.
+// It writes formatted message prefixed with timestamp as a separate line.
+func (f *RotatingFile) Logf(message string, args ...interface{}) {
+	line := fmt.Sprintf(message, args...)
+	line = strings.TrimSuffix(line, "\n")
+
+	_, _ = fmt.Fprintf(f, "%s %s\n", f.now().UTC().Format(time.RFC3339Nano), line)
+}
+
+// Rotate rotates file immediately.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+
+	return f.rotate()
+}
+
+// Close closes file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openTime = f.now()
+
+	return nil
+}
+
+func (f *RotatingFile) needRotate(n int64) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	if f.opts.MaxSize > 0 && f.size+n > f.opts.MaxSize {
+		return true
+	}
+
+	if f.opts.Interval > 0 && f.now().Sub(f.openTime) >= f.opts.Interval {
+		return true
+	}
+
+	return false
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	backup := f.backupPath()
+
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if f.opts.Compress {
+		if err := compressFile(backup); err != nil {
+			return err
+		}
+	}
+
+	return f.removeBackups()
+}
+
+// Choose unique name for rotated file.
+func (f *RotatingFile) backupPath() string {
+	base := f.path + "." + f.now().UTC().Format(rotatingFileTimeFormat)
+
+	for n := 0; ; n++ {
+		path := base
+		if n != 0 {
+			path = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		if !fileExists(path) && !fileExists(path+".gz") {
+			return path
+		}
+	}
+}
+
+// Remove oldest rotated files exceeding MaxBackups.
+func (f *RotatingFile) removeBackups() error {
+	if f.opts.MaxBackups == 0 {
+		return nil
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > f.opts.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// List rotated files, oldest first.
+func (f *RotatingFile) backups() ([]string, error) {
+	dir, name := filepath.Split(f.path)
+
+	infos, err := ioutil.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, info := range infos {
+		if !strings.HasPrefix(info.Name(), name+".") {
+			continue
+		}
+
+		stamp := strings.TrimPrefix(info.Name(), name+".")
+		stamp = strings.TrimSuffix(stamp, ".gz")
+		if i := strings.IndexByte(stamp, '-'); i >= 0 {
+			stamp = stamp[:i]
+		}
+
+		if _, err := time.Parse(rotatingFileTimeFormat, stamp); err != nil {
+			continue
+		}
+
+		backups = append(backups, filepath.Join(dir, info.Name()))
+	}
+
+	// Timestamps have fixed width, so lexical order is chronological.
+	sort.SliceStable(backups, func(i, j int) bool {
+		return strings.TrimSuffix(backups[i], ".gz") <
+			strings.TrimSuffix(backups[j], ".gz")
+	})
+
+	return backups, nil
+}
+
+// Replace file with its gzip-compressed copy with ".gz" suffix.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(dst)
+
+	_, err = io.Copy(zw, src)
+	if err == nil {
+		err = zw.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+
+	_ = src.Close()
+
+	return os.Remove(path)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
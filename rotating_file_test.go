@@ -0,0 +1,154 @@
+package httpexpect
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRotatingFile(
+	t *testing.T, opts RotatingFileOpts,
+) (*RotatingFile, string, *time.Time) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "monitor.log")
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f, err := NewRotatingFile(path, opts)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	f.now = func() time.Time { return now }
+	f.openTime = now
+
+	return f, path, &now
+}
+
+func listBackups(t *testing.T, path string) []string {
+	matches, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	sort.Strings(matches)
+	return matches
+}
+
+func readFile(t *testing.T, path string) string {
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestRotatingFileSize(t *testing.T) {
+	f, path, now := newTestRotatingFile(t, RotatingFileOpts{MaxSize: 10})
+
+	for _, s := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddddddddddddd\n"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+		*now = now.Add(time.Second)
+	}
+
+	assert.Equal(t, "dddddddddddddd\n", readFile(t, path))
+
+	backups := listBackups(t, path)
+	require.Equal(t, 2, len(backups))
+	assert.Equal(t, "aaaa\nbbbb\n", readFile(t, backups[0]))
+	assert.Equal(t, "cccc\n", readFile(t, backups[1]))
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+	f, path, now := newTestRotatingFile(t, RotatingFileOpts{Interval: time.Hour})
+
+	f.Logf("first")
+
+	*now = now.Add(30 * time.Minute)
+	f.Logf("second\n")
+
+	*now = now.Add(30 * time.Minute)
+	f.Logf("third")
+
+	assert.Equal(t, "2020-01-01T01:00:00Z third\n", readFile(t, path))
+
+	backups := listBackups(t, path)
+	require.Equal(t, 1, len(backups))
+	assert.Equal(t, path+".20200101T010000.000000000", backups[0])
+	assert.Equal(t,
+		"2020-01-01T00:00:00Z first\n2020-01-01T00:30:00Z second\n",
+		readFile(t, backups[0]))
+}
+
+func TestRotatingFileBackups(t *testing.T) {
+	f, path, now := newTestRotatingFile(t, RotatingFileOpts{
+		MaxBackups: 2,
+		Compress:   true,
+	})
+
+	for _, s := range []string{"one", "two", "three", "four"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+		require.NoError(t, f.Rotate())
+		*now = now.Add(time.Second)
+	}
+
+	backups := listBackups(t, path)
+	require.Equal(t, 2, len(backups))
+
+	for i, want := range []string{"three", "four"} {
+		assert.True(t, strings.HasSuffix(backups[i], ".gz"))
+
+		file, err := os.Open(backups[i])
+		require.NoError(t, err)
+
+		zr, err := gzip.NewReader(file)
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(b))
+
+		_ = file.Close()
+	}
+
+	assert.Equal(t, "", readFile(t, path))
+}
+
+func TestRotatingFileSameTimestamp(t *testing.T) {
+	f, path, _ := newTestRotatingFile(t, RotatingFileOpts{})
+
+	for _, s := range []string{"one", "two"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+		require.NoError(t, f.Rotate())
+	}
+
+	backups := listBackups(t, path)
+	require.Equal(t, 2, len(backups))
+	assert.Equal(t, "one", readFile(t, backups[0]))
+	assert.Equal(t, "two", readFile(t, backups[1]))
+}
+
+func TestRotatingFileErrors(t *testing.T) {
+	_, err := NewRotatingFile("test.log", RotatingFileOpts{MaxSize: -1})
+	assert.Error(t, err)
+
+	_, err = NewRotatingFile(filepath.Join("no", "such", "dir", "test.log"),
+		RotatingFileOpts{})
+	assert.Error(t, err)
+
+	f, _, _ := newTestRotatingFile(t, RotatingFileOpts{})
+	require.NoError(t, f.Close())
+
+	_, err = f.Write([]byte("foo"))
+	assert.Error(t, err)
+	assert.Error(t, f.Rotate())
+	assert.Error(t, f.Close())
+}
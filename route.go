@@ -0,0 +1,250 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Routes is a registry of named request templates (routes).
+//
+// Routes allow large test suites to define endpoints in one place, and to
+// find out which of them were exercised by tests, see Coverage and Unused.
+//
+// Routes are registered using Expect.Route and invoked using Expect.Named.
+// To collect coverage across multiple tests, create a single Routes instance
+// and pass it to every Expect via Config.Routes.
+//
+// Routes is safe for concurrent use.
+type Routes struct {
+	mu     sync.Mutex
+	routes map[string]*routeEntry
+}
+
+type routeEntry struct {
+	method string
+	path   string
+	calls  int
+}
+
+// RouteCoverage describes a registered route and how many times it
+// was exercised, i.e. how many requests created from it received
+// a response.
+type RouteCoverage struct {
+	Name   string
+	Method string
+	Path   string
+	Calls  int
+}
+
+// NewRoutes returns a new empty Routes instance.
+func NewRoutes() *Routes {
+	return &Routes{
+		routes: map[string]*routeEntry{},
+	}
+}
+
+// Names returns sorted list of names of registered routes.
+func (rs *Routes) Names() []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return rs.names()
+}
+
+// Coverage returns registered routes with number of calls, sorted
+// by name.
+//
+// Example:
+//
+//	for _, route := range routes.Coverage() {
+//	    fmt.Printf("%s %s %s: %d\n", route.Name, route.Method, route.Path, route.Calls)
+//	}
+func (rs *Routes) Coverage() []RouteCoverage {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var coverage []RouteCoverage
+
+	for _, name := range rs.names() {
+		route := rs.routes[name]
+		coverage = append(coverage, RouteCoverage{
+			Name:   name,
+			Method: route.method,
+			Path:   route.path,
+			Calls:  route.calls,
+		})
+	}
+
+	return coverage
+}
+
+// Unused returns sorted list of names of routes that were never
+// exercised.
+//
+// Example:
+//
+//	func TestMain(m *testing.M) {
+//	    code := m.Run()
+//	    if unused := routes.Unused(); len(unused) != 0 {
+//	        fmt.Printf("routes not covered by tests: %v\n", unused)
+//	    }
+//	    os.Exit(code)
+//	}
+func (rs *Routes) Unused() []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var unused []string
+
+	for _, name := range rs.names() {
+		if rs.routes[name].calls == 0 {
+			unused = append(unused, name)
+		}
+	}
+
+	return unused
+}
+
+func (rs *Routes) names() []string {
+	names := make([]string, 0, len(rs.routes))
+	for name := range rs.routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (rs *Routes) add(name, method, path string) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if prev, ok := rs.routes[name]; ok {
+		if prev.method == method && prev.path == path {
+			return nil
+		}
+		return fmt.Errorf("route %q is already registered as %s %s",
+			name, prev.method, prev.path)
+	}
+
+	rs.routes[name] = &routeEntry{
+		method: method,
+		path:   path,
+	}
+
+	return nil
+}
+
+func (rs *Routes) get(name string) (method, path string, ok bool) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	route, ok := rs.routes[name]
+	if !ok {
+		return "", "", false
+	}
+
+	return route.method, route.path, true
+}
+
+func (rs *Routes) hit(name string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if route, ok := rs.routes[name]; ok {
+		route.calls++
+	}
+}
+
+// Route registers named route in Config.Routes, which can be later
+// invoked using Named.
+//
+// Registering the same route twice is allowed; registering different
+// route with the same name reports failure.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Route("user", "GET", "/users/{id}")
+//	e.Route("user.update", "PUT", "/users/{id}")
+//
+//	e.Named("user").WithPath("id", 42).
+//	    Expect().
+//	    Status(http.StatusOK)
+func (e *Expect) Route(name, method, path string) *Expect {
+	e.chain.enter("Route(%q)", name)
+	defer e.chain.leave()
+
+	var err error
+
+	if name == "" {
+		err = errors.New("unexpected empty route name")
+	} else if method == "" {
+		err = errors.New("unexpected empty route method")
+	} else {
+		err = e.config.Routes.add(name, strings.ToUpper(method), path)
+	}
+
+	if err != nil {
+		// chain of Expect is shared by all its requests,
+		// so failure is reported on a separate copy
+		chain := e.chain.clone()
+		chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				err,
+			},
+		})
+	}
+
+	return e
+}
+
+// Named returns a new Request instance created from route registered
+// with given name, see Route.
+//
+// pathargs are used to substitute parameters in route path, the same
+// way as in Request. Parameters can also be substituted by name using
+// Request.WithPath.
+//
+// If route is not registered, failure is reported.
+//
+// Example:
+//
+//	e.Route("repo", "GET", "/repos/{user}/{repo}")
+//
+//	e.Named("repo", "gavv", "httpexpect").
+//	    Expect().
+//	    Status(http.StatusOK)
+func (e *Expect) Named(name string, pathargs ...interface{}) *Request {
+	e.chain.enter("Named(%q)", name)
+	defer e.chain.leave()
+
+	routes := e.config.Routes
+
+	method, path, ok := routes.get(name)
+	if !ok {
+		req := e.newRequest("", "")
+		req.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{routes.Names()},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: route %q is registered", name),
+			},
+		})
+		return req
+	}
+
+	req := e.newRequest(method, path, pathargs...)
+
+	req.WithMatcher(func(*Response) {
+		routes.hit(name)
+	})
+
+	return req
+}
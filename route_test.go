@@ -0,0 +1,111 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutes(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.Path))
+	})
+
+	routes := NewRoutes()
+
+	newExpect := func(reporter Reporter) *Expect {
+		return WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+			Routes: routes,
+		})
+	}
+
+	t.Run("register", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		newExpect(reporter).
+			Route("user", "GET", "/users/{id}").
+			Route("user.update", "put", "/users/{id}").
+			Route("repo", "GET", "/repos/{user}/{repo}").
+			Route("user", "GET", "/users/{id}")
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, []string{"repo", "user", "user.update"}, routes.Names())
+	})
+
+	t.Run("invoke", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		e.Named("user").WithPath("id", 42).
+			Expect().
+			Body().Equal("GET /users/42")
+
+		e.Named("user", 7).
+			Expect().
+			Body().Equal("GET /users/7")
+
+		e.Named("user.update").WithPath("id", 1).
+			Expect().
+			Body().Equal("PUT /users/1")
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("coverage", func(t *testing.T) {
+		assert.Equal(t, []RouteCoverage{
+			{Name: "repo", Method: "GET", Path: "/repos/{user}/{repo}", Calls: 0},
+			{Name: "user", Method: "GET", Path: "/users/{id}", Calls: 2},
+			{Name: "user.update", Method: "PUT", Path: "/users/{id}", Calls: 1},
+		}, routes.Coverage())
+
+		assert.Equal(t, []string{"repo"}, routes.Unused())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		req := newExpect(reporter).Named("missing")
+		req.chain.assertFailed(t)
+
+		req.Expect().chain.assertFailed(t)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		e.Route("user", "POST", "/users")
+		assert.True(t, reporter.reported)
+
+		reporter.reported = false
+
+		e.Route("", "GET", "/")
+		assert.True(t, reporter.reported)
+
+		reporter.reported = false
+
+		e.Route("root", "", "/")
+		assert.True(t, reporter.reported)
+
+		reporter.reported = false
+
+		e.GET("/").Expect().Status(http.StatusOK)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("default registry", func(t *testing.T) {
+		e1 := WithConfig(Config{Reporter: newMockReporter(t)})
+		e2 := WithConfig(Config{Reporter: newMockReporter(t)})
+
+		e1.Route("a", "GET", "/a")
+
+		assert.Equal(t, []string{"a"}, e1.config.Routes.Names())
+		assert.Empty(t, e2.config.Routes.Names())
+	})
+}
@@ -0,0 +1,369 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/imkira/go-interpol"
+)
+
+// Scenario is an ordered multi-step flow, like login, create, fetch,
+// and delete, where later steps use variables captured from responses
+// of earlier steps.
+//
+// Steps are declared using Step and executed by Run. Every step receives
+// a copy of Expect and the scenario variables. Requests created by this
+// copy have their {named} path parameters substituted automatically from
+// variables with the same names.
+//
+// Failures of steps are not reported immediately. Instead, when a step
+// fails, remaining steps are skipped, and Run reports a single failure
+// that describes the whole scenario: which steps passed, which step
+// failed and why, and which steps were skipped.
+//
+// Example:
+//
+//	sc := e.Scenario("user lifecycle")
+//
+//	sc.Step("login", func(e *httpexpect.Expect, vars *httpexpect.Environment) *httpexpect.Response {
+//	    return e.POST("/login").WithJSON(credentials).
+//	        Expect().
+//	        Status(http.StatusOK)
+//	}).Capture("token", "$.token")
+//
+//	sc.Step("create", func(e *httpexpect.Expect, vars *httpexpect.Environment) *httpexpect.Response {
+//	    return e.POST("/users").WithJSON(user).
+//	        WithHeader("Authorization", "Bearer "+vars.GetString("token")).
+//	        Expect().
+//	        Status(http.StatusCreated)
+//	}).Capture("id", "$.id")
+//
+//	sc.Step("fetch", func(e *httpexpect.Expect, vars *httpexpect.Environment) *httpexpect.Response {
+//	    return e.GET("/users/{id}"). // {id} is taken from vars
+//	        Expect().
+//	        Status(http.StatusOK)
+//	})
+//
+//	sc.Run()
+type Scenario struct {
+	chain  *chain
+	expect *Expect
+	name   string
+	steps  []*ScenarioStep
+	vars   map[string]interface{}
+}
+
+// ScenarioStep is a single step of Scenario, created by Scenario.Step.
+type ScenarioStep struct {
+	chain    *chain
+	name     string
+	fn       func(e *Expect, vars *Environment) *Response
+	captures []scenarioCapture
+}
+
+type scenarioCapture struct {
+	name   string
+	path   string
+	header string
+}
+
+// Scenario returns a new Scenario with given name.
+//
+// Example:
+//
+//	sc := e.Scenario("checkout")
+func (e *Expect) Scenario(name string) *Scenario {
+	e.chain.enter("Scenario(%q)", name)
+	defer e.chain.leave()
+
+	return &Scenario{
+		chain:  e.chain.clone(),
+		expect: e,
+		name:   name,
+		vars:   map[string]interface{}{},
+	}
+}
+
+// Step appends a new step to scenario.
+//
+// fn should send request(s) using given Expect instance and return the
+// response from which variables are captured (see ScenarioStep.Capture).
+// fn may return nil if step doesn't capture anything.
+//
+// vars contains variables captured by previous steps. fn may also put
+// new variables into it.
+func (s *Scenario) Step(
+	name string, fn func(e *Expect, vars *Environment) *Response,
+) *ScenarioStep {
+	s.chain.enter("Step(%q)", name)
+	defer s.chain.leave()
+
+	step := &ScenarioStep{
+		chain: s.chain.clone(),
+		name:  name,
+		fn:    fn,
+	}
+
+	if fn == nil {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil step function"),
+			},
+		})
+		return step
+	}
+
+	s.steps = append(s.steps, step)
+
+	return step
+}
+
+// Capture stores value found by given JSON path in response body of the
+// step into a scenario variable with given name.
+//
+// If response is not JSON, or path doesn't match, the step fails.
+//
+// Example:
+//
+//	sc.Step("create", createUser).
+//	    Capture("id", "$.id").
+//	    Capture("email", "$.contacts.email")
+func (st *ScenarioStep) Capture(name, path string) *ScenarioStep {
+	st.chain.enter("Capture(%q, %q)", name, path)
+	defer st.chain.leave()
+
+	st.captures = append(st.captures, scenarioCapture{
+		name: name,
+		path: path,
+	})
+
+	return st
+}
+
+// CaptureHeader stores value of given response header of the step into
+// a scenario variable with given name.
+//
+// If response has no such header, the step fails.
+//
+// Example:
+//
+//	sc.Step("create", createUser).
+//	    CaptureHeader("location", "Location")
+func (st *ScenarioStep) CaptureHeader(name, header string) *ScenarioStep {
+	st.chain.enter("CaptureHeader(%q, %q)", name, header)
+	defer st.chain.leave()
+
+	st.captures = append(st.captures, scenarioCapture{
+		name:   name,
+		header: header,
+	})
+
+	return st
+}
+
+// Vars returns a new Environment instance with scenario variables,
+// i.e. values captured so far.
+//
+// Example:
+//
+//	sc.Run()
+//	id := sc.Vars().GetInt("id")
+func (s *Scenario) Vars() *Environment {
+	env := newEnvironment(s.chain)
+	env.data = s.vars
+
+	return env
+}
+
+// Run executes scenario steps in order of declaration.
+//
+// If a step fails, remaining steps are skipped, and a single failure is
+// reported, which includes status of every step, failed assertion path,
+// and all errors reported by the failed step.
+//
+// Example:
+//
+//	sc.Run()
+func (s *Scenario) Run() *Scenario {
+	s.chain.enter("Run()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	for i, step := range s.steps {
+		handler := &scenarioHandler{}
+
+		if s.runStep(step, handler) {
+			continue
+		}
+
+		s.fail(i, handler.failures)
+		return s
+	}
+
+	return s
+}
+
+// Run step using a copy of Expect that collects failures instead of
+// reporting them. Returns false if step failed.
+func (s *Scenario) runStep(step *ScenarioStep, handler *scenarioHandler) bool {
+	ce := s.expect.clone()
+	ce.chain = s.chain.clone()
+	ce.chain.handler = handler
+	ce.chain.enter("Step(%q)", step.name)
+
+	vars := newEnvironment(ce.chain)
+	vars.data = s.vars
+
+	ce.builders = append(ce.builders, func(req *Request) {
+		injectScenarioVars(req, s.vars)
+	})
+
+	resp := step.fn(ce, vars)
+
+	if len(step.captures) != 0 && resp == nil {
+		ce.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("step %q has captures, but returned nil response",
+					step.name),
+			},
+		})
+	}
+
+	if resp != nil {
+		for _, c := range step.captures {
+			if !s.capture(resp, c) {
+				break
+			}
+		}
+	}
+
+	return !handler.failed()
+}
+
+func (s *Scenario) capture(resp *Response, c scenarioCapture) bool {
+	if c.header != "" {
+		resp.chain.enter("CaptureHeader(%q, %q)", c.name, c.header)
+		defer resp.chain.leave()
+
+		if resp.chain.failed() {
+			return false
+		}
+
+		value := resp.httpResp.Header.Get(c.header)
+		if value == "" {
+			resp.chain.fail(AssertionFailure{
+				Type:     AssertContainsKey,
+				Actual:   &AssertionValue{resp.httpResp.Header},
+				Expected: &AssertionValue{c.header},
+				Errors: []error{
+					fmt.Errorf("expected: response has %q header", c.header),
+				},
+			})
+			return false
+		}
+
+		s.vars[c.name] = value
+		return true
+	}
+
+	resp.chain.enter("Capture(%q, %q)", c.name, c.path)
+	defer resp.chain.leave()
+
+	value := resp.JSON().Path(c.path)
+	if value.chain.failed() {
+		return false
+	}
+
+	s.vars[c.name] = value.Raw()
+	return true
+}
+
+func (s *Scenario) fail(index int, failures []scenarioFailure) {
+	failed := s.steps[index]
+
+	errs := []error{
+		fmt.Errorf("expected: scenario %q completes successfully", s.name),
+		fmt.Errorf("step %d of %d (%q) failed", index+1, len(s.steps), failed.name),
+	}
+
+	for i, step := range s.steps {
+		var status string
+		switch {
+		case i < index:
+			status = "passed"
+		case i == index:
+			status = "failed"
+		default:
+			status = "skipped"
+		}
+		errs = append(errs, fmt.Errorf("  step %d %q: %s", i+1, step.name, status))
+	}
+
+	for _, f := range failures {
+		errs = append(errs, fmt.Errorf("failed assertion: %s", strings.Join(f.path, ".")))
+		errs = append(errs, f.failure.Errors...)
+	}
+
+	failure := *failures[0].failure
+	failure.Errors = errs
+
+	s.chain.fail(failure)
+}
+
+// Substitute {named} path parameters that were not provided explicitly
+// with scenario variables with the same names.
+func injectScenarioVars(req *Request, vars map[string]interface{}) {
+	var keys []string
+
+	_, _ = interpol.WithFunc(req.path, func(k string, w io.Writer) error {
+		keys = append(keys, k)
+		return nil
+	})
+
+	for _, k := range keys {
+		if value, ok := vars[k]; ok && value != nil {
+			req.withPath(k, value)
+		}
+	}
+}
+
+type scenarioFailure struct {
+	path    []string
+	failure *AssertionFailure
+}
+
+// AssertionHandler that collects failures of a single step.
+type scenarioHandler struct {
+	mu       sync.Mutex
+	failures []scenarioFailure
+}
+
+func (h *scenarioHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *scenarioHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, scenarioFailure{
+		path:    append([]string(nil), ctx.Path...),
+		failure: failure,
+	})
+}
+
+func (h *scenarioHandler) failed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.failures) != 0
+}
@@ -0,0 +1,239 @@
+package httpexpect
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createScenarioHandler() http.Handler {
+	users := map[string]bool{}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token":"secret"}`))
+	})
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		users["42"] = true
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/users/42")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":42}`))
+	})
+
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/users/")
+		if !users[id] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(users, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":` + id + `}`))
+	})
+
+	return mux
+}
+
+func TestScenarioFailed(t *testing.T) {
+	e, _ := newMockExpect(t, createScenarioHandler())
+
+	sc := e.Scenario("test")
+	sc.chain.fail(mockFailure())
+
+	called := false
+
+	sc.Step("step", func(e *Expect, vars *Environment) *Response {
+		called = true
+		return nil
+	}).Capture("id", "$.id")
+
+	sc.Run()
+
+	assert.False(t, called)
+	sc.chain.assertFailed(t)
+}
+
+func TestScenarioBadUsage(t *testing.T) {
+	e, _ := newMockExpect(t, createScenarioHandler())
+
+	t.Run("nil step", func(t *testing.T) {
+		sc := e.Scenario("test")
+
+		sc.Step("step", nil)
+		sc.chain.assertFailed(t)
+	})
+
+	t.Run("capture without response", func(t *testing.T) {
+		sc := e.Scenario("test")
+
+		sc.Step("step", func(e *Expect, vars *Environment) *Response {
+			return nil
+		}).Capture("id", "$.id")
+
+		sc.chain.assertNotFailed(t)
+
+		sc.Run()
+		sc.chain.assertFailed(t)
+	})
+}
+
+func TestScenarioRun(t *testing.T) {
+	e, reporter := newMockExpect(t, createScenarioHandler())
+
+	var steps []string
+
+	sc := e.Scenario("user lifecycle")
+
+	sc.Step("login", func(e *Expect, vars *Environment) *Response {
+		steps = append(steps, "login")
+		return e.POST("/login").
+			Expect().
+			Status(http.StatusOK)
+	}).Capture("token", "$.token")
+
+	sc.Step("create", func(e *Expect, vars *Environment) *Response {
+		steps = append(steps, "create")
+		return e.POST("/users").
+			WithHeader("Authorization", "Bearer "+vars.GetString("token")).
+			Expect().
+			Status(http.StatusCreated)
+	}).Capture("id", "$.id").CaptureHeader("location", "Location")
+
+	sc.Step("fetch", func(e *Expect, vars *Environment) *Response {
+		steps = append(steps, "fetch")
+		e.GET("/users/{id}").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().ValueEqual("id", 42)
+		return nil
+	})
+
+	sc.Step("delete", func(e *Expect, vars *Environment) *Response {
+		steps = append(steps, "delete")
+		vars.Put("deleted", true)
+		return e.DELETE(vars.GetString("location")).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	sc.Run()
+	sc.chain.assertNotFailed(t)
+
+	assert.Equal(t, []string{"login", "create", "fetch", "delete"}, steps)
+	assert.False(t, reporter.reported)
+
+	vars := sc.Vars()
+
+	assert.Equal(t, "secret", vars.GetString("token"))
+	assert.Equal(t, 42.0, vars.GetFloat("id"))
+	assert.Equal(t, "/users/42", vars.GetString("location"))
+	assert.True(t, vars.GetBool("deleted"))
+	vars.chain.assertNotFailed(t)
+}
+
+func TestScenarioReport(t *testing.T) {
+	e, _ := newMockExpect(t, createScenarioHandler())
+
+	handler := &mockAssertionHandler{}
+
+	e = e.clone()
+	e.chain = e.chain.clone()
+	e.chain.handler = handler
+
+	var steps []string
+
+	sc := e.Scenario("user lifecycle")
+
+	sc.Step("login", func(e *Expect, vars *Environment) *Response {
+		steps = append(steps, "login")
+		return e.POST("/login").Expect()
+	})
+
+	sc.Step("create", func(e *Expect, vars *Environment) *Response {
+		steps = append(steps, "create")
+		// no token, server responds with 401
+		return e.POST("/users").
+			Expect().
+			Status(http.StatusCreated)
+	}).Capture("id", "$.id")
+
+	sc.Step("fetch", func(e *Expect, vars *Environment) *Response {
+		steps = append(steps, "fetch")
+		return e.GET("/users/{id}").Expect()
+	})
+
+	sc.Run()
+	sc.chain.assertFailed(t)
+
+	assert.Equal(t, []string{"login", "create"}, steps)
+
+	require.NotNil(t, handler.failure)
+	assert.Equal(t, AssertEqual, handler.failure.Type)
+
+	var msgs []string
+	for _, err := range handler.failure.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	report := strings.Join(msgs, "\n")
+
+	assert.Contains(t, report, `step 2 of 3 ("create") failed`)
+	assert.Contains(t, report, `step 1 "login": passed`)
+	assert.Contains(t, report, `step 2 "create": failed`)
+	assert.Contains(t, report, `step 3 "fetch": skipped`)
+	assert.Contains(t, report, `Status()`)
+}
+
+func TestScenarioCapture(t *testing.T) {
+	e, _ := newMockExpect(t, createScenarioHandler())
+
+	t.Run("missing path", func(t *testing.T) {
+		sc := e.Scenario("test")
+
+		sc.Step("login", func(e *Expect, vars *Environment) *Response {
+			return e.POST("/login").Expect()
+		}).Capture("id", "$.id")
+
+		sc.Run()
+		sc.chain.assertFailed(t)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		sc := e.Scenario("test")
+
+		sc.Step("login", func(e *Expect, vars *Environment) *Response {
+			return e.POST("/login").Expect()
+		}).CaptureHeader("location", "Location")
+
+		sc.Run()
+		sc.chain.assertFailed(t)
+	})
+
+	t.Run("explicit path args", func(t *testing.T) {
+		sc := e.Scenario("test")
+
+		sc.Step("fetch", func(e *Expect, vars *Environment) *Response {
+			vars.Put("id", 42)
+			return e.GET("/users/{id}", 100).
+				Expect().
+				Status(http.StatusNotFound)
+		})
+
+		sc.Run()
+		sc.chain.assertNotFailed(t)
+	})
+}
@@ -0,0 +1,316 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SecurityHeadersOpts defines expectations of SecurityHeaders matcher.
+type SecurityHeadersOpts struct {
+	// Minimum max-age of Strict-Transport-Security header.
+	// If zero, 180 days is used.
+	HSTSMinAge time.Duration
+
+	// Require includeSubDomains directive in Strict-Transport-Security header.
+	HSTSIncludeSubDomains bool
+
+	// Expected Content-Security-Policy header.
+	// If empty, header should be present and non-empty.
+	ContentSecurityPolicy string
+
+	// Expected X-Frame-Options header (case-insensitive).
+	// If empty, header should be either "DENY" or "SAMEORIGIN".
+	FrameOptions string
+
+	// Expected Referrer-Policy header.
+	// If empty, header should contain known policy other than "unsafe-url".
+	ReferrerPolicy string
+
+	// Headers that are not checked for any route, e.g. when server is
+	// tested over plain HTTP and doesn't send Strict-Transport-Security.
+	// May be empty.
+	Skip []string
+
+	// Routes for which all or some headers are not checked.
+	// May be empty.
+	Exclusions []SecurityHeadersExclusion
+}
+
+// SecurityHeadersExclusion disables checks of SecurityHeaders matcher
+// for requests with matching path.
+type SecurityHeadersExclusion struct {
+	// Pattern for request URL path, in path.Match syntax, e.g. "/static/*".
+	Path string
+
+	// Headers that are not checked for matching requests.
+	// If empty, no headers are checked.
+	Headers []string
+}
+
+const defaultHSTSMinAge = 180 * 24 * time.Hour
+
+var referrerPolicies = map[string]bool{
+	"no-referrer":                     true,
+	"no-referrer-when-downgrade":      true,
+	"origin":                          true,
+	"origin-when-cross-origin":        true,
+	"same-origin":                     true,
+	"strict-origin":                   true,
+	"strict-origin-when-cross-origin": true,
+	"unsafe-url":                      true,
+}
+
+// SecurityHeaders returns matcher that checks presence and values of
+// security-related response headers:
+//   - Strict-Transport-Security (HSTS)
+//   - Content-Security-Policy
+//   - X-Content-Type-Options
+//   - X-Frame-Options
+//   - Referrer-Policy
+//
+// Checks are applied as CheckBundle named "SecurityHeaders", so all
+// violated requirements are reported at once. Some headers may be
+// skipped globally or for specific routes, see SecurityHeadersOpts.
+//
+// Matcher can be passed to Expect.Matcher or Request.WithMatcher.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "https://example.com")
+//
+//	e = e.Matcher(httpexpect.SecurityHeaders(httpexpect.SecurityHeadersOpts{
+//	    ReferrerPolicy: "no-referrer",
+//	    Exclusions: []httpexpect.SecurityHeadersExclusion{
+//	        {Path: "/healthz"},
+//	        {Path: "/api/*", Headers: []string{"Content-Security-Policy"}},
+//	    },
+//	}))
+func SecurityHeaders(opts SecurityHeadersOpts) func(*Response) {
+	if opts.HSTSMinAge == 0 {
+		opts.HSTSMinAge = defaultHSTSMinAge
+	}
+
+	checks := []struct {
+		header string
+		check  Check
+	}{
+		{"Strict-Transport-Security", Check{
+			Name: "StrictTransportSecurity",
+			Func: func(resp *Response) {
+				checkHSTS(resp, opts)
+			},
+		}},
+		{"Content-Security-Policy", Check{
+			Name: "ContentSecurityPolicy",
+			Func: func(resp *Response) {
+				if opts.ContentSecurityPolicy != "" {
+					resp.Header("Content-Security-Policy").
+						Equal(opts.ContentSecurityPolicy)
+				} else {
+					resp.Header("Content-Security-Policy").NotEmpty()
+				}
+			},
+		}},
+		{"X-Content-Type-Options", Check{
+			Name: "ContentTypeOptions",
+			Func: func(resp *Response) {
+				resp.Header("X-Content-Type-Options").EqualFold("nosniff")
+			},
+		}},
+		{"X-Frame-Options", Check{
+			Name: "FrameOptions",
+			Func: func(resp *Response) {
+				if opts.FrameOptions != "" {
+					resp.Header("X-Frame-Options").EqualFold(opts.FrameOptions)
+				} else {
+					checkFrameOptions(resp)
+				}
+			},
+		}},
+		{"Referrer-Policy", Check{
+			Name: "ReferrerPolicy",
+			Func: func(resp *Response) {
+				checkReferrerPolicy(resp, opts)
+			},
+		}},
+	}
+
+	return func(resp *Response) {
+		skipped := securityHeadersSkipped(resp, opts)
+		if skipped == nil {
+			return
+		}
+
+		bundle := Checks("SecurityHeaders")
+		for _, c := range checks {
+			if !skipped[http.CanonicalHeaderKey(c.header)] {
+				bundle = bundle.With(c.check)
+			}
+		}
+
+		resp.Check(bundle)
+	}
+}
+
+// Return set of headers that should not be checked for response,
+// or nil if no headers should be checked.
+func securityHeadersSkipped(resp *Response, opts SecurityHeadersOpts) map[string]bool {
+	skipped := map[string]bool{}
+
+	for _, name := range opts.Skip {
+		skipped[http.CanonicalHeaderKey(name)] = true
+	}
+
+	if resp.httpResp == nil || resp.httpResp.Request == nil ||
+		resp.httpResp.Request.URL == nil {
+		return skipped
+	}
+
+	reqPath := resp.httpResp.Request.URL.Path
+
+	for _, excl := range opts.Exclusions {
+		if ok, _ := path.Match(excl.Path, reqPath); !ok {
+			continue
+		}
+
+		if len(excl.Headers) == 0 {
+			return nil
+		}
+
+		for _, name := range excl.Headers {
+			skipped[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+
+	return skipped
+}
+
+func checkHSTS(resp *Response, opts SecurityHeadersOpts) {
+	header := resp.Header("Strict-Transport-Security").NotEmpty()
+	if header.chain.failed() {
+		return
+	}
+
+	value := header.Raw()
+
+	var (
+		maxAge            int64 = -1
+		includeSubDomains bool
+	)
+
+	for _, directive := range strings.Split(value, ";") {
+		name, arg := directive, ""
+		if eq := strings.IndexByte(directive, '='); eq >= 0 {
+			name, arg = directive[:eq], directive[eq+1:]
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		arg = strings.Trim(strings.TrimSpace(arg), `"`)
+
+		switch name {
+		case "max-age":
+			n, err := strconv.ParseInt(arg, 10, 64)
+			if err == nil && n >= 0 {
+				maxAge = n
+			}
+		case "includesubdomains":
+			includeSubDomains = true
+		}
+	}
+
+	if maxAge < 0 {
+		resp.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(
+					"expected: Strict-Transport-Security header has valid max-age"),
+			},
+		})
+		return
+	}
+
+	minAge := int64(opts.HSTSMinAge / time.Second)
+	if maxAge < minAge {
+		resp.chain.fail(AssertionFailure{
+			Type:     AssertGe,
+			Actual:   &AssertionValue{maxAge},
+			Expected: &AssertionValue{minAge},
+			Errors: []error{
+				fmt.Errorf(
+					"expected: Strict-Transport-Security max-age is at least %d seconds",
+					minAge),
+			},
+		})
+		return
+	}
+
+	if opts.HSTSIncludeSubDomains && !includeSubDomains {
+		resp.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: Strict-Transport-Security header" +
+					" has includeSubDomains directive"),
+			},
+		})
+	}
+}
+
+func checkFrameOptions(resp *Response) {
+	header := resp.Header("X-Frame-Options").NotEmpty()
+	if header.chain.failed() {
+		return
+	}
+
+	value := header.Raw()
+
+	if policy := strings.ToUpper(value); policy != "DENY" && policy != "SAMEORIGIN" {
+		resp.chain.fail(AssertionFailure{
+			Type:     AssertBelongs,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{AssertionList{"DENY", "SAMEORIGIN"}},
+			Errors: []error{
+				errors.New("expected: X-Frame-Options header belongs to given list"),
+			},
+		})
+	}
+}
+
+func checkReferrerPolicy(resp *Response, opts SecurityHeadersOpts) {
+	if opts.ReferrerPolicy != "" {
+		resp.Header("Referrer-Policy").Equal(opts.ReferrerPolicy)
+		return
+	}
+
+	header := resp.Header("Referrer-Policy").NotEmpty()
+	if header.chain.failed() {
+		return
+	}
+
+	value := header.Raw()
+
+	// If header contains a list, the last known policy is applied.
+	policy := ""
+	for _, token := range splitHeaderList([]string{value}) {
+		if token = strings.ToLower(token); referrerPolicies[token] {
+			policy = token
+		}
+	}
+
+	if policy == "" || policy == "unsafe-url" {
+		resp.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: Referrer-Policy header contains" +
+					" known policy other than unsafe-url"),
+			},
+		})
+	}
+}
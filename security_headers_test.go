@@ -0,0 +1,232 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func secureHeaders() http.Header {
+	return http.Header{
+		"Strict-Transport-Security": {"max-age=31536000; includeSubDomains"},
+		"Content-Security-Policy":   {"default-src 'self'"},
+		"X-Content-Type-Options":    {"nosniff"},
+		"X-Frame-Options":           {"DENY"},
+		"Referrer-Policy":           {"no-referrer"},
+	}
+}
+
+func newSecurityHeadersResponse(
+	t *testing.T, path string, header http.Header,
+) (*Response, *mockReporter) {
+	reporter := newMockReporter(t)
+
+	req, err := http.NewRequest("GET", "http://example.com"+path, nil)
+	assert.NoError(t, err)
+
+	resp := NewResponse(reporter, &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Request:    req,
+	})
+
+	return resp, reporter
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	cases := []struct {
+		name   string
+		opts   SecurityHeadersOpts
+		modify func(h http.Header)
+		fail   bool
+	}{
+		{
+			name:   "secure",
+			modify: func(h http.Header) {},
+		},
+		{
+			name: "missing_hsts",
+			modify: func(h http.Header) {
+				h.Del("Strict-Transport-Security")
+			},
+			fail: true,
+		},
+		{
+			name: "short_hsts",
+			modify: func(h http.Header) {
+				h.Set("Strict-Transport-Security", "max-age=3600")
+			},
+			fail: true,
+		},
+		{
+			name: "short_hsts_allowed",
+			opts: SecurityHeadersOpts{HSTSMinAge: time.Hour},
+			modify: func(h http.Header) {
+				h.Set("Strict-Transport-Security", "max-age=3600")
+			},
+		},
+		{
+			name: "invalid_hsts",
+			modify: func(h http.Header) {
+				h.Set("Strict-Transport-Security", "includeSubDomains")
+			},
+			fail: true,
+		},
+		{
+			name: "hsts_without_subdomains",
+			opts: SecurityHeadersOpts{HSTSIncludeSubDomains: true},
+			modify: func(h http.Header) {
+				h.Set("Strict-Transport-Security", "max-age=31536000")
+			},
+			fail: true,
+		},
+		{
+			name:   "csp_mismatch",
+			opts:   SecurityHeadersOpts{ContentSecurityPolicy: "default-src 'none'"},
+			modify: func(h http.Header) {},
+			fail:   true,
+		},
+		{
+			name: "missing_csp",
+			modify: func(h http.Header) {
+				h.Del("Content-Security-Policy")
+			},
+			fail: true,
+		},
+		{
+			name: "sniffing",
+			modify: func(h http.Header) {
+				h.Set("X-Content-Type-Options", "sniff")
+			},
+			fail: true,
+		},
+		{
+			name: "sameorigin",
+			modify: func(h http.Header) {
+				h.Set("X-Frame-Options", "sameorigin")
+			},
+		},
+		{
+			name: "allow_from",
+			modify: func(h http.Header) {
+				h.Set("X-Frame-Options", "ALLOW-FROM https://example.com")
+			},
+			fail: true,
+		},
+		{
+			name:   "frame_options_mismatch",
+			opts:   SecurityHeadersOpts{FrameOptions: "SAMEORIGIN"},
+			modify: func(h http.Header) {},
+			fail:   true,
+		},
+		{
+			name: "referrer_list",
+			modify: func(h http.Header) {
+				h.Set("Referrer-Policy", "no-referrer, strict-origin-when-cross-origin")
+			},
+		},
+		{
+			name: "unsafe_referrer",
+			modify: func(h http.Header) {
+				h.Set("Referrer-Policy", "unsafe-url")
+			},
+			fail: true,
+		},
+		{
+			name: "unknown_referrer",
+			modify: func(h http.Header) {
+				h.Set("Referrer-Policy", "everything")
+			},
+			fail: true,
+		},
+		{
+			name:   "referrer_mismatch",
+			opts:   SecurityHeadersOpts{ReferrerPolicy: "same-origin"},
+			modify: func(h http.Header) {},
+			fail:   true,
+		},
+		{
+			name: "skipped",
+			opts: SecurityHeadersOpts{Skip: []string{"strict-transport-security"}},
+			modify: func(h http.Header) {
+				h.Del("Strict-Transport-Security")
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := secureHeaders()
+			tc.modify(header)
+
+			resp, reporter := newSecurityHeadersResponse(t, "/", header)
+
+			SecurityHeaders(tc.opts)(resp)
+
+			if tc.fail {
+				resp.chain.assertFailed(t)
+				assert.True(t, reporter.reported)
+			} else {
+				resp.chain.assertNotFailed(t)
+				assert.False(t, reporter.reported)
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersExclusions(t *testing.T) {
+	matcher := SecurityHeaders(SecurityHeadersOpts{
+		Exclusions: []SecurityHeadersExclusion{
+			{Path: "/healthz"},
+			{Path: "/api/*", Headers: []string{"Content-Security-Policy"}},
+		},
+	})
+
+	resp, _ := newSecurityHeadersResponse(t, "/healthz", http.Header{})
+	matcher(resp)
+	resp.chain.assertNotFailed(t)
+
+	header := secureHeaders()
+	header.Del("Content-Security-Policy")
+
+	resp, _ = newSecurityHeadersResponse(t, "/api/users", header)
+	matcher(resp)
+	resp.chain.assertNotFailed(t)
+
+	resp, _ = newSecurityHeadersResponse(t, "/users", header)
+	matcher(resp)
+	resp.chain.assertFailed(t)
+
+	resp, _ = newSecurityHeadersResponse(t, "/api/users/1", header)
+	matcher(resp)
+	resp.chain.assertFailed(t)
+}
+
+func TestSecurityHeadersMatcher(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range secureHeaders() {
+			w.Header()[k] = v
+		}
+		if r.URL.Path == "/insecure" {
+			w.Header().Del("X-Frame-Options")
+		}
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	}).Matcher(SecurityHeaders(SecurityHeadersOpts{}))
+
+	e.GET("/secure").Expect().chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+
+	e.GET("/insecure").Expect().chain.assertFailed(t)
+	assert.True(t, reporter.reported)
+}
@@ -0,0 +1,568 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ShutdownCheck verifies that server shuts down gracefully: requests that
+// were in flight when shutdown started are completed, while new requests
+// are refused.
+//
+// It works both with live servers (e.g. httptest.Server or http.Server)
+// and with in-process handlers behind Binder, if handler implements
+// draining by itself.
+//
+// Run performs the following steps:
+//   - starts all in-flight requests concurrently and waits until they're
+//     passed to client, plus settle delay, so that they reach the server
+//   - invokes shutdown function in background
+//   - repeatedly sends probe request until it's refused, while shutdown
+//     is in progress
+//   - waits until in-flight requests and shutdown function complete
+//
+// Probe request is refused if it fails to connect (when no statuses are
+// given to Refuse), or if it's answered with one of given statuses.
+// Failures of probe requests are reported with SeverityLog.
+type ShutdownCheck struct {
+	chain  *chain
+	expect *Expect
+
+	shutdown func() error
+
+	inFlight []func(e *Expect) *Response
+
+	probe          func(e *Expect) *Request
+	refuseStatuses []int
+
+	settleDelay time.Duration
+	timeout     time.Duration
+}
+
+// GracefulShutdown returns a new ShutdownCheck instance, which invokes
+// given function to trigger server shutdown.
+//
+// Shutdown function may block until shutdown is finished; if it returns
+// error, failure is reported.
+//
+// Example:
+//
+//	server := httptest.NewServer(handler)
+//	e := httpexpect.Default(t, server.URL)
+//
+//	httpexpect.GracefulShutdown(e, func() error {
+//	    return server.Config.Shutdown(context.Background())
+//	}).
+//	    InFlight(func(e *httpexpect.Expect) *httpexpect.Response {
+//	        return e.GET("/slow").Expect().Status(http.StatusOK)
+//	    }).
+//	    Refuse(func(e *httpexpect.Expect) *httpexpect.Request {
+//	        return e.GET("/fast")
+//	    }).
+//	    Run()
+func GracefulShutdown(e *Expect, shutdown func() error) *ShutdownCheck {
+	if e == nil {
+		panic("GracefulShutdown: Expect is nil")
+	}
+
+	s := &ShutdownCheck{
+		expect:      e,
+		shutdown:    shutdown,
+		settleDelay: 50 * time.Millisecond,
+		timeout:     5 * time.Second,
+	}
+
+	s.chain = e.chain.clone()
+	s.chain.enter("GracefulShutdown()")
+	defer s.chain.leave()
+
+	if shutdown == nil {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil shutdown argument"),
+			},
+		})
+	}
+
+	return s
+}
+
+// InFlight adds request that should be in flight when shutdown starts.
+// Can be called multiple times; all in-flight requests run concurrently.
+//
+// Function should construct and send request using given Expect instance
+// and return resulting response; it also may perform additional checks on
+// response. Every function gets its own copy of Expect.
+//
+// Example:
+//
+//	check.InFlight(func(e *httpexpect.Expect) *httpexpect.Response {
+//	    return e.POST("/export").Expect().Status(http.StatusOK)
+//	})
+func (s *ShutdownCheck) InFlight(fn func(e *Expect) *Response) *ShutdownCheck {
+	s.chain.enter("InFlight()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if fn == nil {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return s
+	}
+
+	s.inFlight = append(s.inFlight, fn)
+
+	return s
+}
+
+// Refuse sets probe request, which should be refused after shutdown
+// starts.
+//
+// If no statuses are given, probe is refused if it fails to connect to
+// server. Otherwise, probe is refused if it's answered with one of given
+// statuses, e.g. http.StatusServiceUnavailable.
+//
+// Function should construct request using given Expect instance, but
+// should not send it.
+//
+// Example:
+//
+//	check.Refuse(func(e *httpexpect.Expect) *httpexpect.Request {
+//	    return e.GET("/health")
+//	}, http.StatusServiceUnavailable)
+func (s *ShutdownCheck) Refuse(
+	fn func(e *Expect) *Request, statuses ...int,
+) *ShutdownCheck {
+	s.chain.enter("Refuse()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if fn == nil {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return s
+	}
+
+	s.probe = fn
+	s.refuseStatuses = append([]int(nil), statuses...)
+
+	return s
+}
+
+// WithSettleDelay sets delay between the moment when all in-flight
+// requests are passed to client and the moment when shutdown is
+// triggered. Default is 50ms.
+//
+// Example:
+//
+//	check.WithSettleDelay(200 * time.Millisecond)
+func (s *ShutdownCheck) WithSettleDelay(delay time.Duration) *ShutdownCheck {
+	s.chain.enter("WithSettleDelay()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if delay < 0 {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative delay"),
+			},
+		})
+		return s
+	}
+
+	s.settleDelay = delay
+
+	return s
+}
+
+// WithTimeout sets timeout for every step of the check: starting
+// in-flight requests, refusing probe, and completing in-flight requests
+// and shutdown. Default is 5s.
+//
+// Example:
+//
+//	check.WithTimeout(30 * time.Second)
+func (s *ShutdownCheck) WithTimeout(timeout time.Duration) *ShutdownCheck {
+	s.chain.enter("WithTimeout()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if timeout <= 0 {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive timeout"),
+			},
+		})
+		return s
+	}
+
+	s.timeout = timeout
+
+	return s
+}
+
+// Run performs the check. See ShutdownCheck for details.
+//
+// Example:
+//
+//	httpexpect.GracefulShutdown(e, shutdown).
+//	    InFlight(slowRequest).
+//	    Refuse(newRequest, http.StatusServiceUnavailable).
+//	    Run()
+func (s *ShutdownCheck) Run() *ShutdownCheck {
+	s.chain.enter("Run()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if len(s.inFlight) == 0 {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("expected at least one in-flight request, see InFlight()"),
+			},
+		})
+		return s
+	}
+
+	started := make(chan struct{}, len(s.inFlight))
+	results := make([]*Response, len(s.inFlight))
+
+	var wg sync.WaitGroup
+
+	for n, fn := range s.inFlight {
+		config := s.expect.config
+		config.Client = newShutdownClient(config.Client, started)
+
+		e := s.expect.fork(config)
+
+		wg.Add(1)
+		go func(n int, fn func(e *Expect) *Response) {
+			defer wg.Done()
+			results[n] = fn(e)
+		}(n, fn)
+	}
+
+	inFlightDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(inFlightDone)
+	}()
+
+	if !s.waitStarted(started, inFlightDone) {
+		return s
+	}
+
+	time.Sleep(s.settleDelay)
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		shutdownDone <- s.shutdown()
+	}()
+
+	if s.probe != nil {
+		s.runProbe(inFlightDone, shutdownDone)
+	}
+
+	s.waitInFlight(inFlightDone, results)
+	s.waitShutdown(shutdownDone)
+
+	return s
+}
+
+func (s *ShutdownCheck) waitStarted(
+	started <-chan struct{}, inFlightDone <-chan struct{},
+) bool {
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	for n := 0; n < len(s.inFlight); n++ {
+		// signals are buffered, so check them before completion
+		select {
+		case <-started:
+			continue
+		default:
+		}
+
+		select {
+		case <-started:
+
+		case <-inFlightDone:
+			s.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("in-flight request completed without being sent"),
+				},
+			})
+			return false
+
+		case <-timer.C:
+			s.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("in-flight requests were not sent in %v", s.timeout),
+				},
+			})
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *ShutdownCheck) runProbe(
+	inFlightDone <-chan struct{}, shutdownDone <-chan error,
+) {
+	deadline := time.Now().Add(s.timeout)
+
+	var lastErr error
+
+	for {
+		// check completion before sending probe, so that at least one
+		// probe is sent after shutdown is finished
+		finished := isClosed(inFlightDone) && len(shutdownDone) != 0
+
+		refused, err := s.sendProbe()
+		if refused {
+			return
+		}
+		lastErr = err
+
+		if finished || time.Now().After(deadline) {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if len(s.refuseStatuses) == 0 {
+		s.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("expected: new requests are refused after shutdown started"),
+				lastErr,
+			},
+		})
+		return
+	}
+
+	expected := AssertionList{}
+	for _, status := range s.refuseStatuses {
+		expected = append(expected, statusCodeText(status))
+	}
+
+	var actual interface{}
+	if resp, ok := lastErr.(*shutdownProbeError); ok && resp.status != 0 {
+		actual = statusCodeText(resp.status)
+	}
+
+	s.chain.fail(AssertionFailure{
+		Type:     AssertBelongs,
+		Actual:   &AssertionValue{actual},
+		Expected: &AssertionValue{expected},
+		Errors: []error{
+			errors.New("expected: new requests are refused with given status" +
+				" after shutdown started"),
+			lastErr,
+		},
+	})
+}
+
+// Returns true if probe was refused, or error describing probe result.
+func (s *ShutdownCheck) sendProbe() (bool, error) {
+	e := s.expect.fork(s.expect.config)
+
+	req := s.probe(e)
+	if req == nil {
+		return false, errors.New("probe function returned nil request")
+	}
+
+	req.chain.setSeverity(SeverityLog)
+
+	resp := req.Expect()
+
+	if resp.httpResp == nil {
+		if len(s.refuseStatuses) == 0 {
+			return true, nil
+		}
+		return false, &shutdownProbeError{}
+	}
+
+	for _, status := range s.refuseStatuses {
+		if resp.httpResp.StatusCode == status {
+			return true, nil
+		}
+	}
+
+	return false, &shutdownProbeError{status: resp.httpResp.StatusCode}
+}
+
+func (s *ShutdownCheck) waitInFlight(
+	inFlightDone <-chan struct{}, results []*Response,
+) {
+	select {
+	case <-inFlightDone:
+
+	case <-time.After(s.timeout):
+		s.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("in-flight requests were not completed in %v"+
+					" after shutdown started", s.timeout),
+			},
+		})
+		return
+	}
+
+	for n, resp := range results {
+		if resp == nil || resp.httpResp == nil {
+			s.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("in-flight request #%d was interrupted by shutdown", n+1),
+				},
+			})
+			return
+		}
+
+		if resp.chain.failed() {
+			s.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("in-flight request #%d failed during shutdown", n+1),
+				},
+			})
+			return
+		}
+	}
+}
+
+func (s *ShutdownCheck) waitShutdown(shutdownDone <-chan error) {
+	select {
+	case err := <-shutdownDone:
+		if err != nil {
+			s.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("shutdown failed"),
+					err,
+				},
+			})
+		}
+
+	case <-time.After(s.timeout):
+		s.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("shutdown was not completed in %v", s.timeout),
+			},
+		})
+	}
+}
+
+type shutdownProbeError struct {
+	status int
+}
+
+func (e *shutdownProbeError) Error() string {
+	if e.status == 0 {
+		return "probe request failed to connect"
+	}
+	return fmt.Sprintf("probe request was answered with %s", statusCodeText(e.status))
+}
+
+// Returns client that signals when first request is sent.
+// If client is *http.Client, its transport is wrapped instead, so that
+// redirect settings still can be applied to it.
+func newShutdownClient(client Client, started chan<- struct{}) Client {
+	signal := &shutdownSignal{started: started}
+
+	if httpClient, ok := client.(*http.Client); ok {
+		clientCopy := *httpClient
+
+		transport := clientCopy.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+
+		clientCopy.Transport = &shutdownTransport{
+			RoundTripper: transport,
+			signal:       signal,
+		}
+
+		return &clientCopy
+	}
+
+	return &shutdownClient{
+		Client: client,
+		signal: signal,
+	}
+}
+
+type shutdownSignal struct {
+	started chan<- struct{}
+	once    sync.Once
+}
+
+func (s *shutdownSignal) notify() {
+	s.once.Do(func() {
+		s.started <- struct{}{}
+	})
+}
+
+type shutdownClient struct {
+	Client
+	signal *shutdownSignal
+}
+
+func (c *shutdownClient) Do(req *http.Request) (*http.Response, error) {
+	c.signal.notify()
+	return c.Client.Do(req)
+}
+
+type shutdownTransport struct {
+	http.RoundTripper
+	signal *shutdownSignal
+}
+
+func (t *shutdownTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.signal.notify()
+	return t.RoundTripper.RoundTrip(req)
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,163 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Handler that rejects new requests with 503 while draining.
+type drainingHandler struct {
+	mu       sync.Mutex
+	draining bool
+	active   int
+	ignore   bool
+}
+
+func (h *drainingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	if h.draining && !h.ignore {
+		h.mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	h.active++
+	h.mu.Unlock()
+
+	if r.URL.Path == "/slow" {
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	h.mu.Lock()
+	h.active--
+	h.mu.Unlock()
+}
+
+func (h *drainingHandler) shutdown() error {
+	h.mu.Lock()
+	h.draining = true
+	h.mu.Unlock()
+
+	for {
+		h.mu.Lock()
+		active := h.active
+		h.mu.Unlock()
+
+		if active == 0 {
+			return nil
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestShutdownCheck(t *testing.T) {
+	inFlight := func(e *Expect) *Response {
+		return e.GET("/slow").Expect().Status(http.StatusOK)
+	}
+
+	probe := func(e *Expect) *Request {
+		return e.GET("/fast")
+	}
+
+	t.Run("graceful", func(t *testing.T) {
+		handler := &drainingHandler{}
+		e, reporter := newMockExpect(t, handler)
+
+		check := GracefulShutdown(e, handler.shutdown).
+			InFlight(inFlight).
+			InFlight(inFlight).
+			Refuse(probe, http.StatusServiceUnavailable).
+			Run()
+
+		check.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("not refused", func(t *testing.T) {
+		handler := &drainingHandler{ignore: true}
+		e, reporter := newMockExpect(t, handler)
+
+		check := GracefulShutdown(e, handler.shutdown).
+			InFlight(inFlight).
+			Refuse(probe, http.StatusServiceUnavailable).
+			WithTimeout(time.Second).
+			Run()
+
+		check.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("shutdown error", func(t *testing.T) {
+		handler := &drainingHandler{}
+		e, _ := newMockExpect(t, handler)
+
+		check := GracefulShutdown(e, func() error {
+			return errors.New("test")
+		}).
+			InFlight(inFlight).
+			Run()
+
+		check.chain.assertFailed(t)
+	})
+
+	t.Run("in-flight failed", func(t *testing.T) {
+		handler := &drainingHandler{}
+		e, _ := newMockExpect(t, handler)
+
+		check := GracefulShutdown(e, handler.shutdown).
+			InFlight(func(e *Expect) *Response {
+				return e.GET("/slow").Expect().Status(http.StatusNotFound)
+			}).
+			Run()
+
+		check.chain.assertFailed(t)
+	})
+
+	t.Run("not sent", func(t *testing.T) {
+		handler := &drainingHandler{}
+		e, _ := newMockExpect(t, handler)
+
+		check := GracefulShutdown(e, handler.shutdown).
+			InFlight(func(e *Expect) *Response {
+				return nil
+			}).
+			Run()
+
+		check.chain.assertFailed(t)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		handler := &drainingHandler{}
+
+		e, _ := newMockExpect(t, handler)
+		GracefulShutdown(e, nil).chain.assertFailed(t)
+
+		e, _ = newMockExpect(t, handler)
+		GracefulShutdown(e, handler.shutdown).Run().chain.assertFailed(t)
+
+		e, _ = newMockExpect(t, handler)
+		GracefulShutdown(e, handler.shutdown).InFlight(nil).chain.assertFailed(t)
+
+		e, _ = newMockExpect(t, handler)
+		GracefulShutdown(e, handler.shutdown).Refuse(nil).chain.assertFailed(t)
+
+		e, _ = newMockExpect(t, handler)
+		GracefulShutdown(e, handler.shutdown).
+			WithSettleDelay(-1).chain.assertFailed(t)
+
+		e, _ = newMockExpect(t, handler)
+		GracefulShutdown(e, handler.shutdown).
+			WithTimeout(0).chain.assertFailed(t)
+
+		assert.Panics(t, func() {
+			GracefulShutdown(nil, handler.shutdown)
+		})
+	})
+}
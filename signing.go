@@ -0,0 +1,362 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AWSCredentials defines credentials used to sign requests with AWS
+// Signature Version 4, see Request.WithAWSSigV4.
+type AWSCredentials struct {
+	// Access key ID.
+	AccessKeyID string
+
+	// Secret access key.
+	SecretAccessKey string
+
+	// Session token of temporary credentials.
+	// May be empty.
+	SessionToken string
+}
+
+// HMACCanonicalizer builds string to sign from final request and its body,
+// see Request.WithHMACSignature.
+type HMACCanonicalizer func(req *http.Request, body []byte) string
+
+// DefaultHMACCanonicalizer is used by WithHMACSignature if canonicalizer
+// is nil.
+//
+// It returns request method, request URI (path and query), and hex-encoded
+// SHA-256 of request body, separated by newlines.
+func DefaultHMACCanonicalizer(req *http.Request, body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return req.Method + "\n" +
+		req.URL.RequestURI() + "\n" +
+		hex.EncodeToString(sum[:])
+}
+
+// Function that signs final request with given body.
+type requestSigner func(req *http.Request, body []byte, now time.Time)
+
+// WithAWSSigV4 signs request with AWS Signature Version 4, using given
+// credentials, region, and service name, e.g. "execute-api" or "s3".
+//
+// Signature is computed when request is sent, over the final request,
+// i.e. after all With* calls, transforms, and Config.RequestTransformer.
+// Request gets X-Amz-Date and Authorization headers, X-Amz-Security-Token
+// header if credentials have session token, and X-Amz-Content-Sha256
+// header for "s3" service.
+//
+// Signed headers are Host, Content-Type, Content-MD5, and all X-Amz-*
+// headers.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "https://abc.execute-api.us-east-1.amazonaws.com/prod/users")
+//	req.WithAWSSigV4(httpexpect.AWSCredentials{
+//	    AccessKeyID:     "AKIDEXAMPLE",
+//	    SecretAccessKey: "secret",
+//	}, "us-east-1", "execute-api")
+func (r *Request) WithAWSSigV4(creds AWSCredentials, region, service string) *Request {
+	r.chain.enter("WithAWSSigV4()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty AWS access key ID or secret access key"),
+			},
+		})
+		return r
+	}
+
+	if region == "" || service == "" {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty AWS region or service"),
+			},
+		})
+		return r
+	}
+
+	r.signers = append(r.signers,
+		func(req *http.Request, body []byte, now time.Time) {
+			signAWSv4(req, body, now, creds, region, service)
+		})
+
+	return r
+}
+
+// WithHMACSignature signs request with HMAC-SHA256, using given key, and
+// sets given header to hex-encoded signature.
+//
+// String to sign is built by canonicalizer from the final request, i.e.
+// after all With* calls, transforms, and Config.RequestTransformer, and
+// its body, as it is sent. If canonicalizer is nil, DefaultHMACCanonicalizer
+// is used.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "http://example.com/webhooks")
+//	req.WithJSON(event)
+//	req.WithHMACSignature("X-Signature", secret,
+//	    func(req *http.Request, body []byte) string {
+//	        return req.Header.Get("X-Timestamp") + "." + string(body)
+//	    })
+func (r *Request) WithHMACSignature(
+	header string, key []byte, canonicalizer HMACCanonicalizer,
+) *Request {
+	r.chain.enter("WithHMACSignature()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if header == "" {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty header name"),
+			},
+		})
+		return r
+	}
+
+	if canonicalizer == nil {
+		canonicalizer = DefaultHMACCanonicalizer
+	}
+
+	r.signers = append(r.signers,
+		func(req *http.Request, body []byte, _ time.Time) {
+			req.Header.Set(header,
+				hex.EncodeToString(hmacSHA256(key, canonicalizer(req, body))))
+		})
+
+	return r
+}
+
+func (r *Request) signRequest() bool {
+	var body []byte
+
+	if r.httpReq.Body != nil && r.httpReq.Body != http.NoBody {
+		content, err := ioutil.ReadAll(r.httpReq.Body)
+		if err != nil {
+			r.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to read request body"),
+					err,
+				},
+			})
+			return false
+		}
+
+		r.httpReq.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+		body = content
+	}
+
+	now := r.nowFn()
+
+	for _, signer := range r.signers {
+		signer(r.httpReq, body, now)
+	}
+
+	return true
+}
+
+const (
+	awsSigningAlgorithm = "AWS4-HMAC-SHA256"
+	awsTimeFormat       = "20060102T150405Z"
+	awsDateFormat       = "20060102"
+)
+
+func signAWSv4(
+	req *http.Request, body []byte, now time.Time,
+	creds AWSCredentials, region, service string,
+) {
+	now = now.UTC()
+
+	amzDate := now.Format(awsTimeFormat)
+	scope := strings.Join([]string{
+		now.Format(awsDateFormat), region, service, "aws4_request",
+	}, "/")
+
+	payloadSum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(payloadSum[:])
+
+	req.Header.Del("Authorization")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+	if service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+
+	canonicalHeaders, signedHeaders := awsCanonicalHeaders(req)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		awsCanonicalPath(req.URL, service),
+		awsCanonicalQuery(req.URL),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	requestSum := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		awsSigningAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(requestSum[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), now.Format(awsDateFormat))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization",
+		awsSigningAlgorithm+" Credential="+creds.AccessKeyID+"/"+scope+
+			", SignedHeaders="+signedHeaders+
+			", Signature="+signature)
+}
+
+func awsCanonicalPath(u *url.URL, service string) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+
+	// S3 expects path to be encoded once, other services expect
+	// already encoded path to be encoded again
+	if service == "s3" {
+		return path
+	}
+
+	return awsEscape(path, false)
+}
+
+func awsCanonicalQuery(u *url.URL) string {
+	query := u.Query()
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+
+		for _, v := range values {
+			pairs = append(pairs, awsEscape(k, true)+"="+awsEscape(v, true))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func awsCanonicalHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{
+		"host": host,
+	}
+
+	for name, values := range req.Header {
+		lname := strings.ToLower(name)
+
+		if lname != "content-type" && lname != "content-md5" &&
+			!strings.HasPrefix(lname, "x-amz-") {
+			continue
+		}
+
+		trimmed := make([]string, 0, len(values))
+		for _, v := range values {
+			trimmed = append(trimmed, strings.Join(strings.Fields(v), " "))
+		}
+
+		headers[lname] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+
+	for _, name := range names {
+		canonical.WriteString(name)
+		canonical.WriteString(":")
+		canonical.WriteString(headers[name])
+		canonical.WriteString("\n")
+	}
+
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// Percent-encode all bytes except unreserved characters, as defined
+// by AWS. Slash is encoded only if encodeSlash is true.
+func awsEscape(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var buf strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			buf.WriteByte(c)
+
+		case c == '/' && !encodeSlash:
+			buf.WriteByte(c)
+
+		default:
+			buf.WriteByte('%')
+			buf.WriteByte(hexDigits[c>>4])
+			buf.WriteByte(hexDigits[c&15])
+		}
+	}
+
+	return buf.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,258 @@
+package httpexpect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAWSCredentials = AWSCredentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+func newSigningRequest(
+	t *testing.T, client *mockClient, method, baseURL, path string,
+) *Request {
+	req := NewRequestC(Config{
+		BaseURL:  baseURL,
+		Client:   client,
+		Reporter: newMockReporter(t),
+	}, method, path)
+
+	req.nowFn = func() time.Time {
+		return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	}
+
+	return req
+}
+
+func TestSigningFailed(t *testing.T) {
+	reporter := newMockReporter(t)
+	chain := newChainWithDefaults("test", reporter)
+	config := newMockConfig(reporter)
+
+	chain.fail(mockFailure())
+
+	req := newRequest(chain, config, "GET", "")
+
+	req.WithAWSSigV4(testAWSCredentials, "us-east-1", "service")
+	req.WithHMACSignature("X-Signature", []byte("key"), nil)
+
+	req.chain.assertFailed(t)
+	assert.Empty(t, req.signers)
+}
+
+func TestSigningUsage(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(req *Request)
+	}{
+		{
+			name: "empty access key",
+			fn: func(req *Request) {
+				req.WithAWSSigV4(AWSCredentials{SecretAccessKey: "secret"},
+					"us-east-1", "service")
+			},
+		},
+		{
+			name: "empty region",
+			fn: func(req *Request) {
+				req.WithAWSSigV4(testAWSCredentials, "", "service")
+			},
+		},
+		{
+			name: "empty header",
+			fn: func(req *Request) {
+				req.WithHMACSignature("", []byte("key"), nil)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			req := newSigningRequest(t, client, "GET", "http://example.com", "/")
+			tc.fn(req)
+
+			req.chain.assertFailed(t)
+
+			req.Expect().chain.assertFailed(t)
+			assert.Nil(t, client.req)
+		})
+	}
+}
+
+func TestSigningAWSSigV4(t *testing.T) {
+	t.Run("get vanilla", func(t *testing.T) {
+		client := &mockClient{}
+
+		newSigningRequest(t, client, "GET", "https://example.amazonaws.com", "/").
+			WithAWSSigV4(testAWSCredentials, "us-east-1", "service").
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+
+		assert.Equal(t, "20150830T123600Z", client.req.Header.Get("X-Amz-Date"))
+		assert.Equal(t,
+			"AWS4-HMAC-SHA256 "+
+				"Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+				"SignedHeaders=host;x-amz-date, "+
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+			client.req.Header.Get("Authorization"))
+	})
+
+	t.Run("query and content type", func(t *testing.T) {
+		client := &mockClient{}
+
+		newSigningRequest(t, client, "GET", "https://iam.amazonaws.com", "/").
+			WithQuery("Version", "2010-05-08").
+			WithQuery("Action", "ListUsers").
+			WithHeader("Content-Type",
+				"application/x-www-form-urlencoded; charset=utf-8").
+			WithAWSSigV4(testAWSCredentials, "us-east-1", "iam").
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+
+		assert.Equal(t,
+			"AWS4-HMAC-SHA256 "+
+				"Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+				"SignedHeaders=content-type;host;x-amz-date, "+
+				"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+			client.req.Header.Get("Authorization"))
+	})
+
+	t.Run("s3 and session token", func(t *testing.T) {
+		client := &mockClient{}
+
+		creds := testAWSCredentials
+		creds.SessionToken = "token"
+
+		resp := newSigningRequest(t, client,
+			"PUT", "https://bucket.s3.amazonaws.com", "/a b").
+			WithText("hello").
+			WithAWSSigV4(creds, "us-east-1", "s3").
+			Expect()
+
+		resp.chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+
+		sum := sha256.Sum256([]byte("hello"))
+
+		assert.Equal(t, hex.EncodeToString(sum[:]),
+			client.req.Header.Get("X-Amz-Content-Sha256"))
+		assert.Equal(t, "token", client.req.Header.Get("X-Amz-Security-Token"))
+
+		assert.Contains(t, client.req.Header.Get("Authorization"),
+			"SignedHeaders=content-type;host;x-amz-content-sha256;"+
+				"x-amz-date;x-amz-security-token, ")
+
+		// mock client echoes request body
+		resp.Body().Equal("hello")
+	})
+}
+
+func TestSigningAWSCanonical(t *testing.T) {
+	req, err := http.NewRequest("GET",
+		"https://example.com/a%20b/c:d?b=2&a=x+y&a=%2F", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/a%2520b/c%3Ad", awsCanonicalPath(req.URL, "service"))
+	assert.Equal(t, "/a%20b/c:d", awsCanonicalPath(req.URL, "s3"))
+	assert.Equal(t, "a=%2F&a=x%20y&b=2", awsCanonicalQuery(req.URL))
+
+	req.Header.Set("X-Amz-Meta", "  a   b  ")
+	req.Header.Add("X-Amz-Meta", "c")
+	req.Header.Set("User-Agent", "test")
+
+	headers, signed := awsCanonicalHeaders(req)
+
+	assert.Equal(t, "host:example.com\nx-amz-meta:a b,c\n", headers)
+	assert.Equal(t, "host;x-amz-meta", signed)
+}
+
+func TestSigningHMAC(t *testing.T) {
+	key := []byte("secret")
+
+	expected := func(data string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(data))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	t.Run("default canonicalizer", func(t *testing.T) {
+		client := &mockClient{}
+
+		newSigningRequest(t, client, "POST", "http://example.com", "/hooks").
+			WithQuery("a", 1).
+			WithHMACSignature("X-Signature", key, nil).
+			WithJSON(map[string]interface{}{"event": "push"}).
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+
+		sum := sha256.Sum256([]byte(`{"event":"push"}`))
+
+		assert.Equal(t,
+			expected("POST\n/hooks?a=1\n"+hex.EncodeToString(sum[:])),
+			client.req.Header.Get("X-Signature"))
+	})
+
+	t.Run("custom canonicalizer", func(t *testing.T) {
+		client := &mockClient{}
+
+		resp := newSigningRequest(t, client, "POST", "http://example.com", "/hooks").
+			WithHeader("X-Timestamp", "123").
+			WithChunked(strings.NewReader("payload")).
+			WithHMACSignature("X-Signature", key,
+				func(req *http.Request, body []byte) string {
+					return req.Header.Get("X-Timestamp") + "." + string(body)
+				}).
+			Expect()
+
+		resp.chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+
+		assert.Equal(t, expected("123.payload"),
+			client.req.Header.Get("X-Signature"))
+
+		resp.Body().Equal("payload")
+	})
+
+	t.Run("after transformers", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			RequestTransformer: func(r *http.Request) {
+				r.Header.Set("X-Timestamp", "456")
+			},
+		}, "GET", "/")
+
+		req.WithHMACSignature("X-Signature", key,
+			func(req *http.Request, body []byte) string {
+				return req.Header.Get("X-Timestamp")
+			}).
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+
+		assert.Equal(t, expected("456"), client.req.Header.Get("X-Signature"))
+	})
+}
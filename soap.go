@@ -0,0 +1,457 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// SOAP namespaces.
+const (
+	soap11EnvelopeNS = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12EnvelopeNS = "http://www.w3.org/2003/05/soap-envelope"
+	soap11BindingNS  = "http://schemas.xmlsoap.org/wsdl/soap/"
+	soap12BindingNS  = "http://schemas.xmlsoap.org/wsdl/soap12/"
+)
+
+// SOAPVersion defines SOAP protocol version.
+type SOAPVersion int
+
+const (
+	// SOAP 1.1, uses "text/xml" content type and SOAPAction header.
+	SOAP11 SOAPVersion = iota
+
+	// SOAP 1.2, uses "application/soap+xml" content type with action parameter.
+	SOAP12
+)
+
+// WSDL holds operations parsed from WSDL 1.1 document.
+//
+// Use LoadWSDL or ParseWSDL to construct it, then pass its operations
+// to Expect.SOAP or Request.WithSOAP.
+type WSDL struct {
+	// Target namespace of WSDL document.
+	TargetNamespace string
+
+	operations []*WSDLOperation
+}
+
+// WSDLOperation describes single SOAP operation.
+type WSDLOperation struct {
+	// Operation name.
+	Name string
+
+	// Endpoint URL from service port address.
+	Endpoint string
+
+	// SOAPAction from binding.
+	SOAPAction string
+
+	// SOAP version of binding.
+	Version SOAPVersion
+
+	// Name and namespace of element wrapping operation input.
+	InputElement   string
+	InputNamespace string
+}
+
+type wsdlDefinitions struct {
+	TargetNamespace string         `xml:"targetNamespace,attr"`
+	Attrs           []xml.Attr     `xml:",any,attr"`
+	Messages        []wsdlMessage  `xml:"message"`
+	PortTypes       []wsdlPortType `xml:"portType"`
+	Bindings        []wsdlBinding  `xml:"binding"`
+	Services        []wsdlService  `xml:"service"`
+}
+
+type wsdlMessage struct {
+	Name  string     `xml:"name,attr"`
+	Parts []wsdlPart `xml:"part"`
+}
+
+type wsdlPart struct {
+	Name    string `xml:"name,attr"`
+	Element string `xml:"element,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+type wsdlPortType struct {
+	Name       string              `xml:"name,attr"`
+	Operations []wsdlPortOperation `xml:"operation"`
+}
+
+type wsdlPortOperation struct {
+	Name  string `xml:"name,attr"`
+	Input struct {
+		Message string `xml:"message,attr"`
+	} `xml:"input"`
+}
+
+type wsdlBinding struct {
+	Name       string                 `xml:"name,attr"`
+	Type       string                 `xml:"type,attr"`
+	Operations []wsdlBindingOperation `xml:"operation"`
+}
+
+type wsdlBindingOperation struct {
+	Name          string `xml:"name,attr"`
+	SOAPOperation struct {
+		XMLName    xml.Name
+		SOAPAction string `xml:"soapAction,attr"`
+	} `xml:"operation"`
+}
+
+type wsdlService struct {
+	Name  string     `xml:"name,attr"`
+	Ports []wsdlPort `xml:"port"`
+}
+
+type wsdlPort struct {
+	Name    string `xml:"name,attr"`
+	Binding string `xml:"binding,attr"`
+	Address struct {
+		XMLName  xml.Name
+		Location string `xml:"location,attr"`
+	} `xml:"address"`
+}
+
+// LoadWSDL reads and parses WSDL document from given file.
+func LoadWSDL(path string) (*WSDL, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseWSDL(data)
+}
+
+// ParseWSDL parses WSDL 1.1 document.
+//
+// Only SOAP bindings are taken into account. Every operation of every
+// SOAP port of every service becomes a WSDLOperation.
+func ParseWSDL(data []byte) (*WSDL, error) {
+	var defs wsdlDefinitions
+
+	if err := xml.Unmarshal(data, &defs); err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string]string{}
+	for _, attr := range defs.Attrs {
+		if attr.Name.Space == "xmlns" {
+			namespaces[attr.Name.Local] = attr.Value
+		}
+	}
+
+	resolve := func(qname string) (space, local string) {
+		if idx := strings.Index(qname, ":"); idx >= 0 {
+			if ns, ok := namespaces[qname[:idx]]; ok {
+				return ns, qname[idx+1:]
+			}
+			return defs.TargetNamespace, qname[idx+1:]
+		}
+		return defs.TargetNamespace, qname
+	}
+
+	wsdl := &WSDL{
+		TargetNamespace: defs.TargetNamespace,
+	}
+
+	for _, service := range defs.Services {
+		for _, port := range service.Ports {
+			var version SOAPVersion
+
+			switch port.Address.XMLName.Space {
+			case soap11BindingNS:
+				version = SOAP11
+			case soap12BindingNS:
+				version = SOAP12
+			default:
+				continue
+			}
+
+			_, bindingName := resolve(port.Binding)
+			binding := defs.findBinding(bindingName)
+			if binding == nil {
+				return nil, fmt.Errorf("wsdl binding %q not found", port.Binding)
+			}
+
+			_, portTypeName := resolve(binding.Type)
+			portType := defs.findPortType(portTypeName)
+			if portType == nil {
+				return nil, fmt.Errorf("wsdl port type %q not found", binding.Type)
+			}
+
+			for _, bop := range binding.Operations {
+				op := &WSDLOperation{
+					Name:           bop.Name,
+					Endpoint:       port.Address.Location,
+					SOAPAction:     bop.SOAPOperation.SOAPAction,
+					Version:        version,
+					InputElement:   bop.Name,
+					InputNamespace: defs.TargetNamespace,
+				}
+
+				if pop := portType.findOperation(bop.Name); pop != nil {
+					_, msgName := resolve(pop.Input.Message)
+					if msg := defs.findMessage(msgName); msg != nil {
+						for _, part := range msg.Parts {
+							if part.Element != "" {
+								op.InputNamespace, op.InputElement = resolve(part.Element)
+								break
+							}
+						}
+					}
+				}
+
+				wsdl.operations = append(wsdl.operations, op)
+			}
+		}
+	}
+
+	if len(wsdl.operations) == 0 {
+		return nil, errors.New("wsdl document has no soap operations")
+	}
+
+	return wsdl, nil
+}
+
+func (d *wsdlDefinitions) findBinding(name string) *wsdlBinding {
+	for n := range d.Bindings {
+		if d.Bindings[n].Name == name {
+			return &d.Bindings[n]
+		}
+	}
+	return nil
+}
+
+func (d *wsdlDefinitions) findPortType(name string) *wsdlPortType {
+	for n := range d.PortTypes {
+		if d.PortTypes[n].Name == name {
+			return &d.PortTypes[n]
+		}
+	}
+	return nil
+}
+
+func (d *wsdlDefinitions) findMessage(name string) *wsdlMessage {
+	for n := range d.Messages {
+		if d.Messages[n].Name == name {
+			return &d.Messages[n]
+		}
+	}
+	return nil
+}
+
+func (p *wsdlPortType) findOperation(name string) *wsdlPortOperation {
+	for n := range p.Operations {
+		if p.Operations[n].Name == name {
+			return &p.Operations[n]
+		}
+	}
+	return nil
+}
+
+// Operations returns names of all operations, in document order.
+// If the same operation is available via several ports (e.g. both
+// SOAP 1.1 and 1.2), it's listed once.
+func (w *WSDL) Operations() []string {
+	var names []string
+
+	seen := map[string]bool{}
+	for _, op := range w.operations {
+		if !seen[op.Name] {
+			seen[op.Name] = true
+			names = append(names, op.Name)
+		}
+	}
+
+	return names
+}
+
+// Operation returns operation with given name, or nil if there is no such
+// operation. If version is given, only operations of this SOAP version
+// are considered.
+func (w *WSDL) Operation(name string, version ...SOAPVersion) *WSDLOperation {
+	for _, op := range w.operations {
+		if op.Name != name {
+			continue
+		}
+		if len(version) != 0 && op.Version != version[0] {
+			continue
+		}
+		return op
+	}
+	return nil
+}
+
+// Envelope returns SOAP envelope for operation.
+//
+// body defines contents of operation input element. It may be:
+//   - string or []byte - raw XML inserted as is
+//   - map[string]interface{} - every key becomes a child element; nested
+//     maps become nested elements, other values are formatted using
+//     fmt.Sprint and escaped
+//   - nil - input element is left empty
+//
+// Example:
+//
+//	op.Envelope(map[string]interface{}{"City": "Paris"})
+//	// <soap:Envelope xmlns:soap="...">
+//	//   <soap:Body>
+//	//     <tns:GetWeather xmlns:tns="..."><City>Paris</City></tns:GetWeather>
+//	//   </soap:Body>
+//	// </soap:Envelope>
+func (op *WSDLOperation) Envelope(body interface{}) ([]byte, error) {
+	var content bytes.Buffer
+
+	switch b := body.(type) {
+	case nil:
+	case string:
+		content.WriteString(b)
+	case []byte:
+		content.Write(b)
+	case map[string]interface{}:
+		writeSOAPFields(&content, b)
+	default:
+		return nil, fmt.Errorf("unsupported soap body type %T", body)
+	}
+
+	envNS := soap11EnvelopeNS
+	if op.Version == SOAP12 {
+		envNS = soap12EnvelopeNS
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, `<soap:Envelope xmlns:soap="%s">`, envNS)
+	buf.WriteString(`<soap:Body>`)
+	fmt.Fprintf(&buf, `<tns:%s xmlns:tns="%s">`, op.InputElement, op.InputNamespace)
+	buf.Write(content.Bytes())
+	fmt.Fprintf(&buf, `</tns:%s>`, op.InputElement)
+	buf.WriteString(`</soap:Body>`)
+	buf.WriteString(`</soap:Envelope>`)
+
+	return buf.Bytes(), nil
+}
+
+func writeSOAPFields(buf *bytes.Buffer, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(buf, "<%s>", k)
+		if nested, ok := fields[k].(map[string]interface{}); ok {
+			writeSOAPFields(buf, nested)
+		} else {
+			_ = xml.EscapeText(buf, []byte(fmt.Sprint(fields[k])))
+		}
+		fmt.Fprintf(buf, "</%s>", k)
+	}
+}
+
+// SOAP returns a new Request instance for given SOAP operation.
+// It's a shorthand for e.POST("").WithSOAP(op, body).
+//
+// Example:
+//
+//	wsdl, _ := httpexpect.LoadWSDL("weather.wsdl")
+//
+//	e.SOAP(wsdl.Operation("GetWeather"), map[string]interface{}{
+//	    "City": "Paris",
+//	}).
+//	    Expect().
+//	    Status(http.StatusOK).
+//	    XML(httpexpect.ContentOpts{MediaType: "text/xml"})
+func (e *Expect) SOAP(op *WSDLOperation, body interface{}) *Request {
+	return e.Request(http.MethodPost, "").WithSOAP(op, body)
+}
+
+// WithSOAP sets request body to SOAP envelope for given operation and sets
+// headers required by operation SOAP version.
+//
+// If Config.BaseURL is empty, request URL is set to operation endpoint.
+// Otherwise, BaseURL is kept, and only path of the endpoint is used.
+//
+// See WSDLOperation.Envelope for supported body types.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "")
+//	req.WithSOAP(wsdl.Operation("GetWeather"), "<City>Paris</City>")
+func (r *Request) WithSOAP(op *WSDLOperation, body interface{}) *Request {
+	r.chain.enter("WithSOAP()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	if op == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil operation argument"),
+			},
+		})
+		return r
+	}
+
+	envelope, err := op.Envelope(body)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid soap body argument"),
+				err,
+			},
+		})
+		return r
+	}
+
+	if op.Endpoint != "" {
+		if r.config.BaseURL == "" {
+			u, err := r.httpReq.URL.Parse(op.Endpoint)
+			if err != nil {
+				r.chain.fail(AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{op.Endpoint},
+					Errors: []error{
+						errors.New("invalid soap endpoint"),
+						err,
+					},
+				})
+				return r
+			}
+			r.httpReq.URL = u
+		} else if u, err := r.httpReq.URL.Parse(op.Endpoint); err == nil {
+			r.path = concatPaths(u.Path, r.path)
+		}
+	}
+
+	switch op.Version {
+	case SOAP12:
+		contentType := "application/soap+xml; charset=utf-8"
+		if op.SOAPAction != "" {
+			contentType += fmt.Sprintf("; action=%q", op.SOAPAction)
+		}
+		r.setType("WithSOAP()", contentType, false)
+
+	default:
+		r.setType("WithSOAP()", "text/xml; charset=utf-8", false)
+		r.httpReq.Header.Set("SOAPAction", fmt.Sprintf("%q", op.SOAPAction))
+	}
+
+	r.setBody("WithSOAP()", bytes.NewReader(envelope), len(envelope), false)
+
+	return r
+}
@@ -0,0 +1,244 @@
+package httpexpect
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWSDL = `<?xml version="1.0" encoding="UTF-8"?>
+<definitions xmlns="http://schemas.xmlsoap.org/wsdl/"
+    xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/"
+    xmlns:soap12="http://schemas.xmlsoap.org/wsdl/soap12/"
+    xmlns:tns="http://example.com/weather"
+    xmlns:xsd1="http://example.com/weather/schema"
+    targetNamespace="http://example.com/weather">
+
+  <message name="GetWeatherInput">
+    <part name="body" element="xsd1:GetWeatherRequest"/>
+  </message>
+  <message name="PingInput"/>
+
+  <portType name="WeatherPortType">
+    <operation name="GetWeather">
+      <input message="tns:GetWeatherInput"/>
+    </operation>
+    <operation name="Ping">
+      <input message="tns:PingInput"/>
+    </operation>
+  </portType>
+
+  <binding name="WeatherSoap" type="tns:WeatherPortType">
+    <soap:binding style="document" transport="http://schemas.xmlsoap.org/soap/http"/>
+    <operation name="GetWeather">
+      <soap:operation soapAction="http://example.com/GetWeather"/>
+    </operation>
+    <operation name="Ping">
+      <soap:operation soapAction="http://example.com/Ping"/>
+    </operation>
+  </binding>
+
+  <binding name="WeatherSoap12" type="tns:WeatherPortType">
+    <soap12:binding style="document" transport="http://schemas.xmlsoap.org/soap/http"/>
+    <operation name="GetWeather">
+      <soap12:operation soapAction="http://example.com/GetWeather"/>
+    </operation>
+  </binding>
+
+  <service name="WeatherService">
+    <port name="WeatherPort" binding="tns:WeatherSoap">
+      <soap:address location="http://example.com/soap/weather"/>
+    </port>
+    <port name="WeatherPort12" binding="tns:WeatherSoap12">
+      <soap12:address location="http://example.com/soap12/weather"/>
+    </port>
+  </service>
+</definitions>`
+
+func TestWSDLParse(t *testing.T) {
+	wsdl, err := ParseWSDL([]byte(testWSDL))
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://example.com/weather", wsdl.TargetNamespace)
+	assert.Equal(t, []string{"GetWeather", "Ping"}, wsdl.Operations())
+
+	op := wsdl.Operation("GetWeather")
+	require.NotNil(t, op)
+	assert.Equal(t, &WSDLOperation{
+		Name:           "GetWeather",
+		Endpoint:       "http://example.com/soap/weather",
+		SOAPAction:     "http://example.com/GetWeather",
+		Version:        SOAP11,
+		InputElement:   "GetWeatherRequest",
+		InputNamespace: "http://example.com/weather/schema",
+	}, op)
+
+	op12 := wsdl.Operation("GetWeather", SOAP12)
+	require.NotNil(t, op12)
+	assert.Equal(t, SOAP12, op12.Version)
+	assert.Equal(t, "http://example.com/soap12/weather", op12.Endpoint)
+
+	ping := wsdl.Operation("Ping")
+	require.NotNil(t, ping)
+	assert.Equal(t, "Ping", ping.InputElement)
+	assert.Equal(t, "http://example.com/weather", ping.InputNamespace)
+
+	assert.Nil(t, wsdl.Operation("Missing"))
+	assert.Nil(t, wsdl.Operation("Ping", SOAP12))
+}
+
+func TestWSDLParseErrors(t *testing.T) {
+	_, err := ParseWSDL([]byte("<bad"))
+	assert.Error(t, err)
+
+	_, err = ParseWSDL([]byte(`<definitions/>`))
+	assert.Error(t, err)
+
+	_, err = ParseWSDL([]byte(`<definitions
+	    xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/">
+	  <service name="s">
+	    <port name="p" binding="missing">
+	      <soap:address location="http://example.com"/>
+	    </port>
+	  </service>
+	</definitions>`))
+	assert.Error(t, err)
+
+	_, err = LoadWSDL("missing.wsdl")
+	assert.Error(t, err)
+}
+
+func TestWSDLLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "weather.wsdl")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testWSDL), 0644))
+
+	wsdl, err := LoadWSDL(path)
+	require.NoError(t, err)
+	assert.NotNil(t, wsdl.Operation("GetWeather"))
+}
+
+func TestWSDLEnvelope(t *testing.T) {
+	op := &WSDLOperation{
+		Name:           "GetWeather",
+		InputElement:   "GetWeatherRequest",
+		InputNamespace: "http://example.com/ns",
+	}
+
+	b, err := op.Envelope(map[string]interface{}{
+		"City": "Paris & Co",
+		"Options": map[string]interface{}{
+			"Units": "metric",
+			"Days":  3,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">`+
+			`<soap:Body>`+
+			`<tns:GetWeatherRequest xmlns:tns="http://example.com/ns">`+
+			`<City>Paris &amp; Co</City>`+
+			`<Options><Days>3</Days><Units>metric</Units></Options>`+
+			`</tns:GetWeatherRequest>`+
+			`</soap:Body>`+
+			`</soap:Envelope>`,
+		string(b))
+
+	b, err = op.Envelope("<City>Paris</City>")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "<City>Paris</City>")
+
+	b, err = op.Envelope([]byte("<City>Paris</City>"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "<City>Paris</City>")
+
+	b, err = op.Envelope(nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "></tns:GetWeatherRequest>")
+
+	op.Version = SOAP12
+	b, err = op.Envelope(nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), soap12EnvelopeNS)
+
+	_, err = op.Envelope(123)
+	assert.Error(t, err)
+}
+
+func TestWSDLRequest(t *testing.T) {
+	wsdl, err := ParseWSDL([]byte(testWSDL))
+	require.NoError(t, err)
+
+	t.Run("soap11", func(t *testing.T) {
+		client := &mockClient{}
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client:   client,
+			Reporter: reporter,
+		})
+
+		e.SOAP(wsdl.Operation("GetWeather"), "<City>Paris</City>").
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+		assert.Equal(t, "POST", client.req.Method)
+		assert.Equal(t, "http://example.com/soap/weather", client.req.URL.String())
+		assert.Equal(t, "text/xml; charset=utf-8",
+			client.req.Header.Get("Content-Type"))
+		assert.Equal(t, `"http://example.com/GetWeather"`,
+			client.req.Header.Get("SOAPAction"))
+	})
+
+	t.Run("soap12_base_url", func(t *testing.T) {
+		client := &mockClient{}
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://localhost:8080",
+			Client:   client,
+			Reporter: reporter,
+		})
+
+		e.SOAP(wsdl.Operation("GetWeather", SOAP12), nil).
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.NotNil(t, client.req)
+		assert.Equal(t, "http://localhost:8080/soap12/weather",
+			client.req.URL.String())
+		assert.Equal(t,
+			`application/soap+xml; charset=utf-8; action="http://example.com/GetWeather"`,
+			client.req.Header.Get("Content-Type"))
+		assert.Equal(t, "", client.req.Header.Get("SOAPAction"))
+	})
+
+	t.Run("nil_operation", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, http.MethodPost, "")
+		req.WithSOAP(nil, "")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("bad_body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		config := newMockConfig(reporter)
+
+		req := NewRequestC(config, http.MethodPost, "")
+		req.WithSOAP(wsdl.Operation("Ping"), 123)
+		req.chain.assertFailed(t)
+	})
+}
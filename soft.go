@@ -0,0 +1,142 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SoftAssertions runs fn in soft assertion mode and reports all failures
+// made inside fn together, as a single failure, after fn returns.
+//
+// fn receives a copy of Expect. All requests, responses, and values
+// created from this copy are in soft mode too. In soft mode:
+//
+//   - failures are not reported immediately, so even a fatal reporter,
+//     like RequireReporter, doesn't stop the test on first failure;
+//   - failed assertion doesn't prevent further assertions on the same
+//     value, so that all checks of a response get evaluated; values
+//     derived from the failed one (e.g. Object.Value of a missing key)
+//     remain failed, to avoid reporting meaningless follow-up failures.
+//
+// Failures with severity other than SeverityError (e.g. those made after
+// Response.Warn) are not collected and are reported immediately.
+//
+// Example:
+//
+//	e.SoftAssertions(func(e *httpexpect.Expect) {
+//	    obj := e.GET("/users/john").
+//	        Expect().
+//	        Status(http.StatusOK).
+//	        JSON().Object()
+//
+//	    obj.ValueEqual("name", "john").
+//	        ValueEqual("role", "admin").
+//	        ValueEqual("active", true)
+//	})
+func (e *Expect) SoftAssertions(fn func(e *Expect)) *Expect {
+	e.chain.enter("SoftAssertions()")
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return e
+	}
+
+	if fn == nil {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function"),
+			},
+		})
+		return e
+	}
+
+	handler := &softHandler{
+		parent: e.chain.handler,
+	}
+
+	ce := e.clone()
+	ce.chain = e.chain.clone()
+	ce.chain.handler = handler
+	ce.chain.soft = true
+
+	fn(ce)
+
+	if len(handler.failures) != 0 {
+		// report using a copy of chain, so that Expect remains usable
+		e.chain.clone().fail(AssertionFailure{
+			Type:   AssertOperation,
+			Errors: handler.errors(),
+		})
+	}
+
+	return e
+}
+
+type softFailure struct {
+	path    []string
+	failure *AssertionFailure
+}
+
+// AssertionHandler that collects failures of soft assertions block.
+type softHandler struct {
+	parent AssertionHandler
+
+	mu        sync.Mutex
+	succeeded int
+	failures  []softFailure
+}
+
+func (h *softHandler) Success(ctx *AssertionContext) {
+	h.mu.Lock()
+	h.succeeded++
+	h.mu.Unlock()
+
+	h.parent.Success(ctx)
+}
+
+func (h *softHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if failure.Severity != SeverityError {
+		h.parent.Failure(ctx, failure)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, softFailure{
+		path:    append([]string(nil), ctx.Path...),
+		failure: failure,
+	})
+}
+
+func (h *softHandler) errors() []error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	errs := []error{
+		errors.New("expected: all soft assertions succeed"),
+		fmt.Errorf("%d of %d soft assertions failed",
+			len(h.failures), len(h.failures)+h.succeeded),
+	}
+
+	for _, f := range h.failures {
+		errs = append(errs, fmt.Errorf("failed assertion: %s", strings.Join(f.path, ".")))
+		errs = append(errs, f.failure.Errors...)
+
+		if f.failure.Actual != nil {
+			errs = append(errs,
+				fmt.Errorf("actual: %s", formatValue(f.failure.Actual.Value)))
+		}
+		if f.failure.Expected != nil {
+			errs = append(errs,
+				fmt.Errorf("expected: %s", formatValue(f.failure.Expected.Value)))
+		}
+	}
+
+	return errs
+}
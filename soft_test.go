@@ -0,0 +1,248 @@
+package httpexpect
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type softTestHandler struct {
+	successes int
+	failures  []*AssertionFailure
+}
+
+func (h *softTestHandler) Success(ctx *AssertionContext) {
+	h.successes++
+}
+
+func (h *softTestHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.failures = append(h.failures, failure)
+}
+
+func createSoftHandler(hits *int32) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name": "john", "role": "user", "active": false}`))
+	})
+
+	return mux
+}
+
+func softErrors(failure *AssertionFailure) string {
+	var msgs []string
+	for _, err := range failure.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func TestSoftAssertionsFailed(t *testing.T) {
+	handler := &softTestHandler{}
+
+	var hits int32
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(createSoftHandler(&hits)),
+		},
+	})
+
+	e.chain.fail(mockFailure())
+
+	called := false
+	e.SoftAssertions(func(e *Expect) {
+		called = true
+	})
+
+	assert.False(t, called)
+}
+
+func TestSoftAssertionsCollect(t *testing.T) {
+	handler := &softTestHandler{}
+
+	var hits int32
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(createSoftHandler(&hits)),
+		},
+	})
+
+	e.SoftAssertions(func(e *Expect) {
+		resp := e.GET("/user").Expect()
+
+		resp.Status(http.StatusOK)
+
+		resp.JSON().Object().
+			ValueEqual("name", "john").
+			ValueEqual("role", "admin").
+			ValueEqual("active", true)
+	})
+
+	require.Equal(t, 1, len(handler.failures))
+
+	failure := handler.failures[0]
+	assert.Equal(t, AssertOperation, failure.Type)
+	assert.Equal(t, SeverityError, failure.Severity)
+
+	msg := softErrors(failure)
+	t.Log(msg)
+
+	assert.Contains(t, msg, "expected: all soft assertions succeed")
+	assert.Contains(t, msg, "3 of ")
+	assert.Contains(t, msg, "Status()")
+	assert.Contains(t, msg, `ValueEqual("role")`)
+	assert.Contains(t, msg, `ValueEqual("active")`)
+	assert.Contains(t, msg, `"admin"`)
+
+	// Expect itself is not failed and remains usable
+	e.chain.assertNotFailed(t)
+
+	e.GET("/user").Expect().Status(http.StatusCreated)
+	assert.Equal(t, 1, len(handler.failures))
+}
+
+func TestSoftAssertionsDerived(t *testing.T) {
+	handler := &softTestHandler{}
+
+	var hits int32
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(createSoftHandler(&hits)),
+		},
+	})
+
+	e.SoftAssertions(func(e *Expect) {
+		obj := e.GET("/user").Expect().JSON().Object()
+
+		// derived value of failed assertion remains failed,
+		// so only one failure is reported
+		obj.Value("missing").String().Equal("foo")
+
+		// but object itself can be checked further
+		obj.ContainsKey("name")
+		obj.ContainsKey("other")
+	})
+
+	require.Equal(t, 1, len(handler.failures))
+
+	msg := softErrors(handler.failures[0])
+	t.Log(msg)
+
+	assert.Contains(t, msg, "2 of ")
+	assert.Contains(t, msg, `Value("missing")`)
+	assert.Contains(t, msg, "ContainsKey()")
+	assert.Contains(t, msg, `expected: "other"`)
+	assert.NotContains(t, msg, "String()")
+}
+
+func TestSoftAssertionsSuccess(t *testing.T) {
+	handler := &softTestHandler{}
+
+	var hits int32
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(createSoftHandler(&hits)),
+		},
+	})
+
+	e.SoftAssertions(func(e *Expect) {
+		e.GET("/user").Expect().
+			Status(http.StatusCreated).
+			JSON().Object().ValueEqual("name", "john")
+	})
+
+	assert.Equal(t, 0, len(handler.failures))
+	assert.NotZero(t, handler.successes)
+}
+
+func TestSoftAssertionsRequestFailure(t *testing.T) {
+	handler := &softTestHandler{}
+
+	var hits int32
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(createSoftHandler(&hits)),
+		},
+	})
+
+	e.SoftAssertions(func(e *Expect) {
+		e.GET("/user").
+			WithJSON(func() {}).
+			Expect().
+			Status(http.StatusCreated)
+	})
+
+	// failed request is not sent
+	assert.Equal(t, int32(0), atomic.LoadInt32(&hits))
+
+	require.Equal(t, 1, len(handler.failures))
+	assert.Contains(t, softErrors(handler.failures[0]), "1 of ")
+}
+
+func TestSoftAssertionsWarning(t *testing.T) {
+	handler := &softTestHandler{}
+
+	var hits int32
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(createSoftHandler(&hits)),
+		},
+	})
+
+	e.SoftAssertions(func(e *Expect) {
+		resp := e.GET("/user").Expect()
+
+		resp.Warn().Status(http.StatusOK)
+
+		require.Equal(t, 1, len(handler.failures))
+		assert.Equal(t, SeverityWarning, handler.failures[0].Severity)
+	})
+
+	assert.Equal(t, 1, len(handler.failures))
+}
+
+func TestSoftAssertionsNil(t *testing.T) {
+	handler := &softTestHandler{}
+
+	var hits int32
+	e := WithConfig(Config{
+		BaseURL:          "http://example.com",
+		Reporter:         newMockReporter(t),
+		AssertionHandler: handler,
+		Client: &http.Client{
+			Transport: NewBinder(createSoftHandler(&hits)),
+		},
+	})
+
+	e.SoftAssertions(nil)
+
+	require.Equal(t, 1, len(handler.failures))
+	assert.Equal(t, AssertUsage, handler.failures[0].Type)
+}
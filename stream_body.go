@@ -0,0 +1,136 @@
+package httpexpect
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// Request body that can be sent multiple times
+// Implemented by bodyWrapper and streamBody
+type rewindBody interface {
+	io.ReadCloser
+	Rewind()
+	GetBody() (io.ReadCloser, error)
+}
+
+// Request body that is streamed from file or reader
+// Unlike bodyWrapper, doesn't read body into memory; instead, it re-opens
+// the underlying source when it should be read again:
+//   - use Read to read body contents and Rewind to restart reading from beginning
+//   - use GetBody to get new reader for body contents
+type streamBody struct {
+	openFunc func() (io.ReadCloser, error)
+
+	currReader io.ReadCloser
+	openErr    error
+
+	mu sync.Mutex
+}
+
+func newFileStreamBody(path string) *streamBody {
+	return &streamBody{
+		openFunc: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}
+}
+
+func newReaderStreamBody(reader io.Reader) *streamBody {
+	seeker, ok := reader.(io.Seeker)
+
+	var offset int64
+	if ok {
+		var err error
+		if offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			ok = false
+		}
+	}
+
+	if ok {
+		return &streamBody{
+			openFunc: func() (io.ReadCloser, error) {
+				if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+					return nil, err
+				}
+				return ioutil.NopCloser(reader), nil
+			},
+		}
+	}
+
+	opened := false
+
+	return &streamBody{
+		openFunc: func() (io.ReadCloser, error) {
+			if opened {
+				return nil, errors.New(
+					"body reader can't be read twice because it's not an io.Seeker")
+			}
+			opened = true
+			return ioutil.NopCloser(reader), nil
+		},
+	}
+}
+
+// Read body contents
+func (sb *streamBody) Read(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	// Preserve open error
+	if sb.openErr != nil {
+		return 0, sb.openErr
+	}
+
+	// Lazy open
+	if sb.currReader == nil {
+		sb.currReader, sb.openErr = sb.openFunc()
+		if sb.openErr != nil {
+			return 0, sb.openErr
+		}
+	}
+
+	return sb.currReader.Read(p)
+}
+
+// Close body
+// Reading may be restarted after Close using Rewind
+func (sb *streamBody) Close() error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	return sb.closeReader()
+}
+
+// Rewind reading to the beginning
+func (sb *streamBody) Rewind() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	// Until first read, rewind is no-op
+	_ = sb.closeReader()
+}
+
+// Create new reader to retrieve body contents
+// New reader always reads body from the beginning
+func (sb *streamBody) GetBody() (io.ReadCloser, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	_ = sb.closeReader()
+
+	return sb.openFunc()
+}
+
+func (sb *streamBody) closeReader() error {
+	if sb.currReader == nil {
+		return nil
+	}
+
+	err := sb.currReader.Close()
+	sb.currReader = nil
+
+	return err
+}
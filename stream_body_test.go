@@ -0,0 +1,85 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamBodyFile(t *testing.T) {
+	fh, _ := ioutil.TempFile("", "httpexpect")
+	filename := fh.Name()
+	_, _ = fh.WriteString("test_body")
+	_ = fh.Close()
+	defer os.Remove(filename)
+
+	body := newFileStreamBody(filename)
+
+	b, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+
+	err = body.Close()
+	assert.NoError(t, err)
+
+	body.Rewind()
+
+	b, err = ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+
+	rd, err := body.GetBody()
+	assert.NoError(t, err)
+
+	b, err = ioutil.ReadAll(rd)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+	assert.NoError(t, rd.Close())
+}
+
+func TestStreamBodySeeker(t *testing.T) {
+	reader := strings.NewReader("xxtest_body")
+	_, _ = reader.Seek(2, 0)
+
+	body := newReaderStreamBody(reader)
+
+	b, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+
+	body.Rewind()
+
+	b, err = ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+
+	rd, err := body.GetBody()
+	assert.NoError(t, err)
+
+	b, err = ioutil.ReadAll(rd)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+}
+
+func TestStreamBodyNonSeeker(t *testing.T) {
+	body := newReaderStreamBody(bytes.NewBufferString("test_body"))
+
+	// rewind before first read is no-op
+	body.Rewind()
+
+	b, err := ioutil.ReadAll(body)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_body", string(b))
+
+	body.Rewind()
+
+	_, err = ioutil.ReadAll(body)
+	assert.Error(t, err)
+
+	_, err = body.GetBody()
+	assert.Error(t, err)
+}
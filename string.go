@@ -1,8 +1,14 @@
 package httpexpect
 
 import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -818,6 +824,65 @@ func (s *String) NotIsASCII() *String {
 	return s
 }
 
+// Checksum succeeds if checksum of string bytes, computed using given
+// hash algorithm, is equal to expected hex-encoded value.
+//
+// Supported algorithms are "md5", "sha1", "sha256", and "sha512". Dash
+// is allowed in algorithm name, e.g. "sha-256". Expected value is compared
+// case-insensitively.
+//
+// Example:
+//
+//	str := NewString(t, "Hello")
+//	str.Checksum("sha256",
+//	    "185f8db32271fe25f561a6fc938b2e264306ec304eda518007d1764826381969")
+func (s *String) Checksum(algorithm, expected string) *String {
+	s.chain.enter("Checksum()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	newHash, ok := checksumAlgorithms[strings.ReplaceAll(
+		strings.ToLower(algorithm), "-", "")]
+	if !ok {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unsupported checksum algorithm %q", algorithm),
+			},
+		})
+		return s
+	}
+
+	h := newHash()
+	_, _ = h.Write([]byte(s.value))
+
+	actual := hex.EncodeToString(h.Sum(nil))
+
+	if actual != strings.ToLower(strings.TrimSpace(expected)) {
+		s.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				fmt.Errorf("expected: %s checksum of string is equal to given value",
+					algorithm),
+			},
+		})
+	}
+
+	return s
+}
+
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
 // AsNumber parses float from string and returns a new Number instance
 // with result.
 //
@@ -985,6 +1050,44 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 		return newDateTime(s.chain, time.Unix(0, 0))
 	}
 
+	tm, ok := parseDateTime(s.chain, s.value, format)
+	if !ok {
+		return newDateTime(s.chain, time.Unix(0, 0))
+	}
+
+	return newDateTime(s.chain, tm)
+}
+
+// AsJWT parses JSON Web Token from string and returns a new JWT instance
+// with result.
+//
+// If the string has "Bearer " prefix, it is removed, so AsJWT can be used
+// directly on Authorization header.
+//
+// If the string can't be parsed as signed token, AsJWT reports failure
+// and returns empty (but non-nil) instance.
+//
+// Example:
+//
+//	str := NewString(t, "Bearer eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0.c2ln")
+//	str.AsJWT().Claims().Value("sub").String().Equal("john")
+//
+//	resp.Header("Authorization").AsJWT().
+//	    Verify(&key.PublicKey).
+//	    NotExpired()
+func (s *String) AsJWT() *JWT {
+	s.chain.enter("AsJWT()")
+	defer s.chain.leave()
+
+	token := strings.TrimSpace(s.value)
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+
+	return newJWT(s.chain, token)
+}
+
+func parseDateTime(chain *chain, value string, format []string) (time.Time, bool) {
 	var formatList []datetimeFormat
 
 	if len(format) != 0 {
@@ -1015,7 +1118,7 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 		err error
 	)
 	for _, f := range formatList {
-		tm, err = time.Parse(f.layout, s.value)
+		tm, err = time.Parse(f.layout, value)
 		if err == nil {
 			break
 		}
@@ -1023,9 +1126,9 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 
 	if err != nil {
 		if len(formatList) == 1 {
-			s.chain.fail(AssertionFailure{
+			chain.fail(AssertionFailure{
 				Type:     AssertMatchFormat,
-				Actual:   &AssertionValue{s.value},
+				Actual:   &AssertionValue{value},
 				Expected: &AssertionValue{formatList[0]},
 				Errors: []error{
 					errors.New("expected: string can be parsed to datetime" +
@@ -1037,9 +1140,9 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 			for _, f := range formatList {
 				expectedFormats = append(expectedFormats, f)
 			}
-			s.chain.fail(AssertionFailure{
+			chain.fail(AssertionFailure{
 				Type:     AssertMatchFormat,
-				Actual:   &AssertionValue{s.value},
+				Actual:   &AssertionValue{value},
 				Expected: &AssertionValue{AssertionList(expectedFormats)},
 				Errors: []error{
 					errors.New("expected: string can be parsed to datetime" +
@@ -1047,10 +1150,10 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 				},
 			})
 		}
-		return newDateTime(s.chain, time.Unix(0, 0))
+		return time.Time{}, false
 	}
 
-	return newDateTime(s.chain, tm)
+	return tm, true
 }
 
 type datetimeFormat struct {
@@ -0,0 +1,572 @@
+package httpexpect
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IsUUID succeeds if string is a UUID in canonical textual form,
+// e.g. "123e4567-e89b-12d3-a456-426614174000". Both lower and upper
+// case hex digits are allowed.
+//
+// Example:
+//
+//	str := NewString(t, "123e4567-e89b-12d3-a456-426614174000")
+//	str.IsUUID()
+func (s *String) IsUUID() *String {
+	s.chain.enter("IsUUID()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if !uuidRe.MatchString(s.value) {
+		s.failFormat("UUID", "expected: string is a valid UUID")
+	}
+
+	return s
+}
+
+// IsSemver succeeds if string is a valid semantic version, as defined
+// by Semantic Versioning 2.0.0, e.g. "1.2.3" or "1.2.3-rc.1+build.5".
+//
+// Leading "v" is not allowed.
+//
+// Example:
+//
+//	str := NewString(t, "1.2.3-rc.1")
+//	str.IsSemver()
+func (s *String) IsSemver() *String {
+	s.chain.enter("IsSemver()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if _, ok := parseSemver(s.value); !ok {
+		s.failFormat("semver", "expected: string is a valid semantic version")
+	}
+
+	return s
+}
+
+// SemverRange succeeds if string is a valid semantic version that
+// satisfies given range.
+//
+// Range consists of one or more sets of comparators separated by "||".
+// Version should satisfy all comparators of at least one set.
+// Comparators in a set are separated by spaces.
+//
+// Supported comparators:
+//   - "=1.2.3", "1.2.3" - equal to version
+//   - "!=1.2.3" - not equal to version
+//   - ">1.2.3", ">=1.2.3", "<1.2.3", "<=1.2.3" - comparison
+//   - "~1.2.3" - patch updates, i.e. ">=1.2.3 <1.3.0"
+//   - "^1.2.3" - compatible updates, i.e. ">=1.2.3 <2.0.0"
+//     (or "<0.3.0" for "^0.2.3", or "<0.0.4" for "^0.0.3")
+//
+// Missing minor and patch versions are treated as zeros in comparisons,
+// e.g. ">=1.2" is the same as ">=1.2.0". Bare partial version matches
+// all versions with given prefix, e.g. "1.2" and "1.2.x" are ">=1.2.0 <1.3.0",
+// and "*" matches any version.
+//
+// Example:
+//
+//	str := NewString(t, "1.4.2")
+//	str.SemverRange(">=1.2 <2")
+//	str.SemverRange("~1.4 || ^2.0.0")
+func (s *String) SemverRange(constraint string) *String {
+	s.chain.enter("SemverRange()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	sets, err := parseSemverRange(constraint)
+	if err != nil {
+		s.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid semver range %q", constraint),
+				err,
+			},
+		})
+		return s
+	}
+
+	ver, ok := parseSemver(s.value)
+	if !ok {
+		s.failFormat("semver", "expected: string is a valid semantic version")
+		return s
+	}
+
+	for _, set := range sets {
+		if set.match(ver) {
+			return s
+		}
+	}
+
+	s.chain.fail(AssertionFailure{
+		Type:     AssertMatchFormat,
+		Actual:   &AssertionValue{s.value},
+		Expected: &AssertionValue{constraint},
+		Errors: []error{
+			errors.New("expected: version satisfies given range"),
+		},
+	})
+
+	return s
+}
+
+// IsEmail succeeds if string is a valid email address without display
+// name, as defined by RFC 5322, e.g. "john@example.com".
+//
+// Example:
+//
+//	str := NewString(t, "john@example.com")
+//	str.IsEmail()
+func (s *String) IsEmail() *String {
+	s.chain.enter("IsEmail()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	addr, err := mail.ParseAddress(s.value)
+	if err != nil || addr.Name != "" || addr.Address != s.value {
+		s.failFormat("email", "expected: string is a valid email address")
+	}
+
+	return s
+}
+
+// IsURL succeeds if string is an absolute URL, i.e. has scheme and host.
+//
+// If schemes are given, URL scheme should be one of them (case-insensitive).
+//
+// Example:
+//
+//	str := NewString(t, "https://example.com/path")
+//	str.IsURL()
+//	str.IsURL("http", "https")
+func (s *String) IsURL(schemes ...string) *String {
+	s.chain.enter("IsURL()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	u, err := url.Parse(s.value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		s.failFormat("URL", "expected: string is a valid absolute URL")
+		return s
+	}
+
+	if len(schemes) == 0 {
+		return s
+	}
+
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return s
+		}
+	}
+
+	var expected []interface{}
+	for _, scheme := range schemes {
+		expected = append(expected, scheme)
+	}
+
+	s.chain.fail(AssertionFailure{
+		Type:     AssertBelongs,
+		Actual:   &AssertionValue{u.Scheme},
+		Expected: &AssertionValue{AssertionList(expected)},
+		Errors: []error{
+			errors.New("expected: URL scheme belongs to given list"),
+		},
+	})
+
+	return s
+}
+
+// IsBase64 succeeds if string is valid base64 data.
+//
+// Both standard and URL-safe alphabets are accepted, with or without
+// padding.
+//
+// Example:
+//
+//	str := NewString(t, "aGVsbG8=")
+//	str.IsBase64()
+func (s *String) IsBase64() *String {
+	s.chain.enter("IsBase64()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	if _, ok := decodeBase64(s.value); !ok {
+		s.failFormat("base64", "expected: string is valid base64 data")
+	}
+
+	return s
+}
+
+// DecodedJSON decodes string as base64 data, parses decoded data as
+// JSON, and returns a new Value instance with result.
+//
+// Base64 alphabets are accepted the same way as by IsBase64.
+//
+// If string is not valid base64 or decoded data is not valid JSON,
+// failure is reported and empty (but non-nil) value is returned.
+//
+// Example:
+//
+//	str := NewString(t, "eyJmb28iOiAiYmFyIn0=")
+//	str.DecodedJSON().Object().ValueEqual("foo", "bar")
+func (s *String) DecodedJSON() *Value {
+	s.chain.enter("DecodedJSON()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return newValue(s.chain, nil)
+	}
+
+	data, ok := decodeBase64(s.value)
+	if !ok {
+		s.failFormat("base64", "expected: string is valid base64 data")
+		return newValue(s.chain, nil)
+	}
+
+	value, err := jsonDecode(s.chain, data)
+	if err != nil {
+		s.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(data)},
+			Errors: []error{
+				errors.New("expected: decoded data is valid json"),
+				err,
+			},
+		})
+		return newValue(s.chain, nil)
+	}
+
+	return newValue(s.chain, value)
+}
+
+func (s *String) failFormat(format string, message string) {
+	s.chain.fail(AssertionFailure{
+		Type:     AssertMatchFormat,
+		Actual:   &AssertionValue{s.value},
+		Expected: &AssertionValue{format},
+		Errors: []error{
+			errors.New(message),
+		},
+	})
+}
+
+var uuidRe = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func decodeBase64(str string) ([]byte, bool) {
+	if str == "" {
+		return nil, false
+	}
+
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		if data, err := enc.DecodeString(str); err == nil {
+			return data, true
+		}
+	}
+
+	return nil, false
+}
+
+var semverRe = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)` +
+	`(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+type semver struct {
+	major, minor, patch uint64
+	pre                 []string
+}
+
+func parseSemver(str string) (semver, bool) {
+	m := semverRe.FindStringSubmatch(str)
+	if m == nil {
+		return semver{}, false
+	}
+
+	var (
+		v   semver
+		err error
+	)
+
+	for i, p := range []*uint64{&v.major, &v.minor, &v.patch} {
+		if *p, err = strconv.ParseUint(m[i+1], 10, 64); err != nil {
+			return semver{}, false
+		}
+	}
+
+	if m[4] != "" {
+		v.pre = strings.Split(m[4], ".")
+	}
+
+	return v, true
+}
+
+// Compare versions according to semver precedence rules.
+// Build metadata is ignored.
+func (v semver) compare(o semver) int {
+	for _, p := range [][2]uint64{
+		{v.major, o.major},
+		{v.minor, o.minor},
+		{v.patch, o.patch},
+	} {
+		if p[0] != p[1] {
+			if p[0] < p[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// version without pre-release has higher precedence
+	switch {
+	case len(v.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		if c := comparePrerelease(v.pre[i], o.pre[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(v.pre) < len(o.pre):
+		return -1
+	case len(v.pre) > len(o.pre):
+		return 1
+	}
+
+	return 0
+}
+
+func comparePrerelease(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+		return 0
+
+	// numeric identifiers have lower precedence
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+type semverComparator struct {
+	op  string
+	ver semver
+}
+
+func (c semverComparator) match(v semver) bool {
+	cmp := v.compare(c.ver)
+
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+
+	return false
+}
+
+type semverSet []semverComparator
+
+func (set semverSet) match(v semver) bool {
+	for _, c := range set {
+		if !c.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseSemverRange(constraint string) ([]semverSet, error) {
+	var sets []semverSet
+
+	for _, group := range strings.Split(constraint, "||") {
+		fields := strings.Fields(group)
+		if len(fields) == 0 {
+			return nil, errors.New("empty comparator set")
+		}
+
+		var set semverSet
+
+		for _, field := range fields {
+			comparators, err := parseSemverComparator(field)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, comparators...)
+		}
+
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+func parseSemverComparator(str string) ([]semverComparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", "!=", "==", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(str, prefix) {
+			op = prefix
+			break
+		}
+	}
+
+	switch str[len(op):] {
+	case "*", "x", "X":
+		if op == "" {
+			return []semverComparator{{op: ">=", ver: semver{}}}, nil
+		}
+	}
+
+	ver, parts, err := parsePartialSemver(str[len(op):])
+	if err != nil {
+		return nil, err
+	}
+
+	// upper bound for ranges: increment component at given index
+	bump := func(idx int) semver {
+		switch idx {
+		case 0:
+			return semver{major: ver.major + 1}
+		case 1:
+			return semver{major: ver.major, minor: ver.minor + 1}
+		default:
+			return semver{major: ver.major, minor: ver.minor, patch: ver.patch + 1}
+		}
+	}
+
+	between := func(max semver) []semverComparator {
+		return []semverComparator{
+			{op: ">=", ver: ver},
+			{op: "<", ver: max},
+		}
+	}
+
+	switch op {
+	case "", "=", "==":
+		if parts < 3 {
+			return between(bump(parts - 1)), nil
+		}
+		return []semverComparator{{op: "=", ver: ver}}, nil
+
+	case "~":
+		if parts == 1 {
+			return between(bump(0)), nil
+		}
+		return between(bump(1)), nil
+
+	case "^":
+		switch {
+		case ver.major != 0 || parts == 1:
+			return between(bump(0)), nil
+		case ver.minor != 0 || parts == 2:
+			return between(bump(1)), nil
+		}
+		return between(bump(2)), nil
+	}
+
+	return []semverComparator{{op: op, ver: ver}}, nil
+}
+
+// Parse version with optional minor and patch, e.g. "1", "1.2", "1.2.x",
+// or "1.2.3-rc.1". Returns version and number of given components.
+func parsePartialSemver(str string) (semver, int, error) {
+	if v, ok := parseSemver(str); ok {
+		return v, 3, nil
+	}
+
+	var (
+		v     semver
+		parts int
+	)
+
+	for i, part := range strings.Split(str, ".") {
+		if i == 3 {
+			return semver{}, 0, fmt.Errorf("invalid version %q", str)
+		}
+
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semver{}, 0, fmt.Errorf("invalid version %q", str)
+		}
+
+		switch i {
+		case 0:
+			v.major = n
+		case 1:
+			v.minor = n
+		case 2:
+			v.patch = n
+		}
+		parts++
+	}
+
+	if parts == 0 {
+		return semver{}, 0, fmt.Errorf("invalid version %q", str)
+	}
+
+	return v, parts, nil
+}
@@ -0,0 +1,207 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringFormatFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newString(chain, "")
+
+	value.IsUUID()
+	value.IsSemver()
+	value.SemverRange("1")
+	value.IsEmail()
+	value.IsURL()
+	value.IsBase64()
+
+	assert.NotNil(t, value.DecodedJSON())
+
+	value.chain.assertFailed(t)
+}
+
+func TestStringIsUUID(t *testing.T) {
+	cases := []struct {
+		str string
+		ok  bool
+	}{
+		{"123e4567-e89b-12d3-a456-426614174000", true},
+		{"123E4567-E89B-12D3-A456-426614174000", true},
+		{"123e4567e89b12d3a456426614174000", false},
+		{"123e4567-e89b-12d3-a456-42661417400", false},
+		{"123e4567-e89b-12d3-a456-42661417400g", false},
+		{"{123e4567-e89b-12d3-a456-426614174000}", false},
+		{"", false},
+	}
+
+	for _, tc := range cases {
+		value := NewString(newMockReporter(t), tc.str)
+		value.IsUUID()
+		assert.Equal(t, !tc.ok, value.chain.failed(), tc.str)
+	}
+}
+
+func TestStringIsSemver(t *testing.T) {
+	cases := []struct {
+		str string
+		ok  bool
+	}{
+		{"1.2.3", true},
+		{"0.0.0", true},
+		{"1.2.3-rc.1", true},
+		{"1.2.3-rc.1+build.5", true},
+		{"1.2.3+build", true},
+		{"v1.2.3", false},
+		{"1.2", false},
+		{"01.2.3", false},
+		{"1.2.3-01", false},
+		{"1.2.3-", false},
+		{"", false},
+	}
+
+	for _, tc := range cases {
+		value := NewString(newMockReporter(t), tc.str)
+		value.IsSemver()
+		assert.Equal(t, !tc.ok, value.chain.failed(), tc.str)
+	}
+}
+
+func TestStringSemverRange(t *testing.T) {
+	cases := []struct {
+		ver        string
+		constraint string
+		ok         bool
+	}{
+		{"1.4.2", ">=1.2 <2", true},
+		{"2.0.0", ">=1.2 <2", false},
+		{"1.1.9", ">=1.2 <2", false},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "=1.2.3", true},
+		{"1.2.4", "1.2.3", false},
+		{"1.2.4", "!=1.2.3", true},
+		{"1.2.9", "1.2", true},
+		{"1.2.9", "1.2.x", true},
+		{"1.3.0", "1.2", false},
+		{"1.9.0", "1", true},
+		{"7.0.0", "*", true},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.9.9", "~1", true},
+		{"1.9.9", "^1.2.3", true},
+		{"2.0.0", "^1.2.3", false},
+		{"0.2.9", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+		{"0.0.3", "^0.0.3", true},
+		{"0.0.4", "^0.0.3", false},
+		{"1.4.0", "~1.2 || ^1.4", true},
+		{"1.3.0", "~1.2 || ^1.4", false},
+		{"1.0.0-rc.1", "<1.0.0", true},
+		{"1.0.0-rc.2", ">1.0.0-rc.1", true},
+		{"1.0.0-rc.10", ">1.0.0-rc.9", true},
+		{"1.0.0-alpha", "<1.0.0-alpha.1", true},
+		{"1.0.0-1", "<1.0.0-alpha", true},
+		{"1.0.0+build", "=1.0.0", true},
+		{"bad", "*", false},
+	}
+
+	for _, tc := range cases {
+		value := NewString(newMockReporter(t), tc.ver)
+		value.SemverRange(tc.constraint)
+		assert.Equal(t, !tc.ok, value.chain.failed(), tc.ver+" "+tc.constraint)
+	}
+
+	for _, constraint := range []string{"", "||", "1.2.3.4", ">=a", "1 ||"} {
+		value := NewString(newMockReporter(t), "1.2.3")
+		value.SemverRange(constraint)
+		value.chain.assertFailed(t)
+	}
+}
+
+func TestStringIsEmail(t *testing.T) {
+	cases := []struct {
+		str string
+		ok  bool
+	}{
+		{"john@example.com", true},
+		{"john.doe+tag@sub.example.com", true},
+		{"John <john@example.com>", false},
+		{"john", false},
+		{"john@", false},
+		{"@example.com", false},
+		{"", false},
+	}
+
+	for _, tc := range cases {
+		value := NewString(newMockReporter(t), tc.str)
+		value.IsEmail()
+		assert.Equal(t, !tc.ok, value.chain.failed(), tc.str)
+	}
+}
+
+func TestStringIsURL(t *testing.T) {
+	cases := []struct {
+		str     string
+		schemes []string
+		ok      bool
+	}{
+		{"https://example.com/path?q=1", nil, true},
+		{"ws://localhost:8080", nil, true},
+		{"HTTPS://example.com", []string{"http", "https"}, true},
+		{"ftp://example.com", []string{"http", "https"}, false},
+		{"/path", nil, false},
+		{"example.com", nil, false},
+		{"mailto:john@example.com", nil, false},
+		{"://example.com", nil, false},
+	}
+
+	for _, tc := range cases {
+		value := NewString(newMockReporter(t), tc.str)
+		value.IsURL(tc.schemes...)
+		assert.Equal(t, !tc.ok, value.chain.failed(), tc.str)
+	}
+}
+
+func TestStringIsBase64(t *testing.T) {
+	cases := []struct {
+		str string
+		ok  bool
+	}{
+		{"aGVsbG8=", true},
+		{"aGVsbG8", true},
+		{"-_-_", true},
+		{"+/+/", true},
+		{"aGVsbG8===", false},
+		{"not base64!", false},
+		{"", false},
+	}
+
+	for _, tc := range cases {
+		value := NewString(newMockReporter(t), tc.str)
+		value.IsBase64()
+		assert.Equal(t, !tc.ok, value.chain.failed(), tc.str)
+	}
+}
+
+func TestStringDecodedJSON(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, "eyJmb28iOiAiYmFyIn0=")
+	value.DecodedJSON().Object().ValueEqual("foo", "bar")
+	value.chain.assertNotFailed(t)
+
+	value = NewString(reporter, "eyJmb28iOiAiYmFyIn0")
+	value.DecodedJSON().Object().ValueEqual("foo", "bar")
+	value.chain.assertNotFailed(t)
+
+	value = NewString(reporter, "bm90IGpzb24=")
+	assert.Nil(t, value.DecodedJSON().Raw())
+	value.chain.assertFailed(t)
+
+	value = NewString(reporter, "not base64!")
+	assert.Nil(t, value.DecodedJSON().Raw())
+	value.chain.assertFailed(t)
+}
@@ -360,6 +360,33 @@ func TestStringIsNotAscii(t *testing.T) {
 	value5.chain.clearFailed()
 }
 
+func TestStringChecksum(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, "Hello")
+
+	value.Checksum("md5", "8b1a9953c4611296a827abf8c47804d7")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Checksum("SHA1", "F7FF9E8B7BB2E09B70935A5D785E0CC5D9D0ABF0")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Checksum("sha-256",
+		"185f8db32271fe25f561a6fc938b2e264306ec304eda518007d1764826381969")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.Checksum("sha256", "0000")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Checksum("crc32", "f7d18982")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
 func TestStringAsNumber(t *testing.T) {
 	reporter := newMockReporter(t)
 
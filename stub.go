@@ -0,0 +1,485 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// StubServer is a local HTTP server that serves predefined responses,
+// e.g. to emulate upstream services that are called by the service
+// under test.
+//
+// Routes are declared using On. Every received request is matched against
+// routes in order of declaration; the first route that matches request
+// method and path, and that is not exhausted (see StubRoute.Times), serves
+// the request. Requests that don't match any route are answered with
+// "404 Not Found" and reported as failure by Verify.
+//
+// StubServer also implements http.Handler, so it may be used without
+// network, e.g. with Binder.
+//
+// StubServer should be closed after use.
+//
+// Example:
+//
+//	stub := e.StubServer()
+//	defer stub.Close()
+//
+//	stub.On("GET", "/users/1").
+//	    ReturnJSON(map[string]interface{}{"id": 1, "name": "john"}).
+//	    Times(1)
+//
+//	// service under test calls stub.URL() + "/users/1"
+//	e.GET("/profiles/1").
+//	    Expect().
+//	    Status(http.StatusOK)
+//
+//	stub.Verify()
+type StubServer struct {
+	chain  *chain
+	server *httptest.Server
+
+	mu        sync.Mutex
+	routes    []*StubRoute
+	unmatched []string
+}
+
+// StubRoute defines response for requests matching given method and path.
+// It is created by StubServer.On.
+type StubRoute struct {
+	chain *chain
+	stub  *StubServer
+
+	method string
+	path   string
+
+	status  int
+	header  http.Header
+	body    []byte
+	handler http.HandlerFunc
+	times   int
+
+	calls []*callbackRecord
+}
+
+// NewStubServer returns a new started StubServer.
+//
+// reporter should not be nil.
+//
+// Example:
+//
+//	stub := NewStubServer(t)
+//	defer stub.Close()
+func NewStubServer(reporter Reporter) *StubServer {
+	return newStubServer(newChainWithDefaults("StubServer()", reporter))
+}
+
+// StubServer returns a new started StubServer.
+// It's a shorthand for NewStubServer, but it uses assertion handler
+// of Expect instance.
+func (e *Expect) StubServer() *StubServer {
+	e.chain.enter("StubServer()")
+	defer e.chain.leave()
+
+	return newStubServer(e.chain)
+}
+
+func newStubServer(parent *chain) *StubServer {
+	s := &StubServer{
+		chain: parent.clone(),
+	}
+
+	s.server = httptest.NewServer(s)
+
+	return s
+}
+
+// URL returns base URL of the stub server, e.g. "http://127.0.0.1:1234".
+func (s *StubServer) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the stub server.
+func (s *StubServer) Close() {
+	s.server.Close()
+}
+
+// ServeHTTP implements http.Handler.
+func (s *StubServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+
+	s.mu.Lock()
+
+	var route *StubRoute
+	for _, r := range s.routes {
+		if r.match(req) {
+			route = r
+			break
+		}
+	}
+
+	if route == nil {
+		call := req.Method + " " + req.URL.RequestURI()
+		s.unmatched = append(s.unmatched, call)
+		s.mu.Unlock()
+
+		http.Error(w, "no stub for "+call, http.StatusNotFound)
+		return
+	}
+
+	route.calls = append(route.calls, &callbackRecord{req: req, body: body})
+
+	status, header, content, handler :=
+		route.status, route.header.Clone(), route.body, route.handler
+
+	s.mu.Unlock()
+
+	if handler != nil {
+		req.Body = ioutil.NopCloser(strings.NewReader(string(body)))
+		handler(w, req)
+		return
+	}
+
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(content)
+}
+
+// On declares a new route for requests with given method and path, and
+// returns StubRoute instance to define response.
+//
+// Path should not contain query string. Path segments in curly braces,
+// like "{id}", match any non-empty segment. Method "*" matches any method.
+//
+// By default, route responds with "200 OK" and empty body to any number
+// of requests.
+//
+// Example:
+//
+//	stub.On("GET", "/users/{id}").ReturnJSON(user)
+//	stub.On("DELETE", "/users/1").ReturnStatus(http.StatusNoContent)
+func (s *StubServer) On(method, path string) *StubRoute {
+	s.chain.enter("On(%q, %q)", method, path)
+	defer s.chain.leave()
+
+	route := &StubRoute{
+		chain:  s.chain.clone(),
+		stub:   s,
+		method: method,
+		path:   path,
+		status: http.StatusOK,
+		header: http.Header{},
+		times:  -1,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routes = append(s.routes, route)
+
+	return route
+}
+
+// Verify succeeds if every route that has expected number of calls (see
+// StubRoute.Times) was called exactly that number of times, and if there
+// were no requests that didn't match any route.
+//
+// Example:
+//
+//	stub.On("POST", "/events").Times(2)
+//	// ...
+//	stub.Verify()
+func (s *StubServer) Verify() *StubServer {
+	s.chain.enter("Verify()")
+	defer s.chain.leave()
+
+	if s.chain.failed() {
+		return s
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.routes {
+		if r.times >= 0 && len(r.calls) != r.times {
+			s.chain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{len(r.calls)},
+				Expected: &AssertionValue{r.times},
+				Errors: []error{
+					fmt.Errorf("expected: stub route %s %s is called %d time(s)",
+						r.method, r.path, r.times),
+				},
+			})
+			return s
+		}
+	}
+
+	if len(s.unmatched) != 0 {
+		s.chain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{s.unmatched},
+			Errors: []error{
+				errors.New("expected: every request matches one of stub routes"),
+			},
+		})
+	}
+
+	return s
+}
+
+// ReturnStatus sets HTTP status code of response.
+// Default is http.StatusOK.
+//
+// Example:
+//
+//	stub.On("GET", "/users/2").ReturnStatus(http.StatusNotFound)
+func (r *StubRoute) ReturnStatus(status int) *StubRoute {
+	r.chain.enter("ReturnStatus()")
+	defer r.chain.leave()
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	r.status = status
+
+	return r
+}
+
+// ReturnHeader adds given header to response.
+//
+// Example:
+//
+//	stub.On("GET", "/users/1").ReturnHeader("ETag", `"v1"`)
+func (r *StubRoute) ReturnHeader(key, value string) *StubRoute {
+	r.chain.enter("ReturnHeader(%q)", key)
+	defer r.chain.leave()
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	r.header.Add(key, value)
+
+	return r
+}
+
+// ReturnBody sets response body.
+//
+// Example:
+//
+//	stub.On("GET", "/health").ReturnBody("ok")
+func (r *StubRoute) ReturnBody(body string) *StubRoute {
+	r.chain.enter("ReturnBody()")
+	defer r.chain.leave()
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	r.body = []byte(body)
+
+	return r
+}
+
+// ReturnJSON sets response body to JSON-encoded value, and sets
+// Content-Type header to "application/json; charset=utf-8".
+//
+// Example:
+//
+//	stub.On("GET", "/users/1").
+//	    ReturnJSON(map[string]interface{}{"id": 1, "name": "john"})
+func (r *StubRoute) ReturnJSON(value interface{}) *StubRoute {
+	r.chain.enter("ReturnJSON()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	b, err := marshalJSON(r.chain.marshaler, value)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("invalid json object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	r.header.Set("Content-Type", "application/json; charset=utf-8")
+	r.body = b
+
+	return r
+}
+
+// ReturnFunc sets handler that produces response. When handler is set,
+// status, headers, and body defined by other Return methods are ignored.
+//
+// Handler is invoked from server goroutine; it should not report
+// assertion failures.
+//
+// Example:
+//
+//	stub.On("POST", "/echo").ReturnFunc(func(w http.ResponseWriter, r *http.Request) {
+//	    _, _ = io.Copy(w, r.Body)
+//	})
+func (r *StubRoute) ReturnFunc(handler http.HandlerFunc) *StubRoute {
+	r.chain.enter("ReturnFunc()")
+	defer r.chain.leave()
+
+	if handler == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil handler"),
+			},
+		})
+		return r
+	}
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	r.handler = handler
+
+	return r
+}
+
+// Times sets expected number of calls of the route, which is checked
+// by StubServer.Verify.
+//
+// After route was called given number of times, it doesn't match further
+// requests anymore; they are served by next matching route, if any.
+// This allows to declare a sequence of responses for the same endpoint.
+//
+// Example:
+//
+//	stub.On("GET", "/jobs/1").ReturnJSON(pending).Times(2)
+//	stub.On("GET", "/jobs/1").ReturnJSON(done).Times(1)
+func (r *StubRoute) Times(n int) *StubRoute {
+	r.chain.enter("Times(%d)", n)
+	defer r.chain.leave()
+
+	if n < 0 {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative number of calls: %d", n),
+			},
+		})
+		return r
+	}
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	r.times = n
+
+	return r
+}
+
+// Count returns a new Number instance with number of calls of the route
+// received so far.
+//
+// Example:
+//
+//	route := stub.On("GET", "/users/1")
+//	// ...
+//	route.Count().Equal(1)
+func (r *StubRoute) Count() *Number {
+	r.chain.enter("Count()")
+	defer r.chain.leave()
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	return newNumber(r.chain, float64(len(r.calls)))
+}
+
+// Call returns a new CallbackCall instance to inspect request received
+// by the route, given its index in order of arrival.
+//
+// If there is no call with given index, failure is reported.
+//
+// Example:
+//
+//	route := stub.On("POST", "/events")
+//	// ...
+//	route.Call(0).JSON().Object().ValueEqual("type", "created")
+func (r *StubRoute) Call(index int) *CallbackCall {
+	r.chain.enter("Call(%d)", index)
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newCallbackCall(r.chain, nil)
+	}
+
+	r.stub.mu.Lock()
+	defer r.stub.mu.Unlock()
+
+	if index < 0 || index >= len(r.calls) {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(r.calls) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: call index is within bounds"),
+			},
+		})
+		return newCallbackCall(r.chain, nil)
+	}
+
+	return newCallbackCall(r.chain, r.calls[index])
+}
+
+// Check if route matches request. Should be called with lock held.
+func (r *StubRoute) match(req *http.Request) bool {
+	if r.times >= 0 && len(r.calls) >= r.times {
+		return false
+	}
+
+	if r.method != "*" && !strings.EqualFold(r.method, req.Method) {
+		return false
+	}
+
+	return stubPathMatches(r.path, req.URL.Path)
+}
+
+func stubPathMatches(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, seg := range patternSegments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != pathSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
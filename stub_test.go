@@ -0,0 +1,268 @@
+package httpexpect
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStubServerFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	stub := newStubServer(chain)
+	defer stub.Close()
+
+	route := stub.On("GET", "/")
+
+	route.ReturnJSON(map[string]interface{}{})
+
+	assert.NotNil(t, route.Count())
+	assert.NotNil(t, route.Call(0))
+
+	stub.Verify()
+
+	route.Call(0).chain.assertFailed(t)
+	route.chain.assertFailed(t)
+	stub.chain.assertFailed(t)
+}
+
+func TestStubServerBadUsage(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	stub := NewStubServer(reporter)
+	defer stub.Close()
+
+	route := stub.On("GET", "/")
+	route.chain.assertNotFailed(t)
+
+	route.ReturnFunc(nil)
+	route.chain.assertFailed(t)
+	route.chain.clearFailed()
+
+	route.Times(-1)
+	route.chain.assertFailed(t)
+	route.chain.clearFailed()
+
+	route.ReturnJSON(make(chan int))
+	route.chain.assertFailed(t)
+	route.chain.clearFailed()
+
+	stub.chain.assertNotFailed(t)
+}
+
+func TestStubServerReturn(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	stub := NewStubServer(reporter)
+	defer stub.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  stub.URL(),
+		Reporter: reporter,
+	})
+
+	stub.On("GET", "/users/1").
+		ReturnJSON(map[string]interface{}{"id": 1, "name": "john"})
+
+	stub.On("GET", "/users/{id}").
+		ReturnStatus(http.StatusNotFound)
+
+	stub.On("POST", "/users").
+		ReturnStatus(http.StatusCreated).
+		ReturnHeader("Location", "/users/2").
+		ReturnBody("created")
+
+	stub.On("*", "/echo").
+		ReturnFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Method", r.Method)
+			_, _ = io.Copy(w, r.Body)
+		})
+
+	t.Run("json", func(t *testing.T) {
+		resp := e.GET("/users/1").Expect()
+
+		resp.Status(http.StatusOK).
+			ContentType("application/json").
+			JSON().Object().ValueEqual("name", "john")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		resp := e.GET("/users/42").Expect()
+
+		resp.Status(http.StatusNotFound)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("status and header", func(t *testing.T) {
+		resp := e.POST("/users").Expect()
+
+		resp.Status(http.StatusCreated)
+		resp.Header("Location").Equal("/users/2")
+		resp.Body().Equal("created")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("func", func(t *testing.T) {
+		resp := e.PUT("/echo").WithText("hello").Expect()
+
+		resp.Status(http.StatusOK)
+		resp.Header("X-Method").Equal("PUT")
+		resp.Body().Equal("hello")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	stub.Verify()
+	stub.chain.assertNotFailed(t)
+}
+
+func TestStubServerCalls(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	stub := NewStubServer(reporter)
+	defer stub.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: reporter,
+		Client: &http.Client{
+			Transport: NewBinder(stub),
+		},
+	})
+
+	route := stub.On("POST", "/events")
+
+	e.POST("/events").WithQuery("n", 1).
+		WithJSON(map[string]interface{}{"type": "created"}).
+		Expect().
+		Status(http.StatusOK)
+
+	e.POST("/events").WithQuery("n", 2).
+		WithJSON(map[string]interface{}{"type": "deleted"}).
+		Expect().
+		Status(http.StatusOK)
+
+	route.Count().Equal(2)
+	route.chain.assertNotFailed(t)
+
+	call := route.Call(1)
+	call.chain.assertNotFailed(t)
+
+	require.NotNil(t, call.Raw())
+
+	call.Method().Equal("POST")
+	call.Path().Equal("/events")
+	call.Query("n").Equal("2")
+	call.JSON().Object().ValueEqual("type", "deleted")
+	call.chain.assertNotFailed(t)
+
+	route.Call(2)
+	route.chain.assertFailed(t)
+}
+
+func TestStubServerTimes(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	newStub := func() (*StubServer, *Expect) {
+		stub := NewStubServer(reporter)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(stub),
+			},
+		})
+
+		stub.On("GET", "/jobs/1").ReturnBody("pending").Times(2)
+		stub.On("GET", "/jobs/1").ReturnBody("done").Times(1)
+
+		return stub, e
+	}
+
+	t.Run("sequence", func(t *testing.T) {
+		stub, e := newStub()
+		defer stub.Close()
+
+		e.GET("/jobs/1").Expect().Body().Equal("pending")
+		e.GET("/jobs/1").Expect().Body().Equal("pending")
+		e.GET("/jobs/1").Expect().Body().Equal("done")
+
+		stub.Verify()
+		stub.chain.assertNotFailed(t)
+	})
+
+	t.Run("too few calls", func(t *testing.T) {
+		stub, e := newStub()
+		defer stub.Close()
+
+		e.GET("/jobs/1").Expect().Body().Equal("pending")
+
+		stub.Verify()
+		stub.chain.assertFailed(t)
+	})
+
+	t.Run("too many calls", func(t *testing.T) {
+		stub, e := newStub()
+		defer stub.Close()
+
+		for i := 0; i < 3; i++ {
+			e.GET("/jobs/1").Expect().Status(http.StatusOK)
+		}
+
+		e.GET("/jobs/1").Expect().Status(http.StatusNotFound)
+
+		stub.Verify()
+		stub.chain.assertFailed(t)
+	})
+}
+
+func TestStubServerUnmatched(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	stub := NewStubServer(reporter)
+	defer stub.Close()
+
+	stub.On("GET", "/users/1")
+
+	resp, err := http.Post(stub.URL()+"/users/1", "text/plain",
+		strings.NewReader("hello"))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	stub.Verify()
+	stub.chain.assertFailed(t)
+}
+
+func TestStubServerPathMatches(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		result  bool
+	}{
+		{"/", "/", true},
+		{"/users", "/users", true},
+		{"/users", "/users/", true},
+		{"/users", "/users/1", false},
+		{"/users/{id}", "/users/1", true},
+		{"/users/{id}", "/users/", false},
+		{"/users/{id}/posts", "/users/1/posts", true},
+		{"/users/{id}/posts", "/users/1/comments", false},
+		{"/users/1", "/users/2", false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.result, stubPathMatches(tc.pattern, tc.path),
+			"pattern %q, path %q", tc.pattern, tc.path)
+	}
+}
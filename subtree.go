@@ -0,0 +1,234 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// SubtreeArrayMatch defines how arrays are matched by Object.ContainsSubtree.
+type SubtreeArrayMatch int
+
+const (
+	// SubtreeArrayInOrder requires every element of expected array to match
+	// some element of actual array, in the same order. Actual array may
+	// contain extra elements before, after, or between matched ones.
+	SubtreeArrayInOrder SubtreeArrayMatch = iota
+
+	// SubtreeArrayAnyOrder requires every element of expected array to match
+	// some element of actual array, in any order.
+	SubtreeArrayAnyOrder
+)
+
+// ContainsSubtree succeeds if given value is a recursive subset of object.
+// Before comparison, both object and value are converted to canonical form.
+//
+// Unlike ContainsSubset, subset matching is applied at every level:
+//   - objects match if actual object has every key of expected object,
+//     and values for those keys match recursively;
+//   - arrays match if every element of expected array matches some
+//     element of actual array, according to arrayMatch
+//     (SubtreeArrayInOrder by default);
+//   - other values match if they are equal.
+//
+// On failure, only mismatched paths are reported, e.g.
+// `$.users[1].name: expected "bob", got "alice"`.
+//
+// value should be map[string]interface{} or struct.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//	    "users": []interface{}{
+//	        map[string]interface{}{"id": 1, "name": "alice", "roles": []interface{}{"admin", "dev"}},
+//	        map[string]interface{}{"id": 2, "name": "bob", "roles": []interface{}{"dev"}},
+//	    },
+//	})
+//
+//	object.ContainsSubtree(map[string]interface{}{  // success
+//	    "users": []interface{}{
+//	        map[string]interface{}{"name": "bob"},
+//	    },
+//	})
+//
+//	object.ContainsSubtree(map[string]interface{}{  // failure, wrong order
+//	    "users": []interface{}{
+//	        map[string]interface{}{"name": "bob"},
+//	        map[string]interface{}{"name": "alice"},
+//	    },
+//	})
+//
+//	object.ContainsSubtree(map[string]interface{}{  // success
+//	    "users": []interface{}{
+//	        map[string]interface{}{"name": "bob"},
+//	        map[string]interface{}{"name": "alice"},
+//	    },
+//	}, httpexpect.SubtreeArrayAnyOrder)
+func (o *Object) ContainsSubtree(
+	value interface{}, arrayMatch ...SubtreeArrayMatch,
+) *Object {
+	o.chain.enter("ContainsSubtree()")
+	defer o.chain.leave()
+
+	if o.chain.failed() {
+		return o
+	}
+
+	if len(arrayMatch) > 1 {
+		o.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple arrayMatch arguments"),
+			},
+		})
+		return o
+	}
+
+	mode := SubtreeArrayInOrder
+	if len(arrayMatch) != 0 {
+		mode = arrayMatch[0]
+	}
+
+	expected, ok := canonMap(o.chain, value)
+	if !ok {
+		return o
+	}
+
+	mismatches := matchSubtree("$", o.value, expected, mode, nil)
+
+	if len(mismatches) != 0 {
+		o.chain.fail(AssertionFailure{
+			Type:     AssertContainsSubset,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{value},
+			Errors: append([]error{
+				errors.New("expected: map contains subtree"),
+			}, mismatches...),
+		})
+	}
+
+	return o
+}
+
+// Compare actual and expected recursively and return error for
+// every mismatched path.
+func matchSubtree(
+	path string, actual, expected interface{}, mode SubtreeArrayMatch, errs []error,
+) []error {
+	switch ev := expected.(type) {
+	case map[string]interface{}:
+		av, ok := actual.(map[string]interface{})
+		if !ok {
+			return append(errs,
+				fmt.Errorf("%s: expected object, got %s", path, formatSubtree(actual)))
+		}
+
+		keys := make([]string, 0, len(ev))
+		for key := range ev {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyPath := path + "." + key
+			child, ok := av[key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: missing key", keyPath))
+				continue
+			}
+			errs = matchSubtree(keyPath, child, ev[key], mode, errs)
+		}
+
+		return errs
+
+	case []interface{}:
+		av, ok := actual.([]interface{})
+		if !ok {
+			return append(errs,
+				fmt.Errorf("%s: expected array, got %s", path, formatSubtree(actual)))
+		}
+
+		return matchSubtreeArray(path, av, ev, mode, errs)
+
+	default:
+		if !reflect.DeepEqual(actual, expected) {
+			return append(errs, fmt.Errorf("%s: expected %s, got %s",
+				path, formatSubtree(expected), formatSubtree(actual)))
+		}
+		return errs
+	}
+}
+
+func matchSubtreeArray(
+	path string, actual, expected []interface{}, mode SubtreeArrayMatch, errs []error,
+) []error {
+	matches := func(a, e interface{}) bool {
+		return len(matchSubtree("", a, e, mode, nil)) == 0
+	}
+
+	// If arrays have the same length and are matched in order, compare them
+	// element-wise to report nested mismatched paths instead of whole elements.
+	if mode == SubtreeArrayInOrder && len(actual) == len(expected) {
+		for i := range expected {
+			errs = matchSubtree(subtreeIndex(path, i),
+				actual[i], expected[i], mode, errs)
+		}
+		return errs
+	}
+
+	pos := 0
+
+	for i, elem := range expected {
+		found := false
+
+		switch mode {
+		case SubtreeArrayInOrder:
+			// on mismatch, keep position, so that following elements
+			// are still checked and reported independently
+			for j := pos; j < len(actual); j++ {
+				if matches(actual[j], elem) {
+					found = true
+					pos = j + 1
+					break
+				}
+			}
+
+		default:
+			for _, a := range actual {
+				if matches(a, elem) {
+					found = true
+					break
+				}
+			}
+		}
+
+		if !found {
+			if mode == SubtreeArrayInOrder {
+				errs = append(errs, fmt.Errorf(
+					"%s: no matching element found in order, expected %s",
+					subtreeIndex(path, i), formatSubtree(elem)))
+			} else {
+				errs = append(errs, fmt.Errorf(
+					"%s: no matching element found, expected %s",
+					subtreeIndex(path, i), formatSubtree(elem)))
+			}
+		}
+	}
+
+	return errs
+}
+
+func subtreeIndex(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+func formatSubtree(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
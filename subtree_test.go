@@ -0,0 +1,194 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtreeFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newObject(chain, map[string]interface{}{})
+
+	value.ContainsSubtree(map[string]interface{}{})
+	value.chain.assertFailed(t)
+}
+
+func TestSubtreeContains(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := map[string]interface{}{
+		"id": 1,
+		"meta": map[string]interface{}{
+			"version": 2,
+			"owner": map[string]interface{}{
+				"name":  "john",
+				"email": "john@example.com",
+			},
+		},
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice", "roles": []interface{}{"admin", "dev"}},
+			map[string]interface{}{"name": "bob", "roles": []interface{}{"dev"}},
+			map[string]interface{}{"name": "carol", "roles": []interface{}{}},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		partial  interface{}
+		mode     []SubtreeArrayMatch
+		expected bool
+	}{
+		{
+			name:     "empty",
+			partial:  map[string]interface{}{},
+			expected: true,
+		},
+		{
+			name: "nested object",
+			partial: map[string]interface{}{
+				"meta": map[string]interface{}{
+					"owner": map[string]interface{}{"name": "john"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "objects in array",
+			partial: map[string]interface{}{
+				"users": []interface{}{
+					map[string]interface{}{"name": "alice", "roles": []interface{}{"dev"}},
+					map[string]interface{}{"name": "carol"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "wrong order",
+			partial: map[string]interface{}{
+				"users": []interface{}{
+					map[string]interface{}{"name": "carol"},
+					map[string]interface{}{"name": "alice"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "any order",
+			partial: map[string]interface{}{
+				"users": []interface{}{
+					map[string]interface{}{"name": "carol"},
+					map[string]interface{}{"name": "alice"},
+				},
+			},
+			mode:     []SubtreeArrayMatch{SubtreeArrayAnyOrder},
+			expected: true,
+		},
+		{
+			name: "any order missing",
+			partial: map[string]interface{}{
+				"users": []interface{}{
+					map[string]interface{}{"name": "dave"},
+				},
+			},
+			mode:     []SubtreeArrayMatch{SubtreeArrayAnyOrder},
+			expected: false,
+		},
+		{
+			name: "missing key",
+			partial: map[string]interface{}{
+				"meta": map[string]interface{}{"missing": true},
+			},
+			expected: false,
+		},
+		{
+			name: "type mismatch",
+			partial: map[string]interface{}{
+				"meta": []interface{}{},
+			},
+			expected: false,
+		},
+		{
+			name: "value mismatch",
+			partial: map[string]interface{}{
+				"id": 2,
+			},
+			expected: false,
+		},
+		{
+			name:     "multiple modes",
+			partial:  map[string]interface{}{},
+			mode:     []SubtreeArrayMatch{SubtreeArrayInOrder, SubtreeArrayAnyOrder},
+			expected: false,
+		},
+		{
+			name:     "invalid value",
+			partial:  func() {},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := NewObject(reporter, data)
+
+			value.ContainsSubtree(tc.partial, tc.mode...)
+
+			if tc.expected {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+		})
+	}
+}
+
+func TestSubtreeMismatches(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	chain := newChainWithConfig("test", Config{
+		AssertionHandler: handler,
+	}.withDefaults())
+
+	value := newObject(chain, map[string]interface{}{
+		"meta": map[string]interface{}{
+			"version": 2,
+		},
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice", "age": 30},
+			map[string]interface{}{"name": "bob", "age": 40},
+		},
+		"tags": []interface{}{"a", "b", "c"},
+	})
+
+	value.ContainsSubtree(map[string]interface{}{
+		"meta": map[string]interface{}{
+			"version": 3,
+			"owner":   "john",
+		},
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice", "age": 30},
+			map[string]interface{}{"name": "robert"},
+		},
+		"tags": []interface{}{"c", "a"},
+	})
+	value.chain.assertFailed(t)
+
+	require.NotNil(t, handler.failure)
+
+	var errs []string
+	for _, err := range handler.failure.Errors {
+		errs = append(errs, err.Error())
+	}
+
+	assert.Equal(t, []string{
+		`expected: map contains subtree`,
+		`$.meta.owner: missing key`,
+		`$.meta.version: expected 3, got 2`,
+		`$.tags[1]: no matching element found in order, expected "a"`,
+		`$.users[1].name: expected "robert", got "bob"`,
+	}, errs)
+}
@@ -0,0 +1,185 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// Case defines a single row of data-driven test table, see Expect.Table.
+type Case struct {
+	// Name of the case.
+	// If empty, it's generated from case index, method, and path,
+	// e.g. "2 GET /users/1".
+	Name string
+
+	// HTTP method of request.
+	// If empty, "GET" is used.
+	Method string
+
+	// Path of request, may contain {named} parameters.
+	// See Expect.Request for details.
+	Path string
+
+	// Arguments to substitute into path parameters.
+	PathArgs []interface{}
+
+	// Arbitrary case data, e.g. request body and expected status.
+	// Data is included in failure reports via AssertionContext.Case.
+	Data interface{}
+}
+
+// Table runs the same test code for every row of a table.
+//
+// Table is created by Expect.Table. If test is set via WithT, every case
+// is run in its own subtest, named after case. Otherwise, cases are run
+// sequentially within current test.
+//
+// Every assertion made within case, including assertions on request
+// passed to ForEach, has AssertionContext.Case set, so that failure
+// report identifies which row failed.
+type Table struct {
+	chain  *chain
+	expect *Expect
+	cases  []Case
+	t      subtestRunner
+}
+
+// Test which can run subtests, like *testing.T.
+type subtestRunner interface {
+	TestingTB
+	Run(name string, fn func(t *testing.T)) bool
+}
+
+// Table returns a new Table instance for given cases.
+//
+// Example:
+//
+//	e.Table([]httpexpect.Case{
+//	    {Path: "/users/1", Data: http.StatusOK},
+//	    {Path: "/users/999", Data: http.StatusNotFound},
+//	    {Name: "bad id", Path: "/users/abc", Data: http.StatusBadRequest},
+//	}).
+//	    WithT(t).
+//	    ForEach(func(tc httpexpect.Case, r *httpexpect.Request) {
+//	        r.Expect().Status(tc.Data.(int))
+//	    })
+func (e *Expect) Table(cases []Case) *Table {
+	e.chain.enter("Table()")
+	defer e.chain.leave()
+
+	tbl := &Table{
+		chain:  e.chain.clone(),
+		expect: e,
+		cases:  make([]Case, 0, len(cases)),
+	}
+
+	for i, tc := range cases {
+		if tc.Method == "" {
+			tc.Method = http.MethodGet
+		}
+		if tc.Name == "" {
+			tc.Name = fmt.Sprintf("%d %s %s", i+1, tc.Method, tc.Path)
+		}
+		tbl.cases = append(tbl.cases, tc)
+	}
+
+	return tbl
+}
+
+// WithT sets test in which table cases are run as subtests.
+//
+// t is usually *testing.T, but can be any TestingTB implementation that
+// has Run method with the same signature as *testing.T.
+//
+// Every subtest uses a copy of Expect bound to the subtest, see
+// Expect.WithTestName for details.
+//
+// Example:
+//
+//	e.Table(cases).WithT(t).ForEach(fn)
+func (tbl *Table) WithT(t TestingTB) *Table {
+	tbl.chain.enter("WithT()")
+	defer tbl.chain.leave()
+
+	if t == nil {
+		tbl.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil test"),
+			},
+		})
+		return tbl
+	}
+
+	runner, ok := t.(subtestRunner)
+	if !ok {
+		tbl.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected test type %T without suitable Run method", t),
+			},
+		})
+		return tbl
+	}
+
+	tbl.t = runner
+
+	return tbl
+}
+
+// ForEach invokes fn for every case, in order of definition. fn receives
+// the case and a new Request built from case method, path, and path
+// arguments. Builders and matchers attached to Expect are applied to
+// request as usual.
+//
+// Example:
+//
+//	e.Table(cases).ForEach(func(tc httpexpect.Case, r *httpexpect.Request) {
+//	    r.Expect().Status(tc.Data.(int))
+//	})
+func (tbl *Table) ForEach(fn func(tc Case, r *Request)) *Table {
+	tbl.chain.enter("ForEach()")
+	defer tbl.chain.leave()
+
+	if tbl.chain.failed() {
+		return tbl
+	}
+
+	if fn == nil {
+		tbl.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function"),
+			},
+		})
+		return tbl
+	}
+
+	for i := range tbl.cases {
+		tc := tbl.cases[i]
+
+		if tbl.t != nil {
+			tbl.t.Run(tc.Name, func(t *testing.T) {
+				tbl.runCase(tbl.expect.WithTestName(t), tc, fn)
+			})
+		} else {
+			ce := tbl.expect.clone()
+			ce.chain = tbl.chain.clone()
+
+			tbl.runCase(ce, tc, fn)
+		}
+	}
+
+	return tbl
+}
+
+func (tbl *Table) runCase(e *Expect, tc Case, fn func(tc Case, r *Request)) {
+	e.chain.setCase(&tc)
+
+	e.chain.enter("Case(%q)", tc.Name)
+	defer e.chain.leave()
+
+	fn(tc, e.newRequest(tc.Method, tc.Path, tc.PathArgs...))
+}
@@ -0,0 +1,161 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTableHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/1":
+			w.WriteHeader(http.StatusOK)
+		case "/users/abc":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestTableFailed(t *testing.T) {
+	e, _ := newMockExpect(t, createTableHandler())
+
+	tbl := e.Table([]Case{{Path: "/users/1"}})
+	tbl.chain.fail(mockFailure())
+
+	called := false
+
+	tbl.ForEach(func(tc Case, r *Request) {
+		called = true
+	})
+
+	assert.False(t, called)
+	tbl.chain.assertFailed(t)
+}
+
+func TestTableBadUsage(t *testing.T) {
+	e, _ := newMockExpect(t, createTableHandler())
+
+	t.Run("nil func", func(t *testing.T) {
+		tbl := e.Table([]Case{{Path: "/users/1"}})
+
+		tbl.ForEach(nil)
+		tbl.chain.assertFailed(t)
+	})
+
+	t.Run("nil test", func(t *testing.T) {
+		tbl := e.Table([]Case{{Path: "/users/1"}})
+
+		tbl.WithT(nil)
+		tbl.chain.assertFailed(t)
+	})
+
+	t.Run("test without run", func(t *testing.T) {
+		tbl := e.Table([]Case{{Path: "/users/1"}})
+
+		tbl.WithT(&mockTestingTB{newMockReporter(t), "test"})
+		tbl.chain.assertFailed(t)
+	})
+}
+
+func TestTableForEach(t *testing.T) {
+	e, _ := newMockExpect(t, createTableHandler())
+
+	cases := []Case{
+		{Path: "/users/1", Data: http.StatusOK},
+		{Path: "/users/{id}", PathArgs: []interface{}{999}, Data: http.StatusNotFound},
+		{Name: "bad id", Method: "POST", Path: "/users/abc", Data: http.StatusBadRequest},
+	}
+
+	var (
+		names    []string
+		methods  []string
+		statuses []int
+	)
+
+	tbl := e.Table(cases)
+
+	tbl.ForEach(func(tc Case, r *Request) {
+		names = append(names, tc.Name)
+		methods = append(methods, r.httpReq.Method)
+
+		require.NotNil(t, r.chain.context.Case)
+		assert.Equal(t, tc.Name, r.chain.context.Case.Name)
+
+		resp := r.Expect().Status(tc.Data.(int))
+		resp.chain.assertNotFailed(t)
+
+		statuses = append(statuses, resp.Raw().StatusCode)
+	})
+
+	tbl.chain.assertNotFailed(t)
+
+	assert.Equal(t, []string{"1 GET /users/1", "2 GET /users/{id}", "bad id"}, names)
+	assert.Equal(t, []string{"GET", "GET", "POST"}, methods)
+	assert.Equal(t,
+		[]int{http.StatusOK, http.StatusNotFound, http.StatusBadRequest}, statuses)
+}
+
+type tableCaseHandler struct {
+	failedCases []*Case
+}
+
+func (h *tableCaseHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *tableCaseHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.failedCases = append(h.failedCases, ctx.Case)
+}
+
+func TestTableFailureContext(t *testing.T) {
+	handler := &tableCaseHandler{}
+
+	e, _ := newMockExpect(t, createTableHandler())
+	e.chain.handler = handler
+
+	e.Table([]Case{
+		{Name: "first", Path: "/users/1", Data: http.StatusOK},
+		{Name: "second", Path: "/users/2", Data: http.StatusOK},
+		{Name: "third", Path: "/users/1", Data: http.StatusOK},
+	}).ForEach(func(tc Case, r *Request) {
+		r.Expect().Status(tc.Data.(int))
+	})
+
+	require.Equal(t, 1, len(handler.failedCases))
+	require.NotNil(t, handler.failedCases[0])
+
+	assert.Equal(t, "second", handler.failedCases[0].Name)
+	assert.Equal(t, http.StatusOK, handler.failedCases[0].Data)
+}
+
+func TestTableSubtests(t *testing.T) {
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(createTableHandler()),
+		},
+	})
+
+	var names []string
+
+	e.Table([]Case{
+		{Path: "/users/1", Data: http.StatusOK},
+		{Name: "bad id", Path: "/users/abc", Data: http.StatusBadRequest},
+	}).WithT(t).ForEach(func(tc Case, r *Request) {
+		names = append(names, r.chain.context.TestName)
+
+		r.Expect().Status(tc.Data.(int))
+	})
+
+	assert.Equal(t, []string{
+		t.Name() + "/1_GET_/users/1",
+		t.Name() + "/bad_id",
+	}, names)
+}
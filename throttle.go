@@ -0,0 +1,146 @@
+package httpexpect
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit defines client-side throttling of requests.
+//
+// Requests are throttled independently for every host, using token bucket
+// algorithm: on average, no more than PerSecond requests are sent to a host
+// every second, and up to Burst requests can be sent at once.
+//
+// If a request exceeds the limit, it's delayed until it fits into it. Every
+// retry attempt is counted as a separate request.
+//
+// RateLimit is safe for concurrent use and may be shared between multiple
+// Expect instances, e.g. parallel tests hitting the same environment.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    BaseURL:  "https://staging.example.com",
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	    RateLimit: &httpexpect.RateLimit{
+//	        PerSecond: 10,
+//	        Burst:     5,
+//	    },
+//	})
+type RateLimit struct {
+	// Maximum average number of requests per second sent to a single host.
+	// Should be positive.
+	PerSecond float64
+
+	// Maximum number of requests that can be sent to a single host at once.
+	// If zero, 1 is used.
+	Burst int
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+	delayed int
+
+	// for tests
+	nowFn   func() time.Time
+	sleepFn func(d time.Duration) <-chan time.Time
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Delayed returns number of requests that were delayed because they
+// exceeded the limit.
+//
+// Example:
+//
+//	for i := 0; i < 10; i++ {
+//	    e.GET("/path").Expect()
+//	}
+//
+//	assert.Equal(t, 5, limit.Delayed())
+func (l *RateLimit) Delayed() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.delayed
+}
+
+// Wait until request to given host fits into limit.
+// Returns error if context is canceled before that.
+func (l *RateLimit) wait(ctx context.Context, host string) error {
+	delay := l.reserve(host)
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.sleep(delay):
+		return nil
+	}
+}
+
+// Take a token from host bucket, and return how long we should wait
+// until the token becomes available. Token may be taken in advance, in
+// which case bucket goes below zero, so that concurrent requests are
+// queued one after another.
+func (l *RateLimit) reserve(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	burst := float64(l.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	now := l.now()
+
+	if l.buckets == nil {
+		l.buckets = make(map[string]*rateBucket)
+	}
+
+	bucket := l.buckets[host]
+	if bucket == nil {
+		bucket = &rateBucket{
+			tokens: burst,
+			last:   now,
+		}
+		l.buckets[host] = bucket
+	}
+
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens += elapsed.Seconds() * l.PerSecond
+		if bucket.tokens > burst {
+			bucket.tokens = burst
+		}
+		bucket.last = now
+	}
+
+	bucket.tokens--
+
+	if bucket.tokens >= 0 {
+		return 0
+	}
+
+	l.delayed++
+
+	return time.Duration(-bucket.tokens / l.PerSecond * float64(time.Second))
+}
+
+func (l *RateLimit) now() time.Time {
+	if l.nowFn != nil {
+		return l.nowFn()
+	}
+	return time.Now()
+}
+
+func (l *RateLimit) sleep(d time.Duration) <-chan time.Time {
+	if l.sleepFn != nil {
+		return l.sleepFn(d)
+	}
+	return time.After(d)
+}
@@ -0,0 +1,126 @@
+package httpexpect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitReserve(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	limit := &RateLimit{
+		PerSecond: 2,
+		Burst:     3,
+		nowFn: func() time.Time {
+			return now
+		},
+	}
+
+	assert.Equal(t, time.Duration(0), limit.reserve("a"))
+	assert.Equal(t, time.Duration(0), limit.reserve("a"))
+	assert.Equal(t, time.Duration(0), limit.reserve("a"))
+	assert.Equal(t, 500*time.Millisecond, limit.reserve("a"))
+	assert.Equal(t, 1000*time.Millisecond, limit.reserve("a"))
+
+	assert.Equal(t, time.Duration(0), limit.reserve("b"))
+
+	now = now.Add(time.Second)
+
+	assert.Equal(t, 500*time.Millisecond, limit.reserve("a"))
+
+	now = now.Add(time.Hour)
+
+	assert.Equal(t, time.Duration(0), limit.reserve("a"))
+	assert.Equal(t, time.Duration(0), limit.reserve("a"))
+	assert.Equal(t, time.Duration(0), limit.reserve("a"))
+	assert.Equal(t, 500*time.Millisecond, limit.reserve("a"))
+
+	assert.Equal(t, 4, limit.Delayed())
+}
+
+func TestRateLimitDefaultBurst(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	limit := &RateLimit{
+		PerSecond: 10,
+		nowFn: func() time.Time {
+			return now
+		},
+	}
+
+	assert.Equal(t, time.Duration(0), limit.reserve("a"))
+	assert.Equal(t, 100*time.Millisecond, limit.reserve("a"))
+}
+
+func TestRateLimitWait(t *testing.T) {
+	limit := &RateLimit{
+		PerSecond: 1,
+		nowFn: func() time.Time {
+			return time.Unix(0, 0)
+		},
+		sleepFn: func(d time.Duration) <-chan time.Time {
+			return make(chan time.Time)
+		},
+	}
+
+	assert.NoError(t, limit.wait(context.Background(), "a"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Equal(t, context.Canceled, limit.wait(ctx, "a"))
+}
+
+func TestRateLimitRequests(t *testing.T) {
+	var sleeps []time.Duration
+
+	limit := &RateLimit{
+		PerSecond: 10,
+		Burst:     2,
+		nowFn: func() time.Time {
+			return time.Unix(0, 0)
+		},
+		sleepFn: func(d time.Duration) <-chan time.Time {
+			sleeps = append(sleeps, d)
+			ch := make(chan time.Time, 1)
+			ch <- time.Time{}
+			return ch
+		},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	e := WithConfig(Config{
+		BaseURL:   "http://example.com",
+		Reporter:  newMockReporter(t),
+		Client:    &http.Client{Transport: NewBinder(handler)},
+		RateLimit: limit,
+	})
+
+	for i := 0; i < 4; i++ {
+		e.GET("/").Expect().Status(http.StatusOK)
+	}
+
+	e.GET("/").WithURL("http://other.com").Expect().Status(http.StatusOK)
+
+	assert.Equal(t, 2, limit.Delayed())
+	assert.Equal(t, []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+	}, sleeps)
+}
+
+func TestRateLimitInvalid(t *testing.T) {
+	assert.Panics(t, func() {
+		WithConfig(Config{
+			Reporter:  newMockReporter(t),
+			RateLimit: &RateLimit{},
+		})
+	})
+}
@@ -0,0 +1,236 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"sync"
+	"time"
+)
+
+// Timing provides methods to inspect breakdown of response round-trip
+// time, collected using net/http/httptrace.
+//
+// Breakdown is available only when request is sent by a client that
+// supports httptrace, e.g. http.Client with default transport. When
+// some phase didn't happen (e.g. connection was reused, or request was
+// handled by Binder), corresponding Duration is not set.
+type Timing struct {
+	chain *chain
+	trace *timingTrace
+}
+
+func newTiming(parent *chain, trace *timingTrace) *Timing {
+	return &Timing{parent.clone(), trace}
+}
+
+// Timing returns a new Timing instance with breakdown of response
+// round-trip time.
+//
+// If request was retried, timing corresponds to the last attempt.
+//
+// Example:
+//
+//	timing := resp.Timing()
+//	timing.DNS().Lt(10 * time.Millisecond)
+//	timing.Connect().Lt(50 * time.Millisecond)
+//	timing.TTFB().Lt(200 * time.Millisecond)
+func (r *Response) Timing() *Timing {
+	r.chain.enter("Timing()")
+	defer r.chain.leave()
+
+	return newTiming(r.chain, r.timing)
+}
+
+// ConnReused returns a new Boolean instance which is true if request was
+// sent over a connection reused from pool.
+//
+// Example:
+//
+//	resp.Timing().ConnReused().False()
+func (t *Timing) ConnReused() *Boolean {
+	t.chain.enter("ConnReused()")
+	defer t.chain.leave()
+
+	if t.chain.failed() || t.trace == nil {
+		return newBoolean(t.chain, false)
+	}
+
+	t.trace.mu.Lock()
+	defer t.trace.mu.Unlock()
+
+	return newBoolean(t.chain, t.trace.connReused)
+}
+
+// DNS returns a new Duration instance with duration of DNS lookup.
+//
+// Example:
+//
+//	resp.Timing().DNS().Lt(10 * time.Millisecond)
+func (t *Timing) DNS() *Duration {
+	t.chain.enter("DNS()")
+	defer t.chain.leave()
+
+	return t.phase(func(tr *timingTrace) (time.Time, time.Time) {
+		return tr.dnsStart, tr.dnsDone
+	})
+}
+
+// Connect returns a new Duration instance with duration of establishing
+// TCP connection.
+//
+// Example:
+//
+//	resp.Timing().Connect().Lt(50 * time.Millisecond)
+func (t *Timing) Connect() *Duration {
+	t.chain.enter("Connect()")
+	defer t.chain.leave()
+
+	return t.phase(func(tr *timingTrace) (time.Time, time.Time) {
+		return tr.connectStart, tr.connectDone
+	})
+}
+
+// TLSHandshake returns a new Duration instance with duration of TLS
+// handshake.
+//
+// Example:
+//
+//	resp.Timing().TLSHandshake().Lt(100 * time.Millisecond)
+func (t *Timing) TLSHandshake() *Duration {
+	t.chain.enter("TLSHandshake()")
+	defer t.chain.leave()
+
+	return t.phase(func(tr *timingTrace) (time.Time, time.Time) {
+		return tr.tlsStart, tr.tlsDone
+	})
+}
+
+// TTFB returns a new Duration instance with time to first byte, i.e.
+// interval between writing request and receiving first byte of response.
+//
+// Example:
+//
+//	resp.Timing().TTFB().Lt(200 * time.Millisecond)
+func (t *Timing) TTFB() *Duration {
+	t.chain.enter("TTFB()")
+	defer t.chain.leave()
+
+	return t.phase(func(tr *timingTrace) (time.Time, time.Time) {
+		return tr.wroteRequest, tr.firstByte
+	})
+}
+
+// Total returns a new Duration instance with interval between starting
+// request and receiving first byte of response, including DNS lookup,
+// connecting, and TLS handshake.
+//
+// Example:
+//
+//	resp.Timing().Total().Lt(time.Second)
+func (t *Timing) Total() *Duration {
+	t.chain.enter("Total()")
+	defer t.chain.leave()
+
+	return t.phase(func(tr *timingTrace) (time.Time, time.Time) {
+		return tr.start, tr.firstByte
+	})
+}
+
+func (t *Timing) phase(
+	get func(tr *timingTrace) (time.Time, time.Time),
+) *Duration {
+	if t.chain.failed() || t.trace == nil {
+		return newDuration(t.chain, nil)
+	}
+
+	t.trace.mu.Lock()
+	start, end := get(t.trace)
+	t.trace.mu.Unlock()
+
+	if start.IsZero() || end.IsZero() {
+		return newDuration(t.chain, nil)
+	}
+
+	d := end.Sub(start)
+
+	return newDuration(t.chain, &d)
+}
+
+type timingTrace struct {
+	mu sync.Mutex
+
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+
+	connReused bool
+
+	// headers of 103 Early Hints responses, in order of arrival
+	earlyHints []http.Header
+}
+
+func newTimingTrace() *timingTrace {
+	return &timingTrace{
+		start: time.Now(),
+	}
+}
+
+func (tr *timingTrace) set(field *time.Time) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if field.IsZero() {
+		*field = time.Now()
+	}
+}
+
+func (tr *timingTrace) withContext(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			tr.set(&tr.dnsStart)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			tr.set(&tr.dnsDone)
+		},
+		ConnectStart: func(string, string) {
+			tr.set(&tr.connectStart)
+		},
+		ConnectDone: func(string, string, error) {
+			tr.set(&tr.connectDone)
+		},
+		TLSHandshakeStart: func() {
+			tr.set(&tr.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			tr.set(&tr.tlsDone)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			tr.mu.Lock()
+			defer tr.mu.Unlock()
+			tr.connReused = info.Reused
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			tr.set(&tr.wroteRequest)
+		},
+		GotFirstResponseByte: func() {
+			tr.set(&tr.firstByte)
+		},
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			if code == http.StatusEarlyHints {
+				tr.mu.Lock()
+				defer tr.mu.Unlock()
+				tr.earlyHints = append(tr.earlyHints, http.Header(header).Clone())
+			}
+			return nil
+		},
+	})
+}
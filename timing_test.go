@@ -0,0 +1,96 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimingFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	timing := newTiming(chain, newTimingTrace())
+
+	assert.NotNil(t, timing.ConnReused())
+	assert.NotNil(t, timing.DNS())
+	assert.NotNil(t, timing.Connect())
+	assert.NotNil(t, timing.TLSHandshake())
+	assert.NotNil(t, timing.TTFB())
+	assert.NotNil(t, timing.Total())
+
+	timing.chain.assertFailed(t)
+}
+
+func TestTimingPhases(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	base := time.Now()
+
+	trace := &timingTrace{
+		start:        base,
+		connectStart: base.Add(1 * time.Millisecond),
+		connectDone:  base.Add(3 * time.Millisecond),
+		wroteRequest: base.Add(4 * time.Millisecond),
+		firstByte:    base.Add(10 * time.Millisecond),
+	}
+
+	timing := newTiming(newChainWithDefaults("test", reporter), trace)
+
+	timing.Connect().Equal(2 * time.Millisecond)
+	timing.TTFB().Equal(6 * time.Millisecond)
+	timing.Total().Equal(10 * time.Millisecond)
+	timing.ConnReused().False()
+
+	timing.DNS().NotSet()
+	timing.TLSHandshake().NotSet()
+
+	timing.chain.assertNotFailed(t)
+
+	dns := timing.DNS()
+	dns.Lt(time.Second)
+	dns.chain.assertFailed(t)
+}
+
+func TestTimingNoTrace(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{})
+
+	timing := resp.Timing()
+	timing.TTFB().NotSet()
+	timing.ConnReused().False()
+	timing.chain.assertNotFailed(t)
+}
+
+func TestTimingE2E(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer server.Close()
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: reporter,
+	})
+
+	resp := e.GET("/").Expect()
+
+	timing := resp.Timing()
+	timing.Connect().IsSet().Lt(time.Minute)
+	timing.TTFB().IsSet().Lt(time.Minute)
+	timing.Total().IsSet().Lt(time.Minute)
+	timing.ConnReused().False()
+	timing.chain.assertNotFailed(t)
+
+	timing = e.GET("/").Expect().Timing()
+	timing.ConnReused().True()
+	timing.Connect().NotSet()
+	timing.chain.assertNotFailed(t)
+}
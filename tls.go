@@ -0,0 +1,482 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TLS provides methods to inspect attached tls.ConnectionState value.
+type TLS struct {
+	chain *chain
+	value *tls.ConnectionState
+}
+
+// NewTLS returns a new TLS instance.
+//
+// reporter and value should not be nil.
+//
+// Example:
+//
+//	state := NewTLS(reporter, resp.TLS)
+//	state.Version().Equal("TLS 1.3")
+//	state.NegotiatedProtocol().Equal("h2")
+func NewTLS(reporter Reporter, value *tls.ConnectionState) *TLS {
+	return newTLS(newChainWithDefaults("TLS()", reporter), value)
+}
+
+func newTLS(parent *chain, val *tls.ConnectionState) *TLS {
+	t := &TLS{parent.clone(), nil}
+
+	if val == nil {
+		t.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil TLS connection state"),
+			},
+		})
+	} else {
+		t.value = val
+	}
+
+	return t
+}
+
+// Raw returns underlying tls.ConnectionState value attached to TLS.
+// This is the value originally passed to NewTLS.
+//
+// Example:
+//
+//	state := NewTLS(t, cs)
+//	assert.Equal(t, cs, state.Raw())
+func (t *TLS) Raw() *tls.ConnectionState {
+	return t.value
+}
+
+// Version returns a new String instance with negotiated TLS version,
+// e.g. "TLS 1.2" or "TLS 1.3".
+//
+// Example:
+//
+//	state := NewTLS(t, cs)
+//	state.Version().Equal("TLS 1.3")
+func (t *TLS) Version() *String {
+	t.chain.enter("Version()")
+	defer t.chain.leave()
+
+	if t.chain.failed() {
+		return newString(t.chain, "")
+	}
+
+	return newString(t.chain, tlsVersionName(t.value.Version))
+}
+
+// CipherSuite returns a new String instance with name of negotiated
+// cipher suite, e.g. "TLS_AES_128_GCM_SHA256".
+//
+// Example:
+//
+//	state := NewTLS(t, cs)
+//	state.CipherSuite().NotEqual("TLS_RSA_WITH_RC4_128_SHA")
+func (t *TLS) CipherSuite() *String {
+	t.chain.enter("CipherSuite()")
+	defer t.chain.leave()
+
+	if t.chain.failed() {
+		return newString(t.chain, "")
+	}
+
+	return newString(t.chain, tls.CipherSuiteName(t.value.CipherSuite))
+}
+
+// NegotiatedProtocol returns a new String instance with application
+// protocol negotiated using ALPN, e.g. "h2" or "http/1.1".
+// String is empty if ALPN was not used.
+//
+// Example:
+//
+//	state := NewTLS(t, cs)
+//	state.NegotiatedProtocol().Equal("h2")
+func (t *TLS) NegotiatedProtocol() *String {
+	t.chain.enter("NegotiatedProtocol()")
+	defer t.chain.leave()
+
+	if t.chain.failed() {
+		return newString(t.chain, "")
+	}
+
+	return newString(t.chain, t.value.NegotiatedProtocol)
+}
+
+// ServerName returns a new String instance with server name requested
+// by client using SNI.
+//
+// Example:
+//
+//	state := NewTLS(t, cs)
+//	state.ServerName().Equal("example.com")
+func (t *TLS) ServerName() *String {
+	t.chain.enter("ServerName()")
+	defer t.chain.leave()
+
+	if t.chain.failed() {
+		return newString(t.chain, "")
+	}
+
+	return newString(t.chain, t.value.ServerName)
+}
+
+// PeerCertificate returns a new Certificate instance with leaf
+// certificate presented by server.
+//
+// If server didn't present certificates, failure is reported.
+//
+// Example:
+//
+//	state := NewTLS(t, cs)
+//	state.PeerCertificate().DNSNames().ContainsOnly("example.com")
+func (t *TLS) PeerCertificate() *Certificate {
+	t.chain.enter("PeerCertificate()")
+	defer t.chain.leave()
+
+	if t.chain.failed() {
+		return newCertificate(t.chain, nil)
+	}
+
+	if len(t.value.PeerCertificates) == 0 {
+		t.chain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{t.value.PeerCertificates},
+			Errors: []error{
+				errors.New("expected: server presented certificate"),
+			},
+		})
+		return newCertificate(t.chain, nil)
+	}
+
+	return newCertificate(t.chain, t.value.PeerCertificates[0])
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("0x%04X", version)
+	}
+}
+
+// Certificate provides methods to inspect attached x509.Certificate value.
+type Certificate struct {
+	chain *chain
+	value *x509.Certificate
+}
+
+// NewCertificate returns a new Certificate instance.
+//
+// reporter and value should not be nil.
+//
+// Example:
+//
+//	cert := NewCertificate(reporter, x509cert)
+//	cert.CommonName().Equal("example.com")
+//	cert.NotAfter().Gt(time.Now().Add(30 * 24 * time.Hour))
+func NewCertificate(reporter Reporter, value *x509.Certificate) *Certificate {
+	return newCertificate(newChainWithDefaults("Certificate()", reporter), value)
+}
+
+func newCertificate(parent *chain, val *x509.Certificate) *Certificate {
+	c := &Certificate{parent.clone(), nil}
+
+	if val == nil {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil certificate"),
+			},
+		})
+	} else {
+		c.value = val
+	}
+
+	return c
+}
+
+// Raw returns underlying x509.Certificate value attached to Certificate.
+// This is the value originally passed to NewCertificate.
+//
+// Example:
+//
+//	cert := NewCertificate(t, x509cert)
+//	assert.Equal(t, x509cert, cert.Raw())
+func (c *Certificate) Raw() *x509.Certificate {
+	return c.value
+}
+
+// CommonName returns a new String instance with common name (CN)
+// of certificate subject.
+//
+// Example:
+//
+//	cert := NewCertificate(t, x509cert)
+//	cert.CommonName().Equal("example.com")
+func (c *Certificate) CommonName() *String {
+	c.chain.enter("CommonName()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, c.value.Subject.CommonName)
+}
+
+// Issuer returns a new String instance with common name (CN)
+// of certificate issuer.
+//
+// Example:
+//
+//	cert := NewCertificate(t, x509cert)
+//	cert.Issuer().Equal("Example Root CA")
+func (c *Certificate) Issuer() *String {
+	c.chain.enter("Issuer()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newString(c.chain, "")
+	}
+
+	return newString(c.chain, c.value.Issuer.CommonName)
+}
+
+// DNSNames returns a new Array instance with DNS names from subject
+// alternative names (SANs) of certificate.
+//
+// Example:
+//
+//	cert := NewCertificate(t, x509cert)
+//	cert.DNSNames().Contains("api.example.com")
+func (c *Certificate) DNSNames() *Array {
+	c.chain.enter("DNSNames()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newArray(c.chain, nil)
+	}
+
+	names := []interface{}{}
+	for _, name := range c.value.DNSNames {
+		names = append(names, name)
+	}
+
+	return newArray(c.chain, names)
+}
+
+// NotBefore returns a new DateTime instance with time when certificate
+// becomes valid.
+//
+// Example:
+//
+//	cert := NewCertificate(t, x509cert)
+//	cert.NotBefore().Lt(time.Now())
+func (c *Certificate) NotBefore() *DateTime {
+	c.chain.enter("NotBefore()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newDateTime(c.chain, time.Unix(0, 0))
+	}
+
+	return newDateTime(c.chain, c.value.NotBefore)
+}
+
+// NotAfter returns a new DateTime instance with time when certificate
+// expires.
+//
+// Example:
+//
+//	cert := NewCertificate(t, x509cert)
+//	cert.NotAfter().Gt(time.Now().Add(30 * 24 * time.Hour))
+func (c *Certificate) NotAfter() *DateTime {
+	c.chain.enter("NotAfter()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return newDateTime(c.chain, time.Unix(0, 0))
+	}
+
+	return newDateTime(c.chain, c.value.NotAfter)
+}
+
+// ValidFor succeeds if certificate is valid for given host name,
+// i.e. host matches one of subject alternative names (wildcards are
+// supported) or IP addresses.
+//
+// Example:
+//
+//	cert := NewCertificate(t, x509cert)
+//	cert.ValidFor("api.example.com")
+func (c *Certificate) ValidFor(host string) *Certificate {
+	c.chain.enter("ValidFor()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	if err := c.value.VerifyHostname(host); err != nil {
+		c.chain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{certificateNames(c.value)},
+			Expected: &AssertionValue{host},
+			Errors: []error{
+				fmt.Errorf("expected: certificate is valid for host %q", host),
+				err,
+			},
+		})
+	}
+
+	return c
+}
+
+func certificateNames(cert *x509.Certificate) []interface{} {
+	names := []interface{}{}
+	for _, name := range cert.DNSNames {
+		names = append(names, name)
+	}
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return names
+}
+
+// TLS returns a new TLS instance with state of TLS connection over which
+// response was received.
+//
+// If response was not received over TLS, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.TLS().Version().Equal("TLS 1.3")
+//	resp.TLS().NegotiatedProtocol().Equal("h2")
+//	resp.TLS().PeerCertificate().NotAfter().Gt(time.Now().Add(7 * 24 * time.Hour))
+func (r *Response) TLS() *TLS {
+	r.chain.enter("TLS()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newTLS(r.chain, nil)
+	}
+
+	if r.httpResp.TLS == nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.httpResp.TLS},
+			Errors: []error{
+				errors.New("expected: response received over TLS connection"),
+			},
+		})
+		return newTLS(r.chain, nil)
+	}
+
+	return newTLS(r.chain, r.httpResp.TLS)
+}
+
+// WithClientCert sets client certificate and private key, in PEM format,
+// that are presented to server when TLS connection is established.
+// It's needed to access endpoints protected by mutual TLS (mTLS).
+//
+// Client should be *http.Client with *http.Transport or nil transport.
+// Transport is copied, and original client is not modified.
+//
+// Example:
+//
+//	certPEM, _ := ioutil.ReadFile("client.crt")
+//	keyPEM, _ := ioutil.ReadFile("client.key")
+//
+//	req := NewRequestC(config, "GET", "https://example.com/secure")
+//	req.WithClientCert(certPEM, keyPEM)
+func (r *Request) WithClientCert(certPEM, keyPEM []byte) *Request {
+	r.chain.enter("WithClientCert()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return r
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid client certificate or key"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.clientCert = &cert
+
+	return r
+}
+
+func (r *Request) setupClientCert() {
+	if r.clientCert == nil {
+		return
+	}
+
+	httpClient, _ := r.config.Client.(*http.Client)
+	if httpClient == nil {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"WithClientCert() can be used only if Client is *http.Client"),
+			},
+		})
+		return
+	}
+
+	var transport *http.Transport
+
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+
+	case *http.Transport:
+		transport = t.Clone()
+
+	default:
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"WithClientCert() can be used only if Client.Transport" +
+						" is *http.Transport"),
+			},
+		})
+		return
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{*r.clientCert}
+
+	clientCopy := *httpClient
+	clientCopy.Transport = transport
+	r.config.Client = &clientCopy
+}
@@ -0,0 +1,193 @@
+package httpexpect
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCert(t *testing.T, cn string) ([]byte, []byte, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		Issuer:       pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn, "*." + cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth,
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, cert
+}
+
+func TestTLSFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newTLS(chain, &tls.ConnectionState{})
+
+	value.Version()
+	value.CipherSuite()
+	value.NegotiatedProtocol()
+	value.ServerName()
+	value.PeerCertificate()
+
+	cert := newCertificate(chain, &x509.Certificate{})
+
+	cert.CommonName()
+	cert.Issuer()
+	cert.DNSNames()
+	cert.NotBefore()
+	cert.NotAfter()
+	cert.ValidFor("example.com")
+}
+
+func TestTLSNil(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewTLS(reporter, nil)
+	value.chain.assertFailed(t)
+
+	cert := NewCertificate(reporter, nil)
+	cert.chain.assertFailed(t)
+}
+
+func TestTLSGetters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	_, _, x509cert := newTestCert(t, "example.com")
+
+	state := &tls.ConnectionState{
+		Version:            tls.VersionTLS13,
+		CipherSuite:        tls.TLS_AES_128_GCM_SHA256,
+		NegotiatedProtocol: "h2",
+		ServerName:         "api.example.com",
+		PeerCertificates:   []*x509.Certificate{x509cert},
+	}
+
+	value := NewTLS(reporter, state)
+	assert.Same(t, state, value.Raw())
+
+	value.Version().Equal("TLS 1.3")
+	value.CipherSuite().Equal("TLS_AES_128_GCM_SHA256")
+	value.NegotiatedProtocol().Equal("h2")
+	value.ServerName().Equal("api.example.com")
+	value.chain.assertNotFailed(t)
+
+	cert := value.PeerCertificate()
+	assert.Same(t, x509cert, cert.Raw())
+
+	cert.CommonName().Equal("example.com")
+	cert.Issuer().Equal("example.com")
+	cert.DNSNames().ContainsOnly("example.com", "*.example.com")
+	cert.NotBefore().Lt(time.Now())
+	cert.NotAfter().Gt(time.Now())
+	cert.ValidFor("api.example.com")
+	cert.chain.assertNotFailed(t)
+
+	cert.ValidFor("example.org")
+	cert.chain.assertFailed(t)
+
+	value = NewTLS(reporter, &tls.ConnectionState{Version: 0x1234})
+	value.Version().Equal("0x1234")
+	value.chain.assertNotFailed(t)
+
+	value.PeerCertificate()
+	value.chain.assertFailed(t)
+}
+
+func TestTLSResponse(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		TLS: &tls.ConnectionState{Version: tls.VersionTLS12},
+	})
+
+	resp.TLS().Version().Equal("TLS 1.2")
+	resp.chain.assertNotFailed(t)
+
+	resp = NewResponse(reporter, &http.Response{})
+
+	resp.TLS()
+	resp.chain.assertFailed(t)
+}
+
+func TestTLSClientCert(t *testing.T) {
+	certPEM, keyPEM, _ := newTestCert(t, "client")
+
+	t.Run("invalid", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Reporter: newMockReporter(t),
+		}, "GET", "https://example.com")
+
+		req.WithClientCert(certPEM, []byte("bad"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		transport := &http.Transport{}
+		client := &http.Client{Transport: transport}
+
+		req := NewRequestC(Config{
+			Reporter: newMockReporter(t),
+			Client:   client,
+		}, "GET", "https://example.com")
+
+		req.WithClientCert(certPEM, keyPEM)
+		req.chain.assertNotFailed(t)
+
+		req.setupClientCert()
+		req.chain.assertNotFailed(t)
+
+		newClient := req.config.Client.(*http.Client)
+		assert.True(t, client != newClient)
+		if transport.TLSClientConfig != nil {
+			assert.Empty(t, transport.TLSClientConfig.Certificates)
+		}
+
+		newTransport := newClient.Transport.(*http.Transport)
+		assert.Len(t, newTransport.TLSClientConfig.Certificates, 1)
+	})
+
+	t.Run("unsupported transport", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Reporter: newMockReporter(t),
+			Client: &http.Client{
+				Transport: NewBinder(http.NotFoundHandler()),
+			},
+		}, "GET", "https://example.com")
+
+		req.WithClientCert(certPEM, keyPEM)
+		req.setupClientCert()
+		req.chain.assertFailed(t)
+	})
+}
@@ -0,0 +1,395 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// UploadProtocol defines how Request.Upload splits payload into requests.
+type UploadProtocol int
+
+const (
+	// UploadContentRange sends every chunk using method and URL of the
+	// original request, with "Content-Range: bytes <first>-<last>/<total>"
+	// header. Non-final chunks should be answered with 2xx status or
+	// 308 (Resume Incomplete), and the final chunk with 2xx status.
+	UploadContentRange UploadProtocol = iota
+
+	// UploadTus implements tus resumable upload protocol v1.0.0
+	// (https://tus.io/protocols/resumable-upload).
+	//
+	// If original request is HEAD, it's treated as request to existing upload
+	// URL, and upload is resumed from offset reported in Upload-Offset header.
+	// Otherwise, original request (usually POST) creates upload and should
+	// be answered with 201 status and Location header.
+	//
+	// Chunks are then sent to upload URL using PATCH requests with
+	// Upload-Offset header, and should be answered with 204 status and
+	// updated Upload-Offset.
+	UploadTus
+)
+
+// UploadOpts defines how Request.Upload performs chunked upload.
+type UploadOpts struct {
+	// Upload protocol.
+	// If zero, UploadContentRange is used.
+	Protocol UploadProtocol
+
+	// Maximum size of a single chunk, in bytes.
+	// If zero, 1 MiB is used.
+	ChunkSize int
+
+	// Offset of the first chunk.
+	// Used to resume interrupted upload with UploadContentRange protocol.
+	// With UploadTus, offset is determined by the server.
+	Offset int64
+
+	// Maximum number of chunks to send.
+	// Used to interrupt upload and then resume it using another request.
+	// If zero, all chunks are sent.
+	MaxChunks int
+
+	// Check is invoked after every chunk response, to perform additional
+	// assertions on it.
+	// May be nil.
+	Check func(chunk UploadChunk, resp *Response)
+}
+
+// UploadChunk describes chunk passed to UploadOpts.Check.
+type UploadChunk struct {
+	// Zero-based index of chunk in current Upload call.
+	Index int
+
+	// Offset of chunk in payload.
+	Offset int64
+
+	// Size of chunk.
+	Size int
+
+	// Total size of payload.
+	Total int64
+
+	// True if chunk is the last one in payload.
+	Last bool
+}
+
+const defaultUploadChunkSize = 1 << 20
+
+const tusVersion = "1.0.0"
+
+// Upload sends content in chunks using given protocol, and returns
+// response for the last sent request.
+//
+// Request on which Upload is called is used as the first request of the
+// upload sequence, see UploadProtocol. Subsequent requests inherit its
+// headers (except body-related) and config.
+//
+// Built-in assertions specific to protocol are applied to every response,
+// and then opts.Check is invoked. If any of them fails, upload is stopped.
+//
+// Example:
+//
+//	resp := e.POST("/files").
+//	    Upload(payload, httpexpect.UploadOpts{
+//	        Protocol:  httpexpect.UploadTus,
+//	        ChunkSize: 1024,
+//	        Check: func(chunk httpexpect.UploadChunk, resp *httpexpect.Response) {
+//	            resp.Header("Upload-Expires").NotEmpty()
+//	        },
+//	    })
+//
+//	resp.Header("Upload-Offset").Equal(strconv.Itoa(len(payload)))
+func (r *Request) Upload(content []byte, opts UploadOpts) *Response {
+	r.chain.enter("Upload()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newResponse(responseOpts{config: r.config, chain: r.chain})
+	}
+
+	if opts.ChunkSize < 0 || opts.MaxChunks < 0 || opts.Offset < 0 ||
+		opts.Offset > int64(len(content)) {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"unexpected negative or out of range UploadOpts field"),
+			},
+		})
+		return newResponse(responseOpts{config: r.config, chain: r.chain})
+	}
+
+	if opts.Protocol != UploadContentRange && opts.Protocol != UploadTus {
+		r.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected UploadOpts.Protocol: %d", opts.Protocol),
+			},
+		})
+		return newResponse(responseOpts{config: r.config, chain: r.chain})
+	}
+
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = defaultUploadChunkSize
+	}
+
+	// Failures are usually reported by child matchers (e.g. Response
+	// returned by Expect), so we use fail callback inherited by children
+	// to detect them.
+	failed := false
+	parentCb := r.chain.failCb
+	r.chain.setFailCallback(func() {
+		failed = true
+		if parentCb != nil {
+			parentCb()
+		}
+	})
+	defer r.chain.setFailCallback(parentCb)
+
+	u := &uploader{
+		req:     r,
+		content: content,
+		opts:    opts,
+		failed:  &failed,
+	}
+
+	var resp *Response
+	if opts.Protocol == UploadTus {
+		resp = u.uploadTus()
+	} else {
+		resp = u.uploadContentRange()
+	}
+
+	if failed {
+		r.chain.setFailed()
+	}
+
+	return resp
+}
+
+type uploader struct {
+	req     *Request
+	content []byte
+	opts    UploadOpts
+	failed  *bool
+}
+
+func (u *uploader) uploadContentRange() *Response {
+	total := int64(len(u.content))
+	offset := u.opts.Offset
+
+	req := u.req
+
+	var resp *Response
+
+	for index := 0; ; index++ {
+		chunk := u.nextChunk(index, offset)
+
+		if req == nil {
+			req = u.newRequest(u.req.httpReq.Method, u.req.httpReq.URL)
+		}
+
+		if req.httpReq.Header.Get("Content-Type") == "" {
+			req.WithHeader("Content-Type", "application/octet-stream")
+		}
+
+		if total == 0 {
+			req.WithHeader("Content-Range", "bytes */0")
+		} else {
+			req.WithHeader("Content-Range", fmt.Sprintf("bytes %d-%d/%d",
+				chunk.Offset, chunk.Offset+int64(chunk.Size)-1, total))
+		}
+
+		req.WithBytes(u.content[chunk.Offset : chunk.Offset+int64(chunk.Size)])
+
+		resp = req.Expect()
+		if *u.failed {
+			return resp
+		}
+
+		if chunk.Last || resp.httpResp.StatusCode != http.StatusPermanentRedirect {
+			resp.StatusRange(Status2xx)
+		}
+
+		if !u.check(chunk, resp) || chunk.Last || u.interrupted(index) {
+			return resp
+		}
+
+		offset += int64(chunk.Size)
+		req = nil
+	}
+}
+
+func (u *uploader) uploadTus() *Response {
+	var (
+		uploadURL *url.URL
+		offset    int64
+	)
+
+	u.req.WithHeader("Tus-Resumable", tusVersion)
+
+	if u.req.httpReq.Method == http.MethodHead {
+		resp := u.req.Expect()
+		if *u.failed {
+			return resp
+		}
+
+		offset = u.tusOffset(resp.StatusRange(Status2xx))
+		if *u.failed {
+			return resp
+		}
+
+		if offset > int64(len(u.content)) {
+			resp.chain.fail(AssertionFailure{
+				Type:     AssertLe,
+				Actual:   &AssertionValue{offset},
+				Expected: &AssertionValue{len(u.content)},
+				Errors: []error{
+					errors.New("expected: Upload-Offset does not exceed payload size"),
+				},
+			})
+			return resp
+		}
+
+		uploadURL = u.req.httpReq.URL
+
+		if offset == int64(len(u.content)) {
+			return resp
+		}
+	} else {
+		u.req.WithHeader("Upload-Length", strconv.Itoa(len(u.content)))
+
+		resp := u.req.Expect()
+		if *u.failed {
+			return resp
+		}
+
+		location := resp.Status(http.StatusCreated).
+			Header("Location").NotEmpty().Raw()
+		if *u.failed {
+			return resp
+		}
+
+		ref, err := url.Parse(location)
+		if err != nil {
+			resp.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{location},
+				Errors: []error{
+					errors.New("expected: valid Location header"),
+					err,
+				},
+			})
+			return resp
+		}
+
+		uploadURL = u.req.httpReq.URL.ResolveReference(ref)
+
+		if len(u.content) == 0 {
+			return resp
+		}
+	}
+
+	var resp *Response
+
+	for index := 0; ; index++ {
+		chunk := u.nextChunk(index, offset)
+
+		req := u.newRequest(http.MethodPatch, uploadURL)
+
+		req.WithHeader("Tus-Resumable", tusVersion)
+		req.WithHeader("Upload-Offset", strconv.FormatInt(chunk.Offset, 10))
+		req.WithHeader("Content-Type", "application/offset+octet-stream")
+		req.WithBytes(u.content[chunk.Offset : chunk.Offset+int64(chunk.Size)])
+
+		resp = req.Expect()
+		if *u.failed {
+			return resp
+		}
+
+		newOffset := chunk.Offset + int64(chunk.Size)
+
+		resp.Status(http.StatusNoContent).
+			Header("Upload-Offset").Equal(strconv.FormatInt(newOffset, 10))
+
+		if !u.check(chunk, resp) || chunk.Last || u.interrupted(index) {
+			return resp
+		}
+
+		offset = newOffset
+	}
+}
+
+func (u *uploader) nextChunk(index int, offset int64) UploadChunk {
+	total := int64(len(u.content))
+
+	size := int64(u.opts.ChunkSize)
+	if offset+size > total {
+		size = total - offset
+	}
+
+	return UploadChunk{
+		Index:  index,
+		Offset: offset,
+		Size:   int(size),
+		Total:  total,
+		Last:   offset+size == total,
+	}
+}
+
+func (u *uploader) check(chunk UploadChunk, resp *Response) bool {
+	if *u.failed {
+		return false
+	}
+
+	if u.opts.Check != nil {
+		u.opts.Check(chunk, resp)
+	}
+
+	return !*u.failed
+}
+
+func (u *uploader) interrupted(index int) bool {
+	return u.opts.MaxChunks != 0 && index+1 == u.opts.MaxChunks
+}
+
+func (u *uploader) newRequest(method string, reqURL *url.URL) *Request {
+	req := newRequest(u.req.chain, u.req.config, method, "")
+
+	req.WithURL(reqURL.String())
+
+	for k, v := range u.req.httpReq.Header {
+		switch http.CanonicalHeaderKey(k) {
+		case "Content-Length", "Content-Range", "Tus-Resumable",
+			"Upload-Length", "Upload-Offset", "Upload-Metadata":
+			continue
+		}
+		req.httpReq.Header[k] = append([]string(nil), v...)
+	}
+
+	return req
+}
+
+func (u *uploader) tusOffset(resp *Response) int64 {
+	header := resp.Header("Upload-Offset").NotEmpty().Raw()
+	if *u.failed {
+		return 0
+	}
+
+	offset, err := strconv.ParseInt(header, 10, 64)
+	if err != nil || offset < 0 {
+		resp.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: Upload-Offset header is non-negative integer"),
+			},
+		})
+		return 0
+	}
+
+	return offset
+}
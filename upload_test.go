@@ -0,0 +1,226 @@
+package httpexpect
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockUploadServer struct {
+	mu      sync.Mutex
+	data    []byte
+	length  int64
+	ranges  []string
+	patches int
+}
+
+func (s *mockUploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodPut:
+		s.ranges = append(s.ranges, r.Header.Get("Content-Range"))
+		s.data = append(s.data, body...)
+
+		var first, last, total int64
+		_, _ = fmt.Sscanf(r.Header.Get("Content-Range"),
+			"bytes %d-%d/%d", &first, &last, &total)
+
+		if last+1 < total {
+			w.WriteHeader(http.StatusPermanentRedirect)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+	case r.Method == http.MethodPost:
+		if r.Header.Get("Tus-Resumable") != tusVersion {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		s.length, _ = strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+		w.Header().Set("Location", "/files/1")
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodHead:
+		w.Header().Set("Upload-Offset", strconv.Itoa(len(s.data)))
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodPatch && r.URL.Path == "/files/1":
+		offset, _ := strconv.Atoi(r.Header.Get("Upload-Offset"))
+		if offset != len(s.data) ||
+			r.Header.Get("Content-Type") != "application/offset+octet-stream" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.patches++
+		s.data = append(s.data, body...)
+		w.Header().Set("Upload-Offset", strconv.Itoa(len(s.data)))
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestUploadContentRange(t *testing.T) {
+	server := &mockUploadServer{}
+	e, _ := newMockExpect(t, server)
+
+	var chunks []UploadChunk
+
+	resp := e.PUT("/upload").
+		WithHeader("Authorization", "Bearer token").
+		Upload([]byte("0123456789"), UploadOpts{
+			ChunkSize: 4,
+			Check: func(chunk UploadChunk, resp *Response) {
+				chunks = append(chunks, chunk)
+			},
+		})
+
+	resp.chain.assertNotFailed(t)
+	resp.Status(http.StatusOK)
+
+	assert.Equal(t, "0123456789", string(server.data))
+	assert.Equal(t, []string{
+		"bytes 0-3/10",
+		"bytes 4-7/10",
+		"bytes 8-9/10",
+	}, server.ranges)
+
+	assert.Equal(t, 3, len(chunks))
+	assert.Equal(t, UploadChunk{
+		Index: 2, Offset: 8, Size: 2, Total: 10, Last: true,
+	}, chunks[2])
+}
+
+func TestUploadContentRangeResume(t *testing.T) {
+	server := &mockUploadServer{}
+	e, _ := newMockExpect(t, server)
+
+	content := []byte("0123456789")
+
+	resp := e.PUT("/upload").
+		Upload(content, UploadOpts{
+			ChunkSize: 3,
+			MaxChunks: 2,
+		})
+
+	resp.chain.assertNotFailed(t)
+	resp.Status(http.StatusPermanentRedirect)
+
+	assert.Equal(t, "012345", string(server.data))
+
+	resp = e.PUT("/upload").
+		Upload(content, UploadOpts{
+			ChunkSize: 3,
+			Offset:    6,
+		})
+
+	resp.chain.assertNotFailed(t)
+	resp.Status(http.StatusOK)
+
+	assert.Equal(t, "0123456789", string(server.data))
+	assert.Equal(t, "bytes 6-8/10", server.ranges[2])
+}
+
+func TestUploadTus(t *testing.T) {
+	server := &mockUploadServer{}
+	e, _ := newMockExpect(t, server)
+
+	content := []byte("0123456789")
+
+	resp := e.POST("/files").
+		Upload(content, UploadOpts{
+			Protocol:  UploadTus,
+			ChunkSize: 4,
+			MaxChunks: 1,
+		})
+
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, int64(10), server.length)
+	assert.Equal(t, "0123", string(server.data))
+
+	resp = e.HEAD("/files/1").
+		Upload(content, UploadOpts{
+			Protocol:  UploadTus,
+			ChunkSize: 4,
+		})
+
+	resp.chain.assertNotFailed(t)
+	resp.Header("Upload-Offset").Equal("10")
+
+	assert.Equal(t, "0123456789", string(server.data))
+	assert.Equal(t, 3, server.patches)
+}
+
+func TestUploadFailed(t *testing.T) {
+	t.Run("bad status", func(t *testing.T) {
+		server := &mockUploadServer{}
+		e, _ := newMockExpect(t, server)
+
+		resp := e.POST("/upload").
+			Upload([]byte("0123456789"), UploadOpts{
+				ChunkSize: 4,
+			})
+
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("bad offset", func(t *testing.T) {
+		server := &mockUploadServer{data: []byte("xx")}
+		e, _ := newMockExpect(t, server)
+
+		req := e.POST("/files")
+		req.Upload([]byte("0123456789"), UploadOpts{
+			Protocol:  UploadTus,
+			ChunkSize: 4,
+		})
+
+		req.chain.assertFailed(t)
+		assert.Equal(t, 0, server.patches)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		server := &mockUploadServer{}
+		e, _ := newMockExpect(t, server)
+
+		req := e.PUT("/upload")
+		req.Upload([]byte("0123456789"), UploadOpts{
+			ChunkSize: 4,
+			Check: func(chunk UploadChunk, resp *Response) {
+				resp.Status(http.StatusOK)
+			},
+		})
+
+		req.chain.assertFailed(t)
+		assert.Equal(t, 1, len(server.ranges))
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		server := &mockUploadServer{}
+		e, _ := newMockExpect(t, server)
+
+		req1 := e.PUT("/upload")
+		req1.Upload([]byte("0123"), UploadOpts{ChunkSize: -1})
+		req1.chain.assertFailed(t)
+
+		req2 := e.PUT("/upload")
+		req2.Upload([]byte("0123"), UploadOpts{Offset: 5})
+		req2.chain.assertFailed(t)
+
+		req3 := e.PUT("/upload")
+		req3.Upload([]byte("0123"), UploadOpts{Protocol: UploadProtocol(-1)})
+		req3.chain.assertFailed(t)
+
+		assert.Equal(t, 0, len(server.ranges))
+	})
+}
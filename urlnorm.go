@@ -0,0 +1,175 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// PathEscaping defines how path parameters are percent-encoded when
+// request URL is built.
+type PathEscaping int
+
+const (
+	// PathEscapeDefault substitutes path parameters as is. Path is treated
+	// as unescaped, and every character not allowed in URL path, including
+	// percent sign, is percent-encoded. Slash in parameter value acts as
+	// path separator.
+	PathEscapeDefault PathEscaping = iota
+
+	// PathEscapeSegments escapes every path parameter as a single path
+	// segment, e.g. slash becomes "%2F" and question mark becomes "%3F".
+	// Path template is treated as already escaped: valid percent-encoded
+	// sequences are preserved (with hex digits in upper case), and other
+	// characters not allowed in URL path are percent-encoded.
+	PathEscapeSegments
+)
+
+// Formats path parameter value according to escaping mode.
+func (r *Request) pathValue(value interface{}) string {
+	s := fmt.Sprint(value)
+
+	if r.config.PathEscaping == PathEscapeSegments {
+		return url.PathEscape(s)
+	}
+
+	return s
+}
+
+// Converts URL host and path to normalized form: international domain
+// names are converted to punycode, and path is escaped according to
+// Config.PathEscaping.
+func (r *Request) normalizeURL(u *url.URL, path string) error {
+	if err := normalizeHost(u); err != nil {
+		return err
+	}
+
+	if r.config.PathEscaping != PathEscapeSegments {
+		u.Path = concatPaths(u.Path, path)
+		return nil
+	}
+
+	rawPath := normalizeEscapes(concatPaths(u.EscapedPath(), path))
+
+	unescaped, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return err
+	}
+
+	u.Path = unescaped
+	u.RawPath = rawPath
+
+	return nil
+}
+
+// Converts international domain name to ASCII (punycode).
+// ASCII hosts are left as is.
+func normalizeHost(u *url.URL) error {
+	hostname := u.Hostname()
+
+	if isASCII(hostname) || net.ParseIP(hostname) != nil {
+		return nil
+	}
+
+	ascii, err := idna.Lookup.ToASCII(hostname)
+	if err != nil {
+		return fmt.Errorf("invalid international domain name %q: %w", hostname, err)
+	}
+
+	if port := u.Port(); port != "" {
+		u.Host = net.JoinHostPort(ascii, port)
+	} else {
+		u.Host = ascii
+	}
+
+	return nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Percent-encodes characters not allowed in URL path (RFC 3986), and
+// converts hex digits of existing escapes to upper case.
+func normalizeEscapes(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			b.WriteByte('%')
+			b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+			i += 2
+
+		case isPathChar(c):
+			b.WriteByte(c)
+
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+
+	return b.String()
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// Unreserved characters, sub-delimiters, ':', '@', and '/'.
+func isPathChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+
+	return strings.IndexByte("-._~!$&'()*+,;=:@/", c) >= 0
+}
+
+// URL returns a new String instance with URL of request that produced
+// response, i.e. final URL after redirects, in normalized form: host is
+// converted to punycode, and path and query are percent-encoded.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://bücher.example")
+//
+//	resp := e.GET("/café").Expect()
+//	resp.URL().Equal("http://xn--bcher-kva.example/caf%C3%A9")
+func (r *Response) URL() *String {
+	r.chain.enter("URL()")
+	defer r.chain.leave()
+
+	if r.chain.failed() {
+		return newString(r.chain, "")
+	}
+
+	if r.httpResp.Request == nil || r.httpResp.Request.URL == nil {
+		r.chain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.httpResp.Request},
+			Errors: []error{
+				errors.New("expected: response has associated request"),
+			},
+		})
+		return newString(r.chain, "")
+	}
+
+	return newString(r.chain, r.httpResp.Request.URL.String())
+}
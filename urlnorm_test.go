@@ -0,0 +1,129 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLNormalizeEscapes(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+	}{
+		{"/a/b", "/a/b"},
+		{"/caf%c3%a9", "/caf%C3%A9"},
+		{"/café", "/caf%C3%A9"},
+		{"/a b", "/a%20b"},
+		{"/50%", "/50%25"},
+		{"/%zz", "/%25zz"},
+		{"/a:b@c;d=e", "/a:b@c;d=e"},
+		{"/a%2Fb", "/a%2Fb"},
+		{"/[x]", "/%5Bx%5D"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.out, normalizeEscapes(tc.in), tc.in)
+	}
+}
+
+func TestURLNormalizeHost(t *testing.T) {
+	cases := []struct {
+		in   string
+		out  string
+		fail bool
+	}{
+		{"example.com", "example.com", false},
+		{"my_service:8080", "my_service:8080", false},
+		{"127.0.0.1:80", "127.0.0.1:80", false},
+		{"[::1]:80", "[::1]:80", false},
+		{"bücher.example", "xn--bcher-kva.example", false},
+		{"BÜCHER.example:8080", "xn--bcher-kva.example:8080", false},
+		{"пример.рф", "xn--e1afmkfd.xn--p1ai", false},
+		{"a\u200db.example", "", true},
+	}
+
+	for _, tc := range cases {
+		u := &url.URL{Scheme: "http", Host: tc.in}
+
+		err := normalizeHost(u)
+		if tc.fail {
+			assert.Error(t, err, tc.in)
+			continue
+		}
+
+		require.NoError(t, err, tc.in)
+		assert.Equal(t, tc.out, u.Host, tc.in)
+	}
+}
+
+func TestURLNormalizeRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host + " " + r.URL.EscapedPath() + " " + r.URL.RawQuery))
+	})
+
+	newConfig := func(t *testing.T, escaping PathEscaping) Config {
+		return Config{
+			BaseURL:      "http://bücher.example",
+			Reporter:     newMockReporter(t),
+			PathEscaping: escaping,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}
+	}
+
+	t.Run("default", func(t *testing.T) {
+		e := WithConfig(newConfig(t, PathEscapeDefault))
+
+		resp := e.GET("/files/{name}").
+			WithPath("name", "naïve/résumé.txt").
+			WithQuery("q", "日本").
+			Expect()
+
+		resp.Body().Equal(
+			"xn--bcher-kva.example /files/na%C3%AFve/r%C3%A9sum%C3%A9.txt q=%E6%97%A5%E6%9C%AC")
+		resp.URL().Equal(
+			"http://xn--bcher-kva.example/files/na%C3%AFve/r%C3%A9sum%C3%A9.txt" +
+				"?q=%E6%97%A5%E6%9C%AC")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("segments", func(t *testing.T) {
+		e := WithConfig(newConfig(t, PathEscapeSegments))
+
+		resp := e.GET("/files/{dir}/{name}", "a/b").
+			WithPath("name", "x?y z%").
+			Expect()
+
+		resp.Body().Equal("xn--bcher-kva.example /files/a%2Fb/x%3Fy%20z%25 ")
+		resp.URL().Equal("http://xn--bcher-kva.example/files/a%2Fb/x%3Fy%20z%25")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("segments pre-escaped template", func(t *testing.T) {
+		e := WithConfig(newConfig(t, PathEscapeSegments))
+
+		resp := e.GET("/caf%c3%a9/über").Expect()
+
+		resp.Body().Equal("xn--bcher-kva.example /caf%C3%A9/%C3%BCber ")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid host", func(t *testing.T) {
+		config := newConfig(t, PathEscapeDefault)
+		config.BaseURL = "http://a\u200db.example"
+
+		resp := WithConfig(config).GET("/").Expect()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("no request", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{})
+		resp.URL()
+		resp.chain.assertFailed(t)
+	})
+}
@@ -1,8 +1,11 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
+	"math"
 	"reflect"
+	"time"
 )
 
 // Value provides methods to inspect attached interface{} object
@@ -61,6 +64,42 @@ func (v *Value) Raw() interface{} {
 	return v.value
 }
 
+// Decode decodes underlying value into target, which should be a pointer
+// to a variable of any type that can be passed to json.Unmarshal, usually
+// a struct.
+//
+// If Config.StrictDecoding is true, Decode fails if JSON object has fields
+// that don't match any field of the target struct.
+//
+// If Config.Validator is set, decoded struct (or every struct element of
+// decoded slice) is validated, and validation errors are reported as
+// failure. This allows to check "validate" struct tags using
+// go-playground/validator.
+//
+// Example:
+//
+//	type User struct {
+//		Name string `json:"name"`
+//	}
+//
+//	var user User
+//	value := NewValue(t, map[string]interface{}{"name": "john"})
+//	value.Decode(&user)
+//
+//	assert.Equal(t, "john", user.Name)
+//
+// Response body may be decoded the same way:
+//
+//	e.GET("/users/john").Expect().JSON().Decode(&user)
+func (v *Value) Decode(target interface{}) *Value {
+	v.chain.enter("Decode()")
+	defer v.chain.leave()
+
+	jsonUnmarshal(v.chain, v.value, target)
+
+	return v
+}
+
 // Path returns a new Value object for child object(s) matching given
 // JSONPath expression.
 //
@@ -256,6 +295,10 @@ func (v *Value) Number() *Number {
 		return newNumber(v.chain, 0)
 	}
 
+	if data, ok := v.value.(json.Number); ok {
+		return newNumberExact(v.chain, data)
+	}
+
 	data, ok := v.value.(float64)
 
 	if !ok {
@@ -305,6 +348,78 @@ func (v *Value) Boolean() *Boolean {
 	return newBoolean(v.chain, data)
 }
 
+// AsDateTime returns a new DateTime attached to underlying value.
+//
+// If underlying value is a string, it is parsed using given formats, the
+// same way as String.AsDateTime does. If underlying value is a number, it
+// is treated as Unix time in seconds, possibly fractional.
+//
+// If underlying value is neither a string nor a number, or can't be parsed,
+// failure is reported and empty (but non-nil) value is returned.
+//
+// Example:
+//
+//	value := NewValue(t, "2022-01-02T15:04:05Z")
+//	value.AsDateTime().Lt(time.Now())
+//
+//	value := NewValue(t, 1641135845)
+//	value.AsDateTime().Equal(time.Unix(1641135845, 0))
+func (v *Value) AsDateTime(format ...string) *DateTime {
+	v.chain.enter("AsDateTime()")
+	defer v.chain.leave()
+
+	if v.chain.failed() {
+		return newDateTime(v.chain, time.Unix(0, 0))
+	}
+
+	switch data := v.value.(type) {
+	case string:
+		tm, ok := parseDateTime(v.chain, data, format)
+		if !ok {
+			return newDateTime(v.chain, time.Unix(0, 0))
+		}
+		return newDateTime(v.chain, tm)
+
+	case float64:
+		if len(format) != 0 {
+			v.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected format argument for numeric value"),
+				},
+			})
+			return newDateTime(v.chain, time.Unix(0, 0))
+		}
+		sec, frac := math.Modf(data)
+		return newDateTime(v.chain, time.Unix(int64(sec), int64(frac*1e9)))
+
+	case json.Number:
+		if len(format) != 0 {
+			v.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected format argument for numeric value"),
+				},
+			})
+			return newDateTime(v.chain, time.Unix(0, 0))
+		}
+		sec, err := data.Int64()
+		if err == nil {
+			return newDateTime(v.chain, time.Unix(sec, 0))
+		}
+	}
+
+	v.chain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{v.value},
+		Errors: []error{
+			errors.New("expected: value is string or number"),
+		},
+	})
+
+	return newDateTime(v.chain, time.Unix(0, 0))
+}
+
 // Null succeeds if value is nil.
 //
 // Note that non-nil interface{} that points to nil value (e.g. nil slice or map)
@@ -2,9 +2,13 @@ package httpexpect
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +22,8 @@ func TestValueFailed(t *testing.T) {
 
 	value.Path("$")
 	value.Schema("")
+	value.Match(nil)
+	value.IfPresent(func(*Value) {})
 
 	assert.NotNil(t, value.Path("/"))
 
@@ -38,6 +44,167 @@ func TestValueFailed(t *testing.T) {
 
 	value.Equal(nil)
 	value.NotEqual(nil)
+
+	var target interface{}
+	value.Decode(&target)
+}
+
+func TestValueDecode(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	reporter := newMockReporter(t)
+
+	data := map[string]interface{}{
+		"name": "john",
+		"age":  30,
+	}
+
+	var user User
+	NewValue(reporter, data).Decode(&user).chain.assertNotFailed(t)
+	assert.Equal(t, User{Name: "john", Age: 30}, user)
+
+	var str string
+	NewValue(reporter, data).Decode(&str).chain.assertFailed(t)
+
+	NewValue(reporter, data).Decode(user).chain.assertFailed(t)
+	NewValue(reporter, data).Decode(nil).chain.assertFailed(t)
+}
+
+func TestValueDecodeStrict(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	data := map[string]interface{}{
+		"name": "john",
+		"age":  30,
+	}
+
+	for _, strict := range []bool{false, true} {
+		config := Config{
+			Reporter:       newMockReporter(t),
+			StrictDecoding: strict,
+		}.withDefaults()
+
+		var user User
+		value := newValue(newChainWithConfig("Value()", config), data)
+		value.Decode(&user)
+
+		if strict {
+			value.chain.assertFailed(t)
+		} else {
+			value.chain.assertNotFailed(t)
+			assert.Equal(t, User{Name: "john"}, user)
+		}
+
+		var users []User
+		value = newValue(newChainWithConfig("Value()", config),
+			[]interface{}{map[string]interface{}{"name": "bob"}})
+		value.Decode(&users)
+
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, []User{{Name: "bob"}}, users)
+	}
+}
+
+type mockStructValidator struct {
+	calls int
+}
+
+func (v *mockStructValidator) Struct(s interface{}) error {
+	v.calls++
+
+	rv := reflect.ValueOf(s)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unexpected %T", s)
+	}
+
+	if f := rv.Elem().FieldByName("Name"); f.IsValid() && f.String() == "" {
+		return errors.New("Name is required")
+	}
+
+	return nil
+}
+
+func TestValueDecodeValidator(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	newChain := func(validator StructValidator) *chain {
+		return newChainWithConfig("Value()", Config{
+			Reporter:  newMockReporter(t),
+			Validator: validator,
+		}.withDefaults())
+	}
+
+	t.Run("struct", func(t *testing.T) {
+		validator := &mockStructValidator{}
+
+		var user User
+		newValue(newChain(validator), map[string]interface{}{"name": "john"}).
+			Decode(&user).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, 1, validator.calls)
+		assert.Equal(t, "john", user.Name)
+
+		newValue(newChain(validator), map[string]interface{}{"name": ""}).
+			Decode(&user).
+			chain.assertFailed(t)
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		validator := &mockStructValidator{}
+
+		var users []User
+		newArray(newChain(validator), []interface{}{
+			map[string]interface{}{"name": "john"},
+			map[string]interface{}{"name": "bob"},
+		}).
+			Decode(&users).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, 2, validator.calls)
+
+		var ptrs []*User
+		newArray(newChain(validator), []interface{}{
+			map[string]interface{}{"name": "john"},
+			map[string]interface{}{"name": ""},
+		}).
+			Decode(&ptrs).
+			chain.assertFailed(t)
+	})
+
+	t.Run("not struct", func(t *testing.T) {
+		validator := &mockStructValidator{}
+
+		var ids []int
+		newArray(newChain(validator), []interface{}{1, 2}).
+			Decode(&ids).
+			chain.assertNotFailed(t)
+
+		var m map[string]interface{}
+		newObject(newChain(validator), map[string]interface{}{"name": ""}).
+			Decode(&m).
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, 0, validator.calls)
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		validator := &mockStructValidator{}
+
+		var user User
+		newValue(newChain(validator), "john").
+			Decode(&user).
+			chain.assertFailed(t)
+
+		assert.Equal(t, 0, validator.calls)
+	})
 }
 
 func TestValueCastNull(t *testing.T) {
@@ -667,3 +834,38 @@ func TestValueSchema(t *testing.T) {
 	NewValue(reporter, data1).Schema("file:///bad/path").chain.assertFailed(t)
 	NewValue(reporter, data1).Schema("{ bad json").chain.assertFailed(t)
 }
+
+func TestValueAsDateTime(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("string", func(t *testing.T) {
+		value := NewValue(reporter, "2022-01-02T15:04:05Z")
+
+		value.AsDateTime().Equal(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+		value.chain.assertNotFailed(t)
+
+		value.AsDateTime(time.RFC3339).
+			EqualWithZone(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))
+		value.chain.assertNotFailed(t)
+
+		value.AsDateTime(time.RFC1123)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("number", func(t *testing.T) {
+		value := NewValue(reporter, 1641135845.5)
+
+		value.AsDateTime().Equal(time.Unix(1641135845, 500000000))
+		value.chain.assertNotFailed(t)
+
+		value.AsDateTime(time.RFC3339)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("bad", func(t *testing.T) {
+		value := NewValue(reporter, true)
+
+		value.AsDateTime()
+		value.chain.assertFailed(t)
+	})
+}
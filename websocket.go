@@ -1,7 +1,6 @@
 package httpexpect
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -35,6 +34,17 @@ type Websocket struct {
 	writeTimeout time.Duration
 
 	isClosed bool
+
+	// set after first Ping, ExpectPing, or QueueExpect call
+	reader  *websocketReader
+	pingSeq int
+
+	queue          []*websocketExpectation
+	orderTolerance int
+
+	rpcID            int
+	rpcResponses     map[string]map[string]interface{}
+	rpcNotifications []map[string]interface{}
 }
 
 // Deprecated: use NewWebsocketC instead.
@@ -160,9 +170,104 @@ func (c *Websocket) Subprotocol() *String {
 	return newString(c.chain, c.conn.Subprotocol())
 }
 
+// HasSubprotocol succeeds if the server selected a subprotocol during
+// handshake and it belongs to given list of subprotocols offered by the
+// client (via Sec-WebSocket-Protocol request header).
+//
+// If list is empty, any non-empty subprotocol is accepted.
+//
+// Example:
+//
+//	conn := e.GET("/ws").
+//	    WithHeader("Sec-WebSocket-Protocol", "v2.rpc, v1.rpc").
+//	    WithWebsocketUpgrade().
+//	    Expect().
+//	    Websocket()
+//	conn.HasSubprotocol("v2.rpc", "v1.rpc")
+func (c *Websocket) HasSubprotocol(offered ...string) *Websocket {
+	c.chain.enter("HasSubprotocol()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("HasSubprotocol()") {
+		return c
+	}
+
+	protocol := c.conn.Subprotocol()
+
+	if protocol == "" {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{protocol},
+			Errors: []error{
+				errors.New("expected: server selected websocket subprotocol"),
+			},
+		})
+		return c
+	}
+
+	if len(offered) == 0 {
+		return c
+	}
+
+	for _, p := range offered {
+		if p == protocol {
+			return c
+		}
+	}
+
+	list := AssertionList{}
+	for _, p := range offered {
+		list = append(list, p)
+	}
+
+	c.chain.fail(AssertionFailure{
+		Type:     AssertBelongs,
+		Actual:   &AssertionValue{protocol},
+		Expected: &AssertionValue{list},
+		Errors: []error{
+			errors.New("expected: selected websocket subprotocol belongs to offered list"),
+		},
+	})
+
+	return c
+}
+
+// NoSubprotocol succeeds if the server didn't select any subprotocol
+// during handshake.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.NoSubprotocol()
+func (c *Websocket) NoSubprotocol() *Websocket {
+	c.chain.enter("NoSubprotocol()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("NoSubprotocol()") {
+		return c
+	}
+
+	if protocol := c.conn.Subprotocol(); protocol != "" {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{protocol},
+			Errors: []error{
+				errors.New("expected: server didn't select websocket subprotocol"),
+			},
+		})
+	}
+
+	return c
+}
+
 // Expect reads next message from WebSocket connection and
 // returns a new WebsocketMessage instance.
 //
+// Fragmented messages, i.e. messages sent as initial frame followed by
+// continuation frames, are reassembled before being returned, and message
+// type is taken from the initial frame. If connection is closed by peer,
+// returned message is a close message with close code and reason.
+//
 // Example:
 //
 //	msg := conn.Expect()
@@ -297,7 +402,8 @@ func (c *Websocket) CloseWithBytes(b []byte, code ...int) *Websocket {
 }
 
 // CloseWithJSON cleanly closes the underlying WebSocket connection
-// by sending given object (marshaled using json.Marshal()) as a close message
+// by sending given object (marshaled using json.Marshal(), or Config.Marshaler
+// if it's set) as a close message
 // and then waiting (with timeout) for the server to close the connection.
 //
 // WebSocket close code may be optionally specified.
@@ -336,7 +442,7 @@ func (c *Websocket) CloseWithJSON(
 		return c
 	}
 
-	b, err := json.Marshal(object)
+	b, err := marshalJSON(c.chain.marshaler, object)
 
 	if err != nil {
 		c.chain.fail(AssertionFailure{
@@ -463,7 +569,7 @@ func (c *Websocket) WriteText(s string) *Websocket {
 }
 
 // WriteJSON writes to the underlying WebSocket connection given object,
-// marshaled using json.Marshal().
+// marshaled using json.Marshal(), or Config.Marshaler if it's set.
 func (c *Websocket) WriteJSON(object interface{}) *Websocket {
 	c.chain.enter("WriteJSON()")
 	defer c.chain.leave()
@@ -472,7 +578,7 @@ func (c *Websocket) WriteJSON(object interface{}) *Websocket {
 		return c
 	}
 
-	b, err := json.Marshal(object)
+	b, err := marshalJSON(c.chain.marshaler, object)
 
 	if err != nil {
 		c.chain.fail(AssertionFailure{
@@ -519,14 +625,34 @@ func (c *Websocket) checkUnusable(where string) bool {
 }
 
 func (c *Websocket) readMessage() *WebsocketMessage {
-	m := newWebsocketMessage(c.chain)
+	if c.reader != nil {
+		msg, ok := c.reader.read(c.readTimeout)
+		if !ok {
+			c.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to read from websocket: no message in %s",
+						c.readTimeout),
+				},
+			})
+			return nil
+		}
+		return c.makeMessage(msg.typ, msg.content, msg.err)
+	}
 
 	if !c.setReadDeadline() {
 		return nil
 	}
 
-	var err error
-	m.typ, m.content, err = c.conn.ReadMessage()
+	typ, content, err := c.conn.ReadMessage()
+
+	return c.makeMessage(typ, content, err)
+}
+
+func (c *Websocket) makeMessage(typ int, content []byte, err error) *WebsocketMessage {
+	m := newWebsocketMessage(c.chain)
+
+	m.typ, m.content = typ, content
 
 	if err != nil {
 		closeErr, ok := err.(*websocket.CloseError)
@@ -644,6 +770,10 @@ func (c *Websocket) setWriteDeadline() bool {
 }
 
 func (c *Websocket) printRead(typ int, content []byte, closeCode int) {
+	if c.config.RedactionRules != nil {
+		content = c.config.RedactionRules.redactBody(content)
+	}
+
 	for _, printer := range c.config.Printers {
 		if p, ok := printer.(WebsocketPrinter); ok {
 			p.WebsocketRead(typ, content, closeCode)
@@ -652,6 +782,10 @@ func (c *Websocket) printRead(typ int, content []byte, closeCode int) {
 }
 
 func (c *Websocket) printWrite(typ int, content []byte, closeCode int) {
+	if c.config.RedactionRules != nil {
+		content = c.config.RedactionRules.redactBody(content)
+	}
+
 	for _, printer := range c.config.Printers {
 		if p, ok := printer.(WebsocketPrinter); ok {
 			p.WebsocketWrite(typ, content, closeCode)
@@ -0,0 +1,269 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// websocketControlConn is implemented by WebsocketConn implementations that
+// allow to send and handle control frames, like *websocket.Conn.
+type websocketControlConn interface {
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	SetPingHandler(h func(appData string) error)
+	SetPongHandler(h func(appData string) error)
+}
+
+// websocketReader reads connection in background goroutine.
+//
+// Control frames are handled only while connection is being read, so it's
+// necessary to read connection continuously to receive pongs and pings,
+// even if nobody is waiting for data messages. Data messages are queued
+// until they are requested.
+type websocketReader struct {
+	mu       sync.Mutex
+	messages []websocketReadResult
+	notify   chan struct{}
+
+	pongs chan string
+	pings chan string
+}
+
+type websocketReadResult struct {
+	typ     int
+	content []byte
+	err     error
+}
+
+// Ping sends ping control frame to the server and waits until the server
+// responds with pong frame, not longer than given timeout.
+//
+// If timeout expires, or connection doesn't support control frames (e.g.
+// if it's not *websocket.Conn), failure is reported.
+//
+// After the first call of Ping, ExpectPing, or QueueExpect, connection is
+// read in background, and messages are queued until they are requested.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.Ping(time.Second)
+func (c *Websocket) Ping(timeout time.Duration) *Websocket {
+	c.chain.enter("Ping()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("Ping()") || !c.checkControl() || !c.startReader() {
+		return c
+	}
+
+	c.pingSeq++
+	payload := strconv.Itoa(c.pingSeq)
+
+	ctrl := c.conn.(websocketControlConn)
+
+	deadline := time.Now().Add(timeout)
+	if c.writeTimeout != noDuration {
+		deadline = time.Now().Add(c.writeTimeout)
+	}
+
+	if err := ctrl.WriteControl(websocket.PingMessage, []byte(payload), deadline); err != nil {
+		c.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to write ping to websocket"),
+				err,
+			},
+		})
+		return c
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case pong := <-c.reader.pongs:
+			if pong == payload {
+				return c
+			}
+
+		case <-timer.C:
+			c.chain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("expected: pong received within %s", timeout),
+				},
+			})
+			return c
+		}
+	}
+}
+
+// ExpectPing waits until the server sends ping control frame, not longer
+// than given timeout. Pings received after the previous call of ExpectPing
+// (or of Ping) are taken into account too.
+//
+// Pings are answered with pongs automatically. ExpectPing can be called in
+// a loop to verify that server sends heartbeats with given interval.
+//
+// If timeout expires, or connection doesn't support control frames (e.g.
+// if it's not *websocket.Conn), failure is reported.
+//
+// After the first call of Ping, ExpectPing, or QueueExpect, connection is
+// read in background, and messages are queued until they are requested.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	for i := 0; i < 3; i++ {
+//	    conn.ExpectPing(30 * time.Second)
+//	}
+func (c *Websocket) ExpectPing(timeout time.Duration) *Websocket {
+	c.chain.enter("ExpectPing()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("ExpectPing()") || !c.checkControl() || !c.startReader() {
+		return c
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-c.reader.pings:
+		return c
+
+	case <-timer.C:
+		c.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("expected: ping received within %s", timeout),
+			},
+		})
+		return c
+	}
+}
+
+func (c *Websocket) checkControl() bool {
+	if _, ok := c.conn.(websocketControlConn); !ok {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("websocket connection of type %T"+
+					" doesn't support control frames", c.conn),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// Start background reader, if it's not started yet.
+func (c *Websocket) startReader() bool {
+	if c.reader != nil {
+		return true
+	}
+
+	// Pings and pongs are delivered to channels with limited capacity;
+	// if nobody consumes them, extra ones are dropped.
+	r := &websocketReader{
+		notify: make(chan struct{}, 1),
+		pongs:  make(chan string, 16),
+		pings:  make(chan string, 16),
+	}
+
+	if ctrl, ok := c.conn.(websocketControlConn); ok {
+		ctrl.SetPongHandler(func(data string) error {
+			select {
+			case r.pongs <- data:
+			default:
+			}
+			return nil
+		})
+
+		ctrl.SetPingHandler(func(data string) error {
+			select {
+			case r.pings <- data:
+			default:
+			}
+			err := ctrl.WriteControl(websocket.PongMessage, []byte(data),
+				time.Now().Add(time.Second))
+			if err == websocket.ErrCloseSent {
+				return nil
+			}
+			return err
+		})
+	}
+
+	if err := c.conn.SetReadDeadline(infiniteTime); err != nil {
+		c.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to set read deadline for websocket"),
+				err,
+			},
+		})
+		return false
+	}
+
+	c.reader = r
+
+	go r.run(c.conn)
+
+	return true
+}
+
+func (r *websocketReader) run(conn WebsocketConn) {
+	for {
+		typ, content, err := conn.ReadMessage()
+
+		r.mu.Lock()
+		r.messages = append(r.messages, websocketReadResult{typ, content, err})
+		r.mu.Unlock()
+
+		select {
+		case r.notify <- struct{}{}:
+		default:
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Return next queued message, waiting for it not longer than given timeout.
+// Zero timeout means no timeout.
+func (r *websocketReader) read(timeout time.Duration) (websocketReadResult, bool) {
+	var timeoutCh <-chan time.Time
+	if timeout != noDuration {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	for {
+		r.mu.Lock()
+		if len(r.messages) != 0 {
+			msg := r.messages[0]
+			// error is kept in queue, since all subsequent reads will fail
+			if msg.err == nil {
+				r.messages = r.messages[1:]
+			}
+			r.mu.Unlock()
+			return msg, true
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-r.notify:
+		case <-timeoutCh:
+			return websocketReadResult{}, false
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func createHeartbeatHandler(pingInterval time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := &websocket.Upgrader{}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer c.Close()
+
+		done := make(chan struct{})
+		defer close(done)
+
+		if pingInterval != 0 {
+			go func() {
+				ticker := time.NewTicker(pingInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						err := c.WriteControl(websocket.PingMessage, []byte("hb"),
+							time.Now().Add(time.Second))
+						if err != nil {
+							return
+						}
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+
+		for {
+			mt, message, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := c.WriteMessage(mt, message); err != nil {
+				return
+			}
+		}
+	})
+}
+
+func TestWebsocketPing(t *testing.T) {
+	e, reporter := newMockExpect(t, createHeartbeatHandler(0))
+
+	ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+	defer ws.Disconnect()
+
+	ws.WriteText("foo")
+	ws.Ping(time.Second)
+	ws.Ping(time.Second)
+	ws.Expect().TextMessage().Body().Equal("foo")
+
+	ws.WriteText("bar").Expect().TextMessage().Body().Equal("bar")
+
+	ws.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+}
+
+func TestWebsocketExpectPing(t *testing.T) {
+	t.Run("heartbeat", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createHeartbeatHandler(10*time.Millisecond))
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		for i := 0; i < 3; i++ {
+			ws.ExpectPing(time.Second)
+		}
+
+		ws.WriteText("foo").Expect().TextMessage().Body().Equal("foo")
+
+		ws.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createHeartbeatHandler(0))
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.ExpectPing(10 * time.Millisecond)
+		ws.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+}
+
+func TestWebsocketReadTimeout(t *testing.T) {
+	e, _ := newMockExpect(t, createHeartbeatHandler(0))
+
+	ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+	defer ws.Disconnect()
+
+	ws.Ping(time.Second)
+	ws.chain.assertNotFailed(t)
+
+	ws.WithReadTimeout(10 * time.Millisecond).Expect()
+	ws.chain.assertFailed(t)
+}
+
+func TestWebsocketPingUnsupported(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	ws := NewWebsocketC(Config{
+		Reporter: reporter,
+	}, newMockWebsocketConn())
+
+	ws.Ping(time.Second)
+	ws.chain.assertFailed(t)
+	ws.chain.clearFailed()
+
+	ws.ExpectPing(time.Second)
+	ws.chain.assertFailed(t)
+}
@@ -0,0 +1,356 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+)
+
+// WriteJSONRPC writes JSON-RPC 2.0 request with given method and params
+// to the underlying WebSocket connection.
+//
+// Requests are assigned sequential ids, starting from 1. Response can be
+// then read using ExpectJSONRPCResult or ExpectJSONRPCError.
+//
+// params should be an array, a slice, a map, or a struct. If params is
+// nil, it's omitted from request.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.WriteJSONRPC("subtract", []int{42, 23})
+//	conn.ExpectJSONRPCResult().Number().Equal(19)
+func (c *Websocket) WriteJSONRPC(method string, params interface{}) *Websocket {
+	c.chain.enter("WriteJSONRPC()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("WriteJSONRPC()") {
+		return c
+	}
+
+	c.rpcID++
+
+	c.writeJSONRPC(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      c.rpcID,
+		"method":  method,
+	}, params)
+
+	return c
+}
+
+// WriteJSONRPCNotification writes JSON-RPC 2.0 notification (i.e. request
+// without id, which doesn't have response) with given method and params
+// to the underlying WebSocket connection.
+//
+// If params is nil, it's omitted from notification.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.WriteJSONRPCNotification("update", []int{1, 2, 3})
+func (c *Websocket) WriteJSONRPCNotification(method string, params interface{}) *Websocket {
+	c.chain.enter("WriteJSONRPCNotification()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("WriteJSONRPCNotification()") {
+		return c
+	}
+
+	c.writeJSONRPC(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+	}, params)
+
+	return c
+}
+
+// ExpectJSONRPCResult reads JSON-RPC 2.0 response for request with given id
+// and returns a new Value instance with its result.
+//
+// If id is omitted, id of the last request sent by WriteJSONRPC is used.
+//
+// Messages are read until response with given id is received. Responses to
+// other requests and notifications from server that are received meanwhile
+// are retained and can be read later by ExpectJSONRPCResult, ExpectJSONRPCError,
+// and ExpectJSONRPCNotification. Batch responses are supported too.
+//
+// If response contains error instead of result, or if a message is not a
+// valid JSON-RPC 2.0 message, failure is reported.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.WriteJSONRPC("sum", []int{1, 2})
+//	conn.WriteJSONRPC("sum", []int{3, 4})
+//	conn.ExpectJSONRPCResult(2).Number().Equal(7)
+//	conn.ExpectJSONRPCResult(1).Number().Equal(3)
+func (c *Websocket) ExpectJSONRPCResult(id ...int) *Value {
+	c.chain.enter("ExpectJSONRPCResult()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("ExpectJSONRPCResult()") {
+		return newValue(c.chain, nil)
+	}
+
+	resp := c.expectJSONRPCResponse(id)
+	if resp == nil {
+		return newValue(c.chain, nil)
+	}
+
+	if rpcErr, ok := resp["error"]; ok {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertNotContainsKey,
+			Actual: &AssertionValue{resp},
+			Expected: &AssertionValue{
+				"error",
+			},
+			Errors: []error{
+				errors.New("expected: JSON-RPC response contains result"),
+				fmt.Errorf("got JSON-RPC error: %s", formatJSONRPCError(rpcErr)),
+			},
+		})
+		return newValue(c.chain, nil)
+	}
+
+	if _, ok := resp["result"]; !ok {
+		c.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{resp},
+			Expected: &AssertionValue{"result"},
+			Errors: []error{
+				errors.New("expected: JSON-RPC response contains result"),
+			},
+		})
+		return newValue(c.chain, nil)
+	}
+
+	return newValue(c.chain, resp["result"])
+}
+
+// ExpectJSONRPCError reads JSON-RPC 2.0 response for request with given id
+// and returns a new Object instance with its error, which contains "code",
+// "message", and optionally "data" keys.
+//
+// If id is omitted, id of the last request sent by WriteJSONRPC is used.
+// See ExpectJSONRPCResult for details on how messages are read.
+//
+// If response contains result instead of error, failure is reported.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.WriteJSONRPC("foobar", nil)
+//	conn.ExpectJSONRPCError().ValueEqual("code", -32601)
+func (c *Websocket) ExpectJSONRPCError(id ...int) *Object {
+	c.chain.enter("ExpectJSONRPCError()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("ExpectJSONRPCError()") {
+		return newObject(c.chain, nil)
+	}
+
+	resp := c.expectJSONRPCResponse(id)
+	if resp == nil {
+		return newObject(c.chain, nil)
+	}
+
+	rpcErr, ok := resp["error"].(map[string]interface{})
+	if !ok {
+		c.chain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{resp},
+			Expected: &AssertionValue{"error"},
+			Errors: []error{
+				errors.New("expected: JSON-RPC response contains error object"),
+			},
+		})
+		return newObject(c.chain, nil)
+	}
+
+	return newObject(c.chain, rpcErr)
+}
+
+// ExpectJSONRPCNotification reads JSON-RPC 2.0 notification (i.e. request
+// from server) with given method and returns a new Value instance with its
+// params, or null if notification has no params.
+//
+// See ExpectJSONRPCResult for details on how messages are read.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.WriteJSONRPC("subscribe", []string{"prices"})
+//	conn.ExpectJSONRPCResult().Boolean().True()
+//	conn.ExpectJSONRPCNotification("price").Object().ContainsKey("value")
+func (c *Websocket) ExpectJSONRPCNotification(method string) *Value {
+	c.chain.enter("ExpectJSONRPCNotification(%q)", method)
+	defer c.chain.leave()
+
+	if c.checkUnusable("ExpectJSONRPCNotification()") {
+		return newValue(c.chain, nil)
+	}
+
+	for {
+		for i, n := range c.rpcNotifications {
+			if n["method"] == method {
+				c.rpcNotifications = append(
+					c.rpcNotifications[:i:i], c.rpcNotifications[i+1:]...)
+				return newValue(c.chain, n["params"])
+			}
+		}
+
+		if !c.readJSONRPC() {
+			return newValue(c.chain, nil)
+		}
+	}
+}
+
+func (c *Websocket) writeJSONRPC(msg map[string]interface{}, params interface{}) {
+	if params != nil {
+		msg["params"] = params
+	}
+
+	b, err := marshalJSON(c.chain.marshaler, msg)
+	if err != nil {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{params},
+			Errors: []error{
+				errors.New("invalid json-rpc params"),
+				err,
+			},
+		})
+		return
+	}
+
+	c.writeMessage(websocket.TextMessage, b)
+}
+
+func (c *Websocket) expectJSONRPCResponse(id []int) map[string]interface{} {
+	if len(id) > 1 {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple id arguments"),
+			},
+		})
+		return nil
+	}
+
+	var key string
+	if len(id) != 0 {
+		key = strconv.Itoa(id[0])
+	} else if c.rpcID != 0 {
+		key = strconv.Itoa(c.rpcID)
+	} else {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("id should be specified if no request was sent by WriteJSONRPC"),
+			},
+		})
+		return nil
+	}
+
+	for {
+		if resp, ok := c.rpcResponses[key]; ok {
+			delete(c.rpcResponses, key)
+			return resp
+		}
+
+		if !c.readJSONRPC() {
+			return nil
+		}
+	}
+}
+
+// Read next message and store responses and notifications it contains.
+func (c *Websocket) readJSONRPC() bool {
+	m := c.readMessage()
+	if m == nil {
+		return false
+	}
+
+	if m.typ == websocket.CloseMessage {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertNotEqual,
+			Actual: &AssertionValue{wsMessageType(m.typ)},
+			Expected: &AssertionValue{
+				wsMessageType(websocket.CloseMessage),
+			},
+			Errors: []error{
+				errors.New("expected: JSON-RPC message, got close message"),
+			},
+		})
+		return false
+	}
+
+	value, err := jsonDecode(c.chain, m.content)
+	if err != nil {
+		c.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(m.content)},
+			Errors: []error{
+				errors.New("expected: JSON-RPC message is valid json"),
+				err,
+			},
+		})
+		return false
+	}
+
+	batch, ok := value.([]interface{})
+	if !ok {
+		batch = []interface{}{value}
+	}
+
+	for _, item := range batch {
+		msg, ok := item.(map[string]interface{})
+		if !ok || msg["jsonrpc"] != "2.0" {
+			c.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{item},
+				Errors: []error{
+					errors.New(`expected: JSON-RPC message is object with "jsonrpc": "2.0"`),
+				},
+			})
+			return false
+		}
+
+		if _, ok := msg["method"]; ok {
+			c.rpcNotifications = append(c.rpcNotifications, msg)
+			continue
+		}
+
+		if c.rpcResponses == nil {
+			c.rpcResponses = map[string]map[string]interface{}{}
+		}
+		c.rpcResponses[jsonRPCIDKey(msg["id"])] = msg
+	}
+
+	return true
+}
+
+func jsonRPCIDKey(id interface{}) string {
+	switch v := id.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatJSONRPCError(rpcErr interface{}) string {
+	obj, ok := rpcErr.(map[string]interface{})
+	if !ok {
+		return fmt.Sprint(rpcErr)
+	}
+	return fmt.Sprintf("code %v: %v", obj["code"], obj["message"])
+}
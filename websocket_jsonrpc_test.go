@@ -0,0 +1,192 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonRPCMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      interface{}     `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   interface{}     `json:"error,omitempty"`
+}
+
+func createJSONRPCHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := &websocket.Upgrader{}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer c.Close()
+
+		var held *jsonRPCMessage
+
+		for {
+			var req jsonRPCMessage
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+
+			resp := jsonRPCMessage{JSONRPC: "2.0", ID: req.ID}
+
+			switch req.Method {
+			case "sum":
+				var args []int
+				_ = json.Unmarshal(req.Params, &args)
+				sum := 0
+				for _, a := range args {
+					sum += a
+				}
+				resp.Result = sum
+
+			case "subscribe":
+				_ = c.WriteJSON(jsonRPCMessage{
+					JSONRPC: "2.0",
+					Method:  "price",
+					Params:  json.RawMessage(`{"value": 42}`),
+				})
+				resp.Result = true
+
+			case "hold":
+				held = &resp
+				held.Result = "held"
+				continue
+
+			case "release":
+				resp.Result = "released"
+				_ = c.WriteJSON([]interface{}{resp, held})
+				continue
+
+			case "notify":
+				continue
+
+			case "garbage":
+				_ = c.WriteMessage(websocket.TextMessage, []byte(`{"foo": "bar"}`))
+				continue
+
+			default:
+				resp.Error = map[string]interface{}{
+					"code":    -32601,
+					"message": "Method not found",
+				}
+			}
+
+			_ = c.WriteJSON(resp)
+		}
+	})
+}
+
+func TestWebsocketJSONRPC(t *testing.T) {
+	t.Run("result", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createJSONRPCHandler())
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.WriteJSONRPC("sum", []int{1, 2})
+		ws.ExpectJSONRPCResult().Number().Equal(3)
+
+		ws.WriteJSONRPC("sum", []int{3, 4})
+		ws.ExpectJSONRPCResult(2).Number().Equal(7)
+
+		ws.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createJSONRPCHandler())
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.WriteJSONRPC("foobar", nil)
+		ws.ExpectJSONRPCError().ValueEqual("code", -32601)
+		ws.chain.assertNotFailed(t)
+
+		ws.WriteJSONRPC("foobar", nil)
+		ws.ExpectJSONRPCResult()
+		ws.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("unexpected result", func(t *testing.T) {
+		e, _ := newMockExpect(t, createJSONRPCHandler())
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.WriteJSONRPC("sum", []int{1})
+		ws.ExpectJSONRPCError()
+		ws.chain.assertFailed(t)
+	})
+
+	t.Run("notification", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createJSONRPCHandler())
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.WriteJSONRPC("subscribe", []string{"prices"})
+		ws.ExpectJSONRPCResult().Boolean().True()
+		ws.ExpectJSONRPCNotification("price").Object().ValueEqual("value", 42)
+
+		ws.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("out of order batch", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createJSONRPCHandler())
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.WriteJSONRPCNotification("notify", nil)
+		ws.WriteJSONRPC("hold", nil)
+		ws.WriteJSONRPC("release", nil)
+
+		ws.ExpectJSONRPCResult(1).String().Equal("held")
+		ws.ExpectJSONRPCResult(2).String().Equal("released")
+
+		ws.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createJSONRPCHandler())
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.WriteJSONRPC("garbage", nil)
+		ws.ExpectJSONRPCResult()
+		ws.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		e, _ := newMockExpect(t, createJSONRPCHandler())
+
+		ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+		defer ws.Disconnect()
+
+		ws.ExpectJSONRPCResult()
+		ws.chain.assertFailed(t)
+		ws.chain.clearFailed()
+
+		ws.ExpectJSONRPCResult(1, 2)
+		ws.chain.assertFailed(t)
+		ws.chain.clearFailed()
+
+		ws.WriteJSONRPC("sum", func() {})
+		ws.chain.assertFailed(t)
+	})
+}
@@ -0,0 +1,311 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebsocketLoadOpts defines how Expect.WebsocketLoad runs concurrent
+// WebSocket connections.
+type WebsocketLoadOpts struct {
+	// Number of concurrent connections.
+	// If zero, 10 is used.
+	Connections int
+
+	// Request constructs handshake request for connection with given index.
+	// WithWebsocketUpgrade is called on returned request automatically.
+	// Should not be nil.
+	Request func(e *Expect, index int) *Request
+
+	// Script is run for every established connection, concurrently.
+	// Connection is disconnected after script returns, unless it was
+	// already closed by script.
+	// May be nil.
+	Script func(index int, ws *Websocket)
+
+	// Maximum number of connections that are allowed to fail.
+	// If more connections fail, aggregated failure is reported.
+	// If zero, any failed connection causes failure.
+	MaxFailures int
+}
+
+const defaultWebsocketConnections = 10
+
+// WebsocketLoad provides methods to inspect results of concurrent
+// WebSocket connections opened by Expect.WebsocketLoad.
+type WebsocketLoad struct {
+	chain   *chain
+	results []websocketLoadResult
+	latency *Latency
+}
+
+type websocketLoadResult struct {
+	failures []*AssertionFailure
+}
+
+// WebsocketLoad opens opts.Connections concurrent WebSocket connections,
+// runs opts.Script for every connection, and aggregates failures and
+// message latencies.
+//
+// Failures reported by individual connections (including handshake
+// failures) are not reported immediately. Instead, if more than
+// opts.MaxFailures connections failed, a single failure listing errors
+// of every failed connection is reported.
+//
+// Message latency is measured as time elapsed between writing a message
+// and reading the next message on the same connection.
+//
+// Example:
+//
+//	load := e.WebsocketLoad(httpexpect.WebsocketLoadOpts{
+//	    Connections: 50,
+//	    Request: func(e *httpexpect.Expect, index int) *httpexpect.Request {
+//	        return e.GET("/chat").WithQuery("user", index)
+//	    },
+//	    Script: func(index int, ws *httpexpect.Websocket) {
+//	        ws.WriteText("ping").
+//	            Expect().
+//	            TextMessage().Body().Equal("pong")
+//	    },
+//	})
+//
+//	load.Latency().Percentile(95).Lt(50 * time.Millisecond)
+func (e *Expect) WebsocketLoad(opts WebsocketLoadOpts) *WebsocketLoad {
+	e.chain.enter("WebsocketLoad()")
+	defer e.chain.leave()
+
+	if e.chain.failed() {
+		return newWebsocketLoad(e.chain, nil)
+	}
+
+	if opts.Request == nil {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil WebsocketLoadOpts.Request"),
+			},
+		})
+		return newWebsocketLoad(e.chain, nil)
+	}
+
+	if opts.Connections < 0 || opts.MaxFailures < 0 {
+		e.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative WebsocketLoadOpts field"),
+			},
+		})
+		return newWebsocketLoad(e.chain, nil)
+	}
+
+	connections := opts.Connections
+	if connections == 0 {
+		connections = defaultWebsocketConnections
+	}
+
+	load := newWebsocketLoad(e.chain, make([]websocketLoadResult, connections))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < connections; i++ {
+		wg.Add(1)
+
+		go func(index int) {
+			defer wg.Done()
+
+			load.results[index].failures =
+				runWebsocketLoadConn(e, opts, index, load.latency)
+		}(i)
+	}
+
+	wg.Wait()
+
+	var failedErrors []error
+	for i, res := range load.results {
+		if len(res.failures) != 0 {
+			failedErrors = append(failedErrors,
+				fmt.Errorf("connection %d: %s", i, joinFailures(res.failures)))
+		}
+	}
+
+	if len(failedErrors) > opts.MaxFailures {
+		load.chain.fail(AssertionFailure{
+			Type:     AssertLe,
+			Actual:   &AssertionValue{len(failedErrors)},
+			Expected: &AssertionValue{opts.MaxFailures},
+			Errors: append([]error{
+				fmt.Errorf("expected: at most %d of %d websocket connections fail",
+					opts.MaxFailures, connections),
+			}, failedErrors...),
+		})
+	}
+
+	return load
+}
+
+func newWebsocketLoad(parent *chain, results []websocketLoadResult) *WebsocketLoad {
+	l := &WebsocketLoad{
+		chain:   parent.clone(),
+		results: results,
+	}
+
+	l.latency = newLatency(l.chain)
+
+	return l
+}
+
+// Run single connection using a copy of Expect that collects failures
+// instead of reporting them.
+func runWebsocketLoadConn(
+	e *Expect, opts WebsocketLoadOpts, index int, latency *Latency,
+) []*AssertionFailure {
+	handler := &websocketLoadHandler{}
+
+	ce := e.clone()
+	ce.chain = e.chain.clone()
+	ce.chain.handler = handler
+
+	req := opts.Request(ce, index)
+	if req == nil {
+		return []*AssertionFailure{{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil request returned by WebsocketLoadOpts.Request"),
+			},
+		}}
+	}
+
+	ws := req.WithWebsocketUpgrade().Expect().Websocket()
+
+	if ws.conn != nil {
+		ws.conn = &timedWebsocketConn{WebsocketConn: ws.conn, latency: latency}
+
+		if opts.Script != nil {
+			opts.Script(index, ws)
+		}
+
+		ws.Disconnect()
+	}
+
+	return handler.failures
+}
+
+func joinFailures(failures []*AssertionFailure) string {
+	var msgs []string
+	for _, failure := range failures {
+		for _, err := range failure.Errors {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AssertionHandler that collects failures of a single connection.
+type websocketLoadHandler struct {
+	mu       sync.Mutex
+	failures []*AssertionFailure
+}
+
+func (h *websocketLoadHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *websocketLoadHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, failure)
+}
+
+// WebsocketConn that measures time between write and next read.
+type timedWebsocketConn struct {
+	WebsocketConn
+
+	latency   *Latency
+	lastWrite time.Time
+}
+
+func (c *timedWebsocketConn) ReadMessage() (int, []byte, error) {
+	typ, p, err := c.WebsocketConn.ReadMessage()
+
+	if err == nil && !c.lastWrite.IsZero() {
+		c.latency.AddDuration(time.Since(c.lastWrite))
+		c.lastWrite = time.Time{}
+	}
+
+	return typ, p, err
+}
+
+func (c *timedWebsocketConn) WriteMessage(typ int, data []byte) error {
+	err := c.WebsocketConn.WriteMessage(typ, data)
+
+	if err == nil && c.lastWrite.IsZero() {
+		c.lastWrite = time.Now()
+	}
+
+	return err
+}
+
+// Raw returns number of failures of every connection.
+func (l *WebsocketLoad) Raw() []int {
+	counts := make([]int, len(l.results))
+	for i, res := range l.results {
+		counts[i] = len(res.failures)
+	}
+	return counts
+}
+
+// Connections returns a new Number instance with total number of
+// connections.
+//
+// Example:
+//
+//	load.Connections().Equal(50)
+func (l *WebsocketLoad) Connections() *Number {
+	l.chain.enter("Connections()")
+	defer l.chain.leave()
+
+	if l.chain.failed() {
+		return newNumber(l.chain, 0)
+	}
+
+	return newNumber(l.chain, float64(len(l.results)))
+}
+
+// Failed returns a new Number instance with number of connections
+// that reported at least one failure.
+//
+// Example:
+//
+//	load.Failed().Le(2)
+func (l *WebsocketLoad) Failed() *Number {
+	l.chain.enter("Failed()")
+	defer l.chain.leave()
+
+	if l.chain.failed() {
+		return newNumber(l.chain, 0)
+	}
+
+	failed := 0
+	for _, res := range l.results {
+		if len(res.failures) != 0 {
+			failed++
+		}
+	}
+
+	return newNumber(l.chain, float64(failed))
+}
+
+// Latency returns Latency instance with message latencies collected from
+// all connections.
+//
+// Example:
+//
+//	load.Latency().Max().Lt(time.Second)
+func (l *WebsocketLoad) Latency() *Latency {
+	return l.latency
+}
@@ -0,0 +1,108 @@
+package httpexpect
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func websocketLoadRequest(e *Expect, index int) *Request {
+	return e.GET("/test")
+}
+
+func TestWebsocketLoad(t *testing.T) {
+	e, reporter := newMockExpect(t, createWebsocketHandler(wsHandlerOpts{}))
+
+	load := e.WebsocketLoad(WebsocketLoadOpts{
+		Connections: 8,
+		Request:     websocketLoadRequest,
+		Script: func(index int, ws *Websocket) {
+			ws.WriteText("foo").Expect().TextMessage().Body().Equal("foo")
+			ws.WriteText("bar").Expect().TextMessage().Body().Equal("bar")
+		},
+	})
+
+	load.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+
+	load.Connections().Equal(8)
+	load.Failed().Equal(0)
+	load.Latency().Count().Equal(16)
+
+	assert.Equal(t, make([]int, 8), load.Raw())
+}
+
+func TestWebsocketLoadFailures(t *testing.T) {
+	script := func(index int, ws *Websocket) {
+		msg := "foo"
+		if index%2 == 1 {
+			msg = "bar"
+		}
+		ws.WriteText(msg).Expect().TextMessage().Body().Equal("foo")
+	}
+
+	t.Run("reported", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createWebsocketHandler(wsHandlerOpts{}))
+
+		load := e.WebsocketLoad(WebsocketLoadOpts{
+			Connections: 4,
+			Request:     websocketLoadRequest,
+			Script:      script,
+			MaxFailures: 1,
+		})
+
+		load.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+
+		assert.Equal(t, []int{0, 1, 0, 1}, load.Raw())
+	})
+
+	t.Run("tolerated", func(t *testing.T) {
+		e, reporter := newMockExpect(t, createWebsocketHandler(wsHandlerOpts{}))
+
+		load := e.WebsocketLoad(WebsocketLoadOpts{
+			Connections: 4,
+			Request:     websocketLoadRequest,
+			Script:      script,
+			MaxFailures: 2,
+		})
+
+		load.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+
+		load.Failed().Equal(2)
+	})
+
+	t.Run("handshake", func(t *testing.T) {
+		server := httptest.NewServer(createWebsocketHandler(wsHandlerOpts{}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		load := e.WebsocketLoad(WebsocketLoadOpts{
+			Connections: 2,
+			Request: func(e *Expect, index int) *Request {
+				return e.GET("/empty")
+			},
+		})
+
+		load.chain.assertFailed(t)
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		e, _ := newMockExpect(t, createWebsocketHandler(wsHandlerOpts{}))
+
+		load1 := e.WebsocketLoad(WebsocketLoadOpts{})
+		load1.chain.assertFailed(t)
+
+		load2 := e.WebsocketLoad(WebsocketLoadOpts{
+			Connections: -1,
+			Request:     websocketLoadRequest,
+		})
+		load2.chain.assertFailed(t)
+	})
+}
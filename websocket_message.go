@@ -1,8 +1,9 @@
 package httpexpect
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
+	"fmt"
 
 	"github.com/gorilla/websocket"
 )
@@ -386,6 +387,94 @@ func (m *WebsocketMessage) NotCode(code ...int) *WebsocketMessage {
 	return m
 }
 
+// CodeInRange succeeds if WebSocket close code is within given range [min; max].
+//
+// CodeInRange fails if WebSocket message type is not "8 - Connection Close Frame".
+//
+// It's useful to check close code class, e.g. 4000-4999 codes that are
+// reserved for private use by applications, or 1000-2999 codes that are
+// defined by the protocol.
+//
+// Example:
+//
+//	msg := conn.Expect().CloseMessage()
+//	msg.CodeInRange(4000, 4999)
+func (m *WebsocketMessage) CodeInRange(min, max int) *WebsocketMessage {
+	m.chain.enter("CodeInRange()")
+	defer m.chain.leave()
+
+	if m.chain.failed() {
+		return m
+	}
+
+	if min > max {
+		m.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("invalid range: min %d is greater than max %d", min, max),
+			},
+		})
+		return m
+	}
+
+	if !m.checkClose() {
+		return m
+	}
+
+	if m.closeCode < min || m.closeCode > max {
+		m.chain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{wsCloseCode(m.closeCode)},
+			Expected: &AssertionValue{AssertionRange{
+				Min: wsCloseCode(min),
+				Max: wsCloseCode(max),
+			}},
+			Errors: []error{
+				errors.New("expected: close code is within given range"),
+			},
+		})
+	}
+
+	return m
+}
+
+// Reason returns a new String instance with close reason of WebSocket
+// message, i.e. text that follows close code in close frame payload.
+//
+// Reason fails if WebSocket message type is not "8 - Connection Close Frame".
+//
+// Example:
+//
+//	msg := conn.Expect().CloseMessage()
+//	msg.Code(websocket.ClosePolicyViolation)
+//	msg.Reason().Equal("token expired")
+func (m *WebsocketMessage) Reason() *String {
+	m.chain.enter("Reason()")
+	defer m.chain.leave()
+
+	if m.chain.failed() || !m.checkClose() {
+		return newString(m.chain, "")
+	}
+
+	return newString(m.chain, string(m.content))
+}
+
+func (m *WebsocketMessage) checkClose() bool {
+	if m.typ != websocket.CloseMessage {
+		m.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{wsMessageType(m.typ)},
+			Expected: &AssertionValue{wsMessageType(websocket.CloseMessage)},
+			Errors: []error{
+				errors.New("expected: close message"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
 // Body returns a new String instance with WebSocket message content.
 //
 // Example:
@@ -404,6 +493,62 @@ func (m *WebsocketMessage) Body() *String {
 	return newString(m.chain, string(m.content))
 }
 
+// Bytes returns a new Array instance with WebSocket message content,
+// where every element is a Number with value of a single byte.
+//
+// It's useful to inspect binary messages. To check checksum of content,
+// use Body().Checksum().
+//
+// Example:
+//
+//	msg := conn.Expect().BinaryMessage()
+//	msg.Bytes().Length().Equal(4)
+//	msg.Bytes().Element(0).Number().Equal(0xCA)
+//	msg.Body().Checksum("sha256", expectedSum)
+func (m *WebsocketMessage) Bytes() *Array {
+	m.chain.enter("Bytes()")
+	defer m.chain.leave()
+
+	if m.chain.failed() {
+		return newArray(m.chain, nil)
+	}
+
+	elements := make([]interface{}, 0, len(m.content))
+	for _, b := range m.content {
+		elements = append(elements, float64(b))
+	}
+
+	return newArray(m.chain, elements)
+}
+
+// BytesEqual succeeds if WebSocket message content is equal to given bytes.
+//
+// Example:
+//
+//	msg := conn.Expect().BinaryMessage()
+//	msg.BytesEqual([]byte{0xCA, 0xFE, 0xBA, 0xBE})
+func (m *WebsocketMessage) BytesEqual(b []byte) *WebsocketMessage {
+	m.chain.enter("BytesEqual()")
+	defer m.chain.leave()
+
+	if m.chain.failed() {
+		return m
+	}
+
+	if !bytes.Equal(m.content, b) {
+		m.chain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{m.content},
+			Expected: &AssertionValue{b},
+			Errors: []error{
+				errors.New("expected: message content is equal to given bytes"),
+			},
+		})
+	}
+
+	return m
+}
+
 // NoContent succeeds if WebSocket message has no content (is empty).
 func (m *WebsocketMessage) NoContent() *WebsocketMessage {
 	m.chain.enter("NoContent()")
@@ -451,9 +596,8 @@ func (m *WebsocketMessage) JSON() *Value {
 		return newValue(m.chain, nil)
 	}
 
-	var value interface{}
-
-	if err := json.Unmarshal(m.content, &value); err != nil {
+	value, err := jsonDecode(m.chain, m.content)
+	if err != nil {
 		m.chain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
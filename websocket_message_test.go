@@ -24,9 +24,13 @@ func TestWebsocketMessageFailed(t *testing.T) {
 	msg.NotType(0)
 	msg.Code(0)
 	msg.NotCode(0)
+	msg.CodeInRange(0, 0)
 	msg.NoContent()
+	msg.BytesEqual(nil)
 
+	msg.Reason().chain.assertFailed(t)
 	msg.Body().chain.assertFailed(t)
+	msg.Bytes().chain.assertFailed(t)
 	msg.JSON().chain.assertFailed(t)
 }
 
@@ -52,6 +56,10 @@ func TestWebsocketMessageBadUsage(t *testing.T) {
 	msg.NotCode()
 	msg.chain.assertFailed(t)
 	msg.chain.clearFailed()
+
+	msg.CodeInRange(20, 10)
+	msg.chain.assertFailed(t)
+	msg.chain.clearFailed()
 }
 
 func TestWebsocketMessageCloseMessage(t *testing.T) {
@@ -284,6 +292,37 @@ func TestWebsocketMessageMatchCodes(t *testing.T) {
 	msg.chain.clearFailed()
 }
 
+func TestWebsocketMessageCodeInRange(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("close", func(t *testing.T) {
+		msg := NewWebsocketMessage(reporter, websocket.CloseMessage, nil, 4001)
+
+		msg.CodeInRange(4000, 4999)
+		msg.chain.assertNotFailed(t)
+		msg.chain.clearFailed()
+
+		msg.CodeInRange(4001, 4001)
+		msg.chain.assertNotFailed(t)
+		msg.chain.clearFailed()
+
+		msg.CodeInRange(1000, 2999)
+		msg.chain.assertFailed(t)
+		msg.chain.clearFailed()
+
+		msg.CodeInRange(4002, 4999)
+		msg.chain.assertFailed(t)
+		msg.chain.clearFailed()
+	})
+
+	t.Run("not close", func(t *testing.T) {
+		msg := NewWebsocketMessage(reporter, websocket.TextMessage, nil, 4001)
+
+		msg.CodeInRange(4000, 4999)
+		msg.chain.assertFailed(t)
+	})
+}
+
 func TestWebsocketMessageCodeAndType(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -353,6 +392,91 @@ func TestWebsocketMessageBody(t *testing.T) {
 	require.Equal(t, "test", s.Raw())
 }
 
+func TestWebsocketMessageReason(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("close", func(t *testing.T) {
+		msg := NewWebsocketMessage(reporter, websocket.CloseMessage,
+			[]byte("token expired"), websocket.ClosePolicyViolation)
+
+		s := msg.Reason()
+		s.chain.assertNotFailed(t)
+
+		require.Equal(t, "token expired", s.Raw())
+	})
+
+	t.Run("no reason", func(t *testing.T) {
+		msg := NewWebsocketMessage(reporter, websocket.CloseMessage,
+			nil, websocket.CloseNormalClosure)
+
+		s := msg.Reason()
+		s.chain.assertNotFailed(t)
+
+		require.Equal(t, "", s.Raw())
+	})
+
+	t.Run("not close", func(t *testing.T) {
+		msg := NewWebsocketMessage(reporter, websocket.TextMessage,
+			[]byte("token expired"))
+
+		s := msg.Reason()
+		s.chain.assertFailed(t)
+
+		require.Equal(t, "", s.Raw())
+	})
+}
+
+func TestWebsocketMessageBytes(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("binary", func(t *testing.T) {
+		msg := NewWebsocketMessage(reporter, websocket.BinaryMessage,
+			[]byte{0xca, 0xfe, 0x00})
+
+		a := msg.Bytes()
+		a.chain.assertNotFailed(t)
+
+		require.Equal(t, []interface{}{202.0, 254.0, 0.0}, a.Raw())
+
+		a.Length().Equal(3)
+		a.Element(1).Number().Equal(0xfe)
+		a.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		msg := NewWebsocketMessage(reporter, websocket.BinaryMessage, nil)
+
+		a := msg.Bytes()
+		a.chain.assertNotFailed(t)
+
+		require.Equal(t, []interface{}{}, a.Raw())
+	})
+}
+
+func TestWebsocketMessageBytesEqual(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	msg := NewWebsocketMessage(reporter, websocket.BinaryMessage,
+		[]byte{0xca, 0xfe, 0xba, 0xbe})
+
+	msg.BytesEqual([]byte{0xca, 0xfe, 0xba, 0xbe})
+	msg.chain.assertNotFailed(t)
+	msg.chain.clearFailed()
+
+	msg.BytesEqual([]byte{0xca, 0xfe})
+	msg.chain.assertFailed(t)
+	msg.chain.clearFailed()
+
+	msg.BytesEqual(nil)
+	msg.chain.assertFailed(t)
+	msg.chain.clearFailed()
+
+	empty := NewWebsocketMessage(reporter, websocket.BinaryMessage, nil)
+
+	empty.BytesEqual([]byte{})
+	empty.chain.assertNotFailed(t)
+}
+
 func TestWebsocketMessageJSON(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -0,0 +1,284 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Message expected by QueueExpect or QueueExpectJSON.
+type websocketExpectation struct {
+	index   int
+	matcher func(msg *WebsocketMessage)
+	matched bool
+}
+
+// QueueExpect registers expectation for a message that should be received
+// from WebSocket connection before Done is called.
+//
+// Message satisfies expectation if matcher doesn't report any failures for
+// it. Failures reported by matcher are not propagated to test; they only
+// mean that message doesn't match.
+//
+// Messages are read in background, starting from the first QueueExpect
+// call, so writes and server pushes can be interleaved arbitrarily.
+// Every message satisfies at most one expectation. By default, messages
+// should arrive in the same order as expectations were registered; use
+// WithOrderTolerance to relax ordering.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.QueueExpect(func(msg *httpexpect.WebsocketMessage) {
+//	    msg.TextMessage().Body().Equal("welcome")
+//	})
+//	conn.WriteText("hello")
+//	conn.Done(time.Second)
+func (c *Websocket) QueueExpect(matcher func(msg *WebsocketMessage)) *Websocket {
+	c.chain.enter("QueueExpect()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("QueueExpect()") {
+		return c
+	}
+
+	c.queueExpect(matcher)
+
+	return c
+}
+
+// QueueExpectJSON is like QueueExpect, but matcher receives JSON value
+// decoded from message. Messages that are not valid JSON don't satisfy
+// the expectation.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.WithOrderTolerance(-1)
+//	conn.QueueExpectJSON(func(v *httpexpect.Value) {
+//	    v.Object().ValueEqual("event", "created")
+//	})
+//	conn.QueueExpectJSON(func(v *httpexpect.Value) {
+//	    v.Object().ValueEqual("event", "updated")
+//	})
+//	conn.WriteJSON(map[string]string{"action": "create_and_update"})
+//	conn.Done(5 * time.Second)
+func (c *Websocket) QueueExpectJSON(matcher func(value *Value)) *Websocket {
+	c.chain.enter("QueueExpectJSON()")
+	defer c.chain.leave()
+
+	if c.checkUnusable("QueueExpectJSON()") {
+		return c
+	}
+
+	if matcher == nil {
+		c.queueExpect(nil)
+		return c
+	}
+
+	c.queueExpect(func(msg *WebsocketMessage) {
+		matcher(msg.JSON())
+	})
+
+	return c
+}
+
+// WithOrderTolerance defines how much messages expected by QueueExpect
+// may be reordered.
+//
+// Message may satisfy one of the first n+1 not yet satisfied expectations,
+// in order of registration. Zero value (default) means that messages
+// should arrive exactly in order of expectations. Negative value means
+// that messages may arrive in any order.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.WithOrderTolerance(1)
+func (c *Websocket) WithOrderTolerance(n int) *Websocket {
+	c.chain.enter("WithOrderTolerance()")
+	defer c.chain.leave()
+
+	if c.chain.failed() {
+		return c
+	}
+
+	c.orderTolerance = n
+
+	return c
+}
+
+// Done waits until all expectations registered by QueueExpect and
+// QueueExpectJSON are satisfied, not longer than given timeout.
+//
+// Failure is reported if timeout expires, if a message arrives that
+// doesn't satisfy any expectation, or if a message arrives too early
+// according to WithOrderTolerance. After Done returns, expectations
+// are cleared, so new ones can be registered.
+//
+// Example:
+//
+//	conn := resp.Websocket()
+//	conn.QueueExpectJSON(func(v *httpexpect.Value) {
+//	    v.Object().ValueEqual("type", "ack")
+//	})
+//	conn.WriteJSON(map[string]string{"type": "ping"})
+//	conn.Done(time.Second)
+func (c *Websocket) Done(timeout time.Duration) *Websocket {
+	c.chain.enter("Done()")
+	defer c.chain.leave()
+
+	queue := c.queue
+	c.queue = nil
+
+	if c.checkUnusable("Done()") || len(queue) == 0 {
+		return c
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		pending := pendingExpectations(queue)
+		if len(pending) == 0 {
+			return c
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			c.failPending(pending, timeout)
+			return c
+		}
+
+		msg, ok := c.reader.read(remaining)
+		if !ok {
+			c.failPending(pending, timeout)
+			return c
+		}
+
+		m := c.makeMessage(msg.typ, msg.content, msg.err)
+		if m == nil {
+			return c
+		}
+
+		window := pending
+		if c.orderTolerance >= 0 && c.orderTolerance+1 < len(window) {
+			window = window[:c.orderTolerance+1]
+		}
+
+		if e := c.matchExpectation(window, m); e != nil {
+			e.matched = true
+			continue
+		}
+
+		if e := c.matchExpectation(pending[len(window):], m); e != nil {
+			c.chain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(m.content)},
+				Errors: []error{
+					errors.New("expected: messages arrive in order of expectations"),
+					fmt.Errorf(
+						"message matched expectation #%d, but expectation #%d"+
+							" is not satisfied yet (order tolerance is %d)",
+						e.index, pending[0].index, c.orderTolerance),
+				},
+			})
+			return c
+		}
+
+		c.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(m.content)},
+			Errors: []error{
+				errors.New("expected: message satisfies one of queued expectations"),
+				fmt.Errorf("got unexpected %s", wsMessageType(m.typ)),
+			},
+		})
+		return c
+	}
+}
+
+func (c *Websocket) queueExpect(matcher func(msg *WebsocketMessage)) {
+	if matcher == nil {
+		c.chain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil matcher"),
+			},
+		})
+		return
+	}
+
+	if !c.startReader() {
+		return
+	}
+
+	c.queue = append(c.queue, &websocketExpectation{
+		index:   len(c.queue) + 1,
+		matcher: matcher,
+	})
+}
+
+// Return first expectation satisfied by message, or nil.
+func (c *Websocket) matchExpectation(
+	expectations []*websocketExpectation, m *WebsocketMessage,
+) *websocketExpectation {
+	for _, e := range expectations {
+		handler := &websocketMatchHandler{}
+
+		mc := c.chain.clone()
+		mc.handler = handler
+		mc.failCb = nil
+
+		e.matcher(&WebsocketMessage{
+			chain:     mc,
+			typ:       m.typ,
+			content:   m.content,
+			closeCode: m.closeCode,
+		})
+
+		if !handler.failed {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// AssertionHandler that only remembers whether matcher failed.
+type websocketMatchHandler struct {
+	failed bool
+}
+
+func (h *websocketMatchHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *websocketMatchHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.failed = true
+}
+
+func (c *Websocket) failPending(pending []*websocketExpectation, timeout time.Duration) {
+	indexes := make([]int, 0, len(pending))
+	for _, e := range pending {
+		indexes = append(indexes, e.index)
+	}
+
+	c.chain.fail(AssertionFailure{
+		Type: AssertOperation,
+		Errors: []error{
+			fmt.Errorf("expected: all queued messages received within %s", timeout),
+			fmt.Errorf("unsatisfied expectations: %v", indexes),
+		},
+	})
+}
+
+func pendingExpectations(queue []*websocketExpectation) []*websocketExpectation {
+	var pending []*websocketExpectation
+	for _, e := range queue {
+		if !e.matched {
+			pending = append(pending, e)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,148 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func createQueueHandler() http.Handler {
+	events := map[string][]string{
+		"ordered":  {"created", "updated", "deleted"},
+		"reversed": {"deleted", "updated", "created"},
+		"swapped":  {"updated", "created", "deleted"},
+		"single":   {"created"},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := &websocket.Upgrader{}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer c.Close()
+
+		// server pushes greeting before anything is written by client
+		_ = c.WriteMessage(websocket.TextMessage, []byte("welcome"))
+
+		for {
+			_, message, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			for _, event := range events[string(message)] {
+				err := c.WriteJSON(map[string]string{"event": event})
+				if err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+func queueEvents(ws *Websocket, events ...string) {
+	for _, event := range events {
+		event := event
+		ws.QueueExpectJSON(func(v *Value) {
+			v.Object().ValueEqual("event", event)
+		})
+	}
+}
+
+func TestWebsocketQueue(t *testing.T) {
+	cases := []struct {
+		name      string
+		push      string
+		tolerance int
+		fail      bool
+	}{
+		{name: "ordered", push: "ordered", tolerance: 0},
+		{name: "swapped strict", push: "swapped", tolerance: 0, fail: true},
+		{name: "swapped tolerated", push: "swapped", tolerance: 1},
+		{name: "reversed tolerance 1", push: "reversed", tolerance: 1, fail: true},
+		{name: "reversed tolerance 2", push: "reversed", tolerance: 2},
+		{name: "reversed any order", push: "reversed", tolerance: -1},
+		{name: "missing", push: "single", tolerance: -1, fail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e, reporter := newMockExpect(t, createQueueHandler())
+
+			ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+			defer ws.Disconnect()
+
+			ws.QueueExpect(func(msg *WebsocketMessage) {
+				msg.TextMessage().Body().Equal("welcome")
+			})
+			ws.Done(time.Second)
+			ws.chain.assertNotFailed(t)
+
+			ws.WithOrderTolerance(tc.tolerance)
+			queueEvents(ws, "created", "updated", "deleted")
+
+			ws.WriteText(tc.push)
+			ws.Done(100 * time.Millisecond)
+
+			if tc.fail {
+				ws.chain.assertFailed(t)
+				assert.True(t, reporter.reported)
+			} else {
+				ws.chain.assertNotFailed(t)
+				assert.False(t, reporter.reported)
+			}
+		})
+	}
+}
+
+func TestWebsocketQueueUnexpected(t *testing.T) {
+	e, reporter := newMockExpect(t, createQueueHandler())
+
+	ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+	defer ws.Disconnect()
+
+	queueEvents(ws, "created")
+	ws.Done(time.Second)
+
+	ws.chain.assertFailed(t)
+	assert.True(t, reporter.reported)
+}
+
+func TestWebsocketQueueExpectAfter(t *testing.T) {
+	e, reporter := newMockExpect(t, createQueueHandler())
+
+	ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+	defer ws.Disconnect()
+
+	ws.QueueExpect(func(msg *WebsocketMessage) {
+		msg.Body().Equal("welcome")
+	})
+	ws.WriteText("single")
+	ws.Done(time.Second)
+
+	ws.Expect().JSON().Object().ValueEqual("event", "created")
+
+	ws.chain.assertNotFailed(t)
+	assert.False(t, reporter.reported)
+}
+
+func TestWebsocketQueueUsage(t *testing.T) {
+	e, _ := newMockExpect(t, createQueueHandler())
+
+	ws := e.GET("/").WithWebsocketUpgrade().Expect().Websocket()
+	defer ws.Disconnect()
+
+	ws.Done(time.Second)
+	ws.chain.assertNotFailed(t)
+
+	ws.QueueExpect(nil)
+	ws.chain.assertFailed(t)
+	ws.chain.clearFailed()
+
+	ws.QueueExpectJSON(nil)
+	ws.chain.assertFailed(t)
+}
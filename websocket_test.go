@@ -1,7 +1,11 @@
 package httpexpect
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -34,6 +38,22 @@ func TestWebsocketFailed(t *testing.T) {
 	ws.WriteBytesText([]byte("a"))
 	ws.WriteText("a")
 	ws.WriteJSON(map[string]string{"a": "b"})
+	ws.WriteJSONRPC("a", nil)
+	ws.WriteJSONRPCNotification("a", nil)
+
+	ws.HasSubprotocol()
+	ws.NoSubprotocol()
+	ws.Ping(time.Second)
+	ws.ExpectPing(time.Second)
+
+	ws.ExpectJSONRPCResult(1).chain.assertFailed(t)
+	ws.ExpectJSONRPCError(1).chain.assertFailed(t)
+	ws.ExpectJSONRPCNotification("a").chain.assertFailed(t)
+
+	ws.WithOrderTolerance(1)
+	ws.QueueExpect(func(msg *WebsocketMessage) {})
+	ws.QueueExpectJSON(func(value *Value) {})
+	ws.Done(time.Second)
 
 	ws.Close()
 	ws.CloseWithBytes([]byte("a"))
@@ -935,6 +955,56 @@ func TestWebsocketSubprotocol(t *testing.T) {
 	}
 }
 
+func TestWebsocketHasSubprotocol(t *testing.T) {
+	cases := []struct {
+		name        string
+		subprotocol string
+		offered     []string
+		fail        bool
+	}{
+		{name: "any", subprotocol: "soap"},
+		{name: "offered", subprotocol: "soap", offered: []string{"wamp", "soap"}},
+		{name: "not offered", subprotocol: "soap", offered: []string{"wamp"}, fail: true},
+		{name: "none", subprotocol: "", fail: true},
+		{name: "none offered", subprotocol: "", offered: []string{"soap"}, fail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+			ws := NewWebsocketC(Config{
+				Reporter: reporter,
+			}, newMockWebsocketConn().WithSubprotocol(tc.subprotocol))
+
+			ws.HasSubprotocol(tc.offered...)
+
+			if tc.fail {
+				ws.chain.assertFailed(t)
+			} else {
+				ws.chain.assertNotFailed(t)
+			}
+		})
+	}
+
+	t.Run("no subprotocol", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		ws := NewWebsocketC(Config{
+			Reporter: reporter,
+		}, newMockWebsocketConn())
+
+		ws.NoSubprotocol()
+		ws.chain.assertNotFailed(t)
+
+		ws = NewWebsocketC(Config{
+			Reporter: reporter,
+		}, newMockWebsocketConn().WithSubprotocol("soap"))
+
+		ws.NoSubprotocol()
+		ws.chain.assertFailed(t)
+	})
+}
+
 func TestWebsocketSetReadDeadline(t *testing.T) {
 	type args struct {
 		wsConn WebsocketConn
@@ -1025,6 +1095,77 @@ func TestWebsocketSetWriteDeadline(t *testing.T) {
 	}
 }
 
+func TestWebsocketFragmentedMessage(t *testing.T) {
+	payload := bytes.Repeat([]byte{0xde, 0xad, 0xbe, 0xef}, 256)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// small write buffer forces message to be split into
+		// initial frame and several continuation frames
+		upgrader := &websocket.Upgrader{WriteBufferSize: 256}
+
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		defer c.Close()
+
+		for _, typ := range []int{websocket.BinaryMessage, websocket.TextMessage} {
+			wr, err := c.NextWriter(typ)
+			if err != nil {
+				return
+			}
+			for i := 0; i < len(payload); i += 100 {
+				end := i + 100
+				if end > len(payload) {
+					end = len(payload)
+				}
+				if _, err := wr.Write(payload[i:end]); err != nil {
+					return
+				}
+			}
+			if err := wr.Close(); err != nil {
+				return
+			}
+		}
+
+		_ = c.WriteMessage(websocket.CloseMessage,
+			websocket.FormatCloseMessage(4003, "quota exceeded"))
+	})
+
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		BaseURL:         "http://example.com",
+		Reporter:        reporter,
+		WebsocketDialer: NewWebsocketDialer(handler),
+	})
+
+	ws := e.GET("/").WithWebsocketUpgrade().
+		Expect().
+		Status(http.StatusSwitchingProtocols).
+		Websocket()
+	defer ws.Disconnect()
+
+	sum := sha256.Sum256(payload)
+
+	binMsg := ws.Expect()
+	binMsg.BinaryMessage().BytesEqual(payload)
+	binMsg.Bytes().Length().Equal(len(payload))
+	binMsg.Body().Checksum("sha256", hex.EncodeToString(sum[:]))
+
+	textMsg := ws.Expect()
+	textMsg.TextMessage().Body().Length().Equal(len(payload))
+
+	closeMsg := ws.Expect()
+	closeMsg.CloseMessage().CodeInRange(4000, 4999)
+	closeMsg.Reason().Equal("quota exceeded")
+
+	ws.chain.assertNotFailed(t)
+	binMsg.chain.assertNotFailed(t)
+	textMsg.chain.assertNotFailed(t)
+	closeMsg.chain.assertNotFailed(t)
+}
+
 func TestWebsocketDisconnect(t *testing.T) {
 	type args struct {
 		wsConn WebsocketConn
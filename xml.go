@@ -0,0 +1,230 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// XML provides methods to inspect XML document.
+type XML struct {
+	chain   *chain
+	content []byte
+}
+
+// NewXML returns a new XML instance.
+//
+// reporter should not be nil.
+//
+// Example:
+//
+//	x := NewXML(t, []byte("<user><name>john</name></user>"))
+//	x.ValidateXSD("user.xsd")
+func NewXML(reporter Reporter, content []byte) *XML {
+	chain := newChainWithDefaults("XML()", reporter)
+
+	if !checkXML(chain, content) {
+		return newXML(chain, nil)
+	}
+
+	return newXML(chain, content)
+}
+
+func newXML(parent *chain, content []byte) *XML {
+	return &XML{parent.clone(), content}
+}
+
+func checkXML(chain *chain, content []byte) bool {
+	if err := xmlWellFormed(content); err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{string(content)},
+			Errors: []error{
+				errors.New("expected: well-formed xml document"),
+				err,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// Raw returns underlying XML document.
+//
+// Example:
+//
+//	x := NewXML(t, []byte("<user/>"))
+//	assert.Equal(t, []byte("<user/>"), x.Raw())
+func (x *XML) Raw() []byte {
+	return x.content
+}
+
+// String returns a new String instance with XML document.
+//
+// Example:
+//
+//	x := NewXML(t, []byte("<user/>"))
+//	x.String().Contains("user")
+func (x *XML) String() *String {
+	x.chain.enter("String()")
+	defer x.chain.leave()
+
+	return newString(x.chain, string(x.content))
+}
+
+// ValidateXSD succeeds if XML document is valid according to XSD
+// schema loaded from given file.
+//
+// Only a subset of XML Schema is supported:
+//   - global and local element declarations, including refs and
+//     minOccurs/maxOccurs
+//   - named and anonymous complex types with a single sequence, all,
+//     or choice group of elements, and mixed content
+//   - attributes with required/optional use
+//   - simple content extensions
+//   - simple types with restrictions (enumeration, pattern, length,
+//     minLength, maxLength) on top of other simple types
+//   - builtin types string, boolean, integer, long, int, short, byte,
+//     nonNegativeInteger, positiveInteger, unsignedLong, unsignedInt,
+//     unsignedShort, unsignedByte, decimal, float, double, date,
+//     dateTime, time, and anyType
+//   - targetNamespace and elementFormDefault
+//
+// Schema must be self-contained and may declare namespace prefixes
+// only on its root element. Annotations are ignored.
+//
+// Any other construct, like import, include, complexContent, nested
+// groups, numeric facets, or unknown builtin type, is reported as
+// usage error instead of being silently ignored.
+//
+// Every validation error includes line and column of the offending
+// element in XML document.
+//
+// Example:
+//
+//	x := NewXML(t, []byte("<user><name>john</name></user>"))
+//	x.ValidateXSD("testdata/user.xsd")
+func (x *XML) ValidateXSD(schemaPath string) *XML {
+	x.chain.enter("ValidateXSD(%q)", schemaPath)
+	defer x.chain.leave()
+
+	if x.chain.failed() {
+		return x
+	}
+
+	schemaData, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		x.chain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to read xsd schema"),
+				err,
+			},
+		})
+		return x
+	}
+
+	if x.chain.manifest != nil {
+		x.chain.manifest.addFile("xsd", schemaPath)
+	}
+
+	x.validateXSD(schemaData, schemaPath, schemaPath)
+
+	return x
+}
+
+// ValidateXSDString is like ValidateXSD, but accepts schema contents
+// instead of file path.
+//
+// Example:
+//
+//	x := NewXML(t, []byte("<user><name>john</name></user>"))
+//	x.ValidateXSDString(userSchema)
+func (x *XML) ValidateXSDString(schema string) *XML {
+	x.chain.enter("ValidateXSDString()")
+	defer x.chain.leave()
+
+	if x.chain.failed() {
+		return x
+	}
+
+	x.validateXSD([]byte(schema), schema, "inline")
+
+	return x
+}
+
+func (x *XML) validateXSD(schemaData []byte, schemaRef, source string) {
+	schema, err := parseXSD(schemaData)
+	if err != nil {
+		var unsupported *xsdUnsupportedError
+		if errors.As(err, &unsupported) {
+			x.chain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unsupported xsd schema"),
+					err,
+				},
+			})
+			return
+		}
+
+		x.chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{schemaRef},
+			Errors: []error{
+				errors.New("expected: valid xsd schema"),
+				err,
+			},
+		})
+		return
+	}
+
+	if x.chain.manifest != nil {
+		x.chain.manifest.addSchema("xsd", source, schema.TargetNamespace, schema.Version)
+	}
+
+	validationErrs := schema.validate(x.content)
+
+	if len(validationErrs) != 0 {
+		errs := []error{
+			errors.New("expected: xml document matches given xsd schema"),
+		}
+		errs = append(errs, validationErrs...)
+
+		x.chain.fail(AssertionFailure{
+			Type:     AssertMatchSchema,
+			Actual:   &AssertionValue{string(x.content)},
+			Expected: &AssertionValue{schemaRef},
+			Errors:   errs,
+		})
+	}
+}
+
+func xmlWellFormed(content []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	hasRoot := false
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := tok.(xml.StartElement); ok {
+			hasRoot = true
+		}
+	}
+
+	if !hasRoot {
+		return fmt.Errorf("xml document has no root element")
+	}
+
+	return nil
+}
@@ -0,0 +1,507 @@
+package httpexpect
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserXSD = `<?xml version="1.0"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:simpleType name="roleType">
+    <xs:restriction base="xs:string">
+      <xs:enumeration value="admin"/>
+      <xs:enumeration value="user"/>
+    </xs:restriction>
+  </xs:simpleType>
+
+  <xs:simpleType name="loginType">
+    <xs:restriction base="xs:string">
+      <xs:pattern value="[a-z]+"/>
+      <xs:maxLength value="8"/>
+    </xs:restriction>
+  </xs:simpleType>
+
+  <xs:complexType name="phoneType">
+    <xs:simpleContent>
+      <xs:extension base="xs:string">
+        <xs:attribute name="kind" type="xs:string" use="required"/>
+      </xs:extension>
+    </xs:simpleContent>
+  </xs:complexType>
+
+  <xs:element name="user">
+    <xs:complexType>
+      <xs:sequence>
+        <xs:element name="login" type="loginType"/>
+        <xs:element name="age" type="xs:int"/>
+        <xs:element name="role" type="roleType" minOccurs="0"/>
+        <xs:element name="phone" type="phoneType" minOccurs="0" maxOccurs="unbounded"/>
+      </xs:sequence>
+      <xs:attribute name="id" type="xs:positiveInteger" use="required"/>
+      <xs:attribute name="active" type="xs:boolean"/>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`
+
+func TestXMLFailed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.fail(mockFailure())
+
+	value := newXML(chain, []byte("<user/>"))
+
+	assert.NotNil(t, value.String())
+
+	value.ValidateXSD("missing.xsd")
+	value.ValidateXSDString(testUserXSD)
+
+	value.chain.assertFailed(t)
+}
+
+func TestXMLWellFormed(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewXML(reporter, []byte("<user><login>john</login></user>"))
+	value.chain.assertNotFailed(t)
+	assert.Equal(t, []byte("<user><login>john</login></user>"), value.Raw())
+	assert.Equal(t, "<user><login>john</login></user>", value.String().Raw())
+
+	value = NewXML(reporter, []byte("<user><login>john</user>"))
+	value.chain.assertFailed(t)
+	assert.Nil(t, value.Raw())
+
+	value = NewXML(reporter, []byte("just text"))
+	value.chain.assertFailed(t)
+}
+
+func TestXMLValidateXSD(t *testing.T) {
+	cases := []struct {
+		name  string
+		doc   string
+		valid bool
+	}{
+		{
+			name:  "valid",
+			doc:   `<user id="1"><login>john</login><age>30</age></user>`,
+			valid: true,
+		},
+		{
+			name: "valid_all_fields",
+			doc: `<user id="1" active="true" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <login>john</login>
+  <age>30</age>
+  <role>admin</role>
+  <phone kind="home">123</phone>
+  <phone kind="work">456</phone>
+</user>`,
+			valid: true,
+		},
+		{
+			name:  "bad_root",
+			doc:   `<admin id="1"><login>john</login><age>30</age></admin>`,
+			valid: false,
+		},
+		{
+			name:  "missing_element",
+			doc:   `<user id="1"><login>john</login></user>`,
+			valid: false,
+		},
+		{
+			name:  "wrong_order",
+			doc:   `<user id="1"><age>30</age><login>john</login></user>`,
+			valid: false,
+		},
+		{
+			name:  "unexpected_element",
+			doc:   `<user id="1"><login>john</login><age>30</age><foo/></user>`,
+			valid: false,
+		},
+		{
+			name:  "bad_int",
+			doc:   `<user id="1"><login>john</login><age>abc</age></user>`,
+			valid: false,
+		},
+		{
+			name:  "bad_enum",
+			doc:   `<user id="1"><login>john</login><age>30</age><role>root</role></user>`,
+			valid: false,
+		},
+		{
+			name:  "bad_pattern",
+			doc:   `<user id="1"><login>John1</login><age>30</age></user>`,
+			valid: false,
+		},
+		{
+			name:  "bad_max_length",
+			doc:   `<user id="1"><login>johnjohnjohn</login><age>30</age></user>`,
+			valid: false,
+		},
+		{
+			name:  "missing_attribute",
+			doc:   `<user><login>john</login><age>30</age></user>`,
+			valid: false,
+		},
+		{
+			name:  "bad_attribute",
+			doc:   `<user id="0"><login>john</login><age>30</age></user>`,
+			valid: false,
+		},
+		{
+			name:  "unexpected_attribute",
+			doc:   `<user id="1" foo="bar"><login>john</login><age>30</age></user>`,
+			valid: false,
+		},
+		{
+			name: "missing_nested_attribute",
+			doc: `<user id="1"><login>john</login><age>30</age>` +
+				`<phone>123</phone></user>`,
+			valid: false,
+		},
+		{
+			name:  "unexpected_text",
+			doc:   `<user id="1">text<login>john</login><age>30</age></user>`,
+			valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewXML(reporter, []byte(tc.doc))
+			value.chain.assertNotFailed(t)
+
+			value.ValidateXSDString(testUserXSD)
+
+			if tc.valid {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+		})
+	}
+}
+
+func TestXMLValidateXSDErrors(t *testing.T) {
+	schema, err := parseXSD([]byte(testUserXSD))
+	require.NoError(t, err)
+
+	doc := "<user id=\"1\">\n  <login>john</login>\n  <age>abc</age>\n</user>"
+
+	errs := schema.validate([]byte(doc))
+	require.Equal(t, 1, len(errs))
+
+	assert.Equal(t,
+		`line 3, column 3: value "abc" of <age> is not a valid int`,
+		errs[0].Error())
+}
+
+func TestXMLValidateXSDChoiceAll(t *testing.T) {
+	schema := `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="pet">
+    <xs:complexType>
+      <xs:choice>
+        <xs:element name="cat" type="xs:string"/>
+        <xs:element ref="dog"/>
+      </xs:choice>
+    </xs:complexType>
+  </xs:element>
+  <xs:element name="dog">
+    <xs:complexType>
+      <xs:all>
+        <xs:element name="name" type="xs:string"/>
+        <xs:element name="born" type="xs:date" minOccurs="0"/>
+      </xs:all>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`
+
+	check := func(doc string, valid bool) {
+		reporter := newMockReporter(t)
+
+		value := NewXML(reporter, []byte(doc))
+		value.ValidateXSDString(schema)
+
+		if valid {
+			value.chain.assertNotFailed(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+	}
+
+	check(`<pet><cat>tom</cat></pet>`, true)
+	check(`<pet><dog><born>2020-01-02</born><name>rex</name></dog></pet>`, true)
+	check(`<pet><dog><name>rex</name></dog></pet>`, true)
+	check(`<pet></pet>`, false)
+	check(`<pet><cat>tom</cat><cat>tom</cat></pet>`, false)
+	check(`<pet><bird/></pet>`, false)
+	check(`<pet><dog><name>rex</name><name>rex</name></dog></pet>`, false)
+	check(`<pet><dog><born>2020-01-02</born></dog></pet>`, false)
+	check(`<pet><dog><name>rex</name><born>bad</born></dog></pet>`, false)
+}
+
+func TestXMLValidateXSDIntegers(t *testing.T) {
+	cases := []struct {
+		typ   string
+		value string
+		valid bool
+	}{
+		{"byte", "127", true},
+		{"byte", "-128", true},
+		{"byte", "128", false},
+		{"byte", "99999999999", false},
+		{"short", "32767", true},
+		{"short", "32768", false},
+		{"int", "2147483647", true},
+		{"int", "2147483648", false},
+		{"long", "9223372036854775807", true},
+		{"long", "9223372036854775808", false},
+		{"unsignedByte", "255", true},
+		{"unsignedByte", "+255", true},
+		{"unsignedByte", "256", false},
+		{"unsignedByte", "-1", false},
+		{"unsignedShort", "65535", true},
+		{"unsignedShort", "65536", false},
+		{"unsignedInt", "4294967295", true},
+		{"unsignedInt", "4294967296", false},
+		{"unsignedLong", "18446744073709551615", true},
+		{"unsignedLong", "18446744073709551616", false},
+		{"integer", "-123456789012345678901234567890", true},
+		{"integer", "1.5", false},
+		{"nonNegativeInteger", "0", true},
+		{"nonNegativeInteger", "123456789012345678901234567890", true},
+		{"nonNegativeInteger", "-1", false},
+		{"positiveInteger", "1", true},
+		{"positiveInteger", "0", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.typ+"_"+tc.value, func(t *testing.T) {
+			schema := `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">` +
+				`<xs:element name="n" type="xs:` + tc.typ + `"/></xs:schema>`
+
+			reporter := newMockReporter(t)
+
+			value := NewXML(reporter, []byte(`<n>`+tc.value+`</n>`))
+			value.ValidateXSDString(schema)
+
+			if tc.valid {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+		})
+	}
+}
+
+func TestXMLValidateXSDNamespace(t *testing.T) {
+	schema := `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"
+    xmlns:tns="urn:user" targetNamespace="urn:user" elementFormDefault="qualified">
+  <xs:simpleType name="name">
+    <xs:restriction base="xs:string">
+      <xs:minLength value="1"/>
+    </xs:restriction>
+  </xs:simpleType>
+  <xs:element name="user">
+    <xs:complexType>
+      <xs:sequence>
+        <xs:element name="name" type="tns:name"/>
+      </xs:sequence>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`
+
+	check := func(doc string, valid bool) {
+		reporter := newMockReporter(t)
+
+		value := NewXML(reporter, []byte(doc))
+		value.ValidateXSDString(schema)
+
+		if valid {
+			value.chain.assertNotFailed(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+	}
+
+	check(`<user xmlns="urn:user"><name>john</name></user>`, true)
+	check(`<u:user xmlns:u="urn:user"><u:name>john</u:name></u:user>`, true)
+	check(`<user><name>john</name></user>`, false)
+	check(`<user xmlns="urn:other"><name>john</name></user>`, false)
+	check(`<u:user xmlns:u="urn:user"><name>john</name></u:user>`, false)
+	check(`<user xmlns="urn:user"><name></name></user>`, false)
+}
+
+func TestXMLValidateXSDUnsupported(t *testing.T) {
+	wrap := func(body string) string {
+		return `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">` +
+			body + `<xs:element name="root" type="xs:string"/></xs:schema>`
+	}
+
+	cases := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name:   "import",
+			schema: wrap(`<xs:import namespace="urn:other" schemaLocation="other.xsd"/>`),
+		},
+		{
+			name:   "include",
+			schema: wrap(`<xs:include schemaLocation="other.xsd"/>`),
+		},
+		{
+			name: "complex content",
+			schema: wrap(`<xs:complexType name="base"/>` +
+				`<xs:complexType name="derived"><xs:complexContent>` +
+				`<xs:extension base="base"/>` +
+				`</xs:complexContent></xs:complexType>`),
+		},
+		{
+			name: "nested group",
+			schema: wrap(`<xs:complexType name="t"><xs:sequence>` +
+				`<xs:choice><xs:element name="a" type="xs:string"/></xs:choice>` +
+				`</xs:sequence></xs:complexType>`),
+		},
+		{
+			name: "group occurs",
+			schema: wrap(`<xs:complexType name="t"><xs:sequence maxOccurs="2">` +
+				`<xs:element name="a" type="xs:string"/>` +
+				`</xs:sequence></xs:complexType>`),
+		},
+		{
+			name: "numeric facet",
+			schema: wrap(`<xs:simpleType name="t"><xs:restriction base="xs:int">` +
+				`<xs:minInclusive value="1"/>` +
+				`</xs:restriction></xs:simpleType>`),
+		},
+		{
+			name: "digits facet",
+			schema: wrap(`<xs:simpleType name="t"><xs:restriction base="xs:decimal">` +
+				`<xs:totalDigits value="5"/>` +
+				`</xs:restriction></xs:simpleType>`),
+		},
+		{
+			name: "list",
+			schema: wrap(`<xs:simpleType name="t">` +
+				`<xs:list itemType="xs:int"/>` +
+				`</xs:simpleType>`),
+		},
+		{
+			name:   "unknown builtin",
+			schema: wrap(`<xs:element name="a" type="xs:strng"/>`),
+		},
+		{
+			name:   "unsupported builtin",
+			schema: wrap(`<xs:element name="a" type="xs:dateTimeStamp"/>`),
+		},
+		{
+			name: "unsupported attribute builtin",
+			schema: wrap(`<xs:complexType name="t">` +
+				`<xs:attribute name="a" type="xs:anyURI"/>` +
+				`</xs:complexType>`),
+		},
+		{
+			name:   "element attribute",
+			schema: wrap(`<xs:element name="a" type="xs:string" nillable="true"/>`),
+		},
+		{
+			name: "prohibited use",
+			schema: wrap(`<xs:complexType name="t">` +
+				`<xs:attribute name="a" type="xs:string" use="prohibited"/>` +
+				`</xs:complexType>`),
+		},
+		{
+			name: "qualified attributes",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"` +
+				` attributeFormDefault="qualified">` +
+				`<xs:element name="root" type="xs:string"/></xs:schema>`,
+		},
+		{
+			name: "foreign type",
+			schema: `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"` +
+				` xmlns:o="urn:other">` +
+				`<xs:element name="root" type="o:type"/></xs:schema>`,
+		},
+		{
+			name: "nested namespace declaration",
+			schema: wrap(`<xs:element name="a" xmlns:o="urn:other" ` +
+				`type="xs:string"/>`),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseXSD([]byte(tc.schema))
+			require.Error(t, err)
+
+			var unsupported *xsdUnsupportedError
+			assert.True(t, errors.As(err, &unsupported), err.Error())
+
+			handler := &mockAssertionHandler{}
+
+			chain := newChainWithDefaults("test", newMockReporter(t))
+			chain.handler = handler
+
+			value := newXML(chain, []byte(`<root>text</root>`))
+			value.ValidateXSDString(tc.schema)
+
+			value.chain.assertFailed(t)
+			require.NotNil(t, handler.failure)
+			assert.Equal(t, AssertUsage, handler.failure.Type)
+		})
+	}
+
+	t.Run("annotation", func(t *testing.T) {
+		schema := wrap(`<xs:annotation><xs:documentation>` +
+			`<p>anything <b>goes</b></p>` +
+			`</xs:documentation></xs:annotation>`)
+
+		reporter := newMockReporter(t)
+
+		value := NewXML(reporter, []byte(`<root>text</root>`))
+		value.ValidateXSDString(schema)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("undefined type", func(t *testing.T) {
+		_, err := parseXSD([]byte(wrap(`<xs:element name="a" type="missing"/>`)))
+		require.Error(t, err)
+
+		var unsupported *xsdUnsupportedError
+		assert.False(t, errors.As(err, &unsupported))
+	})
+}
+
+func TestXMLValidateXSDFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "user.xsd")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testUserXSD), 0644))
+
+	reporter := newMockReporter(t)
+
+	value := NewXML(reporter,
+		[]byte(`<user id="1"><login>john</login><age>30</age></user>`))
+
+	value.ValidateXSD(path)
+	value.chain.assertNotFailed(t)
+
+	value.ValidateXSD(filepath.Join(dir, "missing.xsd"))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.ValidateXSDString("<bad")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.ValidateXSDString(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"/>`)
+	value.chain.assertFailed(t)
+}
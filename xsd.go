@@ -0,0 +1,1035 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Subset of XML Schema definition language, enough to validate
+// typical API payloads. See XML.ValidateXSD for supported features.
+//
+// Schema is first checked against the list of supported constructs,
+// and anything else is rejected instead of being silently ignored.
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+
+type xsdSchema struct {
+	TargetNamespace    string `xml:"targetNamespace,attr"`
+	Version            string `xml:"version,attr"`
+	ElementFormDefault string `xml:"elementFormDefault,attr"`
+
+	Elements     []xsdElement     `xml:"element"`
+	ComplexTypes []xsdComplexType `xml:"complexType"`
+	SimpleTypes  []xsdSimpleType  `xml:"simpleType"`
+
+	prefixes       map[string]string
+	elementMap     map[string]*xsdElement
+	complexTypeMap map[string]*xsdComplexType
+	simpleTypeMap  map[string]*xsdSimpleType
+}
+
+type xsdElement struct {
+	Name        string          `xml:"name,attr"`
+	Type        string          `xml:"type,attr"`
+	Ref         string          `xml:"ref,attr"`
+	MinOccurs   string          `xml:"minOccurs,attr"`
+	MaxOccurs   string          `xml:"maxOccurs,attr"`
+	ComplexType *xsdComplexType `xml:"complexType"`
+	SimpleType  *xsdSimpleType  `xml:"simpleType"`
+
+	namespace string
+}
+
+type xsdComplexType struct {
+	Name          string            `xml:"name,attr"`
+	Mixed         bool              `xml:"mixed,attr"`
+	Sequence      *xsdGroup         `xml:"sequence"`
+	All           *xsdGroup         `xml:"all"`
+	Choice        *xsdGroup         `xml:"choice"`
+	Attributes    []xsdAttribute    `xml:"attribute"`
+	SimpleContent *xsdSimpleContent `xml:"simpleContent"`
+}
+
+type xsdGroup struct {
+	Elements []xsdElement `xml:"element"`
+}
+
+type xsdAttribute struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+	Use  string `xml:"use,attr"`
+}
+
+type xsdSimpleContent struct {
+	Extension *xsdExtension `xml:"extension"`
+}
+
+type xsdExtension struct {
+	Base       string         `xml:"base,attr"`
+	Attributes []xsdAttribute `xml:"attribute"`
+}
+
+type xsdSimpleType struct {
+	Name        string          `xml:"name,attr"`
+	Restriction *xsdRestriction `xml:"restriction"`
+}
+
+type xsdRestriction struct {
+	Base         string     `xml:"base,attr"`
+	Enumerations []xsdFacet `xml:"enumeration"`
+	Patterns     []xsdFacet `xml:"pattern"`
+	Length       *xsdFacet  `xml:"length"`
+	MinLength    *xsdFacet  `xml:"minLength"`
+	MaxLength    *xsdFacet  `xml:"maxLength"`
+}
+
+type xsdFacet struct {
+	Value string `xml:"value,attr"`
+}
+
+// Construct which is not supported by validator.
+type xsdUnsupportedError struct {
+	message string
+}
+
+func (e *xsdUnsupportedError) Error() string {
+	return e.message
+}
+
+func xsdUnsupported(format string, args ...interface{}) error {
+	return &xsdUnsupportedError{fmt.Sprintf(format, args...)}
+}
+
+// Supported children and attributes of every schema element.
+// Annotations and "id" attributes are allowed everywhere.
+type xsdConstruct struct {
+	children []string
+	attrs    []string
+}
+
+var xsdConstructs = map[string]xsdConstruct{
+	"schema": {
+		children: []string{"element", "complexType", "simpleType"},
+		attrs: []string{
+			"targetNamespace", "version", "elementFormDefault", "attributeFormDefault",
+		},
+	},
+	"element": {
+		children: []string{"complexType", "simpleType"},
+		attrs:    []string{"name", "type", "ref", "minOccurs", "maxOccurs"},
+	},
+	"complexType": {
+		children: []string{"sequence", "all", "choice", "attribute", "simpleContent"},
+		attrs:    []string{"name", "mixed"},
+	},
+	"sequence": {
+		children: []string{"element"},
+	},
+	"all": {
+		children: []string{"element"},
+	},
+	"choice": {
+		children: []string{"element"},
+	},
+	"attribute": {
+		attrs: []string{"name", "type", "use"},
+	},
+	"simpleContent": {
+		children: []string{"extension"},
+	},
+	"extension": {
+		children: []string{"attribute"},
+		attrs:    []string{"base"},
+	},
+	"simpleType": {
+		children: []string{"restriction"},
+		attrs:    []string{"name"},
+	},
+	"restriction": {
+		children: []string{
+			"enumeration", "pattern", "length", "minLength", "maxLength",
+		},
+		attrs: []string{"base"},
+	},
+	"enumeration": {attrs: []string{"value"}},
+	"pattern":     {attrs: []string{"value"}},
+	"length":      {attrs: []string{"value"}},
+	"minLength":   {attrs: []string{"value"}},
+	"maxLength":   {attrs: []string{"value"}},
+}
+
+// Supported builtin types.
+var xsdBuiltinTypes = map[string]bool{
+	"anyType":            true,
+	"string":             true,
+	"boolean":            true,
+	"integer":            true,
+	"long":               true,
+	"int":                true,
+	"short":              true,
+	"byte":               true,
+	"nonNegativeInteger": true,
+	"positiveInteger":    true,
+	"unsignedLong":       true,
+	"unsignedInt":        true,
+	"unsignedShort":      true,
+	"unsignedByte":       true,
+	"decimal":            true,
+	"float":              true,
+	"double":             true,
+	"date":               true,
+	"dateTime":           true,
+	"time":               true,
+}
+
+// Sizes of bounded integer types.
+var xsdIntBits = map[string]int{
+	"long":          64,
+	"int":           32,
+	"short":         16,
+	"byte":          8,
+	"unsignedLong":  64,
+	"unsignedInt":   32,
+	"unsignedShort": 16,
+	"unsignedByte":  8,
+}
+
+// Parsed instance document node.
+type xsdNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xsdNode
+	text     strings.Builder
+	line     int
+	column   int
+}
+
+func parseXSD(data []byte) (*xsdSchema, error) {
+	prefixes, err := checkXSDConstructs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema xsdSchema
+
+	if err := xml.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+
+	schema.prefixes = prefixes
+
+	if len(schema.Elements) == 0 {
+		return nil, errors.New("xsd schema has no global elements")
+	}
+
+	schema.elementMap = map[string]*xsdElement{}
+	for n := range schema.Elements {
+		schema.elementMap[schema.Elements[n].Name] = &schema.Elements[n]
+	}
+
+	schema.complexTypeMap = map[string]*xsdComplexType{}
+	for n := range schema.ComplexTypes {
+		schema.complexTypeMap[schema.ComplexTypes[n].Name] = &schema.ComplexTypes[n]
+	}
+
+	schema.simpleTypeMap = map[string]*xsdSimpleType{}
+	for n := range schema.SimpleTypes {
+		schema.simpleTypeMap[schema.SimpleTypes[n].Name] = &schema.SimpleTypes[n]
+	}
+
+	if err := schema.resolveNames(); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// Check that schema contains only supported elements and attributes,
+// and collect namespace prefixes declared on its root.
+func checkXSDConstructs(data []byte) (map[string]string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	prefixes := map[string]string{}
+
+	var stack []string
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+
+			if len(stack) == 0 {
+				if t.Name.Space != xsdNamespace || name != "schema" {
+					return nil, fmt.Errorf("unexpected root element <%s>", name)
+				}
+			} else {
+				if t.Name.Space != xsdNamespace {
+					return nil, xsdUnsupported(
+						"element <%s> from namespace %q", name, t.Name.Space)
+				}
+
+				if name == "annotation" {
+					if err := decoder.Skip(); err != nil {
+						return nil, err
+					}
+					continue
+				}
+
+				if !xsdContains(xsdConstructs[stack[len(stack)-1]].children, name) {
+					return nil, xsdUnsupported(
+						"element <%s> in <%s>", name, stack[len(stack)-1])
+				}
+			}
+
+			for _, attr := range t.Attr {
+				if err := checkXSDAttr(name, attr, len(stack) == 0, prefixes); err != nil {
+					return nil, err
+				}
+			}
+
+			stack = append(stack, name)
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return prefixes, nil
+}
+
+func checkXSDAttr(
+	element string, attr xml.Attr, isRoot bool, prefixes map[string]string,
+) error {
+	switch {
+	case attr.Name.Space == "xmlns" ||
+		(attr.Name.Space == "" && attr.Name.Local == "xmlns"):
+		if !isRoot {
+			return xsdUnsupported(
+				"namespace declaration in <%s>, only <schema> may declare namespaces",
+				element)
+		}
+		if attr.Name.Space == "xmlns" {
+			prefixes[attr.Name.Local] = attr.Value
+		} else {
+			prefixes[""] = attr.Value
+		}
+		return nil
+
+	case attr.Name.Space != "":
+		// attributes from other namespaces don't affect validation
+		return nil
+
+	case attr.Name.Local == "id":
+		return nil
+
+	case !xsdContains(xsdConstructs[element].attrs, attr.Name.Local):
+		return xsdUnsupported("attribute %q of <%s>", attr.Name.Local, element)
+	}
+
+	switch attr.Name.Local {
+	case "elementFormDefault":
+		if attr.Value != "qualified" && attr.Value != "unqualified" {
+			return fmt.Errorf("invalid elementFormDefault %q", attr.Value)
+		}
+
+	case "attributeFormDefault":
+		if attr.Value != "unqualified" {
+			return xsdUnsupported("attributeFormDefault %q", attr.Value)
+		}
+
+	case "use":
+		if attr.Value != "required" && attr.Value != "optional" {
+			return xsdUnsupported("attribute use %q", attr.Value)
+		}
+	}
+
+	return nil
+}
+
+// Resolve qualified names of referenced types and elements, and
+// assign namespaces to element declarations.
+func (s *xsdSchema) resolveNames() error {
+	for n := range s.Elements {
+		if err := s.resolveElementNames(&s.Elements[n], true); err != nil {
+			return err
+		}
+	}
+
+	for n := range s.ComplexTypes {
+		if err := s.resolveComplexNames(&s.ComplexTypes[n]); err != nil {
+			return err
+		}
+	}
+
+	for n := range s.SimpleTypes {
+		if err := s.resolveSimpleNames(&s.SimpleTypes[n]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *xsdSchema) resolveElementNames(decl *xsdElement, global bool) error {
+	if decl.Ref != "" {
+		namespace, local, err := s.resolveQName(decl.Ref)
+		if err != nil {
+			return err
+		}
+		if namespace != s.TargetNamespace {
+			return xsdUnsupported("element ref %q from other schema", decl.Ref)
+		}
+		if s.elementMap[local] == nil {
+			return fmt.Errorf("undefined element %q", decl.Ref)
+		}
+		decl.Ref = local
+		return nil
+	}
+
+	if global || s.ElementFormDefault == "qualified" {
+		decl.namespace = s.TargetNamespace
+	}
+
+	if decl.Type != "" {
+		typeName, err := s.resolveType(decl.Type, false)
+		if err != nil {
+			return err
+		}
+		decl.Type = typeName
+	}
+
+	if decl.ComplexType != nil {
+		if err := s.resolveComplexNames(decl.ComplexType); err != nil {
+			return err
+		}
+	}
+
+	if decl.SimpleType != nil {
+		if err := s.resolveSimpleNames(decl.SimpleType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *xsdSchema) resolveComplexNames(ct *xsdComplexType) error {
+	for _, group := range []*xsdGroup{ct.Sequence, ct.All, ct.Choice} {
+		if group == nil {
+			continue
+		}
+		for n := range group.Elements {
+			if err := s.resolveElementNames(&group.Elements[n], false); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.resolveAttributeNames(ct.Attributes); err != nil {
+		return err
+	}
+
+	if ct.SimpleContent != nil && ct.SimpleContent.Extension != nil {
+		ext := ct.SimpleContent.Extension
+
+		typeName, err := s.resolveType(ext.Base, true)
+		if err != nil {
+			return err
+		}
+		ext.Base = typeName
+
+		if err := s.resolveAttributeNames(ext.Attributes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *xsdSchema) resolveAttributeNames(attrs []xsdAttribute) error {
+	for n := range attrs {
+		if attrs[n].Type == "" {
+			continue
+		}
+		typeName, err := s.resolveType(attrs[n].Type, true)
+		if err != nil {
+			return err
+		}
+		attrs[n].Type = typeName
+	}
+
+	return nil
+}
+
+func (s *xsdSchema) resolveSimpleNames(st *xsdSimpleType) error {
+	if st.Restriction == nil || st.Restriction.Base == "" {
+		return nil
+	}
+
+	typeName, err := s.resolveType(st.Restriction.Base, true)
+	if err != nil {
+		return err
+	}
+	st.Restriction.Base = typeName
+
+	return nil
+}
+
+// Resolve type reference into either "xs:<name>" for builtin type,
+// or "<name>" for type defined in schema.
+func (s *xsdSchema) resolveType(qname string, simple bool) (string, error) {
+	namespace, local, err := s.resolveQName(qname)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case namespace == xsdNamespace:
+		if !xsdBuiltinTypes[local] || (simple && local == "anyType") {
+			return "", xsdUnsupported("builtin type %q", qname)
+		}
+		return "xs:" + local, nil
+
+	case namespace == s.TargetNamespace:
+		if s.simpleTypeMap[local] != nil {
+			return local, nil
+		}
+		if !simple && s.complexTypeMap[local] != nil {
+			return local, nil
+		}
+		return "", fmt.Errorf("undefined type %q", qname)
+
+	default:
+		return "", xsdUnsupported("type %q from other schema", qname)
+	}
+}
+
+func (s *xsdSchema) resolveQName(qname string) (namespace string, local string, err error) {
+	prefix := ""
+	local = qname
+
+	if idx := strings.IndexByte(qname, ':'); idx >= 0 {
+		prefix, local = qname[:idx], qname[idx+1:]
+	}
+
+	namespace, ok := s.prefixes[prefix]
+	if !ok && prefix != "" {
+		return "", "", fmt.Errorf("undeclared namespace prefix in %q", qname)
+	}
+
+	return namespace, local, nil
+}
+
+func (s *xsdSchema) validate(content []byte) []error {
+	root, err := parseXSDNodes(content)
+	if err != nil {
+		return []error{err}
+	}
+
+	decl := s.elementMap[root.name.Local]
+	if decl == nil || !decl.matches(root) {
+		return []error{
+			xsdError(root, "unexpected root element <%s>", root.name.Local),
+		}
+	}
+
+	var errs []error
+	s.validateElement(root, decl, &errs)
+
+	return errs
+}
+
+func (s *xsdSchema) validateElement(node *xsdNode, decl *xsdElement, errs *[]error) {
+	switch {
+	case decl.ComplexType != nil:
+		s.validateComplex(node, decl.ComplexType, errs)
+
+	case decl.SimpleType != nil:
+		s.validateNoChildren(node, errs)
+		s.validateSimpleValue(node, decl.SimpleType, node.text.String(), errs)
+
+	case decl.Type == "xs:anyType":
+		// anything is allowed
+
+	case decl.Type != "":
+		if ct := s.complexTypeMap[decl.Type]; ct != nil {
+			s.validateComplex(node, ct, errs)
+		} else {
+			s.validateNoChildren(node, errs)
+			s.validateTypeValue(node, decl.Type, node.text.String(), errs)
+		}
+	}
+}
+
+func (s *xsdSchema) validateComplex(
+	node *xsdNode, ct *xsdComplexType, errs *[]error,
+) {
+	attrDecls := ct.Attributes
+
+	if ct.SimpleContent != nil && ct.SimpleContent.Extension != nil {
+		ext := ct.SimpleContent.Extension
+		attrDecls = append(attrDecls, ext.Attributes...)
+
+		s.validateNoChildren(node, errs)
+		s.validateTypeValue(node, ext.Base, node.text.String(), errs)
+	} else {
+		if !ct.Mixed && strings.TrimSpace(node.text.String()) != "" {
+			*errs = append(*errs,
+				xsdError(node, "element <%s> must not contain text", node.name.Local))
+		}
+
+		switch {
+		case ct.Sequence != nil:
+			s.validateSequence(node, ct.Sequence, errs)
+		case ct.All != nil:
+			s.validateAll(node, ct.All, errs)
+		case ct.Choice != nil:
+			s.validateChoice(node, ct.Choice, errs)
+		default:
+			s.validateNoChildren(node, errs)
+		}
+	}
+
+	s.validateAttributes(node, attrDecls, errs)
+}
+
+func (s *xsdSchema) validateSequence(node *xsdNode, group *xsdGroup, errs *[]error) {
+	pos := 0
+
+	for n := range group.Elements {
+		decl := s.resolveElement(&group.Elements[n])
+		minOccurs, maxOccurs := xsdOccurs(&group.Elements[n])
+
+		count := 0
+		for pos < len(node.children) && decl.matches(node.children[pos]) {
+			if maxOccurs >= 0 && count == maxOccurs {
+				break
+			}
+			s.validateElement(node.children[pos], decl, errs)
+			pos++
+			count++
+		}
+
+		if count < minOccurs {
+			*errs = append(*errs,
+				xsdError(node, "element <%s> must contain at least %d <%s> element(s)",
+					node.name.Local, minOccurs, decl.Name))
+		}
+	}
+
+	for ; pos < len(node.children); pos++ {
+		*errs = append(*errs,
+			xsdError(node.children[pos], "unexpected element <%s> in <%s>",
+				node.children[pos].name.Local, node.name.Local))
+	}
+}
+
+func (s *xsdSchema) validateAll(node *xsdNode, group *xsdGroup, errs *[]error) {
+	counts := make([]int, len(group.Elements))
+
+	for _, child := range node.children {
+		found := false
+
+		for n := range group.Elements {
+			decl := s.resolveElement(&group.Elements[n])
+			if !decl.matches(child) {
+				continue
+			}
+
+			found = true
+			counts[n]++
+
+			if counts[n] > 1 {
+				*errs = append(*errs,
+					xsdError(child, "duplicate element <%s> in <%s>",
+						child.name.Local, node.name.Local))
+			} else {
+				s.validateElement(child, decl, errs)
+			}
+			break
+		}
+
+		if !found {
+			*errs = append(*errs,
+				xsdError(child, "unexpected element <%s> in <%s>",
+					child.name.Local, node.name.Local))
+		}
+	}
+
+	for n := range group.Elements {
+		minOccurs, _ := xsdOccurs(&group.Elements[n])
+		if counts[n] < minOccurs {
+			*errs = append(*errs,
+				xsdError(node, "element <%s> must contain <%s> element",
+					node.name.Local, s.resolveElement(&group.Elements[n]).Name))
+		}
+	}
+}
+
+func (s *xsdSchema) validateChoice(node *xsdNode, group *xsdGroup, errs *[]error) {
+	if len(node.children) == 0 {
+		for n := range group.Elements {
+			if minOccurs, _ := xsdOccurs(&group.Elements[n]); minOccurs == 0 {
+				return
+			}
+		}
+		*errs = append(*errs,
+			xsdError(node, "element <%s> must contain one of choice elements",
+				node.name.Local))
+		return
+	}
+
+	first := node.children[0]
+
+	for n := range group.Elements {
+		decl := s.resolveElement(&group.Elements[n])
+		if !decl.matches(first) {
+			continue
+		}
+
+		s.validateSequence(node, &xsdGroup{
+			Elements: []xsdElement{group.Elements[n]},
+		}, errs)
+		return
+	}
+
+	*errs = append(*errs,
+		xsdError(first, "unexpected element <%s> in <%s>",
+			first.name.Local, node.name.Local))
+}
+
+func (s *xsdSchema) validateAttributes(
+	node *xsdNode, decls []xsdAttribute, errs *[]error,
+) {
+	for _, attr := range node.attrs {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" ||
+			strings.HasSuffix(attr.Name.Space, "XMLSchema-instance") {
+			continue
+		}
+
+		found := false
+		for _, decl := range decls {
+			if attr.Name.Space == "" && decl.Name == attr.Name.Local {
+				found = true
+				if decl.Type != "" {
+					s.validateTypeValue(node, decl.Type, attr.Value, errs)
+				}
+				break
+			}
+		}
+
+		if !found {
+			*errs = append(*errs,
+				xsdError(node, "unexpected attribute %q in <%s>",
+					attr.Name.Local, node.name.Local))
+		}
+	}
+
+	for _, decl := range decls {
+		if decl.Use != "required" {
+			continue
+		}
+
+		found := false
+		for _, attr := range node.attrs {
+			if attr.Name.Space == "" && attr.Name.Local == decl.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			*errs = append(*errs,
+				xsdError(node, "missing required attribute %q in <%s>",
+					decl.Name, node.name.Local))
+		}
+	}
+}
+
+func (s *xsdSchema) validateNoChildren(node *xsdNode, errs *[]error) {
+	if len(node.children) != 0 {
+		*errs = append(*errs,
+			xsdError(node.children[0], "element <%s> must not contain elements",
+				node.name.Local))
+	}
+}
+
+func (s *xsdSchema) validateTypeValue(
+	node *xsdNode, typeName string, value string, errs *[]error,
+) {
+	if strings.HasPrefix(typeName, "xs:") {
+		s.validateBuiltinValue(node, strings.TrimPrefix(typeName, "xs:"), value, errs)
+	} else if st := s.simpleTypeMap[typeName]; st != nil {
+		s.validateSimpleValue(node, st, value, errs)
+	}
+}
+
+func (s *xsdSchema) validateSimpleValue(
+	node *xsdNode, st *xsdSimpleType, value string, errs *[]error,
+) {
+	rs := st.Restriction
+	if rs == nil {
+		return
+	}
+
+	if rs.Base != "" {
+		errCount := len(*errs)
+		s.validateTypeValue(node, rs.Base, value, errs)
+		if len(*errs) != errCount {
+			return
+		}
+	}
+
+	if len(rs.Enumerations) != 0 {
+		found := false
+		for _, enum := range rs.Enumerations {
+			if enum.Value == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*errs = append(*errs,
+				xsdError(node, "value %q is not in enumeration", value))
+		}
+	}
+
+	for _, pattern := range rs.Patterns {
+		re, err := regexp.Compile("^(?:" + pattern.Value + ")$")
+		if err != nil {
+			*errs = append(*errs,
+				xsdError(node, "invalid pattern %q: %s", pattern.Value, err))
+			continue
+		}
+		if !re.MatchString(value) {
+			*errs = append(*errs,
+				xsdError(node, "value %q does not match pattern %q",
+					value, pattern.Value))
+		}
+	}
+
+	length := utf8.RuneCountInString(value)
+
+	checkLength := func(facet *xsdFacet, what string, ok func(n int) bool) {
+		if facet == nil {
+			return
+		}
+		n, err := strconv.Atoi(facet.Value)
+		if err != nil {
+			*errs = append(*errs,
+				xsdError(node, "invalid %s facet %q", what, facet.Value))
+			return
+		}
+		if !ok(n) {
+			*errs = append(*errs,
+				xsdError(node, "value %q violates %s %d", value, what, n))
+		}
+	}
+
+	checkLength(rs.Length, "length", func(n int) bool { return length == n })
+	checkLength(rs.MinLength, "minLength", func(n int) bool { return length >= n })
+	checkLength(rs.MaxLength, "maxLength", func(n int) bool { return length <= n })
+}
+
+func (s *xsdSchema) validateBuiltinValue(
+	node *xsdNode, typeName string, value string, errs *[]error,
+) {
+	value = strings.TrimSpace(value)
+
+	var err error
+
+	switch typeName {
+	case "string":
+		return
+
+	case "boolean":
+		switch value {
+		case "true", "false", "1", "0":
+		default:
+			err = errors.New("invalid boolean")
+		}
+
+	case "integer", "nonNegativeInteger", "positiveInteger":
+		// arbitrary precision
+		n, ok := new(big.Int).SetString(value, 10)
+		switch {
+		case !ok:
+			err = errors.New("invalid integer")
+		case typeName == "nonNegativeInteger" && n.Sign() < 0:
+			err = errors.New("negative")
+		case typeName == "positiveInteger" && n.Sign() <= 0:
+			err = errors.New("not positive")
+		}
+
+	case "long", "int", "short", "byte":
+		_, err = strconv.ParseInt(value, 10, xsdIntBits[typeName])
+
+	case "unsignedLong", "unsignedInt", "unsignedShort", "unsignedByte":
+		_, err = strconv.ParseUint(strings.TrimPrefix(value, "+"), 10,
+			xsdIntBits[typeName])
+
+	case "decimal", "float", "double":
+		_, err = strconv.ParseFloat(value, 64)
+
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+
+	case "dateTime":
+		_, err = time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			_, err = time.Parse("2006-01-02T15:04:05", value)
+		}
+
+	case "time":
+		_, err = time.Parse("15:04:05", value)
+
+	default:
+		err = errors.New("unsupported type")
+	}
+
+	if err != nil {
+		*errs = append(*errs,
+			xsdError(node, "value %q of <%s> is not a valid %s",
+				value, node.name.Local, typeName))
+	}
+}
+
+func (s *xsdSchema) resolveElement(decl *xsdElement) *xsdElement {
+	if decl.Ref != "" {
+		if ref := s.elementMap[decl.Ref]; ref != nil {
+			return ref
+		}
+	}
+	return decl
+}
+
+func (decl *xsdElement) matches(node *xsdNode) bool {
+	return node.name.Local == decl.Name && node.name.Space == decl.namespace
+}
+
+func xsdOccurs(decl *xsdElement) (minOccurs int, maxOccurs int) {
+	minOccurs, maxOccurs = 1, 1
+
+	if decl.MinOccurs != "" {
+		if n, err := strconv.Atoi(decl.MinOccurs); err == nil {
+			minOccurs = n
+		}
+	}
+
+	if decl.MaxOccurs == "unbounded" {
+		maxOccurs = -1
+	} else if decl.MaxOccurs != "" {
+		if n, err := strconv.Atoi(decl.MaxOccurs); err == nil {
+			maxOccurs = n
+		}
+	}
+
+	return minOccurs, maxOccurs
+}
+
+func xsdContains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func xsdError(node *xsdNode, format string, args ...interface{}) error {
+	return fmt.Errorf("line %d, column %d: %s",
+		node.line, node.column, fmt.Sprintf(format, args...))
+}
+
+func parseXSDNodes(content []byte) (*xsdNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	var (
+		root  *xsdNode
+		stack []*xsdNode
+	)
+
+	offset := int64(0)
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			line, column := xsdPosition(content, offset)
+
+			node := &xsdNode{
+				name:   t.Name,
+				attrs:  t.Attr,
+				line:   line,
+				column: column,
+			}
+
+			if len(stack) == 0 {
+				root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			}
+
+			stack = append(stack, node)
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) != 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+
+		offset = decoder.InputOffset()
+	}
+
+	if root == nil {
+		return nil, errors.New("xml document has no root element")
+	}
+
+	return root, nil
+}
+
+func xsdPosition(content []byte, offset int64) (line int, column int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+
+	// skip whitespace preceding the token
+	for offset < int64(len(content)) && content[offset] != '<' {
+		offset++
+	}
+
+	prefix := content[:offset]
+
+	line = bytes.Count(prefix, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(prefix, '\n')
+
+	return line, column
+}